@@ -10,21 +10,24 @@ gen() {
 
 go install -mod=readonly github.com/golang/protobuf/protoc-gen-go
 
-rm -rf controller/gen/common controller/gen/config controller/gen/controller controller/gen/public
+rm -rf controller/gen/common controller/gen/config controller/gen/controller controller/gen/public controller/gen/spire
 
 gen proto/common/healthcheck.proto \
     proto/controller/tap.proto \
     proto/public.proto \
-    proto/config/config.proto
+    proto/config/config.proto \
+    proto/spire/svid.proto
 
 # TODO: Re-organize the top-level /proto directory to mirror output packages.
 # As a work-around, manually move files after generation.
 mkdir -p controller/gen/common/healthcheck
 mkdir -p controller/gen/controller/tap
 mkdir -p controller/gen/public
+mkdir -p controller/gen/spire/svid
 
 mv controller/gen/common/healthcheck.pb.go   controller/gen/common/healthcheck/
 mv controller/gen/controller/tap.pb.go       controller/gen/controller/tap/
 mv controller/gen/public.pb.go               controller/gen/public/
+mv controller/gen/spire/svid.pb.go           controller/gen/spire/svid/
 
 git add controller/gen
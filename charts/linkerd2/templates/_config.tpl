@@ -8,7 +8,16 @@
     "trustAnchorsPem": "{{required "Please provide the identity trust anchors" .Values.global.identityTrustAnchorsPEM | trim | replace "\n" "\\n"}}",
     "issuanceLifetime": "{{.Values.identity.issuer.issuanceLifetime}}",
     "clockSkewAllowance": "{{.Values.identity.issuer.clockSkewAllowance}}",
-    "scheme": "{{.Values.identity.issuer.scheme}}"
+    "renewalJitter": "{{.Values.identity.issuer.renewalJitter}}",
+    "scheme": "{{.Values.identity.issuer.scheme}}",
+    "issuerKeyAlgorithm": "{{.Values.identity.issuer.keyAlgorithm}}"
+    {{- with .Values.identity.issuer.certManager }}{{ if .enabled }},
+    "certManager": {
+      "trustAnchorSecret": "{{.trustAnchorSecret}}",
+      "duration": "{{.duration}}",
+      "renewBefore": "{{.renewBefore}}"
+    }
+    {{- end }}{{ end }}
   },
   "autoInjectContext": null,
   "omitWebhookSideEffects": {{.Values.omitWebhookSideEffects}},
@@ -19,11 +28,11 @@
 {{- define "linkerd.configs.proxy" -}}
 {
   "proxyImage":{
-    "imageName":"{{.Values.global.proxy.image.name}}",
+    "imageName":"{{ include "partials.image" (dict "image" .Values.global.proxy.image.name "default" "ghcr.io/linkerd/proxy" "cryptoPolicy" .Values.global.cryptoPolicy "registry" .Values.global.registry) }}",
     "pullPolicy":"{{.Values.global.proxy.image.pullPolicy}}"
   },
   "proxyInitImage":{
-    "imageName":"{{.Values.global.proxyInit.image.name}}",
+    "imageName":"{{ include "partials.image" (dict "image" .Values.global.proxyInit.image.name "default" "ghcr.io/linkerd/proxy-init" "registry" .Values.global.registry) }}",
     "pullPolicy":"{{.Values.global.proxyInit.image.pullPolicy}}"
   },
   "controlPort":{
@@ -59,13 +68,17 @@
   "proxyVersion": "{{.Values.global.proxy.image.version}}",
   "proxyInitImageVersion": "{{.Values.global.proxyInit.image.version}}",
   "debugImage":{
-    "imageName":"{{.Values.debugContainer.image.name}}",
+    "imageName":"{{ include "partials.image" (dict "image" .Values.debugContainer.image.name "default" "ghcr.io/linkerd/debug" "registry" .Values.global.registry) }}",
     "pullPolicy":"{{.Values.debugContainer.image.pullPolicy}}"
   },
   "debugImageVersion": "{{.Values.debugContainer.image.version}}",
   "destinationGetNetworks": "{{.Values.global.proxy.destinationGetNetworks}}",
   "outboundConnectTimeout": "{{.Values.global.proxy.outboundConnectTimeout}}",
-  "inboundConnectTimeout": "{{.Values.global.proxy.inboundConnectTimeout}}"
+  "inboundConnectTimeout": "{{.Values.global.proxy.inboundConnectTimeout}}",
+  "outboundConnectBackoffMin": "{{.Values.global.proxy.outboundConnectBackoffMin}}",
+  "outboundConnectBackoffMax": "{{.Values.global.proxy.outboundConnectBackoffMax}}",
+  "outboundConnectBackoffJitter": "{{.Values.global.proxy.outboundConnectBackoffJitter}}",
+  "excludedNodeSelector": "{{.Values.global.proxy.excludedNodeSelector}}"
 }
 {{- end -}}
 
@@ -20,3 +20,32 @@ affinity:
           - {{ .component }}
       topologyKey: kubernetes.io/hostname
 {{- end }}
+
+{{ define "linkerd.topology-spread-constraints" -}}
+topologySpreadConstraints:
+- maxSkew: 1
+  topologyKey: topology.kubernetes.io/zone
+  whenUnsatisfiable: ScheduleAnyway
+  labelSelector:
+    matchLabels:
+      {{ .label }}: {{ .component }}
+{{- end }}
+
+{{ define "linkerd.pod-disruption-budget" -}}
+kind: PodDisruptionBudget
+apiVersion: policy/v1beta1
+metadata:
+  name: {{ .name }}
+  namespace: {{ .Values.global.namespace }}
+  labels:
+    {{ .Values.global.controllerComponentLabel }}: {{ .component }}
+    {{ .Values.global.controllerNamespaceLabel }}: {{ .Values.global.namespace }}
+  annotations:
+    {{ .Values.global.createdByAnnotation }}: {{ default (printf "linkerd/helm %s" .Values.global.linkerdVersion) .Values.global.cliVersion }}
+spec:
+  maxUnavailable: 1
+  selector:
+    matchLabels:
+      {{ .Values.global.controllerComponentLabel }}: {{ .component }}
+      {{ .Values.global.controllerNamespaceLabel }}: {{ .Values.global.namespace }}
+{{- end }}
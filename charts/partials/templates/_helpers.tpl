@@ -59,3 +59,35 @@ For example "11,22,55" will become{"portRange":11},{"portRange":22},
 {{- end -}}
 {{- end -}}
 {{- end -}}
+
+{{/*
+Rewrites an image reference to be pulled from the given registry, if any,
+keeping only the last element of its path. For example, with the registry
+"registry.example.com/mirror", "prom/prometheus:v2.19.3" will become
+"registry.example.com/mirror/prometheus:v2.19.3"
+
+Only the images the charts ship with are rewritten: the image is compared,
+without its tag, against its default name in the chart, given by "default",
+and the ones overridden with another name are pulled as they are. When
+"cryptoPolicy" is "fips", the default is the FIPS variant of the image, with
+the "-fips" suffix.
+*/}}
+{{- define "partials.image" -}}
+{{- $default := .default -}}
+{{- if eq (.cryptoPolicy | default "default") "fips" -}}
+{{- $default = printf "%s-fips" .default -}}
+{{- end -}}
+{{- if and .registry (eq (regexReplaceAll ":[^/]*$" .image "") $default) -}}
+{{ .registry }}/{{ base .image }}
+{{- else -}}
+{{ .image }}
+{{- end -}}
+{{- end -}}
+
+{{/*
+Returns the log level of a control plane component: its entry in
+componentLogLevels if any, else global.controllerLogLevel
+*/}}
+{{- define "linkerd.log-level" -}}
+{{- index (.Values.componentLogLevels | default dict) .component | default .Values.global.controllerLogLevel -}}
+{{- end -}}
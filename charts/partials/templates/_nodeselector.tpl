@@ -1,4 +1,8 @@
 {{- define "linkerd.node-selector" -}}
+{{- $nodeSelector := .Values.nodeSelector -}}
+{{- if and .component .Values.componentNodeSelectors -}}
+{{- $nodeSelector = merge (dict) (index .Values.componentNodeSelectors .component | default dict) .Values.nodeSelector -}}
+{{- end -}}
 nodeSelector:
-{{- toYaml .Values.nodeSelector | trim | nindent 2 }}
+{{- toYaml $nodeSelector | trim | nindent 2 }}
 {{- end -}}
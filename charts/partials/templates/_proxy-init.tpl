@@ -19,7 +19,7 @@ args:
 - --timeout-close-wait-secs
 - {{ .Values.global.proxyInit.closeWaitTimeoutSecs | quote}}
 {{- end }}
-image: {{.Values.global.proxyInit.image.name}}:{{.Values.global.proxyInit.image.version}}
+image: {{ include "partials.image" (dict "image" .Values.global.proxyInit.image.name "default" "ghcr.io/linkerd/proxy-init" "registry" .Values.global.registry) }}:{{.Values.global.proxyInit.image.version}}
 imagePullPolicy: {{.Values.global.proxyInit.image.pullPolicy}}
 name: linkerd-init
 {{ include "partials.resources" .Values.global.proxyInit.resources }}
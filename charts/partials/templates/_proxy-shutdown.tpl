@@ -0,0 +1,38 @@
+{{- define "partials.proxy-shutdown" -}}
+args:
+- -c
+- |
+  # Waits for the processes of the other containers to exit, shared through
+  # shareProcessNamespace, then shuts down the proxy so that the pod completes.
+  # This container's own processes are recognized by LINKERD_PROXY_SHUTDOWN.
+  # The first seconds leave time for the other containers to start.
+  export LINKERD_PROXY_SHUTDOWN=1
+  running() {
+    for p in /proc/[0-9]*; do
+      [ "${p#/proc/}" = 1 ] && continue
+      [ "$(cat $p/comm 2>/dev/null)" = linkerd2-proxy ] && continue
+      grep -qz LINKERD_PROXY_SHUTDOWN=1 $p/environ 2>/dev/null && continue
+      [ -e $p ] && return 0
+    done
+    return 1
+  }
+  waited=0
+  while running || [ $waited -lt 5 ]; do
+    sleep 1
+    waited=$((waited+1))
+  done
+  for p in /proc/[0-9]*; do
+    if [ "$(cat $p/comm 2>/dev/null)" = linkerd2-proxy ]; then
+      echo "shutting down the proxy"
+      kill -TERM ${p#/proc/}
+    fi
+  done
+command:
+- /bin/sh
+image: {{ include "partials.image" (dict "image" .Values.global.proxy.image.name "default" "ghcr.io/linkerd/proxy" "cryptoPolicy" .Values.global.cryptoPolicy "registry" .Values.global.registry) }}:{{.Values.global.proxy.image.version}}
+imagePullPolicy: {{.Values.global.proxy.image.pullPolicy}}
+name: linkerd-proxy-shutdown
+securityContext:
+  runAsUser: {{.Values.global.proxy.uid}}
+terminationMessagePolicy: FallbackToLogsOnError
+{{- end -}}
@@ -4,6 +4,14 @@ env:
 - name: LINKERD2_PROXY_INBOUND_PORTS_REQUIRE_IDENTITY
   value: "{{.Values.global.proxy.requireIdentityOnInboundPorts}}"
 {{ end -}}
+{{ if .Values.global.proxy.opaquePorts -}}
+{{- /* these variables replace the proxy's defaults, which are kept */ -}}
+{{- $opaquePorts := printf "25,443,587,3306,5432,11211,%s" .Values.global.proxy.opaquePorts | splitList "," | uniq | join "," -}}
+- name: LINKERD2_PROXY_INBOUND_PORTS_DISABLE_PROTOCOL_DETECTION
+  value: "{{$opaquePorts}}"
+- name: LINKERD2_PROXY_OUTBOUND_PORTS_DISABLE_PROTOCOL_DETECTION
+  value: "{{$opaquePorts}}"
+{{ end -}}
 - name: LINKERD2_PROXY_LOG
   value: {{.Values.global.proxy.logLevel}}
 - name: LINKERD2_PROXY_LOG_FORMAT
@@ -24,6 +32,18 @@ env:
 - name: LINKERD2_PROXY_OUTBOUND_CONNECT_TIMEOUT
   value: "{{.Values.global.proxy.outboundConnectTimeout }}"
 {{ end -}}
+{{ if .Values.global.proxy.outboundConnectBackoffMin -}}
+- name: LINKERD2_PROXY_OUTBOUND_CONNECT_BACKOFF_MIN
+  value: "{{.Values.global.proxy.outboundConnectBackoffMin }}"
+{{ end -}}
+{{ if .Values.global.proxy.outboundConnectBackoffMax -}}
+- name: LINKERD2_PROXY_OUTBOUND_CONNECT_BACKOFF_MAX
+  value: "{{.Values.global.proxy.outboundConnectBackoffMax }}"
+{{ end -}}
+{{ if .Values.global.proxy.outboundConnectBackoffJitter -}}
+- name: LINKERD2_PROXY_OUTBOUND_CONNECT_BACKOFF_JITTER
+  value: "{{.Values.global.proxy.outboundConnectBackoffJitter }}"
+{{ end -}}
 - name: LINKERD2_PROXY_CONTROL_LISTEN_ADDR
   value: 0.0.0.0:{{.Values.global.proxy.ports.control}}
 - name: LINKERD2_PROXY_ADMIN_LISTEN_ADDR
@@ -111,7 +131,11 @@ env:
 - name: LINKERD2_PROXY_TRACE_COLLECTOR_SVC_NAME
   value: {{ .Values.global.proxy.trace.collectorSvcAccount }}.serviceaccount.identity.$(_l5d_ns).$(_l5d_trustdomain)
 {{ end -}}
-image: {{.Values.global.proxy.image.name}}:{{.Values.global.proxy.image.version}}
+{{ range .Values.global.proxy.additionalEnv -}}
+- name: {{ .name }}
+  value: {{ .value | quote }}
+{{ end -}}
+image: {{ include "partials.image" (dict "image" .Values.global.proxy.image.name "default" "ghcr.io/linkerd/proxy" "cryptoPolicy" .Values.global.cryptoPolicy "registry" .Values.global.registry) }}:{{.Values.global.proxy.image.version}}
 imagePullPolicy: {{.Values.global.proxy.image.pullPolicy}}
 livenessProbe:
   httpGet:
@@ -1,4 +1,10 @@
 {{- define "linkerd.tolerations" -}}
+{{- $tolerations := .Values.tolerations -}}
+{{- if and .component .Values.componentTolerations -}}
+{{- $tolerations = concat (.Values.tolerations | default list) (index .Values.componentTolerations .component | default list) -}}
+{{- end -}}
+{{- if $tolerations -}}
 tolerations:
-{{ toYaml .Values.tolerations | trim | indent 2 }}
+{{ toYaml $tolerations | trim | indent 2 }}
+{{- end -}}
 {{- end -}}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -24,8 +25,25 @@ type checkOptions struct {
 	multicluster       bool
 	dataPlaneOnly      bool
 	wait               time.Duration
+	retryAll           bool
+	retryInterval      time.Duration
+	maxRetryInterval   time.Duration
+	retryBackoff       float64
+	checkTimeout       time.Duration
+	categoryTimeouts   []string
+	certExpiryWarning  string
+	certExpiryError    string
+	proxyCertExpiry    string
 	namespace          string
+	selector           string
 	cniEnabled         bool
+	openshift          bool
+	extensions         bool
+	network            bool
+	networkProbeImage  string
+	sourceContext      string
+	gatewayName        string
+	gatewayNamespace   string
 	output             string
 	cliVersionOverride string
 }
@@ -37,8 +55,25 @@ func newCheckOptions() *checkOptions {
 		preInstallOnly:     false,
 		dataPlaneOnly:      false,
 		wait:               300 * time.Second,
+		retryAll:           false,
+		retryInterval:      5 * time.Second,
+		maxRetryInterval:   time.Minute,
+		retryBackoff:       1,
+		checkTimeout:       30 * time.Second,
+		categoryTimeouts:   []string{},
+		certExpiryWarning:  "60d",
+		certExpiryError:    "0s",
+		proxyCertExpiry:    "1h",
 		namespace:          "",
+		selector:           "",
 		cniEnabled:         false,
+		openshift:          false,
+		extensions:         false,
+		network:            false,
+		networkProbeImage:  healthcheck.DefaultNetworkProbeImage,
+		sourceContext:      "",
+		gatewayName:        healthcheck.DefaultGatewayName,
+		gatewayNamespace:   healthcheck.DefaultGatewayNamespace,
 		output:             tableOutput,
 		cliVersionOverride: "",
 	}
@@ -49,10 +84,21 @@ func (options *checkOptions) nonConfigFlagSet() *pflag.FlagSet {
 	flags := pflag.NewFlagSet("non-config-check", pflag.ExitOnError)
 
 	flags.BoolVar(&options.cniEnabled, "linkerd-cni-enabled", options.cniEnabled, "When running pre-installation checks (--pre), assume the linkerd-cni plugin is already installed, and a NET_ADMIN check is not needed")
-	flags.StringVarP(&options.namespace, "namespace", "n", options.namespace, "Namespace to use for --proxy checks (default: all namespaces)")
+	flags.StringVarP(&options.namespace, "namespace", "n", options.namespace, "Namespace to use for --proxy checks (default: all namespaces), and to run the --network probe pod in (default: \"default\")")
+	flags.StringVar(&options.certExpiryWarning, "cert-expiry-warning", options.certExpiryWarning, "Warn about the trust anchors, issuer and webhook certificates expiring within this time, e.g. 30d or 720h; must be greater than 0")
+	flags.StringVar(&options.certExpiryError, "cert-expiry-error", options.certExpiryError, "Fail the checks if the trust anchors or issuer certificate expire within this time, e.g. 7d (default: once expired)")
+	flags.StringVar(&options.proxyCertExpiry, "proxy-cert-expiry-warning", options.proxyCertExpiry, "With --proxy, warn about the data plane proxies whose certificate expires within this time, e.g. 30m; must be greater than 0")
+	flags.StringVarP(&options.selector, "selector", "l", options.selector, "Selector (label query) of the pods to use for --proxy checks, supports '=', '==', and '!='")
 	flags.BoolVar(&options.preInstallOnly, "pre", options.preInstallOnly, "Only run pre-installation checks, to determine if the control plane can be installed")
 	flags.BoolVar(&options.dataPlaneOnly, "proxy", options.dataPlaneOnly, "Only run data-plane checks, to determine if the data plane is healthy")
-	flags.BoolVar(&options.multicluster, "multicluster", options.multicluster, "Run multicluster checks")
+	flags.BoolVar(&options.multicluster, "multicluster", options.multicluster, "Run multicluster checks; with --pre, check that this cluster's gateway can be linked from the --source-context cluster instead")
+	flags.StringVar(&options.sourceContext, "source-context", options.sourceContext, "With --pre --multicluster, kubeconfig context of the cluster that will link to this one, to check that it shares the trust anchors and can reach the gateway")
+	flags.StringVar(&options.gatewayName, "gateway-name", options.gatewayName, "With --pre --multicluster, name of the gateway service")
+	flags.StringVar(&options.gatewayNamespace, "gateway-namespace", options.gatewayNamespace, "With --pre --multicluster, namespace of the gateway service")
+	flags.BoolVar(&options.openshift, "openshift", options.openshift, "Run the OpenShift checks, to validate the SecurityContextConstraints used by the control plane")
+	flags.BoolVar(&options.network, "network", options.network, "Also run the network checks, which probe the control plane ports from a pod run in the --namespace namespace (default \"default\"), and diagnose the NetworkPolicies and webhook timeouts blocking them")
+	flags.StringVar(&options.networkProbeImage, "network-probe-image", options.networkProbeImage, "Image of the pod run by --network, which needs a shell and nc")
+	flags.BoolVar(&options.extensions, "extensions", options.extensions, "Also run the checks of the extensions, i.e. the linkerd-<name> executables in the PATH, where name is made of lowercase letters, digits and dashes, which must support \"check -o json\"; the linkerd-stable-* and linkerd-edge-* CLIs are skipped")
 
 	return flags
 }
@@ -63,8 +109,14 @@ func (options *checkOptions) checkFlagSet() *pflag.FlagSet {
 
 	flags.StringVar(&options.versionOverride, "expected-version", options.versionOverride, "Overrides the version used when checking if Linkerd is running the latest version (mostly for testing)")
 	flags.StringVar(&options.cliVersionOverride, "cli-version-override", "", "Used to override the version of the cli (mostly for testing)")
-	flags.StringVarP(&options.output, "output", "o", options.output, "Output format. One of: basic, json")
+	flags.StringVarP(&options.output, "output", "o", options.output, fmt.Sprintf("Output format. One of: %s, %s, %s (for CI systems supporting Static Analysis Results Interchange Format reports)", tableOutput, jsonOutput, sarifOutput))
 	flags.DurationVar(&options.wait, "wait", options.wait, "Maximum allowed time for all tests to pass")
+	flags.BoolVar(&options.retryAll, "retry-all", options.retryAll, "Retry every failing check, and not only the ones waiting for resources to become ready, until it passes or --wait expires; warnings aren't retried")
+	flags.DurationVar(&options.retryInterval, "retry-interval", options.retryInterval, "Time to wait before retrying a failing check")
+	flags.Float64Var(&options.retryBackoff, "retry-backoff", options.retryBackoff, "Factor the time to wait is multiplied by after each retry of a check, for an exponential backoff")
+	flags.DurationVar(&options.maxRetryInterval, "max-retry-interval", options.maxRetryInterval, "Maximum time to wait before retrying a failing check, when using --retry-backoff")
+	flags.DurationVar(&options.checkTimeout, "check-timeout", options.checkTimeout, "Timeout of each run of a check")
+	flags.StringArrayVar(&options.categoryTimeouts, "category-timeout", options.categoryTimeouts, "Maximum time the checks of a category are retried for, as <category>=<duration>, e.g. linkerd-identity=1m (can be repeated)")
 
 	return flags
 }
@@ -76,12 +128,95 @@ func (options *checkOptions) validate() error {
 	if !options.preInstallOnly && options.cniEnabled {
 		return errors.New("--linkerd-cni-enabled can only be used with --pre")
 	}
-	if options.output != tableOutput && options.output != jsonOutput {
-		return fmt.Errorf("Invalid output type '%s'. Supported output types are: %s, %s", options.output, jsonOutput, tableOutput)
+	if !options.dataPlaneOnly && options.selector != "" {
+		return errors.New("--selector can only be used with --proxy")
+	}
+	if options.retryInterval <= 0 || options.checkTimeout <= 0 {
+		return errors.New("--retry-interval and --check-timeout must be positive")
+	}
+	if options.retryBackoff < 1 {
+		return errors.New("--retry-backoff must be greater than or equal to 1")
+	}
+	if _, err := options.parseCategoryTimeouts(); err != nil {
+		return err
+	}
+	if _, _, _, err := options.parseCertExpiryWindows(); err != nil {
+		return err
+	}
+	if options.preInstallOnly && options.network {
+		return errors.New("--pre and --network flags are mutually exclusive")
+	}
+	if options.sourceContext != "" && !(options.preInstallOnly && options.multicluster) {
+		return errors.New("--source-context can only be used with --pre --multicluster")
+	}
+	if options.preInstallOnly && options.extensions {
+		return errors.New("--pre and --extensions flags are mutually exclusive")
+	}
+	if options.output != tableOutput && options.output != jsonOutput && options.output != sarifOutput {
+		return fmt.Errorf("Invalid output type '%s'. Supported output types are: %s, %s, %s", options.output, jsonOutput, sarifOutput, tableOutput)
 	}
 	return nil
 }
 
+// parseCategoryTimeouts parses the --category-timeout flags
+func (options *checkOptions) parseCategoryTimeouts() (map[healthcheck.CategoryID]time.Duration, error) {
+	timeouts := map[healthcheck.CategoryID]time.Duration{}
+	for _, t := range options.categoryTimeouts {
+		parts := strings.SplitN(t, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("invalid --category-timeout %q, expected <category>=<duration>", t)
+		}
+		timeout, err := time.ParseDuration(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid --category-timeout %q: %s", t, err)
+		}
+		timeouts[healthcheck.CategoryID(parts[0])] = timeout
+	}
+	return timeouts, nil
+}
+
+// parseCertExpiryWindows parses the --cert-expiry-warning,
+// --cert-expiry-error and --proxy-cert-expiry-warning flags. The warning
+// windows can't be 0, which the health checks would replace with their
+// defaults.
+func (options *checkOptions) parseCertExpiryWindows() (warning, failure, proxyWarning time.Duration, err error) {
+	if warning, err = parseExpiryWindow("--cert-expiry-warning", options.certExpiryWarning); err != nil {
+		return
+	}
+	if failure, err = parseExpiryWindow("--cert-expiry-error", options.certExpiryError); err != nil {
+		return
+	}
+	if proxyWarning, err = parseExpiryWindow("--proxy-cert-expiry-warning", options.proxyCertExpiry); err != nil {
+		return
+	}
+	if warning == 0 {
+		err = errors.New("--cert-expiry-warning must be greater than 0")
+	} else if proxyWarning == 0 {
+		err = errors.New("--proxy-cert-expiry-warning must be greater than 0")
+	} else if failure > warning {
+		err = errors.New("--cert-expiry-error must be shorter than --cert-expiry-warning")
+	}
+	return
+}
+
+// parseExpiryWindow parses a duration, which may also be a number of days,
+// e.g. 30d
+func parseExpiryWindow(flag, window string) (time.Duration, error) {
+	var d time.Duration
+	var err error
+	if strings.HasSuffix(window, "d") {
+		var days int
+		days, err = strconv.Atoi(strings.TrimSuffix(window, "d"))
+		d = time.Duration(days) * 24 * time.Hour
+	} else {
+		d, err = time.ParseDuration(window)
+	}
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid %s %q, expected a duration such as 30d or 12h", flag, window)
+	}
+	return d, nil
+}
+
 // newCmdCheckConfig is a subcommand for `linkerd check config`
 func newCmdCheckConfig(options *checkOptions) *cobra.Command {
 	cmd := &cobra.Command{
@@ -130,7 +265,34 @@ non-zero exit code.`,
   linkerd check config
 
   # Check that the Linkerd data plane proxies in the "app" namespace are up and running
-  linkerd check --proxy --namespace app`,
+  linkerd check --proxy --namespace app
+
+  # Check the data plane proxies of the "web" workload only, reporting issues by pod
+  linkerd check --proxy --namespace app --selector app=web
+
+  # Check that the "target" cluster can be linked from the "source" cluster,
+  # before running "linkerd multicluster link"
+  linkerd --context target check --pre --multicluster --source-context source
+
+  # Check that the Linkerd control plane can be installed on OpenShift
+  linkerd check --pre --openshift
+
+  # Wait up to 10 minutes for all the checks to pass, retrying them with an
+  # exponential backoff, e.g. in a CI pipeline right after an install
+  linkerd check --wait 10m --retry-all --retry-backoff 2 --max-retry-interval 1m
+
+  # Warn about the certificates expiring within 30 days, and fail if one expires within a week
+  linkerd check --cert-expiry-warning 30d --cert-expiry-error 7d
+
+  # Check that the "app" namespace can reach the control plane, diagnosing the
+  # NetworkPolicies blocking it
+  linkerd check --network --namespace app
+
+  # Also run the checks of the installed extensions, e.g. linkerd-foo
+  linkerd check --extensions
+
+  # Report the results as SARIF, to be uploaded by a CI system
+  linkerd check -o sarif > linkerd-check.sarif`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return configureAndRunChecks(stdout, stderr, "", options)
 		},
@@ -145,9 +307,35 @@ non-zero exit code.`,
 }
 
 func configureAndRunChecks(wout io.Writer, werr io.Writer, stage string, options *checkOptions) error {
+	hc, err := options.newHealthChecker(stage)
+	if err != nil {
+		return err
+	}
+
+	success := runChecks(wout, werr, hc, options.output)
+
+	if !success {
+		os.Exit(1)
+	}
+
+	return nil
+}
+
+// newHealthChecker returns the HealthChecker running the checks selected by
+// the options, for the given stage
+func (options *checkOptions) newHealthChecker(stage string) (*healthcheck.HealthChecker, error) {
 	err := options.validate()
 	if err != nil {
-		return fmt.Errorf("Validation error when executing check command: %v", err)
+		return nil, fmt.Errorf("Validation error when executing check command: %v", err)
+	}
+
+	categoryTimeouts, err := options.parseCategoryTimeouts()
+	if err != nil {
+		return nil, fmt.Errorf("Validation error when executing check command: %v", err)
+	}
+	certExpiryWarning, certExpiryError, proxyCertExpiryWarning, err := options.parseCertExpiryWindows()
+	if err != nil {
+		return nil, fmt.Errorf("Validation error when executing check command: %v", err)
 	}
 
 	if options.cliVersionOverride != "" {
@@ -161,16 +349,23 @@ func configureAndRunChecks(wout io.Writer, werr io.Writer, stage string, options
 	}
 
 	var installManifest string
-	if options.preInstallOnly {
+	if options.preInstallOnly && options.multicluster {
+		// Linkerd is already installed on a cluster being linked
+		checks = append(checks, healthcheck.LinkerdMulticlusterPreLinkChecks)
+	} else if options.preInstallOnly {
 		checks = append(checks, healthcheck.LinkerdPreInstallChecks)
 		if options.cniEnabled {
 			checks = append(checks, healthcheck.LinkerdCNIPluginChecks)
 		} else {
 			checks = append(checks, healthcheck.LinkerdPreInstallCapabilityChecks)
 		}
+		if options.openshift {
+			checks = append(checks, healthcheck.LinkerdPreInstallOpenShiftChecks)
+		}
+		checks = append(checks, healthcheck.LinkerdPreInstallConflictsChecks)
 		installManifest, err = renderInstallManifest()
 		if err != nil {
-			return fmt.Errorf("Error rendering install manifest: %v", err)
+			return nil, fmt.Errorf("Error rendering install manifest: %v", err)
 		}
 	} else {
 		checks = append(checks, healthcheck.LinkerdConfigChecks)
@@ -184,21 +379,47 @@ func configureAndRunChecks(wout io.Writer, werr io.Writer, stage string, options
 			if options.dataPlaneOnly {
 				checks = append(checks, healthcheck.LinkerdDataPlaneChecks)
 				checks = append(checks, healthcheck.LinkerdIdentityDataPlane)
+				checks = append(checks, healthcheck.LinkerdProxyCertificatesChecks)
 			} else {
 				checks = append(checks, healthcheck.LinkerdControlPlaneVersionChecks)
 			}
 			checks = append(checks, healthcheck.LinkerdCNIPluginChecks)
 			checks = append(checks, healthcheck.LinkerdHAChecks)
+			checks = append(checks, healthcheck.LinkerdFIPSChecks)
+			checks = append(checks, healthcheck.LinkerdPodSecurityChecks)
 			checks = append(checks, healthcheck.LinkerdMulticlusterChecks)
+			if options.openshift {
+				checks = append(checks, healthcheck.LinkerdOpenShiftChecks)
+			}
 
 			checks = append(checks, healthcheck.AddOnCategories...)
+
+			if options.network {
+				checks = append(checks, healthcheck.LinkerdNetworkChecks)
+			}
+
+			if options.extensions {
+				extensionChecks, err := registerExtensionChecks(findExtensions(os.Getenv("PATH")), options.wait)
+				if err != nil {
+					return nil, err
+				}
+				checks = append(checks, extensionChecks...)
+			}
 		}
 	}
 
-	hc := healthcheck.NewHealthChecker(checks, &healthcheck.Options{
+	// the categories of the extensions are only known once registered
+	for id := range categoryTimeouts {
+		if !healthcheck.IsKnownCategory(id) {
+			return nil, fmt.Errorf("Validation error when executing check command: unknown category %q in --category-timeout", id)
+		}
+	}
+
+	return healthcheck.NewHealthChecker(checks, &healthcheck.Options{
 		ControlPlaneNamespace: controlPlaneNamespace,
 		CNINamespace:          cniNamespace,
 		DataPlaneNamespace:    options.namespace,
+		DataPlaneSelector:     options.selector,
 		KubeConfig:            kubeconfigPath,
 		KubeContext:           kubeContext,
 		Impersonate:           impersonate,
@@ -209,22 +430,33 @@ func configureAndRunChecks(wout io.Writer, werr io.Writer, stage string, options
 		CNIEnabled:            options.cniEnabled,
 		InstallManifest:       installManifest,
 		MultiCluster:          options.multicluster,
-	})
-
-	success := runChecks(wout, werr, hc, options.output)
-
-	if !success {
-		os.Exit(1)
-	}
-
-	return nil
+		RetryAll:              options.retryAll,
+		RetryBackoff: healthcheck.Backoff{
+			Interval:    options.retryInterval,
+			Factor:      options.retryBackoff,
+			MaxInterval: options.maxRetryInterval,
+		},
+		CheckTimeout:           options.checkTimeout,
+		CategoryTimeouts:       categoryTimeouts,
+		CertExpiryWarning:      certExpiryWarning,
+		CertExpiryError:        certExpiryError,
+		ProxyCertExpiryWarning: proxyCertExpiryWarning,
+		NetworkProbeImage:      options.networkProbeImage,
+		GatewayName:            options.gatewayName,
+		GatewayNamespace:       options.gatewayNamespace,
+		SourceKubeContext:      options.sourceContext,
+	}), nil
 }
 
 func runChecks(wout io.Writer, werr io.Writer, hc *healthcheck.HealthChecker, output string) bool {
-	if output == jsonOutput {
+	switch output {
+	case jsonOutput:
 		return runChecksJSON(wout, werr, hc)
+	case sarifOutput:
+		return runChecksSARIF(wout, werr, hc)
+	default:
+		return runChecksTable(wout, hc)
 	}
-	return runChecksTable(wout, hc)
 }
 
 func runChecksTable(wout io.Writer, hc *healthcheck.HealthChecker) bool {
@@ -283,86 +515,16 @@ func runChecksTable(wout io.Writer, hc *healthcheck.HealthChecker) bool {
 	return success
 }
 
-type checkOutput struct {
-	Success    bool             `json:"success"`
-	Categories []*checkCategory `json:"categories"`
-}
-
-type checkCategory struct {
-	Name   string   `json:"categoryName"`
-	Checks []*check `json:"checks"`
-}
-
-// check is a user-facing version of `healthcheck.CheckResult`, for output via
-// `linkerd check -o json`.
-type check struct {
-	Description string      `json:"description"`
-	Hint        string      `json:"hint,omitempty"`
-	Error       string      `json:"error,omitempty"`
-	Result      checkResult `json:"result"`
-}
-
-type checkResult string
-
-const (
-	checkSuccess checkResult = "success"
-	checkWarn    checkResult = "warning"
-	checkErr     checkResult = "error"
-)
-
 func runChecksJSON(wout io.Writer, werr io.Writer, hc *healthcheck.HealthChecker) bool {
-	var categories []*checkCategory
-
-	collectJSONOutput := func(result *healthcheck.CheckResult) {
-		categoryName := string(result.Category)
-		if categories == nil || categories[len(categories)-1].Name != categoryName {
-			categories = append(categories, &checkCategory{
-				Name:   categoryName,
-				Checks: []*check{},
-			})
-		}
-
-		if !result.Retry {
-			currentCategory := categories[len(categories)-1]
-			// ignore checks that are going to be retried, we want only final results
-			status := checkSuccess
-			if result.Err != nil {
-				status = checkErr
-				if result.Warning {
-					status = checkWarn
-				}
-			}
-
-			currentCheck := &check{
-				Description: result.Description,
-				Result:      status,
-			}
-
-			if result.Err != nil {
-				currentCheck.Error = result.Err.Error()
-
-				if result.HintAnchor != "" {
-					currentCheck.Hint = fmt.Sprintf("%s%s", healthcheck.HintBaseURL, result.HintAnchor)
-				}
-			}
-			currentCategory.Checks = append(currentCategory.Checks, currentCheck)
-		}
-	}
-
-	result := hc.RunChecks(collectJSONOutput)
-
-	outputJSON := checkOutput{
-		Success:    result,
-		Categories: categories,
-	}
+	results := hc.RunChecksWithResults(nil)
 
-	resultJSON, err := json.MarshalIndent(outputJSON, "", "  ")
+	resultJSON, err := json.MarshalIndent(results, "", "  ")
 	if err == nil {
 		fmt.Fprintf(wout, "%s\n", string(resultJSON))
 	} else {
 		fmt.Fprintf(werr, "JSON serialization of the check result failed with %s", err)
 	}
-	return result
+	return results.Success
 }
 
 func renderInstallManifest() (string, error) {
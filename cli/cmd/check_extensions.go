@@ -0,0 +1,179 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/linkerd/linkerd2/pkg/healthcheck"
+)
+
+var (
+	// extensionNameRegex matches the names of the executables `linkerd check
+	// --extensions` runs the checks of: linkerd-<name>,
+	// where name is made of lowercase letters, digits and dashes
+	extensionNameRegex = regexp.MustCompile(`^linkerd-[a-z][a-z0-9]*(-[a-z0-9]+)*$`)
+
+	// versionedCLIPrefixes are the prefixes of the linkerd CLIs installed side
+	// by side by the install script, e.g. ~/.linkerd2/bin/linkerd-stable-2.9.0,
+	// which aren't extensions
+	versionedCLIPrefixes = []string{"linkerd-stable-", "linkerd-edge-"}
+)
+
+// isExtension returns whether an executable name follows the naming
+// convention of the extensions
+func isExtension(name string) bool {
+	for _, prefix := range versionedCLIPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return false
+		}
+	}
+	return extensionNameRegex.MatchString(name)
+}
+
+// findExtensions returns the paths of the extensions, i.e. the executables
+// named linkerd-<name> as checked by isExtension, in the directories of
+// pathList, a PATH-like list. As with a shell, the first executable found for
+// each name shadows the others.
+func findExtensions(pathList string) []string {
+	var extensions []string
+	seen := map[string]bool{}
+	for _, dir := range filepath.SplitList(pathList) {
+		files, err := ioutil.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, f := range files {
+			name := f.Name()
+			if !isExtension(name) || seen[name] {
+				continue
+			}
+			if f.IsDir() || f.Mode()&0111 == 0 {
+				continue
+			}
+			seen[name] = true
+			extensions = append(extensions, filepath.Join(dir, name))
+		}
+	}
+	return extensions
+}
+
+// registerExtensionChecks runs `<extension> check -o json` for each of the
+// extensions, and registers the categories it reports so that the
+// HealthCheckers created afterwards replay their results. The extensions get
+// the control plane namespace, kubeconfig and context through the same
+// --linkerd-namespace, --kubeconfig and --context flags as the CLI. An
+// extension whose results can't be read is reported as a failed check. The
+// categories are registered as <extension>/<category>, so that they can't
+// collide with the built-in categories nor with the ones of other extensions.
+// It returns the IDs of the registered categories.
+func registerExtensionChecks(extensions []string, timeout time.Duration) ([]healthcheck.CategoryID, error) {
+	var ids []healthcheck.CategoryID
+	register := func(id healthcheck.CategoryID, checkers []healthcheck.Checker) error {
+		if err := healthcheck.RegisterCategory(id, checkers); err != nil {
+			return err
+		}
+		ids = append(ids, id)
+		return nil
+	}
+
+	for _, ext := range extensions {
+		name := filepath.Base(ext)
+		categories, err := runExtensionChecks(ext, timeout)
+		if err != nil {
+			err = fmt.Errorf("%s check failed: %s", name, err)
+			if regErr := register(extensionCategoryID(name, "extension"), []healthcheck.Checker{
+				{
+					Description: "extension checks can be run",
+					Check: func(context.Context, *healthcheck.HealthChecker) error {
+						return err
+					},
+				},
+			}); regErr != nil {
+				return nil, regErr
+			}
+			continue
+		}
+
+		for _, c := range categories {
+			var checkers []healthcheck.Checker
+			for _, result := range c.Checks {
+				checkers = append(checkers, extensionChecker(result))
+			}
+			if err := register(extensionCategoryID(name, string(c.Name)), checkers); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return ids, nil
+}
+
+func extensionCategoryID(extension, category string) healthcheck.CategoryID {
+	return healthcheck.CategoryID(fmt.Sprintf("%s/%s", extension, category))
+}
+
+func runExtensionChecks(ext string, timeout time.Duration) ([]*healthcheck.CategoryResults, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, ext, extensionCheckArgs()...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	// a failed check makes the extension exit with a non-zero code, so its
+	// error is only reported if it didn't output any result
+	runErr := cmd.Run()
+	var output healthcheck.Results
+	if err := json.Unmarshal(stdout.Bytes(), &output); err != nil {
+		if runErr != nil {
+			return nil, fmt.Errorf("%s: %s", runErr, strings.TrimSpace(stderr.String()))
+		}
+		return nil, fmt.Errorf("invalid output: %s", err)
+	}
+	return output.Categories, nil
+}
+
+// extensionCheckArgs returns the arguments of `<extension> check`, passing on
+// the global flags selecting the cluster and the control plane
+func extensionCheckArgs() []string {
+	args := []string{"check", "-o", jsonOutput, "--linkerd-namespace", controlPlaneNamespace}
+	if kubeconfigPath != "" {
+		args = append(args, "--kubeconfig", kubeconfigPath)
+	}
+	if kubeContext != "" {
+		args = append(args, "--context", kubeContext)
+	}
+	return args
+}
+
+// extensionChecker returns a Checker replaying the result of an extension's
+// check
+func extensionChecker(result *healthcheck.Check) healthcheck.Checker {
+	checker := healthcheck.Checker{
+		Description: result.Description,
+		Warning:     result.Result == healthcheck.CheckWarning,
+	}
+
+	var err error
+	if result.Result != healthcheck.CheckSuccess {
+		err = errors.New(result.Error)
+		if strings.HasPrefix(result.Hint, healthcheck.HintBaseURL) {
+			checker.HintAnchor = strings.TrimPrefix(result.Hint, healthcheck.HintBaseURL)
+		} else if result.Hint != "" {
+			err = fmt.Errorf("%s\n    see %s for hints", result.Error, result.Hint)
+		}
+	}
+	checker.Check = func(context.Context, *healthcheck.HealthChecker) error {
+		return err
+	}
+	return checker
+}
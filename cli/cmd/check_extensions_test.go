@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/linkerd/linkerd2/pkg/healthcheck"
+)
+
+func TestExtensionChecks(t *testing.T) {
+	dir, err := ioutil.TempDir("", "linkerd-extensions")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	files := map[string]struct {
+		content string
+		mode    os.FileMode
+	}{
+		"linkerd-foo": {`#!/bin/sh
+cat <<EOF
+{
+  "success": false,
+  "categories": [
+    {
+      "categoryName": "linkerd-foo",
+      "checks": [
+        {"description": "foo is running", "result": "success"},
+        {"description": "foo is configured", "result": "error", "error": "foo is misconfigured", "hint": "https://example.com/foo"}
+      ]
+    }
+  ]
+}
+EOF
+exit 1
+`, 0755},
+		"linkerd-bar":   {"#!/bin/sh\necho 'bar is broken' >&2\nexit 2\n", 0755},
+		"linkerd-notes": {"not an executable", 0644},
+		"kubectl-foo":   {"#!/bin/sh\nexit 0\n", 0755},
+		// versioned CLIs installed by the install script, and names not
+		// following the convention
+		"linkerd-stable-2.9.0":  {"#!/bin/sh\nexit 0\n", 0755},
+		"linkerd-edge-20.10.1":  {"#!/bin/sh\nexit 0\n", 0755},
+		"linkerd-stable-latest": {"#!/bin/sh\nexit 0\n", 0755},
+		"linkerd-Foo.sh":        {"#!/bin/sh\nexit 0\n", 0755},
+		"linkerd-":              {"#!/bin/sh\nexit 0\n", 0755},
+	}
+	for name, f := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(f.content), f.mode); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+	}
+
+	extensions := findExtensions(dir + string(os.PathListSeparator) + filepath.Join(dir, "missing"))
+	expected := []string{filepath.Join(dir, "linkerd-bar"), filepath.Join(dir, "linkerd-foo")}
+	if !reflect.DeepEqual(extensions, expected) {
+		t.Fatalf("Expected extensions %v, got %v", expected, extensions)
+	}
+
+	ids, err := registerExtensionChecks(extensions, 10*time.Second)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	expectedIDs := []healthcheck.CategoryID{"linkerd-bar/extension", "linkerd-foo/linkerd-foo"}
+	if !reflect.DeepEqual(ids, expectedIDs) {
+		t.Fatalf("Expected categories %v, got %v", expectedIDs, ids)
+	}
+
+	hc := healthcheck.NewHealthChecker(ids, &healthcheck.Options{})
+	output := bytes.NewBufferString("")
+	if runChecksTable(output, hc) {
+		t.Fatalf("Expected the extension checks to fail")
+	}
+
+	for _, line := range []string{
+		"× extension checks can be run",
+		"linkerd-bar check failed: exit status 2: bar is broken",
+		"√ foo is running",
+		"× foo is configured",
+		"foo is misconfigured\n    see https://example.com/foo for hints",
+	} {
+		if !strings.Contains(output.String(), line) {
+			t.Errorf("Expected output to contain %q, got:\n%s", line, output)
+		}
+	}
+}
+
+func TestExtensionCheckArgs(t *testing.T) {
+	dir, err := ioutil.TempDir("", "linkerd-extensions")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	// the extension records the arguments it's run with
+	argsFile := filepath.Join(dir, "args")
+	ext := filepath.Join(dir, "linkerd-foo")
+	script := `#!/bin/sh
+for arg in "$@"; do echo "$arg" >> ` + argsFile + `; done
+echo '{"success": true, "categories": []}'
+`
+	if err := ioutil.WriteFile(ext, []byte(script), 0755); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	defer func(ns, kubeconfig, context string) {
+		controlPlaneNamespace, kubeconfigPath, kubeContext = ns, kubeconfig, context
+	}(controlPlaneNamespace, kubeconfigPath, kubeContext)
+	controlPlaneNamespace = "linkerd-test"
+	kubeconfigPath = "/tmp/kubeconfig"
+	kubeContext = "kind-test"
+
+	if _, err := registerExtensionChecks([]string{ext}, 10*time.Second); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	out, err := ioutil.ReadFile(argsFile)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	args := strings.Split(strings.TrimSpace(string(out)), "\n")
+	expected := []string{
+		"check", "-o", "json",
+		"--linkerd-namespace", "linkerd-test",
+		"--kubeconfig", "/tmp/kubeconfig",
+		"--context", "kind-test",
+	}
+	if !reflect.DeepEqual(args, expected) {
+		t.Fatalf("Expected the extension to be run with %v, got %v", expected, args)
+	}
+}
@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/linkerd/linkerd2/pkg/healthcheck"
+	"github.com/linkerd/linkerd2/pkg/version"
+)
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+)
+
+var nonAlphaNum = regexp.MustCompile(`[^a-z0-9]+`)
+
+// sarifLog is the subset of the Static Analysis Results Interchange Format
+// (SARIF) written by `linkerd check -o sarif`: each check is a rule, and each
+// of its final results is a result of that rule.
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	Name             string       `json:"name"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+	HelpURI          string       `json:"helpUri,omitempty"`
+	Properties       sarifProps   `json:"properties"`
+}
+
+type sarifProps struct {
+	Category string `json:"category"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Kind      string          `json:"kind"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+// sarifLocation locates a result: the checks don't map to source files, so
+// only the logical location of the check, within its category, is set.
+type sarifLocation struct {
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations"`
+}
+
+type sarifLogicalLocation struct {
+	Name               string `json:"name"`
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+func runChecksSARIF(wout io.Writer, werr io.Writer, hc *healthcheck.HealthChecker) bool {
+	results := hc.RunChecksWithResults(nil)
+
+	out, err := json.MarshalIndent(checksToSARIF(results.Categories), "", "  ")
+	if err != nil {
+		fmt.Fprintf(werr, "SARIF serialization of the check result failed with %s", err)
+		return results.Success
+	}
+	fmt.Fprintf(wout, "%s\n", out)
+	return results.Success
+}
+
+// checksToSARIF converts the results of the checks to a SARIF log
+func checksToSARIF(categories []*healthcheck.CategoryResults) sarifLog {
+	driver := sarifDriver{
+		Name:           "linkerd-check",
+		Version:        version.Version,
+		InformationURI: "https://linkerd.io/2/reference/cli/check/",
+		Rules:          []sarifRule{},
+	}
+	results := []sarifResult{}
+
+	for _, category := range categories {
+		for _, c := range category.Checks {
+			id := checkRuleID(string(category.Name), c.Description)
+			driver.Rules = append(driver.Rules, sarifRule{
+				ID:               id,
+				Name:             c.Description,
+				ShortDescription: sarifMessage{Text: c.Description},
+				HelpURI:          c.Hint,
+				Properties:       sarifProps{Category: string(category.Name)},
+			})
+
+			result := sarifResult{
+				RuleID:  id,
+				Kind:    "pass",
+				Level:   "none",
+				Message: sarifMessage{Text: c.Description},
+				Locations: []sarifLocation{{
+					LogicalLocations: []sarifLogicalLocation{{
+						Name:               string(category.Name),
+						FullyQualifiedName: id,
+						Kind:               "module",
+					}},
+				}},
+			}
+			if c.Result != healthcheck.CheckSuccess {
+				result.Kind = "fail"
+				result.Level = "error"
+				if c.Result == healthcheck.CheckWarning {
+					result.Level = "warning"
+				}
+				result.Message.Text = fmt.Sprintf("%s: %s", c.Description, c.Error)
+				if c.Hint != "" {
+					result.Message.Text += fmt.Sprintf(" (see %s for hints)", c.Hint)
+				}
+			}
+			results = append(results, result)
+		}
+	}
+
+	return sarifLog{
+		Version: sarifVersion,
+		Schema:  sarifSchema,
+		Runs:    []sarifRun{{Tool: sarifTool{Driver: driver}, Results: results}},
+	}
+}
+
+// checkRuleID derives the SARIF rule ID of a check from its category and
+// description, e.g. "kubernetes-api/can-query-the-kubernetes-api"
+func checkRuleID(category, description string) string {
+	slug := func(s string) string {
+		return strings.Trim(nonAlphaNum.ReplaceAllString(strings.ToLower(s), "-"), "-")
+	}
+	return fmt.Sprintf("%s/%s", slug(category), slug(description))
+}
@@ -5,7 +5,9 @@ import (
 	"context"
 	"fmt"
 	"io/ioutil"
+	"reflect"
 	"testing"
+	"time"
 
 	"github.com/linkerd/linkerd2/pkg/healthcheck"
 )
@@ -64,4 +66,97 @@ func TestCheckStatus(t *testing.T) {
 			t.Fatalf("Expected function to render:\n%s\bbut got:\n%s", expectedContent, output)
 		}
 	})
+
+	t.Run("Prints expected output in sarif", func(t *testing.T) {
+		hc := healthcheck.NewHealthChecker(
+			[]healthcheck.CategoryID{},
+			&healthcheck.Options{},
+		)
+		hc.Add("category", "check1", "", func(context.Context) error {
+			return nil
+		})
+		hc.Add("category", "check2", "hint-anchor", func(context.Context) error {
+			return fmt.Errorf("This should contain instructions for fail")
+		})
+
+		output := bytes.NewBufferString("")
+		runChecks(output, stderr, hc, sarifOutput)
+
+		goldenFileBytes, err := ioutil.ReadFile("testdata/check_output_sarif.golden")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		expectedContent := string(goldenFileBytes)
+
+		if expectedContent != output.String() {
+			t.Fatalf("Expected function to render:\n%s\bbut got:\n%s", expectedContent, output)
+		}
+	})
+}
+
+func TestParseCategoryTimeouts(t *testing.T) {
+	options := newCheckOptions()
+	options.categoryTimeouts = []string{"linkerd-identity=1m", "linkerd-api=30s"}
+	timeouts, err := options.parseCategoryTimeouts()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	expected := map[healthcheck.CategoryID]time.Duration{"linkerd-identity": time.Minute, "linkerd-api": 30 * time.Second}
+	if !reflect.DeepEqual(timeouts, expected) {
+		t.Fatalf("Expected %v, got %v", expected, timeouts)
+	}
+
+	for _, invalid := range []string{"linkerd-identity", "=1m", "linkerd-identity=soon"} {
+		options.categoryTimeouts = []string{invalid}
+		if err := options.validate(); err == nil {
+			t.Errorf("Expected an error validating --category-timeout %s", invalid)
+		}
+	}
+
+	options.categoryTimeouts = []string{"linkerd-unknown=1m"}
+	if _, err := options.newHealthChecker(""); err == nil {
+		t.Error("Expected an error for an unknown --category-timeout category")
+	}
+}
+
+func TestParseExpiryWindow(t *testing.T) {
+	testCases := map[string]time.Duration{
+		"30d":  30 * 24 * time.Hour,
+		"720h": 720 * time.Hour,
+		"0s":   0,
+	}
+	for window, expected := range testCases {
+		actual, err := parseExpiryWindow("--cert-expiry-warning", window)
+		if err != nil {
+			t.Errorf("Unexpected error parsing %s: %s", window, err)
+		} else if actual != expected {
+			t.Errorf("Expected %s to be parsed as %s, got %s", window, expected, actual)
+		}
+	}
+
+	for _, invalid := range []string{"d", "-1d", "soon"} {
+		if _, err := parseExpiryWindow("--cert-expiry-warning", invalid); err == nil {
+			t.Errorf("Expected an error parsing %q", invalid)
+		}
+	}
+
+	options := newCheckOptions()
+	options.certExpiryWarning = "7d"
+	options.certExpiryError = "30d"
+	if err := options.validate(); err == nil {
+		t.Errorf("Expected an error with an error window longer than the warning one")
+	}
+
+	for _, modify := range []func(*checkOptions){
+		func(o *checkOptions) { o.certExpiryWarning = "0s" },
+		func(o *checkOptions) { o.certExpiryWarning = "0d" },
+		func(o *checkOptions) { o.proxyCertExpiry = "0s" },
+	} {
+		options := newCheckOptions()
+		modify(options)
+		if err := options.validate(); err == nil {
+			t.Errorf("Expected an error with an empty warning window, got none for %+v", options)
+		}
+	}
 }
@@ -74,5 +74,10 @@ func newCmdDiagnostics() *cobra.Command {
 
 	cmd.Flags().DurationVarP(&options.wait, "wait", "w", options.wait, "Time allowed to fetch diagnostics")
 
+	cmd.AddCommand(newCmdDiagnosticsControllerMetrics())
+	cmd.AddCommand(newCmdDiagnosticsEndpoints())
+	cmd.AddCommand(newCmdDiagnosticsProxyMetrics())
+	cmd.AddCommand(newCmdDiagnosticsProxyResources())
+
 	return cmd
 }
@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type controllerMetricsOptions struct {
+	wait       time.Duration
+	components []string
+}
+
+func newCmdDiagnosticsControllerMetrics() *cobra.Command {
+	options := &controllerMetricsOptions{
+		wait: 30 * time.Second,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "controller-metrics [flags]",
+		Short: "Fetch the metrics of all the Linkerd control plane containers into one dump",
+		Long: `Fetch the metrics of all the Linkerd control plane containers into one dump.
+
+  This command initiates a port-forward to each control plane container, and
+  queries their /metrics endpoint. Each sample of the dump is labeled with the
+  component, the pod and the container it was scraped from, so that the dump
+  of the whole control plane can be attached to a support request or loaded
+  as is into a Prometheus-compatible tool.`,
+		Example: `  # Get the metrics of the whole control plane.
+  linkerd diagnostics controller-metrics > linkerd-metrics.txt
+
+  # Get the metrics of the destination and identity components only.
+  linkerd diagnostics controller-metrics --component destination --component identity`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			k8sAPI, err := k8s.NewAPI(kubeconfigPath, kubeContext, impersonate, impersonateGroup, 0)
+			if err != nil {
+				return err
+			}
+
+			podList, err := k8sAPI.CoreV1().Pods(controlPlaneNamespace).List(metav1.ListOptions{LabelSelector: k8s.ControllerComponentLabel})
+			if err != nil {
+				return err
+			}
+
+			pods := filterControllerPods(podList.Items, options.components)
+			if len(pods) == 0 {
+				return fmt.Errorf("no control plane pods found in the %s namespace", controlPlaneNamespace)
+			}
+
+			components := make(map[string]string)
+			for _, pod := range pods {
+				components[pod.GetName()] = pod.GetLabels()[k8s.ControllerComponentLabel]
+			}
+
+			results := getMetrics(k8sAPI, pods, adminHTTPPortName, options.wait, verbose)
+			renderControllerMetrics(results, components, os.Stdout)
+			return nil
+		},
+	}
+
+	cmd.Flags().DurationVarP(&options.wait, "wait", "w", options.wait, "Time allowed to fetch the metrics")
+	cmd.Flags().StringArrayVarP(&options.components, "component", "c", options.components, "Only fetch the metrics of this control plane component, like \"destination\" (can be repeated)")
+
+	return cmd
+}
+
+// filterControllerPods returns the pods that belong to one of components, or
+// all the pods when components is empty.
+func filterControllerPods(pods []corev1.Pod, components []string) []corev1.Pod {
+	if len(components) == 0 {
+		return pods
+	}
+
+	var filtered []corev1.Pod
+	for _, pod := range pods {
+		for _, component := range components {
+			if pod.GetLabels()[k8s.ControllerComponentLabel] == component {
+				filtered = append(filtered, pod)
+				break
+			}
+		}
+	}
+	return filtered
+}
+
+func renderControllerMetrics(results []metricsResult, components map[string]string, w io.Writer) {
+	for i, result := range results {
+		component := components[result.pod]
+		fmt.Fprintf(w, "#\n# POD %s (%d of %d)\n# COMPONENT %s\n", result.pod, i+1, len(results), component)
+		if result.err != nil {
+			fmt.Fprintf(w, "# ERROR %s\n", result.err)
+			continue
+		}
+		fmt.Fprintf(w, "# CONTAINER %s\n#\n", result.container)
+
+		metrics, err := labelMetrics(result.metrics, map[string]string{
+			"component": component,
+			"pod":       result.pod,
+			"container": result.container,
+		})
+		if err != nil {
+			fmt.Fprintf(w, "# ERROR %s\n", err)
+			continue
+		}
+		w.Write(metrics)
+	}
+}
+
+// labelMetrics adds labels to all the samples of metrics, which are returned
+// sorted by name. A label that a sample already has isn't overridden.
+func labelMetrics(metrics []byte, labels map[string]string) ([]byte, error) {
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(bytes.NewReader(metrics))
+	if err != nil {
+		return nil, fmt.Errorf("invalid metrics: %s", err)
+	}
+
+	names := make([]string, 0, len(families))
+	for name := range families {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	labelNames := make([]string, 0, len(labels))
+	for name := range labels {
+		labelNames = append(labelNames, name)
+	}
+	sort.Strings(labelNames)
+
+	var buf bytes.Buffer
+	for _, name := range names {
+		family := families[name]
+		for _, metric := range family.GetMetric() {
+			existing := make(map[string]struct{})
+			for _, pair := range metric.GetLabel() {
+				existing[pair.GetName()] = struct{}{}
+			}
+			for _, labelName := range labelNames {
+				if _, ok := existing[labelName]; ok {
+					continue
+				}
+				labelName, value := labelName, labels[labelName] // pin
+				metric.Label = append(metric.Label, &dto.LabelPair{Name: &labelName, Value: &value})
+			}
+		}
+		if _, err := expfmt.MetricFamilyToText(&buf, family); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
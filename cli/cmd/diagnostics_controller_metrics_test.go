@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestFilterControllerPods(t *testing.T) {
+	pod := func(name, component string) corev1.Pod {
+		return corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{k8s.ControllerComponentLabel: component},
+		}}
+	}
+	pods := []corev1.Pod{
+		pod("linkerd-controller-1", "controller"),
+		pod("linkerd-destination-1", "destination"),
+		pod("linkerd-identity-1", "identity"),
+	}
+
+	testCases := []struct {
+		components []string
+		expected   []string
+	}{
+		{nil, []string{"linkerd-controller-1", "linkerd-destination-1", "linkerd-identity-1"}},
+		{[]string{"identity", "destination"}, []string{"linkerd-destination-1", "linkerd-identity-1"}},
+		{[]string{"tap"}, nil},
+	}
+
+	for i, tc := range testCases {
+		tc := tc // pin
+		var names []string
+		for _, p := range filterControllerPods(pods, tc.components) {
+			names = append(names, p.GetName())
+		}
+		if len(names) != len(tc.expected) {
+			t.Fatalf("test case %d: expected %v, got %v", i, tc.expected, names)
+		}
+		for j := range names {
+			if names[j] != tc.expected[j] {
+				t.Errorf("test case %d: expected %v, got %v", i, tc.expected, names)
+			}
+		}
+	}
+}
+
+func TestRenderControllerMetrics(t *testing.T) {
+	results := []metricsResult{
+		{
+			pod:       "linkerd-destination-1",
+			container: "destination",
+			metrics: []byte(`# HELP grpc_server_handled_total Total number of RPCs completed on the server.
+# TYPE grpc_server_handled_total counter
+grpc_server_handled_total{grpc_code="OK",grpc_method="Get"} 12
+# HELP go_goroutines Number of goroutines that currently exist.
+# TYPE go_goroutines gauge
+go_goroutines{pod="overridden"} 42
+`),
+		},
+		{pod: "linkerd-identity-1", err: errors.New("pod not running: linkerd-identity-1")},
+	}
+	components := map[string]string{
+		"linkerd-destination-1": "destination",
+		"linkerd-identity-1":    "identity",
+	}
+
+	var buf bytes.Buffer
+	renderControllerMetrics(results, components, &buf)
+
+	expected := `#
+# POD linkerd-destination-1 (1 of 2)
+# COMPONENT destination
+# CONTAINER destination
+#
+# HELP go_goroutines Number of goroutines that currently exist.
+# TYPE go_goroutines gauge
+go_goroutines{pod="overridden",component="destination",container="destination"} 42
+# HELP grpc_server_handled_total Total number of RPCs completed on the server.
+# TYPE grpc_server_handled_total counter
+grpc_server_handled_total{grpc_code="OK",grpc_method="Get",component="destination",container="destination",pod="linkerd-destination-1"} 12
+#
+# POD linkerd-identity-1 (2 of 2)
+# COMPONENT identity
+# ERROR pod not running: linkerd-identity-1
+`
+	if buf.String() != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, buf.String())
+	}
+}
@@ -0,0 +1,340 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	pb "github.com/linkerd/linkerd2-proxy-api/go/destination"
+	"github.com/linkerd/linkerd2/controller/api/destination"
+	"github.com/linkerd/linkerd2/pkg/addr"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/spf13/cobra"
+)
+
+const (
+	destinationDeployment = "linkerd-destination"
+	destinationPort       = 8086
+)
+
+type destinationEndpointsOptions struct {
+	outputFormat string
+	wait         time.Duration
+}
+
+// destinationEndpoints holds the answer of the destination service for one
+// authority.
+type destinationEndpoints struct {
+	Authority string                    `json:"authority"`
+	Exists    bool                      `json:"exists"`
+	Endpoints []destinationEndpointsRow `json:"endpoints"`
+	Profile   *destinationProfile       `json:"profile"`
+}
+
+type destinationEndpointsRow struct {
+	Namespace    string `json:"namespace"`
+	IP           string `json:"ip"`
+	Port         uint32 `json:"port"`
+	Pod          string `json:"pod"`
+	Weight       uint32 `json:"weight"`
+	Identity     string `json:"identity"`
+	ProtocolHint string `json:"protocol_hint"`
+}
+
+type destinationProfile struct {
+	Routes       []destinationRoute       `json:"routes"`
+	RetryBudget  *destinationRetryBudget  `json:"retry_budget"`
+	DstOverrides []destinationDstOverride `json:"dst_overrides"`
+}
+
+type destinationRoute struct {
+	Name      string `json:"name"`
+	Retryable bool   `json:"retryable"`
+	Timeout   string `json:"timeout"`
+}
+
+type destinationRetryBudget struct {
+	RetryRatio          float32 `json:"retry_ratio"`
+	MinRetriesPerSecond uint32  `json:"min_retries_per_second"`
+	TTL                 string  `json:"ttl"`
+}
+
+type destinationDstOverride struct {
+	Authority string `json:"authority"`
+	Weight    uint32 `json:"weight"`
+}
+
+func newCmdDiagnosticsEndpoints() *cobra.Command {
+	options := &destinationEndpointsOptions{
+		outputFormat: tableOutput,
+		wait:         30 * time.Second,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "endpoints [flags] (AUTHORITY)...",
+		Short: "Introspect Linkerd's service discovery state",
+		Long: `Introspect Linkerd's service discovery state.
+
+  This command initiates a port-forward to the destination service and queries
+  it for the given authorities, the same way a proxy does. It prints the
+  endpoints the proxies are sent for each authority, with their weights, TLS
+  identities and protocol hints, and the service profile applied to it. This
+  helps debugging discrepancies between the state of the cluster and what the
+  proxies see. Unlike 'linkerd endpoints', it doesn't go through the public API.
+
+  Each AUTHORITY is a fully qualified host with a port.`,
+		Example: `  # Get the endpoints of the web service of the emojivoto namespace.
+  linkerd diagnostics endpoints web.emojivoto.svc.cluster.local:80
+
+  # Get the endpoints of several authorities, in JSON.
+  linkerd diagnostics endpoints web.emojivoto.svc.cluster.local:80 emoji-svc.emojivoto.svc.cluster.local:8080 -o json`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if options.outputFormat != tableOutput && options.outputFormat != jsonOutput {
+				return fmt.Errorf("--output currently only supports %s and %s", tableOutput, jsonOutput)
+			}
+
+			k8sAPI, err := k8s.NewAPI(kubeconfigPath, kubeContext, impersonate, impersonateGroup, 0)
+			if err != nil {
+				return err
+			}
+
+			portForward, err := k8s.NewPortForward(
+				k8sAPI,
+				controlPlaneNamespace,
+				destinationDeployment,
+				"localhost",
+				0,
+				destinationPort,
+				verbose,
+			)
+			if err != nil {
+				return err
+			}
+
+			defer portForward.Stop()
+			if err = portForward.Init(); err != nil {
+				return fmt.Errorf("error running port-forward: %s", err)
+			}
+
+			client, conn, err := destination.NewClient(portForward.Address())
+			if err != nil {
+				return err
+			}
+			defer conn.Close()
+
+			results := make([]destinationEndpoints, 0, len(args))
+			for _, authority := range args {
+				result, err := requestDestinationEndpoints(client, authority, options.wait)
+				if err != nil {
+					return fmt.Errorf("failed to resolve %s: %s", authority, err)
+				}
+				results = append(results, *result)
+			}
+
+			return renderDestinationEndpoints(results, options.outputFormat, os.Stdout)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.outputFormat, "output", "o", options.outputFormat, "Output format; one of: \"table\" or \"json\"")
+	cmd.Flags().DurationVarP(&options.wait, "wait", "w", options.wait, "Time allowed to query the destination service")
+
+	return cmd
+}
+
+// requestDestinationEndpoints reads the first update of the Get and GetProfile streams
+// of the destination service for authority. The first update holds the whole
+// state known to the destination service at that time.
+func requestDestinationEndpoints(client pb.DestinationClient, authority string, wait time.Duration) (*destinationEndpoints, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), wait)
+	defer cancel()
+
+	req := &pb.GetDestination{
+		Scheme: "k8s",
+		Path:   authority,
+	}
+
+	stream, err := client.Get(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	update, err := stream.Recv()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &destinationEndpoints{Authority: authority}
+	switch updateType := update.Update.(type) {
+	case *pb.Update_Add:
+		result.Exists = true
+		result.Endpoints = toDestinationEndpointsRows(updateType.Add)
+	case *pb.Update_NoEndpoints:
+		result.Exists = updateType.NoEndpoints.GetExists()
+	default:
+		return nil, fmt.Errorf("unexpected first update: %v", update)
+	}
+
+	profileStream, err := client.GetProfile(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	profile, err := profileStream.Recv()
+	if err != nil {
+		return nil, err
+	}
+	result.Profile = toDestinationProfile(profile)
+
+	return result, nil
+}
+
+func toDestinationEndpointsRows(set *pb.WeightedAddrSet) []destinationEndpointsRow {
+	rows := make([]destinationEndpointsRow, 0, len(set.GetAddrs()))
+	for _, wa := range set.GetAddrs() {
+		hint := ""
+		if wa.GetProtocolHint().GetH2() != nil {
+			hint = "h2"
+		}
+		rows = append(rows, destinationEndpointsRow{
+			Namespace:    set.GetMetricLabels()["namespace"],
+			IP:           addr.ProxyIPToString(wa.GetAddr().GetIp()),
+			Port:         wa.GetAddr().GetPort(),
+			Pod:          wa.GetMetricLabels()["pod"],
+			Weight:       wa.GetWeight(),
+			Identity:     wa.GetTlsIdentity().GetDnsLikeIdentity().GetName(),
+			ProtocolHint: hint,
+		})
+	}
+
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].Pod != rows[j].Pod {
+			return rows[i].Pod < rows[j].Pod
+		}
+		return rows[i].IP < rows[j].IP
+	})
+	return rows
+}
+
+func toDestinationProfile(profile *pb.DestinationProfile) *destinationProfile {
+	p := &destinationProfile{
+		Routes:       []destinationRoute{},
+		DstOverrides: []destinationDstOverride{},
+	}
+
+	for _, route := range profile.GetRoutes() {
+		r := destinationRoute{
+			Name:      route.GetMetricsLabels()["route"],
+			Retryable: route.GetIsRetryable(),
+		}
+		if timeout, err := ptypes.Duration(route.GetTimeout()); err == nil && route.GetTimeout() != nil {
+			r.Timeout = timeout.String()
+		}
+		p.Routes = append(p.Routes, r)
+	}
+
+	if budget := profile.GetRetryBudget(); budget != nil {
+		p.RetryBudget = &destinationRetryBudget{
+			RetryRatio:          budget.GetRetryRatio(),
+			MinRetriesPerSecond: budget.GetMinRetriesPerSecond(),
+		}
+		if ttl, err := ptypes.Duration(budget.GetTtl()); err == nil && budget.GetTtl() != nil {
+			p.RetryBudget.TTL = ttl.String()
+		}
+	}
+
+	for _, dst := range profile.GetDstOverrides() {
+		p.DstOverrides = append(p.DstOverrides, destinationDstOverride{
+			Authority: dst.GetAuthority(),
+			Weight:    dst.GetWeight(),
+		})
+	}
+
+	return p
+}
+
+func renderDestinationEndpoints(results []destinationEndpoints, outputFormat string, w io.Writer) error {
+	if outputFormat == jsonOutput {
+		b, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(w, string(b))
+		return nil
+	}
+
+	for i, result := range results {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		renderDestinationEndpointsTable(result, w)
+	}
+	return nil
+}
+
+func renderDestinationEndpointsTable(result destinationEndpoints, w io.Writer) {
+	fmt.Fprintf(w, "AUTHORITY %s\n\n", result.Authority)
+
+	switch {
+	case !result.Exists:
+		fmt.Fprintln(w, "The authority doesn't match any service")
+	case len(result.Endpoints) == 0:
+		fmt.Fprintln(w, "No endpoints found")
+	default:
+		t := tabwriter.NewWriter(w, 0, 0, padding, ' ', 0)
+		fmt.Fprintln(t, strings.Join([]string{"NAMESPACE", "IP", "PORT", "POD", "WEIGHT", "IDENTITY", "PROTOCOL_HINT"}, "\t"))
+		for _, row := range result.Endpoints {
+			fmt.Fprintf(t, "%s\t%s\t%d\t%s\t%d\t%s\t%s\n",
+				row.Namespace,
+				row.IP,
+				row.Port,
+				row.Pod,
+				row.Weight,
+				valueOrDash(row.Identity),
+				valueOrDash(row.ProtocolHint),
+			)
+		}
+		t.Flush()
+	}
+
+	profile := result.Profile
+	if profile == nil {
+		return
+	}
+
+	fmt.Fprintln(w)
+	if len(profile.Routes) == 0 && profile.RetryBudget == nil && len(profile.DstOverrides) == 0 {
+		fmt.Fprintln(w, "No service profile")
+		return
+	}
+
+	if len(profile.Routes) > 0 {
+		t := tabwriter.NewWriter(w, 0, 0, padding, ' ', 0)
+		fmt.Fprintln(t, "ROUTE\tRETRYABLE\tTIMEOUT")
+		for _, route := range profile.Routes {
+			fmt.Fprintf(t, "%s\t%t\t%s\n", route.Name, route.Retryable, valueOrDash(route.Timeout))
+		}
+		t.Flush()
+	}
+
+	if budget := profile.RetryBudget; budget != nil {
+		fmt.Fprintln(w)
+		fmt.Fprintf(w, "Retry budget: %g retry ratio, %d min retries per second, %s TTL\n",
+			budget.RetryRatio, budget.MinRetriesPerSecond, valueOrDash(budget.TTL))
+	}
+
+	if len(profile.DstOverrides) > 0 {
+		fmt.Fprintln(w)
+		t := tabwriter.NewWriter(w, 0, 0, padding, ' ', 0)
+		fmt.Fprintln(t, "DST_OVERRIDE\tWEIGHT")
+		for _, dst := range profile.DstOverrides {
+			fmt.Fprintf(t, "%s\t%d\n", dst.Authority, dst.Weight)
+		}
+		t.Flush()
+	}
+}
@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/duration"
+	pb "github.com/linkerd/linkerd2-proxy-api/go/destination"
+	"github.com/linkerd/linkerd2/controller/api/public"
+)
+
+func TestDiagnosticsEndpoints(t *testing.T) {
+	addrSet := public.BuildAddrSet(public.AuthorityEndpoints{
+		Namespace: "emojivoto",
+		ServiceID: "web-svc",
+		Pods: []public.PodDetails{
+			{Name: "web-7bf9f47bd5-jjdrl", IP: 84281096, Port: 8080},
+			{Name: "web-6bf9f47bd5-jjcrl", IP: 16909060, Port: 8080},
+		},
+	})
+	for _, addr := range addrSet.Addrs {
+		addr.Weight = 10000
+		addr.ProtocolHint = &pb.ProtocolHint{Protocol: &pb.ProtocolHint_H2_{H2: &pb.ProtocolHint_H2{}}}
+	}
+	addrSet.Addrs[0].TlsIdentity = &pb.TlsIdentity{
+		Strategy: &pb.TlsIdentity_DnsLikeIdentity_{
+			DnsLikeIdentity: &pb.TlsIdentity_DnsLikeIdentity{
+				Name: "web.emojivoto.serviceaccount.identity.linkerd.cluster.local",
+			},
+		},
+	}
+
+	profile := &pb.DestinationProfile{
+		Routes: []*pb.Route{
+			{
+				MetricsLabels: map[string]string{"route": "GET /api/list"},
+				IsRetryable:   true,
+				Timeout:       &duration.Duration{Nanos: 25000000},
+			},
+			{
+				MetricsLabels: map[string]string{"route": "POST /api/vote"},
+			},
+		},
+		RetryBudget: &pb.RetryBudget{
+			RetryRatio:          0.2,
+			MinRetriesPerSecond: 10,
+			Ttl:                 &duration.Duration{Seconds: 10},
+		},
+		DstOverrides: []*pb.WeightedDst{
+			{Authority: "web-svc.emojivoto.svc.cluster.local:80", Weight: 900},
+			{Authority: "web-svc-v2.emojivoto.svc.cluster.local:80", Weight: 100},
+		},
+	}
+
+	testCases := []struct {
+		name         string
+		addrSet      *pb.WeightedAddrSet
+		profile      *pb.DestinationProfile
+		outputFormat string
+		file         string
+	}{
+		{
+			"endpoints and profile",
+			addrSet,
+			profile,
+			tableOutput,
+			"diagnostics_endpoints_output.golden",
+		},
+		{
+			"endpoints and profile (json)",
+			addrSet,
+			profile,
+			jsonOutput,
+			"diagnostics_endpoints_output_json.golden",
+		},
+		{
+			"unknown authority",
+			nil,
+			&pb.DestinationProfile{},
+			tableOutput,
+			"diagnostics_endpoints_no_endpoints_output.golden",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.name, func(t *testing.T) {
+			updates := []pb.Update{{Update: &pb.Update_NoEndpoints{NoEndpoints: &pb.NoEndpoints{Exists: false}}}}
+			if tc.addrSet != nil {
+				updates = []pb.Update{{Update: &pb.Update_Add{Add: tc.addrSet}}}
+			}
+
+			client := &public.MockAPIClient{
+				DestinationGetClientToReturn: &public.MockDestinationGetClient{
+					UpdatesToReturn: updates,
+				},
+				DestinationGetProfileClientToReturn: &public.MockDestinationGetProfileClient{
+					ProfilesToReturn: []*pb.DestinationProfile{tc.profile},
+				},
+			}
+
+			result, err := requestDestinationEndpoints(client, "web-svc.emojivoto.svc.cluster.local:80", time.Second)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+
+			var buf bytes.Buffer
+			if err := renderDestinationEndpoints([]destinationEndpoints{*result}, tc.outputFormat, &buf); err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			diffTestdata(t, tc.file, buf.String())
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/prometheus/common/expfmt"
+	"github.com/spf13/cobra"
+)
+
+type proxyMetricsOptions struct {
+	namespace string
+	wait      time.Duration
+	metrics   []string
+}
+
+func newCmdDiagnosticsProxyMetrics() *cobra.Command {
+	options := &proxyMetricsOptions{
+		namespace: defaultNamespace,
+		wait:      30 * time.Second,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "proxy-metrics [flags] (RESOURCE)",
+		Short: "Fetch the raw Prometheus metrics of the proxies of a resource",
+		Long: `Fetch the raw Prometheus metrics of the proxies of a resource.
+
+  This command initiates a port-forward to the proxy admin port of each pod of
+  the given resource, and dumps the /metrics endpoint of their proxies. The
+  --metric flag restricts the dump to the given metrics.
+
+  The RESOURCE argument specifies the target resource (TYPE/NAME), like for
+  'linkerd metrics'.`,
+		Example: `  # Get the metrics of the proxies of the web deployment.
+  linkerd diagnostics proxy-metrics -n emojivoto deploy/web
+
+  # Get the TCP metrics and the request counts of the proxy of a pod.
+  linkerd diagnostics proxy-metrics -n emojivoto po/web-5f86686c4d-58p7k --metric 'tcp_*' --metric request_total`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			k8sAPI, err := k8s.NewAPI(kubeconfigPath, kubeContext, impersonate, impersonateGroup, 0)
+			if err != nil {
+				return err
+			}
+
+			pods, err := getPodsFor(k8sAPI, options.namespace, args[0])
+			if err != nil {
+				return err
+			}
+
+			results := getMetrics(k8sAPI, pods, k8s.ProxyAdminPortName, options.wait, verbose)
+			renderProxyMetrics(results, options.metrics, os.Stdout)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.namespace, "namespace", "n", options.namespace, "Namespace of resource")
+	cmd.Flags().DurationVarP(&options.wait, "wait", "w", options.wait, "Time allowed to fetch the proxy metrics")
+	cmd.Flags().StringArrayVarP(&options.metrics, "metric", "m", options.metrics, "Only dump the metrics with this name; a trailing '*' matches the metrics with this prefix (can be repeated)")
+
+	return cmd
+}
+
+func renderProxyMetrics(results []metricsResult, names []string, w io.Writer) {
+	for i, result := range results {
+		fmt.Fprintf(w, "#\n# POD %s (%d of %d)\n#\n", result.pod, i+1, len(results))
+		if result.err != nil {
+			fmt.Fprintf(w, "# ERROR %s\n", result.err)
+			continue
+		}
+
+		metrics, err := filterMetrics(result.metrics, names)
+		if err != nil {
+			fmt.Fprintf(w, "# ERROR %s\n", err)
+			continue
+		}
+		w.Write(metrics)
+	}
+}
+
+// filterMetrics returns the metrics whose names match one of names, sorted by
+// name. A name ending with '*' matches the metrics with this prefix. All the
+// metrics are returned as is when names is empty.
+func filterMetrics(metrics []byte, names []string) ([]byte, error) {
+	if len(names) == 0 {
+		return metrics, nil
+	}
+
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(bytes.NewReader(metrics))
+	if err != nil {
+		return nil, fmt.Errorf("invalid metrics: %s", err)
+	}
+
+	var matching []string
+	for family := range families {
+		if matchesMetricName(family, names) {
+			matching = append(matching, family)
+		}
+	}
+	sort.Strings(matching)
+
+	var buf bytes.Buffer
+	for _, family := range matching {
+		if _, err := expfmt.MetricFamilyToText(&buf, families[family]); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+func matchesMetricName(metric string, names []string) bool {
+	for _, name := range names {
+		if prefix := strings.TrimSuffix(name, "*"); prefix != name {
+			if strings.HasPrefix(metric, prefix) {
+				return true
+			}
+		} else if metric == name {
+			return true
+		}
+	}
+	return false
+}
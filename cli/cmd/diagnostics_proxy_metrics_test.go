@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestFilterMetrics(t *testing.T) {
+	metrics := []byte(`# HELP request_total Total count of HTTP requests.
+# TYPE request_total counter
+request_total{direction="inbound"} 3
+# HELP tcp_open_total Total count of opened connections
+# TYPE tcp_open_total counter
+tcp_open_total{direction="inbound"} 2
+# HELP tcp_open_connections Number of currently-open connections
+# TYPE tcp_open_connections gauge
+tcp_open_connections{direction="inbound"} 1
+# HELP process_cpu_seconds_total Total user and system CPU time spent in seconds.
+# TYPE process_cpu_seconds_total counter
+process_cpu_seconds_total 36
+`)
+
+	testCases := []struct {
+		names    []string
+		expected string
+	}{
+		{
+			names:    nil,
+			expected: string(metrics),
+		},
+		{
+			names: []string{"tcp_*", "request_total"},
+			expected: `# HELP request_total Total count of HTTP requests.
+# TYPE request_total counter
+request_total{direction="inbound"} 3
+# HELP tcp_open_connections Number of currently-open connections
+# TYPE tcp_open_connections gauge
+tcp_open_connections{direction="inbound"} 1
+# HELP tcp_open_total Total count of opened connections
+# TYPE tcp_open_total counter
+tcp_open_total{direction="inbound"} 2
+`,
+		},
+		{
+			names:    []string{"request"},
+			expected: "",
+		},
+	}
+
+	for i, tc := range testCases {
+		tc := tc // pin
+		filtered, err := filterMetrics(metrics, tc.names)
+		if err != nil {
+			t.Fatalf("test case %d: unexpected error: %s", i, err)
+		}
+		if string(filtered) != tc.expected {
+			t.Errorf("test case %d: expected:\n%s\ngot:\n%s", i, tc.expected, filtered)
+		}
+	}
+}
+
+func TestRenderProxyMetrics(t *testing.T) {
+	results := []metricsResult{
+		{pod: "web-1", metrics: []byte("request_total 3\nresponse_total 3\n")},
+		{pod: "web-2", err: errors.New("pod not running: web-2")},
+	}
+
+	var buf bytes.Buffer
+	renderProxyMetrics(results, []string{"request_total"}, &buf)
+
+	expected := `#
+# POD web-1 (1 of 2)
+#
+# TYPE request_total untyped
+request_total 3
+#
+# POD web-2 (2 of 2)
+#
+# ERROR pod not running: web-2
+`
+	if buf.String() != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, buf.String())
+	}
+}
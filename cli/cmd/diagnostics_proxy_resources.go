@@ -0,0 +1,203 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/linkerd/linkerd2/pkg/inject"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/prometheus/common/expfmt"
+	"github.com/spf13/cobra"
+	k8sResource "k8s.io/apimachinery/pkg/api/resource"
+)
+
+const (
+	proxyCPUSecondsMetric     = "process_cpu_seconds_total"
+	proxyStartTimeMetric      = "process_start_time_seconds"
+	proxyResidentMemoryMetric = "process_resident_memory_bytes"
+
+	// proxyMemoryLimitHeadroom is the factor between the memory limit of the
+	// recommended profile and the observed resident memory, leaving room for
+	// bursts of traffic
+	proxyMemoryLimitHeadroom = 2
+)
+
+type proxyResourcesOptions struct {
+	namespace string
+	wait      time.Duration
+}
+
+// proxyResourceUsage is the resource usage of the proxy of a pod: its average
+// CPU usage since it started, in millicores, and its resident memory, in bytes
+type proxyResourceUsage struct {
+	pod    string
+	cpu    int64
+	memory int64
+	err    error
+}
+
+func newCmdDiagnosticsProxyResources() *cobra.Command {
+	options := &proxyResourcesOptions{
+		namespace: defaultNamespace,
+		wait:      30 * time.Second,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "proxy-resources [flags] (RESOURCE)",
+		Short: "Recommend a proxy resources profile from the resource usage of the proxies",
+		Long: `Recommend a proxy resources profile from the resource usage of the proxies.
+
+  This command initiates a port-forward to the pods of the given resource, and
+  queries the /metrics endpoint of their proxies for their average CPU usage
+  since they started and their resident memory. It then recommends the
+  smallest proxy resources profile covering the largest usage, to be set with
+  the config.linkerd.io/proxy-resources-profile annotation.
+
+  The RESOURCE argument specifies the target resource (TYPE/NAME), like for
+  'linkerd metrics'.`,
+		Example: `  # Recommend a proxy resources profile for the web deployment.
+  linkerd diagnostics proxy-resources -n emojivoto deploy/web`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			k8sAPI, err := k8s.NewAPI(kubeconfigPath, kubeContext, impersonate, impersonateGroup, 0)
+			if err != nil {
+				return err
+			}
+
+			pods, err := getPodsFor(k8sAPI, options.namespace, args[0])
+			if err != nil {
+				return err
+			}
+
+			results := getMetrics(k8sAPI, pods, k8s.ProxyAdminPortName, options.wait, verbose)
+			now := time.Now()
+			usages := make([]proxyResourceUsage, len(results))
+			for i, result := range results {
+				usages[i] = proxyResourceUsage{pod: result.pod, err: result.err}
+				if result.err == nil {
+					usages[i].cpu, usages[i].memory, usages[i].err = parseProxyResourceUsage(result.metrics, now)
+				}
+			}
+
+			renderProxyResources(usages, os.Stdout)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.namespace, "namespace", "n", options.namespace, "Namespace of resource")
+	cmd.Flags().DurationVarP(&options.wait, "wait", "w", options.wait, "Time allowed to fetch the proxy metrics")
+
+	return cmd
+}
+
+// parseProxyResourceUsage returns the average CPU usage in millicores since
+// the proxy started, and its resident memory in bytes, from its metrics
+func parseProxyResourceUsage(metrics []byte, now time.Time) (int64, int64, error) {
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(bytes.NewReader(metrics))
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid metrics: %s", err)
+	}
+
+	value := func(name string) (float64, error) {
+		family, ok := families[name]
+		if !ok || len(family.GetMetric()) == 0 {
+			return 0, fmt.Errorf("missing %s metric", name)
+		}
+		m := family.GetMetric()[0]
+		if m.GetCounter() != nil {
+			return m.GetCounter().GetValue(), nil
+		}
+		return m.GetGauge().GetValue(), nil
+	}
+
+	cpuSeconds, err := value(proxyCPUSecondsMetric)
+	if err != nil {
+		return 0, 0, err
+	}
+	startTime, err := value(proxyStartTimeMetric)
+	if err != nil {
+		return 0, 0, err
+	}
+	memory, err := value(proxyResidentMemoryMetric)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	uptime := float64(now.UnixNano())/float64(time.Second) - startTime
+	if uptime <= 0 {
+		return 0, 0, errors.New("the proxy just started")
+	}
+	return int64(1000 * cpuSeconds / uptime), int64(memory), nil
+}
+
+// recommendProxyResourcesProfile returns the smallest proxy resources profile
+// whose requests cover the given CPU usage in millicores and resident memory
+// in bytes, and whose memory limit leaves enough headroom. Returns an empty
+// string if none does.
+func recommendProxyResourcesProfile(cpu, memory int64) string {
+	for _, name := range inject.ProxyResourceProfileNames {
+		profile := inject.ProxyResourceProfiles[name]
+		cpuRequest := k8sResource.MustParse(profile.CPU.Request)
+		memoryRequest := k8sResource.MustParse(profile.Memory.Request)
+		memoryLimit := k8sResource.MustParse(profile.Memory.Limit)
+		if cpuRequest.MilliValue() >= cpu && memoryRequest.Value() >= memory &&
+			memoryLimit.Value() >= proxyMemoryLimitHeadroom*memory {
+			return name
+		}
+	}
+	return ""
+}
+
+func renderProxyResources(usages []proxyResourceUsage, w io.Writer) {
+	var maxCPU, maxMemory int64
+	measured := 0
+	t := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(t, "POD\tCPU\tMEMORY")
+	for _, u := range usages {
+		if u.err != nil {
+			fmt.Fprintf(t, "%s\t-\t-\t(%s)\n", u.pod, u.err)
+			continue
+		}
+		measured++
+		if u.cpu > maxCPU {
+			maxCPU = u.cpu
+		}
+		if u.memory > maxMemory {
+			maxMemory = u.memory
+		}
+		fmt.Fprintf(t, "%s\t%dm\t%s\n", u.pod, u.cpu, formatMebibytes(u.memory))
+	}
+	t.Flush()
+
+	fmt.Fprintln(w)
+	if measured == 0 {
+		fmt.Fprintln(w, "No proxy metrics found, can't recommend a proxy resources profile")
+		return
+	}
+
+	name := recommendProxyResourcesProfile(maxCPU, maxMemory)
+	if name == "" {
+		fmt.Fprintf(w, "No proxy resources profile fits the usage of the proxies, consider setting their resources explicitly, e.g.:\n")
+		fmt.Fprintf(w, "  --proxy-cpu-request=%dm --proxy-memory-request=%s --proxy-memory-limit=%s\n",
+			maxCPU, formatMebibytes(maxMemory), formatMebibytes(proxyMemoryLimitHeadroom*maxMemory))
+		return
+	}
+	profile := inject.ProxyResourceProfiles[name]
+	fmt.Fprintf(w, "Recommended proxy resources profile: %s\n", name)
+	fmt.Fprintf(w, "  cpu: request %s, limit %s\n", profile.CPU.Request, profile.CPU.Limit)
+	fmt.Fprintf(w, "  memory: request %s, limit %s\n", profile.Memory.Request, profile.Memory.Limit)
+	fmt.Fprintf(w, "Set it with the \"%s: %s\" annotation on the pod template, or the --proxy-resources-profile=%s flag\n",
+		k8s.ProxyResourcesProfileAnnotation, name, name)
+}
+
+// formatMebibytes formats bytes as mebibytes, rounded up
+func formatMebibytes(bytes int64) string {
+	const mebibyte = 1 << 20
+	return fmt.Sprintf("%dMi", (bytes+mebibyte-1)/mebibyte)
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseProxyResourceUsage(t *testing.T) {
+	metrics := []byte(`# HELP process_cpu_seconds_total Total user and system CPU time spent in seconds.
+# TYPE process_cpu_seconds_total counter
+process_cpu_seconds_total 36
+# HELP process_start_time_seconds Time that this process started, in seconds since the Unix epoch.
+# TYPE process_start_time_seconds gauge
+process_start_time_seconds 1600000000
+# HELP process_resident_memory_bytes Resident memory size in bytes.
+# TYPE process_resident_memory_bytes gauge
+process_resident_memory_bytes 15728640
+`)
+
+	cpu, memory, err := parseProxyResourceUsage(metrics, time.Unix(1600003600, 0))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if cpu != 10 {
+		t.Errorf("Expected 10 millicores, got %d", cpu)
+	}
+	if memory != 15728640 {
+		t.Errorf("Expected 15728640 bytes, got %d", memory)
+	}
+
+	if _, _, err := parseProxyResourceUsage([]byte("process_cpu_seconds_total 36\n"), time.Now()); err == nil {
+		t.Fatal("Expected an error for missing metrics, got nothing")
+	}
+}
+
+func TestRecommendProxyResourcesProfile(t *testing.T) {
+	testCases := []struct {
+		cpu      int64
+		memory   int64
+		expected string
+	}{
+		{5, 10 << 20, "small"},
+		{5, 40 << 20, "medium"},
+		{200, 10 << 20, "large"},
+		{400, 300 << 20, ""},
+		{3000, 10 << 20, ""},
+	}
+	for _, tc := range testCases {
+		if actual := recommendProxyResourcesProfile(tc.cpu, tc.memory); actual != tc.expected {
+			t.Errorf("Expected %q for %dm and %d bytes, got %q", tc.expected, tc.cpu, tc.memory, actual)
+		}
+	}
+}
+
+func TestRenderProxyResources(t *testing.T) {
+	usages := []proxyResourceUsage{
+		{pod: "web-1", cpu: 4, memory: 12 << 20},
+		{pod: "web-2", cpu: 60, memory: 30 << 20},
+		{pod: "web-3", err: errors.New("pod not running: web-3")},
+	}
+
+	var buf bytes.Buffer
+	renderProxyResources(usages, &buf)
+	expected := `POD    CPU  MEMORY
+web-1  4m   12Mi
+web-2  60m  30Mi
+web-3  -    -  (pod not running: web-3)
+
+Recommended proxy resources profile: medium
+  cpu: request 100m, limit 1
+  memory: request 64Mi, limit 250Mi
+Set it with the "config.linkerd.io/proxy-resources-profile: medium" annotation on the pod template, or the --proxy-resources-profile=medium flag
+`
+	if buf.String() != expected {
+		t.Fatalf("Expected:\n%s\ngot:\n%s", expected, buf.String())
+	}
+}
@@ -155,6 +155,10 @@ func generateAnnotationsDocs() []annotationDoc {
 			Name:        k8s.ProxyIgnoreOutboundPortsAnnotation,
 			Description: "Outbound ports that should skip the proxy",
 		},
+		{
+			Name:        k8s.ProxyOpaquePortsAnnotation,
+			Description: "Ports of protocols that can't be detected, like server-speaks-first ones, whose inbound and outbound traffic is forwarded by the proxy without protocol detection, in addition to the default 25, 443, 587, 3306, 5432 and 11211",
+		},
 		{
 			Name:        k8s.ProxyInboundPortAnnotation,
 			Description: "Proxy port to use for inbound traffic",
@@ -167,6 +171,10 @@ func generateAnnotationsDocs() []annotationDoc {
 			Name:        k8s.ProxyOutboundPortAnnotation,
 			Description: "Proxy port to use for outbound traffic",
 		},
+		{
+			Name:        k8s.ProxyResourcesProfileAnnotation,
+			Description: "Sizing profile of the proxy resources (small, medium or large), overridden by the proxy resource annotations",
+		},
 		{
 			Name:        k8s.ProxyCPURequestAnnotation,
 			Description: "Amount of CPU units that the proxy sidecar requests",
@@ -223,6 +231,10 @@ func generateAnnotationsDocs() []annotationDoc {
 			Name:        k8s.ProxyTraceCollectorSvcAccountAnnotation,
 			Description: "The trace collector's service account name. E.g., `tracing-service-account`. If not provided, it will be defaulted to `default`.",
 		},
+		{
+			Name:        k8s.ProxyEnvAnnotation,
+			Description: "Extra environment variables of the proxy, as a YAML or JSON map of names to values, e.g. `{\"LINKERD2_PROXY_FEATURE\": \"a,b\"}`, to toggle experimental proxy features",
+		},
 		{
 			Name:        k8s.ProxyWaitBeforeExitSecondsAnnotation,
 			Description: "The proxy sidecar will stay alive for at least the given period before receiving SIGTERM signal from Kubernetes but no longer than pod's `terminationGracePeriodSeconds`. If not provided, it will be defaulted to `0`",
@@ -0,0 +1,295 @@
+package cmd
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/linkerd/linkerd2/pkg/version"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// doctorBundleDir is the directory holding the files of a doctor bundle
+const doctorBundleDir = "linkerd-doctor"
+
+var (
+	privateKeyPEM = regexp.MustCompile(`(?s)-----BEGIN ([A-Z ]*)PRIVATE KEY-----.*?-----END [A-Z ]*PRIVATE KEY-----`)
+	bearerToken   = regexp.MustCompile(`(?i)(bearer\s+)[a-z0-9\-._~+/]+=*`)
+)
+
+type doctorOptions struct {
+	output    string
+	namespace string
+	selector  string
+	since     time.Duration
+	wait      time.Duration
+}
+
+func newDoctorOptions() *doctorOptions {
+	return &doctorOptions{
+		output:    fmt.Sprintf("linkerd-doctor-%s.tar.gz", time.Now().Format("20060102-150405")),
+		namespace: "",
+		selector:  "",
+		since:     time.Hour,
+		wait:      30 * time.Second,
+	}
+}
+
+// doctorBundle holds the files of a diagnostics bundle, and the errors that
+// prevented collecting some of them
+type doctorBundle struct {
+	files map[string][]byte
+	errs  []string
+}
+
+func newDoctorBundle() *doctorBundle {
+	return &doctorBundle{files: map[string][]byte{}}
+}
+
+// add adds a file to the bundle, sanitized
+func (b *doctorBundle) add(name string, content []byte) {
+	b.files[name] = sanitize(content)
+}
+
+func (b *doctorBundle) addYAML(name string, obj interface{}) {
+	content, err := yaml.Marshal(obj)
+	if err != nil {
+		b.addErr(name, err)
+		return
+	}
+	b.add(name, content)
+}
+
+func (b *doctorBundle) addErr(what string, err error) {
+	b.errs = append(b.errs, fmt.Sprintf("%s: %s", what, err))
+}
+
+// write writes the bundle as a tar.gz archive, whose files are under the
+// linkerd-doctor directory
+func (b *doctorBundle) write(w io.Writer) error {
+	files := b.files
+	if len(b.errs) > 0 {
+		files = map[string][]byte{"errors.txt": []byte(fmt.Sprintf("%s\n", strings.Join(b.errs, "\n")))}
+		for name, content := range b.files {
+			files[name] = content
+		}
+	}
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	now := time.Now()
+	for _, name := range names {
+		header := &tar.Header{
+			Name:    path.Join(doctorBundleDir, name),
+			Mode:    0644,
+			Size:    int64(len(files[name])),
+			ModTime: now,
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if _, err := tw.Write(files[name]); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// sanitize redacts the private keys and bearer tokens found in content
+func sanitize(content []byte) []byte {
+	content = privateKeyPEM.ReplaceAll(content, []byte("-----BEGIN ${1}PRIVATE KEY-----\n[REDACTED]\n-----END ${1}PRIVATE KEY-----"))
+	return bearerToken.ReplaceAll(content, []byte("${1}[REDACTED]"))
+}
+
+func newCmdDoctor() *cobra.Command {
+	options := newDoctorOptions()
+
+	cmd := &cobra.Command{
+		Use:   "doctor [flags]",
+		Args:  cobra.NoArgs,
+		Short: "Collect a diagnostics bundle to attach to support issues",
+		Long: `Collect a diagnostics bundle to attach to support issues.
+
+The doctor command gathers in a single tar.gz archive:
+  * the output of "linkerd check"
+  * the linkerd-config ConfigMap
+  * the control plane pods, their logs, their metrics and the events of the
+    control plane namespace
+  * the proxy logs and metrics of the pods selected by --namespace and
+    --selector, and the events of their namespace
+
+The private keys and bearer tokens found in these files are redacted, and no
+Secret is collected.`,
+		Example: `  # Collect the control plane diagnostics
+  linkerd doctor
+
+  # Also collect the proxy logs and metrics of the "web" pods of the "emojivoto" namespace
+  linkerd doctor --namespace emojivoto --selector app=web -o web-issue.tar.gz`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if options.selector != "" && options.namespace == "" {
+				return fmt.Errorf("--selector requires --namespace")
+			}
+
+			k8sAPI, err := k8s.NewAPI(kubeconfigPath, kubeContext, impersonate, impersonateGroup, 0)
+			if err != nil {
+				return err
+			}
+
+			bundle := newDoctorBundle()
+			collectDoctorBundle(bundle, k8sAPI, options)
+
+			f, err := os.Create(options.output)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			if err := bundle.write(f); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(stderr, "Wrote the diagnostics bundle to %s (%d files", options.output, len(bundle.files))
+			if len(bundle.errs) > 0 {
+				fmt.Fprintf(stderr, ", %d errors listed in errors.txt", len(bundle.errs))
+			}
+			fmt.Fprintln(stderr, ")")
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.output, "output", "o", options.output, "Path of the tar.gz archive to write")
+	cmd.Flags().StringVarP(&options.namespace, "namespace", "n", options.namespace, "Namespace of the pods to collect the proxy logs and metrics of (default: none)")
+	cmd.Flags().StringVarP(&options.selector, "selector", "l", options.selector, "Selector (label query) of the pods of --namespace to collect the proxy logs and metrics of (default: all of them)")
+	cmd.Flags().DurationVar(&options.since, "since", options.since, "Only collect the logs newer than this duration")
+	cmd.Flags().DurationVarP(&options.wait, "wait", "w", options.wait, "Time allowed to fetch the metrics")
+
+	return cmd
+}
+
+// collectDoctorBundle adds the diagnostics to the bundle
+func collectDoctorBundle(bundle *doctorBundle, k8sAPI *k8s.KubernetesAPI, options *doctorOptions) {
+	bundle.add("version.txt", []byte(fmt.Sprintf("Client version: %s\n", version.Version)))
+	collectCheckOutput(bundle)
+	collectConfig(bundle, k8sAPI)
+
+	pods, err := k8sAPI.CoreV1().Pods(controlPlaneNamespace).List(metav1.ListOptions{})
+	if err != nil {
+		bundle.addErr("control plane pods", err)
+	} else {
+		bundle.addYAML("control-plane/pods.yaml", pods)
+		collectLogs(bundle, k8sAPI, "control-plane/logs", pods.Items, "", options.since)
+		collectMetrics(bundle, k8sAPI, "control-plane/metrics", pods.Items, adminHTTPPortName, options.wait)
+	}
+	collectEvents(bundle, k8sAPI, "control-plane/events.yaml", controlPlaneNamespace)
+
+	if options.namespace == "" {
+		return
+	}
+	pods, err = k8sAPI.CoreV1().Pods(options.namespace).List(metav1.ListOptions{LabelSelector: options.selector})
+	if err != nil {
+		bundle.addErr("proxies pods", err)
+	} else {
+		dir := path.Join("proxies", options.namespace)
+		collectLogs(bundle, k8sAPI, path.Join(dir, "logs"), pods.Items, k8s.ProxyContainerName, options.since)
+		collectMetrics(bundle, k8sAPI, path.Join(dir, "metrics"), pods.Items, k8s.ProxyAdminPortName, options.wait)
+	}
+	collectEvents(bundle, k8sAPI, path.Join("proxies", options.namespace, "events.yaml"), options.namespace)
+}
+
+func collectCheckOutput(bundle *doctorBundle) {
+	options := newCheckOptions()
+	options.wait = 0
+	hc, err := options.newHealthChecker("")
+	if err != nil {
+		bundle.addErr("check", err)
+		return
+	}
+	var out, errOut bytes.Buffer
+	runChecksJSON(&out, &errOut, hc)
+	if errOut.Len() > 0 {
+		bundle.addErr("check", fmt.Errorf("%s", errOut.String()))
+	}
+	bundle.add("check.json", out.Bytes())
+}
+
+func collectConfig(bundle *doctorBundle, k8sAPI *k8s.KubernetesAPI) {
+	for _, name := range []string{k8s.ConfigConfigMapName, k8s.AddOnsConfigMapName} {
+		cm, err := k8sAPI.CoreV1().ConfigMaps(controlPlaneNamespace).Get(name, metav1.GetOptions{})
+		if err != nil {
+			bundle.addErr(name, err)
+			continue
+		}
+		bundle.addYAML(fmt.Sprintf("%s.yaml", name), cm)
+	}
+}
+
+func collectEvents(bundle *doctorBundle, k8sAPI *k8s.KubernetesAPI, name, namespace string) {
+	events, err := k8sAPI.CoreV1().Events(namespace).List(metav1.ListOptions{})
+	if err != nil {
+		bundle.addErr(name, err)
+		return
+	}
+	sort.Slice(events.Items, func(i, j int) bool {
+		return events.Items[i].LastTimestamp.Before(&events.Items[j].LastTimestamp)
+	})
+	bundle.addYAML(name, events)
+}
+
+// collectLogs adds the logs of the containers of pods, or of their container
+// named container if it's not empty, as <dir>/<pod>/<container>.log
+func collectLogs(bundle *doctorBundle, k8sAPI *k8s.KubernetesAPI, dir string, pods []corev1.Pod, container string, since time.Duration) {
+	sinceSeconds := int64(since.Seconds())
+	for _, pod := range pods {
+		for _, c := range append(append([]corev1.Container{}, pod.Spec.InitContainers...), pod.Spec.Containers...) {
+			if container != "" && c.Name != container {
+				continue
+			}
+			name := path.Join(dir, pod.Name, fmt.Sprintf("%s.log", c.Name))
+			logs, err := k8sAPI.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{
+				Container:    c.Name,
+				SinceSeconds: &sinceSeconds,
+			}).DoRaw()
+			if err != nil {
+				bundle.addErr(name, err)
+				continue
+			}
+			bundle.add(name, logs)
+		}
+	}
+}
+
+// collectMetrics adds the metrics of the containers of pods exposing them on
+// portName, as <dir>/<pod>-<container>.txt
+func collectMetrics(bundle *doctorBundle, k8sAPI *k8s.KubernetesAPI, dir string, pods []corev1.Pod, portName string, wait time.Duration) {
+	for _, result := range getMetrics(k8sAPI, pods, portName, wait, false) {
+		name := path.Join(dir, fmt.Sprintf("%s-%s.txt", result.pod, result.container))
+		if result.container == "" {
+			name = path.Join(dir, fmt.Sprintf("%s.txt", result.pod))
+		}
+		if result.err != nil {
+			bundle.addErr(name, result.err)
+			continue
+		}
+		bundle.add(name, result.metrics)
+	}
+}
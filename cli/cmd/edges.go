@@ -19,6 +19,7 @@ type edgesOptions struct {
 	namespace     string
 	outputFormat  string
 	allNamespaces bool
+	timeWindow    string
 }
 
 func newEdgesOptions() *edgesOptions {
@@ -26,6 +27,7 @@ func newEdgesOptions() *edgesOptions {
 		namespace:     defaultNamespace,
 		outputFormat:  tableOutput,
 		allNamespaces: false,
+		timeWindow:    "1m",
 	}
 }
 
@@ -63,7 +65,19 @@ func newCmdEdges() *cobra.Command {
   * pods
   * replicasets
   * replicationcontrollers
-  * statefulsets`,
+  * statefulsets
+
+  The JSON output (-o json) is a stable interface for scripts: an array with an
+  object per edge, with the fields src, src_namespace, src_kind, dst,
+  dst_namespace, dst_kind, client_id and server_id (the identities as
+  displayed, <serviceaccount>.<namespace>), client_identity and server_identity
+  (the full mTLS identities), no_tls_reason, and tcp_open_connections,
+  tcp_tls_connections (the open connections secured with mTLS),
+  tcp_read_bytes_rate and tcp_write_bytes_rate, which are null for edges
+  without TCP stats.
+
+  Edges are found for both HTTP and non-HTTP TCP traffic. The wide output (-o
+  wide) adds the TCP stats of the edges, as seen by their source.`,
 		Example: `  # Get all edges between pods that either originate from or terminate in the demo namespace.
   linkerd edges po -n test
 
@@ -114,6 +128,7 @@ func newCmdEdges() *cobra.Command {
 	cmd.PersistentFlags().StringVarP(&options.namespace, "namespace", "n", options.namespace, "Namespace of the specified resource")
 	cmd.PersistentFlags().StringVarP(&options.outputFormat, "output", "o", options.outputFormat, "Output format; one of: \"table\" or \"json\" or \"wide\"")
 	cmd.PersistentFlags().BoolVarP(&options.allNamespaces, "all-namespaces", "A", options.allNamespaces, "If present, returns edges across all namespaces, ignoring the \"--namespace\" flag")
+	cmd.PersistentFlags().StringVarP(&options.timeWindow, "time-window", "t", options.timeWindow, "Window of the TCP stats (for example: \"15s\", \"1m\", \"10m\", \"1h\")")
 	return cmd
 }
 
@@ -155,6 +170,7 @@ func buildEdgesRequests(resources []string, options *edgesOptions) ([]*pb.EdgesR
 			ResourceType:  target.Type,
 			Namespace:     options.namespace,
 			AllNamespaces: options.allNamespaces,
+			TimeWindow:    options.timeWindow,
 		}
 
 		req, err := util.BuildEdgesRequest(requestParams)
@@ -195,13 +211,25 @@ func renderEdgeStats(rows []*pb.Edge, options *edgesOptions) string {
 }
 
 type edgeRow struct {
-	src          string
-	srcNamespace string
-	dst          string
-	dstNamespace string
-	client       string
-	server       string
-	msg          string
+	src            string
+	srcNamespace   string
+	srcKind        string
+	dst            string
+	dstNamespace   string
+	dstKind        string
+	client         string
+	server         string
+	clientIdentity string
+	serverIdentity string
+	msg            string
+	tcpStats       *edgeTCPStats
+}
+
+type edgeTCPStats struct {
+	openConnections uint64
+	tlsConnections  uint64
+	readBytes       float64
+	writeBytes      float64
 }
 
 const (
@@ -212,6 +240,9 @@ const (
 	clientHeader       = "CLIENT_ID"
 	serverHeader       = "SERVER_ID"
 	msgHeader          = "SECURED"
+	tcpConnHeader      = "TCP_CONN"
+	readBytesHeader    = "READ_BYTES/SEC"
+	writeBytesHeader   = "WRITE_BYTES/SEC"
 )
 
 func writeEdgesToBuffer(rows []*pb.Edge, w *tabwriter.Writer, options *edgesOptions) {
@@ -242,13 +273,25 @@ func writeEdgesToBuffer(rows []*pb.Edge, w *tabwriter.Writer, options *edgesOpti
 			}
 
 			row := edgeRow{
-				client:       clientID,
-				server:       serverID,
-				msg:          msg,
-				src:          r.Src.Name,
-				srcNamespace: r.Src.Namespace,
-				dst:          r.Dst.Name,
-				dstNamespace: r.Dst.Namespace,
+				client:         clientID,
+				server:         serverID,
+				clientIdentity: r.ClientId,
+				serverIdentity: r.ServerId,
+				msg:            msg,
+				src:            r.Src.Name,
+				srcNamespace:   r.Src.Namespace,
+				srcKind:        r.Src.Type,
+				dst:            r.Dst.Name,
+				dstNamespace:   r.Dst.Namespace,
+				dstKind:        r.Dst.Type,
+			}
+			if r.TcpStats != nil {
+				row.tcpStats = &edgeTCPStats{
+					openConnections: r.TcpStats.GetOpenConnections(),
+					tlsConnections:  r.TcpStats.GetTlsConnections(),
+					readBytes:       getByteRate(r.TcpStats.GetReadBytesTotal(), options.timeWindow),
+					writeBytes:      getByteRate(r.TcpStats.GetWriteBytesTotal(), options.timeWindow),
+				}
 			}
 
 			edgeRows = append(edgeRows, row)
@@ -313,7 +356,13 @@ func printEdgeTable(edgeRows []edgeRow, w *tabwriter.Writer, maxSrcLength, maxSr
 	}
 
 	if outputFormat == wideOutput {
-		headers = append(headers, fmt.Sprintf(clientTemplate, clientHeader), fmt.Sprintf(serverTemplate, serverHeader))
+		headers = append(headers,
+			fmt.Sprintf(clientTemplate, clientHeader),
+			fmt.Sprintf(serverTemplate, serverHeader),
+			tcpConnHeader,
+			readBytesHeader,
+			writeBytesHeader,
+		)
 	}
 
 	headers = append(headers, fmt.Sprintf(msgTemplate, msgHeader)+"\t")
@@ -332,6 +381,13 @@ func printEdgeTable(edgeRows []edgeRow, w *tabwriter.Writer, maxSrcLength, maxSr
 		if outputFormat == wideOutput {
 			templateString += fmt.Sprintf("%s\t%s\t", clientTemplate, serverTemplate)
 			values = append(values, row.client, row.server)
+
+			if row.tcpStats != nil {
+				templateString += "%d\t%.1fB/s\t%.1fB/s\t"
+				values = append(values, row.tcpStats.openConnections, row.tcpStats.readBytes, row.tcpStats.writeBytes)
+			} else {
+				templateString += "-\t-\t-\t"
+			}
 		}
 
 		templateString += fmt.Sprintf("%s\t\n", msgTemplate)
@@ -355,14 +411,24 @@ func renderEdges(buffer bytes.Buffer, options *edgesOptions) string {
 	return out
 }
 
+// edgesJSONStats is an entry of the JSON output of the edges command
 type edgesJSONStats struct {
-	Src          string `json:"src"`
-	SrcNamespace string `json:"src_namespace"`
-	Dst          string `json:"dst"`
-	DstNamespace string `json:"dst_namespace"`
-	Client       string `json:"client_id"`
-	Server       string `json:"server_id"`
-	Msg          string `json:"no_tls_reason"`
+	Src            string `json:"src"`
+	SrcNamespace   string `json:"src_namespace"`
+	SrcKind        string `json:"src_kind"`
+	Dst            string `json:"dst"`
+	DstNamespace   string `json:"dst_namespace"`
+	DstKind        string `json:"dst_kind"`
+	Client         string `json:"client_id"`
+	Server         string `json:"server_id"`
+	ClientIdentity string `json:"client_identity"`
+	ServerIdentity string `json:"server_identity"`
+	Msg            string `json:"no_tls_reason"`
+
+	TCPConnections    *uint64  `json:"tcp_open_connections"`
+	TCPTLSConnections *uint64  `json:"tcp_tls_connections"`
+	TCPReadBytes      *float64 `json:"tcp_read_bytes_rate"`
+	TCPWriteBytes     *float64 `json:"tcp_write_bytes_rate"`
 }
 
 func printEdgesJSON(edgeRows []edgeRow, w *tabwriter.Writer) {
@@ -371,13 +437,23 @@ func printEdgesJSON(edgeRows []edgeRow, w *tabwriter.Writer) {
 
 	for _, row := range edgeRows {
 		entry := &edgesJSONStats{
-			Src:          row.src,
-			SrcNamespace: row.srcNamespace,
-			Dst:          row.dst,
-			DstNamespace: row.dstNamespace,
-			Client:       row.client,
-			Server:       row.server,
-			Msg:          row.msg}
+			Src:            row.src,
+			SrcNamespace:   row.srcNamespace,
+			SrcKind:        row.srcKind,
+			Dst:            row.dst,
+			DstNamespace:   row.dstNamespace,
+			DstKind:        row.dstKind,
+			Client:         row.client,
+			Server:         row.server,
+			ClientIdentity: row.clientIdentity,
+			ServerIdentity: row.serverIdentity,
+			Msg:            row.msg}
+		if row.tcpStats != nil {
+			entry.TCPConnections = &row.tcpStats.openConnections
+			entry.TCPTLSConnections = &row.tcpStats.tlsConnections
+			entry.TCPReadBytes = &row.tcpStats.readBytes
+			entry.TCPWriteBytes = &row.tcpStats.writeBytes
+		}
 		entries = append(entries, entry)
 	}
 
@@ -0,0 +1,283 @@
+package cmd
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type identityOptions struct {
+	namespace    string
+	outputFormat string
+}
+
+// identityRow describes the certificate currently served by a pod's proxy
+type identityRow struct {
+	Namespace string `json:"namespace"`
+	Pod       string `json:"pod"`
+	Identity  string `json:"identity"`
+	Serial    string `json:"serial,omitempty"`
+	NotBefore string `json:"notBefore,omitempty"`
+	NotAfter  string `json:"notAfter,omitempty"`
+	Issuer    string `json:"issuer,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+func newIdentityOptions() *identityOptions {
+	return &identityOptions{
+		namespace:    defaultNamespace,
+		outputFormat: tableOutput,
+	}
+}
+
+// validate performs all validation on the command-line options.
+// It returns the first error encountered, or `nil` if the options are valid.
+func (o *identityOptions) validate() error {
+	if o.outputFormat == tableOutput || o.outputFormat == jsonOutput {
+		return nil
+	}
+
+	return fmt.Errorf("--output currently only supports %s and %s", tableOutput, jsonOutput)
+}
+
+func newCmdIdentity() *cobra.Command {
+	options := newIdentityOptions()
+
+	cmd := &cobra.Command{
+		Use:   "identity [flags] [RESOURCE]",
+		Short: "List the certificates currently issued to Linkerd proxies",
+		Long: `List the certificates currently issued to Linkerd proxies.
+
+  This command initiates a port-forward to each meshed pod of the given
+  resource, or of the whole namespace when no resource is given, and reports
+  the identity, serial number and validity of the certificate presented by its
+  proxy.
+
+  The RESOURCE argument specifies the target resource to query:
+  (TYPE/NAME)
+
+  Examples:
+  * deploy/my-deploy
+  * po/mypod1
+  * sts/my-statefulset`,
+		Example: `  # List the certificates of all the meshed pods in the emojivoto namespace.
+  linkerd identity -n emojivoto
+
+  # List the certificates of the pods of the web deployment as JSON.
+  linkerd identity -n emojivoto deploy/web -o json`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := options.validate(); err != nil {
+				return err
+			}
+
+			k8sAPI, err := k8s.NewAPI(kubeconfigPath, kubeContext, impersonate, impersonateGroup, 0)
+			if err != nil {
+				return err
+			}
+
+			var pods []corev1.Pod
+			if len(args) == 0 {
+				podList, err := k8sAPI.CoreV1().Pods(options.namespace).List(metav1.ListOptions{})
+				if err != nil {
+					return err
+				}
+				pods = podList.Items
+			} else {
+				pods, err = getPodsFor(k8sAPI, options.namespace, args[0])
+				if err != nil {
+					return err
+				}
+			}
+
+			var rows []identityRow
+			for i := range pods {
+				pod := pods[i]
+				if !k8s.IsMeshed(&pod, controlPlaneNamespace) {
+					continue
+				}
+				rows = append(rows, getIdentityRow(k8sAPI, pod))
+			}
+
+			if len(rows) == 0 {
+				return errors.New("no meshed pods found")
+			}
+
+			_, err = fmt.Print(renderIdentities(rows, options.outputFormat))
+			return err
+		},
+	}
+
+	cmd.PersistentFlags().StringVarP(&options.namespace, "namespace", "n", options.namespace, "Namespace of resource")
+	cmd.PersistentFlags().StringVarP(&options.outputFormat, "output", "o", options.outputFormat, fmt.Sprintf("Output format; one of: \"%s\" or \"%s\"", tableOutput, jsonOutput))
+
+	return cmd
+}
+
+// getIdentityRow fetches the certificate served by the proxy of a pod over a
+// port-forward to its admin port
+func getIdentityRow(k8sAPI *k8s.KubernetesAPI, pod corev1.Pod) identityRow {
+	row := identityRow{
+		Namespace: pod.GetNamespace(),
+		Pod:       pod.GetName(),
+	}
+
+	container := findProxyContainer(pod)
+	if container == nil {
+		row.Error = fmt.Sprintf("no %s container found", k8s.ProxyContainerName)
+		return row
+	}
+
+	identity, err := proxyIdentity(pod, *container)
+	if err != nil {
+		row.Error = err.Error()
+		return row
+	}
+	row.Identity = identity
+
+	crt, err := getProxyCertificate(k8sAPI, pod, *container, identity)
+	if err != nil {
+		row.Error = err.Error()
+		return row
+	}
+	row.setCertificate(crt)
+
+	return row
+}
+
+// findProxyContainer returns the proxy container of the pod, which is an init
+// container when the proxy is injected as a native sidecar, or nil if the pod
+// has none
+func findProxyContainer(pod corev1.Pod) *corev1.Container {
+	for _, containers := range [][]corev1.Container{pod.Spec.InitContainers, pod.Spec.Containers} {
+		for i := range containers {
+			if containers[i].Name == k8s.ProxyContainerName {
+				return &containers[i]
+			}
+		}
+	}
+	return nil
+}
+
+// proxyIdentity returns the local identity of the proxy container of a pod,
+// built the same way as the LINKERD2_PROXY_IDENTITY_LOCAL_NAME environment
+// variable
+func proxyIdentity(pod corev1.Pod, container corev1.Container) (string, error) {
+	env := map[string]string{}
+	for _, e := range container.Env {
+		env[e.Name] = e.Value
+	}
+
+	if _, ok := env["LINKERD2_PROXY_IDENTITY_DISABLED"]; ok {
+		return "", errors.New("identity is disabled")
+	}
+
+	for _, name := range []string{"_l5d_ns", "_l5d_trustdomain"} {
+		if _, ok := env[name]; !ok {
+			return "", fmt.Errorf("the proxy container has no %s environment variable", name)
+		}
+	}
+
+	// _pod_sa comes from the downward API, so it's read from the pod spec
+	sa := pod.Spec.ServiceAccountName
+	if sa == "" {
+		sa = "default"
+	}
+
+	return fmt.Sprintf("%s.%s.serviceaccount.identity.%s.%s", sa, pod.GetNamespace(), env["_l5d_ns"], env["_l5d_trustdomain"]), nil
+}
+
+// getProxyCertificate performs a TLS handshake with the proxy's admin port and
+// returns the leaf certificate it presents. The certificate isn't verified: it
+// is only reported.
+func getProxyCertificate(k8sAPI *k8s.KubernetesAPI, pod corev1.Pod, container corev1.Container, identity string) (*x509.Certificate, error) {
+	portForward, err := k8s.NewContainerMetricsForward(k8sAPI, pod, container, verbose, k8s.ProxyAdminPortName)
+	if err != nil {
+		return nil, err
+	}
+
+	defer portForward.Stop()
+	if err = portForward.Init(); err != nil {
+		return nil, fmt.Errorf("error running port-forward: %s", err)
+	}
+
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+	conn, err := tls.DialWithDialer(dialer, "tcp", portForward.Address(), &tls.Config{
+		ServerName: identity,
+		// #nosec G402 -- the certificate is only reported, not trusted
+		InsecureSkipVerify: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("TLS handshake failed: %s", err)
+	}
+	defer conn.Close()
+
+	crts := conn.ConnectionState().PeerCertificates
+	if len(crts) == 0 {
+		return nil, errors.New("the proxy presented no certificate")
+	}
+	return crts[0], nil
+}
+
+func (r *identityRow) setCertificate(crt *x509.Certificate) {
+	if len(crt.DNSNames) > 0 {
+		r.Identity = crt.DNSNames[0]
+	}
+	r.Serial = crt.SerialNumber.String()
+	r.NotBefore = crt.NotBefore.UTC().Format(time.RFC3339)
+	r.NotAfter = crt.NotAfter.UTC().Format(time.RFC3339)
+	r.Issuer = crt.Issuer.CommonName
+}
+
+func renderIdentities(rows []identityRow, outputFormat string) string {
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].Namespace != rows[j].Namespace {
+			return rows[i].Namespace < rows[j].Namespace
+		}
+		return rows[i].Pod < rows[j].Pod
+	})
+
+	var buffer bytes.Buffer
+	if outputFormat == jsonOutput {
+		b, err := json.MarshalIndent(rows, "", "  ")
+		if err != nil {
+			return fmt.Sprintf("Error rendering JSON: %s\n", err)
+		}
+		buffer.Write(b)
+		buffer.WriteString("\n")
+		return buffer.String()
+	}
+
+	w := tabwriter.NewWriter(&buffer, 0, 0, padding, ' ', 0)
+	fmt.Fprintln(w, strings.Join([]string{namespaceHeader, podHeader, "IDENTITY", "SERIAL", "EXPIRES"}, "\t"))
+	for _, row := range rows {
+		if row.Error != "" {
+			fmt.Fprintf(w, "%s\t%s\t%s\t-\tERROR: %s\n", row.Namespace, row.Pod, valueOrDash(row.Identity), row.Error)
+			continue
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", row.Namespace, row.Pod, row.Identity, row.Serial, row.NotAfter)
+	}
+	w.Flush()
+
+	return buffer.String()
+}
+
+func valueOrDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/linkerd/linkerd2/pkg/tls"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestProxyIdentity(t *testing.T) {
+	container := corev1.Container{
+		Name: "linkerd-proxy",
+		Env: []corev1.EnvVar{
+			{Name: "_pod_sa", ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "spec.serviceAccountName"}}},
+			{Name: "_l5d_ns", Value: "linkerd"},
+			{Name: "_l5d_trustdomain", Value: "cluster.local"},
+		},
+	}
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "emojivoto"},
+		Spec: corev1.PodSpec{
+			ServiceAccountName: "web",
+			Containers:         []corev1.Container{container},
+		},
+	}
+
+	identity, err := proxyIdentity(pod, container)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	expected := "web.emojivoto.serviceaccount.identity.linkerd.cluster.local"
+	if identity != expected {
+		t.Fatalf("Expected identity %s, got %s", expected, identity)
+	}
+
+	pod.Spec.ServiceAccountName = ""
+	identity, err = proxyIdentity(pod, container)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	expected = "default.emojivoto.serviceaccount.identity.linkerd.cluster.local"
+	if identity != expected {
+		t.Fatalf("Expected identity %s, got %s", expected, identity)
+	}
+
+	container.Env = append(container.Env, corev1.EnvVar{Name: "LINKERD2_PROXY_IDENTITY_DISABLED", Value: "disabled"})
+	if _, err := proxyIdentity(pod, container); err == nil {
+		t.Fatal("Expected an error for a proxy with identity disabled")
+	}
+}
+
+func TestFindProxyContainer(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		spec     corev1.PodSpec
+		expected bool
+	}{
+		{"sidecar container", corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}, {Name: "linkerd-proxy"}}}, true},
+		{"native sidecar", corev1.PodSpec{InitContainers: []corev1.Container{{Name: "linkerd-init"}, {Name: "linkerd-proxy"}}, Containers: []corev1.Container{{Name: "app"}}}, true},
+		{"unmeshed pod", corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}}, false},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.desc, func(t *testing.T) {
+			container := findProxyContainer(corev1.Pod{Spec: tc.spec})
+			if found := container != nil && container.Name == "linkerd-proxy"; found != tc.expected {
+				t.Fatalf("Expected the proxy to be found: %t, got %v", tc.expected, container)
+			}
+		})
+	}
+}
+
+func TestRenderIdentities(t *testing.T) {
+	ca, err := tls.GenerateRootCA("identity.linkerd.cluster.local", "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	key, err := tls.GenerateKey()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	identity := "web.emojivoto.serviceaccount.identity.linkerd.cluster.local"
+	crt, err := ca.IssueEndEntityCrt(&x509.CertificateRequest{
+		Subject:   pkix.Name{CommonName: identity},
+		DNSNames:  []string{identity},
+		PublicKey: &key.PublicKey,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	ok := identityRow{Namespace: "emojivoto", Pod: "web-1"}
+	ok.setCertificate(crt.Certificate)
+	rows := []identityRow{
+		{Namespace: "emojivoto", Pod: "web-2", Error: "TLS handshake failed"},
+		ok,
+	}
+
+	table := renderIdentities(rows, tableOutput)
+	lines := strings.Split(strings.TrimSpace(table), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("Expected 3 lines, got:\n%s", table)
+	}
+	if !strings.Contains(lines[1], "web-1") || !strings.Contains(lines[1], identity) || !strings.Contains(lines[1], ok.Serial) {
+		t.Fatalf("Unexpected row for web-1: %s", lines[1])
+	}
+	if !strings.Contains(lines[2], "ERROR: TLS handshake failed") {
+		t.Fatalf("Unexpected row for web-2: %s", lines[2])
+	}
+
+	var decoded []identityRow
+	if err := json.Unmarshal([]byte(renderIdentities(rows, jsonOutput)), &decoded); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if len(decoded) != 2 || decoded[0] != ok {
+		t.Fatalf("Unexpected JSON rows: %+v", decoded)
+	}
+	if decoded[0].Issuer != "identity.linkerd.cluster.local" {
+		t.Fatalf("Expected issuer identity.linkerd.cluster.local, got %s", decoded[0].Issuer)
+	}
+}
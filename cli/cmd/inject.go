@@ -40,6 +40,10 @@ type resourceTransformerInject struct {
 	overrideAnnotations map[string]string
 	enableDebugSidecar  bool
 	closeWaitTimeout    time.Duration
+	overrides           injectOverrides
+	podTemplatePaths    map[string]string
+	detectOpaquePorts   bool
+	opaquePorts         *opaquePortsDetector
 }
 
 func runInjectCmd(inputs []io.Reader, errWriter, outWriter io.Writer, transformer *resourceTransformerInject) int {
@@ -48,8 +52,10 @@ func runInjectCmd(inputs []io.Reader, errWriter, outWriter io.Writer, transforme
 
 func newCmdInject() *cobra.Command {
 	options := &proxyConfigOptions{}
-	var manualOption, enableDebugSidecar bool
+	var manualOption, enableDebugSidecar, verify, detectOpaquePorts bool
 	var closeWaitTimeout time.Duration
+	var overridesFile string
+	var podTemplatePaths map[string]string
 
 	cmd := &cobra.Command{
 		Use:   "inject [flags] CONFIG-FILE",
@@ -65,7 +71,29 @@ sub-folders, or coming from stdin.`,
   linkerd inject http://url.to/yml | kubectl apply -f -
 
   # Inject all the resources inside a folder and its sub-folders.
-  linkerd inject <folder> | kubectl apply -f -`,
+  linkerd inject <folder> | kubectl apply -f -
+
+  # Inject all the resources inside a folder, with the proxy configuration of
+  # some of the workloads overridden, e.g. with an overrides.yaml file holding:
+  #   deployment/web:
+  #     logLevel: debug
+  #     resources:
+  #       cpu: {request: 100m, limit: "1"}
+  #   emoji:
+  #     skipOutboundPorts: ["3306"]
+  linkerd inject --overrides overrides.yaml <folder> | kubectl apply -f -
+
+  # Inject the pods of a custom resource, given the path to its pod template.
+  linkerd inject --pod-template-path SparkCluster=/spec/worker/template cluster.yml | kubectl apply -f -
+
+  # Inject all the resources inside a folder, marking the MySQL, SMTP, Redis...
+  # ports of their pods and Services as opaque, so that the proxy forwards their
+  # traffic instead of waiting for the protocol detection to time out.
+  linkerd inject --detect-opaque-ports <folder> | kubectl apply -f -
+
+  # Inject the debug sidecar into a deployment, then capture its traffic.
+  kubectl get deploy/web -o yaml | linkerd inject --debug-sidecar - | kubectl apply -f -
+  kubectl exec deploy/web -c linkerd-debug -- tshark -i any -f "tcp port 8080"`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if len(args) < 1 {
 				return fmt.Errorf("please specify a kubernetes resource file")
@@ -74,6 +102,20 @@ sub-folders, or coming from stdin.`,
 			if err := options.validate(); err != nil {
 				return err
 			}
+			if verify && options.ignoreCluster {
+				return errors.New("--verify cannot be used with --ignore-cluster")
+			}
+			if err := validatePodTemplatePaths(podTemplatePaths); err != nil {
+				return err
+			}
+
+			var overrides injectOverrides
+			if overridesFile != "" {
+				var err error
+				if overrides, err = readInjectOverrides(overridesFile); err != nil {
+					return err
+				}
+			}
 
 			in, err := read(args[0])
 			if err != nil {
@@ -94,7 +136,22 @@ sub-folders, or coming from stdin.`,
 				overrideAnnotations: overrideAnnotations,
 				enableDebugSidecar:  enableDebugSidecar,
 				closeWaitTimeout:    closeWaitTimeout,
+				overrides:           overrides,
+				podTemplatePaths:    podTemplatePaths,
+				detectOpaquePorts:   detectOpaquePorts,
+			}
+			if verify {
+				k8sAPI, err := k8s.NewAPI(kubeconfigPath, kubeContext, impersonate, impersonateGroup, 0)
+				if err != nil {
+					return err
+				}
+				verifier := &injectVerifier{
+					transformer: transformer,
+					dryRun:      newDryRunPodCreator(k8sAPI),
+				}
+				os.Exit(runInjectVerify(in, stderr, stdout, verifier))
 			}
+
 			exitCode := uninjectAndInject(in, stderr, stdout, transformer)
 			os.Exit(exitCode)
 			return nil
@@ -126,8 +183,15 @@ sub-folders, or coming from stdin.`,
 		"Ignore the current Kubernetes cluster when checking for existing cluster configuration (default false)",
 	)
 
+	flags.BoolVar(&verify, "verify", verify,
+		"Send the pods of the resources through the proxy-injector of the cluster with server-side dry-run, and print the differences with the pods injected by the CLI instead of the resources")
+
+	flags.BoolVar(&enableDebugSidecar, "debug-sidecar", enableDebugSidecar,
+		"Inject the debug sidecar (tshark, iproute2, curl) alongside the proxy, for data plane debugging")
+
 	flags.BoolVar(&enableDebugSidecar, "enable-debug-sidecar", enableDebugSidecar,
 		"Inject a debug sidecar for data plane debugging")
+	flags.MarkDeprecated("enable-debug-sidecar", "use --debug-sidecar instead")
 
 	flags.StringVar(&options.traceCollector, "trace-collector", options.traceCollector,
 		"Collector Service address for the proxies to send Trace Data")
@@ -142,21 +206,51 @@ sub-folders, or coming from stdin.`,
 		&closeWaitTimeout, "close-wait-timeout", closeWaitTimeout,
 		"Sets nf_conntrack_tcp_timeout_close_wait")
 
+	flags.StringVar(
+		&overridesFile, "overrides", overridesFile,
+		"YAML file mapping workloads, as <kind>/<name> or <name>, to the overrides of their proxy configuration: logLevel, resources (cpu and memory, request and limit), skipInboundPorts and skipOutboundPorts. They take precedence over the flags.")
+
+	flags.BoolVar(&detectOpaquePorts, "detect-opaque-ports", detectOpaquePorts,
+		fmt.Sprintf("Set the %s annotation of the pods with the ports of known server-speaks-first protocols (MySQL, SMTP, memcached...) found in their container ports and in the Services of the input, unless already set", k8s.ProxyOpaquePortsAnnotation))
+
+	flags.StringToStringVar(
+		&podTemplatePaths, "pod-template-path", podTemplatePaths,
+		"JSON pointer to the pod template of the custom resources of a kind, as <kind>=<path>, e.g. SparkCluster=/spec/worker/template. Argo Rollouts are supported out of the box.")
+
 	cmd.PersistentFlags().AddFlagSet(flags)
 
 	return cmd
 }
 
+// validatePodTemplatePaths checks that the values of --pod-template-path are
+// JSON pointers
+func validatePodTemplatePaths(paths map[string]string) error {
+	for kind, path := range paths {
+		if !strings.HasPrefix(path, "/") || strings.HasSuffix(path, "/") {
+			return fmt.Errorf("--pod-template-path: the path of %s should be a JSON pointer like /spec/template, got %q", kind, path)
+		}
+	}
+	return nil
+}
+
 func uninjectAndInject(inputs []io.Reader, errWriter, outWriter io.Writer, transformer *resourceTransformerInject) int {
 	var out bytes.Buffer
-	if exitCode := runUninjectSilentCmd(inputs, errWriter, &out, transformer.configs); exitCode != 0 {
+	if exitCode := runUninjectSilentCmd(inputs, errWriter, &out, transformer.configs, transformer.podTemplatePaths); exitCode != 0 {
 		return exitCode
 	}
+	if transformer.detectOpaquePorts {
+		detector, err := newOpaquePortsDetector(out.Bytes())
+		if err != nil {
+			fmt.Fprintf(errWriter, "Error detecting opaque ports: %s\n", err)
+			return 1
+		}
+		transformer.opaquePorts = detector
+	}
 	return runInjectCmd([]io.Reader{&out}, errWriter, outWriter, transformer)
 }
 
-func (rt resourceTransformerInject) transform(bytes []byte) ([]byte, []inject.Report, error) {
-	conf := inject.NewResourceConfig(rt.configs, inject.OriginCLI)
+func (rt resourceTransformerInject) parse(bytes []byte, configs *cfg.All) (*inject.ResourceConfig, *inject.Report, error) {
+	conf := inject.NewResourceConfig(configs, inject.OriginCLI).WithPodTemplatePaths(rt.podTemplatePaths)
 
 	if rt.enableDebugSidecar {
 		conf.AppendPodAnnotation(k8s.ProxyEnableDebugAnnotation, "true")
@@ -167,10 +261,26 @@ func (rt resourceTransformerInject) transform(bytes []byte) ([]byte, []inject.Re
 	}
 
 	report, err := conf.ParseMetaAndYAML(bytes)
+	return conf, report, err
+}
+
+func (rt resourceTransformerInject) transform(bytes []byte) ([]byte, []inject.Report, error) {
+	overrideAnnotations := rt.overrideAnnotations
+	conf, report, err := rt.parse(bytes, rt.configs)
 	if err != nil {
 		return nil, nil, err
 	}
 
+	// the workload is parsed again with its own configuration, if it has
+	// overrides
+	if overrides := rt.overrides.forWorkload(report.Kind, report.Name); overrides != nil {
+		var configs *cfg.All
+		configs, overrideAnnotations = overrides.apply(rt.configs, rt.overrideAnnotations)
+		if conf, report, err = rt.parse(bytes, configs); err != nil {
+			return nil, nil, err
+		}
+	}
+
 	if conf.IsControlPlaneComponent() && !rt.injectProxy {
 		return nil, nil, errors.New("--manual must be set when injecting control plane components")
 	}
@@ -178,7 +288,7 @@ func (rt resourceTransformerInject) transform(bytes []byte) ([]byte, []inject.Re
 	reports := []inject.Report{*report}
 
 	if rt.allowNsInject && conf.IsNamespace() {
-		b, err := conf.InjectNamespace(rt.overrideAnnotations)
+		b, err := conf.InjectNamespace(overrideAnnotations)
 		return b, reports, err
 	}
 	if b, _ := report.Injectable(); !b {
@@ -195,8 +305,22 @@ func (rt resourceTransformerInject) transform(bytes []byte) ([]byte, []inject.Re
 		conf.AppendPodAnnotation(k8s.ProxyInjectAnnotation, k8s.ProxyInjectEnabled)
 	}
 
-	if len(rt.overrideAnnotations) > 0 {
-		conf.AppendPodAnnotations(rt.overrideAnnotations)
+	if len(overrideAnnotations) > 0 {
+		conf.AppendPodAnnotations(overrideAnnotations)
+	}
+
+	if rt.opaquePorts != nil {
+		template := conf.GetPodTemplate()
+		if _, ok := template.Annotations[k8s.ProxyOpaquePortsAnnotation]; !ok && overrideAnnotations[k8s.ProxyOpaquePortsAnnotation] == "" {
+			ports, err := rt.opaquePorts.detectWorkload(bytes, template)
+			if err != nil {
+				return nil, nil, err
+			}
+			if ports != "" {
+				conf.AppendPodAnnotation(k8s.ProxyOpaquePortsAnnotation, ports)
+				reports[0].OpaquePorts = ports
+			}
+		}
 	}
 
 	patchJSON, err := conf.GetPatch(rt.injectProxy)
@@ -234,6 +358,8 @@ func (resourceTransformerInject) generateReport(reports []inject.Report, output
 	udp := []string{}
 	injectDisabled := []string{}
 	automountServiceAccountTokenFalse := []string{}
+	opaquePorts := []string{}
+	excludedNodes := []string{}
 	warningsPrinted := verbose
 
 	for _, r := range reports {
@@ -265,6 +391,16 @@ func (resourceTransformerInject) generateReport(reports []inject.Report, output
 			automountServiceAccountTokenFalse = append(automountServiceAccountTokenFalse, r.ResName())
 			warningsPrinted = true
 		}
+
+		if r.WindowsNode || r.ExcludedNode {
+			excludedNodes = append(excludedNodes, r.ResName())
+			warningsPrinted = true
+		}
+
+		if r.OpaquePorts != "" {
+			opaquePorts = append(opaquePorts, fmt.Sprintf("%s (%s)", r.ResName(), r.OpaquePorts))
+			warningsPrinted = true
+		}
 	}
 
 	//
@@ -313,6 +449,14 @@ func (resourceTransformerInject) generateReport(reports []inject.Report, output
 	if len(automountServiceAccountTokenFalse) == 0 && verbose {
 		output.Write([]byte(fmt.Sprintf("%s %s\n", okStatus, automountServiceAccountTokenDesc)))
 	}
+
+	if len(excludedNodes) > 0 {
+		output.Write([]byte(fmt.Sprintf("%s %s scheduled to Windows or excluded nodes, where the proxy isn't injected\n", warnStatus, strings.Join(excludedNodes, ", "))))
+	}
+
+	if len(opaquePorts) > 0 {
+		output.Write([]byte(fmt.Sprintf("%s opaque ports detected in %s\n", okStatus, strings.Join(opaquePorts, ", "))))
+	}
 	//
 	// Summary
 	//
@@ -460,6 +604,26 @@ func (options *proxyConfigOptions) overrideConfigs(configs *cfg.All, overrideAnn
 		overrideAnnotations[k8s.ProxyDisableTapAnnotation] = strconv.FormatBool(true)
 	}
 
+	if options.outboundConnectTimeout != "" {
+		configs.Proxy.OutboundConnectTimeout = options.outboundConnectTimeout
+		overrideAnnotations[k8s.ProxyOutboundConnectTimeout] = options.outboundConnectTimeout
+	}
+	if options.outboundConnectBackoffMin != "" {
+		configs.Proxy.OutboundConnectBackoffMin = options.outboundConnectBackoffMin
+		overrideAnnotations[k8s.ProxyOutboundConnectBackoffMin] = options.outboundConnectBackoffMin
+	}
+	if options.outboundConnectBackoffMax != "" {
+		configs.Proxy.OutboundConnectBackoffMax = options.outboundConnectBackoffMax
+		overrideAnnotations[k8s.ProxyOutboundConnectBackoffMax] = options.outboundConnectBackoffMax
+	}
+	if options.outboundConnectBackoffJitter != "" {
+		configs.Proxy.OutboundConnectBackoffJitter = options.outboundConnectBackoffJitter
+		overrideAnnotations[k8s.ProxyOutboundConnectBackoffJitter] = options.outboundConnectBackoffJitter
+	}
+	if options.excludedNodeSelector != "" {
+		configs.Proxy.ExcludedNodeSelector = options.excludedNodeSelector
+	}
+
 	// keep track of this option because its true/false value results in different
 	// values being assigned to the LINKERD2_PROXY_DESTINATION_PROFILE_SUFFIXES
 	// env var. Its annotation is added only if its value is true.
@@ -468,6 +632,9 @@ func (options *proxyConfigOptions) overrideConfigs(configs *cfg.All, overrideAnn
 		overrideAnnotations[k8s.ProxyEnableExternalProfilesAnnotation] = strconv.FormatBool(true)
 	}
 
+	if options.proxyResourcesProfile != "" {
+		overrideAnnotations[k8s.ProxyResourcesProfileAnnotation] = options.proxyResourcesProfile
+	}
 	if options.proxyCPURequest != "" {
 		configs.Proxy.Resource.RequestCpu = options.proxyCPURequest
 		overrideAnnotations[k8s.ProxyCPURequestAnnotation] = options.proxyCPURequest
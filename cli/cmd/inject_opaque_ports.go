@@ -0,0 +1,174 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	yamlDecoder "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+)
+
+// knownOpaquePorts are the default ports of the protocols whose traffic
+// can't be detected by the proxy, mostly because the server speaks first
+var knownOpaquePorts = map[int32]string{
+	25:    "smtp",
+	587:   "smtp",
+	3306:  "mysql",
+	4444:  "galera",
+	5432:  "postgresql",
+	6379:  "redis",
+	9300:  "elasticsearch",
+	11211: "memcached",
+}
+
+// knownOpaquePortNames are the names of the ports of these protocols, as
+// used in container and service ports
+var knownOpaquePortNames = map[string]bool{
+	"smtp":          true,
+	"mysql":         true,
+	"galera":        true,
+	"postgres":      true,
+	"postgresql":    true,
+	"redis":         true,
+	"elasticsearch": true,
+	"memcache":      true,
+	"memcached":     true,
+}
+
+// opaquePortsDetector detects the opaque ports of the workloads being
+// injected, from their container ports and the Services of the same input
+type opaquePortsDetector struct {
+	services []*corev1.Service
+}
+
+// newOpaquePortsDetector returns a detector knowing about the Services in the
+// YAML input, including the ones in Lists
+func newOpaquePortsDetector(input []byte) (*opaquePortsDetector, error) {
+	d := &opaquePortsDetector{}
+	reader := yamlDecoder.NewYAMLReader(bufio.NewReaderSize(bytes.NewReader(input), 4096))
+	for {
+		b, err := reader.Read()
+		if err == io.EOF {
+			return d, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if err := d.addServices(b); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (d *opaquePortsDetector) addServices(b []byte) error {
+	isList, err := kindIsList(b)
+	if err != nil {
+		return err
+	}
+	if isList {
+		var list corev1.List
+		if err := yaml.Unmarshal(b, &list); err != nil {
+			return err
+		}
+		for _, item := range list.Items {
+			if err := d.addServices(item.Raw); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	var meta struct {
+		Kind string `json:"kind"`
+	}
+	if err := yaml.Unmarshal(b, &meta); err != nil {
+		return err
+	}
+	if strings.ToLower(meta.Kind) != k8s.Service {
+		return nil
+	}
+	var svc corev1.Service
+	if err := yaml.Unmarshal(b, &svc); err != nil {
+		return err
+	}
+	d.services = append(d.services, &svc)
+	return nil
+}
+
+// detectWorkload returns the comma-separated opaque ports of the workload in
+// bytes, given its pod template
+func (d *opaquePortsDetector) detectWorkload(bytes []byte, template *corev1.PodTemplateSpec) (string, error) {
+	var workload struct {
+		metav1.ObjectMeta `json:"metadata"`
+	}
+	if err := yaml.Unmarshal(bytes, &workload); err != nil {
+		return "", err
+	}
+	return d.detect(workload.Namespace, template), nil
+}
+
+// detect returns the comma-separated opaque ports of the pod template of a
+// workload in namespace: the ones it serves, from its container ports and the
+// target ports of the Services selecting it, and the ones of all the Services
+// it may connect to
+func (d *opaquePortsDetector) detect(namespace string, template *corev1.PodTemplateSpec) string {
+	ports := map[int32]bool{}
+
+	containerPorts := map[string]int32{}
+	for _, c := range template.Spec.Containers {
+		for _, p := range c.Ports {
+			if p.Name != "" {
+				containerPorts[p.Name] = p.ContainerPort
+			}
+			if isOpaquePort(p.Name, p.ContainerPort) {
+				ports[p.ContainerPort] = true
+			}
+		}
+	}
+
+	for _, svc := range d.services {
+		for _, p := range svc.Spec.Ports {
+			if !isOpaquePort(p.Name, p.Port) {
+				continue
+			}
+			ports[p.Port] = true
+
+			if svc.Namespace != namespace || len(svc.Spec.Selector) == 0 ||
+				!labels.SelectorFromSet(svc.Spec.Selector).Matches(labels.Set(template.Labels)) {
+				continue
+			}
+			switch {
+			case p.TargetPort.StrVal != "":
+				if port, ok := containerPorts[p.TargetPort.StrVal]; ok {
+					ports[port] = true
+				}
+			case p.TargetPort.IntVal != 0:
+				ports[p.TargetPort.IntVal] = true
+			}
+		}
+	}
+
+	sorted := make([]int, 0, len(ports))
+	for port := range ports {
+		sorted = append(sorted, int(port))
+	}
+	sort.Ints(sorted)
+	detected := make([]string, len(sorted))
+	for i, port := range sorted {
+		detected[i] = strconv.Itoa(port)
+	}
+	return strings.Join(detected, ",")
+}
+
+func isOpaquePort(name string, port int32) bool {
+	_, ok := knownOpaquePorts[port]
+	return ok || knownOpaquePortNames[strings.ToLower(name)]
+}
@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	cfg "github.com/linkerd/linkerd2/controller/gen/config"
+	"sigs.k8s.io/yaml"
+)
+
+// injectOverrides are the proxy configuration overrides read from an
+// --overrides file, keyed by the workloads they apply to, as <kind>/<name>
+// (e.g. deployment/web) or as <name> for the workloads of any kind
+type injectOverrides map[string]*workloadOverrides
+
+// workloadOverrides are the proxy configuration overrides of a workload
+type workloadOverrides struct {
+	LogLevel          string             `json:"logLevel,omitempty"`
+	Resources         *overrideResources `json:"resources,omitempty"`
+	SkipInboundPorts  []string           `json:"skipInboundPorts,omitempty"`
+	SkipOutboundPorts []string           `json:"skipOutboundPorts,omitempty"`
+}
+
+type overrideResources struct {
+	CPU    overrideConstraints `json:"cpu,omitempty"`
+	Memory overrideConstraints `json:"memory,omitempty"`
+}
+
+type overrideConstraints struct {
+	Request string `json:"request,omitempty"`
+	Limit   string `json:"limit,omitempty"`
+}
+
+// readInjectOverrides reads and validates an --overrides file
+func readInjectOverrides(path string) (injectOverrides, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var overrides injectOverrides
+	if err := yaml.UnmarshalStrict(b, &overrides); err != nil {
+		return nil, fmt.Errorf("invalid overrides file %s: %s", path, err)
+	}
+	for workload, o := range overrides {
+		if o == nil {
+			return nil, fmt.Errorf("invalid overrides file %s: no overrides for %s", path, workload)
+		}
+		if strings.Count(workload, "/") > 1 {
+			return nil, fmt.Errorf("invalid overrides file %s: %q should be <kind>/<name> or <name>", path, workload)
+		}
+		if err := o.proxyConfigOptions().validate(); err != nil {
+			return nil, fmt.Errorf("invalid overrides file %s: %s: %s", path, workload, err)
+		}
+	}
+	return overrides, nil
+}
+
+// forWorkload returns the overrides of the workload of the given kind and
+// name; the ones of <kind>/<name> take precedence over the ones of <name>
+func (o injectOverrides) forWorkload(kind, name string) *workloadOverrides {
+	if w, ok := o[fmt.Sprintf("%s/%s", strings.ToLower(kind), name)]; ok {
+		return w
+	}
+	return o[name]
+}
+
+// proxyConfigOptions returns options holding the overrides, like the flags of
+// `linkerd inject` would
+func (w *workloadOverrides) proxyConfigOptions() *proxyConfigOptions {
+	options := &proxyConfigOptions{
+		proxyLogLevel:       w.LogLevel,
+		ignoreInboundPorts:  w.SkipInboundPorts,
+		ignoreOutboundPorts: w.SkipOutboundPorts,
+	}
+	if r := w.Resources; r != nil {
+		options.proxyCPURequest = r.CPU.Request
+		options.proxyCPULimit = r.CPU.Limit
+		options.proxyMemoryRequest = r.Memory.Request
+		options.proxyMemoryLimit = r.Memory.Limit
+	}
+	return options
+}
+
+// apply returns a copy of configs and overrideAnnotations updated with the
+// overrides
+func (w *workloadOverrides) apply(configs *cfg.All, overrideAnnotations map[string]string) (*cfg.All, map[string]string) {
+	configs = proto.Clone(configs).(*cfg.All)
+	annotations := map[string]string{}
+	for k, v := range overrideAnnotations {
+		annotations[k] = v
+	}
+
+	options := w.proxyConfigOptions()
+	// overrideConfigs always sets this one, so it must be kept as configured
+	options.enableExternalProfiles = !configs.GetProxy().GetDisableExternalProfiles()
+	if configs.Proxy.Resource == nil {
+		configs.Proxy.Resource = &cfg.ResourceRequirements{}
+	}
+	options.overrideConfigs(configs, annotations)
+	return configs, annotations
+}
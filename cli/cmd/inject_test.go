@@ -24,6 +24,9 @@ type testCase struct {
 	testInjectConfig       *config.All
 	overrideAnnotations    map[string]string
 	enableDebugSidecarFlag bool
+	overrides              injectOverrides
+	podTemplatePaths       map[string]string
+	detectOpaquePorts      bool
 }
 
 func mkFilename(filename string, verbose bool) string {
@@ -49,6 +52,9 @@ func testUninjectAndInject(t *testing.T, tc testCase) {
 		overrideAnnotations: tc.overrideAnnotations,
 		enableDebugSidecar:  tc.enableDebugSidecarFlag,
 		allowNsInject:       true,
+		overrides:           tc.overrides,
+		podTemplatePaths:    tc.podTemplatePaths,
+		detectOpaquePorts:   tc.detectOpaquePorts,
 	}
 
 	if exitCode := uninjectAndInject([]io.Reader{read}, report, output, transformer); exitCode != 0 {
@@ -99,6 +105,10 @@ func TestUninjectAndInject(t *testing.T) {
 		LimitMemory:   "150Mi",
 	}
 
+	excludedNodesConfig := testInstallConfig()
+	excludedNodesConfig.Proxy.ProxyVersion = defaultConfig.Proxy.ProxyVersion
+	excludedNodesConfig.Proxy.ExcludedNodeSelector = "example.com/pool=gpu"
+
 	cniEnabledConfig := testInstallConfig()
 	cniEnabledConfig.Proxy.ProxyVersion = defaultConfig.Proxy.ProxyVersion
 	cniEnabledConfig.Global.CniEnabled = true
@@ -163,6 +173,30 @@ func TestUninjectAndInject(t *testing.T) {
 			injectProxy:      true,
 			testInjectConfig: defaultConfig,
 		},
+		{
+			inputFileName:    "inject_emojivoto_list.input.yml",
+			goldenFileName:   "inject_emojivoto_list_overrides.golden.yml",
+			reportFileName:   "inject_emojivoto_list.report",
+			injectProxy:      true,
+			testInjectConfig: defaultConfig,
+			overrideAnnotations: map[string]string{
+				k8s.ProxyLogLevelAnnotation: "warn",
+			},
+			overrides: injectOverrides{
+				"deployment/web": {
+					LogLevel: "debug",
+					Resources: &overrideResources{
+						CPU: overrideConstraints{Request: "100m", Limit: "1"},
+					},
+				},
+				"emoji": {
+					SkipOutboundPorts: []string{"3306"},
+				},
+				"deployment/emoji": {
+					SkipInboundPorts: []string{"25"},
+				},
+			},
+		},
 		{
 			inputFileName:    "inject_emojivoto_deployment_hostNetwork_false.input.yml",
 			goldenFileName:   "inject_emojivoto_deployment_hostNetwork_false.golden.yml",
@@ -198,6 +232,43 @@ func TestUninjectAndInject(t *testing.T) {
 			injectProxy:      true,
 			testInjectConfig: defaultConfig,
 		},
+		{
+			inputFileName:    "inject_emojivoto_job.input.yml",
+			goldenFileName:   "inject_emojivoto_job.golden.yml",
+			reportFileName:   "inject_emojivoto_job.report",
+			injectProxy:      true,
+			testInjectConfig: defaultConfig,
+		},
+		{
+			inputFileName:    "inject_emojivoto_job_on_failure.input.yml",
+			goldenFileName:   "inject_emojivoto_job_on_failure.golden.yml",
+			reportFileName:   "inject_emojivoto_job_on_failure.report",
+			injectProxy:      true,
+			testInjectConfig: defaultConfig,
+		},
+		{
+			inputFileName:    "inject_emojivoto_rollout.input.yml",
+			goldenFileName:   "inject_emojivoto_rollout.golden.yml",
+			reportFileName:   "inject_emojivoto_rollout.report",
+			injectProxy:      true,
+			testInjectConfig: defaultConfig,
+		},
+		{
+			inputFileName:    "inject_emojivoto_custom_resource.input.yml",
+			goldenFileName:   "inject_emojivoto_custom_resource.golden.yml",
+			reportFileName:   "inject_emojivoto_custom_resource.report",
+			injectProxy:      false,
+			testInjectConfig: defaultConfig,
+			podTemplatePaths: map[string]string{"VotingCluster": "/spec/worker/template"},
+		},
+		{
+			inputFileName:     "inject_emojivoto_opaque_ports.input.yml",
+			goldenFileName:    "inject_emojivoto_opaque_ports.golden.yml",
+			reportFileName:    "inject_emojivoto_opaque_ports.report",
+			injectProxy:       true,
+			testInjectConfig:  defaultConfig,
+			detectOpaquePorts: true,
+		},
 		{
 			inputFileName:    "inject_emojivoto_cronjob.input.yml",
 			goldenFileName:   "inject_emojivoto_cronjob.golden.yml",
@@ -233,6 +304,13 @@ func TestUninjectAndInject(t *testing.T) {
 			injectProxy:      true,
 			testInjectConfig: defaultConfig,
 		},
+		{
+			inputFileName:    "inject_excluded_nodes.input.yml",
+			goldenFileName:   "inject_excluded_nodes.golden.yml",
+			reportFileName:   "inject_excluded_nodes.report",
+			injectProxy:      true,
+			testInjectConfig: excludedNodesConfig,
+		},
 		{
 			inputFileName:    "inject_emojivoto_already_injected.input.yml",
 			goldenFileName:   "inject_emojivoto_already_injected.golden.yml",
@@ -711,6 +789,21 @@ func TestOverrideConfigsParameterized(t *testing.T) {
 				k8s.DebugImageAnnotation:     "my.custom.registry/linkerd-io/debug",
 			},
 		},
+		{
+			description: "proxy connect overrides",
+			configOptions: proxyConfigOptions{
+				outboundConnectTimeout:       "3s",
+				outboundConnectBackoffMin:    "50ms",
+				outboundConnectBackoffMax:    "2s",
+				outboundConnectBackoffJitter: "0.2",
+			},
+			expectedOverrides: map[string]string{
+				k8s.ProxyOutboundConnectTimeout:       "3s",
+				k8s.ProxyOutboundConnectBackoffMin:    "50ms",
+				k8s.ProxyOutboundConnectBackoffMax:    "2s",
+				k8s.ProxyOutboundConnectBackoffJitter: "0.2",
+			},
+		},
 		{
 			description:       "no overrides",
 			configOptions:     proxyConfigOptions{},
@@ -886,3 +979,71 @@ func TestOverwriteRegistry(t *testing.T) {
 		})
 	}
 }
+
+func TestReadInjectOverrides(t *testing.T) {
+	testCases := []struct {
+		content string
+		err     string
+	}{
+		{
+			content: `
+deployment/web:
+  logLevel: debug
+  resources:
+    memory:
+      request: 20Mi
+      limit: 250Mi
+emoji:
+  skipInboundPorts: ["25", "8000-8010"]
+`,
+		},
+		{
+			content: "web:\n  image: ghcr.io/linkerd/proxy\n",
+			err:     `invalid overrides file %s: error unmarshaling JSON: while decoding JSON: json: unknown field "image"`,
+		},
+		{
+			content: "emojivoto/deployment/web:\n  logLevel: debug\n",
+			err:     `invalid overrides file %s: "emojivoto/deployment/web" should be <kind>/<name> or <name>`,
+		},
+		{
+			content: "web:\n  resources:\n    cpu:\n      request: 2\n      limit: 1\n",
+			err:     "invalid overrides file %s: web: The cpu limit '1' cannot be lower than the cpu request '2'",
+		},
+	}
+
+	for i, tc := range testCases {
+		tc := tc // pin
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			f, err := ioutil.TempFile("", "overrides-*.yaml")
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			defer os.Remove(f.Name())
+			if _, err := f.WriteString(tc.content); err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			f.Close()
+
+			overrides, err := readInjectOverrides(f.Name())
+			if tc.err != "" {
+				expected := fmt.Sprintf(tc.err, f.Name())
+				if err == nil || err.Error() != expected {
+					t.Fatalf("Expected error %q, got %v", expected, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if w := overrides.forWorkload("Deployment", "web"); w == nil || w.LogLevel != "debug" {
+				t.Fatalf("Expected the overrides of deployment/web, got %+v", w)
+			}
+			if w := overrides.forWorkload("StatefulSet", "emoji"); w == nil || len(w.SkipInboundPorts) != 2 {
+				t.Fatalf("Expected the overrides of emoji, got %+v", w)
+			}
+			if w := overrides.forWorkload("Deployment", "voting"); w != nil {
+				t.Fatalf("Expected no overrides for deployment/voting, got %+v", w)
+			}
+		})
+	}
+}
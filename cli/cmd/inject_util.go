@@ -10,8 +10,10 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/linkerd/linkerd2/pkg/inject"
+	"github.com/sergi/go-diff/diffmatchpatch"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -19,6 +21,10 @@ import (
 	"sigs.k8s.io/yaml"
 )
 
+// diffContext is the number of unchanged lines returned by diffLines around
+// the differences
+const diffContext = 2
+
 type resourceTransformer interface {
 	transform([]byte) ([]byte, []inject.Report, error)
 	generateReport([]inject.Report, io.Writer)
@@ -240,3 +246,48 @@ func concatErrors(errs []error, delimiter string) error {
 	}
 	return errors.New(message)
 }
+
+// diffLines returns the lines of from and to that differ, prefixed by - and +
+// respectively, with a few unchanged lines around them
+func diffLines(from, to []byte) []string {
+	dmp := diffmatchpatch.New()
+	a, b, lines := dmp.DiffLinesToChars(string(from), string(to))
+	diffs := dmp.DiffCharsToLines(dmp.DiffMain(a, b, false), lines)
+
+	var out []string
+	for i, d := range diffs {
+		text := strings.Split(strings.TrimSuffix(d.Text, "\n"), "\n")
+		switch d.Type {
+		case diffmatchpatch.DiffDelete:
+			out = append(out, prefixLines("-", text)...)
+		case diffmatchpatch.DiffInsert:
+			out = append(out, prefixLines("+", text)...)
+		case diffmatchpatch.DiffEqual:
+			// only the lines following the previous difference and
+			// preceding the next one are kept
+			head, tail := 0, 0
+			if i > 0 {
+				head = diffContext
+			}
+			if i < len(diffs)-1 {
+				tail = diffContext
+			}
+			if head+tail >= len(text) {
+				out = append(out, prefixLines(" ", text)...)
+				continue
+			}
+			out = append(out, prefixLines(" ", text[:head])...)
+			out = append(out, "...")
+			out = append(out, prefixLines(" ", text[len(text)-tail:])...)
+		}
+	}
+	return out
+}
+
+func prefixLines(prefix string, lines []string) []string {
+	prefixed := make([]string, len(lines))
+	for i, line := range lines {
+		prefixed[i] = prefix + line
+	}
+	return prefixed
+}
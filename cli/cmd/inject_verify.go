@@ -0,0 +1,308 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/linkerd/linkerd2/pkg/inject"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	yamlDecoder "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/yaml"
+)
+
+// injectVerifier compares the pods injected by `linkerd inject --manual` with
+// the ones injected by the proxy-injector of the cluster
+type injectVerifier struct {
+	transformer *resourceTransformerInject
+
+	// dryRun creates the pod with server-side dry-run, returning it as
+	// admitted by the API server and its webhooks
+	dryRun func(*corev1.Pod) (*corev1.Pod, error)
+}
+
+// verifyResult holds the differences between the pods injected by the CLI
+// and by the proxy-injector for a resource
+type verifyResult struct {
+	resource string
+	diff     []string
+}
+
+// injectedConfig holds the fields of a pod set by the injection
+type injectedConfig struct {
+	Annotations    map[string]string  `json:"annotations,omitempty"`
+	Labels         map[string]string  `json:"labels,omitempty"`
+	InitContainers []corev1.Container `json:"initContainers,omitempty"`
+	Containers     []corev1.Container `json:"containers,omitempty"`
+	Volumes        []corev1.Volume    `json:"volumes,omitempty"`
+}
+
+var (
+	injectedContainers = map[string]bool{
+		k8s.InitContainerName:        true,
+		k8s.ProxyContainerName:       true,
+		k8s.DebugSidecarName:         true,
+		k8s.ProxyShutdownSidecarName: true,
+	}
+
+	injectedVolumes = map[string]bool{
+		k8s.IdentityEndEntityVolumeName:    true,
+		k8s.InitXtablesLockVolumeMountName: true,
+		k8s.PodInfoVolumeName:              true,
+	}
+
+	// the metadata that legitimately differs between the CLI and the
+	// proxy-injector: the latter can't find the owner of a dry-run pod
+	unverifiedMetadata = map[string]bool{
+		k8s.CreatedByAnnotation:             true,
+		k8s.ProxyInjectAnnotation:           true,
+		k8s.ProxyDeploymentLabel:            true,
+		k8s.ProxyReplicationControllerLabel: true,
+		k8s.ProxyReplicaSetLabel:            true,
+		k8s.ProxyJobLabel:                   true,
+		k8s.ProxyDaemonSetLabel:             true,
+		k8s.ProxyStatefulSetLabel:           true,
+		k8s.ProxyCronJobLabel:               true,
+	}
+)
+
+// newDryRunPodCreator returns a function creating pods with server-side
+// dry-run, so that they go through the proxy-injector without being persisted
+func newDryRunPodCreator(client kubernetes.Interface) func(*corev1.Pod) (*corev1.Pod, error) {
+	return func(pod *corev1.Pod) (*corev1.Pod, error) {
+		var created corev1.Pod
+		err := client.CoreV1().RESTClient().Post().
+			Namespace(pod.Namespace).
+			Resource("pods").
+			Param("dryRun", metav1.DryRunAll).
+			Body(pod).
+			Do().
+			Into(&created)
+		return &created, err
+	}
+}
+
+// runInjectVerify verifies the resources of the inputs and prints the
+// differences found. Returns the integer representation of os.Exit code; 0
+// if the CLI and the proxy-injector inject all the resources alike.
+func runInjectVerify(inputs []io.Reader, errWriter, outWriter io.Writer, v *injectVerifier) int {
+	var results []verifyResult
+	for _, input := range inputs {
+		reader := yamlDecoder.NewYAMLReader(bufio.NewReaderSize(input, 4096))
+		for {
+			bytes, err := reader.Read()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				fmt.Fprintf(errWriter, "Error reading resources: %s\n", err)
+				return 1
+			}
+			r, err := v.verifyAll(bytes)
+			if err != nil {
+				fmt.Fprintf(errWriter, "Error verifying resources: %s\n", err)
+				return 1
+			}
+			results = append(results, r...)
+		}
+	}
+
+	if len(results) == 0 {
+		fmt.Fprintln(outWriter, "No injectable resources to verify")
+		return 0
+	}
+
+	exitCode := 0
+	for _, r := range results {
+		if len(r.diff) == 0 {
+			fmt.Fprintf(outWriter, "%s %s: the CLI and the proxy-injector inject the same configuration\n", okStatus, r.resource)
+			continue
+		}
+		exitCode = 1
+		fmt.Fprintf(outWriter, "%s %s: the CLI (-) and the proxy-injector (+) inject different configurations:\n", failStatus, r.resource)
+		for _, line := range r.diff {
+			fmt.Fprintf(outWriter, "    %s\n", line)
+		}
+	}
+	return exitCode
+}
+
+// verifyAll verifies the resource in bytes, or the items of the list in
+// bytes
+func (v *injectVerifier) verifyAll(bytes []byte) ([]verifyResult, error) {
+	isList, err := kindIsList(bytes)
+	if err != nil {
+		return nil, err
+	}
+	if !isList {
+		r, err := v.verify(bytes)
+		if err != nil || r == nil {
+			return nil, err
+		}
+		return []verifyResult{*r}, nil
+	}
+
+	var list corev1.List
+	if err := yaml.Unmarshal(bytes, &list); err != nil {
+		return nil, err
+	}
+	var results []verifyResult
+	for _, item := range list.Items {
+		r, err := v.verify(item.Raw)
+		if err != nil {
+			return nil, err
+		}
+		if r != nil {
+			results = append(results, *r)
+		}
+	}
+	return results, nil
+}
+
+// verify injects the pod of the resource in bytes with the CLI and with the
+// proxy-injector, and compares them. Returns nil if the resource isn't
+// injectable.
+func (v *injectVerifier) verify(bytes []byte) (*verifyResult, error) {
+	clean, _, err := resourceTransformerUninjectSilent{configs: v.transformer.configs, podTemplatePaths: v.transformer.podTemplatePaths}.transform(bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	conf := inject.NewResourceConfig(v.transformer.configs, inject.OriginCLI).WithPodTemplatePaths(v.transformer.podTemplatePaths)
+	report, err := conf.ParseMetaAndYAML(clean)
+	if err != nil {
+		return nil, err
+	}
+	if conf.IsNamespace() || conf.GetPodTemplate() == nil {
+		return nil, nil
+	}
+	if injectable, _ := report.Injectable(); !injectable {
+		return nil, nil
+	}
+
+	// the pod injected by the CLI goes through the API server for its
+	// defaults, but skips the proxy-injector
+	manual := *v.transformer
+	manual.injectProxy = true
+	local, err := v.admit(&manual, clean, k8s.ProxyInjectDisabled)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", report.ResName(), err)
+	}
+
+	// the pod annotated by the CLI is injected by the proxy-injector
+	auto := *v.transformer
+	auto.injectProxy = false
+	injected, err := v.admit(&auto, clean, "")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", report.ResName(), err)
+	}
+
+	diff, err := diffInjectedConfigs(local, injected)
+	if err != nil {
+		return nil, err
+	}
+	return &verifyResult{resource: report.ResName(), diff: diff}, nil
+}
+
+// admit transforms the resource in bytes with rt and creates its pod with
+// dryRun, setting its inject annotation if not empty
+func (v *injectVerifier) admit(rt *resourceTransformerInject, bytes []byte, injectAnnotation string) (*corev1.Pod, error) {
+	transformed, _, err := rt.transform(bytes)
+	if err != nil {
+		return nil, err
+	}
+	conf := inject.NewResourceConfig(rt.configs, inject.OriginCLI).WithPodTemplatePaths(rt.podTemplatePaths)
+	if _, err := conf.ParseMetaAndYAML(transformed); err != nil {
+		return nil, err
+	}
+	var workload struct {
+		metav1.ObjectMeta `json:"metadata"`
+	}
+	if err := yaml.Unmarshal(transformed, &workload); err != nil {
+		return nil, err
+	}
+
+	template := conf.GetPodTemplate()
+	pod := &corev1.Pod{ObjectMeta: template.ObjectMeta, Spec: template.Spec}
+	pod.Name = ""
+	pod.GenerateName = fmt.Sprintf("%s-", workload.Name)
+	pod.Namespace = workload.Namespace
+	if pod.Namespace == "" {
+		pod.Namespace = defaultNamespace
+	}
+	if injectAnnotation != "" {
+		if pod.Annotations == nil {
+			pod.Annotations = map[string]string{}
+		}
+		pod.Annotations[k8s.ProxyInjectAnnotation] = injectAnnotation
+	}
+	return v.dryRun(pod)
+}
+
+// newInjectedConfig returns the fields of the pod set by the injection,
+// leaving out the service account token mounted in every container
+func newInjectedConfig(pod *corev1.Pod) *injectedConfig {
+	config := &injectedConfig{
+		Annotations: injectedMetadata(pod.Annotations),
+		Labels:      injectedMetadata(pod.Labels),
+	}
+	for _, c := range pod.Spec.InitContainers {
+		if injectedContainers[c.Name] {
+			config.InitContainers = append(config.InitContainers, withoutServiceAccountMount(c))
+		}
+	}
+	for _, c := range pod.Spec.Containers {
+		if injectedContainers[c.Name] {
+			config.Containers = append(config.Containers, withoutServiceAccountMount(c))
+		}
+	}
+	for _, volume := range pod.Spec.Volumes {
+		if injectedVolumes[volume.Name] {
+			config.Volumes = append(config.Volumes, volume)
+		}
+	}
+	return config
+}
+
+func injectedMetadata(metadata map[string]string) map[string]string {
+	injected := map[string]string{}
+	for k, v := range metadata {
+		if strings.Contains(k, k8s.Prefix+"/") && !unverifiedMetadata[k] {
+			injected[k] = v
+		}
+	}
+	return injected
+}
+
+func withoutServiceAccountMount(c corev1.Container) corev1.Container {
+	mounts := []corev1.VolumeMount{}
+	for _, m := range c.VolumeMounts {
+		if m.MountPath != k8s.MountPathServiceAccount {
+			mounts = append(mounts, m)
+		}
+	}
+	c.VolumeMounts = mounts
+	return c
+}
+
+// diffInjectedConfigs returns the differences between the YAML
+// representations of the injected configs of the local and injected pods
+func diffInjectedConfigs(local, injected *corev1.Pod) ([]string, error) {
+	localYAML, err := yaml.Marshal(newInjectedConfig(local))
+	if err != nil {
+		return nil, err
+	}
+	injectedYAML, err := yaml.Marshal(newInjectedConfig(injected))
+	if err != nil {
+		return nil, err
+	}
+	if bytes.Equal(localYAML, injectedYAML) {
+		return nil, nil
+	}
+	return diffLines(localYAML, injectedYAML), nil
+}
@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	cfg "github.com/linkerd/linkerd2/controller/gen/config"
+	"github.com/linkerd/linkerd2/pkg/inject"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// fakeProxyInjector returns a dryRun function injecting the pods annotated
+// with linkerd.io/inject: enabled like the proxy-injector configured with
+// configs would
+func fakeProxyInjector(configs *cfg.All) func(*corev1.Pod) (*corev1.Pod, error) {
+	return func(pod *corev1.Pod) (*corev1.Pod, error) {
+		if pod.Annotations[k8s.ProxyInjectAnnotation] != k8s.ProxyInjectEnabled {
+			return pod, nil
+		}
+
+		podJSON, err := json.Marshal(pod)
+		if err != nil {
+			return nil, err
+		}
+		conf := inject.NewResourceConfig(configs, inject.OriginWebhook).WithKind("Pod")
+		if _, err := conf.ParseMetaAndYAML(podJSON); err != nil {
+			return nil, err
+		}
+		patchJSON, err := conf.GetPatch(true)
+		if err != nil {
+			return nil, err
+		}
+		patch, err := jsonpatch.DecodePatch(patchJSON)
+		if err != nil {
+			return nil, err
+		}
+		injectedJSON, err := patch.Apply(podJSON)
+		if err != nil {
+			return nil, err
+		}
+		var injected corev1.Pod
+		err = json.Unmarshal(injectedJSON, &injected)
+		return &injected, err
+	}
+}
+
+func TestRunInjectVerify(t *testing.T) {
+	configs := testInstallConfig()
+	configs.Proxy.ProxyVersion = "test-inject-proxy-version"
+
+	driftedConfigs := testInstallConfig()
+	driftedConfigs.Proxy.ProxyVersion = "test-inject-proxy-version"
+	driftedConfigs.Proxy.LogLevel = &cfg.LogLevel{Level: "debug"}
+
+	testCases := []struct {
+		name             string
+		injectorConfigs  *cfg.All
+		expectedExitCode int
+		expectedOutput   []string
+	}{
+		{
+			name:             "same configuration",
+			injectorConfigs:  configs,
+			expectedExitCode: 0,
+			expectedOutput: []string{
+				"deployment/web: the CLI and the proxy-injector inject the same configuration",
+			},
+		},
+		{
+			name:             "drifted configuration",
+			injectorConfigs:  driftedConfigs,
+			expectedExitCode: 1,
+			expectedOutput: []string{
+				"deployment/web: the CLI (-) and the proxy-injector (+) inject different configurations:",
+				"      - name: LINKERD2_PROXY_LOG",
+				"    -    value: warn,linkerd=info",
+				"    +    value: debug",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.name, func(t *testing.T) {
+			file, err := os.Open("testdata/inject_emojivoto_deployment.input.yml")
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			defer file.Close()
+
+			verifier := &injectVerifier{
+				transformer: &resourceTransformerInject{
+					allowNsInject:       true,
+					configs:             configs,
+					overrideAnnotations: map[string]string{},
+				},
+				dryRun: fakeProxyInjector(tc.injectorConfigs),
+			}
+			var stdout, stderr bytes.Buffer
+			exitCode := runInjectVerify([]io.Reader{file}, &stderr, &stdout, verifier)
+			if exitCode != tc.expectedExitCode {
+				t.Fatalf("Expected exit code %d, got %d\nstdout:\n%s\nstderr:\n%s", tc.expectedExitCode, exitCode, stdout.String(), stderr.String())
+			}
+			for _, expected := range tc.expectedOutput {
+				if !strings.Contains(stdout.String(), expected) {
+					t.Errorf("Expected output to contain %q, got:\n%s", expected, stdout.String())
+				}
+			}
+		})
+	}
+}
@@ -1,11 +1,16 @@
 package cmd
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"net"
+	"net/url"
 	"os"
+	"path"
+	"strconv"
 	"strings"
 	"time"
 
@@ -18,6 +23,7 @@ import (
 	"github.com/linkerd/linkerd2/pkg/issuercerts"
 	"github.com/linkerd/linkerd2/pkg/k8s"
 	consts "github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/linkerd/linkerd2/pkg/kms"
 	"github.com/linkerd/linkerd2/pkg/tls"
 	"github.com/linkerd/linkerd2/pkg/version"
 	log "github.com/sirupsen/logrus"
@@ -26,6 +32,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/helm/pkg/chartutil"
 	"sigs.k8s.io/yaml"
@@ -40,6 +47,8 @@ type (
 	installOptions struct {
 		clusterDomain               string
 		addOnConfig                 string
+		addOns                      []string
+		skipAddOns                  []string
 		controlPlaneVersion         string
 		controllerReplicas          uint
 		controllerLogLevel          string
@@ -47,13 +56,41 @@ type (
 		controllerUID               int64
 		disableH2Upgrade            bool
 		disableHeartbeat            bool
+		enableHealthExporter        bool
+		enableTrustBundle           bool
+		enableIdentityRotation      bool
+		trustBundleNSSelector       string
 		cniEnabled                  bool
+		openshift                   bool
+		enableNetworkPolicies       bool
+		cryptoPolicy                string
 		enableEndpointSlices        bool
 		skipChecks                  bool
 		omitWebhookSideEffects      bool
 		restrictDashboardPrivileges bool
 		controlPlaneTracing         bool
+		smokeTest                   bool
+		controlPlaneMetricsPort     uint
+		controlPlaneMetricsScheme   string
+		prometheusURL               string
+		imagePullSecrets            []string
+		imageArchitectures          []string
+		componentArchitectures      map[string]string
+		componentLogLevels          map[string]string
+		injectorFailurePolicy       string
+		injectorNamespaceSelector   string
+		injectorObjectSelector      string
+		orderManifest               string
+		outputDir                   string
+		output                      string
+		apply                       bool
+		applyTimeout                time.Duration
+		listImages                  bool
+		crdOutput                   bool
+		postRenderer                string
+		dryRun                      string
 		identityOptions             *installIdentityOptions
+		valuesOptions               *valuesOptions
 		*proxyConfigOptions
 
 		recordedFlags []*pb.Install_Flag
@@ -68,9 +105,30 @@ type (
 
 		issuanceLifetime   time.Duration
 		clockSkewAllowance time.Duration
+		renewalJitter      time.Duration
 
 		trustPEMFile, crtPEMFile, keyPEMFile string
 		identityExternalIssuer               bool
+
+		// keyURI identifies the issuer private key in a KMS, in which case the
+		// key isn't stored in the issuer secret
+		keyURI string
+
+		issuerKeyAlgorithm string
+
+		// certManager holds the settings of the cert-manager resources that
+		// manage the issuer secret when --identity-external-issuer=cert-manager
+		certManager l5dcharts.IssuerCertManager
+
+		// spire holds the settings of the SPIRE server the issuer certificate
+		// is obtained from, when its address is set
+		spire l5dcharts.IdentitySpire
+	}
+
+	// externalIssuerValue is the value of the --identity-external-issuer flag,
+	// which is either a boolean or "cert-manager"
+	externalIssuerValue struct {
+		idopts *installIdentityOptions
 	}
 
 	// helper struct to move those values together
@@ -86,12 +144,21 @@ const (
 	// addOnChartsPath is where the linkerd2 add-ons will be present
 	addOnChartsPath = "add-ons"
 
+	crdsStage         = "crds"
 	configStage       = "config"
 	controlPlaneStage = "control-plane"
 
 	defaultIdentityIssuanceLifetime   = 24 * time.Hour
 	defaultIdentityClockSkewAllowance = 20 * time.Second
 
+	// externalIssuerCertManager is the --identity-external-issuer value that
+	// has cert-manager issue the identity issuer certificate
+	externalIssuerCertManager = "cert-manager"
+
+	// fipsImageSuffix is appended to the names of the proxy and controller
+	// images with --crypto-policy fips
+	fipsImageSuffix = "-fips"
+
 	helmDefaultChartName = "linkerd2"
 	helmDefaultChartDir  = "linkerd2"
 
@@ -112,23 +179,59 @@ If you are sure you'd like to have a fresh install, remove these resources with:
 Otherwise, you can use the --ignore-cluster flag to overwrite the existing global resources.
 `
 
+	errMsgInsufficientCapacity = `Unable to install the Linkerd control plane. The cluster doesn't meet its requirements:
+
+%s
+
+You can use the --ignore-cluster flag if you just want to generate the installation config.`
+
+	errMsgOpenShiftSCC = `Unable to install the Linkerd control plane on OpenShift. The SecurityContextConstraints can't be created:
+
+%s
+
+Make sure the cluster serves the security.openshift.io/v1 API and that you can create SecurityContextConstraints.
+You can use the --ignore-cluster flag if you just want to generate the installation config.`
+
 	errMsgLinkerdConfigResourceConflict = "Can't install the Linkerd control plane in the '%s' namespace. Reason: %s.\nIf this is expected, use the --ignore-cluster flag to continue the installation.\n"
-	errMsgGlobalResourcesMissing        = "Can't install the Linkerd control plane in the '%s' namespace. The required Linkerd global resources are missing.\nIf this is expected, use the --skip-checks flag to continue the installation.\n"
+	errMsgPreviousStagesMissing         = "Can't install the Linkerd control plane in the '%s' namespace: %s.\nIf this is expected, use the --skip-checks flag to continue the installation.\n"
 )
 
 var (
+	// controlPlaneAPIPorts are the ports on which the control plane components
+	// serve their APIs and webhooks
+	controlPlaneAPIPorts = []uint{8080, 8084, 8085, 8086, 8088, 8089, 8443}
+
+	// supportedArchitectures are the architectures accepted by
+	// --image-architectures
+	supportedArchitectures = []string{"amd64", "arm64", "arm", "s390x", "ppc64le"}
+
+	// schedulableComponents are the control plane components whose node
+	// selector, tolerations and log level can be set individually, through
+	// --component-arch, --controller-component-log-level or the
+	// componentNodeSelectors, componentTolerations and componentLogLevels
+	// values
+	schedulableComponents = []string{"controller", "destination", "health-exporter", "heartbeat", "identity", "identity-rotation", "proxy-injector", "sp-validator", "tap", "trust-bundle", "web"}
+
+	templatesCRDsStage = []string{
+		"templates/serviceprofile-crd.yaml",
+		"templates/trafficsplit-crd.yaml",
+		"templates/linkerdcontrolplane-crd.yaml",
+		"templates/proxyconfig-crd.yaml",
+	}
+
 	templatesConfigStage = []string{
 		"templates/namespace.yaml",
 		"templates/identity-rbac.yaml",
 		"templates/controller-rbac.yaml",
 		"templates/destination-rbac.yaml",
 		"templates/heartbeat-rbac.yaml",
+		"templates/health-exporter-rbac.yaml",
+		"templates/identity-rotation-rbac.yaml",
 		"templates/web-rbac.yaml",
-		"templates/serviceprofile-crd.yaml",
-		"templates/trafficsplit-crd.yaml",
 		"templates/proxy-injector-rbac.yaml",
 		"templates/sp-validator-rbac.yaml",
 		"templates/tap-rbac.yaml",
+		"templates/trust-bundle-rbac.yaml",
 		"templates/psp.yaml",
 	}
 
@@ -140,11 +243,16 @@ var (
 		"templates/controller.yaml",
 		"templates/destination.yaml",
 		"templates/heartbeat.yaml",
+		"templates/health-exporter.yaml",
+		"templates/identity-rotation.yaml",
 		"templates/web.yaml",
 		"templates/proxy-injector.yaml",
 		"templates/sp-validator.yaml",
 		"templates/tap.yaml",
+		"templates/trust-bundle.yaml",
 		"templates/linkerd-config-addons.yaml",
+		"templates/prometheus-scrape-config.yaml",
+		"templates/network-policies.yaml",
 	}
 )
 
@@ -161,6 +269,12 @@ func newInstallOptionsWithDefaults() (*installOptions, error) {
 		return nil, err
 	}
 
+	return newInstallOptionsFromValues(defaults)
+}
+
+// newInstallOptionsFromValues initializes install options with their defaults
+// taken from the given chart values.
+func newInstallOptionsFromValues(defaults *l5dcharts.Values) (*installOptions, error) {
 	issuanceLifetime, err := time.ParseDuration(defaults.Identity.Issuer.IssuanceLifetime)
 	if err != nil {
 		return nil, err
@@ -171,6 +285,16 @@ func newInstallOptionsWithDefaults() (*installOptions, error) {
 		return nil, err
 	}
 
+	renewalJitter, err := time.ParseDuration(defaults.Identity.Issuer.RenewalJitter)
+	if err != nil {
+		return nil, err
+	}
+
+	var imagePullSecrets []string
+	for _, secret := range defaults.Global.ImagePullSecrets {
+		imagePullSecrets = append(imagePullSecrets, secret["name"])
+	}
+
 	return &installOptions{
 		clusterDomain:               defaults.Global.ClusterDomain,
 		controlPlaneVersion:         version.Version,
@@ -180,11 +304,30 @@ func newInstallOptionsWithDefaults() (*installOptions, error) {
 		controllerUID:               defaults.ControllerUID,
 		disableH2Upgrade:            !defaults.EnableH2Upgrade,
 		disableHeartbeat:            defaults.DisableHeartBeat,
+		enableHealthExporter:        defaults.EnableHealthExporter,
+		enableTrustBundle:           defaults.EnableTrustBundle,
+		enableIdentityRotation:      defaults.EnableIdentityRotation,
+		trustBundleNSSelector:       defaults.TrustBundleNamespaceSelector,
 		cniEnabled:                  defaults.Global.CNIEnabled,
+		openshift:                   defaults.Global.OpenShift,
+		enableNetworkPolicies:       defaults.EnableNetworkPolicies,
+		cryptoPolicy:                defaults.Global.CryptoPolicy,
 		enableEndpointSlices:        defaults.Global.EnableEndpointSlices,
 		omitWebhookSideEffects:      defaults.OmitWebhookSideEffects,
 		restrictDashboardPrivileges: defaults.RestrictDashboardPrivileges,
 		controlPlaneTracing:         defaults.Global.ControlPlaneTracing,
+		controlPlaneMetricsPort:     uint(defaults.Global.ControlPlaneMetrics.Port),
+		controlPlaneMetricsScheme:   defaults.Global.ControlPlaneMetrics.Scheme,
+		prometheusURL:               defaults.Global.PrometheusURL,
+		imagePullSecrets:            imagePullSecrets,
+		imageArchitectures:          defaults.Global.ImageArchitectures,
+		componentArchitectures:      map[string]string{},
+		componentLogLevels:          map[string]string{},
+		injectorFailurePolicy:       defaults.ProxyInjector.FailurePolicy,
+		output:                      yamlOutput,
+		applyTimeout:                5 * time.Minute,
+		dryRun:                      dryRunNone,
+		valuesOptions:               &valuesOptions{},
 		proxyConfigOptions: &proxyConfigOptions{
 			proxyVersion:           version.Version,
 			ignoreCluster:          false,
@@ -213,12 +356,21 @@ func newInstallOptionsWithDefaults() (*installOptions, error) {
 			waitBeforeExitSeconds:  defaults.Global.Proxy.WaitBeforeExitSeconds,
 			inboundConnectTimeout:  defaults.Global.Proxy.InboundConnectTimeout,
 			outboundConnectTimeout: defaults.Global.Proxy.OutboundConnectTimeout,
+
+			outboundConnectBackoffMin:    defaults.Global.Proxy.OutboundConnectBackoffMin,
+			outboundConnectBackoffMax:    defaults.Global.Proxy.OutboundConnectBackoffMax,
+			outboundConnectBackoffJitter: defaults.Global.Proxy.OutboundConnectBackoffJitter,
+			excludedNodeSelector:         defaults.Global.Proxy.ExcludedNodeSelector,
 		},
 		identityOptions: &installIdentityOptions{
 			trustDomain:            defaults.Global.IdentityTrustDomain,
 			issuanceLifetime:       issuanceLifetime,
 			clockSkewAllowance:     clockSkewAllowance,
+			renewalJitter:          renewalJitter,
 			identityExternalIssuer: false,
+			certManager:            *defaults.Identity.Issuer.CertManager,
+			spire:                  *defaults.Identity.Spire,
+			issuerKeyAlgorithm:     defaults.Identity.Issuer.KeyAlgorithm,
 		},
 
 		heartbeatSchedule: func() string {
@@ -236,15 +388,51 @@ func newInstallOptionsWithDefaults() (*installOptions, error) {
 //
 //                                 | recordableFlagSet | allStageFlagSet | installOnlyFlagSet | installPersistentFlagSet | upgradeOnlyFlagSet | "skip-checks" |
 // `linkerd install`               |        X          |       X         |         X          |            X             |                    |               |
+// `linkerd install crds`          |                   |       X         |                    |            X             |                    |               |
 // `linkerd install config`        |                   |       X         |                    |            X             |                    |               |
 // `linkerd install control-plane` |        X          |       X         |         X          |            X             |                    |       X       |
 // `linkerd upgrade`               |        X          |       X         |                    |                          |          X         |               |
+// `linkerd upgrade crds`          |                   |       X         |                    |                          |                    |               |
 // `linkerd upgrade config`        |                   |       X         |                    |                          |                    |               |
 // `linkerd upgrade control-plane` |        X          |       X         |                    |                          |          X         |               |
 //
-// allStageFlagSet is a subset of recordableFlagSet, but is also added to `linkerd [install|upgrade] config`
+// allStageFlagSet is a subset of recordableFlagSet, but is also added to `linkerd [install|upgrade] [crds|config]`
 // proxyConfigOptions.flagSet is a subset of recordableFlagSet, and is used by `linkerd inject`.
 
+// newCmdInstallCRDs is a subcommand for `linkerd install crds`
+func newCmdInstallCRDs(options *installOptions, parentFlags *pflag.FlagSet) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "crds [flags]",
+		Args:  cobra.NoArgs,
+		Short: "Output Kubernetes CustomResourceDefinitions to install Linkerd",
+		Long: `Output Kubernetes CustomResourceDefinitions to install Linkerd.
+
+This command provides the CustomResourceDefinitions used by the Linkerd control
+plane, so that they can be installed by a cluster administrator separately from
+the other cluster-wide resources. This command should be followed by
+"linkerd install config".`,
+		Example: `  # Default install.
+  linkerd install crds | kubectl apply -f -`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !options.ignoreCluster {
+				if err := errAfterRunningChecks(options, crdsStage); err != nil {
+					if healthcheck.IsCategoryError(err, healthcheck.KubernetesAPIChecks) {
+						fmt.Fprintf(os.Stderr, errMsgCannotInitializeClient, err)
+					} else {
+						fmt.Fprintf(os.Stderr, errMsgGlobalResourcesExist, err)
+					}
+					os.Exit(1)
+				}
+			}
+			return installRunE(options, crdsStage, parentFlags)
+		},
+	}
+
+	cmd.Flags().AddFlagSet(options.allStageFlagSet())
+
+	return cmd
+}
+
 // newCmdInstallConfig is a subcommand for `linkerd install config`
 func newCmdInstallConfig(options *installOptions, parentFlags *pflag.FlagSet) *cobra.Command {
 	cmd := &cobra.Command{
@@ -254,8 +442,8 @@ func newCmdInstallConfig(options *installOptions, parentFlags *pflag.FlagSet) *c
 		Long: `Output Kubernetes cluster-wide resources to install Linkerd.
 
 This command provides Kubernetes configs necessary to install cluster-wide
-resources for the Linkerd control plane. This command should be followed by
-"linkerd install control-plane".`,
+resources for the Linkerd control plane. It should be run after
+"linkerd install crds", and followed by "linkerd install control-plane".`,
 		Example: `  # Default install.
   linkerd install config | kubectl apply -f -
 
@@ -263,9 +451,11 @@ resources for the Linkerd control plane. This command should be followed by
   linkerd install config -l linkerdtest | kubectl apply -f -`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if !options.ignoreCluster {
-				if err := errAfterRunningChecks(options); err != nil {
+				if err := errAfterRunningChecks(options, configStage); err != nil {
 					if healthcheck.IsCategoryError(err, healthcheck.KubernetesAPIChecks) {
 						fmt.Fprintf(os.Stderr, errMsgCannotInitializeClient, err)
+					} else if healthcheck.IsCategoryError(err, healthcheck.LinkerdPreInstallOpenShiftChecks) {
+						fmt.Fprintf(os.Stderr, errMsgOpenShiftSCC, err)
 					} else {
 						fmt.Fprintf(os.Stderr, errMsgGlobalResourcesExist, err)
 					}
@@ -295,7 +485,8 @@ func newCmdInstallControlPlane(options *installOptions) *cobra.Command {
 		Long: `Output Kubernetes control plane resources to install Linkerd.
 
 This command provides Kubernetes configs necessary to install the Linkerd
-control plane. It should be run after "linkerd install config".`,
+control plane. It should be run after "linkerd install crds" and
+"linkerd install config".`,
 		Example: `  # Default install.
   linkerd install control-plane | kubectl apply -f -
 
@@ -303,13 +494,13 @@ control plane. It should be run after "linkerd install config".`,
   linkerd install control-plane -l linkerdtest | kubectl apply -f -`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if !options.skipChecks {
-				// check if global resources exist to determine if the `install config`
-				// stage succeeded
-				if err := errAfterRunningChecks(options); err == nil {
+				// check if the CRDs and the global resources exist to determine if the
+				// `install crds` and `install config` stages succeeded
+				if err := errIfPreviousStagesMissing(options); err != nil {
 					if healthcheck.IsCategoryError(err, healthcheck.KubernetesAPIChecks) {
 						fmt.Fprintf(os.Stderr, errMsgCannotInitializeClient, err)
 					} else {
-						fmt.Fprintf(os.Stderr, errMsgGlobalResourcesMissing, controlPlaneNamespace)
+						fmt.Fprintf(os.Stderr, errMsgPreviousStagesMissing, controlPlaneNamespace, err)
 					}
 					os.Exit(1)
 				}
@@ -365,13 +556,82 @@ control plane.`,
   # Install Linkerd into a non-default namespace.
   linkerd install -l linkerdtest | kubectl apply -f -
 
-  # Installation may also be broken up into two stages by user privilege, via
-  # subcommands.`,
+  # Installation may also be broken up into three stages by user privilege, via
+  # subcommands.
+  linkerd install crds | kubectl apply -f -
+  linkerd install config | kubectl apply -f -
+  linkerd install control-plane | kubectl apply -f -
+
+  # Validate that the install would be accepted by a throwaway cluster.
+  linkerd install --smoke-test --context kind-linkerd-ci
+
+  # Write each resource to its own file, e.g. to commit them into a GitOps repo.
+  linkerd install --output-dir linkerd-manifests
+
+  # Apply the resources directly to the cluster and wait for them to be ready.
+  linkerd install --apply
+
+  # Mirror the images into a private registry for an air-gapped install.
+  linkerd install --registry registry.example.com/linkerd --list-images | while read src dst; do
+    docker pull $src && docker tag $src $dst && docker push $dst
+  done
+
+  # Install the tracing add-on, without Grafana.
+  linkerd install --addons tracing --skip-addons grafana | kubectl apply -f -
+
+  # Get JSON proxy logs, and debug logs from the destination component only.
+  linkerd install --proxy-log-format json --controller-component-log-level destination=debug | kubectl apply -f -
+
+  # Run the destination and identity components on the arm64 nodes of a mixed-architecture cluster.
+  linkerd install --component-arch destination=arm64,identity=arm64 | kubectl apply -f -
+
+  # Allow the control plane traffic in a cluster enforcing a default-deny NetworkPolicy.
+  linkerd install --enable-network-policies | kubectl apply -f -
+
+  # Install with the FIPS variants of the images, and FIPS-approved crypto only.
+  linkerd install --crypto-policy fips | kubectl apply -f -
+
+  # Install on OpenShift, with SecurityContextConstraints instead of PodSecurityPolicies.
+  linkerd install --openshift | oc apply -f -
+
+  # Check that the cluster's admission controllers accept every resource before applying them.
+  linkerd install --dry-run=server | kubectl apply -f -
+
+  # Emit the resources as a stream of JSON objects, e.g. to post-process them with jq.
+  linkerd install -o json | jq -c 'select(.kind == "Deployment")'
+
+  # Generate a kustomize base, to be patched by overlays.
+  linkerd install --output kustomize --output-dir linkerd-base
+
+  # Read the install configuration from a values file, as used by the Helm chart.
+  linkerd install -f linkerd-values.yaml | kubectl apply -f -
+
+  # Patch the rendered manifest with an executable, e.g. a wrapper around kustomize.
+  linkerd install --post-renderer ./kustomize-wrapper.sh | kubectl apply -f -
+
+  # Declare the control plane as a resource reconciled in-cluster by "linkerd operator".
+  linkerd install crds | kubectl apply -f -
+  linkerd install --crd-output | kubectl apply -f -
+
+  # Override any chart value, even if there's no dedicated flag for it.
+  linkerd install --set global.proxy.logLevel=debug --set-file global.identityTrustAnchorsPEM=ca.crt | kubectl apply -f -`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if options.smokeTest {
+				return installSmokeTestRunE(options, flags)
+			}
+
+			if options.listImages {
+				return installListImagesRunE(stdout, options, flags)
+			}
+
 			if !options.ignoreCluster {
-				if err := errAfterRunningChecks(options); err != nil {
+				if err := errAfterRunningChecks(options, ""); err != nil {
 					if healthcheck.IsCategoryError(err, healthcheck.KubernetesAPIChecks) {
 						fmt.Fprintf(os.Stderr, errMsgCannotInitializeClient, err)
+					} else if healthcheck.IsCategoryError(err, healthcheck.LinkerdPreInstallCapacityChecks) {
+						fmt.Fprintf(os.Stderr, errMsgInsufficientCapacity, err)
+					} else if healthcheck.IsCategoryError(err, healthcheck.LinkerdPreInstallOpenShiftChecks) {
+						fmt.Fprintf(os.Stderr, errMsgOpenShiftSCC, err)
 					} else {
 						fmt.Fprintf(os.Stderr, errMsgGlobalResourcesExist, err)
 					}
@@ -379,6 +639,10 @@ control plane.`,
 				}
 			}
 
+			if options.crdOutput {
+				return installCRDOutputRunE(stdout, options, flags)
+			}
+
 			return installRunE(options, "", flags)
 		},
 	}
@@ -389,6 +653,17 @@ control plane.`,
 	cmd.Flags().AddFlagSet(installOnlyFlags)
 	cmd.PersistentFlags().AddFlagSet(installPersistentFlags)
 
+	cmd.Flags().BoolVar(
+		&options.listImages, "list-images", options.listImages,
+		"List the images used by the control plane and its enabled add-ons, instead of printing the manifest. With --registry or global.registry, each image is followed by the name it must be mirrored to",
+	)
+
+	cmd.Flags().BoolVar(
+		&options.crdOutput, "crd-output", options.crdOutput,
+		"Output a LinkerdControlPlane resource holding the chart values, to be reconciled by \"linkerd operator\", instead of the manifest. The private keys are output in Secrets of the control plane namespace. The CRDs must be installed first with \"linkerd install crds\"",
+	)
+
+	cmd.AddCommand(newCmdInstallCRDs(options, flags))
 	cmd.AddCommand(newCmdInstallConfig(options, flags))
 	cmd.AddCommand(newCmdInstallControlPlane(options))
 
@@ -401,10 +676,122 @@ func installRunE(options *installOptions, stage string, flags *pflag.FlagSet) er
 		return err
 	}
 
-	return render(os.Stdout, values)
+	var buf bytes.Buffer
+	if err := render(&buf, values); err != nil {
+		return err
+	}
+
+	if options.postRenderer != "" {
+		if err := postRender(options.postRenderer, &buf); err != nil {
+			return err
+		}
+	}
+
+	if stage == "" || stage == controlPlaneStage {
+		if err := appendConfigHistory(&buf, nil, "install", values.Configs, options.recordedFlags, time.Now()); err != nil {
+			return fmt.Errorf("could not record the configuration history: %s", err)
+		}
+	}
+
+	if options.dryRun == dryRunServer {
+		k, err := k8s.NewAPI(kubeconfigPath, kubeContext, impersonate, impersonateGroup, 0)
+		if err != nil {
+			return err
+		}
+		if err := dryRunManifest(os.Stderr, k, buf.Bytes()); err != nil {
+			return err
+		}
+	}
+
+	if options.orderManifest != "" {
+		f, err := os.Create(options.orderManifest)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		if err := writeApplyOrder(f, buf.Bytes(), options.outputDir != ""); err != nil {
+			return fmt.Errorf("could not write order manifest: %s", err)
+		}
+	}
+
+	if options.apply {
+		k, err := k8s.NewAPI(kubeconfigPath, kubeContext, impersonate, impersonateGroup, 0)
+		if err != nil {
+			return err
+		}
+		return applyManifest(os.Stdout, k, buf.Bytes(), options.applyTimeout)
+	}
+
+	if options.outputDir != "" {
+		if err := writeManifestDir(options.outputDir, buf.Bytes()); err != nil {
+			return fmt.Errorf("could not write manifests to %s: %s", options.outputDir, err)
+		}
+		if options.output == kustomizeOutput {
+			if err := writeKustomization(options.outputDir, buf.Bytes()); err != nil {
+				return fmt.Errorf("could not write kustomization to %s: %s", options.outputDir, err)
+			}
+		}
+		return nil
+	}
+
+	return writeManifest(os.Stdout, buf.Bytes(), options.output)
+}
+
+// installSmokeTestRunE renders the install manifest and, instead of printing
+// it, validates it against the cluster selected with --context: the RBAC
+// pre-install checks are run and then the manifest goes through
+// dryRunManifest, the same server-side dry-run as `linkerd install
+// --dry-run=server`.
+func installSmokeTestRunE(options *installOptions, flags *pflag.FlagSet) error {
+	values, _, err := options.validateAndBuild("", flags)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err := render(&buf, values); err != nil {
+		return err
+	}
+
+	if options.postRenderer != "" {
+		if err := postRender(options.postRenderer, &buf); err != nil {
+			return err
+		}
+	}
+
+	checks := []healthcheck.CategoryID{
+		healthcheck.KubernetesAPIChecks,
+		healthcheck.KubernetesVersionChecks,
+		healthcheck.LinkerdPreInstallChecks,
+	}
+	hc := healthcheck.NewHealthChecker(checks, &healthcheck.Options{
+		ControlPlaneNamespace: controlPlaneNamespace,
+		KubeConfig:            kubeconfigPath,
+		KubeContext:           kubeContext,
+		Impersonate:           impersonate,
+		ImpersonateGroup:      impersonateGroup,
+		APIAddr:               apiAddr,
+		CNIEnabled:            options.cniEnabled,
+		InstallManifest:       buf.String(),
+	})
+
+	if !runChecksTable(stdout, hc) {
+		os.Exit(1)
+	}
+
+	k, err := k8s.NewAPI(kubeconfigPath, kubeContext, impersonate, impersonateGroup, 0)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(stdout)
+	return dryRunManifest(stdout, k, buf.Bytes())
 }
 
 func (options *installOptions) validateAndBuild(stage string, flags *pflag.FlagSet) (*l5dcharts.Values, *pb.All, error) {
+	if err := options.applyValueFiles(flags); err != nil {
+		return nil, nil, err
+	}
+
 	if err := options.validate(); err != nil {
 		return nil, nil, err
 	}
@@ -419,12 +806,16 @@ func (options *installOptions) validateAndBuild(stage string, flags *pflag.FlagS
 }
 
 func (options *installOptions) validateAndBuildWithIdentity(stage string, identityValues *identityWithAnchorsAndTrustDomain) (*l5dcharts.Values, *pb.All, error) {
+	options.applyProxyResourcesProfile()
 	configs := options.configs(toIdentityContext(identityValues))
 
 	values, err := options.buildValuesWithoutIdentity(configs)
 	if err != nil {
 		return nil, nil, err
 	}
+	if err = checkIdentityCryptoPolicy(identityValues, options.cryptoPolicy); err != nil {
+		return nil, nil, err
+	}
 	values.Identity = identityValues.Identity
 	values.Global.IdentityTrustAnchorsPEM = identityValues.TrustAnchorsPEM
 	values.Global.IdentityTrustDomain = identityValues.TrustDomain
@@ -436,6 +827,15 @@ func (options *installOptions) validateAndBuildWithIdentity(stage string, identi
 		return nil, nil, err
 	}
 
+	// Apply the --set* overrides last, so that they take precedence
+	if err = options.valuesOptions.applyTo(values, configs); err != nil {
+		return nil, nil, err
+	}
+
+	if err = values.Validate(); err != nil {
+		return nil, nil, err
+	}
+
 	if options.enableEndpointSlices {
 		if err = validateEndpointSlicesFeature(); err != nil {
 			return nil, nil, fmt.Errorf("--enableEndpointSlice=true not supported: %s", err)
@@ -463,15 +863,31 @@ func (options *installOptions) recordableFlagSet() *pflag.FlagSet {
 		&options.controllerLogLevel, "controller-log-level", options.controllerLogLevel,
 		"Log level for the controller and web components",
 	)
+	flags.StringToStringVar(
+		&options.componentLogLevels, "controller-component-log-level", options.componentLogLevels,
+		"Log level of individual control plane components, overriding --controller-log-level, e.g. controller=debug,destination=info",
+	)
 
 	flags.BoolVar(
 		&options.highAvailability, "ha", options.highAvailability,
-		"Enable HA deployment config for the control plane (default false)",
+		"Enable HA deployment config for the control plane: multiple replicas, pod anti-affinity, topology spread constraints and PodDisruptionBudgets (default false)",
 	)
 	flags.Int64Var(
 		&options.controllerUID, "controller-uid", options.controllerUID,
 		"Run the control plane components under this user ID",
 	)
+	flags.StringSliceVar(
+		&options.imageArchitectures, "image-architectures", options.imageArchitectures,
+		fmt.Sprintf("Architectures the control plane and proxy images are built for. Nodes that can run the control plane must have one of them. Supported: %s", strings.Join(supportedArchitectures, ", ")),
+	)
+	flags.StringVar(
+		&options.cryptoPolicy, "crypto-policy", options.cryptoPolicy,
+		fmt.Sprintf("Crypto policy of the control plane and the proxies. One of: %s. With %q, the FIPS variants of the proxy and controller images are used, the identity issuer and trust anchors must have FIPS-approved keys, and the control plane only negotiates FIPS-approved TLS cipher suites", strings.Join(tls.CryptoPolicies, ", "), tls.CryptoPolicyFIPS),
+	)
+	flags.StringToStringVar(
+		&options.componentArchitectures, "component-arch", options.componentArchitectures,
+		fmt.Sprintf("Run a control plane component on the nodes of the given architecture, e.g. destination=arm64. The component gets a %s node selector, and tolerates the NoSchedule taint with the same key and value", k8s.ArchLabel),
+	)
 	flags.BoolVar(
 		&options.disableH2Upgrade, "disable-h2-upgrade", options.disableH2Upgrade,
 		"Prevents the controller from instructing proxies to perform transparent HTTP/2 upgrading (default false)",
@@ -480,10 +896,30 @@ func (options *installOptions) recordableFlagSet() *pflag.FlagSet {
 		&options.disableHeartbeat, "disable-heartbeat", options.disableHeartbeat,
 		"Disables the heartbeat cronjob (default false)",
 	)
+	flags.BoolVar(
+		&options.enableHealthExporter, "enable-health-exporter", options.enableHealthExporter,
+		"Run the health exporter, which runs the checks of \"linkerd check\" on an interval and exposes their results as Prometheus metrics and Kubernetes events (default false)",
+	)
+	flags.BoolVar(
+		&options.enableIdentityRotation, "enable-identity-rotation", options.enableIdentityRotation,
+		"Run the identity rotation cronjob, which completes the issuer rotations started by \"linkerd upgrade --rotate-issuer\" (default false)",
+	)
+	flags.BoolVar(
+		&options.enableTrustBundle, "enable-trust-bundle", options.enableTrustBundle,
+		"Run the trust bundle controller, which publishes the trust anchors into a ConfigMap in every namespace selected by --trust-bundle-namespace-selector (default false)",
+	)
+	flags.StringVar(
+		&options.trustBundleNSSelector, "trust-bundle-namespace-selector", options.trustBundleNSSelector,
+		"Label selector of the namespaces the trust bundle controller publishes the trust anchors into; all namespaces when empty",
+	)
 	flags.DurationVar(
 		&options.identityOptions.issuanceLifetime, "identity-issuance-lifetime", options.identityOptions.issuanceLifetime,
 		"The amount of time for which the Identity issuer should certify identity",
 	)
+	flags.DurationVar(
+		&options.identityOptions.renewalJitter, "identity-renewal-jitter", options.identityOptions.renewalJitter,
+		"The maximum random amount of time by which the lifetime of each proxy certificate is shortened, to spread their renewals",
+	)
 	flags.DurationVar(
 		&options.identityOptions.clockSkewAllowance, "identity-clock-skew-allowance", options.identityOptions.clockSkewAllowance,
 		"The amount of time to allow for clock skew within a Linkerd cluster",
@@ -492,6 +928,18 @@ func (options *installOptions) recordableFlagSet() *pflag.FlagSet {
 		&options.omitWebhookSideEffects, "omit-webhook-side-effects", options.omitWebhookSideEffects,
 		"Omit the sideEffects flag in the webhook manifests, This flag must be provided during install or upgrade for Kubernetes versions pre 1.12",
 	)
+	flags.StringVar(
+		&options.injectorFailurePolicy, "proxy-injector-failure-policy", options.injectorFailurePolicy,
+		"Failure policy of the proxy injector webhook, Ignore or Fail: whether the pods are created without the proxy or rejected when the proxy injector can't be reached (default: Ignore, or Fail with --ha)",
+	)
+	flags.StringVar(
+		&options.injectorNamespaceSelector, "proxy-injector-namespace-selector", options.injectorNamespaceSelector,
+		"Label selector of the namespaces whose pods are sent to the proxy injector, e.g. 'kubernetes.io/metadata.name notin (kube-system)' or 'linkerd.io/inject' to only consider the opted-in namespaces. The namespaces labeled config.linkerd.io/admission-webhooks=disabled are always excluded",
+	)
+	flags.StringVar(
+		&options.injectorObjectSelector, "proxy-injector-object-selector", options.injectorObjectSelector,
+		"Label selector of the pods sent to the proxy injector (default: all the pods)",
+	)
 	flags.BoolVar(
 		&options.controlPlaneTracing, "control-plane-tracing", options.controlPlaneTracing,
 		"Enables Control Plane Tracing with the defaults",
@@ -504,13 +952,43 @@ func (options *installOptions) recordableFlagSet() *pflag.FlagSet {
 		&options.identityOptions.keyPEMFile, "identity-issuer-key-file", options.identityOptions.keyPEMFile,
 		"A path to a PEM-encoded file containing the Linkerd Identity issuer private key (generated by default)",
 	)
+	flags.StringVar(
+		&options.identityOptions.keyURI, "identity-issuer-key-uri", options.identityOptions.keyURI,
+		fmt.Sprintf("URI of the Linkerd Identity issuer private key held by a KMS, which performs the signing operations instead of the identity service holding the key (e.g. %s://projects/<project>/locations/<location>/keyRings/<ring>/cryptoKeys/<key>/cryptoKeyVersions/<version>); requires --identity-issuer-certificate-file and --identity-trust-anchors-file", kms.GCPScheme),
+	)
+	flags.StringVar(
+		&options.identityOptions.spire.ServerAddr, "identity-spire-server-addr", options.identityOptions.spire.ServerAddr,
+		"Address (host:port) of a SPIRE server the Linkerd Identity issuer certificate is obtained from, instead of an issuer certificate and key; requires --identity-trust-anchors-file with the SPIRE bundle",
+	)
+	flags.StringVar(
+		&options.identityOptions.spire.AgentSocketPath, "identity-spire-agent-socket-path", options.identityOptions.spire.AgentSocketPath,
+		"Path of the SPIRE agent Workload API socket on the nodes, from which the Linkerd Identity service fetches the X509-SVID it authenticates to the SPIRE server with",
+	)
 	flags.StringVar(
 		&options.identityOptions.trustPEMFile, "identity-trust-anchors-file", options.identityOptions.trustPEMFile,
 		"A path to a PEM-encoded file containing Linkerd Identity trust anchors (generated by default)",
 	)
 
+	flags.UintVar(
+		&options.controlPlaneMetricsPort, "control-plane-metrics-port", options.controlPlaneMetricsPort,
+		"Port on which all the control plane components serve metrics (default 0, which keeps each component's default port)",
+	)
+	flags.StringVar(
+		&options.controlPlaneMetricsScheme, "control-plane-metrics-scheme", options.controlPlaneMetricsScheme,
+		"Scheme used to scrape the control plane components' metrics. One of: http, https",
+	)
+	flags.StringVar(
+		&options.prometheusURL, "prometheus-url", options.prometheusURL,
+		"URL of an existing Prometheus queried by the control plane, instead of installing the bundled Prometheus add-on. The scrape configs it needs are rendered into the linkerd-prometheus-scrape-config ConfigMap",
+	)
+	flags.StringSliceVar(
+		&options.imagePullSecrets, "image-pull-secrets", options.imagePullSecrets,
+		"Names of the Secrets used to pull the images from an authenticated registry, attached to every ServiceAccount and pod template of the control plane and its add-ons",
+	)
+
 	flags.BoolVar(&options.enableEndpointSlices, "enable-endpoint-slices", options.enableEndpointSlices,
 		"Enables the usage of EndpointSlice informers and resources for destination service")
+	flags.AddFlagSet(options.valuesOptions.overridesFlagSet())
 
 	flags.StringVarP(&options.controlPlaneVersion, "control-plane-version", "", options.controlPlaneVersion, "Tag to be used for the control plane component images")
 
@@ -542,6 +1020,15 @@ func (options *installOptions) allStageFlagSet() *pflag.FlagSet {
 		"Omit the NET_ADMIN capability in the PSP and the proxy-init container when injecting the proxy; requires the linkerd-cni plugin to already be installed",
 	)
 
+	flags.BoolVar(&options.openshift, "openshift", options.openshift,
+		"Render SecurityContextConstraints instead of PodSecurityPolicies, and run the OpenShift checks before installing",
+	)
+
+	flags.BoolVar(
+		&options.enableNetworkPolicies, "enable-network-policies", options.enableNetworkPolicies,
+		"Create NetworkPolicies restricting the traffic to the control plane to what it needs, for clusters enforcing a default-deny policy; the proxies of other namespaces are only covered when listed in the dataPlaneNetworkPolicyNamespaces value, and an external Prometheus must be selected by the networkPolicyPrometheusSelector value (default false)",
+	)
+
 	flags.BoolVar(
 		&options.restrictDashboardPrivileges, "restrict-dashboard-privileges", options.restrictDashboardPrivileges,
 		"Restrict the Linkerd Dashboard's default privileges to disallow Tap and Check",
@@ -551,6 +1038,14 @@ func (options *installOptions) allStageFlagSet() *pflag.FlagSet {
 		&options.addOnConfig, "addon-config", options.addOnConfig,
 		"A path to a configuration file of add-ons. If add-on config already exists, this new config gets merged with the existing one (unless --addon-overwrite is used)",
 	)
+	flags.StringSliceVar(
+		&options.addOns, "addons", options.addOns,
+		fmt.Sprintf("Add-ons to enable, on top of the ones enabled by default or by --addon-config. Any of: %s", strings.Join(l5dcharts.AddOnNames, ", ")),
+	)
+	flags.StringSliceVar(
+		&options.skipAddOns, "skip-addons", options.skipAddOns,
+		"Add-ons to disable, even if enabled by default or by --addon-config",
+	)
 
 	return flags
 }
@@ -568,9 +1063,19 @@ func (options *installOptions) installOnlyFlagSet() *pflag.FlagSet {
 		&options.identityOptions.trustDomain, "identity-trust-domain", options.identityOptions.trustDomain,
 		"Configures the name suffix used for identities.",
 	)
+	flags.Var(
+		&externalIssuerValue{options.identityOptions}, "identity-external-issuer",
+		fmt.Sprintf("Whether to use an external identity issuer; set to \"%s\" to have cert-manager issue and rotate the issuer certificate from the trust anchor stored in the '%s' secret (default false)",
+			externalIssuerCertManager, options.identityOptions.certManager.TrustAnchorSecret),
+	)
+	flags.Lookup("identity-external-issuer").NoOptDefVal = "true"
+	flags.StringVar(
+		&options.identityOptions.issuerKeyAlgorithm, "identity-issuer-key-algorithm", options.identityOptions.issuerKeyAlgorithm,
+		fmt.Sprintf("Algorithm of the Linkerd Identity issuer key, which is generated with it or must be used by the provided issuer certificate. One of: %s", strings.Join(tls.KeyAlgorithms, ", ")),
+	)
 	flags.BoolVar(
-		&options.identityOptions.identityExternalIssuer, "identity-external-issuer", options.identityOptions.identityExternalIssuer,
-		"Whether to use an external identity issuer (default false)",
+		&options.smokeTest, "smoke-test", options.smokeTest,
+		"Validate the rendered manifests against the cluster selected with --context using server-side dry-run, instead of printing them (default false)",
 	)
 	return flags
 }
@@ -584,6 +1089,32 @@ func (options *installOptions) installPersistentFlagSet() *pflag.FlagSet {
 		&options.ignoreCluster, "ignore-cluster", options.ignoreCluster,
 		"Ignore the current Kubernetes cluster when checking for existing cluster configuration (default false)",
 	)
+	flags.StringVar(
+		&options.orderManifest, "order-manifest", options.orderManifest,
+		"A path to a file where the recommended apply order of the rendered resources is written (namespace, CRDs, RBAC, config, workloads, webhooks)",
+	)
+	flags.StringVar(
+		&options.outputDir, "output-dir", options.outputDir,
+		"Write each rendered resource to its own file (named kind_namespace_name.yaml) in this directory, instead of printing the manifest; the files written by a previous run that aren't rendered anymore are removed",
+	)
+	flags.StringVarP(
+		&options.output, "output", "o", options.output,
+		fmt.Sprintf("Output format. One of: %s, %s (a stream of JSON objects, one per line), %s (a kustomize base written to --output-dir)", yamlOutput, jsonOutput, kustomizeOutput),
+	)
+	flags.BoolVar(
+		&options.apply, "apply", options.apply,
+		"Apply the rendered resources to the cluster with server-side apply and wait for them to be ready, instead of printing them (default false)",
+	)
+	flags.DurationVar(
+		&options.applyTimeout, "apply-timeout", options.applyTimeout,
+		"How long to wait for the control plane workloads to be ready when using --apply",
+	)
+	flags.StringVar(
+		&options.dryRun, "dry-run", options.dryRun,
+		fmt.Sprintf("Must be %q or %q. With %q, every rendered resource is first submitted to the cluster with server-side dry-run, and nothing is printed or applied if any of them is rejected. The namespaces that don't exist yet are temporarily created for the dry-run", dryRunNone, dryRunServer, dryRunServer),
+	)
+	flags.AddFlagSet(options.valuesOptions.valueFilesFlagSet())
+	flags.AddFlagSet(options.postRendererFlagSet())
 
 	return flags
 }
@@ -623,6 +1154,18 @@ func (options *installOptions) UpdateAddOnValuesFromConfig(values *l5dcharts.Val
 
 	}
 
+	// --addons and --skip-addons take precedence over the add-on config
+	for _, name := range options.addOns {
+		if err := l5dcharts.SetAddOnEnabled(values, name, true); err != nil {
+			return fmt.Errorf("--addons: %s", err)
+		}
+	}
+	for _, name := range options.skipAddOns {
+		if err := l5dcharts.SetAddOnEnabled(values, name, false); err != nil {
+			return fmt.Errorf("--skip-addons: %s", err)
+		}
+	}
+
 	return nil
 }
 
@@ -654,6 +1197,8 @@ func (options *installOptions) recordFlags(flags *pflag.FlagSet) {
 			switch f.Name {
 			case "ignore-cluster", "control-plane-version", "proxy-version", "identity-issuer-certificate-file", "identity-issuer-key-file", "identity-trust-anchors-file", "addon-config":
 				// These flags don't make sense to record.
+			case "set", "set-string", "set-file":
+				// These flags are recorded once per value below.
 			default:
 				options.recordedFlags = append(options.recordedFlags, &pb.Install_Flag{
 					Name:  f.Name,
@@ -662,24 +1207,120 @@ func (options *installOptions) recordFlags(flags *pflag.FlagSet) {
 			}
 		}
 	})
+
+	options.recordedFlags = append(options.recordedFlags, options.valuesOptions.recordedFlags()...)
 }
 
 func (options *installOptions) validate() error {
-	if options.ignoreCluster && options.identityOptions.identityExternalIssuer {
+	if options.ignoreCluster && options.identityOptions.identityExternalIssuer && !options.identityOptions.certManager.Enabled {
 		return errors.New("--ignore-cluster is not supported when --identity-external-issuer=true")
 	}
 
+	if options.smokeTest {
+		if options.ignoreCluster {
+			return errors.New("--smoke-test cannot be used with --ignore-cluster")
+		}
+		// Avoid accidentally exercising whatever the current context points to
+		if kubeContext == "" {
+			return errors.New("--smoke-test requires an explicit target cluster to be set with --context")
+		}
+	}
+
 	if options.controlPlaneVersion != "" && !alphaNumDashDot.MatchString(options.controlPlaneVersion) {
 		return fmt.Errorf("%s is not a valid version", options.controlPlaneVersion)
 	}
 
+	if options.apply {
+		switch {
+		case options.ignoreCluster:
+			return errors.New("--apply cannot be used with --ignore-cluster")
+		case options.smokeTest:
+			return errors.New("--apply cannot be used with --smoke-test")
+		case options.outputDir != "":
+			return errors.New("--apply cannot be used with --output-dir")
+		case options.output != yamlOutput:
+			return errors.New("--apply cannot be used with --output")
+		}
+	}
+
+	for _, secret := range options.imagePullSecrets {
+		if errs := validation.IsDNS1123Subdomain(secret); len(errs) > 0 {
+			return fmt.Errorf("invalid image pull secret '%s': %s", secret, errs[0])
+		}
+	}
+
+	if len(options.imageArchitectures) == 0 {
+		return errors.New("--image-architectures must not be empty")
+	}
+	for _, arch := range options.imageArchitectures {
+		if !containsString(supportedArchitectures, arch) {
+			return fmt.Errorf("--image-architectures: unsupported architecture '%s', must be one of: %s", arch, strings.Join(supportedArchitectures, ", "))
+		}
+	}
+	for component, arch := range options.componentArchitectures {
+		if !containsString(schedulableComponents, component) {
+			return fmt.Errorf("--component-arch: unknown component '%s', must be one of: %s", component, strings.Join(schedulableComponents, ", "))
+		}
+		if !containsString(options.imageArchitectures, arch) {
+			return fmt.Errorf("--component-arch: the images aren't built for %s, which isn't in --image-architectures", arch)
+		}
+	}
+
+	switch options.dryRun {
+	case dryRunNone:
+	case dryRunServer:
+		switch {
+		case options.ignoreCluster:
+			return errors.New("--dry-run=server cannot be used with --ignore-cluster")
+		case options.smokeTest:
+			return errors.New("--dry-run=server cannot be used with --smoke-test")
+		}
+	default:
+		return fmt.Errorf("--dry-run must be one of: %s, %s", dryRunNone, dryRunServer)
+	}
+
+	switch options.output {
+	case yamlOutput:
+	case jsonOutput:
+		if options.outputDir != "" {
+			return errors.New("--output json cannot be used with --output-dir")
+		}
+	case kustomizeOutput:
+		if options.outputDir == "" {
+			return errors.New("--output kustomize requires --output-dir to be set")
+		}
+	default:
+		return fmt.Errorf("--output must be one of: %s, %s, %s", yamlOutput, jsonOutput, kustomizeOutput)
+	}
+
 	if options.identityOptions == nil {
 		// Programmer error: identityOptions may be empty, but it must be set by the constructor.
 		panic("missing identity options")
 	}
 
 	if _, err := log.ParseLevel(options.controllerLogLevel); err != nil {
-		return fmt.Errorf("--controller-log-level must be one of: panic, fatal, error, warn, info, debug")
+		return fmt.Errorf("--controller-log-level must be one of: panic, fatal, error, warn, info, debug, trace")
+	}
+	for component, level := range options.componentLogLevels {
+		if !containsString(schedulableComponents, component) {
+			return fmt.Errorf("--controller-component-log-level: unknown component '%s', must be one of: %s", component, strings.Join(schedulableComponents, ", "))
+		}
+		if _, err := log.ParseLevel(level); err != nil {
+			return fmt.Errorf("--controller-component-log-level: the log level of %s must be one of: panic, fatal, error, warn, info, debug, trace", component)
+		}
+	}
+
+	if options.injectorFailurePolicy != "" && options.injectorFailurePolicy != "Ignore" && options.injectorFailurePolicy != "Fail" {
+		return fmt.Errorf("--proxy-injector-failure-policy must be one of: Ignore, Fail")
+	}
+	if _, err := metav1.ParseToLabelSelector(options.injectorNamespaceSelector); err != nil {
+		return fmt.Errorf("--proxy-injector-namespace-selector is invalid: %s", err)
+	}
+	if _, err := metav1.ParseToLabelSelector(options.injectorObjectSelector); err != nil {
+		return fmt.Errorf("--proxy-injector-object-selector is invalid: %s", err)
+	}
+	if _, err := labels.Parse(options.trustBundleNSSelector); err != nil {
+		return fmt.Errorf("--trust-bundle-namespace-selector is invalid: %s", err)
 	}
 
 	if err := options.proxyConfigOptions.validate(); err != nil {
@@ -689,7 +1330,78 @@ func (options *installOptions) validate() error {
 		return errors.New("--proxy-log-level must not be empty")
 	}
 
-	return nil
+	if err := options.validateControlPlaneMetrics(); err != nil {
+		return err
+	}
+
+	if options.prometheusURL != "" {
+		u, err := url.ParseRequestURI(options.prometheusURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("--prometheus-url must be an absolute http or https URL, got '%s'", options.prometheusURL)
+		}
+	}
+
+	if !containsString(tls.CryptoPolicies, options.cryptoPolicy) {
+		return fmt.Errorf("--crypto-policy must be one of: %s", strings.Join(tls.CryptoPolicies, ", "))
+	}
+
+	for _, name := range options.addOns {
+		if !containsString(l5dcharts.AddOnNames, name) {
+			return fmt.Errorf("--addons: unknown add-on '%s', must be one of: %s", name, strings.Join(l5dcharts.AddOnNames, ", "))
+		}
+		if containsString(options.skipAddOns, name) {
+			return fmt.Errorf("the %s add-on can't be both in --addons and --skip-addons", name)
+		}
+		if name == l5dcharts.PrometheusAddOn && options.prometheusURL != "" {
+			return errors.New("--addons: the prometheus add-on can't be enabled with --prometheus-url")
+		}
+	}
+	for _, name := range options.skipAddOns {
+		if !containsString(l5dcharts.AddOnNames, name) {
+			return fmt.Errorf("--skip-addons: unknown add-on '%s', must be one of: %s", name, strings.Join(l5dcharts.AddOnNames, ", "))
+		}
+		if name == l5dcharts.PrometheusAddOn && options.prometheusURL == "" {
+			return errors.New("--skip-addons: the control plane needs a Prometheus, set --prometheus-url to skip the prometheus add-on")
+		}
+	}
+
+	return nil
+}
+
+// validateControlPlaneMetrics ensures the control plane metrics port doesn't
+// collide with any of the ports used by the proxy or by the control plane
+// components' APIs.
+func (options *installOptions) validateControlPlaneMetrics() error {
+	if options.controlPlaneMetricsScheme != "http" && options.controlPlaneMetricsScheme != "https" {
+		return fmt.Errorf("--control-plane-metrics-scheme must be one of: http, https")
+	}
+
+	port := options.controlPlaneMetricsPort
+	if port == 0 {
+		return nil
+	}
+	if port > 65535 {
+		return fmt.Errorf("--control-plane-metrics-port must be a valid port number, got %d", port)
+	}
+
+	proxyPorts := map[string]uint{
+		"--inbound-port":  options.proxyInboundPort,
+		"--outbound-port": options.proxyOutboundPort,
+		"--admin-port":    options.proxyAdminPort,
+		"--control-port":  options.proxyControlPort,
+	}
+	for flag, p := range proxyPorts {
+		if p == port {
+			return fmt.Errorf("--control-plane-metrics-port %d conflicts with the proxy port set by %s", port, flag)
+		}
+	}
+	for _, p := range controlPlaneAPIPorts {
+		if p == port {
+			return fmt.Errorf("--control-plane-metrics-port %d conflicts with a port used by the control plane components", port)
+		}
+	}
+
+	return nil
 }
 
 func validateEndpointSlicesFeature() error {
@@ -709,6 +1421,12 @@ func (options *installOptions) buildValuesWithoutIdentity(configs *pb.All) (*l5d
 		return nil, err
 	}
 
+	// The values files are merged onto the defaults before applying the
+	// options, so that they also cover the values without a dedicated flag.
+	if err := options.valuesOptions.mergeValueFiles(installValues); err != nil {
+		return nil, err
+	}
+
 	if options.highAvailability {
 		// use the HA defaults if CLI options aren't provided
 		if options.controllerReplicas == 1 {
@@ -762,29 +1480,85 @@ func (options *installOptions) buildValuesWithoutIdentity(configs *pb.All) (*l5d
 	installValues.Configs.Global = globalJSON
 	installValues.Configs.Proxy = proxyJSON
 	installValues.Configs.Install = installJSON
-	installValues.ControllerImage = fmt.Sprintf("%s/controller", options.dockerRegistry)
+	installValues.ControllerImage = options.cryptoPolicyImage(fmt.Sprintf("%s/controller", options.dockerRegistry))
 	installValues.Global.ControllerImageVersion = configs.GetGlobal().GetVersion()
 	installValues.Global.ControllerLogLevel = options.controllerLogLevel
+	for component, level := range options.componentLogLevels {
+		if installValues.ComponentLogLevels == nil {
+			installValues.ComponentLogLevels = map[string]string{}
+		}
+		installValues.ComponentLogLevels[component] = level
+	}
 	installValues.ControllerReplicas = options.controllerReplicas
 	installValues.ControllerUID = options.controllerUID
 	installValues.Global.ControlPlaneTracing = options.controlPlaneTracing
+	installValues.Global.ControlPlaneMetrics = &l5dcharts.ControlPlaneMetrics{
+		Port:   uint32(options.controlPlaneMetricsPort),
+		Scheme: options.controlPlaneMetricsScheme,
+	}
+	if options.prometheusURL != "" {
+		// the external Prometheus replaces the bundled one
+		installValues.Global.PrometheusURL = options.prometheusURL
+		if installValues.Prometheus == nil {
+			installValues.Prometheus = l5dcharts.Prometheus{}
+		}
+		installValues.Prometheus["enabled"] = false
+	}
 	installValues.EnableH2Upgrade = !options.disableH2Upgrade
 	installValues.EnablePodAntiAffinity = options.highAvailability
 	installValues.Global.HighAvailability = options.highAvailability
 	installValues.Global.ImagePullPolicy = options.imagePullPolicy
+	installValues.Global.ImagePullSecrets = nil
+	for _, secret := range options.imagePullSecrets {
+		installValues.Global.ImagePullSecrets = append(installValues.Global.ImagePullSecrets, map[string]string{"name": secret})
+	}
 	installValues.Global.Namespace = controlPlaneNamespace
 	installValues.Global.CNIEnabled = options.cniEnabled
+	installValues.Global.OpenShift = options.openshift
+	installValues.EnableNetworkPolicies = options.enableNetworkPolicies
+	installValues.Global.CryptoPolicy = options.cryptoPolicy
+	installValues.Global.ImageArchitectures = options.imageArchitectures
+	for component, arch := range options.componentArchitectures {
+		if installValues.ComponentNodeSelectors == nil {
+			installValues.ComponentNodeSelectors = map[string]map[string]string{}
+		}
+		if installValues.ComponentNodeSelectors[component] == nil {
+			installValues.ComponentNodeSelectors[component] = map[string]string{}
+		}
+		installValues.ComponentNodeSelectors[component][k8s.ArchLabel] = arch
+
+		if installValues.ComponentTolerations == nil {
+			installValues.ComponentTolerations = map[string][]interface{}{}
+		}
+		installValues.ComponentTolerations[component] = append(installValues.ComponentTolerations[component], map[string]interface{}{
+			"key":      k8s.ArchLabel,
+			"operator": "Equal",
+			"value":    arch,
+			"effect":   "NoSchedule",
+		})
+	}
 	installValues.Global.EnableEndpointSlices = options.enableEndpointSlices
 	installValues.OmitWebhookSideEffects = options.omitWebhookSideEffects
+	installValues.ProxyInjector.FailurePolicy = options.injectorFailurePolicy
+	if options.injectorNamespaceSelector != "" {
+		// validated above
+		installValues.ProxyInjector.NamespaceSelector, _ = metav1.ParseToLabelSelector(options.injectorNamespaceSelector)
+	}
+	if options.injectorObjectSelector != "" {
+		installValues.ProxyInjector.ObjectSelector, _ = metav1.ParseToLabelSelector(options.injectorObjectSelector)
+	}
 	installValues.HeartbeatSchedule = options.heartbeatSchedule()
 	installValues.RestrictDashboardPrivileges = options.restrictDashboardPrivileges
 	installValues.DisableHeartBeat = options.disableHeartbeat
+	installValues.EnableHealthExporter = options.enableHealthExporter
+	installValues.EnableTrustBundle = options.enableTrustBundle
+	installValues.EnableIdentityRotation = options.enableIdentityRotation
+	installValues.TrustBundleNamespaceSelector = options.trustBundleNSSelector
 	installValues.WebImage = fmt.Sprintf("%s/web", options.dockerRegistry)
-	if options.dockerRegistry != "ghcr.io/linkerd" {
-		if installValues.Grafana["image"] == nil {
-			installValues.Grafana["image"] = map[string]interface{}{}
-		}
-		installValues.Grafana["image"].(map[string]interface{})["name"] = fmt.Sprintf("%s/grafana", options.dockerRegistry)
+	if options.dockerRegistry != defaultDockerRegistry {
+		// the chart takes care of the images not under the default registry,
+		// like the add-ons' ones
+		installValues.Global.Registry = options.dockerRegistry
 	}
 
 	installValues.Global.Proxy = &l5dcharts.Proxy{
@@ -792,8 +1566,13 @@ func (options *installOptions) buildValuesWithoutIdentity(configs *pb.All) (*l5d
 		EnableExternalProfiles: options.enableExternalProfiles,
 		OutboundConnectTimeout: options.outboundConnectTimeout,
 		InboundConnectTimeout:  options.inboundConnectTimeout,
+
+		OutboundConnectBackoffMin:    options.outboundConnectBackoffMin,
+		OutboundConnectBackoffMax:    options.outboundConnectBackoffMax,
+		OutboundConnectBackoffJitter: options.outboundConnectBackoffJitter,
+		ExcludedNodeSelector:         options.excludedNodeSelector,
 		Image: &l5dcharts.Image{
-			Name:       registryOverride(options.proxyImage, options.dockerRegistry),
+			Name:       options.cryptoPolicyImage(registryOverride(options.proxyImage, options.dockerRegistry)),
 			PullPolicy: options.imagePullPolicy,
 			Version:    options.proxyVersion,
 		},
@@ -858,7 +1637,7 @@ func render(w io.Writer, values *l5dcharts.Values) error {
 		addOnCharts[addOn.Name()] = &charts.Chart{
 			Name:      addOn.Name(),
 			Dir:       addOnChartsPath + "/" + addOn.Name(),
-			Namespace: controlPlaneNamespace,
+			Namespace: values.Global.Namespace,
 			RawValues: append(addOn.Values(), rawValues...),
 			Files: []*chartutil.BufferedFile{
 				{
@@ -871,6 +1650,14 @@ func render(w io.Writer, values *l5dcharts.Values) error {
 		}
 	}
 
+	if values.Stage == "" || values.Stage == crdsStage {
+		for _, template := range templatesCRDsStage {
+			files = append(files,
+				&chartutil.BufferedFile{Name: template},
+			)
+		}
+	}
+
 	if values.Stage == "" || values.Stage == configStage {
 		for _, template := range templatesConfigStage {
 			files = append(files,
@@ -902,7 +1689,7 @@ func render(w io.Writer, values *l5dcharts.Values) error {
 	chart := &charts.Chart{
 		Name:      helmDefaultChartName,
 		Dir:       helmDefaultChartDir,
-		Namespace: controlPlaneNamespace,
+		Namespace: values.Global.Namespace,
 		RawValues: rawValues,
 		Files:     files,
 	}
@@ -963,9 +1750,9 @@ func (options *installOptions) proxyConfig() *pb.Proxy {
 		ignoreOutboundPorts = append(ignoreOutboundPorts, &pb.PortRange{PortRange: portOrRange})
 	}
 
-	return &pb.Proxy{
+	proxy := &pb.Proxy{
 		ProxyImage: &pb.Image{
-			ImageName:  registryOverride(options.proxyImage, options.dockerRegistry),
+			ImageName:  options.cryptoPolicyImage(registryOverride(options.proxyImage, options.dockerRegistry)),
 			PullPolicy: options.imagePullPolicy,
 		},
 		ProxyInitImage: &pb.Image{
@@ -1005,32 +1792,98 @@ func (options *installOptions) proxyConfig() *pb.Proxy {
 			ImageName:  registryOverride(options.debugImage, options.dockerRegistry),
 			PullPolicy: options.imagePullPolicy,
 		},
-		DebugImageVersion: options.debugImageVersion,
+		DebugImageVersion:            options.debugImageVersion,
+		OutboundConnectTimeout:       options.outboundConnectTimeout,
+		InboundConnectTimeout:        options.inboundConnectTimeout,
+		OutboundConnectBackoffMin:    options.outboundConnectBackoffMin,
+		OutboundConnectBackoffMax:    options.outboundConnectBackoffMax,
+		OutboundConnectBackoffJitter: options.outboundConnectBackoffJitter,
+		ExcludedNodeSelector:         options.excludedNodeSelector,
+	}
+	omitDefaultConnectTimeouts(proxy)
+	return proxy
+}
+
+// omitDefaultConnectTimeouts clears the connect timeouts of proxy that are
+// left at the chart defaults, so that linkerd-config only holds the customized
+// ones and the injected proxies only get the env vars for those.
+func omitDefaultConnectTimeouts(proxy *pb.Proxy) {
+	defaults, err := l5dcharts.NewValues(false)
+	if err != nil {
+		return
+	}
+	if proxy.OutboundConnectTimeout == defaults.Global.Proxy.OutboundConnectTimeout {
+		proxy.OutboundConnectTimeout = ""
+	}
+	if proxy.InboundConnectTimeout == defaults.Global.Proxy.InboundConnectTimeout {
+		proxy.InboundConnectTimeout = ""
 	}
 }
 
-func errAfterRunningChecks(options *installOptions) error {
-	checks := []healthcheck.CategoryID{
-		healthcheck.KubernetesAPIChecks,
-		healthcheck.LinkerdPreInstallGlobalResourcesChecks,
+func errAfterRunningChecks(options *installOptions, stage string) error {
+	checks := []healthcheck.CategoryID{healthcheck.KubernetesAPIChecks}
+	if stage != crdsStage {
+		checks = append(checks, healthcheck.LinkerdPreInstallGlobalResourcesChecks)
 	}
+	if stage != configStage && stage != controlPlaneStage {
+		checks = append(checks, healthcheck.LinkerdPreInstallCRDsChecks)
+	}
+	if options.openshift && stage != controlPlaneStage {
+		checks = append(checks, healthcheck.LinkerdPreInstallOpenShiftChecks)
+	}
+
+	// The capacity and conflicts checks are only run for a full install, since
+	// the crds and config stages don't deploy the control plane
+	var requests corev1.ResourceList
+	var scheduling []healthcheck.ComponentScheduling
+	if stage == "" {
+		checks = append(checks, healthcheck.LinkerdPreInstallCapacityChecks)
+		checks = append(checks, healthcheck.LinkerdPreInstallConflictsChecks)
+
+		values, err := options.buildValuesWithoutIdentity(options.configs(nil))
+		if err != nil {
+			return err
+		}
+		requests, err = controlPlaneRequests(values)
+		if err != nil {
+			return err
+		}
+		scheduling, err = controlPlaneScheduling(values)
+		if err != nil {
+			return err
+		}
+	}
+
 	hc := healthcheck.NewHealthChecker(checks, &healthcheck.Options{
-		ControlPlaneNamespace: controlPlaneNamespace,
-		KubeConfig:            kubeconfigPath,
-		Impersonate:           impersonate,
-		ImpersonateGroup:      impersonateGroup,
-		KubeContext:           kubeContext,
-		APIAddr:               apiAddr,
-		CNIEnabled:            options.cniEnabled,
+		ControlPlaneNamespace:  controlPlaneNamespace,
+		KubeConfig:             kubeconfigPath,
+		Impersonate:            impersonate,
+		ImpersonateGroup:       impersonateGroup,
+		KubeContext:            kubeContext,
+		APIAddr:                apiAddr,
+		CNIEnabled:             options.cniEnabled,
+		ControlPlaneRequests:   requests,
+		ImageArchitectures:     options.imageArchitectures,
+		ControlPlaneScheduling: scheduling,
 	})
 
 	var k8sAPIError error
 	errMsgs := []string{}
+	capacityErrMsgs := []string{}
+	openshiftErrMsgs := []string{}
 	hc.RunChecks(func(result *healthcheck.CheckResult) {
+		if result.Err != nil && result.Warning {
+			fmt.Fprintf(os.Stderr, "%s %s: %s\n", warnStatus, result.Description, result.Err)
+			return
+		}
 		if result.Err != nil {
 			if ce, ok := result.Err.(*healthcheck.CategoryError); ok {
 				if ce.Category == healthcheck.KubernetesAPIChecks {
 					k8sAPIError = ce
+				} else if ce.Category == healthcheck.LinkerdPreInstallCapacityChecks {
+					capacityErrMsgs = append(capacityErrMsgs, ce.Error())
+				} else if ce.Category == healthcheck.LinkerdPreInstallOpenShiftChecks {
+					openshiftErrMsgs = append(openshiftErrMsgs, ce.Error())
 				} else if re, ok := ce.Err.(*healthcheck.ResourceError); ok {
 					// resource error, print in kind.group/name format
 					for _, res := range re.Resources {
@@ -1056,6 +1909,77 @@ func errAfterRunningChecks(options *installOptions) error {
 		return errors.New(strings.Join(errMsgs, "\n"))
 	}
 
+	if len(capacityErrMsgs) > 0 {
+		return &healthcheck.CategoryError{
+			Category: healthcheck.LinkerdPreInstallCapacityChecks,
+			Err:      errors.New(strings.Join(capacityErrMsgs, "\n")),
+		}
+	}
+
+	if len(openshiftErrMsgs) > 0 {
+		return &healthcheck.CategoryError{
+			Category: healthcheck.LinkerdPreInstallOpenShiftChecks,
+			Err:      errors.New(strings.Join(openshiftErrMsgs, "\n")),
+		}
+	}
+
+	return nil
+}
+
+// errIfPreviousStagesMissing returns an error if the crds or the config stage
+// wasn't applied before the control-plane stage. The pre-install checks of the
+// CRDs and of the global resources fail once their stage was applied, so each
+// of them must fail on its own.
+func errIfPreviousStagesMissing(options *installOptions) error {
+	checks := []healthcheck.CategoryID{
+		healthcheck.KubernetesAPIChecks,
+		healthcheck.LinkerdPreInstallCRDsChecks,
+		healthcheck.LinkerdPreInstallGlobalResourcesChecks,
+	}
+	hc := healthcheck.NewHealthChecker(checks, &healthcheck.Options{
+		ControlPlaneNamespace: controlPlaneNamespace,
+		KubeConfig:            kubeconfigPath,
+		Impersonate:           impersonate,
+		ImpersonateGroup:      impersonateGroup,
+		KubeContext:           kubeContext,
+		APIAddr:               apiAddr,
+		CNIEnabled:            options.cniEnabled,
+	})
+
+	var results []*healthcheck.CheckResult
+	hc.RunChecks(func(result *healthcheck.CheckResult) {
+		results = append(results, result)
+	})
+
+	return previousStagesError(results)
+}
+
+// previousStagesError returns an error naming the stages missing before the
+// control-plane stage, given the results of the pre-install checks of the CRDs
+// and of the global resources, or the error of the KubernetesAPIChecks if any.
+func previousStagesError(results []*healthcheck.CheckResult) error {
+	failed := map[healthcheck.CategoryID]bool{}
+	for _, result := range results {
+		if result.Err == nil || result.Warning {
+			continue
+		}
+		if result.Category == healthcheck.KubernetesAPIChecks {
+			return result.Err
+		}
+		failed[result.Category] = true
+	}
+
+	var missing []string
+	if !failed[healthcheck.LinkerdPreInstallCRDsChecks] {
+		missing = append(missing, "the required Linkerd CRDs are missing, run 'linkerd install crds' first")
+	}
+	if !failed[healthcheck.LinkerdPreInstallGlobalResourcesChecks] {
+		missing = append(missing, "the required Linkerd global resources are missing, run 'linkerd install config' first")
+	}
+	if len(missing) > 0 {
+		return errors.New(strings.Join(missing, "; "))
+	}
+
 	return nil
 }
 
@@ -1105,7 +2029,61 @@ func (idopts *installIdentityOptions) validate() error {
 		}
 	}
 
-	if idopts.identityExternalIssuer {
+	if idopts.renewalJitter < 0 || idopts.renewalJitter >= idopts.issuanceLifetime {
+		return fmt.Errorf("--identity-renewal-jitter must not be negative and must be shorter than --identity-issuance-lifetime (%s)", idopts.issuanceLifetime)
+	}
+
+	if err := tls.ValidateKeyAlgorithm(idopts.issuerKeyAlgorithm); err != nil {
+		return fmt.Errorf("invalid --identity-issuer-key-algorithm: %s", err)
+	}
+
+	if idopts.spire.ServerAddr != "" {
+		if idopts.identityExternalIssuer || idopts.keyURI != "" {
+			return errors.New("--identity-spire-server-addr must not be specified if --identity-external-issuer or --identity-issuer-key-uri is set")
+		}
+		if idopts.crtPEMFile != "" || idopts.keyPEMFile != "" {
+			return errors.New("--identity-issuer-certificate-file and --identity-issuer-key-file must not be specified if --identity-spire-server-addr is set")
+		}
+		if idopts.trustPEMFile == "" {
+			return errors.New("--identity-trust-anchors-file must be specified if --identity-spire-server-addr is set")
+		}
+		if _, _, err := net.SplitHostPort(idopts.spire.ServerAddr); err != nil {
+			return fmt.Errorf("invalid --identity-spire-server-addr: %s", err)
+		}
+		if !path.IsAbs(idopts.spire.AgentSocketPath) {
+			return fmt.Errorf("--identity-spire-agent-socket-path must be an absolute path: %s", idopts.spire.AgentSocketPath)
+		}
+		if err := checkFilesExist([]string{idopts.trustPEMFile}); err != nil {
+			return err
+		}
+	} else if idopts.keyURI != "" {
+		if idopts.identityExternalIssuer {
+			return errors.New("--identity-issuer-key-uri must not be specified if --identity-external-issuer is set")
+		}
+		if idopts.keyPEMFile != "" {
+			return errors.New("--identity-issuer-key-file must not be specified if --identity-issuer-key-uri is set")
+		}
+		if idopts.trustPEMFile == "" || idopts.crtPEMFile == "" {
+			return errors.New("--identity-trust-anchors-file and --identity-issuer-certificate-file must be specified if --identity-issuer-key-uri is set")
+		}
+		if _, err := kms.ParseKeyURI(idopts.keyURI); err != nil {
+			return err
+		}
+		if err := checkFilesExist([]string{idopts.trustPEMFile, idopts.crtPEMFile}); err != nil {
+			return err
+		}
+	} else if idopts.certManager.Enabled {
+		if idopts.crtPEMFile != "" || idopts.keyPEMFile != "" {
+			return fmt.Errorf("--identity-issuer-certificate-file and --identity-issuer-key-file must not be specified if --identity-external-issuer=%s", externalIssuerCertManager)
+		}
+
+		if idopts.trustPEMFile == "" {
+			return fmt.Errorf("--identity-trust-anchors-file must be specified if --identity-external-issuer=%s", externalIssuerCertManager)
+		}
+		if err := checkFilesExist([]string{idopts.trustPEMFile}); err != nil {
+			return err
+		}
+	} else if idopts.identityExternalIssuer {
 
 		if idopts.crtPEMFile != "" {
 			return errors.New("--identity-issuer-certificate-file must not be specified if --identity-external-issuer=true")
@@ -1148,7 +2126,13 @@ func (idopts *installIdentityOptions) validateAndBuild() (*identityWithAnchorsAn
 		return nil, err
 	}
 
-	if idopts.identityExternalIssuer {
+	if idopts.spire.ServerAddr != "" {
+		return idopts.readSpireValues()
+	} else if idopts.keyURI != "" {
+		return idopts.readKMSValues()
+	} else if idopts.certManager.Enabled {
+		return idopts.readCertManagerValues()
+	} else if idopts.identityExternalIssuer {
 		return idopts.readExternallyManaged()
 	} else if idopts.trustPEMFile != "" && idopts.crtPEMFile != "" && idopts.keyPEMFile != "" {
 		return idopts.readValues()
@@ -1162,7 +2146,7 @@ func (idopts *installIdentityOptions) issuerName() string {
 }
 
 func (idopts *installIdentityOptions) genValues() (*identityWithAnchorsAndTrustDomain, error) {
-	root, err := tls.GenerateRootCAWithDefaults(idopts.issuerName())
+	root, err := tls.GenerateRootCA(idopts.issuerName(), idopts.issuerKeyAlgorithm)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate root certificate for identity: %s", err)
 	}
@@ -1175,6 +2159,8 @@ func (idopts *installIdentityOptions) genValues() (*identityWithAnchorsAndTrustD
 				Scheme:              consts.IdentityIssuerSchemeLinkerd,
 				ClockSkewAllowance:  idopts.clockSkewAllowance.String(),
 				IssuanceLifetime:    idopts.issuanceLifetime.String(),
+				RenewalJitter:       idopts.renewalJitter.String(),
+				KeyAlgorithm:        idopts.issuerKeyAlgorithm,
 				CrtExpiry:           root.Cred.Crt.Certificate.NotAfter,
 				CrtExpiryAnnotation: k8s.IdentityIssuerExpiryAnnotation,
 				TLS: &l5dcharts.IssuerTLS{
@@ -1210,10 +2196,126 @@ func (idopts *installIdentityOptions) readExternallyManaged() (*identityWithAnch
 				Scheme:             string(corev1.SecretTypeTLS),
 				ClockSkewAllowance: idopts.clockSkewAllowance.String(),
 				IssuanceLifetime:   idopts.issuanceLifetime.String(),
+				RenewalJitter:      idopts.renewalJitter.String(),
+				KeyAlgorithm:       idopts.issuerKeyAlgorithm,
+			},
+		},
+	}, nil
+
+}
+
+// readCertManagerValues reads the trust anchors from disk and produces the
+// values rendering the cert-manager resources that issue the issuer
+// certificate, which doesn't exist yet and thus isn't verified.
+//
+// The identity options must have already been validated.
+func (idopts *installIdentityOptions) readCertManagerValues() (*identityWithAnchorsAndTrustDomain, error) {
+	anchors, err := ioutil.ReadFile(idopts.trustPEMFile)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := tls.DecodePEMCertificates(string(anchors)); err != nil {
+		return nil, fmt.Errorf("failed to read trust anchors from %s: %s", idopts.trustPEMFile, err)
+	}
+
+	certManager := idopts.certManager
+	return &identityWithAnchorsAndTrustDomain{
+		TrustDomain:     idopts.trustDomain,
+		TrustAnchorsPEM: string(anchors),
+		Identity: &l5dcharts.Identity{
+			Issuer: &l5dcharts.Issuer{
+				Scheme:             string(corev1.SecretTypeTLS),
+				ClockSkewAllowance: idopts.clockSkewAllowance.String(),
+				IssuanceLifetime:   idopts.issuanceLifetime.String(),
+				RenewalJitter:      idopts.renewalJitter.String(),
+				KeyAlgorithm:       idopts.issuerKeyAlgorithm,
+				CertManager:        &certManager,
+			},
+		},
+	}, nil
+}
+
+// readKMSValues reads the issuer certificate and the trust anchors from disk,
+// the issuer private key being held by the KMS identified by the key URI. The
+// certificate is only verified against the anchors, as the key can't be read
+// to check it matches; the identity service fails to start if it doesn't.
+//
+// The identity options must have already been validated.
+func (idopts *installIdentityOptions) readKMSValues() (*identityWithAnchorsAndTrustDomain, error) {
+	anchors, err := ioutil.ReadFile(idopts.trustPEMFile)
+	if err != nil {
+		return nil, err
+	}
+	roots, err := tls.DecodePEMCertPool(string(anchors))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read trust anchors from %s: %s", idopts.trustPEMFile, err)
+	}
+
+	crtb, err := ioutil.ReadFile(idopts.crtPEMFile)
+	if err != nil {
+		return nil, err
+	}
+	crt, err := tls.DecodePEMCrt(string(crtb))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read issuer certificate from %s: %s", idopts.crtPEMFile, err)
+	}
+	if err := crt.Verify(roots, idopts.issuerName(), time.Time{}); err != nil {
+		return nil, fmt.Errorf("failed to verify issuer certificate stored on disk: %s", err)
+	}
+	if err := tls.CheckKeyAlgorithm(crt.Certificate.PublicKey, idopts.issuerKeyAlgorithm); err != nil {
+		return nil, fmt.Errorf("failed to verify issuer certificate stored on disk: %s", err)
+	}
+
+	return &identityWithAnchorsAndTrustDomain{
+		TrustDomain:     idopts.trustDomain,
+		TrustAnchorsPEM: string(anchors),
+		Identity: &l5dcharts.Identity{
+			Issuer: &l5dcharts.Issuer{
+				Scheme:              consts.IdentityIssuerSchemeLinkerd,
+				ClockSkewAllowance:  idopts.clockSkewAllowance.String(),
+				IssuanceLifetime:    idopts.issuanceLifetime.String(),
+				RenewalJitter:       idopts.renewalJitter.String(),
+				KeyAlgorithm:        idopts.issuerKeyAlgorithm,
+				CrtExpiry:           crt.Certificate.NotAfter,
+				CrtExpiryAnnotation: k8s.IdentityIssuerExpiryAnnotation,
+				KeyURI:              idopts.keyURI,
+				TLS: &l5dcharts.IssuerTLS{
+					CrtPEM: crt.EncodeCertificatePEM(),
+				},
 			},
 		},
 	}, nil
+}
 
+// readSpireValues reads the SPIRE bundle used as trust anchors from disk, the
+// issuer certificate being obtained from the SPIRE server by the identity
+// service.
+//
+// The identity options must have already been validated.
+func (idopts *installIdentityOptions) readSpireValues() (*identityWithAnchorsAndTrustDomain, error) {
+	anchors, err := ioutil.ReadFile(idopts.trustPEMFile)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := tls.DecodePEMCertPool(string(anchors)); err != nil {
+		return nil, fmt.Errorf("failed to read trust anchors from %s: %s", idopts.trustPEMFile, err)
+	}
+
+	spire := idopts.spire
+	return &identityWithAnchorsAndTrustDomain{
+		TrustDomain:     idopts.trustDomain,
+		TrustAnchorsPEM: string(anchors),
+		Identity: &l5dcharts.Identity{
+			Issuer: &l5dcharts.Issuer{
+				Scheme:             consts.IdentityIssuerSchemeSpire,
+				ClockSkewAllowance: idopts.clockSkewAllowance.String(),
+				IssuanceLifetime:   idopts.issuanceLifetime.String(),
+				RenewalJitter:      idopts.renewalJitter.String(),
+				KeyAlgorithm:       idopts.issuerKeyAlgorithm,
+			},
+			Spire: &spire,
+		},
+	}, nil
 }
 
 // readValues attempts to read an issuer configuration from disk
@@ -1230,6 +2332,9 @@ func (idopts *installIdentityOptions) readValues() (*identityWithAnchorsAndTrust
 	if err != nil {
 		return nil, fmt.Errorf("failed to verify issuer certs stored on disk: %s", err)
 	}
+	if err := tls.CheckKeyAlgorithm(creds.Certificate.PublicKey, idopts.issuerKeyAlgorithm); err != nil {
+		return nil, fmt.Errorf("failed to verify issuer certs stored on disk: %s", err)
+	}
 
 	return &identityWithAnchorsAndTrustDomain{
 		TrustDomain:     idopts.trustDomain,
@@ -1239,6 +2344,8 @@ func (idopts *installIdentityOptions) readValues() (*identityWithAnchorsAndTrust
 				Scheme:              consts.IdentityIssuerSchemeLinkerd,
 				ClockSkewAllowance:  idopts.clockSkewAllowance.String(),
 				IssuanceLifetime:    idopts.issuanceLifetime.String(),
+				RenewalJitter:       idopts.renewalJitter.String(),
+				KeyAlgorithm:        idopts.issuerKeyAlgorithm,
 				CrtExpiry:           creds.Crt.Certificate.NotAfter,
 				CrtExpiryAnnotation: k8s.IdentityIssuerExpiryAnnotation,
 				TLS: &l5dcharts.IssuerTLS{
@@ -1250,6 +2357,33 @@ func (idopts *installIdentityOptions) readValues() (*identityWithAnchorsAndTrust
 	}, nil
 }
 
+func (v *externalIssuerValue) String() string {
+	if v.idopts.certManager.Enabled {
+		return externalIssuerCertManager
+	}
+	return strconv.FormatBool(v.idopts.identityExternalIssuer)
+}
+
+func (v *externalIssuerValue) Set(value string) error {
+	if value == externalIssuerCertManager {
+		v.idopts.identityExternalIssuer = true
+		v.idopts.certManager.Enabled = true
+		return nil
+	}
+
+	external, err := strconv.ParseBool(value)
+	if err != nil {
+		return fmt.Errorf("must be true, false or %s", externalIssuerCertManager)
+	}
+	v.idopts.identityExternalIssuer = external
+	v.idopts.certManager.Enabled = false
+	return nil
+}
+
+func (v *externalIssuerValue) Type() string {
+	return "string"
+}
+
 func toIdentityContext(idvals *identityWithAnchorsAndTrustDomain) *pb.IdentityContext {
 	if idvals == nil {
 		return nil
@@ -1265,11 +2399,77 @@ func toIdentityContext(idvals *identityWithAnchorsAndTrustDomain) *pb.IdentityCo
 		csa = defaultIdentityClockSkewAllowance
 	}
 
+	// the renewal jitter is empty when upgrading from versions without it
+	rj, err := time.ParseDuration(idvals.Identity.Issuer.RenewalJitter)
+	if err != nil {
+		rj = 0
+	}
+
+	// the cert-manager settings are recorded so that upgrade keeps rendering
+	// the same Certificate
+	var certManager *pb.IdentityContext_CertManager
+	if cm := idvals.Identity.Issuer.CertManager; cm != nil && cm.Enabled {
+		certManager = &pb.IdentityContext_CertManager{
+			TrustAnchorSecret: cm.TrustAnchorSecret,
+			Duration:          cm.Duration,
+			RenewBefore:       cm.RenewBefore,
+		}
+	}
+
 	return &pb.IdentityContext{
 		TrustDomain:        idvals.TrustDomain,
 		TrustAnchorsPem:    idvals.TrustAnchorsPEM,
 		IssuanceLifetime:   ptypes.DurationProto(il),
 		ClockSkewAllowance: ptypes.DurationProto(csa),
+		RenewalJitter:      ptypes.DurationProto(rj),
 		Scheme:             idvals.Identity.Issuer.Scheme,
+		IssuerKeyAlgorithm: idvals.Identity.Issuer.KeyAlgorithm,
+		CertManager:        certManager,
+	}
+}
+
+func containsString(slice []string, s string) bool {
+	for _, item := range slice {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// cryptoPolicyImage returns the variant of image built for the crypto policy:
+// the FIPS images have the "-fips" suffix
+func (options *installOptions) cryptoPolicyImage(image string) string {
+	if options.cryptoPolicy == tls.CryptoPolicyFIPS && !strings.HasSuffix(image, fipsImageSuffix) {
+		return image + fipsImageSuffix
 	}
+	return image
+}
+
+// checkIdentityCryptoPolicy validates that the trust anchors and the issuer
+// certificate, unless it's managed externally, have keys approved by the
+// crypto policy
+func checkIdentityCryptoPolicy(identity *identityWithAnchorsAndTrustDomain, policy string) error {
+	if policy == tls.CryptoPolicyDefault {
+		return nil
+	}
+
+	crts, err := tls.DecodePEMCertificates(identity.TrustAnchorsPEM)
+	if err != nil {
+		return err
+	}
+	if identity.Identity != nil && identity.Identity.Issuer != nil && identity.Identity.Issuer.TLS != nil && identity.Identity.Issuer.TLS.CrtPEM != "" {
+		issuerCrts, err := tls.DecodePEMCertificates(identity.Identity.Issuer.TLS.CrtPEM)
+		if err != nil {
+			return err
+		}
+		crts = append(crts, issuerCrts...)
+	}
+
+	for _, crt := range crts {
+		if err := tls.CheckCryptoPolicy(crt, policy); err != nil {
+			return fmt.Errorf("--crypto-policy %s: %s", policy, err)
+		}
+	}
+	return nil
 }
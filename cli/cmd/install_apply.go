@@ -0,0 +1,274 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// applyFieldManager is the field manager recorded by server-side apply for
+	// the fields set by `linkerd install --apply`
+	applyFieldManager = "linkerd-cli"
+
+	applyPollInterval = 2 * time.Second
+
+	// dryRunNamespaceDeletionTimeout bounds the wait for the deletion of the
+	// namespaces created for the server-side dry-run
+	dryRunNamespaceDeletionTimeout = time.Minute
+
+	// dryRunNone and dryRunServer are the values accepted by --dry-run
+	dryRunNone   = "none"
+	dryRunServer = "server"
+)
+
+// applyManifest applies the resources of manifest in their recommended apply
+// order using server-side apply, and then waits until the workloads are
+// ready. The status of each resource is reported to w. It stops at the first
+// resource that fails to be applied, as later resources are likely to depend
+// on it.
+func applyManifest(w io.Writer, k *k8s.KubernetesAPI, manifest []byte, timeout time.Duration) error {
+	objs, err := splitManifest(manifest)
+	if err != nil {
+		return err
+	}
+	sortByApplyOrder(objs)
+
+	if err := applyObjects(w, k, objs); err != nil {
+		return err
+	}
+	return waitForWorkloads(w, k, objs, timeout)
+}
+
+// applyObjects applies objs in order, reporting the status of each one to w.
+// It stops at the first resource that fails to be applied.
+func applyObjects(w io.Writer, k *k8s.KubernetesAPI, objs []manifestObject) error {
+	for _, obj := range objs {
+		if err := applyObject(k.DynamicClient, obj, false); err != nil {
+			fmt.Fprintf(w, "%s %s: %s\n", failStatus, obj.ref(), err)
+			return fmt.Errorf("failed to apply %s", obj.ref())
+		}
+		fmt.Fprintf(w, "%s %s applied\n", okStatus, obj.ref())
+	}
+	return nil
+}
+
+// waitForWorkloads waits until the workloads among objs are ready, reporting
+// their status to w
+func waitForWorkloads(w io.Writer, k *k8s.KubernetesAPI, objs []manifestObject, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for _, obj := range objs {
+		if !isWorkload(obj.Kind) {
+			continue
+		}
+
+		for {
+			ready, err := workloadReady(k, obj)
+			if err != nil {
+				fmt.Fprintf(w, "%s %s: %s\n", failStatus, obj.ref(), err)
+				return fmt.Errorf("failed to get the status of %s", obj.ref())
+			}
+			if ready {
+				fmt.Fprintf(w, "%s %s is ready\n", okStatus, obj.ref())
+				break
+			}
+			if time.Now().After(deadline) {
+				fmt.Fprintf(w, "%s %s is not ready\n", failStatus, obj.ref())
+				return fmt.Errorf("timed out after %s waiting for %s to be ready", timeout, obj.ref())
+			}
+			time.Sleep(applyPollInterval)
+		}
+	}
+
+	return nil
+}
+
+// dryRunManifest submits every resource of manifest using server-side apply
+// with dry-run semantics, so that it goes through validation and admission
+// without being persisted. All the rejected resources are reported to w.
+// It backs both `linkerd install --dry-run=server` and `--smoke-test`.
+// Since dry-run requires the target namespaces to exist, the missing ones are
+// created for the duration of the dry-run. They're deleted afterwards, waiting
+// until they're gone so that the manifest can be applied right after.
+func dryRunManifest(w io.Writer, k *k8s.KubernetesAPI, manifest []byte) error {
+	objs, err := splitManifest(manifest)
+	if err != nil {
+		return err
+	}
+	sortByApplyOrder(objs)
+
+	created, err := createMissingNamespaces(k, objs)
+	defer deleteTemporaryNamespaces(w, k, created)
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, obj := range objs {
+		if err := applyObject(k.DynamicClient, obj, true); err != nil {
+			fmt.Fprintf(w, "%s %s: %s\n", failStatus, obj.ref(), err)
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d resources were rejected by the server-side dry-run", failed, len(objs))
+	}
+
+	fmt.Fprintf(w, "%s %d resources passed the server-side dry-run\n", okStatus, len(objs))
+	return nil
+}
+
+// createMissingNamespaces creates the namespaces of objs that don't exist, and
+// returns the ones it created, even when it fails.
+func createMissingNamespaces(k *k8s.KubernetesAPI, objs []manifestObject) ([]string, error) {
+	var created []string
+	seen := map[string]bool{}
+	for _, obj := range objs {
+		if obj.Namespace == "" || seen[obj.Namespace] {
+			continue
+		}
+		seen[obj.Namespace] = true
+
+		_, err := k.CoreV1().Namespaces().Get(obj.Namespace, metav1.GetOptions{})
+		if err == nil {
+			continue
+		}
+		if !kerrors.IsNotFound(err) {
+			return created, err
+		}
+
+		ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: obj.Namespace}}
+		if _, err := k.CoreV1().Namespaces().Create(ns); err != nil {
+			return created, fmt.Errorf("cannot create temporary namespace %s: %s", obj.Namespace, err)
+		}
+		created = append(created, obj.Namespace)
+	}
+	return created, nil
+}
+
+// deleteTemporaryNamespaces deletes the namespaces created for the dry-run and
+// waits until they're gone, as a terminating namespace would make applying the
+// manifest fail. The failures are reported to w as warnings.
+func deleteTemporaryNamespaces(w io.Writer, k *k8s.KubernetesAPI, namespaces []string) {
+	for _, ns := range namespaces {
+		if err := k.CoreV1().Namespaces().Delete(ns, &metav1.DeleteOptions{}); err != nil {
+			fmt.Fprintf(w, "%s failed to delete temporary namespace %s: %s\n", warnStatus, ns, err)
+		}
+	}
+
+	deadline := time.Now().Add(dryRunNamespaceDeletionTimeout)
+	for _, ns := range namespaces {
+		for {
+			_, err := k.CoreV1().Namespaces().Get(ns, metav1.GetOptions{})
+			if kerrors.IsNotFound(err) {
+				break
+			}
+			if err != nil || time.Now().After(deadline) {
+				fmt.Fprintf(w, "%s temporary namespace %s is still terminating\n", warnStatus, ns)
+				break
+			}
+			time.Sleep(applyPollInterval)
+		}
+	}
+}
+
+// applyObject applies obj using server-side apply. With dryRun, the request
+// is validated and admitted by the API server but not persisted.
+func applyObject(client dynamic.Interface, obj manifestObject, dryRun bool) error {
+	var u unstructured.Unstructured
+	if err := yaml.Unmarshal(obj.YAML, &u.Object); err != nil {
+		return err
+	}
+	data, err := u.MarshalJSON()
+	if err != nil {
+		return err
+	}
+
+	resource, _ := meta.UnsafeGuessKindToResource(u.GroupVersionKind())
+	var ri dynamic.ResourceInterface = client.Resource(resource)
+	if obj.Namespace != "" {
+		ri = client.Resource(resource).Namespace(obj.Namespace)
+	}
+
+	// Resources installed by Linkerd are owned by it, so take over any field
+	// previously set by another manager, e.g. kubectl.
+	force := true
+	opts := metav1.PatchOptions{
+		FieldManager: applyFieldManager,
+		Force:        &force,
+	}
+	if dryRun {
+		opts.DryRun = []string{metav1.DryRunAll}
+	}
+	_, err = ri.Patch(obj.Name, types.ApplyPatchType, data, opts)
+	return err
+}
+
+func isWorkload(kind string) bool {
+	switch kind {
+	case "Deployment", "DaemonSet", "StatefulSet":
+		return true
+	}
+	return false
+}
+
+func workloadReady(k *k8s.KubernetesAPI, obj manifestObject) (bool, error) {
+	switch obj.Kind {
+	case "Deployment":
+		d, err := k.AppsV1().Deployments(obj.Namespace).Get(obj.Name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		return deploymentReady(d), nil
+	case "DaemonSet":
+		ds, err := k.AppsV1().DaemonSets(obj.Namespace).Get(obj.Name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		return daemonSetReady(ds), nil
+	case "StatefulSet":
+		ss, err := k.AppsV1().StatefulSets(obj.Namespace).Get(obj.Name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		return statefulSetReady(ss), nil
+	}
+	return true, nil
+}
+
+func deploymentReady(d *appsv1.Deployment) bool {
+	replicas := int32(1)
+	if d.Spec.Replicas != nil {
+		replicas = *d.Spec.Replicas
+	}
+	return d.Status.ObservedGeneration >= d.Generation &&
+		d.Status.UpdatedReplicas == replicas &&
+		d.Status.AvailableReplicas == replicas
+}
+
+func daemonSetReady(ds *appsv1.DaemonSet) bool {
+	return ds.Status.ObservedGeneration >= ds.Generation &&
+		ds.Status.UpdatedNumberScheduled == ds.Status.DesiredNumberScheduled &&
+		ds.Status.NumberAvailable == ds.Status.DesiredNumberScheduled
+}
+
+func statefulSetReady(ss *appsv1.StatefulSet) bool {
+	replicas := int32(1)
+	if ss.Spec.Replicas != nil {
+		replicas = *ss.Spec.Replicas
+	}
+	return ss.Status.ObservedGeneration >= ss.Generation &&
+		ss.Status.UpdatedReplicas == replicas &&
+		ss.Status.ReadyReplicas == replicas
+}
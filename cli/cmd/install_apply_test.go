@@ -0,0 +1,192 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/fake"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestWorkloadReadiness(t *testing.T) {
+	replicas := int32(3)
+
+	t.Run("Deployments", func(t *testing.T) {
+		testCases := []struct {
+			status   appsv1.DeploymentStatus
+			expected bool
+		}{
+			{appsv1.DeploymentStatus{ObservedGeneration: 2, UpdatedReplicas: 3, AvailableReplicas: 3}, true},
+			{appsv1.DeploymentStatus{ObservedGeneration: 1, UpdatedReplicas: 3, AvailableReplicas: 3}, false},
+			{appsv1.DeploymentStatus{ObservedGeneration: 2, UpdatedReplicas: 2, AvailableReplicas: 3}, false},
+			{appsv1.DeploymentStatus{ObservedGeneration: 2, UpdatedReplicas: 3, AvailableReplicas: 1}, false},
+		}
+
+		for i, tc := range testCases {
+			d := &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Generation: 2},
+				Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+				Status:     tc.status,
+			}
+			if ready := deploymentReady(d); ready != tc.expected {
+				t.Errorf("Case %d: expected ready to be %t, got %t", i, tc.expected, ready)
+			}
+		}
+	})
+
+	t.Run("DaemonSets", func(t *testing.T) {
+		testCases := []struct {
+			status   appsv1.DaemonSetStatus
+			expected bool
+		}{
+			{appsv1.DaemonSetStatus{ObservedGeneration: 1, DesiredNumberScheduled: 2, UpdatedNumberScheduled: 2, NumberAvailable: 2}, true},
+			{appsv1.DaemonSetStatus{ObservedGeneration: 1, DesiredNumberScheduled: 2, UpdatedNumberScheduled: 1, NumberAvailable: 2}, false},
+			{appsv1.DaemonSetStatus{ObservedGeneration: 1, DesiredNumberScheduled: 2, UpdatedNumberScheduled: 2, NumberAvailable: 0}, false},
+		}
+
+		for i, tc := range testCases {
+			ds := &appsv1.DaemonSet{
+				ObjectMeta: metav1.ObjectMeta{Generation: 1},
+				Status:     tc.status,
+			}
+			if ready := daemonSetReady(ds); ready != tc.expected {
+				t.Errorf("Case %d: expected ready to be %t, got %t", i, tc.expected, ready)
+			}
+		}
+	})
+
+	t.Run("StatefulSets", func(t *testing.T) {
+		testCases := []struct {
+			status   appsv1.StatefulSetStatus
+			expected bool
+		}{
+			{appsv1.StatefulSetStatus{ObservedGeneration: 1, UpdatedReplicas: 3, ReadyReplicas: 3}, true},
+			{appsv1.StatefulSetStatus{ObservedGeneration: 1, UpdatedReplicas: 3, ReadyReplicas: 2}, false},
+		}
+
+		for i, tc := range testCases {
+			ss := &appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{Generation: 1},
+				Spec:       appsv1.StatefulSetSpec{Replicas: &replicas},
+				Status:     tc.status,
+			}
+			if ready := statefulSetReady(ss); ready != tc.expected {
+				t.Errorf("Case %d: expected ready to be %t, got %t", i, tc.expected, ready)
+			}
+		}
+	})
+}
+
+func TestDryRunManifest(t *testing.T) {
+	manifest := `kind: Namespace
+apiVersion: v1
+metadata:
+  name: linkerd
+---
+kind: ConfigMap
+apiVersion: v1
+metadata:
+  name: linkerd-config
+  namespace: linkerd
+---
+kind: ClusterRole
+apiVersion: rbac.authorization.k8s.io/v1
+metadata:
+  name: linkerd-linkerd-controller
+---
+kind: ConfigMap
+apiVersion: v1
+metadata:
+  name: linkerd-config
+  namespace: existing
+`
+
+	newAPI := func(existing ...string) (*k8s.KubernetesAPI, *[]string) {
+		var submitted []string
+		client := fake.NewSimpleDynamicClient(runtime.NewScheme())
+		var namespaces []runtime.Object
+		for _, ns := range existing {
+			namespaces = append(namespaces, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: ns}})
+		}
+		clientset := k8sfake.NewSimpleClientset(namespaces...)
+		client.PrependReactor("patch", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			patch := action.(k8stesting.PatchAction)
+			submitted = append(submitted, patch.GetResource().Resource+"/"+patch.GetName())
+			ns := patch.GetNamespace()
+			if ns == "" {
+				return true, nil, nil
+			}
+			if _, err := clientset.CoreV1().Namespaces().Get(ns, metav1.GetOptions{}); err != nil {
+				return true, nil, kerrors.NewNotFound(schema.GroupResource{Resource: "namespaces"}, ns)
+			}
+			if patch.GetName() == "rejected" {
+				return true, nil, kerrors.NewBadRequest("invalid resource")
+			}
+			return true, nil, nil
+		})
+		return &k8s.KubernetesAPI{Interface: clientset, DynamicClient: client}, &submitted
+	}
+
+	t.Run("Submits the namespaces first", func(t *testing.T) {
+		k, submitted := newAPI("linkerd", "existing")
+		var buf bytes.Buffer
+		if err := dryRunManifest(&buf, k, []byte(manifest)); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if len(*submitted) != 4 || (*submitted)[0] != "namespaces/linkerd" {
+			t.Fatalf("Expected the 4 resources to be submitted, the namespace first, got %v", *submitted)
+		}
+		if !strings.Contains(buf.String(), "4 resources passed the server-side dry-run") {
+			t.Fatalf("Unexpected output: %s", buf.String())
+		}
+	})
+
+	t.Run("Validates the resources of a new namespace in a temporary namespace", func(t *testing.T) {
+		k, _ := newAPI("existing")
+		var buf bytes.Buffer
+		if err := dryRunManifest(&buf, k, []byte(manifest)); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if !strings.Contains(buf.String(), "4 resources passed the server-side dry-run") {
+			t.Fatalf("Unexpected output: %s", buf.String())
+		}
+		if _, err := k.CoreV1().Namespaces().Get("linkerd", metav1.GetOptions{}); !kerrors.IsNotFound(err) {
+			t.Fatalf("Expected the temporary namespace to be deleted, got %v", err)
+		}
+		if _, err := k.CoreV1().Namespaces().Get("existing", metav1.GetOptions{}); err != nil {
+			t.Fatalf("Expected the existing namespace to be kept, got %v", err)
+		}
+	})
+
+	t.Run("Fails when a resource is rejected", func(t *testing.T) {
+		k, _ := newAPI("existing")
+		rejected := manifest + `---
+kind: ConfigMap
+apiVersion: v1
+metadata:
+  name: rejected
+  namespace: linkerd
+`
+		var buf bytes.Buffer
+		err := dryRunManifest(&buf, k, []byte(rejected))
+		expected := "1 of 5 resources were rejected by the server-side dry-run"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("Expected error %q, got %v", expected, err)
+		}
+		if !strings.Contains(buf.String(), "invalid resource") || strings.Contains(buf.String(), "passed") {
+			t.Fatalf("Unexpected output: %s", buf.String())
+		}
+		if _, err := k.CoreV1().Namespaces().Get("linkerd", metav1.GetOptions{}); !kerrors.IsNotFound(err) {
+			t.Fatalf("Expected the temporary namespace to be deleted, got %v", err)
+		}
+	})
+}
@@ -170,6 +170,7 @@ func chartControlPlane(t *testing.T, ha bool, addOnConfig string, ignoreOutbound
 		"templates/_metadata.tpl",
 		"templates/_helpers.tpl",
 		"templates/_debug.tpl",
+		"templates/_proxy-shutdown.tpl",
 		"templates/_trace.tpl",
 		"templates/_capabilities.tpl",
 		"templates/_affinity.tpl",
@@ -178,6 +179,7 @@ func chartControlPlane(t *testing.T, ha bool, addOnConfig string, ignoreOutbound
 		"templates/_tolerations.tpl",
 		"templates/_validate.tpl",
 		"templates/_pull-secrets.tpl",
+		"templates/_prometheus.tpl",
 	}
 
 	chartPartials := chartPartials(t, partialPaths)
@@ -212,7 +214,8 @@ func chartControlPlane(t *testing.T, ha bool, addOnConfig string, ignoreOutbound
 		chart.Dependencies = append(chart.Dependencies, buildAddOnChart(t, addon, chartPartials))
 	}
 
-	for _, filepath := range append(templatesConfigStage, templatesControlPlaneStage...) {
+	templates := append(templatesCRDsStage, templatesConfigStage...)
+	for _, filepath := range append(templates, templatesControlPlaneStage...) {
 		chart.Templates = append(chart.Templates, &pb.Template{
 			Name: filepath,
 		})
@@ -0,0 +1,308 @@
+package cmd
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	pb "github.com/linkerd/linkerd2/controller/gen/config"
+	l5dcharts "github.com/linkerd/linkerd2/pkg/charts/linkerd2"
+	"github.com/linkerd/linkerd2/pkg/config"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// maxConfigRevisions is the number of revisions kept in the
+	// linkerd-config-history ConfigMap; older ones are dropped
+	maxConfigRevisions = 10
+
+	// maxManifestRevisions is the number of latest revisions that keep their
+	// manifest, so that the ConfigMap stays well below its size limit
+	maxManifestRevisions = 2
+
+	configRevisionKeyPrefix = "revision-"
+)
+
+// configRevision records an install or upgrade of the control plane, along
+// with the configuration it was rendered from
+type configRevision struct {
+	Revision       int                   `json:"revision"`
+	Timestamp      string                `json:"timestamp"`
+	CliVersion     string                `json:"cliVersion"`
+	Command        string                `json:"command"`
+	Flags          []*pb.Install_Flag    `json:"flags"`
+	ManifestSHA256 string                `json:"manifestSHA256"`
+	Manifest       string                `json:"manifest,omitempty"`
+	Configs        l5dcharts.ConfigJSONs `json:"configs"`
+}
+
+// fetchConfigHistory returns the revisions recorded in the
+// linkerd-config-history ConfigMap, oldest first. It's empty if the control
+// plane was installed by a version that didn't record its history.
+func fetchConfigHistory(k kubernetes.Interface) ([]configRevision, error) {
+	cm, err := k.CoreV1().ConfigMaps(controlPlaneNamespace).Get(k8s.ConfigHistoryConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var history []configRevision
+	for key, data := range cm.Data {
+		if !strings.HasPrefix(key, configRevisionKeyPrefix) {
+			continue
+		}
+		var rev configRevision
+		if err := json.Unmarshal([]byte(data), &rev); err != nil {
+			return nil, fmt.Errorf("invalid %s in %s: %s", key, k8s.ConfigHistoryConfigMapName, err)
+		}
+		history = append(history, rev)
+	}
+
+	sort.Slice(history, func(i, j int) bool { return history[i].Revision < history[j].Revision })
+	return history, nil
+}
+
+// findConfigRevision returns the configs recorded by the given revision
+func findConfigRevision(history []configRevision, revision int) (*pb.All, error) {
+	for _, rev := range history {
+		if rev.Revision != revision {
+			continue
+		}
+		configs, err := config.FromConfigMap(map[string]string{
+			"global":  rev.Configs.Global,
+			"proxy":   rev.Configs.Proxy,
+			"install": rev.Configs.Install,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("invalid configs in revision %d: %s", revision, err)
+		}
+		return configs, nil
+	}
+
+	return nil, fmt.Errorf("revision %d not found in %s", revision, k8s.ConfigHistoryConfigMapName)
+}
+
+// previousConfigRevision returns the revision recorded before the current one
+func previousConfigRevision(history []configRevision) (configRevision, error) {
+	if len(history) < 2 {
+		return configRevision{}, fmt.Errorf("there's no previous revision to roll back to in %s", k8s.ConfigHistoryConfigMapName)
+	}
+	return history[len(history)-2], nil
+}
+
+// revisionManifest returns the manifest recorded by rev, as it was before the
+// linkerd-config-history ConfigMap got appended to it, without its Secrets
+func revisionManifest(rev configRevision) ([]byte, error) {
+	if rev.Manifest == "" {
+		return nil, fmt.Errorf("revision %d didn't record its manifest", rev.Revision)
+	}
+
+	compressed, err := base64.StdEncoding.DecodeString(rev.Manifest)
+	if err != nil {
+		return nil, fmt.Errorf("invalid manifest in revision %d: %s", rev.Revision, err)
+	}
+	r, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return nil, fmt.Errorf("invalid manifest in revision %d: %s", rev.Revision, err)
+	}
+	manifest, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("invalid manifest in revision %d: %s", rev.Revision, err)
+	}
+
+	sum := sha256.Sum256(manifest)
+	if hex.EncodeToString(sum[:]) != rev.ManifestSHA256 {
+		return nil, fmt.Errorf("the manifest of revision %d doesn't match its hash", rev.Revision)
+	}
+	return manifest, nil
+}
+
+// withoutSecrets returns the resources of the manifest but its Secrets, whose
+// data, like the private key of the identity issuer, mustn't be readable by
+// everyone allowed to read the linkerd-config-history ConfigMap
+func withoutSecrets(manifest []byte) ([]byte, error) {
+	objs, err := splitManifest(manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	for _, obj := range objs {
+		if obj.Kind == "Secret" {
+			continue
+		}
+		buf.WriteString(yamlSep)
+		buf.Write(obj.YAML)
+		if !bytes.HasSuffix(obj.YAML, []byte("\n")) {
+			buf.WriteString("\n")
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// currentSecrets returns the Secrets of the control plane currently in the
+// cluster, as a manifest. As the recorded manifests don't include them, they're
+// appended to the manifest of a rollback, so that applying it with
+// `kubectl apply --prune` keeps them instead of deleting them.
+func currentSecrets(k kubernetes.Interface) ([]byte, error) {
+	secrets, err := k.CoreV1().Secrets(controlPlaneNamespace).List(metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", k8s.ControllerNSLabel, controlPlaneNamespace),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	for _, secret := range secrets.Items {
+		annotations := map[string]string{}
+		for k, v := range secret.Annotations {
+			if k != corev1.LastAppliedConfigAnnotation {
+				annotations[k] = v
+			}
+		}
+		out := corev1.Secret{
+			TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        secret.Name,
+				Namespace:   secret.Namespace,
+				Labels:      secret.Labels,
+				Annotations: annotations,
+			},
+			Type: secret.Type,
+			Data: secret.Data,
+		}
+		b, err := yaml.Marshal(out)
+		if err != nil {
+			return nil, err
+		}
+		buf.WriteString(yamlSep)
+		buf.Write(b)
+	}
+	return buf.Bytes(), nil
+}
+
+// compressManifest returns the gzipped manifest, base64 encoded
+func compressManifest(manifest []byte) (string, error) {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(manifest); err != nil {
+		return "", err
+	}
+	if err := w.Close(); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+// appendConfigHistory adds a revision for the manifest rendered in buf to the
+// history, and appends the resulting linkerd-config-history ConfigMap to buf.
+// The revision records the manifest, and its hash, as it was before the
+// ConfigMap got appended, without its Secrets; older revisions only keep the
+// hash.
+func appendConfigHistory(buf *bytes.Buffer, history []configRevision, command string, configs l5dcharts.ConfigJSONs, flags []*pb.Install_Flag, now time.Time) error {
+	recorded, err := withoutSecrets(buf.Bytes())
+	if err != nil {
+		return err
+	}
+	sum := sha256.Sum256(recorded)
+	manifest, err := compressManifest(recorded)
+	if err != nil {
+		return err
+	}
+
+	revision := 1
+	if len(history) > 0 {
+		revision = history[len(history)-1].Revision + 1
+	}
+	history = append(append([]configRevision{}, history...), configRevision{
+		Revision:       revision,
+		Timestamp:      now.UTC().Format(time.RFC3339),
+		CliVersion:     k8s.CreatedByAnnotationValue(),
+		Command:        command,
+		Flags:          flags,
+		ManifestSHA256: hex.EncodeToString(sum[:]),
+		Manifest:       manifest,
+		Configs:        configs,
+	})
+	if len(history) > maxConfigRevisions {
+		history = history[len(history)-maxConfigRevisions:]
+	}
+	for i := 0; i < len(history)-maxManifestRevisions; i++ {
+		history[i].Manifest = ""
+	}
+
+	cm := &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{Kind: "ConfigMap", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      k8s.ConfigHistoryConfigMapName,
+			Namespace: controlPlaneNamespace,
+			Labels: map[string]string{
+				k8s.ControllerComponentLabel: "controller",
+				k8s.ControllerNSLabel:        controlPlaneNamespace,
+			},
+			Annotations: map[string]string{
+				k8s.CreatedByAnnotation:      k8s.CreatedByAnnotationValue(),
+				k8s.ConfigRevisionAnnotation: strconv.Itoa(revision),
+			},
+		},
+		Data: map[string]string{},
+	}
+	for _, rev := range history {
+		data, err := json.Marshal(rev)
+		if err != nil {
+			return err
+		}
+		cm.Data[fmt.Sprintf("%s%d", configRevisionKeyPrefix, rev.Revision)] = string(data)
+	}
+
+	out, err := yaml.Marshal(cm)
+	if err != nil {
+		return err
+	}
+	if !bytes.HasSuffix(buf.Bytes(), []byte("\n")) {
+		buf.WriteString("\n")
+	}
+	buf.WriteString("---\n")
+	buf.Write(out)
+	return nil
+}
+
+func writeConfigHistory(w io.Writer, history []configRevision) {
+	if len(history) == 0 {
+		fmt.Fprintf(w, "No configuration history found in %s\n", k8s.ConfigHistoryConfigMapName)
+		return
+	}
+
+	t := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(t, "REVISION\tTIMESTAMP\tCOMMAND\tCLI VERSION\tMANIFEST SHA256\tFLAGS")
+	for _, rev := range history {
+		flags := make([]string, len(rev.Flags))
+		for i, f := range rev.Flags {
+			flags[i] = fmt.Sprintf("--%s=%s", f.GetName(), f.GetValue())
+		}
+		sum := rev.ManifestSHA256
+		if len(sum) > 12 {
+			sum = sum[:12]
+		}
+		fmt.Fprintf(t, "%d\t%s\t%s\t%s\t%s\t%s\n", rev.Revision, rev.Timestamp, rev.Command, rev.CliVersion, sum, strings.Join(flags, " "))
+	}
+	t.Flush()
+}
@@ -0,0 +1,184 @@
+package cmd
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+)
+
+func TestAppendConfigHistory(t *testing.T) {
+	now := time.Date(2020, 9, 1, 0, 0, 0, 0, time.UTC)
+	opts, flags, _, _ := testOptionsAndFlags(t)
+	values := installValues(t, opts, flags)
+
+	t.Run("Records the first revision", func(t *testing.T) {
+		buf := renderInstall(t, values)
+		recorded, err := withoutSecrets(buf.Bytes())
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		sum := sha256.Sum256(recorded)
+
+		if err := appendConfigHistory(&buf, nil, "install", values.Configs, opts.recordedFlags, now); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		history := historyFromManifest(t, buf.String())
+		if len(history) != 1 {
+			t.Fatalf("Expected 1 revision, got %d", len(history))
+		}
+		rev := history[0]
+		if rev.Revision != 1 || rev.Command != "install" || rev.Timestamp != "2020-09-01T00:00:00Z" {
+			t.Fatalf("Unexpected revision: %+v", rev)
+		}
+		if rev.ManifestSHA256 != hex.EncodeToString(sum[:]) {
+			t.Fatalf("Expected the hash of the recorded manifest, got %s", rev.ManifestSHA256)
+		}
+		if rev.Configs != values.Configs {
+			t.Fatalf("Expected the configs to be recorded, got %+v", rev.Configs)
+		}
+	})
+
+	t.Run("Records the manifest of the latest revisions", func(t *testing.T) {
+		buf := renderInstall(t, values)
+		manifests := []string{recordedManifest(t, buf.Bytes())}
+		if err := appendConfigHistory(&buf, nil, "install", values.Configs, opts.recordedFlags, now); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		for i := 0; i < maxManifestRevisions; i++ {
+			history := historyFromManifest(t, buf.String())
+			buf = renderInstall(t, values)
+			manifests = append(manifests, recordedManifest(t, buf.Bytes()))
+			if err := appendConfigHistory(&buf, history, "upgrade", values.Configs, opts.recordedFlags, now); err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+		}
+
+		history := historyFromManifest(t, buf.String())
+		if history[0].Manifest != "" {
+			t.Fatalf("Expected revision 1 not to keep its manifest")
+		}
+		if _, err := revisionManifest(history[0]); err == nil {
+			t.Fatal("Expected revision 1 not to have a manifest")
+		}
+		for _, rev := range history[1:] {
+			recorded, err := revisionManifest(rev)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if string(recorded) != manifests[rev.Revision-1] {
+				t.Fatalf("Expected revision %d to record the rendered manifest", rev.Revision)
+			}
+		}
+
+		tampered := history[1]
+		tampered.ManifestSHA256 = "0123456789abcdef"
+		if _, err := revisionManifest(tampered); err == nil || !strings.Contains(err.Error(), "doesn't match its hash") {
+			t.Fatalf("Expected a manifest not matching its hash to be rejected, got %v", err)
+		}
+	})
+
+	t.Run("Doesn't record the Secrets", func(t *testing.T) {
+		buf := renderInstall(t, values)
+		if !strings.Contains(buf.String(), "kind: Secret") || !strings.Contains(buf.String(), "key.pem") {
+			t.Fatal("Expected the rendered manifest to have the identity issuer Secret")
+		}
+		if err := appendConfigHistory(&buf, nil, "install", values.Configs, opts.recordedFlags, now); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		recorded, err := revisionManifest(historyFromManifest(t, buf.String())[0])
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		objs, err := splitManifest(recorded)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if len(objs) == 0 {
+			t.Fatal("Expected the manifest to be recorded")
+		}
+		for _, obj := range objs {
+			if obj.Kind == "Secret" {
+				t.Fatalf("Expected no Secret in the history, found %s", obj.ref())
+			}
+		}
+		if strings.Contains(string(recorded), "key.pem") {
+			t.Fatal("Expected the issuer private key not to be recorded")
+		}
+	})
+
+	t.Run("Keeps the latest revisions", func(t *testing.T) {
+		var history []configRevision
+		for i := 1; i <= maxConfigRevisions; i++ {
+			history = append(history, configRevision{Revision: i, Command: "upgrade"})
+		}
+		buf := renderInstall(t, values)
+
+		if err := appendConfigHistory(&buf, history, "upgrade", values.Configs, opts.recordedFlags, now); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		history = historyFromManifest(t, buf.String())
+		if len(history) != maxConfigRevisions {
+			t.Fatalf("Expected %d revisions, got %d", maxConfigRevisions, len(history))
+		}
+		if first, last := history[0].Revision, history[len(history)-1].Revision; first != 2 || last != maxConfigRevisions+1 {
+			t.Fatalf("Expected revisions 2 to %d, got %d to %d", maxConfigRevisions+1, first, last)
+		}
+	})
+}
+
+func recordedManifest(t *testing.T, manifest []byte) string {
+	recorded, err := withoutSecrets(manifest)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	return string(recorded)
+}
+
+func TestWriteConfigHistory(t *testing.T) {
+	var buf bytes.Buffer
+	writeConfigHistory(&buf, []configRevision{{
+		Revision:       1,
+		Timestamp:      "2020-09-01T00:00:00Z",
+		CliVersion:     "linkerd/cli dev-undefined",
+		Command:        "install",
+		ManifestSHA256: "0123456789abcdef",
+	}})
+
+	expected := `REVISION  TIMESTAMP             COMMAND  CLI VERSION                MANIFEST SHA256  FLAGS
+1         2020-09-01T00:00:00Z  install  linkerd/cli dev-undefined  0123456789ab     
+`
+	if buf.String() != expected {
+		t.Fatalf("Expected:\n%s\nGot:\n%s", expected, buf.String())
+	}
+}
+
+func historyFromManifest(t *testing.T, manifest string) []configRevision {
+	var history []string
+	for _, m := range splitManifests(manifest) {
+		if strings.Contains(m, fmt.Sprintf("name: %s\n", k8s.ConfigHistoryConfigMapName)) {
+			history = append(history, m)
+		}
+	}
+	if len(history) != 1 {
+		t.Fatalf("Expected the manifest to include the %s ConfigMap", k8s.ConfigHistoryConfigMapName)
+	}
+
+	k, err := k8s.NewFakeAPI(history...)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	revisions, err := fetchConfigHistory(k)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	return revisions
+}
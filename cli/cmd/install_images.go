@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+
+	l5dcharts "github.com/linkerd/linkerd2/pkg/charts/linkerd2"
+	"github.com/spf13/pflag"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// installListImagesRunE prints the images used by the control plane and its
+// add-ons, one per line, so that they can be mirrored for air-gapped installs.
+// If a registry is set, either with --registry or global.registry, each image
+// rewritten by the chart is followed by the name it's pulled from; the images
+// overridden with another name are pulled as they are.
+func installListImagesRunE(w io.Writer, options *installOptions, flags *pflag.FlagSet) error {
+	// Build the values against the default registry, so that the images are
+	// listed under the names they're published with. This is done on a copy of
+	// the options, which are left untouched.
+	opts := *options
+	proxyOpts := *options.proxyConfigOptions
+	opts.proxyConfigOptions = &proxyOpts
+	opts.dockerRegistry = defaultDockerRegistry
+	values, _, err := opts.validateAndBuild("", flags)
+	if err != nil {
+		return err
+	}
+	registry := options.dockerRegistry
+	if registry == defaultDockerRegistry {
+		registry = values.Global.Registry
+	}
+
+	values.Global.Registry = ""
+	images, err := renderImages(values)
+	if err != nil {
+		return err
+	}
+	// The debug container isn't part of the control plane, but it's added to
+	// the injected workloads on demand, so its image must be available as well.
+	debugImage := fmt.Sprintf("%s:%s", values.DebugContainer.Image.Name, values.DebugContainer.Image.Version)
+
+	if registry == "" {
+		for _, image := range uniqueImages(append(images, debugImage)) {
+			fmt.Fprintln(w, image)
+		}
+		return nil
+	}
+
+	// Render the chart again with the registry, the images being rewritten in
+	// place, so that both renderings list them in the same order.
+	values.Global.Registry = registry
+	mirrored, err := renderImages(values)
+	if err != nil {
+		return err
+	}
+	if len(mirrored) != len(images) {
+		return fmt.Errorf("expected %d images with the %s registry, got %d", len(images), registry, len(mirrored))
+	}
+	names := map[string]string{debugImage: registryOverride(debugImage, registry)}
+	for i, image := range images {
+		names[image] = mirrored[i]
+	}
+	for _, image := range uniqueImages(append(images, debugImage)) {
+		if names[image] == image {
+			fmt.Fprintln(w, image)
+			continue
+		}
+		fmt.Fprintf(w, "%s %s\n", image, names[image])
+	}
+
+	return nil
+}
+
+// renderImages returns the images of the workloads rendered with values, in
+// the order they appear in the manifest.
+func renderImages(values *l5dcharts.Values) ([]string, error) {
+	var buf bytes.Buffer
+	if err := render(&buf, values); err != nil {
+		return nil, err
+	}
+	return manifestImages(buf.Bytes())
+}
+
+// manifestImages returns the images of all the containers of the workloads in
+// manifest, in order.
+func manifestImages(manifest []byte) ([]string, error) {
+	objs, err := splitManifest(manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	var images []string
+	for _, obj := range objs {
+		var workload struct {
+			Spec struct {
+				Template    corev1.PodTemplateSpec `json:"template"`
+				JobTemplate struct {
+					Spec struct {
+						Template corev1.PodTemplateSpec `json:"template"`
+					} `json:"spec"`
+				} `json:"jobTemplate"`
+			} `json:"spec"`
+		}
+		if err := yaml.Unmarshal(obj.YAML, &workload); err != nil {
+			return nil, fmt.Errorf("error unmarshaling %s: %s", obj.ref(), err)
+		}
+
+		for _, spec := range []corev1.PodSpec{workload.Spec.Template.Spec, workload.Spec.JobTemplate.Spec.Template.Spec} {
+			for _, c := range append(spec.InitContainers, spec.Containers...) {
+				images = append(images, c.Image)
+			}
+		}
+	}
+
+	return images, nil
+}
+
+// uniqueImages returns the sorted images, without duplicates
+func uniqueImages(images []string) []string {
+	seen := map[string]bool{}
+	var unique []string
+	for _, image := range images {
+		if !seen[image] {
+			seen[image] = true
+			unique = append(unique, image)
+		}
+	}
+	sort.Strings(unique)
+	return unique
+}
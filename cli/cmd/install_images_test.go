@@ -0,0 +1,185 @@
+package cmd
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestManifestImages(t *testing.T) {
+	manifest := `kind: Deployment
+apiVersion: apps/v1
+metadata:
+  name: linkerd-controller
+  namespace: linkerd
+spec:
+  template:
+    spec:
+      initContainers:
+      - name: linkerd-init
+        image: ghcr.io/linkerd/proxy-init:v1.3.4
+      containers:
+      - name: public-api
+        image: ghcr.io/linkerd/controller:stable
+      - name: linkerd-proxy
+        image: ghcr.io/linkerd/proxy:stable
+---
+kind: CronJob
+apiVersion: batch/v1beta1
+metadata:
+  name: linkerd-heartbeat
+  namespace: linkerd
+spec:
+  jobTemplate:
+    spec:
+      template:
+        spec:
+          containers:
+          - name: heartbeat
+            image: ghcr.io/linkerd/controller:stable
+---
+kind: Service
+apiVersion: v1
+metadata:
+  name: linkerd-controller-api
+  namespace: linkerd
+`
+
+	images, err := manifestImages([]byte(manifest))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	expected := []string{
+		"ghcr.io/linkerd/proxy-init:v1.3.4",
+		"ghcr.io/linkerd/controller:stable",
+		"ghcr.io/linkerd/proxy:stable",
+		"ghcr.io/linkerd/controller:stable",
+	}
+	if !reflect.DeepEqual(images, expected) {
+		t.Fatalf("Expected images %v, got %v", expected, images)
+	}
+}
+
+func TestInstallListImages(t *testing.T) {
+	t.Run("Lists the images with their published names", func(t *testing.T) {
+		options, err := testInstallOptions()
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		var buf bytes.Buffer
+		if err := installListImagesRunE(&buf, options, nil); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		for _, image := range []string{"prom/prometheus:v2.19.3", "ghcr.io/linkerd/debug:install-debug-version"} {
+			if !strings.Contains(buf.String(), image+"\n") {
+				t.Errorf("Expected %s to be listed, got:\n%s", image, buf.String())
+			}
+		}
+	})
+
+	t.Run("Lists the mirrored names when a registry is set", func(t *testing.T) {
+		options, err := testInstallOptions()
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		options.dockerRegistry = "registry.example.com/mirror"
+
+		var buf bytes.Buffer
+		if err := installListImagesRunE(&buf, options, nil); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+			fields := strings.Fields(line)
+			if len(fields) != 2 {
+				t.Fatalf("Expected an image and its mirrored name, got %q", line)
+			}
+			if !strings.HasPrefix(fields[1], "registry.example.com/mirror/") {
+				t.Errorf("Expected %s to be mirrored to registry.example.com/mirror, got %s", fields[0], fields[1])
+			}
+		}
+		if !strings.Contains(buf.String(), "prom/prometheus:v2.19.3 registry.example.com/mirror/prometheus:v2.19.3\n") {
+			t.Errorf("Expected the prometheus image to be mirrored, got:\n%s", buf.String())
+		}
+		if options.dockerRegistry != "registry.example.com/mirror" {
+			t.Errorf("Expected the options to be left untouched, got the %s registry", options.dockerRegistry)
+		}
+	})
+
+	t.Run("Doesn't mirror the overridden images", func(t *testing.T) {
+		options, err := testInstallOptions()
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		options.dockerRegistry = "registry.example.com/mirror"
+		options.proxyImage = "registry.example.com/custom/proxy"
+
+		var buf bytes.Buffer
+		if err := installListImagesRunE(&buf, options, nil); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		if !strings.Contains(buf.String(), "\nregistry.example.com/custom/proxy:install-proxy-version\n") {
+			t.Errorf("Expected the overridden proxy image to be listed alone, got:\n%s", buf.String())
+		}
+		if !strings.Contains(buf.String(), "ghcr.io/linkerd/controller:install-control-plane-version registry.example.com/mirror/controller:install-control-plane-version\n") {
+			t.Errorf("Expected the controller image to be mirrored, got:\n%s", buf.String())
+		}
+	})
+}
+
+func TestRenderRegistryOverriddenImages(t *testing.T) {
+	options, err := testInstallOptions()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	values, _, err := options.validateAndBuild("", nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	values.Global.Registry = "registry.example.com/mirror"
+	values.Global.Proxy.Image.Name = "registry.example.com/custom/proxy"
+
+	images, err := renderImages(values)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	for _, image := range images {
+		if strings.HasPrefix(image, "registry.example.com/mirror/proxy:") {
+			t.Fatalf("Expected the overridden proxy image not to be rewritten, got %s", image)
+		}
+	}
+	if !containsString(images, "registry.example.com/custom/proxy:install-proxy-version") {
+		t.Errorf("Expected the overridden proxy image, got %v", images)
+	}
+}
+
+func TestRenderRegistryFIPSImages(t *testing.T) {
+	options, err := testInstallOptions()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	values, _, err := options.validateAndBuild("", nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	values.Global.Registry = "registry.example.com/mirror"
+	values.Global.CryptoPolicy = "fips"
+	values.ControllerImage = "ghcr.io/linkerd/controller-fips"
+	values.WebImage = "ghcr.io/linkerd/web-fips"
+
+	images, err := renderImages(values)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if !containsString(images, "registry.example.com/mirror/controller-fips:install-control-plane-version") {
+		t.Errorf("Expected the FIPS controller image to be rewritten, got %v", images)
+	}
+	// the web image has no FIPS variant in the chart
+	if !containsString(images, "ghcr.io/linkerd/web-fips:install-control-plane-version") {
+		t.Errorf("Expected the overridden web image not to be rewritten, got %v", images)
+	}
+}
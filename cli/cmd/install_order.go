@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"sigs.k8s.io/yaml"
+)
+
+// applyStage groups resource kinds that can be applied together. Stages are
+// listed in the order they must be applied: a resource may only depend on
+// resources from the same or earlier stages.
+type applyStage struct {
+	name  string
+	kinds []string
+}
+
+// applyStages encodes the install ordering knowledge that is otherwise only
+// implicit in the way templates are grouped: namespaces must exist before the
+// resources in them, CRDs before any CRs, RBAC before the workloads using it,
+// and webhooks/API services last, since they point at workloads that must be
+// up for the API server to be able to call them.
+var applyStages = []applyStage{
+	{"namespace", []string{"Namespace"}},
+	{"crds", []string{"CustomResourceDefinition"}},
+	{"rbac", []string{"ServiceAccount", "ClusterRole", "ClusterRoleBinding", "Role", "RoleBinding", "PodSecurityPolicy", "SecurityContextConstraints"}},
+	{"config", []string{"ConfigMap", "Secret", "NetworkPolicy"}},
+	{"workloads", []string{"Service", "Deployment", "DaemonSet", "StatefulSet", "CronJob", "Job", "PodDisruptionBudget"}},
+	{"webhooks", []string{"MutatingWebhookConfiguration", "ValidatingWebhookConfiguration", "APIService"}},
+}
+
+// applyStageOther is the stage for resource kinds not known to applyStages.
+// These are applied after the workloads but before the webhooks.
+const applyStageOther = "other"
+
+// orderedObject identifies a rendered resource along with its apply stage.
+type orderedObject struct {
+	Stage     string `json:"stage"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	File      string `json:"file,omitempty"`
+}
+
+func applyStageIndex(kind string) (int, string) {
+	for i, stage := range applyStages {
+		for _, k := range stage.kinds {
+			if k == kind {
+				return i, stage.name
+			}
+		}
+	}
+	// right before the webhooks
+	return len(applyStages) - 1, applyStageOther
+}
+
+// sortByApplyOrder sorts objs in the recommended apply order. Resources
+// within the same stage keep their relative order.
+func sortByApplyOrder(objs []manifestObject) {
+	sort.SliceStable(objs, func(i, j int) bool {
+		si, namei := applyStageIndex(objs[i].Kind)
+		sj, namej := applyStageIndex(objs[j].Kind)
+		if si != sj {
+			return si < sj
+		}
+		// unknown kinds go before the webhooks stage they share an index with
+		return namei == applyStageOther && namej != applyStageOther
+	})
+}
+
+// applyOrder parses a multi-document YAML manifest and returns its resources
+// in the recommended apply order. Resources within the same stage keep their
+// order of appearance in the manifest. If withFiles is set, each resource also
+// references the file it's written to by --output-dir.
+func applyOrder(manifest []byte, withFiles bool) ([]orderedObject, error) {
+	objs, err := splitManifest(manifest)
+	if err != nil {
+		return nil, err
+	}
+	sortByApplyOrder(objs)
+
+	ordered := make([]orderedObject, len(objs))
+	for i, obj := range objs {
+		_, stage := applyStageIndex(obj.Kind)
+		ordered[i] = orderedObject{
+			Stage:     stage,
+			Kind:      obj.Kind,
+			Namespace: obj.Namespace,
+			Name:      obj.Name,
+		}
+		if withFiles {
+			ordered[i].File = obj.fileName()
+		}
+	}
+	return ordered, nil
+}
+
+// writeApplyOrder writes the recommended apply order of the resources in
+// manifest to w, as a YAML list.
+func writeApplyOrder(w io.Writer, manifest []byte, withFiles bool) error {
+	ordered, err := applyOrder(manifest, withFiles)
+	if err != nil {
+		return err
+	}
+
+	out, err := yaml.Marshal(ordered)
+	if err != nil {
+		return err
+	}
+
+	if _, err := fmt.Fprintln(w, "# Recommended apply order of the rendered Linkerd resources"); err != nil {
+		return err
+	}
+	_, err = w.Write(out)
+	return err
+}
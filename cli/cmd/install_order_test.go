@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestApplyOrder(t *testing.T) {
+	manifest := `---
+###
+### Some header
+###
+---
+kind: Deployment
+apiVersion: apps/v1
+metadata:
+  name: linkerd-controller
+  namespace: linkerd
+---
+kind: MutatingWebhookConfiguration
+apiVersion: admissionregistration.k8s.io/v1beta1
+metadata:
+  name: linkerd-proxy-injector-webhook-config
+---
+kind: Widget
+apiVersion: example.com/v1
+metadata:
+  name: custom
+  namespace: linkerd
+---
+kind: ServiceAccount
+apiVersion: v1
+metadata:
+  name: linkerd-controller
+  namespace: linkerd
+---
+kind: CustomResourceDefinition
+apiVersion: apiextensions.k8s.io/v1beta1
+metadata:
+  name: serviceprofiles.linkerd.io
+---
+kind: ConfigMap
+apiVersion: v1
+metadata:
+  name: linkerd-config
+  namespace: linkerd
+---
+kind: Namespace
+apiVersion: v1
+metadata:
+  name: linkerd
+---
+kind: Service
+apiVersion: v1
+metadata:
+  name: linkerd-controller-api
+  namespace: linkerd
+`
+
+	expected := []orderedObject{
+		{Stage: "namespace", Kind: "Namespace", Name: "linkerd"},
+		{Stage: "crds", Kind: "CustomResourceDefinition", Name: "serviceprofiles.linkerd.io"},
+		{Stage: "rbac", Kind: "ServiceAccount", Namespace: "linkerd", Name: "linkerd-controller"},
+		{Stage: "config", Kind: "ConfigMap", Namespace: "linkerd", Name: "linkerd-config"},
+		{Stage: "workloads", Kind: "Deployment", Namespace: "linkerd", Name: "linkerd-controller"},
+		{Stage: "workloads", Kind: "Service", Namespace: "linkerd", Name: "linkerd-controller-api"},
+		{Stage: "other", Kind: "Widget", Namespace: "linkerd", Name: "custom"},
+		{Stage: "webhooks", Kind: "MutatingWebhookConfiguration", Name: "linkerd-proxy-injector-webhook-config"},
+	}
+
+	ordered, err := applyOrder([]byte(manifest), false)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if !reflect.DeepEqual(ordered, expected) {
+		t.Fatalf("Expected order:\n%+v\nGot:\n%+v", expected, ordered)
+	}
+}
@@ -0,0 +1,234 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	yamlDecoder "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+)
+
+// manifestObject is a single resource of a rendered manifest.
+type manifestObject struct {
+	Kind      string
+	Namespace string
+	Name      string
+	YAML      []byte
+}
+
+// ref returns a human readable reference to the object, e.g.
+// Deployment linkerd/linkerd-controller.
+func (obj manifestObject) ref() string {
+	if obj.Namespace == "" {
+		return fmt.Sprintf("%s %s", obj.Kind, obj.Name)
+	}
+	return fmt.Sprintf("%s %s/%s", obj.Kind, obj.Namespace, obj.Name)
+}
+
+// fileName returns the name of the file the object is written to when using
+// --output-dir, i.e. kind_namespace_name.yaml, or kind_name.yaml for
+// cluster-scoped resources.
+func (obj manifestObject) fileName() string {
+	parts := []string{obj.Kind, obj.Namespace, obj.Name}
+	if obj.Namespace == "" {
+		parts = []string{obj.Kind, obj.Name}
+	}
+	name := strings.ToLower(strings.Join(parts, "_"))
+	// resource names may contain characters that aren't portable in file
+	// names, like the colons in system:* roles
+	name = strings.NewReplacer(":", "-", "/", "-").Replace(name)
+	return name + ".yaml"
+}
+
+// splitManifest parses a multi-document YAML manifest into its resources.
+// Documents holding only comments, like the section headers of the rendered
+// install manifest, are skipped.
+func splitManifest(manifest []byte) ([]manifestObject, error) {
+	var objs []manifestObject
+	yamlReader := yamlDecoder.NewYAMLReader(bufio.NewReader(bytes.NewReader(manifest)))
+	for {
+		objYAML, err := yamlReader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error reading manifest: %s", err)
+		}
+
+		var meta struct {
+			Kind     string `json:"kind"`
+			Metadata struct {
+				Name      string `json:"name"`
+				Namespace string `json:"namespace"`
+			} `json:"metadata"`
+		}
+		if err := yaml.Unmarshal(objYAML, &meta); err != nil {
+			return nil, fmt.Errorf("error unmarshaling yaml object %s: %s", objYAML, err)
+		}
+		if meta.Kind == "" {
+			// Ignore header blocks with only comments
+			continue
+		}
+
+		objs = append(objs, manifestObject{
+			Kind:      meta.Kind,
+			Namespace: meta.Metadata.Namespace,
+			Name:      meta.Metadata.Name,
+			YAML:      objYAML,
+		})
+	}
+
+	return objs, nil
+}
+
+// manifestDirIndexFile is the file in --output-dir recording the apply order
+// of the resources written to it, along with their files. It's not a .yaml
+// file, so that `kubectl apply -f <dir>` doesn't try to apply it.
+const manifestDirIndexFile = ".linkerd-apply-order"
+
+// writeManifestDir writes each resource of manifest to its own file in dir,
+// creating dir if needed, along with the manifestDirIndexFile. Existing files
+// with the same names are overwritten, and the files written by the previous
+// render that aren't written anymore are removed, so that the resources
+// dropped since then don't linger in it.
+func writeManifestDir(dir string, manifest []byte) error {
+	objs, err := splitManifest(manifest)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	written := map[string]manifestObject{}
+	for _, obj := range objs {
+		name := obj.fileName()
+		if prev, ok := written[name]; ok {
+			return fmt.Errorf("%s and %s would both be written to %s", prev.ref(), obj.ref(), name)
+		}
+		written[name] = obj
+
+		content := append(bytes.TrimSpace(obj.YAML), '\n')
+		if err := ioutil.WriteFile(filepath.Join(dir, name), content, 0644); err != nil {
+			return err
+		}
+	}
+
+	if err := removeStaleFiles(dir, written); err != nil {
+		return err
+	}
+
+	var index bytes.Buffer
+	if err := writeApplyOrder(&index, manifest, true); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(dir, manifestDirIndexFile), index.Bytes(), 0644)
+}
+
+// removeStaleFiles removes the files listed in the manifestDirIndexFile of dir
+// that aren't in written. Only the files written by a previous render are
+// removed: other files, like an order manifest or a README, are left alone.
+func removeStaleFiles(dir string, written map[string]manifestObject) error {
+	index, err := ioutil.ReadFile(filepath.Join(dir, manifestDirIndexFile))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var previous []orderedObject
+	if err := yaml.Unmarshal(index, &previous); err != nil {
+		return fmt.Errorf("invalid %s: %s", manifestDirIndexFile, err)
+	}
+
+	for _, obj := range previous {
+		// the index may have been edited, so only the .yaml files directly in
+		// dir are removed
+		if obj.File == "" || filepath.Base(obj.File) != obj.File || filepath.Ext(obj.File) != ".yaml" {
+			continue
+		}
+		if _, ok := written[obj.File]; ok {
+			continue
+		}
+		path := filepath.Join(dir, obj.File)
+		if f, err := os.Lstat(path); err != nil || !f.Mode().IsRegular() {
+			continue
+		}
+		if err := os.Remove(path); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeManifest writes the rendered manifest to w in the given output format.
+// With jsonOutput, every resource is written as a JSON object on its own
+// line, which can be consumed by tools like jq.
+func writeManifest(w io.Writer, manifest []byte, output string) error {
+	if output != jsonOutput {
+		_, err := w.Write(manifest)
+		return err
+	}
+
+	objs, err := splitManifest(manifest)
+	if err != nil {
+		return err
+	}
+
+	for _, obj := range objs {
+		j, err := yaml.YAMLToJSON(obj.YAML)
+		if err != nil {
+			return fmt.Errorf("error converting %s %s/%s to JSON: %s", obj.Kind, obj.Namespace, obj.Name, err)
+		}
+		if _, err := fmt.Fprintf(w, "%s\n", j); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// kustomization is the subset of kustomize's Kustomization used for the
+// kustomize base generated by `linkerd install --output kustomize`.
+type kustomization struct {
+	APIVersion string   `json:"apiVersion"`
+	Kind       string   `json:"kind"`
+	Resources  []string `json:"resources"`
+}
+
+// kustomizationFileName is the name of the file kustomize looks for in a base
+// directory.
+const kustomizationFileName = "kustomization.yaml"
+
+// writeKustomization writes a kustomization.yaml to dir, listing the files
+// written by writeManifestDir in their recommended apply order.
+func writeKustomization(dir string, manifest []byte) error {
+	ordered, err := applyOrder(manifest, true)
+	if err != nil {
+		return err
+	}
+
+	k := kustomization{
+		APIVersion: "kustomize.config.k8s.io/v1beta1",
+		Kind:       "Kustomization",
+		Resources:  make([]string, len(ordered)),
+	}
+	for i, obj := range ordered {
+		k.Resources[i] = obj.File
+	}
+
+	out, err := yaml.Marshal(k)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(filepath.Join(dir, kustomizationFileName), out, 0644)
+}
@@ -0,0 +1,237 @@
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestWriteManifestDir(t *testing.T) {
+	manifest := `---
+###
+### Some header
+###
+---
+kind: Namespace
+apiVersion: v1
+metadata:
+  name: linkerd
+---
+kind: ClusterRole
+apiVersion: rbac.authorization.k8s.io/v1
+metadata:
+  name: system:linkerd-controller
+---
+kind: Deployment
+apiVersion: apps/v1
+metadata:
+  name: linkerd-controller
+  namespace: linkerd
+`
+
+	dir, err := ioutil.TempDir("", "linkerd-output-dir")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	outputDir := filepath.Join(dir, "manifests")
+	if err := writeManifestDir(outputDir, []byte(manifest)); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	files, err := ioutil.ReadDir(outputDir)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	var names []string
+	for _, f := range files {
+		names = append(names, f.Name())
+	}
+	sort.Strings(names)
+
+	expected := []string{
+		manifestDirIndexFile,
+		"clusterrole_system-linkerd-controller.yaml",
+		"deployment_linkerd_linkerd-controller.yaml",
+		"namespace_linkerd.yaml",
+	}
+	if !reflect.DeepEqual(names, expected) {
+		t.Fatalf("Expected files %v, got %v", expected, names)
+	}
+
+	content, err := ioutil.ReadFile(filepath.Join(outputDir, "namespace_linkerd.yaml"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	expectedContent := `kind: Namespace
+apiVersion: v1
+metadata:
+  name: linkerd
+`
+	if string(content) != expectedContent {
+		t.Fatalf("Expected content:\n%s\nGot:\n%s", expectedContent, content)
+	}
+
+	t.Run("Removes the files of the previous render", func(t *testing.T) {
+		previous := manifest + `---
+kind: Deployment
+apiVersion: apps/v1
+metadata:
+  name: linkerd-web
+  namespace: linkerd
+`
+		if err := writeManifestDir(outputDir, []byte(previous)); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		stale := filepath.Join(outputDir, "deployment_linkerd_linkerd-web.yaml")
+		if _, err := os.Stat(stale); err != nil {
+			t.Fatalf("Expected %s to be written, got %v", stale, err)
+		}
+
+		// an order manifest and the other files not written by linkerd
+		// install are kept, whatever their extension
+		kept := []string{
+			filepath.Join(outputDir, "order.yaml"),
+			filepath.Join(outputDir, "deployment_default_app.yaml"),
+			filepath.Join(outputDir, "README.md"),
+		}
+		for _, f := range kept {
+			if err := ioutil.WriteFile(f, []byte("kept"), 0644); err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+		}
+
+		if err := writeManifestDir(outputDir, []byte(manifest)); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		if _, err := os.Stat(stale); !os.IsNotExist(err) {
+			t.Errorf("Expected %s to be removed, got %v", stale, err)
+		}
+		for _, f := range append(kept, filepath.Join(outputDir, "namespace_linkerd.yaml")) {
+			if _, err := os.Stat(f); err != nil {
+				t.Errorf("Expected %s to be kept, got %v", f, err)
+			}
+		}
+	})
+
+	t.Run("Doesn't remove anything without an index", func(t *testing.T) {
+		if err := os.Remove(filepath.Join(outputDir, manifestDirIndexFile)); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		unknown := filepath.Join(outputDir, "deployment_linkerd_linkerd-grafana.yaml")
+		if err := ioutil.WriteFile(unknown, []byte("kept"), 0644); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		if err := writeManifestDir(outputDir, []byte(manifest)); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if _, err := os.Stat(unknown); err != nil {
+			t.Errorf("Expected %s to be kept, got %v", unknown, err)
+		}
+	})
+
+	t.Run("Rejects file name collisions", func(t *testing.T) {
+		duplicated := manifest + `---
+kind: Deployment
+apiVersion: apps/v1
+metadata:
+  name: linkerd-controller
+  namespace: linkerd
+`
+		err := writeManifestDir(outputDir, []byte(duplicated))
+		expected := "Deployment linkerd/linkerd-controller and Deployment linkerd/linkerd-controller would both be written to deployment_linkerd_linkerd-controller.yaml"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("Expected error %q, got %v", expected, err)
+		}
+	})
+}
+
+func TestWriteKustomization(t *testing.T) {
+	manifest := `kind: Deployment
+apiVersion: apps/v1
+metadata:
+  name: linkerd-controller
+  namespace: linkerd
+---
+kind: Namespace
+apiVersion: v1
+metadata:
+  name: linkerd
+`
+
+	dir, err := ioutil.TempDir("", "linkerd-kustomize")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err := writeKustomization(dir, []byte(manifest)); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	content, err := ioutil.ReadFile(filepath.Join(dir, kustomizationFileName))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	expected := `apiVersion: kustomize.config.k8s.io/v1beta1
+kind: Kustomization
+resources:
+- namespace_linkerd.yaml
+- deployment_linkerd_linkerd-controller.yaml
+`
+	if string(content) != expected {
+		t.Fatalf("Expected kustomization:\n%s\nGot:\n%s", expected, content)
+	}
+}
+
+func TestWriteManifest(t *testing.T) {
+	manifest := `---
+###
+### Some header
+###
+---
+kind: Namespace
+apiVersion: v1
+metadata:
+  name: linkerd
+---
+kind: ConfigMap
+apiVersion: v1
+metadata:
+  name: linkerd-config
+  namespace: linkerd
+data:
+  values: |
+    a: 1
+`
+
+	t.Run("YAML output is left untouched", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := writeManifest(&buf, []byte(manifest), yamlOutput); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if buf.String() != manifest {
+			t.Fatalf("Expected manifest:\n%s\nGot:\n%s", manifest, buf.String())
+		}
+	})
+
+	t.Run("JSON output has one object per line", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := writeManifest(&buf, []byte(manifest), jsonOutput); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		expected := `{"apiVersion":"v1","kind":"Namespace","metadata":{"name":"linkerd"}}
+{"apiVersion":"v1","data":{"values":"a: 1\n"},"kind":"ConfigMap","metadata":{"name":"linkerd-config","namespace":"linkerd"}}
+`
+		if buf.String() != expected {
+			t.Fatalf("Expected JSON:\n%s\nGot:\n%s", expected, buf.String())
+		}
+	})
+}
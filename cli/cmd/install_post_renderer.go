@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+
+	"github.com/spf13/pflag"
+)
+
+// postRendererFlagSet returns the --post-renderer flag, shared by install and
+// upgrade. Like values files, the post-renderer isn't persisted, and must be
+// provided again on upgrade.
+func (options *installOptions) postRendererFlagSet() *pflag.FlagSet {
+	flags := pflag.NewFlagSet("post-renderer", pflag.ExitOnError)
+
+	flags.StringVar(
+		&options.postRenderer, "post-renderer", options.postRenderer,
+		"The path to an executable to be used for post rendering, e.g. to patch the manifests with kustomize. It reads the rendered manifest from its standard input, and must write the modified manifest to its standard output. If it exists in $PATH, the binary name is enough",
+	)
+
+	return flags
+}
+
+// postRender pipes the rendered manifest held by buf through the post-renderer
+// command, mirroring Helm's --post-renderer, and replaces the content of buf
+// with its output.
+func postRender(command string, buf *bytes.Buffer) error {
+	path, err := exec.LookPath(command)
+	if err != nil {
+		return fmt.Errorf("unable to find post-renderer %s: %s", command, err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(path)
+	cmd.Stdin = bytes.NewReader(buf.Bytes())
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("error while running post-renderer %s: %s\n%s", command, err, stderr.String())
+	}
+
+	// Catch broken post-renderers before their output reaches the cluster
+	objs, err := splitManifest(stdout.Bytes())
+	if err != nil {
+		return fmt.Errorf("invalid post-renderer output: %s", err)
+	}
+	if len(objs) == 0 {
+		return errors.New("post-renderer returned an empty manifest")
+	}
+
+	buf.Reset()
+	_, err = buf.Write(stdout.Bytes())
+	return err
+}
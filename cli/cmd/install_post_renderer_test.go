@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPostRender(t *testing.T) {
+	manifest := `kind: Namespace
+apiVersion: v1
+metadata:
+  name: linkerd
+`
+
+	dir, err := ioutil.TempDir("", "linkerd-post-renderer")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	writeScript := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := ioutil.WriteFile(path, []byte("#!/bin/sh\n"+content), 0755); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		return path
+	}
+
+	t.Run("Replaces the manifest with the post-renderer output", func(t *testing.T) {
+		script := writeScript("label.sh", "sed 's/  name: linkerd/  name: linkerd\\n  labels:\\n    team: mesh/'\n")
+
+		buf := bytes.NewBufferString(manifest)
+		if err := postRender(script, buf); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		expected := `kind: Namespace
+apiVersion: v1
+metadata:
+  name: linkerd
+  labels:
+    team: mesh
+`
+		if buf.String() != expected {
+			t.Fatalf("Expected manifest:\n%s\nGot:\n%s", expected, buf.String())
+		}
+	})
+
+	t.Run("Rejects failing post-renderers", func(t *testing.T) {
+		script := writeScript("fail.sh", "echo 'kustomize failed' >&2\nexit 1\n")
+
+		err := postRender(script, bytes.NewBufferString(manifest))
+		if err == nil || !strings.Contains(err.Error(), "kustomize failed") {
+			t.Fatalf("Expected the post-renderer error to be reported, got %v", err)
+		}
+	})
+
+	t.Run("Rejects empty output", func(t *testing.T) {
+		script := writeScript("empty.sh", "cat > /dev/null\n")
+
+		err := postRender(script, bytes.NewBufferString(manifest))
+		expected := "post-renderer returned an empty manifest"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("Expected error %q, got %v", expected, err)
+		}
+	})
+
+	t.Run("Rejects missing post-renderers", func(t *testing.T) {
+		err := postRender(filepath.Join(dir, "missing.sh"), bytes.NewBufferString(manifest))
+		if err == nil || !strings.HasPrefix(err.Error(), "unable to find post-renderer") {
+			t.Fatalf("Expected a missing post-renderer error, got %v", err)
+		}
+	})
+}
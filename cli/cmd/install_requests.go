@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	l5dcharts "github.com/linkerd/linkerd2/pkg/charts/linkerd2"
+	"github.com/linkerd/linkerd2/pkg/healthcheck"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// componentResources holds the resources of a control plane component's
+// containers and of its proxy, along with its number of replicas.
+type componentResources struct {
+	replicas  int64
+	resources *l5dcharts.Resources
+	proxy     *l5dcharts.Resources
+}
+
+// controlPlaneRequests returns the CPU and memory requested by all the
+// replicas of the control plane components and of the enabled add-ons,
+// including their proxies, as they'd be rendered from values.
+func controlPlaneRequests(values *l5dcharts.Values) (corev1.ResourceList, error) {
+	dashboardReplicas := int64(1)
+	if values.Dashboard != nil {
+		dashboardReplicas = int64(values.Dashboard.Replicas)
+	}
+
+	controllerReplicas := int64(values.ControllerReplicas)
+	components := []componentResources{
+		{controllerReplicas, values.PublicAPIResources, values.PublicAPIProxyResources},
+		{controllerReplicas, values.DestinationResources, values.DestinationProxyResources},
+		{controllerReplicas, values.IdentityResources, values.IdentityProxyResources},
+		{controllerReplicas, values.ProxyInjectorResources, values.ProxyInjectorProxyResources},
+		{controllerReplicas, values.SPValidatorResources, values.SPValidatorProxyResources},
+		{controllerReplicas, values.TapResources, values.TapProxyResources},
+		{dashboardReplicas, values.WebResources, values.WebProxyResources},
+	}
+
+	for _, addOn := range []map[string]interface{}{values.Prometheus, values.Grafana} {
+		if enabled, _ := addOn["enabled"].(bool); !enabled {
+			continue
+		}
+		resources, err := addOnResources(addOn)
+		if err != nil {
+			return nil, err
+		}
+		components = append(components, componentResources{1, resources, nil})
+	}
+
+	requests := corev1.ResourceList{}
+	for _, c := range components {
+		for _, r := range []*l5dcharts.Resources{c.resources, proxyResources(c.proxy, values.Global.Proxy.Resources)} {
+			if err := addRequests(requests, r, c.replicas); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return requests, nil
+}
+
+// proxyResources returns the resources of a component's proxy, which fall back
+// to the global proxy resources for the fields that aren't set, just like in
+// the templates.
+func proxyResources(component, global *l5dcharts.Resources) *l5dcharts.Resources {
+	if global == nil {
+		return component
+	}
+	if component == nil {
+		return global
+	}
+
+	r := *component
+	if r.CPU.Request == "" {
+		r.CPU.Request = global.CPU.Request
+	}
+	if r.Memory.Request == "" {
+		r.Memory.Request = global.Memory.Request
+	}
+	return &r
+}
+
+func addOnResources(addOn map[string]interface{}) (*l5dcharts.Resources, error) {
+	if addOn["resources"] == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(addOn["resources"])
+	if err != nil {
+		return nil, err
+	}
+
+	var resources l5dcharts.Resources
+	if err := json.Unmarshal(data, &resources); err != nil {
+		return nil, fmt.Errorf("invalid add-on resources: %s", err)
+	}
+	return &resources, nil
+}
+
+func addRequests(requests corev1.ResourceList, resources *l5dcharts.Resources, replicas int64) error {
+	if resources == nil {
+		return nil
+	}
+
+	for name, request := range map[corev1.ResourceName]string{
+		corev1.ResourceCPU:    resources.CPU.Request,
+		corev1.ResourceMemory: resources.Memory.Request,
+	} {
+		if request == "" {
+			continue
+		}
+		q, err := resource.ParseQuantity(request)
+		if err != nil {
+			return fmt.Errorf("invalid %s request '%s': %s", name, request, err)
+		}
+
+		total := requests[name]
+		for i := int64(0); i < replicas; i++ {
+			total.Add(q)
+		}
+		requests[name] = total
+	}
+
+	return nil
+}
+
+// controlPlaneScheduling returns the node selector and tolerations of each of
+// the enabled control plane components, merged from the global and
+// per-component values just like in the templates.
+func controlPlaneScheduling(values *l5dcharts.Values) ([]healthcheck.ComponentScheduling, error) {
+	var scheduling []healthcheck.ComponentScheduling
+	for _, component := range schedulableComponents {
+		if (component == "heartbeat" && values.DisableHeartBeat) ||
+			(component == "identity-rotation" && !values.EnableIdentityRotation) {
+			continue
+		}
+
+		nodeSelector := map[string]string{}
+		for k, v := range values.NodeSelector {
+			nodeSelector[k] = v
+		}
+		for k, v := range values.ComponentNodeSelectors[component] {
+			nodeSelector[k] = v
+		}
+
+		data, err := json.Marshal(append(append([]interface{}{}, values.Tolerations...), values.ComponentTolerations[component]...))
+		if err != nil {
+			return nil, err
+		}
+		var tolerations []corev1.Toleration
+		if err := json.Unmarshal(data, &tolerations); err != nil {
+			return nil, fmt.Errorf("invalid %s tolerations: %s", component, err)
+		}
+
+		scheduling = append(scheduling, healthcheck.ComponentScheduling{
+			Component:    component,
+			NodeSelector: nodeSelector,
+			Tolerations:  tolerations,
+		})
+	}
+
+	return scheduling, nil
+}
@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"testing"
+
+	l5dcharts "github.com/linkerd/linkerd2/pkg/charts/linkerd2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestControlPlaneRequests(t *testing.T) {
+	resources := func(cpu, memory string) *l5dcharts.Resources {
+		return &l5dcharts.Resources{
+			CPU:    l5dcharts.Constraints{Request: cpu},
+			Memory: l5dcharts.Constraints{Request: memory},
+		}
+	}
+
+	values := &l5dcharts.Values{
+		ControllerReplicas:     3,
+		Dashboard:              &l5dcharts.Dashboard{Replicas: 1},
+		DestinationResources:   resources("100m", "50Mi"),
+		IdentityResources:      resources("100m", "10Mi"),
+		IdentityProxyResources: resources("", "40Mi"),
+		WebProxyResources:      resources("50m", ""),
+		Global:                 &l5dcharts.Global{Proxy: &l5dcharts.Proxy{Resources: resources("10m", "20Mi")}},
+		Prometheus:             l5dcharts.Prometheus{"enabled": true, "resources": map[string]interface{}{"cpu": map[string]interface{}{"request": "300m"}}},
+		Grafana:                l5dcharts.Grafana{"enabled": false, "resources": map[string]interface{}{"cpu": map[string]interface{}{"request": "1"}}},
+	}
+
+	requests, err := controlPlaneRequests(values)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	// destination and identity: 2*3*100m
+	// proxies: 6*3*10m for the controller components, 50m for web, 10m for prometheus
+	// prometheus: 300m
+	expectedCPU := resource.MustParse("1140m")
+	if cpu := requests[corev1.ResourceCPU]; cpu.Cmp(expectedCPU) != 0 {
+		t.Errorf("Expected %s of CPU to be requested, got %s", expectedCPU.String(), cpu.String())
+	}
+
+	// destination: 3*50Mi, identity: 3*10Mi
+	// proxies: 5*3*20Mi + 3*40Mi for the controller components, 20Mi for web and prometheus
+	expectedMemory := resource.MustParse("640Mi")
+	if memory := requests[corev1.ResourceMemory]; memory.Cmp(expectedMemory) != 0 {
+		t.Errorf("Expected %s of memory to be requested, got %s", expectedMemory.String(), memory.String())
+	}
+
+	values.DestinationResources = resources("lots", "")
+	if _, err := controlPlaneRequests(values); err == nil {
+		t.Error("Expected an error for an invalid CPU request")
+	}
+}
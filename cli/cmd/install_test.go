@@ -2,12 +2,20 @@ package cmd
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/golang/protobuf/ptypes"
 	"github.com/linkerd/linkerd2/controller/gen/config"
 	charts "github.com/linkerd/linkerd2/pkg/charts/linkerd2"
+	"github.com/linkerd/linkerd2/pkg/healthcheck"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/linkerd/linkerd2/pkg/tls"
 )
 
 const (
@@ -28,6 +36,12 @@ func TestRender(t *testing.T) {
 	}
 	addFakeTLSSecrets(defaultValues)
 
+	crdsValues, _, err := defaultOptions.validateAndBuild(crdsStage, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error validating options: %v", err)
+	}
+	addFakeTLSSecrets(crdsValues)
+
 	configValues, _, err := defaultOptions.validateAndBuild(configStage, nil)
 	if err != nil {
 		t.Fatalf("Unexpected error validating options: %v", err)
@@ -87,6 +101,9 @@ func TestRender(t *testing.T) {
 			CNIEnabled:               false,
 			IdentityTrustDomain:      defaultValues.Global.IdentityTrustDomain,
 			IdentityTrustAnchorsPEM:  defaultValues.Global.IdentityTrustAnchorsPEM,
+			ControlPlaneMetrics: &charts.ControlPlaneMetrics{
+				Scheme: "http",
+			},
 			Proxy: &charts.Proxy{
 				DestinationGetNetworks: "DestinationGetNetworks",
 				Image: &charts.Image{
@@ -204,6 +221,39 @@ func TestRender(t *testing.T) {
 	withHeartBeatDisabledValues, _, _ := withHeartBeatDisabled.validateAndBuild("", nil)
 	addFakeTLSSecrets(withHeartBeatDisabledValues)
 
+	withHealthExporter, err := testInstallOptions()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v\n", err)
+	}
+	withHealthExporter.enableHealthExporter = true
+	withHealthExporterValues, _, _ := withHealthExporter.validateAndBuild("", nil)
+	addFakeTLSSecrets(withHealthExporterValues)
+
+	withIdentityRotation, err := testInstallOptions()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v\n", err)
+	}
+	withIdentityRotation.enableIdentityRotation = true
+	withIdentityRotationValues, _, _ := withIdentityRotation.validateAndBuild("", nil)
+	addFakeTLSSecrets(withIdentityRotationValues)
+
+	withTrustBundle, err := testInstallOptions()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v\n", err)
+	}
+	withTrustBundle.enableTrustBundle = true
+	withTrustBundle.trustBundleNSSelector = "linkerd.io/trust-bundle=enabled"
+	withTrustBundleValues, _, _ := withTrustBundle.validateAndBuild("", nil)
+	addFakeTLSSecrets(withTrustBundleValues)
+
+	withTapAggregateToAdmin, err := testInstallOptions()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v\n", err)
+	}
+	withTapAggregateToAdminValues, _, _ := withTapAggregateToAdmin.validateAndBuild("", nil)
+	withTapAggregateToAdminValues.Tap.AggregateToAdmin = true
+	addFakeTLSSecrets(withTapAggregateToAdminValues)
+
 	withRestrictedDashboardPrivileges, err := testInstallOptions()
 	if err != nil {
 		t.Fatalf("Unexpected error: %v\n", err)
@@ -256,11 +306,129 @@ func TestRender(t *testing.T) {
 	withCustomDestinationGetNetsValues, _, _ := withCustomDestinationGetNets.validateAndBuild("", nil)
 	addFakeTLSSecrets(withCustomDestinationGetNetsValues)
 
+	withControlPlaneMetrics, err := testInstallOptions()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v\n", err)
+	}
+	withControlPlaneMetrics.controlPlaneMetricsPort = 9900
+	withControlPlaneMetrics.controlPlaneMetricsScheme = "https"
+	withControlPlaneMetricsValues, _, _ := withControlPlaneMetrics.validateAndBuild("", nil)
+	addFakeTLSSecrets(withControlPlaneMetricsValues)
+
+	withImagePullSecrets, err := testInstallOptions()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v\n", err)
+	}
+	withImagePullSecrets.imagePullSecrets = []string{"registry-login"}
+	withImagePullSecretsValues, _, _ := withImagePullSecrets.validateAndBuild("", nil)
+	withImagePullSecretsValues.Tracing["enabled"] = true
+	addFakeTLSSecrets(withImagePullSecretsValues)
+
+	withExternalPrometheus, err := testInstallOptions()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v\n", err)
+	}
+	withExternalPrometheus.prometheusURL = "http://prometheus.monitoring.svc.cluster.local:9090"
+	withExternalPrometheusValues, _, _ := withExternalPrometheus.validateAndBuild("", nil)
+	addFakeTLSSecrets(withExternalPrometheusValues)
+
+	withCertManager, err := testInstallOptions()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v\n", err)
+	}
+	withCertManager.identityOptions.crtPEMFile = ""
+	withCertManager.identityOptions.keyPEMFile = ""
+	withCertManager.identityOptions.identityExternalIssuer = true
+	withCertManager.identityOptions.certManager.Enabled = true
+	withCertManagerValues, _, _ := withCertManager.validateAndBuild("", nil)
+	addFakeTLSSecrets(withCertManagerValues)
+
+	withComponentArch, err := testInstallOptions()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v\n", err)
+	}
+	withComponentArch.componentArchitectures = map[string]string{"destination": "arm64", "identity": "arm64"}
+	withComponentArchValues, _, _ := withComponentArch.validateAndBuild("", nil)
+	addFakeTLSSecrets(withComponentArchValues)
+
+	withLogOptions, err := testInstallOptions()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v\n", err)
+	}
+	withLogOptions.proxyLogFormat = "json"
+	withLogOptions.componentLogLevels = map[string]string{"controller": "debug", "destination": "warn"}
+	withLogOptionsValues, _, _ := withLogOptions.validateAndBuild("", nil)
+	addFakeTLSSecrets(withLogOptionsValues)
+
+	withAddOnFlags, err := testInstallOptions()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v\n", err)
+	}
+	withAddOnFlags.addOns = []string{"tracing"}
+	withAddOnFlags.skipAddOns = []string{"grafana"}
+	withAddOnFlagsValues, _, _ := withAddOnFlags.validateAndBuild("", nil)
+	addFakeTLSSecrets(withAddOnFlagsValues)
+
+	withNetworkPolicies, err := testInstallOptions()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v\n", err)
+	}
+	withNetworkPolicies.enableNetworkPolicies = true
+	withNetworkPoliciesValues, _, _ := withNetworkPolicies.validateAndBuild("", nil)
+	withNetworkPoliciesValues.DataPlaneNetworkPolicyNamespaces = []string{"emojivoto"}
+	addFakeTLSSecrets(withNetworkPoliciesValues)
+
+	withFIPS, err := testInstallOptions()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v\n", err)
+	}
+	withFIPS.cryptoPolicy = "fips"
+	withFIPSValues, _, err := withFIPS.validateAndBuild("", nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v\n", err)
+	}
+	addFakeTLSSecrets(withFIPSValues)
+
+	withInjectorSelectors, err := testInstallOptions()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v\n", err)
+	}
+	withInjectorSelectors.injectorFailurePolicy = "Fail"
+	withInjectorSelectors.injectorNamespaceSelector = "kubernetes.io/metadata.name notin (kube-system),team=emojivoto"
+	withInjectorSelectors.injectorObjectSelector = "app.kubernetes.io/part-of=emojivoto"
+	withInjectorSelectorsValues, _, err := withInjectorSelectors.validateAndBuild("", nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v\n", err)
+	}
+	addFakeTLSSecrets(withInjectorSelectorsValues)
+
+	withOpenShift, err := testInstallOptions()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v\n", err)
+	}
+	withOpenShift.openshift = true
+	withOpenShiftValues, _, _ := withOpenShift.validateAndBuild("", nil)
+	addFakeTLSSecrets(withOpenShiftValues)
+
+	withSpire, err := testInstallOptions()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v\n", err)
+	}
+	withSpire.identityOptions.crtPEMFile = ""
+	withSpire.identityOptions.keyPEMFile = ""
+	withSpire.identityOptions.spire.ServerAddr = "spire-server.spire.svc.cluster.local:8081"
+	withSpireValues, _, err := withSpire.validateAndBuild("", nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v\n", err)
+	}
+	addFakeTLSSecrets(withSpireValues)
+
 	testCases := []struct {
 		values         *charts.Values
 		goldenFileName string
 	}{
 		{defaultValues, "install_default.golden"},
+		{crdsValues, "install_crds.golden"},
 		{configValues, "install_config.golden"},
 		{controlPlaneValues, "install_control-plane.golden"},
 		{metaValues, "install_output.golden"},
@@ -269,12 +437,28 @@ func TestRender(t *testing.T) {
 		{cniEnabledValues, "install_no_init_container.golden"},
 		{withProxyIgnoresValues, "install_proxy_ignores.golden"},
 		{withHeartBeatDisabledValues, "install_heartbeat_disabled_output.golden"},
+		{withHealthExporterValues, "install_health_exporter.golden"},
+		{withIdentityRotationValues, "install_identity_rotation.golden"},
+		{withTrustBundleValues, "install_trust_bundle.golden"},
+		{withTapAggregateToAdminValues, "install_tap_aggregate_to_admin.golden"},
 		{withRestrictedDashboardPrivilegesValues, "install_restricted_dashboard.golden"},
 		{withControlPlaneTracingValues, "install_controlplane_tracing_output.golden"},
 		{withCustomRegistryValues, "install_custom_registry.golden"},
 		{withAddOnConfigStageValues, "install_addon_config.golden"},
 		{withAddOnControlPlaneStageValues, "install_addon_control-plane.golden"},
 		{withCustomDestinationGetNetsValues, "install_default_override_dst_get_nets.golden"},
+		{withControlPlaneMetricsValues, "install_controlplane_metrics.golden"},
+		{withImagePullSecretsValues, "install_image_pull_secrets.golden"},
+		{withExternalPrometheusValues, "install_external_prometheus.golden"},
+		{withCertManagerValues, "install_identity_cert_manager.golden"},
+		{withOpenShiftValues, "install_openshift.golden"},
+		{withComponentArchValues, "install_component_arch.golden"},
+		{withLogOptionsValues, "install_log_options.golden"},
+		{withAddOnFlagsValues, "install_addon_flags.golden"},
+		{withNetworkPoliciesValues, "install_network_policies.golden"},
+		{withFIPSValues, "install_fips.golden"},
+		{withInjectorSelectorsValues, "install_proxy_injector_selectors.golden"},
+		{withSpireValues, "install_identity_spire.golden"},
 	}
 
 	for i, tc := range testCases {
@@ -289,6 +473,86 @@ func TestRender(t *testing.T) {
 	}
 }
 
+func TestRenderOpenShiftSCC(t *testing.T) {
+	options, err := testInstallOptions()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v\n", err)
+	}
+	options.openshift = true
+	values, _, err := options.validateAndBuild("", nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v\n", err)
+	}
+	addFakeTLSSecrets(values)
+	values.OpenShiftSCC = &charts.OpenShiftSCC{
+		RunAsUser: map[string]interface{}{
+			"type":        "MustRunAsRange",
+			"uidRangeMin": 1000650000,
+			"uidRangeMax": 1000659999,
+		},
+		FSGroup: map[string]interface{}{"type": "RunAsAny"},
+	}
+
+	var buf bytes.Buffer
+	if err := render(&buf, values); err != nil {
+		t.Fatalf("Failed to render templates: %v", err)
+	}
+
+	expected := []string{
+		"runAsUser:\n  type: MustRunAsRange\n  uidRangeMax: 1000659999\n  uidRangeMin: 1000650000\n",
+		"supplementalGroups:\n  type: MustRunAs\n  ranges:\n  - min: 1\n    max: 65535\n",
+		"fsGroup:\n  type: RunAsAny\nvolumes:",
+	}
+	for _, e := range expected {
+		if !strings.Contains(buf.String(), e) {
+			t.Errorf("Expected the SecurityContextConstraints to contain:\n%s", e)
+		}
+	}
+}
+
+func TestRenderNetworkPoliciesWithExternalPrometheus(t *testing.T) {
+	options, err := testInstallOptions()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v\n", err)
+	}
+	options.enableNetworkPolicies = true
+	options.prometheusURL = "http://prometheus.monitoring.svc.cluster.local:9090"
+	values, _, err := options.validateAndBuild("", nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v\n", err)
+	}
+	addFakeTLSSecrets(values)
+	values.DataPlaneNetworkPolicyNamespaces = []string{"emojivoto"}
+
+	var buf bytes.Buffer
+	err = render(&buf, values)
+	if err == nil || !strings.Contains(err.Error(), "Please provide networkPolicyPrometheusSelector") {
+		t.Fatalf("Expected the Prometheus selector to be required, got %v", err)
+	}
+
+	values.NetworkPolicyPrometheusSelector = map[string]interface{}{
+		"namespaceSelector": map[string]interface{}{
+			"matchLabels": map[string]interface{}{"name": "monitoring"},
+		},
+	}
+	buf.Reset()
+	if err := render(&buf, values); err != nil {
+		t.Fatalf("Failed to render templates: %v", err)
+	}
+
+	expected := []string{
+		// the control plane's admin ports
+		"  ingress:\n  - from:\n    - podSelector: {}\n    - namespaceSelector:\n        matchLabels:\n          name: monitoring\n",
+		// the proxies' admin port
+		"  - from:\n    - namespaceSelector:\n        matchLabels:\n          name: monitoring\n    ports:\n    - protocol: TCP\n      port: 4191\n",
+	}
+	for _, e := range expected {
+		if !strings.Contains(buf.String(), e) {
+			t.Errorf("Expected the NetworkPolicies to contain:\n%s", e)
+		}
+	}
+}
+
 func TestValidateAndBuild_Errors(t *testing.T) {
 	t.Run("Fails validation for invalid ignoreInboundPorts", func(t *testing.T) {
 		installOptions, err := testInstallOptions()
@@ -313,6 +577,56 @@ func TestValidateAndBuild_Errors(t *testing.T) {
 			t.Fatal("expected error but got nothing")
 		}
 	})
+
+	t.Run("Applies the proxy resources profile", func(t *testing.T) {
+		installOptions, err := testInstallOptions()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v\n", err)
+		}
+		installOptions.proxyResourcesProfile = "medium"
+		installOptions.proxyCPULimit = "500m"
+		values, configs, err := installOptions.validateAndBuild("", nil)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v\n", err)
+		}
+
+		expected := charts.Resources{
+			CPU:    charts.Constraints{Request: "100m", Limit: "500m"},
+			Memory: charts.Constraints{Request: "64Mi", Limit: "250Mi"},
+		}
+		if !reflect.DeepEqual(*values.Global.Proxy.Resources, expected) {
+			t.Fatalf("Expected proxy resources %+v, got %+v", expected, *values.Global.Proxy.Resources)
+		}
+		if configs.Proxy.Resource.RequestMemory != "64Mi" {
+			t.Fatalf("Expected the config's proxy memory request to be 64Mi, got %q", configs.Proxy.Resource.RequestMemory)
+		}
+	})
+
+	t.Run("Rejects unknown proxy resources profiles", func(t *testing.T) {
+		installOptions, err := testInstallOptions()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v\n", err)
+		}
+		installOptions.proxyResourcesProfile = "huge"
+		_, _, err = installOptions.validateAndBuild("", nil)
+		expected := "--proxy-resources-profile must be one of: small, medium, large"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("Expected error %q, got %v", expected, err)
+		}
+	})
+
+	t.Run("Fails schema validation for invalid values overrides", func(t *testing.T) {
+		installOptions, err := testInstallOptions()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v\n", err)
+		}
+		installOptions.valuesOptions.values = []string{"webhookFailurePolicy=Always"}
+		_, _, err = installOptions.validateAndBuild("", nil)
+		expected := "invalid values:\n  * webhookFailurePolicy: must be one of \"Ignore\", \"Fail\", got \"Always\""
+		if err == nil || err.Error() != expected {
+			t.Fatalf("Expected error %q, got %v", expected, err)
+		}
+	})
 }
 
 func testInstallOptions() (*installOptions, error) {
@@ -362,6 +676,36 @@ func TestValidate(t *testing.T) {
 		}
 	})
 
+	t.Run("Rejects invalid proxy injector failure policy", func(t *testing.T) {
+		options, err := testInstallOptions()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v\n", err)
+		}
+
+		options.injectorFailurePolicy = "Always"
+		expected := "--proxy-injector-failure-policy must be one of: Ignore, Fail"
+
+		err = options.validate()
+		if err == nil {
+			t.Fatal("Expected error, got nothing")
+		}
+		if err.Error() != expected {
+			t.Fatalf("Expected error string\"%s\", got \"%s\"", expected, err)
+		}
+	})
+
+	t.Run("Rejects invalid proxy injector namespace selector", func(t *testing.T) {
+		options, err := testInstallOptions()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v\n", err)
+		}
+
+		options.injectorNamespaceSelector = "kubernetes.io/metadata.name in kube-system"
+		if err := options.validate(); err == nil {
+			t.Fatal("Expected error, got nothing")
+		}
+	})
+
 	t.Run("Rejects invalid controller log level", func(t *testing.T) {
 		options, err := testInstallOptions()
 		if err != nil {
@@ -369,8 +713,91 @@ func TestValidate(t *testing.T) {
 		}
 
 		options.controllerLogLevel = "super"
-		expected := "--controller-log-level must be one of: panic, fatal, error, warn, info, debug"
+		expected := "--controller-log-level must be one of: panic, fatal, error, warn, info, debug, trace"
+
+		err = options.validate()
+		if err == nil {
+			t.Fatal("Expected error, got nothing")
+		}
+		if err.Error() != expected {
+			t.Fatalf("Expected error string\"%s\", got \"%s\"", expected, err)
+		}
+	})
+
+	t.Run("Rejects smoke test without an explicit context", func(t *testing.T) {
+		options, err := testInstallOptions()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v\n", err)
+		}
+
+		options.ignoreCluster = false
+		options.smokeTest = true
+		expected := "--smoke-test requires an explicit target cluster to be set with --context"
+
+		err = options.validate()
+		if err == nil {
+			t.Fatal("Expected error, got nothing")
+		}
+		if err.Error() != expected {
+			t.Fatalf("Expected error string\"%s\", got \"%s\"", expected, err)
+		}
+	})
+
+	t.Run("Rejects kustomize output without an output directory", func(t *testing.T) {
+		options, err := testInstallOptions()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v\n", err)
+		}
+
+		options.output = kustomizeOutput
+		expected := "--output kustomize requires --output-dir to be set"
+
+		err = options.validate()
+		if err == nil {
+			t.Fatal("Expected error, got nothing")
+		}
+		if err.Error() != expected {
+			t.Fatalf("Expected error string\"%s\", got \"%s\"", expected, err)
+		}
+	})
+
+	t.Run("Rejects apply with conflicting options", func(t *testing.T) {
+		testCases := []struct {
+			modify   func(*installOptions)
+			expected string
+		}{
+			{func(o *installOptions) { o.ignoreCluster = true }, "--apply cannot be used with --ignore-cluster"},
+			{func(o *installOptions) { o.outputDir = "manifests" }, "--apply cannot be used with --output-dir"},
+			{func(o *installOptions) { o.output = jsonOutput }, "--apply cannot be used with --output"},
+		}
+
+		for _, tc := range testCases {
+			options, err := testInstallOptions()
+			if err != nil {
+				t.Fatalf("Unexpected error: %v\n", err)
+			}
+
+			options.apply = true
+			options.ignoreCluster = false
+			tc.modify(options)
+			err = options.validate()
+			if err == nil {
+				t.Fatal("Expected error, got nothing")
+			}
+			if err.Error() != tc.expected {
+				t.Fatalf("Expected error string\"%s\", got \"%s\"", tc.expected, err)
+			}
+		}
+	})
+
+	t.Run("Rejects invalid image pull secrets", func(t *testing.T) {
+		options, err := testInstallOptions()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v\n", err)
+		}
 
+		options.imagePullSecrets = []string{"Registry_Login"}
+		expected := "invalid image pull secret 'Registry_Login': a DNS-1123 subdomain must consist of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character (e.g. 'example.com', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*')"
 		err = options.validate()
 		if err == nil {
 			t.Fatal("Expected error, got nothing")
@@ -380,6 +807,260 @@ func TestValidate(t *testing.T) {
 		}
 	})
 
+	t.Run("Rejects invalid architectures", func(t *testing.T) {
+		testCases := []struct {
+			imageArchitectures     []string
+			componentArchitectures map[string]string
+			expected               string
+		}{
+			{[]string{"amd64", "riscv64"}, nil, "--image-architectures: unsupported architecture 'riscv64', must be one of: amd64, arm64, arm, s390x, ppc64le"},
+			{[]string{"amd64"}, map[string]string{"grafana": "amd64"}, "--component-arch: unknown component 'grafana', must be one of: controller, destination, health-exporter, heartbeat, identity, identity-rotation, proxy-injector, sp-validator, tap, trust-bundle, web"},
+			{[]string{"amd64"}, map[string]string{"destination": "arm64"}, "--component-arch: the images aren't built for arm64, which isn't in --image-architectures"},
+		}
+
+		for _, tc := range testCases {
+			options, err := testInstallOptions()
+			if err != nil {
+				t.Fatalf("Unexpected error: %v\n", err)
+			}
+
+			options.imageArchitectures = tc.imageArchitectures
+			options.componentArchitectures = tc.componentArchitectures
+			err = options.validate()
+			if err == nil || err.Error() != tc.expected {
+				t.Fatalf("Expected error string\"%s\", got \"%v\"", tc.expected, err)
+			}
+		}
+	})
+
+	t.Run("Rejects invalid log options", func(t *testing.T) {
+		testCases := []struct {
+			proxyLogFormat     string
+			componentLogLevels map[string]string
+			expected           string
+		}{
+			{"yaml", nil, "--proxy-log-format must be one of: plain, json"},
+			{"json", map[string]string{"grafana": "debug"}, "--controller-component-log-level: unknown component 'grafana', must be one of: controller, destination, health-exporter, heartbeat, identity, identity-rotation, proxy-injector, sp-validator, tap, trust-bundle, web"},
+			{"json", map[string]string{"destination": "verbose"}, "--controller-component-log-level: the log level of destination must be one of: panic, fatal, error, warn, info, debug, trace"},
+		}
+
+		for _, tc := range testCases {
+			options, err := testInstallOptions()
+			if err != nil {
+				t.Fatalf("Unexpected error: %v\n", err)
+			}
+
+			options.proxyLogFormat = tc.proxyLogFormat
+			options.componentLogLevels = tc.componentLogLevels
+			err = options.validate()
+			if err == nil || err.Error() != tc.expected {
+				t.Fatalf("Expected error string\"%s\", got \"%v\"", tc.expected, err)
+			}
+		}
+	})
+
+	t.Run("Rejects invalid crypto policies", func(t *testing.T) {
+		options, err := testInstallOptions()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v\n", err)
+		}
+
+		options.cryptoPolicy = "legacy"
+		expected := "--crypto-policy must be one of: default, fips"
+		err = options.validate()
+		if err == nil || err.Error() != expected {
+			t.Fatalf("Expected error string\"%s\", got \"%v\"", expected, err)
+		}
+	})
+
+	t.Run("Rejects invalid add-on flags", func(t *testing.T) {
+		testCases := []struct {
+			addOns        []string
+			skipAddOns    []string
+			prometheusURL string
+			expected      string
+		}{
+			{[]string{"jaeger"}, nil, "", "--addons: unknown add-on 'jaeger', must be one of: grafana, prometheus, tracing"},
+			{nil, []string{"jaeger"}, "", "--skip-addons: unknown add-on 'jaeger', must be one of: grafana, prometheus, tracing"},
+			{[]string{"grafana"}, []string{"grafana"}, "", "the grafana add-on can't be both in --addons and --skip-addons"},
+			{[]string{"prometheus"}, nil, "http://prometheus.example.com", "--addons: the prometheus add-on can't be enabled with --prometheus-url"},
+			{nil, []string{"prometheus"}, "", "--skip-addons: the control plane needs a Prometheus, set --prometheus-url to skip the prometheus add-on"},
+		}
+
+		for _, tc := range testCases {
+			options, err := testInstallOptions()
+			if err != nil {
+				t.Fatalf("Unexpected error: %v\n", err)
+			}
+
+			options.addOns = tc.addOns
+			options.skipAddOns = tc.skipAddOns
+			options.prometheusURL = tc.prometheusURL
+			err = options.validate()
+			if err == nil || err.Error() != tc.expected {
+				t.Fatalf("Expected error string\"%s\", got \"%v\"", tc.expected, err)
+			}
+		}
+	})
+
+	t.Run("Rejects invalid Prometheus URLs", func(t *testing.T) {
+		options, err := testInstallOptions()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v\n", err)
+		}
+
+		for _, u := range []string{"prometheus:9090", "ftp://prometheus:9090", "http://"} {
+			options.prometheusURL = u
+			expected := fmt.Sprintf("--prometheus-url must be an absolute http or https URL, got '%s'", u)
+			err = options.validate()
+			if err == nil || err.Error() != expected {
+				t.Fatalf("Expected error string\"%s\", got \"%v\"", expected, err)
+			}
+		}
+	})
+
+	t.Run("Rejects invalid dry-run options", func(t *testing.T) {
+		testCases := []struct {
+			dryRun        string
+			ignoreCluster bool
+			expected      string
+		}{
+			{dryRunServer, true, "--dry-run=server cannot be used with --ignore-cluster"},
+			{"client", false, "--dry-run must be one of: none, server"},
+		}
+
+		for _, tc := range testCases {
+			options, err := testInstallOptions()
+			if err != nil {
+				t.Fatalf("Unexpected error: %v\n", err)
+			}
+
+			options.dryRun = tc.dryRun
+			options.ignoreCluster = tc.ignoreCluster
+			err = options.validate()
+			if err == nil {
+				t.Fatal("Expected error, got nothing")
+			}
+			if err.Error() != tc.expected {
+				t.Fatalf("Expected error string\"%s\", got \"%s\"", tc.expected, err)
+			}
+		}
+	})
+
+	t.Run("Rejects JSON output with an output directory", func(t *testing.T) {
+		options, err := testInstallOptions()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v\n", err)
+		}
+
+		options.output = jsonOutput
+		options.outputDir = "manifests"
+		expected := "--output json cannot be used with --output-dir"
+
+		err = options.validate()
+		if err == nil {
+			t.Fatal("Expected error, got nothing")
+		}
+		if err.Error() != expected {
+			t.Fatalf("Expected error string\"%s\", got \"%s\"", expected, err)
+		}
+	})
+
+	t.Run("Accepts the proxy connect backoff jitter bounds", func(t *testing.T) {
+		for _, jitter := range []string{"0", "0.1", "1"} {
+			options, err := testInstallOptions()
+			if err != nil {
+				t.Fatalf("Unexpected error: %v\n", err)
+			}
+
+			options.outboundConnectBackoffJitter = jitter
+			if err := options.validate(); err != nil {
+				t.Fatalf("Unexpected error for jitter %s: %s", jitter, err)
+			}
+		}
+	})
+
+	t.Run("Rejects invalid proxy connect settings", func(t *testing.T) {
+		testCases := []struct {
+			modify   func(*proxyConfigOptions)
+			expected string
+		}{
+			{
+				func(o *proxyConfigOptions) { o.outboundConnectTimeout = "1000" },
+				"Invalid duration '1000' for --proxy-connect-timeout flag",
+			},
+			{
+				func(o *proxyConfigOptions) { o.outboundConnectBackoffMin = "fast" },
+				"Invalid duration 'fast' for --proxy-connect-backoff-min flag",
+			},
+			{
+				func(o *proxyConfigOptions) {
+					o.outboundConnectBackoffMin = "2s"
+					o.outboundConnectBackoffMax = "1s"
+				},
+				"The connect backoff max '1s' cannot be lower than the connect backoff min '2s'",
+			},
+			{
+				func(o *proxyConfigOptions) { o.outboundConnectBackoffJitter = "-0.1" },
+				"Invalid jitter '-0.1' for --proxy-connect-backoff-jitter flag: must be a ratio between 0 and 1",
+			},
+			{
+				func(o *proxyConfigOptions) { o.outboundConnectBackoffJitter = "1.01" },
+				"Invalid jitter '1.01' for --proxy-connect-backoff-jitter flag: must be a ratio between 0 and 1",
+			},
+			{
+				func(o *proxyConfigOptions) { o.outboundConnectBackoffJitter = "10" },
+				"Invalid jitter '10' for --proxy-connect-backoff-jitter flag: must be a ratio between 0 and 1",
+			},
+			{
+				func(o *proxyConfigOptions) { o.excludedNodeSelector = "example.com/pool in gpu" },
+				"Invalid selector 'example.com/pool in gpu' for --excluded-node-selector flag: unable to parse requirement: found 'gpu' expected: '('",
+			},
+		}
+
+		for _, tc := range testCases {
+			options, err := testInstallOptions()
+			if err != nil {
+				t.Fatalf("Unexpected error: %v\n", err)
+			}
+
+			tc.modify(options.proxyConfigOptions)
+			err = options.validate()
+			if err == nil {
+				t.Fatal("Expected error, got nothing")
+			}
+			if err.Error() != tc.expected {
+				t.Fatalf("Expected error string\"%s\", got \"%s\"", tc.expected, err)
+			}
+		}
+	})
+
+	t.Run("Rejects conflicting control plane metrics ports", func(t *testing.T) {
+		testCases := []struct {
+			port     uint
+			expected string
+		}{
+			{4191, "--control-plane-metrics-port 4191 conflicts with the proxy port set by --admin-port"},
+			{8086, "--control-plane-metrics-port 8086 conflicts with a port used by the control plane components"},
+		}
+
+		for _, tc := range testCases {
+			options, err := testInstallOptions()
+			if err != nil {
+				t.Fatalf("Unexpected error: %v\n", err)
+			}
+
+			options.controlPlaneMetricsPort = tc.port
+			err = options.validate()
+			if err == nil {
+				t.Fatal("Expected error, got nothing")
+			}
+			if err.Error() != tc.expected {
+				t.Fatalf("Expected error string\"%s\", got \"%s\"", tc.expected, err)
+			}
+		}
+	})
+
 	t.Run("Properly validates proxy log level", func(t *testing.T) {
 		testCases := []struct {
 			input string
@@ -431,7 +1112,7 @@ func TestValidate(t *testing.T) {
 			{"expired", "failed to verify issuer certs stored on disk: not valid anymore. Expired on 1990-01-01T01:01:11Z"},
 			{"not-valid-yet", "failed to verify issuer certs stored on disk: not valid before: 2100-01-01T01:00:51Z"},
 			{"wrong-domain", "failed to verify issuer certs stored on disk: x509: certificate is valid for wrong.linkerd.cluster.local, not identity.linkerd.cluster.local"},
-			{"wrong-algo", "failed to verify issuer certs stored on disk: must use P-256 curve for public key, instead P-521 was used"},
+			{"wrong-algo", "failed to verify issuer certs stored on disk: must use the P-256 or P-384 curve for ECDSA keys, instead P-521 was used"},
 		}
 		for _, tc := range testCases {
 
@@ -509,6 +1190,314 @@ func TestValidate(t *testing.T) {
 			}
 		}
 	})
+
+	t.Run("Requires only the trust anchors file when cert-manager is the external issuer", func(t *testing.T) {
+		options, err := testInstallOptions()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v\n", err)
+		}
+
+		flags := options.installOnlyFlagSet()
+		if err := flags.Parse([]string{"--identity-external-issuer=cert-manager"}); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if !options.identityOptions.identityExternalIssuer || !options.identityOptions.certManager.Enabled {
+			t.Fatal("Expected cert-manager to be set as the external issuer")
+		}
+
+		withCertFiles := *options.identityOptions
+		withoutTrustAnchorsFile := *options.identityOptions
+		withoutTrustAnchorsFile.crtPEMFile = ""
+		withoutTrustAnchorsFile.keyPEMFile = ""
+		withoutTrustAnchorsFile.trustPEMFile = ""
+		withTrustAnchorsFile := withoutTrustAnchorsFile
+		withTrustAnchorsFile.trustPEMFile = filepath.Join("testdata", "valid-trust-anchors.pem")
+
+		testCases := []struct {
+			input         *installIdentityOptions
+			expectedError string
+		}{
+			{&withTrustAnchorsFile, ""},
+			{&withCertFiles, "--identity-issuer-certificate-file and --identity-issuer-key-file must not be specified if --identity-external-issuer=cert-manager"},
+			{&withoutTrustAnchorsFile, "--identity-trust-anchors-file must be specified if --identity-external-issuer=cert-manager"},
+		}
+
+		for _, tc := range testCases {
+			err = tc.input.validate()
+
+			if tc.expectedError != "" {
+				if err == nil || err.Error() != tc.expectedError {
+					t.Fatalf("Expected error string \"%s\", got \"%v\"", tc.expectedError, err)
+				}
+			} else if err != nil {
+				t.Fatalf("Expected no error but got \"%s\"", err)
+			}
+		}
+	})
+
+	t.Run("Requires the issuer certificate and trust anchors files with a KMS issuer key", func(t *testing.T) {
+		options, err := testInstallOptions()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v\n", err)
+		}
+
+		keyURI := "gcpkms://projects/p/locations/global/keyRings/linkerd/cryptoKeys/issuer/cryptoKeyVersions/1"
+		withKeyURI := *options.identityOptions
+		withKeyURI.keyPEMFile = ""
+		withKeyURI.keyURI = keyURI
+		withKeyFile := withKeyURI
+		withKeyFile.keyPEMFile = filepath.Join("testdata", "valid-key.pem")
+		withoutCrtFile := withKeyURI
+		withoutCrtFile.crtPEMFile = ""
+		withExternalIssuer := withKeyURI
+		withExternalIssuer.identityExternalIssuer = true
+		withInvalidKeyURI := withKeyURI
+		withInvalidKeyURI.keyURI = "awskms://alias/issuer"
+
+		testCases := []struct {
+			input         *installIdentityOptions
+			expectedError string
+		}{
+			{&withKeyURI, ""},
+			{&withKeyFile, "--identity-issuer-key-file must not be specified if --identity-issuer-key-uri is set"},
+			{&withoutCrtFile, "--identity-trust-anchors-file and --identity-issuer-certificate-file must be specified if --identity-issuer-key-uri is set"},
+			{&withExternalIssuer, "--identity-issuer-key-uri must not be specified if --identity-external-issuer is set"},
+			{&withInvalidKeyURI, "unsupported KMS in key URI awskms://alias/issuer; supported KMS: gcpkms"},
+		}
+
+		for _, tc := range testCases {
+			err = tc.input.validate()
+
+			if tc.expectedError != "" {
+				if err == nil || err.Error() != tc.expectedError {
+					t.Fatalf("Expected error string \"%s\", got \"%v\"", tc.expectedError, err)
+				}
+			} else if err != nil {
+				t.Fatalf("Expected no error but got \"%s\"", err)
+			}
+		}
+
+		values, err := withKeyURI.validateAndBuild()
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		issuer := values.Identity.Issuer
+		if issuer.KeyURI != keyURI || issuer.TLS.KeyPEM != "" || issuer.TLS.CrtPEM == "" {
+			t.Fatalf("Expected only the issuer certificate and the key URI, got %+v", issuer.TLS)
+		}
+	})
+
+	t.Run("Requires only the trust anchors file with a SPIRE issuer", func(t *testing.T) {
+		options, err := testInstallOptions()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v\n", err)
+		}
+
+		withSpire := *options.identityOptions
+		withSpire.crtPEMFile = ""
+		withSpire.keyPEMFile = ""
+		withSpire.spire.ServerAddr = "spire-server.spire:8081"
+		withIssuerFiles := *options.identityOptions
+		withIssuerFiles.spire.ServerAddr = "spire-server.spire:8081"
+		withoutTrustFile := withSpire
+		withoutTrustFile.trustPEMFile = ""
+		withExternalIssuer := withSpire
+		withExternalIssuer.identityExternalIssuer = true
+		withoutPort := withSpire
+		withoutPort.spire.ServerAddr = "spire-server.spire"
+		withRelativeSocket := withSpire
+		withRelativeSocket.spire.AgentSocketPath = "agent.sock"
+
+		testCases := []struct {
+			input         *installIdentityOptions
+			expectedError string
+		}{
+			{&withSpire, ""},
+			{&withIssuerFiles, "--identity-issuer-certificate-file and --identity-issuer-key-file must not be specified if --identity-spire-server-addr is set"},
+			{&withoutTrustFile, "--identity-trust-anchors-file must be specified if --identity-spire-server-addr is set"},
+			{&withExternalIssuer, "--identity-spire-server-addr must not be specified if --identity-external-issuer or --identity-issuer-key-uri is set"},
+			{&withoutPort, "invalid --identity-spire-server-addr: address spire-server.spire: missing port in address"},
+			{&withRelativeSocket, "--identity-spire-agent-socket-path must be an absolute path: agent.sock"},
+		}
+
+		for _, tc := range testCases {
+			err = tc.input.validate()
+
+			if tc.expectedError != "" {
+				if err == nil || err.Error() != tc.expectedError {
+					t.Fatalf("Expected error string \"%s\", got \"%v\"", tc.expectedError, err)
+				}
+			} else if err != nil {
+				t.Fatalf("Expected no error but got \"%s\"", err)
+			}
+		}
+
+		values, err := withSpire.validateAndBuild()
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if values.Identity.Issuer.Scheme != k8s.IdentityIssuerSchemeSpire || values.Identity.Issuer.TLS != nil {
+			t.Fatalf("Expected the %s scheme without issuer certificate, got %+v", k8s.IdentityIssuerSchemeSpire, values.Identity.Issuer)
+		}
+		if values.Identity.Spire.ServerAddr != withSpire.spire.ServerAddr {
+			t.Fatalf("Expected the SPIRE server %s, got %s", withSpire.spire.ServerAddr, values.Identity.Spire.ServerAddr)
+		}
+	})
+
+	t.Run("Generates or checks the issuer key with the configured algorithm", func(t *testing.T) {
+		options, err := testInstallOptions()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v\n", err)
+		}
+
+		withFiles := *options.identityOptions
+		withFiles.issuerKeyAlgorithm = "ecdsa-p384"
+		_, err = withFiles.validateAndBuild()
+		expected := "failed to verify issuer certs stored on disk: must use an ecdsa-p384 key, instead ecdsa-p256 was used"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("Expected error string \"%s\", got \"%v\"", expected, err)
+		}
+
+		generated := withFiles
+		generated.crtPEMFile = ""
+		generated.keyPEMFile = ""
+		generated.trustPEMFile = ""
+		values, err := generated.validateAndBuild()
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		crt, err := tls.DecodePEMCrt(values.Identity.Issuer.TLS.CrtPEM)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if err := tls.CheckKeyAlgorithm(crt.Certificate.PublicKey, "ecdsa-p384"); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		idctx := toIdentityContext(values)
+		if idctx.GetIssuerKeyAlgorithm() != "ecdsa-p384" {
+			t.Fatalf("Expected the issuer key algorithm in the identity context, got %+v", idctx)
+		}
+	})
+
+	t.Run("Validates the renewal jitter and stores it in the identity context", func(t *testing.T) {
+		options, err := testInstallOptions()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v\n", err)
+		}
+
+		withJitter := *options.identityOptions
+		withJitter.renewalJitter = withJitter.issuanceLifetime
+		err = withJitter.validate()
+		expected := "--identity-renewal-jitter must not be negative and must be shorter than --identity-issuance-lifetime (24h0m0s)"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("Expected error string \"%s\", got \"%v\"", expected, err)
+		}
+
+		withJitter.renewalJitter = time.Hour
+		values, err := withJitter.validateAndBuild()
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		jitter, err := ptypes.Duration(toIdentityContext(values).GetRenewalJitter())
+		if err != nil || jitter != time.Hour {
+			t.Fatalf("Expected a renewal jitter of 1h in the identity context, got %s (%v)", jitter, err)
+		}
+	})
+}
+
+func TestProxyConfigConnectTimeouts(t *testing.T) {
+	t.Run("Omits the default connect timeouts", func(t *testing.T) {
+		options, err := testInstallOptions()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v\n", err)
+		}
+
+		proxy := options.proxyConfig()
+		if proxy.OutboundConnectTimeout != "" || proxy.InboundConnectTimeout != "" {
+			t.Fatalf("Expected no connect timeouts, got outbound %q and inbound %q", proxy.OutboundConnectTimeout, proxy.InboundConnectTimeout)
+		}
+	})
+
+	t.Run("Keeps the customized connect timeouts", func(t *testing.T) {
+		options, err := testInstallOptions()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v\n", err)
+		}
+
+		options.outboundConnectTimeout = "3s"
+		proxy := options.proxyConfig()
+		if proxy.OutboundConnectTimeout != "3s" || proxy.InboundConnectTimeout != "" {
+			t.Fatalf("Expected only an outbound connect timeout of 3s, got outbound %q and inbound %q", proxy.OutboundConnectTimeout, proxy.InboundConnectTimeout)
+		}
+	})
+}
+
+func TestPreviousStagesError(t *testing.T) {
+	// the pre-install checks fail when the resources of their stage exist
+	crdsExist := &healthcheck.CheckResult{
+		Category: healthcheck.LinkerdPreInstallCRDsChecks,
+		Err:      &healthcheck.CategoryError{Category: healthcheck.LinkerdPreInstallCRDsChecks, Err: errors.New("CRDs exist")},
+	}
+	crdsMissing := &healthcheck.CheckResult{Category: healthcheck.LinkerdPreInstallCRDsChecks}
+	globalResourcesExist := &healthcheck.CheckResult{
+		Category: healthcheck.LinkerdPreInstallGlobalResourcesChecks,
+		Err:      &healthcheck.CategoryError{Category: healthcheck.LinkerdPreInstallGlobalResourcesChecks, Err: errors.New("ClusterRoles exist")},
+	}
+	globalResourcesMissing := &healthcheck.CheckResult{Category: healthcheck.LinkerdPreInstallGlobalResourcesChecks}
+
+	testCases := []struct {
+		name     string
+		results  []*healthcheck.CheckResult
+		expected string
+	}{
+		{
+			"crds and config stages applied",
+			[]*healthcheck.CheckResult{crdsExist, globalResourcesExist},
+			"",
+		},
+		{
+			"only the crds stage applied",
+			[]*healthcheck.CheckResult{crdsExist, globalResourcesMissing},
+			"the required Linkerd global resources are missing, run 'linkerd install config' first",
+		},
+		{
+			"only the config stage applied",
+			[]*healthcheck.CheckResult{crdsMissing, globalResourcesExist},
+			"the required Linkerd CRDs are missing, run 'linkerd install crds' first",
+		},
+		{
+			"no stage applied",
+			[]*healthcheck.CheckResult{crdsMissing, globalResourcesMissing},
+			"the required Linkerd CRDs are missing, run 'linkerd install crds' first; the required Linkerd global resources are missing, run 'linkerd install config' first",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.name, func(t *testing.T) {
+			err := previousStagesError(tc.results)
+			if tc.expected == "" {
+				if err != nil {
+					t.Fatalf("Unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tc.expected {
+				t.Fatalf("Expected error %q, got %v", tc.expected, err)
+			}
+		})
+	}
+
+	t.Run("returns the Kubernetes API errors", func(t *testing.T) {
+		k8sAPIError := &healthcheck.CheckResult{
+			Category: healthcheck.KubernetesAPIChecks,
+			Err:      &healthcheck.CategoryError{Category: healthcheck.KubernetesAPIChecks, Err: errors.New("connection refused")},
+		}
+		err := previousStagesError([]*healthcheck.CheckResult{k8sAPIError})
+		if !healthcheck.IsCategoryError(err, healthcheck.KubernetesAPIChecks) {
+			t.Fatalf("Expected a KubernetesAPIChecks error, got %v", err)
+		}
+	})
 }
 
 func fakeHeartbeatSchedule() string {
@@ -0,0 +1,437 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path"
+	"sort"
+	"strings"
+
+	pb "github.com/linkerd/linkerd2/controller/gen/config"
+	l5dcharts "github.com/linkerd/linkerd2/pkg/charts/linkerd2"
+	"github.com/linkerd/linkerd2/pkg/config"
+	"github.com/spf13/pflag"
+	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/strvals"
+	"sigs.k8s.io/yaml"
+)
+
+// valuesOptions holds the Helm-style values files and overrides. The values
+// files provide the defaults for the install flags, while the overrides are
+// applied on top of the chart values built from them. This allows setting
+// any chart value from the CLI, even if there's no flag for it.
+type valuesOptions struct {
+	valueFiles   []string
+	values       []string
+	stringValues []string
+	fileValues   []string
+}
+
+// overridesFlagSet returns the --set flags. Unlike the values files, the --set
+// and --set-string flags are recorded in the install configuration, and
+// replayed by upgrade.
+func (options *valuesOptions) overridesFlagSet() *pflag.FlagSet {
+	flags := pflag.NewFlagSet("values-overrides", pflag.ExitOnError)
+
+	flags.StringArrayVar(
+		&options.values, "set", options.values,
+		"Set chart values on the command line (can specify multiple or separate values with commas: key1=val1,key2=val2). Persisted across upgrades",
+	)
+	flags.StringArrayVar(
+		&options.stringValues, "set-string", options.stringValues,
+		"Set STRING chart values on the command line (can specify multiple or separate values with commas: key1=val1,key2=val2). Persisted across upgrades",
+	)
+	flags.StringArrayVar(
+		&options.fileValues, "set-file", options.fileValues,
+		"Set chart values from the content of files on the command line (can specify multiple or separate values with commas: key1=path1,key2=path2). The content of the files is not persisted, and must be provided again on upgrade",
+	)
+
+	return flags
+}
+
+func (options *valuesOptions) valueFilesFlagSet() *pflag.FlagSet {
+	flags := pflag.NewFlagSet("value-files", pflag.ExitOnError)
+
+	flags.StringSliceVarP(
+		&options.valueFiles, "values", "f", options.valueFiles,
+		"Specify values in a YAML file or a URL (can specify multiple). Later files take precedence, and flags take precedence over all files. Values files are not persisted, and must be provided again on upgrade",
+	)
+
+	return flags
+}
+
+func (options *valuesOptions) empty() bool {
+	return len(options.values) == 0 && len(options.stringValues) == 0 && len(options.fileValues) == 0
+}
+
+// mergeValueFiles merges the values files, in order, onto values.
+func (options *valuesOptions) mergeValueFiles(values *l5dcharts.Values) error {
+	if len(options.valueFiles) == 0 {
+		return nil
+	}
+
+	return overrideValues(values, func(base chartutil.Values) error {
+		for _, path := range options.valueFiles {
+			in, err := read(path)
+			if err != nil {
+				return fmt.Errorf("failed to read values file %s: %s", path, err)
+			}
+
+			for _, r := range in {
+				raw, err := ioutil.ReadAll(r)
+				if err != nil {
+					return fmt.Errorf("failed to read values file %s: %s", path, err)
+				}
+
+				var fileValues chartutil.Values
+				if err := yaml.Unmarshal(raw, &fileValues); err != nil {
+					return fmt.Errorf("failed to parse values file %s: %s", path, err)
+				}
+				base.MergeInto(fileValues)
+			}
+		}
+		return nil
+	})
+}
+
+// secretValues are the chart values rendered into Secrets, like the private
+// key of the identity issuer. The overrides of these values, or of any value
+// under them, aren't recorded, so that they don't end up in plain text in the
+// linkerd-config and linkerd-config-history ConfigMaps.
+var secretValues = []string{
+	"identity.issuer",
+	"proxyInjector.crtPEM",
+	"proxyInjector.keyPEM",
+	"profileValidator.crtPEM",
+	"profileValidator.keyPEM",
+	"tap.crtPEM",
+	"tap.keyPEM",
+}
+
+// recordedFlags returns the --set and --set-string overrides as install flags,
+// one per value, leaving out the secretValues. The --set-file overrides aren't
+// recorded, as the content of the files may be secret.
+func (options *valuesOptions) recordedFlags() []*pb.Install_Flag {
+	var flags []*pb.Install_Flag
+	for _, value := range withoutSecretValues(options.values, strvals.Parse) {
+		flags = append(flags, &pb.Install_Flag{Name: "set", Value: value})
+	}
+	for _, value := range withoutSecretValues(options.stringValues, strvals.ParseString) {
+		flags = append(flags, &pb.Install_Flag{Name: "set-string", Value: value})
+	}
+	return flags
+}
+
+// withoutSecretValues returns the overrides without the keys matching
+// secretValues. The overrides setting any of these keys are split into one
+// override per key, and the others are kept as they are.
+func withoutSecretValues(overrides []string, parse func(string) (map[string]interface{}, error)) []string {
+	var kept []string
+	for _, override := range overrides {
+		parsed, err := parse(override)
+		if err != nil {
+			// invalid overrides are rejected when they're applied
+			kept = append(kept, override)
+			continue
+		}
+
+		flattened := flattenValues("", parsed)
+		var public []string
+		for _, value := range flattened {
+			if !isSecretValue(value) {
+				public = append(public, value)
+			}
+		}
+		if len(public) == len(flattened) {
+			kept = append(kept, override)
+			continue
+		}
+		kept = append(kept, public...)
+	}
+	return kept
+}
+
+// isSecretValue returns true if the key of the key=value override is, or is
+// under, one of the secretValues
+func isSecretValue(override string) bool {
+	key := strings.SplitN(override, "=", 2)[0]
+	for _, secret := range secretValues {
+		if key == secret || strings.HasPrefix(key, secret+".") || strings.HasPrefix(key, secret+"[") {
+			return true
+		}
+	}
+	return false
+}
+
+// applyTo overrides the chart values with the --set, --set-string and
+// --set-file flags, in that order. Keys are dotted paths into the chart
+// values, e.g. global.proxy.logLevel=debug. Keys that don't map onto the
+// chart values are rejected. configs is then updated from the overridden
+// values, and serialized again into them.
+func (options *valuesOptions) applyTo(values *l5dcharts.Values, configs *pb.All) error {
+	if options.empty() {
+		return nil
+	}
+
+	err := overrideValues(values, func(base chartutil.Values) error {
+		for _, value := range options.values {
+			if err := strvals.ParseInto(value, base); err != nil {
+				return fmt.Errorf("failed parsing --set data: %s", err)
+			}
+		}
+
+		for _, value := range options.stringValues {
+			if err := strvals.ParseIntoString(value, base); err != nil {
+				return fmt.Errorf("failed parsing --set-string data: %s", err)
+			}
+		}
+
+		for _, value := range options.fileValues {
+			reader := func(rs []rune) (interface{}, error) {
+				bytes, err := ioutil.ReadFile(string(rs))
+				return string(bytes), err
+			}
+			if err := strvals.ParseIntoFile(value, base, reader); err != nil {
+				return fmt.Errorf("failed parsing --set-file data: %s", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	updateConfigs(configs, values)
+	globalJSON, proxyJSON, installJSON, err := config.ToJSON(configs)
+	if err != nil {
+		return err
+	}
+	values.Configs.Global = globalJSON
+	values.Configs.Proxy = proxyJSON
+	values.Configs.Install = installJSON
+	return nil
+}
+
+// updateConfigs sets the global and proxy configs from the chart values, the
+// same way the chart's linkerd.configs.* templates do.
+func updateConfigs(configs *pb.All, values *l5dcharts.Values) {
+	configs.Global = &pb.Global{
+		LinkerdNamespace: values.Global.Namespace,
+		CniEnabled:       values.Global.CNIEnabled,
+		Version:          values.Global.ControllerImageVersion,
+		IdentityContext: toIdentityContext(&identityWithAnchorsAndTrustDomain{
+			Identity:        values.Identity,
+			TrustAnchorsPEM: values.Global.IdentityTrustAnchorsPEM,
+			TrustDomain:     values.Global.IdentityTrustDomain,
+		}),
+		OmitWebhookSideEffects: values.OmitWebhookSideEffects,
+		ClusterDomain:          values.Global.ClusterDomain,
+	}
+
+	proxy := values.Global.Proxy
+	proxyInit := values.Global.ProxyInit
+	configs.Proxy = &pb.Proxy{
+		ProxyImage: &pb.Image{
+			ImageName:  chartImageName(proxy.Image.Name, values.Global.Registry),
+			PullPolicy: proxy.Image.PullPolicy,
+		},
+		ProxyInitImage: &pb.Image{
+			ImageName:  chartImageName(proxyInit.Image.Name, values.Global.Registry),
+			PullPolicy: proxyInit.Image.PullPolicy,
+		},
+		ControlPort:         &pb.Port{Port: uint32(proxy.Ports.Control)},
+		IgnoreInboundPorts:  toPortRanges(splitPorts(proxyInit.IgnoreInboundPorts)),
+		IgnoreOutboundPorts: toPortRanges(splitPorts(proxyInit.IgnoreOutboundPorts)),
+		InboundPort:         &pb.Port{Port: uint32(proxy.Ports.Inbound)},
+		AdminPort:           &pb.Port{Port: uint32(proxy.Ports.Admin)},
+		OutboundPort:        &pb.Port{Port: uint32(proxy.Ports.Outbound)},
+		Resource: &pb.ResourceRequirements{
+			RequestCpu:    proxy.Resources.CPU.Request,
+			RequestMemory: proxy.Resources.Memory.Request,
+			LimitCpu:      proxy.Resources.CPU.Limit,
+			LimitMemory:   proxy.Resources.Memory.Limit,
+		},
+		ProxyUid:                proxy.UID,
+		LogLevel:                &pb.LogLevel{Level: proxy.LogLevel},
+		LogFormat:               proxy.LogFormat,
+		DestinationGetNetworks:  proxy.DestinationGetNetworks,
+		DisableExternalProfiles: !proxy.EnableExternalProfiles,
+		ProxyVersion:            proxy.Image.Version,
+		ProxyInitImageVersion:   proxyInit.Image.Version,
+		DebugImage: &pb.Image{
+			ImageName:  chartImageName(values.DebugContainer.Image.Name, values.Global.Registry),
+			PullPolicy: values.DebugContainer.Image.PullPolicy,
+		},
+		DebugImageVersion:            values.DebugContainer.Image.Version,
+		OutboundConnectTimeout:       proxy.OutboundConnectTimeout,
+		InboundConnectTimeout:        proxy.InboundConnectTimeout,
+		OutboundConnectBackoffMin:    proxy.OutboundConnectBackoffMin,
+		OutboundConnectBackoffMax:    proxy.OutboundConnectBackoffMax,
+		OutboundConnectBackoffJitter: proxy.OutboundConnectBackoffJitter,
+		ExcludedNodeSelector:         proxy.ExcludedNodeSelector,
+	}
+	omitDefaultConnectTimeouts(configs.Proxy)
+}
+
+// chartImageName returns the name of an image as rendered by the
+// partials.image template
+func chartImageName(image, registry string) string {
+	if registry == "" {
+		return image
+	}
+	return fmt.Sprintf("%s/%s", registry, path.Base(image))
+}
+
+// splitPorts splits a comma-separated list of ports and port ranges
+func splitPorts(ports string) []string {
+	if ports == "" {
+		return nil
+	}
+	return strings.Split(ports, ",")
+}
+
+// flattenValues turns values parsed by strvals back into key=value overrides,
+// with the keys and values escaped so that they're parsed verbatim.
+func flattenValues(prefix string, values interface{}) []string {
+	switch v := values.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		var overrides []string
+		for _, key := range keys {
+			name := escapeValue(key, "\\=[,.")
+			if prefix != "" {
+				name = fmt.Sprintf("%s.%s", prefix, name)
+			}
+			overrides = append(overrides, flattenValues(name, v[key])...)
+		}
+		return overrides
+	case []interface{}:
+		var overrides []string
+		for i, item := range v {
+			if item != nil {
+				overrides = append(overrides, flattenValues(fmt.Sprintf("%s[%d]", prefix, i), item)...)
+			}
+		}
+		return overrides
+	case nil:
+		return []string{fmt.Sprintf("%s=null", prefix)}
+	default:
+		return []string{fmt.Sprintf("%s=%s", prefix, escapeValue(fmt.Sprint(v), "\\,{"))}
+	}
+}
+
+// escapeValue prefixes the special characters in s with a backslash
+func escapeValue(s, special string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if strings.ContainsRune(special, r) {
+			b.WriteRune('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// overrideValues converts values into a generic map, lets override modify it
+// and converts it back. Keys that don't map onto the chart values are
+// rejected.
+func overrideValues(values *l5dcharts.Values, override func(chartutil.Values) error) error {
+	rawValues, err := yaml.Marshal(values)
+	if err != nil {
+		return err
+	}
+
+	base := chartutil.Values{}
+	if err := yaml.Unmarshal(rawValues, &base); err != nil {
+		return err
+	}
+
+	if err := override(base); err != nil {
+		return err
+	}
+
+	rawValues, err = yaml.Marshal(base)
+	if err != nil {
+		return err
+	}
+
+	var overridden l5dcharts.Values
+	if err := yaml.UnmarshalStrict(rawValues, &overridden); err != nil {
+		return fmt.Errorf("invalid chart values override: %s", err)
+	}
+
+	*values = overridden
+	return nil
+}
+
+// allFlagSet returns all the install flags bound to options.
+func (options *installOptions) allFlagSet() *pflag.FlagSet {
+	flags := pflag.NewFlagSet("all", pflag.ContinueOnError)
+	flags.AddFlagSet(options.recordableFlagSet())
+	flags.AddFlagSet(options.installOnlyFlagSet())
+	flags.AddFlagSet(options.installPersistentFlagSet())
+	return flags
+}
+
+// applyValueFiles uses the values files as the defaults of the install
+// options. Options that don't hold their built-in default, as well as the
+// options whose flag was changed in flags, are left untouched so that flags
+// always take precedence over the values files.
+func (options *installOptions) applyValueFiles(flags *pflag.FlagSet) error {
+	if len(options.valuesOptions.valueFiles) == 0 {
+		return nil
+	}
+
+	defaultValues, err := l5dcharts.NewValues(false)
+	if err != nil {
+		return err
+	}
+	defaults, err := newInstallOptionsFromValues(defaultValues)
+	if err != nil {
+		return err
+	}
+
+	if err := options.valuesOptions.mergeValueFiles(defaultValues); err != nil {
+		return err
+	}
+	fromFiles, err := newInstallOptionsFromValues(defaultValues)
+	if err != nil {
+		return err
+	}
+
+	defaultFlags := defaults.allFlagSet()
+	fileFlags := fromFiles.allFlagSet()
+
+	var setErr error
+	options.allFlagSet().VisitAll(func(f *pflag.Flag) {
+		if setErr != nil {
+			return
+		}
+		if flags != nil {
+			if changed := flags.Lookup(f.Name); changed != nil && changed.Changed {
+				return
+			}
+		}
+
+		defaultValue := defaultFlags.Lookup(f.Name).Value.String()
+		fileValue := fileFlags.Lookup(f.Name).Value.String()
+		if f.Value.String() != defaultValue || fileValue == defaultValue {
+			return
+		}
+
+		// See setFlagsFromInstall
+		if f.Value.Type() == "stringSlice" || f.Value.Type() == "stringToString" {
+			fileValue = strings.Trim(fileValue, "[]")
+		}
+		if err := f.Value.Set(fileValue); err != nil {
+			setErr = fmt.Errorf("invalid value %q for --%s from values files: %s", fileValue, f.Name, err)
+		}
+	})
+
+	return setErr
+}
@@ -0,0 +1,194 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	pb "github.com/linkerd/linkerd2/controller/gen/config"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+)
+
+func TestValuesOptions(t *testing.T) {
+	dir, err := ioutil.TempDir("", "linkerd-values")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	anchorsFile := filepath.Join(dir, "ca.crt")
+	if err := ioutil.WriteFile(anchorsFile, []byte("test-trust-anchors"), 0600); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	t.Run("Overrides values", func(t *testing.T) {
+		options, err := testInstallOptions()
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		options.valuesOptions = &valuesOptions{
+			values:       []string{"global.proxy.logLevel=debug,controllerReplicas=2", "prometheus.enabled=false"},
+			stringValues: []string{"global.proxy.outboundConnectBackoffJitter=0.5"},
+			fileValues:   []string{"global.identityTrustAnchorsPEM=" + anchorsFile},
+		}
+
+		values, configs, err := options.validateAndBuild("", nil)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		if values.Global.Proxy.LogLevel != "debug" {
+			t.Errorf("Expected proxy log level \"debug\", got %q", values.Global.Proxy.LogLevel)
+		}
+		if values.ControllerReplicas != 2 {
+			t.Errorf("Expected 2 controller replicas, got %d", values.ControllerReplicas)
+		}
+		if values.Prometheus["enabled"] != false {
+			t.Errorf("Expected prometheus to be disabled, got %v", values.Prometheus["enabled"])
+		}
+		if values.Global.Proxy.OutboundConnectBackoffJitter != "0.5" {
+			t.Errorf("Expected connect backoff jitter \"0.5\", got %q", values.Global.Proxy.OutboundConnectBackoffJitter)
+		}
+		if values.Global.IdentityTrustAnchorsPEM != "test-trust-anchors" {
+			t.Errorf("Expected trust anchors from file, got %q", values.Global.IdentityTrustAnchorsPEM)
+		}
+		if level := configs.GetProxy().GetLogLevel().GetLevel(); level != "debug" {
+			t.Errorf("Expected proxy config log level \"debug\", got %q", level)
+		}
+		if anchors := configs.GetGlobal().GetIdentityContext().GetTrustAnchorsPem(); anchors != "test-trust-anchors" {
+			t.Errorf("Expected global config trust anchors from file, got %q", anchors)
+		}
+		if !strings.Contains(values.Configs.Global, "test-trust-anchors") {
+			t.Errorf("Expected the global config JSON to be updated, got %s", values.Configs.Global)
+		}
+	})
+
+	t.Run("Merges values files", func(t *testing.T) {
+		first := filepath.Join(dir, "first.yaml")
+		second := filepath.Join(dir, "second.yaml")
+		if err := ioutil.WriteFile(first, []byte("controllerReplicas: 2\nglobal:\n  proxy:\n    logLevel: debug\n"), 0600); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if err := ioutil.WriteFile(second, []byte("global:\n  proxy:\n    logLevel: trace\nnodeSelector:\n  kubernetes.io/arch: amd64\n"), 0600); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		options, err := testInstallOptions()
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		options.valuesOptions = &valuesOptions{valueFiles: []string{first, second}}
+
+		flags := options.recordableFlagSet()
+		if err := flags.Set("controller-replicas", "3"); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		values, configs, err := options.validateAndBuild("", flags)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		if values.ControllerReplicas != 3 {
+			t.Errorf("Expected the --controller-replicas flag to take precedence, got %d replicas", values.ControllerReplicas)
+		}
+		if values.Global.Proxy.LogLevel != "trace" {
+			t.Errorf("Expected proxy log level \"trace\" from the last values file, got %q", values.Global.Proxy.LogLevel)
+		}
+		if level := configs.GetProxy().GetLogLevel().GetLevel(); level != "trace" {
+			t.Errorf("Expected proxy config log level \"trace\", got %q", level)
+		}
+		if values.NodeSelector["kubernetes.io/arch"] != "amd64" {
+			t.Errorf("Expected node selector from the values file, got %v", values.NodeSelector)
+		}
+	})
+
+	t.Run("Rejects invalid overrides", func(t *testing.T) {
+		testCases := []struct {
+			options  *valuesOptions
+			expected string
+		}{
+			{
+				&valuesOptions{values: []string{"global.proxy.logLevel"}},
+				"failed parsing --set data: key \"logLevel\" has no value",
+			},
+			{
+				&valuesOptions{fileValues: []string{"global.identityTrustAnchorsPEM=" + filepath.Join(dir, "missing")}},
+				"failed parsing --set-file data: open " + filepath.Join(dir, "missing") + ": no such file or directory",
+			},
+			{
+				&valuesOptions{valueFiles: []string{anchorsFile}},
+				"failed to parse values file " + anchorsFile + ": error unmarshaling JSON: while decoding JSON: json: cannot unmarshal string into Go value of type chartutil.Values",
+			},
+			{
+				&valuesOptions{values: []string{"global.proxy.logLevl=debug"}},
+				"invalid chart values override: error unmarshaling JSON: while decoding JSON: json: unknown field \"logLevl\"",
+			},
+		}
+
+		for _, tc := range testCases {
+			options, err := testInstallOptions()
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			options.valuesOptions = tc.options
+
+			_, _, err = options.validateAndBuild("", nil)
+			if err == nil {
+				t.Fatal("Expected error, got nothing")
+			}
+			if err.Error() != tc.expected {
+				t.Fatalf("Expected error string \"%s\", got \"%s\"", tc.expected, err)
+			}
+		}
+	})
+
+	t.Run("Doesn't record the secret overrides", func(t *testing.T) {
+		keyFile := filepath.Join(dir, "key.pem")
+		if err := ioutil.WriteFile(keyFile, []byte("test-issuer-private-key"), 0600); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		installOpts, installFlags, _, _ := testOptionsAndFlags(t)
+		installFlags.Set("set-file", "identity.issuer.tls.keyPEM="+keyFile)
+		installFlags.Set("set", "identity.issuer.tls.crtPEM=test-issuer-crt,global.proxy.logLevel=debug")
+		installFlags.Set("set-string", "tap.keyPEM=test-tap-private-key")
+		values := installValues(t, installOpts, installFlags)
+		if values.Identity.Issuer.TLS.KeyPEM != "test-issuer-private-key" {
+			t.Fatalf("Expected the issuer key from the file, got %q", values.Identity.Issuer.TLS.KeyPEM)
+		}
+
+		install := renderInstall(t, values)
+		if err := appendConfigHistory(&install, nil, "install", values.Configs, installOpts.recordedFlags, time.Now()); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		var configMaps []string
+		for _, m := range splitManifests(install.String()) {
+			if strings.Contains(m, "kind: ConfigMap") &&
+				(strings.Contains(m, fmt.Sprintf("name: %s\n", k8s.ConfigConfigMapName)) || strings.Contains(m, fmt.Sprintf("name: %s\n", k8s.ConfigHistoryConfigMapName))) {
+				configMaps = append(configMaps, m)
+			}
+		}
+		if len(configMaps) != 2 {
+			t.Fatalf("Expected the %s and %s ConfigMaps, got %d", k8s.ConfigConfigMapName, k8s.ConfigHistoryConfigMapName, len(configMaps))
+		}
+		for _, cm := range configMaps {
+			for _, secret := range []string{"test-issuer-private-key", "test-issuer-crt", "test-tap-private-key"} {
+				if strings.Contains(cm, secret) {
+					t.Errorf("Expected %q not to be recorded, got:\n%s", secret, cm)
+				}
+			}
+		}
+
+		expected := []*pb.Install_Flag{{Name: "set", Value: "global.proxy.logLevel=debug"}}
+		if !reflect.DeepEqual(installOpts.valuesOptions.recordedFlags(), expected) {
+			t.Errorf("Expected recorded flags %v, got %v", expected, installOpts.valuesOptions.recordedFlags())
+		}
+	})
+}
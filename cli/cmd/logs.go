@@ -3,10 +3,12 @@ package cmd
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"os/signal"
 	"regexp"
+	"strings"
 	"text/template"
 	"time"
 
@@ -29,16 +31,29 @@ type logCmdConfig struct {
 type logsOptions struct {
 	container             string
 	controlPlaneComponent string
+	namespace             string
+	level                 string
 	noColor               bool
 	sinceSeconds          time.Duration
 	tail                  int64
 	timestamps            bool
 }
 
+// logLevels lists the log levels from the most to the least verbose.
+var logLevels = []string{"trace", "debug", "info", "warn", "error"}
+
+// logLevelRegex extracts the level of a log line emitted by the proxy (e.g.
+// "[     0.002s]  WARN ThreadId(01) ...") or by a control plane container,
+// whose logs are formatted by logrus as text (e.g. level=warning) or as JSON
+// (e.g. "level":"warning").
+var logLevelRegex = regexp.MustCompile(`\]\s+(TRACE|DEBUG|INFO|WARN|ERROR)\s|\blevel=(\w+)|"level":"(\w+)"`)
+
 func newLogsOptions() *logsOptions {
 	return &logsOptions{
 		container:             "",
 		controlPlaneComponent: "",
+		namespace:             defaultNamespace,
+		level:                 "",
 		noColor:               false,
 		sinceSeconds:          48 * time.Hour,
 		tail:                  -1,
@@ -70,20 +85,71 @@ func (o *logsOptions) toSternConfig(controlPlaneComponents, availableContainers
 		config.LabelSelector = selector
 	}
 
-	if o.container != "" {
-		var matchingContainer string
-		for _, c := range availableContainers {
-			if o.container == c {
-				matchingContainer = c
-				break
+	if o.container != "" && !containsString(availableContainers, o.container) {
+		return nil, fmt.Errorf("container [%s] does not exist in control plane [%s]", o.container, controlPlaneNamespace)
+	}
+
+	// Do not use regex to filter pods. Instead, we provide the list of all control plane components and use
+	// the label selector to filter logs.
+	podFilterRgx, err := regexp.Compile("")
+	if err != nil {
+		return nil, err
+	}
+
+	config.PodQuery = podFilterRgx
+	config.Namespace = controlPlaneNamespace
+
+	return o.completeSternConfig(config, o.container)
+}
+
+// toWorkloadSternConfig returns the configuration to tail the logs of the
+// given pods of a workload, which default to the logs of their proxies.
+// Only the pods running when the command starts are tailed.
+func (o *logsOptions) toWorkloadSternConfig(pods []corev1.Pod) (*stern.Config, error) {
+	if o.controlPlaneComponent != "" {
+		return nil, errors.New("--control-plane-component cannot be used with a resource")
+	}
+
+	container := o.container
+	if container == "" {
+		container = k8s.ProxyContainerName
+	}
+
+	// The proxy is an init container when it's injected as a native sidecar.
+	// stern watches the statuses of the init containers as well, so running
+	// init containers are tailed like the other containers.
+	names := make([]string, 0, len(pods))
+	var availableContainers []string
+	for _, pod := range pods {
+		names = append(names, regexp.QuoteMeta(pod.GetName()))
+		for _, containers := range [][]corev1.Container{pod.Spec.InitContainers, pod.Spec.Containers} {
+			for _, c := range containers {
+				availableContainers = append(availableContainers, c.Name)
 			}
 		}
-		if matchingContainer == "" {
-			return nil, fmt.Errorf("container [%s] does not exist in control plane [%s]", o.container, controlPlaneNamespace)
-		}
+	}
+	if !containsString(availableContainers, container) {
+		return nil, fmt.Errorf("container [%s] does not exist in the pods of the resource", container)
+	}
+
+	podFilterRgx, err := regexp.Compile(fmt.Sprintf("^(%s)$", strings.Join(names, "|")))
+	if err != nil {
+		return nil, err
+	}
+
+	config := &stern.Config{
+		LabelSelector: labels.Everything(),
+		PodQuery:      podFilterRgx,
+		Namespace:     o.namespace,
 	}
 
-	containerFilterRgx, err := regexp.Compile(o.container)
+	return o.completeSternConfig(config, container)
+}
+
+// completeSternConfig sets the fields of config that don't depend on the pods
+// being tailed.
+func (o *logsOptions) completeSternConfig(config *stern.Config, container string) (*stern.Config, error) {
+	containerFilterRgx, err := regexp.Compile(container)
 	if err != nil {
 		return nil, err
 	}
@@ -93,15 +159,14 @@ func (o *logsOptions) toSternConfig(controlPlaneComponents, availableContainers
 		config.TailLines = &o.tail
 	}
 
-	// Do not use regex to filter pods. Instead, we provide the list of all control plane components and use
-	// the label selector to filter logs.
-	podFilterRgx, err := regexp.Compile("")
+	include, err := logLevelFilter(o.level)
 	if err != nil {
 		return nil, err
 	}
+	config.Include = include
 
 	// Based on stern/cmd/cli.go
-	t := "{{color .PodColor .PodName}} {{color .ContainerColor .ContainerName}} {{.Message}}"
+	t := "{{color .PodColor .PodName}} {{color .ContainerColor .ContainerName}} {{colorLevel .Message}}"
 	if o.noColor {
 		t = "{{.PodName}} {{.ContainerName}} {{.Message}}"
 	}
@@ -116,16 +181,15 @@ func (o *logsOptions) toSternConfig(controlPlaneComponents, availableContainers
 		"color": func(color color.Color, text string) string {
 			return color.SprintFunc()(text)
 		},
+		"colorLevel": colorLogLevel,
 	}
 	template, err := template.New("log").Funcs(funs).Parse(t)
 	if err != nil {
 		return nil, err
 	}
 
-	config.PodQuery = podFilterRgx
 	config.Since = o.sinceSeconds
 	config.Timestamps = o.timestamps
-	config.Namespace = controlPlaneNamespace
 	config.ContainerState = stern.RUNNING
 	config.ExcludeContainerQuery = nil
 	config.Template = template
@@ -133,6 +197,67 @@ func (o *logsOptions) toSternConfig(controlPlaneComponents, availableContainers
 	return config, nil
 }
 
+// logLevelFilter returns the regexes matching the log lines whose level is at
+// least level, or nil to not filter the log lines when level is empty.
+func logLevelFilter(level string) ([]*regexp.Regexp, error) {
+	if level == "" {
+		return nil, nil
+	}
+
+	for i, l := range logLevels {
+		if l != level {
+			continue
+		}
+
+		var proxyLevels, logrusLevels []string
+		for _, accepted := range logLevels[i:] {
+			proxyLevels = append(proxyLevels, strings.ToUpper(accepted))
+			logrusLevels = append(logrusLevels, logrusLevelNames(accepted)...)
+		}
+		proxy := strings.Join(proxyLevels, "|")
+		logrus := strings.Join(logrusLevels, "|")
+
+		return []*regexp.Regexp{
+			regexp.MustCompile(fmt.Sprintf(`\]\s+(%s)\s`, proxy)),
+			regexp.MustCompile(fmt.Sprintf(`\blevel=(%s)\b`, logrus)),
+			regexp.MustCompile(fmt.Sprintf(`"level":"(%s)"`, logrus)),
+		}, nil
+	}
+
+	return nil, fmt.Errorf("--level must be one of %s", strings.Join(logLevels, ", "))
+}
+
+// logrusLevelNames returns the names logrus gives to a log level.
+func logrusLevelNames(level string) []string {
+	switch level {
+	case "warn":
+		return []string{"warning"}
+	case "error":
+		return []string{"error", "fatal", "panic"}
+	default:
+		return []string{level}
+	}
+}
+
+// colorLogLevel colors a log line according to its level: errors in red and
+// warnings in yellow.
+func colorLogLevel(message string) string {
+	match := logLevelRegex.FindStringSubmatch(message)
+	if match == nil {
+		return message
+	}
+
+	level := strings.ToLower(match[1] + match[2] + match[3])
+	switch level {
+	case "error", "fatal", "panic":
+		return color.RedString("%s", message)
+	case "warn", "warning":
+		return color.YellowString("%s", message)
+	default:
+		return message
+	}
+}
+
 func getControlPlaneComponentsAndContainers(pods *corev1.PodList) ([]string, []string) {
 	var controlPlaneComponents, containers []string
 	for _, pod := range pods.Items {
@@ -144,12 +269,32 @@ func getControlPlaneComponentsAndContainers(pods *corev1.PodList) ([]string, []s
 	return controlPlaneComponents, containers
 }
 
-func newLogCmdConfig(options *logsOptions, kubeconfigPath, kubeContext, impersonate string, impersonateGroup []string) (*logCmdConfig, error) {
+func newLogCmdConfig(options *logsOptions, resources []string, kubeconfigPath, kubeContext, impersonate string, impersonateGroup []string) (*logCmdConfig, error) {
 	kubeAPI, err := k8s.NewAPI(kubeconfigPath, kubeContext, impersonate, impersonateGroup, 0)
 	if err != nil {
 		return nil, err
 	}
 
+	if len(resources) != 0 {
+		pods, err := getPodsFor(kubeAPI, options.namespace, resources[0])
+		if err != nil {
+			return nil, err
+		}
+		if len(pods) == 0 {
+			return nil, fmt.Errorf("no pods found for %s", resources[0])
+		}
+
+		c, err := options.toWorkloadSternConfig(pods)
+		if err != nil {
+			return nil, err
+		}
+
+		return &logCmdConfig{
+			kubeAPI,
+			c,
+		}, nil
+	}
+
 	podList, err := kubeAPI.CoreV1().Pods(controlPlaneNamespace).List(metav1.ListOptions{})
 	if err != nil {
 		return nil, err
@@ -172,9 +317,18 @@ func newCmdLogs() *cobra.Command {
 	options := newLogsOptions()
 
 	cmd := &cobra.Command{
-		Use:   "logs [flags]",
-		Short: "Tail logs from containers in the Linkerd control plane",
-		Long:  `Tail logs from containers in the Linkerd control plane.`,
+		Use:   "logs [flags] [RESOURCE]",
+		Short: "Tail logs from containers in the Linkerd control plane or from the proxies of a workload",
+		Long: `Tail logs from containers in the Linkerd control plane or from the proxies of a workload.
+
+  Without the RESOURCE argument, this command tails the logs of the control
+  plane containers. The RESOURCE argument (TYPE/NAME) selects the pods of a
+  workload instead, whose proxy logs are tailed by default. Only the pods
+  running when the command starts are tailed.
+
+  The logs of all the matching pods are merged. The --level flag only shows
+  the log lines of the given level or above, and log lines are colored by
+  level unless --no-color is set.`,
 		Example: `  # Tail logs from all containers in the prometheus control plane component
   linkerd logs --control-plane-component prometheus
 
@@ -186,11 +340,18 @@ func newCmdLogs() *cobra.Command {
 
   # Tail logs from the linkerd-proxy container in the controller component showing timestamps for each line
   linkerd logs --control-plane-component controller --container linkerd-proxy --timestamps
+
+  # Tail the warnings and errors of the destination control plane component from the last 10 minutes
+  linkerd logs --control-plane-component destination --level warn --since 10m
+
+  # Tail logs from the proxies of all the pods of the web deployment
+  linkerd logs --namespace emojivoto deploy/web
 `,
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			color.NoColor = options.noColor
 
-			opts, err := newLogCmdConfig(options, kubeconfigPath, kubeContext, impersonate, impersonateGroup)
+			opts, err := newLogCmdConfig(options, args, kubeconfigPath, kubeContext, impersonate, impersonateGroup)
 
 			if err != nil {
 				return err
@@ -202,6 +363,8 @@ func newCmdLogs() *cobra.Command {
 
 	cmd.PersistentFlags().StringVarP(&options.container, "container", "c", options.container, "Tail logs from the specified container. Options are 'public-api', 'destination', 'tap', 'prometheus', 'grafana' or 'linkerd-proxy'")
 	cmd.PersistentFlags().StringVar(&options.controlPlaneComponent, "control-plane-component", options.controlPlaneComponent, "Tail logs from the specified control plane component. Default value (empty string) causes this command to tail logs from all resources marked with the 'linkerd.io/control-plane-component' label selector")
+	cmd.PersistentFlags().StringVar(&options.namespace, "namespace", options.namespace, "Namespace of the RESOURCE")
+	cmd.PersistentFlags().StringVar(&options.level, "level", options.level, fmt.Sprintf("Only show the log lines of this level or above; one of: %s", strings.Join(logLevels, ", ")))
 	cmd.PersistentFlags().BoolVarP(&options.noColor, "no-color", "n", options.noColor, "Disable colorized output") // needed until at least https://github.com/wercker/stern/issues/69 is resolved
 	cmd.PersistentFlags().DurationVarP(&options.sinceSeconds, "since", "s", options.sinceSeconds, "Duration of how far back logs should be retrieved")
 	cmd.PersistentFlags().Int64Var(&options.tail, "tail", options.tail, "Last number of log lines to show for a given container. -1 does not show previous log lines")
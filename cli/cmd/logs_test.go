@@ -2,11 +2,16 @@ package cmd
 
 import (
 	"fmt"
+	"reflect"
 	"regexp"
+	"sort"
 	"testing"
 
+	"github.com/fatih/color"
 	"github.com/linkerd/linkerd2/pkg/k8s"
 	"github.com/wercker/stern/stern"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 )
 
@@ -105,3 +110,150 @@ func TestNewSternConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestNewWorkloadSternConfig(t *testing.T) {
+	pods := []corev1.Pod{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "web-1"},
+			Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "web"}, {Name: k8s.ProxyContainerName}}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "web-2"},
+			Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "web"}, {Name: k8s.ProxyContainerName}}},
+		},
+	}
+
+	t.Run("defaults to the proxy container", func(t *testing.T) {
+		options := newLogsOptions()
+		options.namespace = "emojivoto"
+		config, err := options.toWorkloadSternConfig(pods)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if config.Namespace != "emojivoto" {
+			t.Errorf("Unexpected namespace: %s", config.Namespace)
+		}
+		if config.PodQuery.String() != "^(web-1|web-2)$" {
+			t.Errorf("Unexpected regex for pod query: %s", config.PodQuery)
+		}
+		if config.ContainerQuery.String() != k8s.ProxyContainerName {
+			t.Errorf("Unexpected regex for container query: %s", config.ContainerQuery)
+		}
+	})
+
+	t.Run("accepts native sidecar proxies", func(t *testing.T) {
+		sidecarPods := []corev1.Pod{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "web-1"},
+				Spec: corev1.PodSpec{
+					InitContainers: []corev1.Container{{Name: k8s.ProxyContainerName}},
+					Containers:     []corev1.Container{{Name: "web"}},
+				},
+			},
+		}
+
+		options := newLogsOptions()
+		config, err := options.toWorkloadSternConfig(sidecarPods)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if config.ContainerQuery.String() != k8s.ProxyContainerName {
+			t.Errorf("Unexpected regex for container query: %s", config.ContainerQuery)
+		}
+		if config.ContainerState != stern.RUNNING {
+			t.Errorf("Unexpected container state: %s", config.ContainerState)
+		}
+	})
+
+	t.Run("rejects unknown containers", func(t *testing.T) {
+		options := newLogsOptions()
+		options.container = "tap"
+		_, err := options.toWorkloadSternConfig(pods)
+		expectedErr := "container [tap] does not exist in the pods of the resource"
+		if err == nil || err.Error() != expectedErr {
+			t.Errorf("Expected error %q, got %v", expectedErr, err)
+		}
+	})
+
+	t.Run("rejects control plane components", func(t *testing.T) {
+		options := newLogsOptions()
+		options.controlPlaneComponent = "destination"
+		if _, err := options.toWorkloadSternConfig(pods); err == nil {
+			t.Error("Expected an error")
+		}
+	})
+}
+
+func TestLogLevelFilter(t *testing.T) {
+	lines := map[string]string{
+		"proxy-info":   "[     0.002s]  INFO ThreadId(01) linkerd2_proxy: Admin interface on 0.0.0.0:4191\n",
+		"proxy-warn":   "[    10.005s]  WARN ThreadId(01) inbound: linkerd2_app_core::errors: Failed to proxy request\n",
+		"proxy-error":  "2020-09-01T10:00:00.0Z [    10.005s] ERROR ThreadId(01) outbound: linkerd2_proxy_http: connection refused\n",
+		"logrus-info":  `time="2020-09-01T10:00:00Z" level=info msg="starting admin server on :9996"` + "\n",
+		"logrus-warn":  `time="2020-09-01T10:00:00Z" level=warning msg="failed to get pod"` + "\n",
+		"logrus-fatal": `{"level":"fatal","msg":"failed to start","time":"2020-09-01T10:00:00Z"}` + "\n",
+	}
+
+	testCases := []struct {
+		level    string
+		expected []string
+	}{
+		{"warn", []string{"logrus-fatal", "logrus-warn", "proxy-error", "proxy-warn"}},
+		{"error", []string{"logrus-fatal", "proxy-error"}},
+		{"info", []string{"logrus-fatal", "logrus-info", "logrus-warn", "proxy-error", "proxy-info", "proxy-warn"}},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.level, func(t *testing.T) {
+			filter, err := logLevelFilter(tc.level)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+
+			var matching []string
+			for name, line := range lines {
+				for _, rex := range filter {
+					if rex.MatchString(line) {
+						matching = append(matching, name)
+						break
+					}
+				}
+			}
+			sort.Strings(matching)
+
+			if !reflect.DeepEqual(matching, tc.expected) {
+				t.Errorf("Expected %v, got %v", tc.expected, matching)
+			}
+		})
+	}
+
+	if filter, err := logLevelFilter(""); err != nil || filter != nil {
+		t.Errorf("Expected no filter, got %v, %v", filter, err)
+	}
+	if _, err := logLevelFilter("verbose"); err == nil {
+		t.Error("Expected an error for an unknown level")
+	}
+}
+
+func TestColorLogLevel(t *testing.T) {
+	noColor := color.NoColor
+	color.NoColor = false
+	defer func() { color.NoColor = noColor }()
+
+	testCases := []struct {
+		message  string
+		expected string
+	}{
+		{"[ 0.1s] ERROR ThreadId(01) outbound: oops\n", color.RedString("%s", "[ 0.1s] ERROR ThreadId(01) outbound: oops\n")},
+		{"level=warning msg=\"hmm\"\n", color.YellowString("%s", "level=warning msg=\"hmm\"\n")},
+		{"level=info msg=\"ok\"\n", "level=info msg=\"ok\"\n"},
+		{"no level\n", "no level\n"},
+	}
+
+	for i, tc := range testCases {
+		if actual := colorLogLevel(tc.message); actual != tc.expected {
+			t.Errorf("test case %d: expected %q, got %q", i, tc.expected, actual)
+		}
+	}
+}
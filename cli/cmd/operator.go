@@ -0,0 +1,334 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	l5dcharts "github.com/linkerd/linkerd2/pkg/charts/linkerd2"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	defaultControlPlaneName = "linkerd"
+
+	controlPlaneReady  = "Ready"
+	controlPlaneFailed = "Failed"
+
+	// operatorRetryMinDelay and operatorRetryMaxDelay bound the exponential
+	// backoff between the attempts to reconcile a failed generation
+	operatorRetryMinDelay = 5 * time.Second
+	operatorRetryMaxDelay = 5 * time.Minute
+)
+
+type operatorOptions struct {
+	name         string
+	applyTimeout time.Duration
+}
+
+func newCmdOperator() *cobra.Command {
+	options := &operatorOptions{
+		name:         defaultControlPlaneName,
+		applyTimeout: 5 * time.Minute,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "operator [flags]",
+		Args:  cobra.NoArgs,
+		Short: "Reconcile the control plane declared by a LinkerdControlPlane resource",
+		Long: `Reconcile the control plane declared by a LinkerdControlPlane resource.
+
+This command watches the LinkerdControlPlane resource, as generated by
+"linkerd install --crd-output", and renders and applies the control plane
+manifest every time its spec changes. The outcome is recorded in the resource's
+status. Failed reconciliations are retried with an exponential backoff, until
+they succeed or the spec changes.
+
+Deleting the resource leaves the control plane in place; use "linkerd uninstall"
+to remove it.
+
+No Deployment, ServiceAccount or RBAC is installed for this command: it must be
+run out of band, e.g. by a Deployment of your own running the linkerd CLI, with
+credentials allowed to manage all the control plane resources.`,
+		Example: `  # Install the CRDs and declare the control plane
+  linkerd install crds | kubectl apply -f -
+  linkerd install --crd-output | kubectl apply -f -
+
+  # Reconcile it
+  linkerd operator`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			k, err := k8s.NewAPI(kubeconfigPath, kubeContext, impersonate, impersonateGroup, 0)
+			if err != nil {
+				return err
+			}
+
+			stop := make(chan os.Signal, 1)
+			signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+			return runOperator(k, options, stop)
+		},
+	}
+
+	cmd.Flags().StringVar(&options.name, "name", options.name, "Name of the LinkerdControlPlane resource to reconcile")
+	cmd.Flags().DurationVar(
+		&options.applyTimeout, "apply-timeout", options.applyTimeout,
+		"How long to wait for the control plane workloads to be ready after applying the manifest",
+	)
+
+	return cmd
+}
+
+// installCRDOutputRunE prints a LinkerdControlPlane resource holding the values
+// built from the install options, instead of the manifest rendered from them.
+// The private keys are printed in Secrets of the control plane namespace
+// rather than in the resource.
+func installCRDOutputRunE(w io.Writer, options *installOptions, flags *pflag.FlagSet) error {
+	if options.apply || options.outputDir != "" || options.dryRun != dryRunNone {
+		return errors.New("--crd-output can't be used with --apply, --output-dir or --dry-run")
+	}
+
+	values, _, err := options.validateAndBuild("", flags)
+	if err != nil {
+		return err
+	}
+
+	u, secrets, err := l5dcharts.NewControlPlaneResource(defaultControlPlaneName, values)
+	if err != nil {
+		return err
+	}
+
+	objs := make([]interface{}, 0)
+	if len(secrets) > 0 && values.InstallNamespace {
+		objs = append(objs, newControlPlaneNamespace(values))
+	}
+	for _, secret := range secrets {
+		objs = append(objs, secret)
+	}
+	objs = append(objs, u.Object)
+
+	var buf bytes.Buffer
+	for _, obj := range objs {
+		data, err := yaml.Marshal(obj)
+		if err != nil {
+			return err
+		}
+		buf.WriteString("---\n")
+		buf.Write(data)
+	}
+
+	return writeManifest(w, buf.Bytes(), options.output)
+}
+
+// newControlPlaneNamespace returns the control plane namespace, as rendered by
+// the chart, so that the Secrets can be created before the control plane.
+func newControlPlaneNamespace(values *l5dcharts.Values) corev1.Namespace {
+	return corev1.Namespace{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Namespace"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: values.Global.Namespace,
+			Annotations: map[string]string{
+				values.Global.ProxyInjectAnnotation: values.Global.ProxyInjectDisabled,
+			},
+			Labels: map[string]string{
+				values.Global.LinkerdNamespaceLabel:    "true",
+				"config.linkerd.io/admission-webhooks": "disabled",
+				values.Global.ControllerNamespaceLabel: values.Global.Namespace,
+			},
+		},
+	}
+}
+
+func runOperator(k *k8s.KubernetesAPI, options *operatorOptions, stop <-chan os.Signal) error {
+	client := k.DynamicClient.Resource(l5dcharts.ControlPlaneGVR)
+	selector := fields.OneTermEqualSelector("metadata.name", options.name).String()
+
+	// the generation whose reconciliation failed, and when to retry it
+	var (
+		failedGeneration int64
+		retryDelay       time.Duration
+		retry            <-chan time.Time
+	)
+	reconcile := func(obj *unstructured.Unstructured) {
+		log.Infof("Reconciling LinkerdControlPlane %s (generation %d)", obj.GetName(), obj.GetGeneration())
+		reconcileErr := reconcileControlPlane(os.Stdout, k, obj, options.applyTimeout)
+		if reconcileErr != nil {
+			failedGeneration = obj.GetGeneration()
+			retryDelay = nextRetryDelay(retryDelay)
+			retry = time.After(retryDelay)
+			log.Errorf("Failed to reconcile LinkerdControlPlane %s (retrying in %s): %s", obj.GetName(), retryDelay, reconcileErr)
+		} else {
+			failedGeneration, retryDelay, retry = 0, 0, nil
+		}
+		if err := updateControlPlaneStatus(client, obj, reconcileErr); err != nil {
+			log.Errorf("Failed to update the status of LinkerdControlPlane %s: %s", obj.GetName(), err)
+		}
+	}
+
+main:
+	for {
+		cpWatch, err := client.Watch(metav1.ListOptions{FieldSelector: selector})
+		if err != nil {
+			return fmt.Errorf("failed to watch LinkerdControlPlane %s: %s", options.name, err)
+		}
+		results := cpWatch.ResultChan()
+
+		for {
+			select {
+			case <-stop:
+				cpWatch.Stop()
+				break main
+			case <-retry:
+				retry = nil
+				obj, err := client.Get(options.name, metav1.GetOptions{})
+				if err != nil {
+					retryDelay = nextRetryDelay(retryDelay)
+					retry = time.After(retryDelay)
+					log.Errorf("Failed to get LinkerdControlPlane %s (retrying in %s): %s", options.name, retryDelay, err)
+					continue
+				}
+				if observedGeneration(obj) != obj.GetGeneration() {
+					reconcile(obj)
+				}
+			case event, ok := <-results:
+				if !ok {
+					log.Info("LinkerdControlPlane watch terminated; restarting watch")
+					continue main
+				}
+				obj, ok := event.Object.(*unstructured.Unstructured)
+				if !ok {
+					log.Errorf("Unknown object type detected: %+v", event.Object)
+					continue
+				}
+
+				switch event.Type {
+				case watch.Added, watch.Modified:
+					// Status updates don't bump the generation, so they don't
+					// trigger a new reconciliation, and a failed generation is
+					// only retried once its backoff elapsed
+					if observedGeneration(obj) == obj.GetGeneration() {
+						continue
+					}
+					if retry != nil && obj.GetGeneration() == failedGeneration {
+						continue
+					}
+					reconcile(obj)
+				case watch.Deleted:
+					log.Infof("LinkerdControlPlane %s deleted; the control plane is left in place", obj.GetName())
+				default:
+					log.Infof("Ignoring event type %s", event.Type)
+				}
+			}
+		}
+	}
+
+	log.Info("Shutting down")
+	return nil
+}
+
+// reconcileControlPlane renders the control plane from the values held by the
+// LinkerdControlPlane resource obj, and applies it.
+func reconcileControlPlane(w io.Writer, k *k8s.KubernetesAPI, obj *unstructured.Unstructured, timeout time.Duration) error {
+	values, err := l5dcharts.ValuesFromControlPlaneResource(*obj)
+	if err != nil {
+		return err
+	}
+	// The operator always manages the whole control plane
+	values.Stage = ""
+
+	if err := loadIssuerKeys(k, values); err != nil {
+		return err
+	}
+
+	if err := values.Validate(); err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err := render(&buf, values); err != nil {
+		return err
+	}
+
+	return applyManifest(w, k, buf.Bytes(), timeout)
+}
+
+// loadIssuerKeys sets the private keys of the identity issuer, which the
+// LinkerdControlPlane resource doesn't hold, from the issuer Secret.
+func loadIssuerKeys(k kubernetes.Interface, values *l5dcharts.Values) error {
+	issuer := values.Identity.Issuer
+	if issuer.Scheme != k8s.IdentityIssuerSchemeLinkerd || issuer.KeyURI != "" || issuer.TLS == nil || issuer.TLS.KeyPEM != "" {
+		return nil
+	}
+
+	secret, err := k.CoreV1().Secrets(values.Global.Namespace).Get(k8s.IdentityIssuerSecretName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to read the identity issuer private key from the %s Secret: %s", k8s.IdentityIssuerSecretName, err)
+	}
+	key, ok := secret.Data[k8s.IdentityIssuerKeyName]
+	if !ok {
+		return fmt.Errorf("the %s Secret has no %s", k8s.IdentityIssuerSecretName, k8s.IdentityIssuerKeyName)
+	}
+	issuer.TLS.KeyPEM = string(key)
+
+	if issuer.NextTLS != nil {
+		nextKey, ok := secret.Data[k8s.IdentityIssuerNextKeyName]
+		if !ok {
+			return fmt.Errorf("the %s Secret has no %s", k8s.IdentityIssuerSecretName, k8s.IdentityIssuerNextKeyName)
+		}
+		issuer.NextTLS.KeyPEM = string(nextKey)
+	}
+
+	return nil
+}
+
+// nextRetryDelay returns the delay before the next attempt to reconcile a
+// failed generation, doubling the previous one
+func nextRetryDelay(previous time.Duration) time.Duration {
+	if previous < operatorRetryMinDelay {
+		return operatorRetryMinDelay
+	}
+	if next := 2 * previous; next < operatorRetryMaxDelay {
+		return next
+	}
+	return operatorRetryMaxDelay
+}
+
+// updateControlPlaneStatus records the outcome of the reconciliation of obj in
+// its status. The generation is only recorded as observed when it succeeded,
+// so that a failed generation keeps being retried.
+func updateControlPlaneStatus(client dynamic.NamespaceableResourceInterface, obj *unstructured.Unstructured, reconcileErr error) error {
+	status := map[string]interface{}{
+		"observedGeneration": obj.GetGeneration(),
+		"phase":              controlPlaneReady,
+		"message":            "",
+	}
+	if reconcileErr != nil {
+		status["observedGeneration"] = observedGeneration(obj)
+		status["phase"] = controlPlaneFailed
+		status["message"] = reconcileErr.Error()
+	}
+
+	obj = obj.DeepCopy()
+	obj.Object["status"] = status
+	_, err := client.UpdateStatus(obj, metav1.UpdateOptions{})
+	return err
+}
+
+func observedGeneration(obj *unstructured.Unstructured) int64 {
+	generation, _, _ := unstructured.NestedInt64(obj.Object, "status", "observedGeneration")
+	return generation
+}
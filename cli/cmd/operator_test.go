@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	l5dcharts "github.com/linkerd/linkerd2/pkg/charts/linkerd2"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"sigs.k8s.io/yaml"
+)
+
+func TestInstallCRDOutput(t *testing.T) {
+	options, err := testInstallOptions()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	var buf bytes.Buffer
+	if err := installCRDOutputRunE(&buf, options, nil); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	objs, err := splitManifest(buf.Bytes())
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	kinds := make([]string, 0, len(objs))
+	for _, obj := range objs {
+		kinds = append(kinds, obj.ref())
+	}
+	expectedKinds := []string{"Namespace linkerd", "Secret linkerd/linkerd-identity-issuer", "LinkerdControlPlane linkerd"}
+	if !reflect.DeepEqual(kinds, expectedKinds) {
+		t.Fatalf("Expected the objects %v, got %v", expectedKinds, kinds)
+	}
+
+	cr := objs[len(objs)-1].YAML
+	if strings.Contains(string(cr), "keyPEM") {
+		t.Fatalf("Expected the LinkerdControlPlane not to hold any private key, got:\n%s", cr)
+	}
+
+	var u unstructured.Unstructured
+	if err := yaml.Unmarshal(cr, &u.Object); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if u.GetKind() != "LinkerdControlPlane" || u.GetName() != defaultControlPlaneName {
+		t.Fatalf("Expected a LinkerdControlPlane named %s, got %s %s", defaultControlPlaneName, u.GetKind(), u.GetName())
+	}
+
+	values, err := l5dcharts.ValuesFromControlPlaneResource(u)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if values.Global.Proxy.Image.Version != installProxyVersion {
+		t.Errorf("Expected the proxy version %s, got %s", installProxyVersion, values.Global.Proxy.Image.Version)
+	}
+
+	t.Run("Rejects --apply", func(t *testing.T) {
+		options.apply = true
+		defer func() { options.apply = false }()
+		if err := installCRDOutputRunE(&buf, options, nil); err == nil {
+			t.Fatal("Expected an error, got nothing")
+		}
+	})
+}
+
+func TestUpdateControlPlaneStatus(t *testing.T) {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("linkerd.io/v1alpha1")
+	obj.SetKind("LinkerdControlPlane")
+	obj.SetName("linkerd")
+	obj.SetGeneration(2)
+	if err := unstructured.SetNestedField(obj.Object, int64(1), "status", "observedGeneration"); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	client := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), obj).Resource(l5dcharts.ControlPlaneGVR)
+
+	testCases := []struct {
+		err        error
+		generation int64
+		phase      string
+		message    string
+	}{
+		// a failed generation isn't observed, so that it's retried
+		{errors.New("failed to apply Deployment/linkerd-controller"), 1, controlPlaneFailed, "failed to apply Deployment/linkerd-controller"},
+		{nil, 2, controlPlaneReady, ""},
+	}
+
+	for _, tc := range testCases {
+		if err := updateControlPlaneStatus(client, obj, tc.err); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		updated, err := client.Get("linkerd", metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if generation := observedGeneration(updated); generation != tc.generation {
+			t.Errorf("Expected the observed generation to be %d, got %d", tc.generation, generation)
+		}
+		phase, _, _ := unstructured.NestedString(updated.Object, "status", "phase")
+		message, _, _ := unstructured.NestedString(updated.Object, "status", "message")
+		if phase != tc.phase || message != tc.message {
+			t.Errorf("Expected phase %q and message %q, got %q and %q", tc.phase, tc.message, phase, message)
+		}
+	}
+}
+
+func TestNextRetryDelay(t *testing.T) {
+	var delays []time.Duration
+	delay := time.Duration(0)
+	for i := 0; i < 9; i++ {
+		delay = nextRetryDelay(delay)
+		delays = append(delays, delay)
+	}
+
+	expected := []time.Duration{5 * time.Second, 10 * time.Second, 20 * time.Second, 40 * time.Second, 80 * time.Second, 160 * time.Second, 5 * time.Minute, 5 * time.Minute, 5 * time.Minute}
+	if !reflect.DeepEqual(delays, expected) {
+		t.Fatalf("Expected delays %v, got %v", expected, delays)
+	}
+}
+
+func TestLoadIssuerKeys(t *testing.T) {
+	k, err := k8s.NewFakeAPI(`
+kind: Secret
+apiVersion: v1
+metadata:
+  name: linkerd-identity-issuer
+  namespace: linkerd
+data:
+  crt.pem: aXNzdWVyLWNydA==
+  key.pem: aXNzdWVyLWtleQ==`)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	values, err := l5dcharts.NewValues(false)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	values.Identity.Issuer.TLS = &l5dcharts.IssuerTLS{CrtPEM: "issuer-crt"}
+
+	if err := loadIssuerKeys(k, values); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if values.Identity.Issuer.TLS.KeyPEM != "issuer-key" {
+		t.Errorf("Expected the issuer key to be read from its secret, got %q", values.Identity.Issuer.TLS.KeyPEM)
+	}
+
+	values.Identity.Issuer.TLS.KeyPEM = ""
+	values.Identity.Issuer.NextTLS = &l5dcharts.IssuerTLS{CrtPEM: "next-issuer-crt"}
+	if err := loadIssuerKeys(k, values); err == nil {
+		t.Error("Expected an error when the staged issuer key is missing, got nothing")
+	}
+}
@@ -4,36 +4,54 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/linkerd/linkerd2/pkg/healthcheck"
 	"github.com/linkerd/linkerd2/pkg/k8s"
 	"github.com/linkerd/linkerd2/pkg/profiles"
 	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	rpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/validation"
 )
 
+const grpcReflectTimeout = 30 * time.Second
+
 type profileOptions struct {
-	name          string
-	namespace     string
-	template      bool
-	openAPI       string
-	proto         string
-	tap           string
-	tapDuration   time.Duration
-	tapRouteLimit uint
+	name             string
+	namespace        string
+	template         bool
+	openAPI          string
+	proto            string
+	tap              string
+	tapDuration      time.Duration
+	tapRouteLimit    uint
+	tapTimeoutFactor float64
+	grpcReflect      string
+	timeout          string
+	retryOn          string
+	retryBudget      string
+	routeOptions     profiles.RouteOptions
 }
 
 func newProfileOptions() *profileOptions {
 	return &profileOptions{
-		name:          "",
-		namespace:     defaultNamespace,
-		template:      false,
-		openAPI:       "",
-		proto:         "",
-		tap:           "",
-		tapDuration:   5 * time.Second,
-		tapRouteLimit: 20,
+		name:             "",
+		namespace:        defaultNamespace,
+		template:         false,
+		openAPI:          "",
+		proto:            "",
+		tap:              "",
+		tapDuration:      5 * time.Second,
+		tapRouteLimit:    20,
+		tapTimeoutFactor: 0,
+		grpcReflect:      "",
+		timeout:          "",
+		retryOn:          "",
+		retryBudget:      "",
 	}
 }
 
@@ -51,10 +69,28 @@ func (options *profileOptions) validate() error {
 	if options.tap != "" {
 		outputs++
 	}
+	if options.tapTimeoutFactor < 0 {
+		return errors.New("--tap-timeout-factor must not be negative")
+	}
+	if options.grpcReflect != "" {
+		outputs++
+		if _, _, err := parseGRPCReflectAuthority(options.grpcReflect); err != nil {
+			return err
+		}
+	}
 	if outputs != 1 {
-		return errors.New("You must specify exactly one of --template or --open-api or --proto or --tap")
+		return errors.New("You must specify exactly one of --template or --open-api or --proto or --tap or --grpc-reflect")
 	}
 
+	routeOptions, err := profiles.ParseRouteOptions(options.timeout, options.retryOn, options.retryBudget)
+	if err != nil {
+		return err
+	}
+	if !routeOptions.IsEmpty() && !options.template && options.openAPI == "" {
+		return errors.New("--timeout, --retry-on and --retry-budget can only be used with --template or --open-api")
+	}
+	options.routeOptions = routeOptions
+
 	// a DNS-1035 label must consist of lower case alphanumeric characters or '-',
 	// start with an alphabetic character, and end with an alphanumeric character
 	if errs := validation.IsDNS1035Label(options.name); len(errs) != 0 {
@@ -76,7 +112,7 @@ func newCmdProfile() *cobra.Command {
 	options := newProfileOptions()
 
 	cmd := &cobra.Command{
-		Use:   "profile [flags] (--template | --open-api file | --proto file | --tap resource) (SERVICE)",
+		Use:   "profile [flags] (--template | --open-api file | --proto file | --tap resource | --grpc-reflect resource:port) (SERVICE)",
 		Short: "Output service profile config for Kubernetes",
 		Long:  "Output service profile config for Kubernetes.",
 		Example: `  # Output a basic template to apply after modification.
@@ -85,11 +121,20 @@ func newCmdProfile() *cobra.Command {
   # Generate a profile from an OpenAPI specification.
   linkerd profile -n emojivoto --open-api web-svc.swagger web-svc
 
+  # Generate a profile from an OpenAPI specification, with a 300ms timeout on
+  # every route, and retries of the requests that fail with a 5xx status code.
+  linkerd profile -n emojivoto --open-api web-svc.swagger web-svc --timeout 300ms --retry-on 5xx --retry-budget retryRatio=0.1,ttl=30s
+
   # Generate a profile from a protobuf definition.
   linkerd profile -n emojivoto --proto Voting.proto vote-svc
 
-  # Generate a profile by watching live traffic based off tap data.
-  linkerd profile -n emojivoto web-svc --tap deploy/web --tap-duration 10s --tap-route-limit 5
+  # Generate a profile from the gRPC server reflection service of a running server.
+  linkerd profile -n emojivoto --grpc-reflect deploy/voting:8080 voting-svc
+
+  # Generate a profile by watching live traffic based off tap data. The path
+  # segments that look like IDs are collapsed into route templates, and each
+  # route gets a timeout of 3 times the p99 latency observed for it.
+  linkerd profile -n emojivoto web-svc --tap deploy/web --tap-duration 10s --tap-route-limit 5 --tap-timeout-factor 3
 `,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -116,13 +161,15 @@ func newCmdProfile() *cobra.Command {
 			}
 
 			if options.template {
-				return profiles.RenderProfileTemplate(options.namespace, options.name, clusterDomain, os.Stdout)
+				return profiles.RenderProfileTemplate(options.namespace, options.name, clusterDomain, options.routeOptions, os.Stdout)
 			} else if options.openAPI != "" {
-				return profiles.RenderOpenAPI(options.openAPI, options.namespace, options.name, clusterDomain, os.Stdout)
+				return profiles.RenderOpenAPI(options.openAPI, options.namespace, options.name, clusterDomain, options.routeOptions, os.Stdout)
 			} else if options.tap != "" {
-				return profiles.RenderTapOutputProfile(k8sAPI, options.tap, options.namespace, options.name, clusterDomain, options.tapDuration, int(options.tapRouteLimit), os.Stdout)
+				return profiles.RenderTapOutputProfile(k8sAPI, options.tap, options.namespace, options.name, clusterDomain, options.tapDuration, int(options.tapRouteLimit), options.tapTimeoutFactor, os.Stdout)
 			} else if options.proto != "" {
 				return profiles.RenderProto(options.proto, options.namespace, options.name, clusterDomain, os.Stdout)
+			} else if options.grpcReflect != "" {
+				return renderGRPCReflectProfile(k8sAPI, options, clusterDomain)
 			}
 
 			// we should never get here
@@ -131,12 +178,77 @@ func newCmdProfile() *cobra.Command {
 	}
 
 	cmd.PersistentFlags().BoolVar(&options.template, "template", options.template, "Output a service profile template")
-	cmd.PersistentFlags().StringVar(&options.openAPI, "open-api", options.openAPI, "Output a service profile based on the given OpenAPI spec file")
+	cmd.PersistentFlags().StringVar(&options.openAPI, "open-api", options.openAPI, "Output a service profile based on the given OpenAPI (Swagger 2.0 or OpenAPI 3.x) spec file")
 	cmd.PersistentFlags().StringVar(&options.tap, "tap", options.tap, "Output a service profile based on tap data for the given target resource")
 	cmd.PersistentFlags().DurationVar(&options.tapDuration, "tap-duration", options.tapDuration, "Duration over which tap data is collected (for example: \"10s\", \"1m\", \"10m\")")
 	cmd.PersistentFlags().UintVar(&options.tapRouteLimit, "tap-route-limit", options.tapRouteLimit, "Max number of routes to add to the profile")
+	cmd.PersistentFlags().Float64Var(&options.tapTimeoutFactor, "tap-timeout-factor", options.tapTimeoutFactor, "Set the timeout of each route to this factor times the p99 latency observed for the route (for example: 2); 0 doesn't set timeouts")
 	cmd.PersistentFlags().StringVarP(&options.namespace, "namespace", "n", options.namespace, "Namespace of the service")
 	cmd.PersistentFlags().StringVar(&options.proto, "proto", options.proto, "Output a service profile based on the given Protobuf spec file")
+	cmd.PersistentFlags().StringVar(&options.grpcReflect, "grpc-reflect", options.grpcReflect, "Output a service profile based on the gRPC server reflection service of the given target resource and port (for example: \"deploy/voting:8080\")")
+	cmd.PersistentFlags().StringVar(&options.timeout, "timeout", options.timeout, "Set this timeout on the routes of the --template or --open-api service profile (for example: \"250ms\")")
+	cmd.PersistentFlags().StringVar(&options.retryOn, "retry-on", options.retryOn, "Mark the routes of idempotent methods of the --template or --open-api service profile as retryable, unless their x-linkerd-retryable OpenAPI extension is set, and classify these comma-separated status codes as failures (for example: \"5xx\", \"503\", \"502-504\")")
+	cmd.PersistentFlags().StringVar(&options.retryBudget, "retry-budget", options.retryBudget, "Set the retry budget of the --template or --open-api service profile; comma-separated retryRatio, minRetriesPerSecond and ttl settings (for example: \"retryRatio=0.2,minRetriesPerSecond=10,ttl=10s\")")
 
 	return cmd
 }
+
+// parseGRPCReflectAuthority splits the value of --grpc-reflect into a resource
+// and a port.
+func parseGRPCReflectAuthority(authority string) (string, int, error) {
+	i := strings.LastIndex(authority, ":")
+	if i <= 0 {
+		return "", 0, fmt.Errorf("invalid --grpc-reflect %q: must be of the form resource:port", authority)
+	}
+
+	port, err := strconv.Atoi(authority[i+1:])
+	if err != nil || port <= 0 || port > 65535 {
+		return "", 0, fmt.Errorf("invalid --grpc-reflect %q: invalid port %q", authority, authority[i+1:])
+	}
+
+	return authority[:i], port, nil
+}
+
+// renderGRPCReflectProfile initiates a port-forward to the gRPC server of a pod
+// of the --grpc-reflect resource, and renders a profile with the services it
+// lists through its server reflection service.
+func renderGRPCReflectProfile(k8sAPI *k8s.KubernetesAPI, options *profileOptions, clusterDomain string) error {
+	resource, port, err := parseGRPCReflectAuthority(options.grpcReflect)
+	if err != nil {
+		return err
+	}
+
+	pods, err := getPodsFor(k8sAPI, options.namespace, resource)
+	if err != nil {
+		return err
+	}
+
+	var pod *corev1.Pod
+	for i := range pods {
+		if pods[i].Status.Phase == corev1.PodRunning {
+			pod = &pods[i]
+			break
+		}
+	}
+	if pod == nil {
+		return fmt.Errorf("no running pods found for %s", resource)
+	}
+
+	portForward, err := k8s.NewPodPortForward(k8sAPI, *pod, "localhost", 0, port, verbose)
+	if err != nil {
+		return err
+	}
+
+	defer portForward.Stop()
+	if err = portForward.Init(); err != nil {
+		return fmt.Errorf("error running port-forward: %s", err)
+	}
+
+	conn, err := grpc.Dial(portForward.Address(), grpc.WithInsecure())
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	return profiles.RenderGRPCReflection(rpb.NewServerReflectionClient(conn), options.namespace, options.name, clusterDomain, grpcReflectTimeout, os.Stdout)
+}
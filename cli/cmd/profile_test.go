@@ -14,7 +14,7 @@ import (
 func TestParseProfile(t *testing.T) {
 	var buf bytes.Buffer
 
-	err := profiles.RenderProfileTemplate("myns", "mysvc", "mycluster.local", &buf)
+	err := profiles.RenderProfileTemplate("myns", "mysvc", "mycluster.local", profiles.RouteOptions{}, &buf)
 	if err != nil {
 		t.Fatalf("Error rendering service profile template: %v", err)
 	}
@@ -35,7 +35,7 @@ func TestParseProfile(t *testing.T) {
 
 func TestValidateOptions(t *testing.T) {
 	options := newProfileOptions()
-	exp := errors.New("You must specify exactly one of --template or --open-api or --proto or --tap")
+	exp := errors.New("You must specify exactly one of --template or --open-api or --proto or --tap or --grpc-reflect")
 	err := options.validate()
 	if err == nil || err.Error() != exp.Error() {
 		t.Fatalf("validateOptions returned unexpected error: %s (expected: %s) for options: %+v", err, exp, options)
@@ -44,7 +44,7 @@ func TestValidateOptions(t *testing.T) {
 	options = newProfileOptions()
 	options.template = true
 	options.openAPI = "openAPI"
-	exp = errors.New("You must specify exactly one of --template or --open-api or --proto or --tap")
+	exp = errors.New("You must specify exactly one of --template or --open-api or --proto or --tap or --grpc-reflect")
 	err = options.validate()
 	if err == nil || err.Error() != exp.Error() {
 		t.Fatalf("validateOptions returned unexpected error: %s (expected: %s) for options: %+v", err, exp, options)
@@ -83,6 +83,63 @@ func TestValidateOptions(t *testing.T) {
 		t.Fatalf("validateOptions returned unexpected error (%s) for options: %+v", err, options)
 	}
 
+	options = newProfileOptions()
+	options.grpcReflect = "deploy/voting:8080"
+	options.name = "voting-svc"
+	err = options.validate()
+	if err != nil {
+		t.Fatalf("validateOptions returned unexpected error (%s) for options: %+v", err, options)
+	}
+
+	options = newProfileOptions()
+	options.grpcReflect = "deploy/voting"
+	options.name = "voting-svc"
+	exp = errors.New("invalid --grpc-reflect \"deploy/voting\": must be of the form resource:port")
+	err = options.validate()
+	if err == nil || err.Error() != exp.Error() {
+		t.Fatalf("validateOptions returned unexpected error: %s (expected: %s) for options: %+v", err, exp, options)
+	}
+
+	options = newProfileOptions()
+	options.grpcReflect = "deploy/voting:grpc"
+	options.name = "voting-svc"
+	exp = errors.New("invalid --grpc-reflect \"deploy/voting:grpc\": invalid port \"grpc\"")
+	err = options.validate()
+	if err == nil || err.Error() != exp.Error() {
+		t.Fatalf("validateOptions returned unexpected error: %s (expected: %s) for options: %+v", err, exp, options)
+	}
+
+	options = newProfileOptions()
+	options.template = true
+	options.name = "template-name"
+	options.timeout = "250ms"
+	options.retryOn = "5xx,429"
+	options.retryBudget = "retryRatio=0.1"
+	err = options.validate()
+	if err != nil {
+		t.Fatalf("validateOptions returned unexpected error (%s) for options: %+v", err, options)
+	}
+
+	options = newProfileOptions()
+	options.template = true
+	options.name = "template-name"
+	options.retryOn = "5yy"
+	exp = errors.New("invalid --retry-on \"5yy\": \"5yy\" is not a status code, a status code class or a status code range")
+	err = options.validate()
+	if err == nil || err.Error() != exp.Error() {
+		t.Fatalf("validateOptions returned unexpected error: %s (expected: %s) for options: %+v", err, exp, options)
+	}
+
+	options = newProfileOptions()
+	options.proto = "Voting.proto"
+	options.name = "voting-svc"
+	options.timeout = "250ms"
+	exp = errors.New("--timeout, --retry-on and --retry-budget can only be used with --template or --open-api")
+	err = options.validate()
+	if err == nil || err.Error() != exp.Error() {
+		t.Fatalf("validateOptions returned unexpected error: %s (expected: %s) for options: %+v", err, exp, options)
+	}
+
 	options = newProfileOptions()
 	options.template = true
 	options.name = "service.name"
@@ -0,0 +1,226 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const proxyLogLevelPath = "/proxy-log-level"
+
+type setLogLevelOptions struct {
+	namespace   string
+	revertAfter time.Duration
+}
+
+// logLevelResult holds the outcome of changing the log level of the proxy of
+// a pod.
+type logLevelResult struct {
+	pod      string
+	previous string
+	err      error
+}
+
+func newCmdProxy() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "proxy",
+		Short: "Manage the proxies of running pods",
+		Args:  cobra.NoArgs,
+	}
+
+	cmd.AddCommand(newCmdProxySetLogLevel())
+
+	return cmd
+}
+
+func newCmdProxySetLogLevel() *cobra.Command {
+	options := &setLogLevelOptions{
+		namespace: defaultNamespace,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "set-log-level [flags] (RESOURCE) (LEVEL)",
+		Short: "Change the log level of the proxies of a resource at runtime",
+		Long: `Change the log level of the proxies of a resource at runtime.
+
+  This command initiates a port-forward to the proxy admin port of each pod of
+  the given resource, and changes the log level of their proxies without
+  restarting the pods. The change is lost when a pod restarts.
+
+  The RESOURCE argument specifies the target resource (TYPE/NAME), like for
+  'linkerd metrics'. The LEVEL argument accepts the same values as the
+  --proxy-log-level flag of 'linkerd inject', like "debug" or
+  "warn,linkerd=debug".
+
+  With --revert-after, the command waits for the given duration, or until it
+  is interrupted, and then restores the log level each proxy had before.`,
+		Example: `  # Get the debug logs of the proxies of the web deployment.
+  linkerd proxy set-log-level -n emojivoto deploy/web debug
+
+  # Get the debug logs of the proxy of a pod for 5 minutes.
+  linkerd proxy set-log-level -n emojivoto po/web-5f86686c4d-58p7k warn,linkerd=debug --revert-after 5m`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			level := strings.TrimSpace(args[1])
+			if level == "" {
+				return errors.New("the log level must not be empty")
+			}
+
+			k8sAPI, err := k8s.NewAPI(kubeconfigPath, kubeContext, impersonate, impersonateGroup, 0)
+			if err != nil {
+				return err
+			}
+
+			pods, err := getPodsFor(k8sAPI, options.namespace, args[0])
+			if err != nil {
+				return err
+			}
+			if len(pods) == 0 {
+				return fmt.Errorf("no pods found for %s", args[0])
+			}
+
+			results := make([]logLevelResult, 0, len(pods))
+			for _, pod := range pods {
+				previous, err := setPodProxyLogLevel(k8sAPI, pod, level)
+				results = append(results, logLevelResult{pod: pod.GetName(), previous: previous, err: err})
+			}
+			renderLogLevelResults(results, level, os.Stdout)
+
+			if !anyLogLevelChanged(results) {
+				return errors.New("failed to change the log level of any proxy")
+			}
+
+			if options.revertAfter == 0 {
+				return nil
+			}
+
+			signals := make(chan os.Signal, 1)
+			signal.Notify(signals, os.Interrupt)
+			defer signal.Stop(signals)
+
+			fmt.Printf("\nReverting the log levels in %s; press Ctrl-C to revert them now\n", options.revertAfter)
+			select {
+			case <-time.After(options.revertAfter):
+			case <-signals:
+			}
+
+			// results are in the same order as pods
+			for i, result := range results {
+				if result.err != nil {
+					continue
+				}
+				if _, err := setPodProxyLogLevel(k8sAPI, pods[i], result.previous); err != nil {
+					fmt.Fprintf(os.Stderr, "Failed to revert the log level of %s: %s\n", result.pod, err)
+					continue
+				}
+				fmt.Printf("Reverted the log level of %s to %s\n", result.pod, result.previous)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.namespace, "namespace", "n", options.namespace, "Namespace of resource")
+	cmd.Flags().DurationVar(&options.revertAfter, "revert-after", options.revertAfter, "Restore the previous log levels after this duration; 0 keeps the new log level")
+
+	return cmd
+}
+
+// setPodProxyLogLevel initiates a port-forward to the proxy admin port of pod,
+// sets the log level of its proxy and returns the log level it had before.
+func setPodProxyLogLevel(k8sAPI *k8s.KubernetesAPI, pod corev1.Pod, level string) (string, error) {
+	if pod.Status.Phase != corev1.PodRunning {
+		return "", fmt.Errorf("pod not running: %s", pod.GetName())
+	}
+
+	proxy := findProxyContainer(pod)
+	if proxy == nil {
+		return "", fmt.Errorf("pod %s has no %s container", pod.GetName(), k8s.ProxyContainerName)
+	}
+
+	portForward, err := k8s.NewContainerMetricsForward(k8sAPI, pod, *proxy, verbose, k8s.ProxyAdminPortName)
+	if err != nil {
+		return "", err
+	}
+
+	defer portForward.Stop()
+	if err = portForward.Init(); err != nil {
+		return "", fmt.Errorf("error running port-forward: %s", err)
+	}
+
+	return setProxyLogLevel(portForward.URLFor(proxyLogLevelPath), level)
+}
+
+// setProxyLogLevel sets the log level of the proxy whose log level endpoint is
+// url, and returns the log level it had before.
+func setProxyLogLevel(url string, level string) (string, error) {
+	previous, err := proxyLogLevelRequest(http.MethodGet, url, "")
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := proxyLogLevelRequest(http.MethodPut, url, level); err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(previous), nil
+}
+
+func proxyLogLevelRequest(method, url, body string) (string, error) {
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+
+	rsp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer rsp.Body.Close()
+
+	rspBody, err := ioutil.ReadAll(rsp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
+		return "", fmt.Errorf("unexpected status %s: %s", rsp.Status, strings.TrimSpace(string(rspBody)))
+	}
+
+	return string(rspBody), nil
+}
+
+// anyLogLevelChanged returns true if the log level of at least one proxy was
+// changed.
+func anyLogLevelChanged(results []logLevelResult) bool {
+	for _, result := range results {
+		if result.err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+func renderLogLevelResults(results []logLevelResult, level string, w io.Writer) {
+	t := tabwriter.NewWriter(w, 0, 0, padding, ' ', 0)
+	fmt.Fprintln(t, strings.Join([]string{podHeader, "PREVIOUS", "LEVEL"}, "\t"))
+	for _, result := range results {
+		if result.err != nil {
+			fmt.Fprintf(t, "%s\t-\tERROR: %s\n", result.pod, result.err)
+			continue
+		}
+		fmt.Fprintf(t, "%s\t%s\t%s\n", result.pod, result.previous, level)
+	}
+	t.Flush()
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSetProxyLogLevel(t *testing.T) {
+	level := "warn,linkerd=info\n"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != proxyLogLevelPath {
+			http.NotFound(w, r)
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			w.Write([]byte(level))
+		case http.MethodPut:
+			body, _ := ioutil.ReadAll(r.Body)
+			if string(body) == "invalid=" {
+				http.Error(w, "invalid log level", http.StatusBadRequest)
+				return
+			}
+			level = string(body)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	previous, err := setProxyLogLevel(server.URL+proxyLogLevelPath, "debug")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if previous != "warn,linkerd=info" {
+		t.Errorf("Expected previous level %q, got %q", "warn,linkerd=info", previous)
+	}
+	if level != "debug" {
+		t.Errorf("Expected level %q, got %q", "debug", level)
+	}
+
+	_, err = setProxyLogLevel(server.URL+proxyLogLevelPath, "invalid=")
+	expectedErr := "unexpected status 400 Bad Request: invalid log level"
+	if err == nil || err.Error() != expectedErr {
+		t.Errorf("Expected error %q, got %v", expectedErr, err)
+	}
+}
+
+func TestRenderLogLevelResults(t *testing.T) {
+	results := []logLevelResult{
+		{pod: "web-1", previous: "warn,linkerd=info"},
+		{pod: "web-2", err: errors.New("pod not running: web-2")},
+	}
+
+	var buf bytes.Buffer
+	renderLogLevelResults(results, "debug", &buf)
+
+	expected := `POD     PREVIOUS            LEVEL
+web-1   warn,linkerd=info   debug
+web-2   -                   ERROR: pod not running: web-2
+`
+	if buf.String() != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, buf.String())
+	}
+}
+
+func TestAnyLogLevelChanged(t *testing.T) {
+	failed := logLevelResult{pod: "web-1", err: errors.New("pod not running: web-1")}
+	changed := logLevelResult{pod: "web-2", previous: "info"}
+
+	if anyLogLevelChanged([]logLevelResult{failed, failed}) {
+		t.Error("Expected no changed log level when every pod failed")
+	}
+	if !anyLogLevelChanged([]logLevelResult{failed, changed}) {
+		t.Error("Expected a changed log level when a pod succeeded")
+	}
+}
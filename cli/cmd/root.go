@@ -7,6 +7,7 @@ import (
 	"net"
 	"os"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -15,9 +16,13 @@ import (
 	"k8s.io/client-go/tools/clientcmd"
 
 	"github.com/fatih/color"
+	"github.com/linkerd/linkerd2/controller/api/util"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"github.com/linkerd/linkerd2/pkg/inject"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	k8sResource "k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/labels"
 )
 
 const (
@@ -26,13 +31,18 @@ const (
 	defaultClusterDomain    = "cluster.local"
 	defaultDockerRegistry   = "ghcr.io/linkerd"
 
-	jsonOutput  = "json"
-	tableOutput = "table"
-	wideOutput  = "wide"
+	jsonOutput      = "json"
+	tableOutput     = "table"
+	wideOutput      = "wide"
+	yamlOutput      = "yaml"
+	kustomizeOutput = "kustomize"
+	sarifOutput     = "sarif"
 
 	maxRps = 100.0
 )
 
+var defaultLatencyPercentiles = []float64{50, 95, 99}
+
 var (
 	// special handling for Windows, on all other platforms these resolve to
 	// os.Stdout and os.Stderr, thanks to https://github.com/mattn/go-colorable
@@ -113,17 +123,21 @@ func init() {
 	RootCmd.AddCommand(newCmdCompletion())
 	RootCmd.AddCommand(newCmdDashboard())
 	RootCmd.AddCommand(newCmdDiagnostics())
+	RootCmd.AddCommand(newCmdDoctor())
 	RootCmd.AddCommand(newCmdDoc())
 	RootCmd.AddCommand(newCmdEdges())
 	RootCmd.AddCommand(newCmdEndpoints())
 	RootCmd.AddCommand(newCmdGet())
+	RootCmd.AddCommand(newCmdIdentity())
 	RootCmd.AddCommand(newCmdInject())
 	RootCmd.AddCommand(newCmdInstall())
 	RootCmd.AddCommand(newCmdInstallCNIPlugin())
 	RootCmd.AddCommand(newCmdInstallSP())
 	RootCmd.AddCommand(newCmdLogs())
 	RootCmd.AddCommand(newCmdMetrics())
+	RootCmd.AddCommand(newCmdOperator())
 	RootCmd.AddCommand(newCmdProfile())
+	RootCmd.AddCommand(newCmdProxy())
 	RootCmd.AddCommand(newCmdRoutes())
 	RootCmd.AddCommand(newCmdStat())
 	RootCmd.AddCommand(newCmdTap())
@@ -138,7 +152,12 @@ func init() {
 type statOptionsBase struct {
 	namespace    string
 	timeWindow   string
+	startTime    string
+	endTime      string
+	step         string
 	outputFormat string
+	// latencyPercentiles replaces the p50, p95 and p99 latency columns
+	latencyPercentiles []string
 }
 
 func newStatOptionsBase() *statOptionsBase {
@@ -158,6 +177,84 @@ func (o *statOptionsBase) validateOutputFormat() error {
 	}
 }
 
+// requestTimeWindow returns the time window to request, which is left to the
+// public API when --start is set
+func (o *statOptionsBase) requestTimeWindow() string {
+	if o.startTime != "" {
+		return ""
+	}
+	return o.timeWindow
+}
+
+func (o *statOptionsBase) addTimeRangeFlags(flags *pflag.FlagSet) {
+	flags.StringVar(&o.startTime, "start", o.startTime, "Start of a past time window, as an RFC3339 time or a duration ago (for example: \"2020-06-01T10:00:00Z\" or \"24h\"); replaces \"--time-window\"")
+	flags.StringVar(&o.endTime, "end", o.endTime, "End of a past time window, as an RFC3339 time or a duration ago (for example: \"2020-06-01T12:00:00Z\" or \"2h\"); by default the time window ends now")
+	flags.StringVar(&o.step, "step", o.step, "If present, evaluates the time window in steps of this duration (for example: \"5m\"), which is cheaper over long time windows")
+}
+
+func (o *statOptionsBase) addLatencyPercentilesFlag(flags *pflag.FlagSet) {
+	flags.StringSliceVar(&o.latencyPercentiles, "latency-percentiles", o.latencyPercentiles, "Latency percentiles to display instead of p50, p95 and p99 (for example: \"p50,p90,p99.9\")")
+}
+
+// latencyColumns returns the percentiles of the latency columns
+func (o *statOptionsBase) latencyColumns() []float64 {
+	percentiles, err := util.ParseLatencyPercentiles(o.latencyPercentiles)
+	if err != nil || len(percentiles) == 0 {
+		return defaultLatencyPercentiles
+	}
+	return percentiles
+}
+
+// latencyHeaders returns the headers of the latency columns, e.g. LATENCY_P99
+func (o *statOptionsBase) latencyHeaders() []string {
+	headers := []string{}
+	for _, percentile := range o.latencyColumns() {
+		headers = append(headers, "LATENCY_P"+util.FormatPercentile(percentile))
+	}
+	return headers
+}
+
+// latencies returns the latencies of the latency columns
+func (o *statOptionsBase) latencies(stats *pb.BasicStats) []uint64 {
+	latencies := []uint64{}
+	for _, percentile := range o.latencyColumns() {
+		latencies = append(latencies, latencyMs(stats, percentile))
+	}
+	return latencies
+}
+
+// latencyMs returns the latency of a percentile, which is either one of the
+// requested percentiles or p50, p95 or p99
+func latencyMs(stats *pb.BasicStats, percentile float64) uint64 {
+	for _, latency := range stats.GetLatencyMsPercentiles() {
+		if latency.GetPercentile() == percentile {
+			return latency.GetLatencyMs()
+		}
+	}
+	switch percentile {
+	case 50:
+		return stats.GetLatencyMsP50()
+	case 95:
+		return stats.GetLatencyMsP95()
+	case 99:
+		return stats.GetLatencyMsP99()
+	}
+	return 0
+}
+
+// latencyJSON returns the latencies of the latency columns keyed by
+// percentile, e.g. "p99.9", when --latency-percentiles is set
+func (o *statOptionsBase) latencyJSON(latencies []uint64) map[string]uint64 {
+	if len(o.latencyPercentiles) == 0 {
+		return nil
+	}
+	entries := map[string]uint64{}
+	for i, percentile := range o.latencyColumns() {
+		entries["p"+util.FormatPercentile(percentile)] = latencies[i]
+	}
+	return entries
+}
+
 func renderStats(buffer bytes.Buffer, options *statOptionsBase) string {
 	var out string
 	switch options.outputFormat {
@@ -241,6 +338,7 @@ type proxyConfigOptions struct {
 	proxyMemoryRequest            string
 	proxyCPULimit                 string
 	proxyMemoryLimit              string
+	proxyResourcesProfile         string
 	enableExternalProfiles        bool
 	traceCollector                string
 	traceCollectorSvcAccount      string
@@ -251,6 +349,10 @@ type proxyConfigOptions struct {
 	disableTap                    bool
 	inboundConnectTimeout         string
 	outboundConnectTimeout        string
+	outboundConnectBackoffMin     string
+	outboundConnectBackoffMax     string
+	outboundConnectBackoffJitter  string
+	excludedNodeSelector          string
 }
 
 func (options *proxyConfigOptions) validate() error {
@@ -319,11 +421,21 @@ func (options *proxyConfigOptions) validate() error {
 		}
 	}
 
+	if options.proxyResourcesProfile != "" {
+		if _, ok := inject.ProxyResourceProfiles[options.proxyResourcesProfile]; !ok {
+			return fmt.Errorf("--proxy-resources-profile must be one of: %s", strings.Join(inject.ProxyResourceProfileNames, ", "))
+		}
+	}
+
 	if options.proxyLogLevel != "" && !validProxyLogLevel.MatchString(options.proxyLogLevel) {
 		return fmt.Errorf("\"%s\" is not a valid proxy log level - for allowed syntax check https://docs.rs/env_logger/0.6.0/env_logger/#enabling-logging",
 			options.proxyLogLevel)
 	}
 
+	if options.proxyLogFormat != "" && options.proxyLogFormat != "plain" && options.proxyLogFormat != "json" {
+		return fmt.Errorf("--proxy-log-format must be one of: plain, json")
+	}
+
 	if err := validateRangeSlice(options.ignoreInboundPorts); err != nil {
 		return err
 	}
@@ -332,9 +444,63 @@ func (options *proxyConfigOptions) validate() error {
 		return err
 	}
 
+	if options.outboundConnectTimeout != "" {
+		if _, err := time.ParseDuration(options.outboundConnectTimeout); err != nil {
+			return fmt.Errorf("Invalid duration '%s' for --proxy-connect-timeout flag", options.outboundConnectTimeout)
+		}
+	}
+
+	var backoffMin, backoffMax time.Duration
+	if options.outboundConnectBackoffMin != "" {
+		var err error
+		if backoffMin, err = time.ParseDuration(options.outboundConnectBackoffMin); err != nil {
+			return fmt.Errorf("Invalid duration '%s' for --proxy-connect-backoff-min flag", options.outboundConnectBackoffMin)
+		}
+	}
+	if options.outboundConnectBackoffMax != "" {
+		var err error
+		if backoffMax, err = time.ParseDuration(options.outboundConnectBackoffMax); err != nil {
+			return fmt.Errorf("Invalid duration '%s' for --proxy-connect-backoff-max flag", options.outboundConnectBackoffMax)
+		}
+	}
+	if backoffMin != 0 && backoffMax != 0 && backoffMin > backoffMax {
+		return fmt.Errorf("The connect backoff max '%s' cannot be lower than the connect backoff min '%s'", options.outboundConnectBackoffMax, options.outboundConnectBackoffMin)
+	}
+
+	if options.outboundConnectBackoffJitter != "" {
+		if jitter, err := strconv.ParseFloat(options.outboundConnectBackoffJitter, 64); err != nil || jitter < 0 || jitter > 1 {
+			return fmt.Errorf("Invalid jitter '%s' for --proxy-connect-backoff-jitter flag: must be a ratio between 0 and 1", options.outboundConnectBackoffJitter)
+		}
+	}
+
+	if _, err := labels.Parse(options.excludedNodeSelector); err != nil {
+		return fmt.Errorf("Invalid selector '%s' for --excluded-node-selector flag: %s", options.excludedNodeSelector, err)
+	}
+
 	return nil
 }
 
+// applyProxyResourcesProfile sets the proxy resources that weren't set
+// explicitly to the ones of the proxy resources profile, if any
+func (options *proxyConfigOptions) applyProxyResourcesProfile() {
+	profile, ok := inject.ProxyResourceProfiles[options.proxyResourcesProfile]
+	if !ok {
+		return
+	}
+	if options.proxyCPURequest == "" {
+		options.proxyCPURequest = profile.CPU.Request
+	}
+	if options.proxyCPULimit == "" {
+		options.proxyCPULimit = profile.CPU.Limit
+	}
+	if options.proxyMemoryRequest == "" {
+		options.proxyMemoryRequest = profile.Memory.Request
+	}
+	if options.proxyMemoryLimit == "" {
+		options.proxyMemoryLimit = profile.Memory.Limit
+	}
+}
+
 // registryOverride replaces the registry of the provided image if the image is
 // using the default registry and the provided registry is not the default.
 func registryOverride(image, registry string) string {
@@ -355,13 +521,23 @@ func (options *proxyConfigOptions) flagSet(e pflag.ErrorHandling) *pflag.FlagSet
 	flags.StringSliceVar(&options.ignoreOutboundPorts, "skip-outbound-ports", options.ignoreOutboundPorts, "Outbound ports and/or port ranges (inclusive) that should skip the proxy")
 	flags.Int64Var(&options.proxyUID, "proxy-uid", options.proxyUID, "Run the proxy under this user ID")
 	flags.StringVar(&options.proxyLogLevel, "proxy-log-level", options.proxyLogLevel, "Log level for the proxy")
+	flags.StringVar(&options.proxyLogFormat, "proxy-log-format", options.proxyLogFormat, "Log format (plain or json) for the proxy")
 	flags.UintVar(&options.proxyControlPort, "control-port", options.proxyControlPort, "Proxy port to use for control")
 	flags.UintVar(&options.proxyAdminPort, "admin-port", options.proxyAdminPort, "Proxy port to serve metrics on")
 	flags.StringVar(&options.proxyCPURequest, "proxy-cpu-request", options.proxyCPURequest, "Amount of CPU units that the proxy sidecar requests")
 	flags.StringVar(&options.proxyMemoryRequest, "proxy-memory-request", options.proxyMemoryRequest, "Amount of Memory that the proxy sidecar requests")
 	flags.StringVar(&options.proxyCPULimit, "proxy-cpu-limit", options.proxyCPULimit, "Maximum amount of CPU units that the proxy sidecar can use")
 	flags.StringVar(&options.proxyMemoryLimit, "proxy-memory-limit", options.proxyMemoryLimit, "Maximum amount of Memory that the proxy sidecar can use")
+	flags.StringVar(&options.proxyResourcesProfile, "proxy-resources-profile", options.proxyResourcesProfile,
+		fmt.Sprintf("Sizing profile of the proxy resources, one of: %s; the --proxy-cpu-* and --proxy-memory-* flags take precedence over it", strings.Join(inject.ProxyResourceProfileNames, ", ")))
 	flags.BoolVar(&options.enableExternalProfiles, "enable-external-profiles", options.enableExternalProfiles, "Enable service profiles for non-Kubernetes services")
+	flags.StringVar(&options.outboundConnectTimeout, "proxy-connect-timeout", options.outboundConnectTimeout,
+		"Maximum time allowed for the proxy to establish an outbound TCP connection. This only bounds connection establishment; per-route timeouts set in ServiceProfiles bound the whole request, including any connect time and retries")
+	flags.StringVar(&options.outboundConnectBackoffMin, "proxy-connect-backoff-min", options.outboundConnectBackoffMin, "Minimum time the proxy waits before retrying a failed outbound connection (the proxy's default is used if empty)")
+	flags.StringVar(&options.outboundConnectBackoffMax, "proxy-connect-backoff-max", options.outboundConnectBackoffMax, "Maximum time the proxy waits before retrying a failed outbound connection (the proxy's default is used if empty)")
+	flags.StringVar(&options.outboundConnectBackoffJitter, "proxy-connect-backoff-jitter", options.outboundConnectBackoffJitter, "Ratio of random jitter applied to the outbound connection backoff, between 0 and 1, e.g. 0.1 (the proxy's default is used if empty)")
+	flags.StringVar(&options.excludedNodeSelector, "excluded-node-selector", options.excludedNodeSelector,
+		"Label selector of the nodes the proxy isn't injected on, matched against the nodeSelector of the pods, e.g. 'example.com/no-mesh=true' (the pods targeting Windows nodes are never injected)")
 
 	// Deprecated flags
 	flags.StringVar(&options.proxyMemoryRequest, "proxy-memory", options.proxyMemoryRequest, "Amount of Memory that the proxy sidecar requests")
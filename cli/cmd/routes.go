@@ -27,9 +27,12 @@ type routesOptions struct {
 
 type routeRowStats struct {
 	rowStats
-	actualRequestRate float64
-	actualSuccessRate float64
-	hasRequestData    bool
+	actualRequestRate  float64
+	actualSuccessRate  float64
+	actualSuccessCount uint64
+	actualFailureCount uint64
+	timeWindow         string
+	hasRequestData     bool
 }
 
 func newRoutesOptions() *routesOptions {
@@ -49,12 +52,25 @@ func newCmdRoutes() *cobra.Command {
 		Short: "Display route stats",
 		Long: `Display route stats.
 
-This command will only display traffic which is sent to a service that has a Service Profile defined.`,
+This command will only display traffic which is sent to a service that has a Service Profile defined.
+
+The JSON output (-o json) is a stable interface for scripts: an object mapping
+each resource to an array with an object per route, with the fields route,
+authority, time_window, success (the success rate, between 0 and 1),
+success_count, failure_count (the raw counts of responses in the time window),
+rps, latency_ms_p50, latency_ms_p95 and latency_ms_p99. With --to, the success
+and rps fields are replaced by their effective_ (after retries) and actual_
+(including retries) variants. With --latency-percentiles, the latency_ms_
+fields are replaced by latency_ms_percentiles, an object mapping each
+percentile (e.g. "p99.9") to its latency.`,
 		Example: `  # Routes for the webapp service in the test namespace.
   linkerd routes service/webapp -n test
 
   # Routes for calls from the traffic deployment to the webapp service in the test namespace.
-  linkerd routes deploy/traffic -n test --to svc/webapp`,
+  linkerd routes deploy/traffic -n test --to svc/webapp
+
+  # Routes for the webapp service in the test namespace on June 1st 2020, in steps of 10 minutes.
+  linkerd routes service/webapp -n test --start 2020-06-01T00:00:00Z --end 2020-06-02T00:00:00Z --step 10m`,
 		Args:      cobra.ExactArgs(1),
 		ValidArgs: util.ValidTargets,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -76,6 +92,8 @@ This command will only display traffic which is sent to a service that has a Ser
 
 	cmd.PersistentFlags().StringVarP(&options.namespace, "namespace", "n", options.namespace, "Namespace of the specified resource")
 	cmd.PersistentFlags().StringVarP(&options.timeWindow, "time-window", "t", options.timeWindow, "Stat window (for example: \"10s\", \"1m\", \"10m\", \"1h\")")
+	options.addTimeRangeFlags(cmd.PersistentFlags())
+	options.addLatencyPercentilesFlag(cmd.PersistentFlags())
 	cmd.PersistentFlags().StringVar(&options.toResource, "to", options.toResource, "If present, shows outbound stats to the specified resource")
 	cmd.PersistentFlags().StringVar(&options.toNamespace, "to-namespace", options.toNamespace, "Sets the namespace used to lookup the \"--to\" resource; by default the current \"--namespace\" is used")
 	cmd.PersistentFlags().StringVarP(&options.outputFormat, "output", "o", options.outputFormat, fmt.Sprintf("Output format; one of: \"%s\", \"%s\", or \"%s\"", tableOutput, wideOutput, jsonOutput))
@@ -118,17 +136,23 @@ func writeRouteStatsToBuffer(resp *pb.TopRoutesResponse, w *tabwriter.Writer, op
 				route := r.GetRoute()
 				table = append(table, &routeRowStats{
 					rowStats: rowStats{
-						route:       route,
-						dst:         r.GetAuthority(),
-						requestRate: getRequestRate(r.Stats.GetSuccessCount(), r.Stats.GetFailureCount(), r.TimeWindow),
-						successRate: getSuccessRate(r.Stats.GetSuccessCount(), r.Stats.GetFailureCount()),
-						latencyP50:  r.Stats.LatencyMsP50,
-						latencyP95:  r.Stats.LatencyMsP95,
-						latencyP99:  r.Stats.LatencyMsP99,
+						route:        route,
+						dst:          r.GetAuthority(),
+						requestRate:  getRequestRate(r.Stats.GetSuccessCount(), r.Stats.GetFailureCount(), r.TimeWindow),
+						successRate:  getSuccessRate(r.Stats.GetSuccessCount(), r.Stats.GetFailureCount()),
+						successCount: r.Stats.GetSuccessCount(),
+						failureCount: r.Stats.GetFailureCount(),
+						latencyP50:   r.Stats.LatencyMsP50,
+						latencyP95:   r.Stats.LatencyMsP95,
+						latencyP99:   r.Stats.LatencyMsP99,
+						latencies:    options.latencies(r.Stats),
 					},
-					actualRequestRate: getRequestRate(r.Stats.GetActualSuccessCount(), r.Stats.GetActualFailureCount(), r.TimeWindow),
-					actualSuccessRate: getSuccessRate(r.Stats.GetActualSuccessCount(), r.Stats.GetActualFailureCount()),
-					hasRequestData:    statHasRequestData(r.Stats),
+					actualRequestRate:  getRequestRate(r.Stats.GetActualSuccessCount(), r.Stats.GetActualFailureCount(), r.TimeWindow),
+					actualSuccessRate:  getSuccessRate(r.Stats.GetActualSuccessCount(), r.Stats.GetActualFailureCount()),
+					actualSuccessCount: r.Stats.GetActualSuccessCount(),
+					actualFailureCount: r.Stats.GetActualFailureCount(),
+					timeWindow:         r.TimeWindow,
+					hasRequestData:     statHasRequestData(r.Stats),
 				})
 			}
 		}
@@ -188,11 +212,8 @@ func printRouteTable(stats []*routeRowStats, w *tabwriter.Writer, options *route
 		}...)
 	}
 
-	headers = append(headers, []string{
-		"LATENCY_P50",
-		"LATENCY_P95",
-		"LATENCY_P99\t", // trailing \t is required to format last column
-	}...)
+	headers = append(headers, options.latencyHeaders()...)
+	headers[len(headers)-1] = headers[len(headers)-1] + "\t" // trailing \t is required to format last column
 
 	fmt.Fprintln(w, strings.Join(headers, "\t"))
 
@@ -202,14 +223,15 @@ func printRouteTable(stats []*routeRowStats, w *tabwriter.Writer, options *route
 		// actual success rate, actual rps
 		templateString = templateString + "%.2f%%\t%.1frps\t"
 	}
-	// p50, p95, p99
-	templateString = templateString + "%dms\t%dms\t%dms\t\n"
+	// latencies
+	latencyColumns := len(options.latencyColumns())
+	templateString = templateString + strings.Repeat("%dms\t", latencyColumns) + "\n"
 
 	var emptyTemplateString string
 	if outputActual {
-		emptyTemplateString = routeTemplate + "\t%s\t-\t-\t-\t-\t-\t-\t-\t\n"
+		emptyTemplateString = routeTemplate + "\t%s\t-\t-\t-\t-\t" + strings.Repeat("-\t", latencyColumns) + "\n"
 	} else {
-		emptyTemplateString = routeTemplate + "\t%s\t-\t-\t-\t-\t-\t\n"
+		emptyTemplateString = routeTemplate + "\t%s\t-\t-\t" + strings.Repeat("-\t", latencyColumns) + "\n"
 	}
 
 	for _, row := range stats {
@@ -231,11 +253,9 @@ func printRouteTable(stats []*routeRowStats, w *tabwriter.Writer, options *route
 					row.actualRequestRate,
 				}...)
 			}
-			values = append(values, []interface{}{
-				row.latencyP50,
-				row.latencyP95,
-				row.latencyP99,
-			}...)
+			for _, latency := range row.latencies {
+				values = append(values, latency)
+			}
 
 			fmt.Fprintf(w, templateString, values...)
 		} else {
@@ -247,17 +267,25 @@ func printRouteTable(stats []*routeRowStats, w *tabwriter.Writer, options *route
 // JSONRouteStats represents the JSON output of the routes command
 // Using pointers there where the value is NA and the corresponding json is null
 type JSONRouteStats struct {
-	Route            string   `json:"route"`
-	Authority        string   `json:"authority"`
-	Success          *float64 `json:"success,omitempty"`
-	Rps              *float64 `json:"rps,omitempty"`
-	EffectiveSuccess *float64 `json:"effective_success,omitempty"`
-	EffectiveRps     *float64 `json:"effective_rps,omitempty"`
-	ActualSuccess    *float64 `json:"actual_success,omitempty"`
-	ActualRps        *float64 `json:"actual_rps,omitempty"`
-	LatencyMSp50     *uint64  `json:"latency_ms_p50"`
-	LatencyMSp95     *uint64  `json:"latency_ms_p95"`
-	LatencyMSp99     *uint64  `json:"latency_ms_p99"`
+	Route                 string            `json:"route"`
+	Authority             string            `json:"authority"`
+	TimeWindow            string            `json:"time_window"`
+	Success               *float64          `json:"success,omitempty"`
+	SuccessCount          *uint64           `json:"success_count,omitempty"`
+	FailureCount          *uint64           `json:"failure_count,omitempty"`
+	Rps                   *float64          `json:"rps,omitempty"`
+	EffectiveSuccess      *float64          `json:"effective_success,omitempty"`
+	EffectiveSuccessCount *uint64           `json:"effective_success_count,omitempty"`
+	EffectiveFailureCount *uint64           `json:"effective_failure_count,omitempty"`
+	EffectiveRps          *float64          `json:"effective_rps,omitempty"`
+	ActualSuccess         *float64          `json:"actual_success,omitempty"`
+	ActualSuccessCount    *uint64           `json:"actual_success_count,omitempty"`
+	ActualFailureCount    *uint64           `json:"actual_failure_count,omitempty"`
+	ActualRps             *float64          `json:"actual_rps,omitempty"`
+	LatencyMSp50          *uint64           `json:"latency_ms_p50"`
+	LatencyMSp95          *uint64           `json:"latency_ms_p95"`
+	LatencyMSp99          *uint64           `json:"latency_ms_p99"`
+	LatencyMS             map[string]uint64 `json:"latency_ms_percentiles,omitempty"`
 }
 
 func printRouteJSON(tables map[string][]*routeRowStats, w *tabwriter.Writer, options *routesOptions) {
@@ -267,22 +295,32 @@ func printRouteJSON(tables map[string][]*routeRowStats, w *tabwriter.Writer, opt
 		for _, row := range table {
 			route := row.route
 			entry := &JSONRouteStats{
-				Route: route,
+				Route:      route,
+				TimeWindow: row.timeWindow,
 			}
 
 			entry.Authority = row.dst
 			if options.toResource != "" {
 				entry.EffectiveSuccess = &row.successRate
+				entry.EffectiveSuccessCount = &row.successCount
+				entry.EffectiveFailureCount = &row.failureCount
 				entry.EffectiveRps = &row.requestRate
 				entry.ActualSuccess = &row.actualSuccessRate
+				entry.ActualSuccessCount = &row.actualSuccessCount
+				entry.ActualFailureCount = &row.actualFailureCount
 				entry.ActualRps = &row.actualRequestRate
 			} else {
 				entry.Success = &row.successRate
+				entry.SuccessCount = &row.successCount
+				entry.FailureCount = &row.failureCount
 				entry.Rps = &row.requestRate
 			}
-			entry.LatencyMSp50 = &row.latencyP50
-			entry.LatencyMSp95 = &row.latencyP95
-			entry.LatencyMSp99 = &row.latencyP99
+			entry.LatencyMS = options.latencyJSON(row.latencies)
+			if entry.LatencyMS == nil {
+				entry.LatencyMSp50 = &row.latencyP50
+				entry.LatencyMSp95 = &row.latencyP95
+				entry.LatencyMSp99 = &row.latencyP99
+			}
 
 			entries[resource] = append(entries[resource], entry)
 		}
@@ -322,10 +360,14 @@ func buildTopRoutesRequest(resource string, options *routesOptions) (*pb.TopRout
 
 	requestParams := util.TopRoutesRequestParams{
 		StatsBaseRequestParams: util.StatsBaseRequestParams{
-			TimeWindow:   options.timeWindow,
-			ResourceName: target.Name,
-			ResourceType: target.Type,
-			Namespace:    options.namespace,
+			TimeWindow:         options.requestTimeWindow(),
+			StartTime:          options.startTime,
+			EndTime:            options.endTime,
+			Step:               options.step,
+			LatencyPercentiles: options.latencyPercentiles,
+			ResourceName:       target.Name,
+			ResourceType:       target.Type,
+			Namespace:          options.namespace,
 		},
 		LabelSelector: options.labelSelector,
 	}
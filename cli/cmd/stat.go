@@ -27,6 +27,11 @@ type statOptions struct {
 	allNamespaces bool
 	labelSelector string
 	unmeshed      bool
+	watch         bool
+	watchInterval time.Duration
+
+	// history is set in watch mode, to render the trends of the stats
+	history *statHistory
 }
 
 type indexedResults struct {
@@ -45,6 +50,8 @@ func newStatOptions() *statOptions {
 		allNamespaces:   false,
 		labelSelector:   "",
 		unmeshed:        false,
+		watch:           false,
+		watchInterval:   5 * time.Second,
 	}
 }
 
@@ -96,7 +103,23 @@ func newCmdStat() *cobra.Command {
   * all (all resource types, not supported in --from or --to)
 
 This command will hide resources that have completed, such as pods that are in the Succeeded or Failed phases.
-If no resource name is specified, displays stats about all resources of the specified RESOURCETYPE`,
+If no resource name is specified, displays stats about all resources of the specified RESOURCETYPE
+
+The JSON output (-o json) is a stable interface for scripts: an array with an
+object per resource, with the fields namespace, kind, name, meshed,
+time_window, success (the success rate, between 0 and 1), success_count,
+failure_count (the raw counts of responses in the time window), rps,
+latency_ms_p50, latency_ms_p95, latency_ms_p99, tcp_open_connections,
+tcp_tls_connections (the open connections secured with mTLS),
+tcp_read_bytes_rate and tcp_write_bytes_rate, and apex, leaf and weight for
+traffic splits. The stats are null for resources without traffic, and the
+HTTP stats are null for resources with TCP traffic only. With
+--latency-percentiles, the latency_ms_ fields are replaced by
+latency_ms_percentiles, an object mapping each percentile (e.g. "p99.9") to
+its latency.
+
+Past time windows can be queried with --start and --end, which take an RFC3339
+time or a duration ago; --from and --to select resources, not times.`,
 		Example: `  # Get all deployments in the test namespace.
   linkerd stat deployments -n test
 
@@ -118,6 +141,9 @@ If no resource name is specified, displays stats about all resources of the spec
   # Get all pods in all namespaces that call the hello1 deployment in the test namespace.
   linkerd stat pods --to deploy/hello1 --to-namespace test --all-namespaces
 
+  # Get all deployments in the test namespace over the day before the last 2 hours, in steps of 5 minutes.
+  linkerd stat deployments -n test --start 26h --end 2h --step 5m
+
   # Get all pods in all namespaces that call the hello1 service in the test namespace.
   linkerd stat pods --to svc/hello1 --to-namespace test --all-namespaces
 
@@ -137,7 +163,10 @@ If no resource name is specified, displays stats about all resources of the spec
   linkerd stat namespaces --from ns/default
 
   # Get all inbound stats to the test namespace.
-  linkerd stat ns/test`,
+  linkerd stat ns/test
+
+  # Watch the stats of the deployments in the test namespace, refreshed every 2 seconds.
+  linkerd stat deploy -n test --watch --watch-interval 2s`,
 		Args:      cobra.MinimumNArgs(1),
 		ValidArgs: util.ValidTargets,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -149,25 +178,17 @@ If no resource name is specified, displays stats about all resources of the spec
 			// The gRPC client is concurrency-safe, so we can reuse it in all the following goroutines
 			// https://github.com/grpc/grpc-go/issues/682
 			client := checkPublicAPIClientOrExit()
-			c := make(chan indexedResults, len(reqs))
-			for num, req := range reqs {
-				go func(num int, req *pb.StatSummaryRequest) {
-					resp, err := requestStatsFromAPI(client, req)
-					rows := respToRows(resp)
-					c <- indexedResults{num, rows, err}
-				}(num, req)
+			fetch := func() ([]*pb.StatTable_PodGroup_Row, error) {
+				return requestAllStatsFromAPI(client, reqs)
 			}
 
-			totalRows := make([]*pb.StatTable_PodGroup_Row, 0)
-			i := 0
-			for res := range c {
-				if res.err != nil {
-					return res.err
-				}
-				totalRows = append(totalRows, res.rows...)
-				if i++; i == len(reqs) {
-					close(c)
-				}
+			if options.watch {
+				return watchStats(os.Stdout, options.watchInterval, options, fetch)
+			}
+
+			totalRows, err := fetch()
+			if err != nil {
+				return err
 			}
 
 			output := renderStatStats(totalRows, options)
@@ -179,6 +200,8 @@ If no resource name is specified, displays stats about all resources of the spec
 
 	cmd.PersistentFlags().StringVarP(&options.namespace, "namespace", "n", options.namespace, "Namespace of the specified resource")
 	cmd.PersistentFlags().StringVarP(&options.timeWindow, "time-window", "t", options.timeWindow, "Stat window (for example: \"15s\", \"1m\", \"10m\", \"1h\"). Needs to be at least 15s.")
+	options.addTimeRangeFlags(cmd.PersistentFlags())
+	options.addLatencyPercentilesFlag(cmd.PersistentFlags())
 	cmd.PersistentFlags().StringVar(&options.toResource, "to", options.toResource, "If present, restricts outbound stats to the specified resource name")
 	cmd.PersistentFlags().StringVar(&options.toNamespace, "to-namespace", options.toNamespace, "Sets the namespace used to lookup the \"--to\" resource; by default the current \"--namespace\" is used")
 	cmd.PersistentFlags().StringVar(&options.fromResource, "from", options.fromResource, "If present, restricts outbound stats from the specified resource name")
@@ -187,6 +210,8 @@ If no resource name is specified, displays stats about all resources of the spec
 	cmd.PersistentFlags().StringVarP(&options.outputFormat, "output", "o", options.outputFormat, "Output format; one of: \"table\" or \"json\" or \"wide\"")
 	cmd.PersistentFlags().StringVarP(&options.labelSelector, "selector", "l", options.labelSelector, "Selector (label query) to filter on, supports '=', '==', and '!='")
 	cmd.PersistentFlags().BoolVar(&options.unmeshed, "unmeshed", options.unmeshed, "If present, include unmeshed resources in the output")
+	cmd.PersistentFlags().BoolVarP(&options.watch, "watch", "w", options.watch, "If present, refresh the stats in place, along with the trends of the success rate and RPS")
+	cmd.PersistentFlags().DurationVar(&options.watchInterval, "watch-interval", options.watchInterval, "Interval between refreshes in watch mode")
 	return cmd
 }
 
@@ -200,6 +225,30 @@ func respToRows(resp *pb.StatSummaryResponse) []*pb.StatTable_PodGroup_Row {
 	return rows
 }
 
+// requestAllStatsFromAPI sends the requests concurrently and returns all the
+// rows of their responses
+func requestAllStatsFromAPI(client pb.ApiClient, reqs []*pb.StatSummaryRequest) ([]*pb.StatTable_PodGroup_Row, error) {
+	c := make(chan indexedResults, len(reqs))
+	for num, req := range reqs {
+		go func(num int, req *pb.StatSummaryRequest) {
+			resp, err := requestStatsFromAPI(client, req)
+			rows := respToRows(resp)
+			c <- indexedResults{num, rows, err}
+		}(num, req)
+	}
+
+	totalRows := make([]*pb.StatTable_PodGroup_Row, 0)
+	for range reqs {
+		res := <-c
+		if res.err != nil {
+			return nil, res.err
+		}
+		totalRows = append(totalRows, res.rows...)
+	}
+
+	return totalRows, nil
+}
+
 func requestStatsFromAPI(client pb.ApiClient, req *pb.StatSummaryRequest) (*pb.StatSummaryResponse, error) {
 	resp, err := client.StatSummary(context.Background(), req)
 	if err != nil {
@@ -224,21 +273,31 @@ func renderStatStats(rows []*pb.StatTable_PodGroup_Row, options *statOptions) st
 const padding = 3
 
 type rowStats struct {
-	route              string
-	dst                string
-	requestRate        float64
-	successRate        float64
-	latencyP50         uint64
-	latencyP95         uint64
-	latencyP99         uint64
+	route        string
+	dst          string
+	requestRate  float64
+	successRate  float64
+	successCount uint64
+	failureCount uint64
+	latencyP50   uint64
+	latencyP95   uint64
+	latencyP99   uint64
+	// latencies are the latencies of the latency columns
+	latencies          []uint64
 	tcpOpenConnections uint64
+	tcpTLSConnections  uint64
 	tcpReadBytes       float64
 	tcpWriteBytes      float64
+	// requestData is false for resources with TCP traffic only
+	requestData  bool
+	successTrend string
+	requestTrend string
 }
 
 type row struct {
-	meshed string
-	status string
+	meshed     string
+	status     string
+	timeWindow string
 	*rowStats
 	*tsStats
 }
@@ -326,20 +385,32 @@ func writeStatsToBuffer(rows []*pb.StatTable_PodGroup_Row, w *tabwriter.Writer,
 			meshedCount = "-"
 		}
 		statTables[resourceKey][key] = &row{
-			meshed: meshedCount,
-			status: r.Status,
+			meshed:     meshedCount,
+			status:     r.Status,
+			timeWindow: r.TimeWindow,
 		}
 
-		if r.Stats != nil && statHasRequestData(r.Stats) {
+		requestData := r.Stats != nil && statHasRequestData(r.Stats)
+		if requestData || r.GetTcpStats() != nil {
 			statTables[resourceKey][key].rowStats = &rowStats{
 				requestRate:        getRequestRate(r.Stats.GetSuccessCount(), r.Stats.GetFailureCount(), r.TimeWindow),
 				successRate:        getSuccessRate(r.Stats.GetSuccessCount(), r.Stats.GetFailureCount()),
-				latencyP50:         r.Stats.LatencyMsP50,
-				latencyP95:         r.Stats.LatencyMsP95,
-				latencyP99:         r.Stats.LatencyMsP99,
+				successCount:       r.Stats.GetSuccessCount(),
+				failureCount:       r.Stats.GetFailureCount(),
+				latencyP50:         r.Stats.GetLatencyMsP50(),
+				latencyP95:         r.Stats.GetLatencyMsP95(),
+				latencyP99:         r.Stats.GetLatencyMsP99(),
+				latencies:          options.latencies(r.Stats),
 				tcpOpenConnections: r.GetTcpStats().GetOpenConnections(),
+				tcpTLSConnections:  r.GetTcpStats().GetTlsConnections(),
 				tcpReadBytes:       getByteRate(r.GetTcpStats().GetReadBytesTotal(), r.TimeWindow),
 				tcpWriteBytes:      getByteRate(r.GetTcpStats().GetWriteBytesTotal(), r.TimeWindow),
+				requestData:        requestData,
+			}
+			if options.history != nil && requestData {
+				samples := options.history.observe(resourceKey+"/"+key, statTables[resourceKey][key].rowStats)
+				statTables[resourceKey][key].successTrend = samples.successTrend()
+				statTables[resourceKey][key].requestTrend = samples.requestTrend()
 			}
 		}
 		if r.TsStats != nil {
@@ -375,7 +446,7 @@ func writeStatsToBuffer(rows []*pb.StatTable_PodGroup_Row, w *tabwriter.Writer,
 		}
 		printStatTables(statTables, w, maxNameLength, maxNamespaceLength, maxLeafLength, maxApexLength, maxWeightLength, options)
 	case jsonOutput:
-		printStatJSON(statTables, w)
+		printStatJSON(statTables, w, options)
 	}
 }
 
@@ -439,13 +510,8 @@ func printSingleStatTable(stats map[string]*row, resourceTypeLabel, resourceType
 		headers = append(headers, "MESHED")
 	}
 
-	headers = append(headers, []string{
-		"SUCCESS",
-		"RPS",
-		"LATENCY_P50",
-		"LATENCY_P95",
-		"LATENCY_P99",
-	}...)
+	headers = append(headers, "SUCCESS", "RPS")
+	headers = append(headers, options.latencyHeaders()...)
 
 	if resourceType != k8s.TrafficSplit {
 		headers = append(headers, "TCP_CONN")
@@ -455,6 +521,14 @@ func printSingleStatTable(stats map[string]*row, resourceTypeLabel, resourceType
 		headers = append(headers, []string{
 			"READ_BYTES/SEC",
 			"WRITE_BYTES/SEC",
+			"TCP_TLS",
+		}...)
+	}
+
+	if options.history != nil {
+		headers = append(headers, []string{
+			"SUCCESS_TREND",
+			"RPS_TREND",
 		}...)
 	}
 
@@ -466,16 +540,22 @@ func printSingleStatTable(stats map[string]*row, resourceTypeLabel, resourceType
 	for _, key := range sortedKeys {
 		namespace, name := namespaceName(resourceTypeLabel, key)
 		values := make([]interface{}, 0)
-		templateString := "%s\t%s\t%.2f%%\t%.1frps\t%dms\t%dms\t%dms\t"
-		templateStringEmpty := "%s\t%s\t-\t-\t-\t-\t-\t-\t"
+		latencyColumns := len(options.latencyColumns())
+		emptyRequestTemplate := "-\t-\t" + strings.Repeat("-\t", latencyColumns)
+		requestTemplate := "%.2f%%\t%.1frps\t" + strings.Repeat("%dms\t", latencyColumns)
+		if stats[key].rowStats != nil && !stats[key].requestData {
+			requestTemplate = emptyRequestTemplate
+		}
+		templateString := "%s\t%s\t" + requestTemplate
+		templateStringEmpty := "%s\t%s\t" + emptyRequestTemplate + "-\t"
 		if resourceType == k8s.Pod {
 			templateString = "%s\t" + templateString
 			templateStringEmpty = "%s\t" + templateStringEmpty
 		}
 
 		if resourceType == k8s.TrafficSplit {
-			templateString = "%s\t%s\t%s\t%s\t%.2f%%\t%.1frps\t%dms\t%dms\t%dms\t"
-			templateStringEmpty = "%s\t%s\t%s\t%s\t-\t-\t-\t-\t-\t"
+			templateString = "%s\t%s\t%s\t%s\t" + requestTemplate
+			templateStringEmpty = "%s\t%s\t%s\t%s\t" + emptyRequestTemplate
 		}
 
 		if !showTCPConns(resourceType) {
@@ -488,7 +568,12 @@ func printSingleStatTable(stats map[string]*row, resourceTypeLabel, resourceType
 		}
 
 		if showTCPBytes(options, resourceType) {
-			templateString = templateString + "%.1fB/s\t%.1fB/s\t"
+			templateString = templateString + "%.1fB/s\t%.1fB/s\t%s\t"
+			templateStringEmpty = templateStringEmpty + "-\t-\t-\t"
+		}
+
+		if options.history != nil {
+			templateString = templateString + "%s\t%s\t"
 			templateStringEmpty = templateStringEmpty + "-\t-\t"
 		}
 
@@ -537,13 +622,15 @@ func printSingleStatTable(stats map[string]*row, resourceTypeLabel, resourceType
 		}
 
 		if stats[key].rowStats != nil {
-			values = append(values, []interface{}{
-				stats[key].successRate * 100,
-				stats[key].requestRate,
-				stats[key].latencyP50,
-				stats[key].latencyP95,
-				stats[key].latencyP99,
-			}...)
+			if stats[key].requestData {
+				values = append(values, []interface{}{
+					stats[key].successRate * 100,
+					stats[key].requestRate,
+				}...)
+				for _, latency := range stats[key].latencies {
+					values = append(values, latency)
+				}
+			}
 
 			if showTCPConns(resourceType) {
 				values = append(values, stats[key].tcpOpenConnections)
@@ -553,9 +640,18 @@ func printSingleStatTable(stats map[string]*row, resourceTypeLabel, resourceType
 				values = append(values, []interface{}{
 					stats[key].tcpReadBytes,
 					stats[key].tcpWriteBytes,
+					tlsPercentage(stats[key].rowStats),
 				}...)
 			}
 
+			if options.history != nil {
+				if stats[key].requestData {
+					values = append(values, stats[key].successTrend, stats[key].requestTrend)
+				} else {
+					values = append(values, "-", "-")
+				}
+			}
+
 			fmt.Fprintf(w, templateString, values...)
 		} else {
 			fmt.Fprintf(w, templateStringEmpty, values...)
@@ -563,6 +659,15 @@ func printSingleStatTable(stats map[string]*row, resourceTypeLabel, resourceType
 	}
 }
 
+// tlsPercentage renders the share of the open TCP connections that are secured
+// with mTLS, or "-" without open connections
+func tlsPercentage(stats *rowStats) string {
+	if stats.tcpOpenConnections == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.0f%%", float64(stats.tcpTLSConnections)/float64(stats.tcpOpenConnections)*100)
+}
+
 func namespaceName(resourceType string, key string) (string, string) {
 	parts := strings.Split(key, "/")
 	namespace := parts[0]
@@ -571,26 +676,35 @@ func namespaceName(resourceType string, key string) (string, string) {
 	return namespace, name
 }
 
-// Using pointers where the value is NA and the corresponding json is null
+// jsonStats is an entry of the JSON output of the stat command. Using pointers
+// where the value is NA and the corresponding json is null. The JSON outputs
+// of the stat, routes and edges commands (jsonStats, JSONRouteStats and
+// edgesJSONStats) are part of the CLI's interface: fields may be added, but
+// not renamed or removed.
 type jsonStats struct {
-	Namespace      string   `json:"namespace"`
-	Kind           string   `json:"kind"`
-	Name           string   `json:"name"`
-	Meshed         string   `json:"meshed,omitempty"`
-	Success        *float64 `json:"success"`
-	Rps            *float64 `json:"rps"`
-	LatencyMSp50   *uint64  `json:"latency_ms_p50"`
-	LatencyMSp95   *uint64  `json:"latency_ms_p95"`
-	LatencyMSp99   *uint64  `json:"latency_ms_p99"`
-	TCPConnections *uint64  `json:"tcp_open_connections,omitempty"`
-	TCPReadBytes   *float64 `json:"tcp_read_bytes_rate,omitempty"`
-	TCPWriteBytes  *float64 `json:"tcp_write_bytes_rate,omitempty"`
-	Apex           string   `json:"apex,omitempty"`
-	Leaf           string   `json:"leaf,omitempty"`
-	Weight         string   `json:"weight,omitempty"`
+	Namespace         string            `json:"namespace"`
+	Kind              string            `json:"kind"`
+	Name              string            `json:"name"`
+	Meshed            string            `json:"meshed,omitempty"`
+	TimeWindow        string            `json:"time_window"`
+	Success           *float64          `json:"success"`
+	SuccessCount      *uint64           `json:"success_count"`
+	FailureCount      *uint64           `json:"failure_count"`
+	Rps               *float64          `json:"rps"`
+	LatencyMSp50      *uint64           `json:"latency_ms_p50"`
+	LatencyMSp95      *uint64           `json:"latency_ms_p95"`
+	LatencyMSp99      *uint64           `json:"latency_ms_p99"`
+	LatencyMS         map[string]uint64 `json:"latency_ms_percentiles,omitempty"`
+	TCPConnections    *uint64           `json:"tcp_open_connections,omitempty"`
+	TCPTLSConnections *uint64           `json:"tcp_tls_connections,omitempty"`
+	TCPReadBytes      *float64          `json:"tcp_read_bytes_rate,omitempty"`
+	TCPWriteBytes     *float64          `json:"tcp_write_bytes_rate,omitempty"`
+	Apex              string            `json:"apex,omitempty"`
+	Leaf              string            `json:"leaf,omitempty"`
+	Weight            string            `json:"weight,omitempty"`
 }
 
-func printStatJSON(statTables map[string]map[string]*row, w *tabwriter.Writer) {
+func printStatJSON(statTables map[string]map[string]*row, w *tabwriter.Writer, options *statOptions) {
 	// avoid nil initialization so that if there are not stats it gets marshalled as an empty array vs null
 	entries := []*jsonStats{}
 	for _, resourceType := range k8s.AllResources {
@@ -599,22 +713,31 @@ func printStatJSON(statTables map[string]map[string]*row, w *tabwriter.Writer) {
 			for _, key := range sortedKeys {
 				namespace, name := namespaceName("", key)
 				entry := &jsonStats{
-					Namespace: namespace,
-					Kind:      resourceType,
-					Name:      name,
+					Namespace:  namespace,
+					Kind:       resourceType,
+					Name:       name,
+					TimeWindow: stats[key].timeWindow,
 				}
 				if resourceType != k8s.TrafficSplit {
 					entry.Meshed = stats[key].meshed
 				}
 				if stats[key].rowStats != nil {
-					entry.Success = &stats[key].successRate
-					entry.Rps = &stats[key].requestRate
-					entry.LatencyMSp50 = &stats[key].latencyP50
-					entry.LatencyMSp95 = &stats[key].latencyP95
-					entry.LatencyMSp99 = &stats[key].latencyP99
+					if stats[key].requestData {
+						entry.Success = &stats[key].successRate
+						entry.SuccessCount = &stats[key].successCount
+						entry.FailureCount = &stats[key].failureCount
+						entry.Rps = &stats[key].requestRate
+						entry.LatencyMS = options.latencyJSON(stats[key].latencies)
+						if entry.LatencyMS == nil {
+							entry.LatencyMSp50 = &stats[key].latencyP50
+							entry.LatencyMSp95 = &stats[key].latencyP95
+							entry.LatencyMSp99 = &stats[key].latencyP99
+						}
+					}
 
 					if showTCPConns(resourceType) {
 						entry.TCPConnections = &stats[key].tcpOpenConnections
+						entry.TCPTLSConnections = &stats[key].tcpTLSConnections
 						entry.TCPReadBytes = &stats[key].tcpReadBytes
 						entry.TCPWriteBytes = &stats[key].tcpWriteBytes
 					}
@@ -675,11 +798,15 @@ func buildStatSummaryRequests(resources []string, options *statOptions) ([]*pb.S
 
 		requestParams := util.StatsSummaryRequestParams{
 			StatsBaseRequestParams: util.StatsBaseRequestParams{
-				TimeWindow:    options.timeWindow,
-				ResourceName:  target.Name,
-				ResourceType:  target.Type,
-				Namespace:     options.namespace,
-				AllNamespaces: options.allNamespaces,
+				TimeWindow:         options.requestTimeWindow(),
+				StartTime:          options.startTime,
+				EndTime:            options.endTime,
+				Step:               options.step,
+				LatencyPercentiles: options.latencyPercentiles,
+				ResourceName:       target.Name,
+				ResourceType:       target.Type,
+				Namespace:          options.namespace,
+				AllNamespaces:      options.allNamespaces,
 			},
 			ToNamespace:   options.toNamespace,
 			FromNamespace: options.fromNamespace,
@@ -746,6 +873,18 @@ func (o *statOptions) validateConflictingFlags() error {
 		return fmt.Errorf("--all-namespaces and --namespace flags are mutually exclusive")
 	}
 
+	if o.watch && o.outputFormat == jsonOutput {
+		return fmt.Errorf("--watch flag is incompatible with json output")
+	}
+
+	if o.watch && o.endTime != "" {
+		return fmt.Errorf("--watch and --end flags are mutually exclusive")
+	}
+
+	if o.watch && o.watchInterval < minWatchInterval {
+		return fmt.Errorf("--watch-interval must be at least %s", minWatchInterval)
+	}
+
 	return nil
 }
 
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/linkerd/linkerd2/controller/api/public"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
 	"github.com/linkerd/linkerd2/pkg/k8s"
 )
 
@@ -12,6 +13,8 @@ type paramsExp struct {
 	options *statOptions
 	resNs   []string
 	file    string
+	// tcpOnly returns stats without HTTP traffic
+	tcpOnly bool
 }
 
 func TestStat(t *testing.T) {
@@ -117,6 +120,84 @@ func TestStat(t *testing.T) {
 		}, k8s.Namespace, t)
 	})
 
+	t.Run("Returns TCP stats of resources without HTTP traffic", func(t *testing.T) {
+		testStatCall(paramsExp{
+			counts: &public.PodCounts{
+				MeshedPods:  1,
+				RunningPods: 2,
+				FailedPods:  0,
+			},
+			options: options,
+			resNs:   []string{"emojivoto1"},
+			file:    "stat_one_tcp_only_output.golden",
+			tcpOnly: true,
+		}, k8s.Namespace, t)
+	})
+
+	t.Run("Returns TCP stats of resources without HTTP traffic (json)", func(t *testing.T) {
+		options := newStatOptions()
+		options.outputFormat = jsonOutput
+		testStatCall(paramsExp{
+			counts: &public.PodCounts{
+				MeshedPods:  1,
+				RunningPods: 2,
+				FailedPods:  0,
+			},
+			options: options,
+			resNs:   []string{"emojivoto1"},
+			file:    "stat_one_tcp_only_output_json.golden",
+			tcpOnly: true,
+		}, k8s.Namespace, t)
+	})
+
+	t.Run("Returns the requested latency percentiles", func(t *testing.T) {
+		response := public.GenStatSummaryResponse("emoji", k8s.Namespace, []string{"emojivoto1"}, &public.PodCounts{
+			MeshedPods:  1,
+			RunningPods: 2,
+		}, true, true)
+		response.GetOk().GetStatTables()[0].GetPodGroup().GetRows()[0].GetStats().LatencyMsPercentiles = []*pb.LatencyPercentile{
+			{Percentile: 50, LatencyMs: 123},
+			{Percentile: 99.9, LatencyMs: 456},
+		}
+
+		for _, outputFormat := range []string{tableOutput, jsonOutput} {
+			options := newStatOptions()
+			options.outputFormat = outputFormat
+			options.latencyPercentiles = []string{"p50", "p99.9"}
+
+			reqs, err := buildStatSummaryRequests([]string{"ns"}, options)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if percentiles := reqs[0].GetLatencyPercentiles(); len(percentiles) != 2 || percentiles[1] != 99.9 {
+				t.Fatalf("Unexpected latency percentiles %v", percentiles)
+			}
+
+			mockClient := &public.MockAPIClient{StatSummaryResponseToReturn: response}
+			resp, err := requestStatsFromAPI(mockClient, reqs[0])
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			file := "stat_latency_percentiles_output.golden"
+			if outputFormat == jsonOutput {
+				file = "stat_latency_percentiles_output_json.golden"
+			}
+			diffTestdata(t, file, renderStatStats(respToRows(resp), options))
+		}
+	})
+
+	t.Run("Rejects invalid latency percentiles", func(t *testing.T) {
+		options := newStatOptions()
+		options.latencyPercentiles = []string{"p100"}
+		expectedError := `latency percentiles must be between 0 and 100, got "p100"`
+
+		_, err := buildStatSummaryRequests([]string{"ns"}, options)
+		if err == nil || err.Error() != expectedError {
+			t.Fatalf("Expected error [%s] instead got [%s]", expectedError, err)
+		}
+	})
+
 	t.Run("Returns an error for named resource queries with the --all-namespaces flag", func(t *testing.T) {
 		options := newStatOptions()
 		options.allNamespaces = true
@@ -207,7 +288,7 @@ func TestStat(t *testing.T) {
 
 func testStatCall(exp paramsExp, resourceType string, t *testing.T) {
 	mockClient := &public.MockAPIClient{}
-	response := public.GenStatSummaryResponse("emoji", resourceType, exp.resNs, exp.counts, true, true)
+	response := public.GenStatSummaryResponse("emoji", resourceType, exp.resNs, exp.counts, !exp.tcpOnly, true)
 	if resourceType == k8s.TrafficSplit {
 		response = public.GenStatTsResponse("foo-split", resourceType, exp.resNs, true, true)
 	}
@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"time"
+
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+)
+
+const (
+	// statHistorySize is the number of refreshes kept for the trends of
+	// `linkerd stat --watch`
+	statHistorySize = 10
+
+	minWatchInterval = time.Second
+
+	// clearScreen moves the cursor to the top left corner of the terminal and
+	// clears it
+	clearScreen = "\033[H\033[2J"
+)
+
+var sparks = []rune("▁▂▃▄▅▆▇█")
+
+// statHistory keeps the success rates and request rates of the resources
+// displayed by `linkerd stat --watch`, to show their trends
+type statHistory struct {
+	samples map[string]*statSamples
+}
+
+type statSamples struct {
+	successRates []float64
+	requestRates []float64
+}
+
+func newStatHistory() *statHistory {
+	return &statHistory{samples: map[string]*statSamples{}}
+}
+
+// observe records the stats of the resource identified by key, and returns
+// its samples so far
+func (h *statHistory) observe(key string, stats *rowStats) *statSamples {
+	samples, ok := h.samples[key]
+	if !ok {
+		samples = &statSamples{}
+		h.samples[key] = samples
+	}
+	samples.successRates = appendSample(samples.successRates, stats.successRate*100)
+	samples.requestRates = appendSample(samples.requestRates, stats.requestRate)
+	return samples
+}
+
+func appendSample(samples []float64, sample float64) []float64 {
+	samples = append(samples, sample)
+	if len(samples) > statHistorySize {
+		samples = samples[len(samples)-statHistorySize:]
+	}
+	return samples
+}
+
+// successTrend renders the trend of the success rate, e.g. "▁▃█ +1.20%"
+func (s *statSamples) successTrend() string {
+	return fmt.Sprintf("%s %+.2f%%", sparkline(s.successRates), lastDelta(s.successRates))
+}
+
+// requestTrend renders the trend of the request rate, e.g. "▁▃█ +0.5rps"
+func (s *statSamples) requestTrend() string {
+	return fmt.Sprintf("%s %+.1frps", sparkline(s.requestRates), lastDelta(s.requestRates))
+}
+
+// sparkline renders values as a bar chart scaled between their minimum and
+// maximum
+func sparkline(values []float64) string {
+	low, high := math.Inf(1), math.Inf(-1)
+	for _, v := range values {
+		low = math.Min(low, v)
+		high = math.Max(high, v)
+	}
+
+	line := make([]rune, len(values))
+	for i, v := range values {
+		spark := 0
+		if high > low {
+			spark = int(math.Round((v - low) / (high - low) * float64(len(sparks)-1)))
+		}
+		line[i] = sparks[spark]
+	}
+	return string(line)
+}
+
+// lastDelta returns the difference between the last two values, or 0 if there
+// are fewer
+func lastDelta(values []float64) float64 {
+	if len(values) < 2 {
+		return 0
+	}
+	return values[len(values)-1] - values[len(values)-2]
+}
+
+// watchStats renders the stats returned by fetch every interval, in place,
+// until fetch fails
+func watchStats(w io.Writer, interval time.Duration, options *statOptions, fetch func() ([]*pb.StatTable_PodGroup_Row, error)) error {
+	options.history = newStatHistory()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		rows, err := fetch()
+		if err != nil {
+			return err
+		}
+
+		output := renderStatStats(rows, options)
+		fmt.Fprintf(w, "%sEvery %s: %s\n\n%s", clearScreen, interval, time.Now().Format(time.RFC1123), output)
+
+		<-ticker.C
+	}
+}
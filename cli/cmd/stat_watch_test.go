@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/linkerd/linkerd2/controller/api/public"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+)
+
+func TestSparkline(t *testing.T) {
+	testCases := []struct {
+		values   []float64
+		expected string
+	}{
+		{[]float64{}, ""},
+		{[]float64{42}, "▁"},
+		{[]float64{1, 1, 1}, "▁▁▁"},
+		{[]float64{0, 7, 3.5, 1}, "▁█▅▂"},
+	}
+
+	for i, tc := range testCases {
+		tc := tc // pin
+		if line := sparkline(tc.values); line != tc.expected {
+			t.Errorf("test case %d: expected %q, got %q", i, tc.expected, line)
+		}
+	}
+}
+
+func TestStatHistory(t *testing.T) {
+	history := newStatHistory()
+
+	var samples *statSamples
+	for i := 0; i < statHistorySize+5; i++ {
+		samples = history.observe("deployment/emojivoto/web", &rowStats{successRate: 1, requestRate: float64(i)})
+	}
+
+	if len(samples.requestRates) != statHistorySize {
+		t.Fatalf("Expected %d samples, got %d", statHistorySize, len(samples.requestRates))
+	}
+	if samples.requestRates[0] != 5 {
+		t.Errorf("Expected the oldest samples to be dropped, got %v", samples.requestRates)
+	}
+	if trend := samples.requestTrend(); trend != "▁▂▃▃▄▅▆▆▇█ +1.0rps" {
+		t.Errorf("Unexpected request trend %q", trend)
+	}
+	if trend := samples.successTrend(); trend != "▁▁▁▁▁▁▁▁▁▁ +0.00%" {
+		t.Errorf("Unexpected success trend %q", trend)
+	}
+}
+
+func TestStatWatch(t *testing.T) {
+	options := newStatOptions()
+	options.watch = true
+	options.history = newStatHistory()
+
+	mockClient := &public.MockAPIClient{}
+	mockClient.StatSummaryResponseToReturn = public.GenStatSummaryResponse("emoji", k8s.Namespace, []string{"emojivoto1"}, &public.PodCounts{
+		MeshedPods:  1,
+		RunningPods: 2,
+	}, true, true)
+
+	reqs, err := buildStatSummaryRequests([]string{"ns"}, options)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var output string
+	for i := 0; i < 3; i++ {
+		rows, err := requestAllStatsFromAPI(mockClient, reqs)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		output = renderStatStats(rows, options)
+	}
+
+	diffTestdata(t, "stat_watch_output.golden", output)
+}
+
+func TestStatWatchValidation(t *testing.T) {
+	options := newStatOptions()
+	options.watch = true
+	options.outputFormat = jsonOutput
+	if err := options.validateConflictingFlags(); err == nil || err.Error() != "--watch flag is incompatible with json output" {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	options.outputFormat = tableOutput
+	options.endTime = "2h"
+	if err := options.validateConflictingFlags(); err == nil || err.Error() != "--watch and --end flags are mutually exclusive" {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	options.endTime = ""
+	options.watchInterval = 0
+	if err := options.validateConflictingFlags(); err == nil || err.Error() != "--watch-interval must be at least 1s" {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
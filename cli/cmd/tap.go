@@ -6,7 +6,10 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"os/signal"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/golang/protobuf/ptypes/duration"
 	"github.com/linkerd/linkerd2/controller/api/util"
@@ -20,6 +23,13 @@ import (
 	"google.golang.org/grpc/codes"
 )
 
+const (
+	// jsonlOutput renders each tap event as a JSON object on its own line
+	jsonlOutput = "jsonl"
+	// harOutput renders the tapped requests as a HAR document once tap stops
+	harOutput = "har"
+)
+
 type renderTapEventFunc func(*pb.TapEvent, string) string
 
 type tapOptions struct {
@@ -33,6 +43,12 @@ type tapOptions struct {
 	path          string
 	output        string
 	labelSelector string
+	grpcMethod    string
+	statusClass   string
+	pathRegex     string
+	minLatency    time.Duration
+	sampleRate    float32
+	record        string
 }
 
 type endpoint struct {
@@ -113,11 +129,22 @@ func newTapOptions() *tapOptions {
 		path:          "",
 		output:        "",
 		labelSelector: "",
+		grpcMethod:    "",
+		statusClass:   "",
+		pathRegex:     "",
+		minLatency:    0,
+		sampleRate:    1,
+		record:        "",
 	}
 }
 
 func (o *tapOptions) validate() error {
-	if o.output == "" || o.output == wideOutput || o.output == jsonOutput {
+	if o.sampleRate <= 0 || o.sampleRate > 1 {
+		return fmt.Errorf("--sample-rate must be greater than 0 and at most 1, got %v", o.sampleRate)
+	}
+
+	switch o.output {
+	case "", wideOutput, jsonOutput, jsonlOutput, harOutput:
 		return nil
 	}
 
@@ -166,7 +193,22 @@ func newCmdTap() *cobra.Command {
   linkerd tap pod/web-dlbvj
 
   # tap the test namespace, filter by request to prod namespace
-  linkerd tap ns/test --to ns/prod`,
+  linkerd tap ns/test --to ns/prod
+
+  # tap the failed requests to the web deployment taking longer than 500ms
+  linkerd tap deploy/web --status-class 5xx --min-latency 500ms
+
+  # tap 1% of the requests to the busy api deployment
+  linkerd tap deploy/api --sample-rate 0.01
+
+  # record the requests to the web deployment, to replay them later
+  linkerd tap deploy/web --record web.l5dtap
+
+  # tap the web deployment and filter the requests with jq
+  linkerd tap deploy/web -o jsonl | jq 'select(.requestInitEvent.path == "/api/vote")'
+
+  # record the requests to the web deployment into a HAR file, until interrupted
+  linkerd tap deploy/web -o har > web.har`,
 		Args:      cobra.RangeArgs(1, 2),
 		ValidArgs: util.ValidTargets,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -180,8 +222,13 @@ func newCmdTap() *cobra.Command {
 				Method:        options.method,
 				Authority:     options.authority,
 				Path:          options.path,
-				Extract:       options.output == jsonOutput,
+				Extract:       options.output == jsonOutput || options.output == jsonlOutput || options.output == harOutput,
 				LabelSelector: options.labelSelector,
+				GrpcMethod:    options.grpcMethod,
+				StatusClass:   options.statusClass,
+				PathRegex:     options.pathRegex,
+				MinLatency:    options.minLatency,
+				SampleRate:    options.sampleRate,
 			}
 
 			err := options.validate()
@@ -220,9 +267,23 @@ func newCmdTap() *cobra.Command {
 	cmd.PersistentFlags().StringVar(&options.path, "path", options.path,
 		"Display requests with paths that start with this prefix")
 	cmd.PersistentFlags().StringVarP(&options.output, "output", "o", options.output,
-		fmt.Sprintf("Output format. One of: \"%s\", \"%s\"", wideOutput, jsonOutput))
+		fmt.Sprintf("Output format. One of: \"%s\", \"%s\", \"%s\", \"%s\"", wideOutput, jsonOutput, jsonlOutput, harOutput))
 	cmd.PersistentFlags().StringVarP(&options.labelSelector, "selector", "l", options.labelSelector,
 		"Selector (label query) to filter on, supports '=', '==', and '!='")
+	cmd.PersistentFlags().Float32Var(&options.sampleRate, "sample-rate", options.sampleRate,
+		"Fraction of the requests to tap, between 0 and 1, sampled at random; the sampled requests still count towards --max-rps")
+	cmd.PersistentFlags().StringVar(&options.grpcMethod, "grpc-method", options.grpcMethod,
+		"Display gRPC requests to this fully-qualified method, e.g. \"emojivoto.v1.VotingService/VoteDoughnut\"")
+	cmd.PersistentFlags().StringVar(&options.statusClass, "status-class", options.statusClass,
+		"Display requests whose response status is in this class, one of: 1xx, 2xx, 3xx, 4xx or 5xx; the events of a request are only displayed once its response started")
+	cmd.PersistentFlags().StringVar(&options.pathRegex, "path-regex", options.pathRegex,
+		"Display requests with paths matching this regular expression")
+	cmd.PersistentFlags().DurationVar(&options.minLatency, "min-latency", options.minLatency,
+		"Display requests whose response ended at least this long after the request started; the events of a request are only displayed once its response ended")
+	cmd.Flags().StringVar(&options.record, "record", options.record,
+		"Also record the tapped events into this file, to replay them with \"linkerd tap replay\"")
+
+	cmd.AddCommand(newCmdTapReplay(options))
 
 	return cmd
 }
@@ -234,6 +295,19 @@ func requestTapByResourceFromAPI(w io.Writer, k8sAPI *k8s.KubernetesAPI, req *pb
 	}
 	defer body.Close()
 
+	if options.record != "" {
+		file, err := os.Create(options.record)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		reader, err = tap.Record(file, req, reader)
+		if err != nil {
+			return fmt.Errorf("failed to record the tap events: %s", err)
+		}
+	}
+
 	return writeTapEventsToBuffer(w, reader, req, options)
 }
 
@@ -247,6 +321,15 @@ func writeTapEventsToBuffer(w io.Writer, tapByteStream *bufio.Reader, req *pb.Ta
 		err = renderTapEvents(tapByteStream, w, renderTapEvent, resource)
 	case jsonOutput:
 		err = renderTapEvents(tapByteStream, w, renderTapEventJSON, "")
+	case jsonlOutput:
+		err = renderTapEvents(tapByteStream, w, renderTapEventJSONL, "")
+	case harOutput:
+		// the HAR document is written once tap stops, so it's interrupted
+		// gracefully
+		stop := make(chan os.Signal, 1)
+		signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+		defer signal.Stop(stop)
+		err = renderTapEventsHAR(tapByteStream, w, stop)
 	}
 	if err != nil {
 		return err
@@ -386,6 +469,16 @@ func renderTapEventJSON(event *pb.TapEvent, _ string) string {
 	return fmt.Sprintf("%s", e)
 }
 
+// renderTapEventJSONL renders a Public API TapEvent to a single line of JSON.
+func renderTapEventJSONL(event *pb.TapEvent, _ string) string {
+	m := mapPublicToDisplayTapEvent(event)
+	e, err := json.Marshal(m)
+	if err != nil {
+		return fmt.Sprintf("{\"error marshalling JSON\": \"%s\"}", err)
+	}
+	return string(e)
+}
+
 // Map public API `TapEvent`s to `displayTapEvent`s
 func mapPublicToDisplayTapEvent(event *pb.TapEvent) *tapEvent {
 	// Map source endpoint
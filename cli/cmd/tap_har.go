@@ -0,0 +1,317 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/duration"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"github.com/linkerd/linkerd2/pkg/protohttp"
+	"github.com/linkerd/linkerd2/pkg/version"
+	log "github.com/sirupsen/logrus"
+)
+
+// The types below are the subset of the HTTP Archive (HAR) 1.2 format
+// (http://www.softwareishard.com/blog/har-12-spec/) that can be filled from
+// tap events. Tap doesn't capture bodies, so their sizes are the only content
+// reported.
+
+type harLog struct {
+	Log harLogContent `json:"log"`
+}
+
+type harLogContent struct {
+	Version string      `json:"version"`
+	Creator harCreator  `json:"creator"`
+	Entries []*harEntry `json:"entries"`
+}
+
+type harCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type harEntry struct {
+	StartedDateTime string      `json:"startedDateTime"`
+	Time            float64     `json:"time"`
+	Request         harRequest  `json:"request"`
+	Response        harResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         harTimings  `json:"timings"`
+	ServerIPAddress string      `json:"serverIPAddress,omitempty"`
+
+	// custom fields, prefixed with an underscore as mandated by the spec
+	Source         *endpoint         `json:"_source"`
+	Destination    *endpoint         `json:"_destination"`
+	ProxyDirection string            `json:"_proxyDirection"`
+	RouteMeta      map[string]string `json:"_routeMeta,omitempty"`
+	GrpcStatusCode *uint32           `json:"_grpcStatusCode,omitempty"`
+	ResetErrorCode *uint32           `json:"_resetErrorCode,omitempty"`
+
+	complete bool
+}
+
+type harNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type harRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	QueryString []harNameValue `json:"queryString"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harResponse struct {
+	Status      uint32         `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	Trailers    []harNameValue `json:"_trailers,omitempty"`
+	Content     harContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int64          `json:"bodySize"`
+}
+
+type harContent struct {
+	Size     int64  `json:"size"`
+	MimeType string `json:"mimeType"`
+}
+
+type harTimings struct {
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+}
+
+// harRecorder pairs the request and response events of the tapped streams
+// into HAR entries
+type harRecorder struct {
+	now     func() time.Time
+	entries []*harEntry
+	streams map[string]*harEntry
+}
+
+func newHARRecorder() *harRecorder {
+	return &harRecorder{
+		now:     time.Now,
+		streams: map[string]*harEntry{},
+	}
+}
+
+// renderTapEventsHAR records the tap events until the stream ends or stop
+// receives a signal, and then writes them to w as a HAR document.
+func renderTapEventsHAR(tapByteStream *bufio.Reader, w io.Writer, stop <-chan os.Signal) error {
+	events := make(chan *pb.TapEvent)
+	go func() {
+		defer close(events)
+		for {
+			log.Debug("Waiting for data...")
+			event := pb.TapEvent{}
+			err := protohttp.FromByteStreamToProtocolBuffers(tapByteStream, &event)
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				return
+			}
+			events <- &event
+		}
+	}()
+
+	recorder := newHARRecorder()
+loop:
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				break loop
+			}
+			recorder.record(event)
+		case <-stop:
+			break loop
+		}
+	}
+
+	return recorder.write(w)
+}
+
+func (r *harRecorder) record(event *pb.TapEvent) {
+	switch ev := event.GetHttp().GetEvent().(type) {
+	case *pb.TapEvent_Http_RequestInit_:
+		entry := &harEntry{
+			StartedDateTime: r.now().UTC().Format(time.RFC3339Nano),
+			Request:         harRequestFor(ev.RequestInit),
+			Response: harResponse{
+				Cookies: []harNameValue{},
+				Headers: []harNameValue{},
+			},
+			ProxyDirection: event.GetProxyDirection().String(),
+			RouteMeta:      event.GetRouteMeta().GetLabels(),
+		}
+		display := mapPublicToDisplayTapEvent(event)
+		entry.Source = display.Source
+		entry.Destination = display.Destination
+		entry.ServerIPAddress = display.Destination.IP
+		r.entries = append(r.entries, entry)
+		r.streams[harStreamKey(event, ev.RequestInit.GetId())] = entry
+
+	case *pb.TapEvent_Http_ResponseInit_:
+		entry, ok := r.streams[harStreamKey(event, ev.ResponseInit.GetId())]
+		if !ok {
+			return
+		}
+		entry.Response.Status = ev.ResponseInit.GetHttpStatus()
+		entry.Response.StatusText = http.StatusText(int(ev.ResponseInit.GetHttpStatus()))
+		entry.Response.HTTPVersion = entry.Request.HTTPVersion
+		entry.Response.Headers = harHeaders(ev.ResponseInit.GetHeaders())
+		for _, h := range entry.Response.Headers {
+			if strings.EqualFold(h.Name, "content-type") {
+				entry.Response.Content.MimeType = h.Value
+			}
+		}
+		entry.Timings.Wait = milliseconds(ev.ResponseInit.GetSinceRequestInit())
+		entry.Time = entry.Timings.Wait
+
+	case *pb.TapEvent_Http_ResponseEnd_:
+		key := harStreamKey(event, ev.ResponseEnd.GetId())
+		entry, ok := r.streams[key]
+		if !ok {
+			return
+		}
+		delete(r.streams, key)
+		entry.complete = true
+		entry.Response.Trailers = harHeaders(ev.ResponseEnd.GetTrailers())
+		entry.Response.Content.Size = int64(ev.ResponseEnd.GetResponseBytes())
+		entry.Response.BodySize = int64(ev.ResponseEnd.GetResponseBytes())
+		entry.Timings.Receive = milliseconds(ev.ResponseEnd.GetSinceResponseInit())
+		entry.Time = milliseconds(ev.ResponseEnd.GetSinceRequestInit())
+		switch eos := ev.ResponseEnd.GetEos().GetEnd().(type) {
+		case *pb.Eos_GrpcStatusCode:
+			entry.GrpcStatusCode = &eos.GrpcStatusCode
+		case *pb.Eos_ResetErrorCode:
+			entry.ResetErrorCode = &eos.ResetErrorCode
+		}
+	}
+}
+
+// write renders the recorded entries as a HAR document. The requests still
+// waiting for their response are included, with a status of 0 as mandated by
+// the spec for aborted requests.
+func (r *harRecorder) write(w io.Writer) error {
+	entries := r.entries
+	if entries == nil {
+		entries = []*harEntry{}
+	}
+	for _, entry := range entries {
+		if !entry.complete {
+			entry.Response.BodySize = -1
+			entry.Response.Content.Size = -1
+		}
+	}
+
+	b, err := json.MarshalIndent(harLog{
+		Log: harLogContent{
+			Version: "1.2",
+			Creator: harCreator{Name: "linkerd tap", Version: version.Version},
+			Entries: entries,
+		},
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(b))
+	return err
+}
+
+func harRequestFor(req *pb.TapEvent_Http_RequestInit) harRequest {
+	scheme := strings.ToLower(formatScheme(req.GetScheme()))
+	if scheme == "" {
+		scheme = "http"
+	}
+	u := fmt.Sprintf("%s://%s%s", scheme, req.GetAuthority(), req.GetPath())
+
+	query := []harNameValue{}
+	if parsed, err := url.Parse(u); err == nil {
+		values := parsed.Query()
+		names := make([]string, 0, len(values))
+		for name := range values {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			for _, value := range values[name] {
+				query = append(query, harNameValue{Name: name, Value: value})
+			}
+		}
+	}
+
+	// tap doesn't report the HTTP version of the requests; h2 is the only one
+	// carrying pseudo-headers such as :authority
+	httpVersion := "HTTP/1.1"
+	for _, h := range req.GetHeaders().GetHeaders() {
+		if strings.HasPrefix(h.GetName(), ":") {
+			httpVersion = "HTTP/2"
+			break
+		}
+	}
+
+	return harRequest{
+		Method:      formatMethod(req.GetMethod()),
+		URL:         u,
+		HTTPVersion: httpVersion,
+		Cookies:     []harNameValue{},
+		Headers:     harHeaders(req.GetHeaders()),
+		QueryString: query,
+		HeadersSize: -1,
+		BodySize:    -1,
+	}
+}
+
+// harHeaders converts tap headers into HAR ones; binary values are base64
+// encoded
+func harHeaders(hs *pb.Headers) []harNameValue {
+	headers := []harNameValue{}
+	for _, h := range hs.GetHeaders() {
+		switch v := h.GetValue().(type) {
+		case *pb.Headers_Header_ValueStr:
+			headers = append(headers, harNameValue{Name: h.GetName(), Value: v.ValueStr})
+		case *pb.Headers_Header_ValueBin:
+			headers = append(headers, harNameValue{Name: h.GetName(), Value: base64.StdEncoding.EncodeToString(v.ValueBin)})
+		}
+	}
+	return headers
+}
+
+// harStreamKey identifies a stream across the proxies reporting tap events
+func harStreamKey(event *pb.TapEvent, id *pb.TapEvent_Http_StreamId) string {
+	src := src(event)
+	dst := dst(event)
+	return fmt.Sprintf("%s/%s/%s/%d/%d", event.GetProxyDirection(), src.formatAddr(), dst.formatAddr(), id.GetBase(), id.GetStream())
+}
+
+func milliseconds(d *duration.Duration) float64 {
+	dur, err := ptypes.Duration(d)
+	if err != nil || d == nil {
+		return -1
+	}
+	return float64(dur) / float64(time.Millisecond)
+}
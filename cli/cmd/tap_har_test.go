@@ -0,0 +1,179 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/duration"
+	"github.com/linkerd/linkerd2/controller/api/util"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"github.com/linkerd/linkerd2/pkg/protohttp"
+)
+
+func harTestEvents() []*pb.TapEvent {
+	id := &pb.TapEvent_Http_StreamId{Base: 1, Stream: 2}
+	return []*pb.TapEvent{
+		util.CreateTapEvent(
+			&pb.TapEvent_Http{
+				Event: &pb.TapEvent_Http_RequestInit_{
+					RequestInit: &pb.TapEvent_Http_RequestInit{
+						Id:        id,
+						Method:    &pb.HttpMethod{Type: &pb.HttpMethod_Registered_{Registered: pb.HttpMethod_GET}},
+						Scheme:    &pb.Scheme{Type: &pb.Scheme_Registered_{Registered: pb.Scheme_HTTP}},
+						Authority: "web.emojivoto:80",
+						Path:      "/api/vote?choice=doughnut",
+						Headers: &pb.Headers{
+							Headers: []*pb.Headers_Header{
+								{Name: ":authority", Value: &pb.Headers_Header_ValueStr{ValueStr: "web.emojivoto:80"}},
+							},
+						},
+					},
+				},
+			},
+			map[string]string{"deployment": "web"},
+			pb.TapEvent_OUTBOUND,
+		),
+		util.CreateTapEvent(
+			&pb.TapEvent_Http{
+				Event: &pb.TapEvent_Http_ResponseInit_{
+					ResponseInit: &pb.TapEvent_Http_ResponseInit{
+						Id:               id,
+						SinceRequestInit: &duration.Duration{Nanos: 2000000},
+						HttpStatus:       200,
+						Headers: &pb.Headers{
+							Headers: []*pb.Headers_Header{
+								{Name: "content-type", Value: &pb.Headers_Header_ValueStr{ValueStr: "application/json"}},
+							},
+						},
+					},
+				},
+			},
+			map[string]string{"deployment": "web"},
+			pb.TapEvent_OUTBOUND,
+		),
+		util.CreateTapEvent(
+			&pb.TapEvent_Http{
+				Event: &pb.TapEvent_Http_ResponseEnd_{
+					ResponseEnd: &pb.TapEvent_Http_ResponseEnd{
+						Id:                id,
+						SinceRequestInit:  &duration.Duration{Nanos: 5000000},
+						SinceResponseInit: &duration.Duration{Nanos: 3000000},
+						ResponseBytes:     42,
+						Eos:               &pb.Eos{End: &pb.Eos_GrpcStatusCode{GrpcStatusCode: 0}},
+					},
+				},
+			},
+			map[string]string{"deployment": "web"},
+			pb.TapEvent_OUTBOUND,
+		),
+		// a request still waiting for its response
+		util.CreateTapEvent(
+			&pb.TapEvent_Http{
+				Event: &pb.TapEvent_Http_RequestInit_{
+					RequestInit: &pb.TapEvent_Http_RequestInit{
+						Id:        &pb.TapEvent_Http_StreamId{Base: 1, Stream: 3},
+						Method:    &pb.HttpMethod{Type: &pb.HttpMethod_Registered_{Registered: pb.HttpMethod_POST}},
+						Authority: "web.emojivoto:80",
+						Path:      "/api/list",
+					},
+				},
+			},
+			map[string]string{"deployment": "web"},
+			pb.TapEvent_OUTBOUND,
+		),
+	}
+}
+
+func TestRenderTapEventsHAR(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	for _, event := range harTestEvents() {
+		if err := protohttp.WriteProtoToHTTPResponse(recorder, event); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+	}
+
+	w := bytes.NewBufferString("")
+	err := renderTapEventsHAR(bufio.NewReader(recorder.Body), w, make(chan os.Signal))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	var har harLog
+	if err := json.Unmarshal(w.Bytes(), &har); err != nil {
+		t.Fatalf("Unexpected error: %s\n%s", err, w.String())
+	}
+	if har.Log.Version != "1.2" {
+		t.Fatalf("Expected HAR version 1.2, got %s", har.Log.Version)
+	}
+	if len(har.Log.Entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(har.Log.Entries))
+	}
+
+	complete := har.Log.Entries[0]
+	if _, err := time.Parse(time.RFC3339Nano, complete.StartedDateTime); err != nil {
+		t.Fatalf("Invalid startedDateTime: %s", err)
+	}
+	if complete.Request.Method != "GET" || complete.Request.URL != "http://web.emojivoto:80/api/vote?choice=doughnut" {
+		t.Fatalf("Unexpected request: %+v", complete.Request)
+	}
+	if complete.Request.HTTPVersion != "HTTP/2" {
+		t.Fatalf("Expected HTTP/2, got %s", complete.Request.HTTPVersion)
+	}
+	if len(complete.Request.QueryString) != 1 || complete.Request.QueryString[0] != (harNameValue{Name: "choice", Value: "doughnut"}) {
+		t.Fatalf("Unexpected query string: %+v", complete.Request.QueryString)
+	}
+	if complete.Response.Status != 200 || complete.Response.StatusText != "OK" {
+		t.Fatalf("Unexpected response status: %d %s", complete.Response.Status, complete.Response.StatusText)
+	}
+	if complete.Response.Content.MimeType != "application/json" || complete.Response.Content.Size != 42 {
+		t.Fatalf("Unexpected response content: %+v", complete.Response.Content)
+	}
+	if complete.Time != 5 || complete.Timings.Wait != 2 || complete.Timings.Receive != 3 {
+		t.Fatalf("Unexpected timings: time=%v %+v", complete.Time, complete.Timings)
+	}
+	if complete.GrpcStatusCode == nil || *complete.GrpcStatusCode != 0 {
+		t.Fatalf("Expected gRPC status 0, got %v", complete.GrpcStatusCode)
+	}
+	if complete.ServerIPAddress != "ff01::1" || complete.Destination.Metadata["deployment"] != "web" {
+		t.Fatalf("Unexpected destination: %s %+v", complete.ServerIPAddress, complete.Destination)
+	}
+
+	pending := har.Log.Entries[1]
+	if pending.Request.Method != "POST" || pending.Request.HTTPVersion != "HTTP/1.1" {
+		t.Fatalf("Unexpected request: %+v", pending.Request)
+	}
+	if pending.Response.Status != 0 || pending.Response.BodySize != -1 {
+		t.Fatalf("Expected an aborted response, got %+v", pending.Response)
+	}
+}
+
+func TestRenderTapEventsHARStopped(t *testing.T) {
+	writer := bytes.NewBufferString("")
+	stop := make(chan os.Signal, 1)
+	stop <- os.Interrupt
+
+	// the stream never ends, the signal stops the recording
+	blocking := bufio.NewReader(blockingReader{})
+	if err := renderTapEventsHAR(blocking, writer, stop); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	var har harLog
+	if err := json.Unmarshal(writer.Bytes(), &har); err != nil {
+		t.Fatalf("Unexpected error: %s\n%s", err, writer.String())
+	}
+	if har.Log.Entries == nil || len(har.Log.Entries) != 0 {
+		t.Fatalf("Expected no entries, got %+v", har.Log.Entries)
+	}
+}
+
+type blockingReader struct{}
+
+func (blockingReader) Read([]byte) (int, error) {
+	select {}
+}
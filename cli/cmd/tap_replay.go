@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/linkerd/linkerd2/controller/api/util"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"github.com/linkerd/linkerd2/pkg/protohttp"
+	"github.com/linkerd/linkerd2/pkg/tap"
+	"github.com/spf13/cobra"
+)
+
+func newCmdTapReplay(options *tapOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "replay [flags] FILE",
+		Short: "Replay a traffic stream recorded with \"linkerd tap --record\"",
+		Long: `Replay a traffic stream recorded with "linkerd tap --record".
+
+  The recorded events are rendered like "linkerd tap" does, with the same
+  output formats. The filters of "linkerd tap", such as --method, --path,
+  --status-class or --min-latency, are applied to the recorded events; --to,
+  --selector and --max-rps are not, since they select the tapped proxies.`,
+		Example: `  # record the requests to the web deployment
+  linkerd tap deploy/web --record web.l5dtap
+
+  # replay the failed requests of the recording as JSON
+  linkerd tap replay web.l5dtap --status-class 5xx -o json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := options.validate(); err != nil {
+				return fmt.Errorf("validation error when executing tap replay command: %v", err)
+			}
+
+			file, err := os.Open(args[0])
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+
+			return replayTapEvents(os.Stdout, file, options)
+		},
+	}
+
+	return cmd
+}
+
+// replayTapEvents renders the events of a recording read from r, filtered by
+// the options
+func replayTapEvents(w io.Writer, r io.Reader, options *tapOptions) error {
+	recorded, events, err := tap.ReadRecording(r)
+	if err != nil {
+		return err
+	}
+
+	// the filters are built from the options, for the recorded target
+	target := recorded.GetTarget().GetResource()
+	req, err := util.BuildTapByResourceRequest(util.TapRequestParams{
+		Resource:    path.Join(target.GetType(), target.GetName()),
+		Namespace:   target.GetNamespace(),
+		Scheme:      options.scheme,
+		Method:      options.method,
+		Authority:   options.authority,
+		Path:        options.path,
+		GrpcMethod:  options.grpcMethod,
+		StatusClass: options.statusClass,
+		PathRegex:   options.pathRegex,
+		MinLatency:  options.minLatency,
+		SampleRate:  options.sampleRate,
+	})
+	if err != nil {
+		return err
+	}
+
+	filter, err := tap.NewReplayFilter(req)
+	if err != nil {
+		return err
+	}
+
+	return writeTapEventsToBuffer(w, filterTapEvents(events, filter.NewStreams()), req, options)
+}
+
+// filterTapEvents returns a byte stream of the events of tapByteStream
+// reported by streams
+func filterTapEvents(tapByteStream *bufio.Reader, streams *tap.Streams) *bufio.Reader {
+	r, w := io.Pipe()
+	go func() {
+		for {
+			if _, err := tapByteStream.Peek(1); err == io.EOF {
+				w.Close()
+				return
+			}
+
+			event := pb.TapEvent{}
+			if err := protohttp.FromByteStreamToProtocolBuffers(tapByteStream, &event); err != nil {
+				w.CloseWithError(err)
+				return
+			}
+
+			for _, filtered := range streams.Filter(&event) {
+				b, err := proto.Marshal(filtered)
+				if err != nil {
+					w.CloseWithError(err)
+					return
+				}
+				if _, err := w.Write(protohttp.SerializeAsPayload(b)); err != nil {
+					return
+				}
+			}
+		}
+	}()
+
+	return bufio.NewReader(r)
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/linkerd/linkerd2/controller/api/util"
+	"github.com/linkerd/linkerd2/pkg/protohttp"
+	"github.com/linkerd/linkerd2/pkg/tap"
+)
+
+func TestReplayTapEvents(t *testing.T) {
+	req, err := util.BuildTapByResourceRequest(util.TapRequestParams{
+		Resource:  "deploy/web",
+		Namespace: "emojivoto",
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	var stream []byte
+	for _, event := range harTestEvents() {
+		b, err := proto.Marshal(event)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		stream = append(stream, protohttp.SerializeAsPayload(b)...)
+	}
+
+	var recording bytes.Buffer
+	reader, err := tap.Record(&recording, req, bytes.NewReader(stream))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	var live bytes.Buffer
+	if err := writeTapEventsToBuffer(&live, reader, req, newTapOptions()); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	t.Run("Replays the recorded events", func(t *testing.T) {
+		var replayed bytes.Buffer
+		if err := replayTapEvents(&replayed, bytes.NewReader(recording.Bytes()), newTapOptions()); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if replayed.String() != live.String() {
+			t.Fatalf("Expected the replay to render:\n%s\nbut got:\n%s", live.String(), replayed.String())
+		}
+	})
+
+	t.Run("Filters the recorded events", func(t *testing.T) {
+		options := newTapOptions()
+		options.method = "POST"
+		options.output = wideOutput
+
+		var replayed bytes.Buffer
+		if err := replayTapEvents(&replayed, bytes.NewReader(recording.Bytes()), options); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		lines := strings.Split(strings.TrimSpace(replayed.String()), "\n")
+		if len(lines) != 1 || !strings.Contains(lines[0], ":method=POST") || !strings.Contains(lines[0], "dst_res=deploy/web") {
+			t.Fatalf("Expected only the POST request to be replayed, got:\n%s", replayed.String())
+		}
+	})
+
+	t.Run("Rejects invalid recordings", func(t *testing.T) {
+		err := replayTapEvents(&bytes.Buffer{}, strings.NewReader("not a recording"), newTapOptions())
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+	})
+}
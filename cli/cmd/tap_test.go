@@ -142,6 +142,8 @@ func busyTest(t *testing.T, output string) {
 		goldenFilePath = "testdata/tap_busy_output_wide.golden"
 	case jsonOutput:
 		goldenFilePath = "testdata/tap_busy_output_json.golden"
+	case jsonlOutput:
+		goldenFilePath = "testdata/tap_busy_output_jsonl.golden"
 	default:
 		goldenFilePath = "testdata/tap_busy_output.golden"
 	}
@@ -170,6 +172,10 @@ func TestRequestTapByResourceFromAPI(t *testing.T) {
 		busyTest(t, "json")
 	})
 
+	t.Run("Should render JSON lines busy response if everything went well", func(t *testing.T) {
+		busyTest(t, "jsonl")
+	})
+
 	t.Run("Should render empty response if no events returned", func(t *testing.T) {
 		resourceType := k8s.Pod
 		params := util.TapRequestParams{
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"io"
 	"sort"
@@ -35,6 +36,8 @@ type topOptions struct {
 	path          string
 	hideSources   bool
 	routes        bool
+	grpcMethods   bool
+	clientID      bool
 	labelSelector string
 }
 
@@ -75,7 +78,10 @@ type tableRow struct {
 	method      string
 	route       string
 	source      string
+	clientID    string
 	destination string
+	grpcService string
+	grpcMethod  string
 	count       int
 	best        time.Duration
 	worst       time.Duration
@@ -102,10 +108,13 @@ type column int
 
 const (
 	sourceColumn column = iota
+	clientIDColumn
 	destinationColumn
 	methodColumn
 	pathColumn
 	routeColumn
+	grpcServiceColumn
+	grpcMethodColumn
 	countColumn
 	bestColumn
 	worstColumn
@@ -135,6 +144,18 @@ func newTopTable() *topTable {
 			},
 		}
 
+	table.columns[clientIDColumn] =
+		tableColumn{
+			header:   "Client ID",
+			width:    23,
+			key:      false,
+			display:  false,
+			flexible: true,
+			value: func(r tableRow) string {
+				return r.clientID
+			},
+		}
+
 	table.columns[destinationColumn] =
 		tableColumn{
 			header:   "Destination",
@@ -183,6 +204,30 @@ func newTopTable() *topTable {
 			},
 		}
 
+	table.columns[grpcServiceColumn] =
+		tableColumn{
+			header:   "gRPC Service",
+			width:    37,
+			key:      false,
+			display:  false,
+			flexible: true,
+			value: func(r tableRow) string {
+				return r.grpcService
+			},
+		}
+
+	table.columns[grpcMethodColumn] =
+		tableColumn{
+			header:   "gRPC Method",
+			width:    23,
+			key:      false,
+			display:  false,
+			flexible: true,
+			value: func(r tableRow) string {
+				return r.grpcMethod
+			},
+		}
+
 	table.columns[countColumn] =
 		tableColumn{
 			header:     "Count",
@@ -269,6 +314,8 @@ func newTopOptions() *topOptions {
 		path:          "",
 		hideSources:   false,
 		routes:        false,
+		grpcMethods:   false,
+		clientID:      false,
 		labelSelector: "",
 	}
 }
@@ -314,7 +361,10 @@ func newCmdTop() *cobra.Command {
   linkerd top deploy/web
 
   # display traffic for the web-dlbvj pod in the default namespace
-  linkerd top pod/web-dlbvj`,
+  linkerd top pod/web-dlbvj
+
+  # display the gRPC methods of the voting deployment called by each client identity
+  linkerd top deploy/voting --grpc-methods --client-identity`,
 		Args:      cobra.RangeArgs(1, 2),
 		ValidArgs: util.ValidTargets,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -331,11 +381,31 @@ func newCmdTop() *cobra.Command {
 				LabelSelector: options.labelSelector,
 			}
 
-			if options.hideSources {
+			if options.routes && options.grpcMethods {
+				return errors.New("--routes and --grpc-methods are mutually exclusive")
+			}
+
+			if options.hideSources || options.clientID {
 				table.columns[sourceColumn].key = false
 				table.columns[sourceColumn].display = false
 			}
 
+			if options.clientID {
+				table.columns[clientIDColumn].key = true
+				table.columns[clientIDColumn].display = true
+			}
+
+			if options.grpcMethods {
+				table.columns[methodColumn].key = false
+				table.columns[methodColumn].display = false
+				table.columns[pathColumn].key = false
+				table.columns[pathColumn].display = false
+				table.columns[grpcServiceColumn].key = true
+				table.columns[grpcServiceColumn].display = true
+				table.columns[grpcMethodColumn].key = true
+				table.columns[grpcMethodColumn].display = true
+			}
+
 			if options.routes {
 				table.columns[methodColumn].key = false
 				table.columns[methodColumn].display = false
@@ -377,6 +447,8 @@ func newCmdTop() *cobra.Command {
 		"Display requests with paths that start with this prefix")
 	cmd.PersistentFlags().BoolVar(&options.hideSources, "hide-sources", options.hideSources, "Hide the source column")
 	cmd.PersistentFlags().BoolVar(&options.routes, "routes", options.routes, "Display data per route instead of per path")
+	cmd.PersistentFlags().BoolVar(&options.grpcMethods, "grpc-methods", options.grpcMethods, "Display data per gRPC service and method instead of per path")
+	cmd.PersistentFlags().BoolVar(&options.clientID, "client-identity", options.clientID, "Display data per client mTLS identity instead of per source")
 	cmd.PersistentFlags().StringVarP(&options.labelSelector, "selector", "l", options.labelSelector, "Selector (label query) to filter on, supports '=', '==', and '!='")
 
 	return cmd
@@ -549,6 +621,8 @@ func newRow(req topRequest) (tableRow, error) {
 	if pod := req.event.DestinationMeta.Labels["pod"]; pod != "" {
 		destination = pod
 	}
+	clientID := clientIdentity(req.event.GetSourceMeta().GetLabels())
+	grpcService, grpcMethod := grpcServiceAndMethod(path, req.rspEnd)
 
 	latency, err := ptypes.Duration(req.rspEnd.GetSinceRequestInit())
 	if err != nil {
@@ -589,7 +663,10 @@ func newRow(req topRequest) (tableRow, error) {
 		method:      method,
 		route:       route,
 		source:      source,
+		clientID:    clientID,
 		destination: destination,
+		grpcService: grpcService,
+		grpcMethod:  grpcMethod,
 		best:        latency,
 		worst:       latency,
 		last:        latency,
@@ -599,6 +676,31 @@ func newRow(req topRequest) (tableRow, error) {
 	}, nil
 }
 
+// clientIdentity returns the mTLS identity of the client of a request, as
+// <serviceaccount>.<namespace> like in "linkerd edges", or "-" if the request
+// wasn't sent over mTLS.
+func clientIdentity(labels map[string]string) string {
+	sa := labels["serviceaccount"]
+	ns := labels[k8s.Namespace]
+	if labels["tls"] != "true" || sa == "" || ns == "" {
+		return "-"
+	}
+	return sa + "." + ns
+}
+
+// grpcServiceAndMethod returns the gRPC service and method of a request from
+// its path, or "-" if the response wasn't a gRPC response.
+func grpcServiceAndMethod(path string, rspEnd *pb.TapEvent_Http_ResponseEnd) (string, string) {
+	if _, ok := rspEnd.GetEos().GetEnd().(*pb.Eos_GrpcStatusCode); !ok {
+		return "-", "-"
+	}
+	parts := strings.SplitN(strings.TrimPrefix(path, "/"), "/", 2)
+	if len(parts) != 2 {
+		return "-", "-"
+	}
+	return parts[0], parts[1]
+}
+
 func (t *topTable) insert(req topRequest) {
 	insert, err := newRow(req)
 	if err != nil {
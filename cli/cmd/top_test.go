@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"github.com/linkerd/linkerd2/pkg/addr"
+)
+
+func TestNewRow(t *testing.T) {
+	newRequest := func(path string, sourceLabels map[string]string, eos *pb.Eos) topRequest {
+		return topRequest{
+			event: &pb.TapEvent{
+				Source:          &pb.TcpAddress{Ip: addr.PublicIPV4(10, 0, 0, 1), Port: 34000},
+				SourceMeta:      &pb.TapEvent_EndpointMeta{Labels: sourceLabels},
+				Destination:     &pb.TcpAddress{Ip: addr.PublicIPV4(10, 0, 0, 2), Port: 8080},
+				DestinationMeta: &pb.TapEvent_EndpointMeta{Labels: map[string]string{"pod": "voting-abc"}},
+			},
+			reqInit: &pb.TapEvent_Http_RequestInit{Path: path},
+			rspInit: &pb.TapEvent_Http_ResponseInit{HttpStatus: 200},
+			rspEnd: &pb.TapEvent_Http_ResponseEnd{
+				SinceRequestInit: ptypes.DurationProto(time.Millisecond),
+				Eos:              eos,
+			},
+		}
+	}
+	grpcOK := &pb.Eos{End: &pb.Eos_GrpcStatusCode{GrpcStatusCode: 0}}
+	meshedClient := map[string]string{"pod": "web-xyz", "serviceaccount": "web", "namespace": "emojivoto", "tls": "true"}
+
+	testCases := []struct {
+		req         topRequest
+		clientID    string
+		grpcService string
+		grpcMethod  string
+	}{
+		{
+			req:         newRequest("/emojivoto.v1.VotingService/VoteDoughnut", meshedClient, grpcOK),
+			clientID:    "web.emojivoto",
+			grpcService: "emojivoto.v1.VotingService",
+			grpcMethod:  "VoteDoughnut",
+		},
+		{
+			req:         newRequest("/api/list", map[string]string{"pod": "web-xyz", "serviceaccount": "web", "namespace": "emojivoto", "tls": "no_identity"}, nil),
+			clientID:    "-",
+			grpcService: "-",
+			grpcMethod:  "-",
+		},
+		{
+			req:         newRequest("/", meshedClient, grpcOK),
+			clientID:    "web.emojivoto",
+			grpcService: "-",
+			grpcMethod:  "-",
+		},
+	}
+
+	for i, tc := range testCases {
+		tc := tc // pin
+		row, err := newRow(tc.req)
+		if err != nil {
+			t.Fatalf("test case %d: unexpected error: %s", i, err)
+		}
+		if row.clientID != tc.clientID {
+			t.Errorf("test case %d: expected client ID %s, got %s", i, tc.clientID, row.clientID)
+		}
+		if row.grpcService != tc.grpcService || row.grpcMethod != tc.grpcMethod {
+			t.Errorf("test case %d: expected gRPC method %s/%s, got %s/%s", i, tc.grpcService, tc.grpcMethod, row.grpcService, row.grpcMethod)
+		}
+		if row.source != "web-xyz" || row.destination != "voting-abc" {
+			t.Errorf("test case %d: unexpected source and destination %s -> %s", i, row.source, row.destination)
+		}
+	}
+}
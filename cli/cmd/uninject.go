@@ -3,35 +3,56 @@ package cmd
 import (
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
 
+	jsonpatch "github.com/evanphx/json-patch"
 	"github.com/linkerd/linkerd2/controller/gen/config"
 	"github.com/linkerd/linkerd2/pkg/inject"
 	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
 )
 
 type resourceTransformerUninject struct {
-	configs *config.All
+	configs          *config.All
+	podTemplatePaths map[string]string
+
+	// diff receives the changes made to each resource, if not nil
+	diff io.Writer
 }
 
 type resourceTransformerUninjectSilent struct {
-	configs *config.All
+	configs          *config.All
+	podTemplatePaths map[string]string
+	diff             io.Writer
+}
+
+func runUninjectCmd(inputs []io.Reader, errWriter, outWriter io.Writer, conf *config.All, podTemplatePaths map[string]string) int {
+	return transformInput(inputs, errWriter, outWriter, resourceTransformerUninject{configs: conf, podTemplatePaths: podTemplatePaths})
 }
 
-func runUninjectCmd(inputs []io.Reader, errWriter, outWriter io.Writer, conf *config.All) int {
-	return transformInput(inputs, errWriter, outWriter, resourceTransformerUninject{conf})
+func runUninjectSilentCmd(inputs []io.Reader, errWriter, outWriter io.Writer, conf *config.All, podTemplatePaths map[string]string) int {
+	return transformInput(inputs, errWriter, outWriter, resourceTransformerUninjectSilent{configs: conf, podTemplatePaths: podTemplatePaths})
 }
 
-func runUninjectSilentCmd(inputs []io.Reader, errWriter, outWriter io.Writer, conf *config.All) int {
-	return transformInput(inputs, errWriter, outWriter, resourceTransformerUninjectSilent{conf})
+// runUninjectDryRunCmd prints the changes uninject would make to each
+// resource to outWriter, instead of the uninjected resources
+func runUninjectDryRunCmd(inputs []io.Reader, errWriter, outWriter io.Writer, conf *config.All, podTemplatePaths map[string]string) int {
+	return transformInput(inputs, errWriter, ioutil.Discard, resourceTransformerUninject{configs: conf, podTemplatePaths: podTemplatePaths, diff: outWriter})
 }
 
 func newCmdUninject() *cobra.Command {
+	var dryRun bool
+	var podTemplatePaths map[string]string
+
 	cmd := &cobra.Command{
 		Use:   "uninject [flags] CONFIG-FILE",
 		Short: "Remove the Linkerd proxy from a Kubernetes config",
 		Long: `Remove the Linkerd proxy from a Kubernetes config.
 
+Only the containers, volumes, annotations and labels added by the injection
+are removed, leaving other sidecars and their configuration untouched.
+
 You can uninject resources contained in a single file, inside a folder and its
 sub-folders, or coming from stdin.`,
 		Example: `  # Uninject all the deployments in the default namespace.
@@ -41,7 +62,10 @@ sub-folders, or coming from stdin.`,
   curl http://url.to/yml | linkerd uninject - | kubectl apply -f -
 
   # Uninject all the resources inside a folder and its sub-folders.
-  linkerd uninject <folder> | kubectl apply -f -`,
+  linkerd uninject <folder> | kubectl apply -f -
+
+  # Print the changes uninject would make to all the deployments.
+  kubectl get deploy -o yaml | linkerd uninject --dry-run -`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 
 			if len(args) < 1 {
@@ -53,30 +77,68 @@ sub-folders, or coming from stdin.`,
 				return err
 			}
 
-			exitCode := runUninjectCmd(in, os.Stderr, os.Stdout, nil)
+			if err := validatePodTemplatePaths(podTemplatePaths); err != nil {
+				return err
+			}
+
+			if dryRun {
+				os.Exit(runUninjectDryRunCmd(in, os.Stderr, os.Stdout, nil, podTemplatePaths))
+			}
+			exitCode := runUninjectCmd(in, os.Stderr, os.Stdout, nil, podTemplatePaths)
 			os.Exit(exitCode)
 			return nil
 		},
 	}
 
+	cmd.Flags().BoolVar(&dryRun, "dry-run", dryRun,
+		"Print the changes uninject would make to each resource, instead of the uninjected resources")
+	cmd.Flags().StringToStringVar(&podTemplatePaths, "pod-template-path", podTemplatePaths,
+		"JSON pointer to the pod template of the custom resources of a kind, as <kind>=<path>, e.g. SparkCluster=/spec/worker/template. Argo Rollouts are supported out of the box.")
+
 	return cmd
 }
 
 func (rt resourceTransformerUninject) transform(bytes []byte) ([]byte, []inject.Report, error) {
-	conf := inject.NewResourceConfig(rt.configs, inject.OriginWebhook)
+	conf := inject.NewResourceConfig(rt.configs, inject.OriginWebhook).WithPodTemplatePaths(rt.podTemplatePaths)
 
 	report, err := conf.ParseMetaAndYAML(bytes)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	output, err := conf.Uninject(report)
+	patchJSON, err := conf.UninjectPatch(report)
 	if err != nil {
 		return nil, nil, err
 	}
-	if output == nil {
-		output = bytes
+	if patchJSON == nil {
 		report.UnsupportedResource = true
+		return bytes, []inject.Report{*report}, nil
+	}
+
+	// the patch only removes what was injected, so it's applied to the
+	// input itself rather than to its parsed representation, which would
+	// drop the fields it doesn't know about
+	origJSON, err := yaml.YAMLToJSON(bytes)
+	if err != nil {
+		return nil, nil, err
+	}
+	patch, err := jsonpatch.DecodePatch(patchJSON)
+	if err != nil {
+		return nil, nil, err
+	}
+	uninjectedJSON, err := patch.Apply(origJSON)
+	if err != nil {
+		return nil, nil, err
+	}
+	output, err := yaml.JSONToYAML(uninjectedJSON)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if rt.diff != nil {
+		if err := writeUninjectDiff(rt.diff, report, origJSON, output); err != nil {
+			return nil, nil, err
+		}
 	}
 	return output, []inject.Report{*report}, nil
 }
@@ -85,6 +147,25 @@ func (rt resourceTransformerUninjectSilent) transform(bytes []byte) ([]byte, []i
 	return resourceTransformerUninject(rt).transform(bytes)
 }
 
+// writeUninjectDiff writes the changes made by uninject to the resource of
+// report, from its origJSON to its uninjected YAML
+func writeUninjectDiff(w io.Writer, report *inject.Report, origJSON, uninjected []byte) error {
+	orig, err := yaml.JSONToYAML(origJSON)
+	if err != nil {
+		return err
+	}
+	lines := diffLines(orig, uninjected)
+	if len(lines) == 0 || (len(lines) == 1 && lines[0] == "...") {
+		fmt.Fprintf(w, "%s: no changes\n", report.ResName())
+		return nil
+	}
+	fmt.Fprintf(w, "%s:\n", report.ResName())
+	for _, line := range lines {
+		fmt.Fprintf(w, "    %s\n", line)
+	}
+	return nil
+}
+
 func (resourceTransformerUninject) generateReport(reports []inject.Report, output io.Writer) {
 	// leading newline to separate from yaml output on stdout
 	output.Write([]byte("\n"))
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strings"
 	"testing"
 )
 
@@ -59,6 +60,22 @@ func TestUninjectYAML(t *testing.T) {
 			goldenFileName: "inject_emojivoto_pod.input.yml",
 			reportFileName: "inject_emojivoto_pod_uninject.report",
 		},
+		{
+			// keep the config.linkerd.io/enable-debug-sidecar annotation
+			inputFileName:  "inject_emojivoto_deployment_debug.golden.yml",
+			goldenFileName: "inject_emojivoto_deployment_debug_uninjected.golden.yml",
+			reportFileName: "inject_emojivoto_deployment_uninject.report",
+		},
+		{
+			inputFileName:  "inject_emojivoto_job.golden.yml",
+			goldenFileName: "inject_emojivoto_job.input.yml",
+			reportFileName: "inject_emojivoto_job_uninject.report",
+		},
+		{
+			inputFileName:  "inject_emojivoto_rollout.golden.yml",
+			goldenFileName: "inject_emojivoto_rollout.input.yml",
+			reportFileName: "inject_emojivoto_rollout_uninject.report",
+		},
 		{
 			inputFileName:  "inject_emojivoto_pod_with_requests.golden.yml",
 			goldenFileName: "inject_emojivoto_pod_with_requests.input.yml",
@@ -84,6 +101,12 @@ func TestUninjectYAML(t *testing.T) {
 			goldenFileName: "inject_emojivoto_deployment_config_overrides.input.yml",
 			reportFileName: "inject_emojivoto_deployment_uninject.report",
 		},
+		{
+			// keep the vault-agent sidecar, its volume and annotations
+			inputFileName:  "uninject_emojivoto_deployment_sidecars.input.yml",
+			goldenFileName: "uninject_emojivoto_deployment_sidecars.golden.yml",
+			reportFileName: "inject_emojivoto_deployment_uninject.report",
+		},
 		{
 			inputFileName:  "inject_emojivoto_namespace_good.golden.yml",
 			goldenFileName: "inject_emojivoto_namespace_uninjected_good.golden.yml",
@@ -109,7 +132,7 @@ func TestUninjectYAML(t *testing.T) {
 			output := new(bytes.Buffer)
 			report := new(bytes.Buffer)
 
-			exitCode := runUninjectCmd(read, report, output, nil)
+			exitCode := runUninjectCmd(read, report, output, nil, nil)
 			if exitCode != 0 {
 				t.Errorf("Failed to uninject %s\n", tc.inputFileName)
 			}
@@ -119,3 +142,34 @@ func TestUninjectYAML(t *testing.T) {
 		})
 	}
 }
+
+func TestUninjectDryRun(t *testing.T) {
+	file, err := os.Open("testdata/uninject_emojivoto_deployment_sidecars.input.yml")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	defer file.Close()
+
+	output := new(bytes.Buffer)
+	report := new(bytes.Buffer)
+	if exitCode := runUninjectDryRunCmd([]io.Reader{file}, report, output, nil, nil); exitCode != 0 {
+		t.Fatalf("Expected exit code 0, got %d\nstderr:\n%s", exitCode, report.String())
+	}
+
+	expected := []string{
+		"deployment/web:",
+		"    -        linkerd.io/created-by: linkerd/cli dev-undefined",
+		"    -        name: linkerd-init",
+		"    -        name: linkerd-identity-end-entity",
+	}
+	for _, e := range expected {
+		if !strings.Contains(output.String(), e) {
+			t.Errorf("Expected output to contain %q, got:\n%s", e, output.String())
+		}
+	}
+	for _, line := range strings.Split(output.String(), "\n") {
+		if strings.HasPrefix(line, "    -") && strings.Contains(line, "vault") {
+			t.Errorf("Expected the vault-agent sidecar to be left untouched, got:\n%s", output.String())
+		}
+	}
+}
@@ -1,30 +1,39 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"strings"
 
 	"github.com/ghodss/yaml"
 	"github.com/linkerd/linkerd2/pkg/k8s"
 	"github.com/spf13/cobra"
 	admissionRegistration "k8s.io/api/admissionregistration/v1beta1"
 	core "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
 	policy "k8s.io/api/policy/v1beta1"
 	rbac "k8s.io/api/rbac/v1"
 	apiextension "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	apiRegistration "k8s.io/kube-aggregator/pkg/apis/apiregistration/v1"
-	apiregistrationv1client "k8s.io/kube-aggregator/pkg/client/clientset_generated/clientset/typed/apiregistration/v1"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 const (
 	yamlSep = "---\n"
 )
 
+var securityContextConstraintsResource = schema.GroupVersionResource{
+	Group:    "security.openshift.io",
+	Version:  "v1",
+	Resource: "securitycontextconstraints",
+}
+
 type kubernetesResource struct {
 	runtime.TypeMeta
 	metav1.ObjectMeta `json:"metadata"`
@@ -42,29 +51,48 @@ func newKubernetesResource(apiVersion, kind, name string) kubernetesResource {
 	}
 }
 
+type uninstallOptions struct {
+	force bool
+}
+
 func newCmdUninstall() *cobra.Command {
+	options := uninstallOptions{}
+
 	cmd := &cobra.Command{
 		Use:   "uninstall",
 		Args:  cobra.NoArgs,
 		Short: "Output Kubernetes resources to uninstall Linkerd control plane",
 		Long: `Output Kubernetes resources to uninstall Linkerd control plane.
 
-This command provides all Kubernetes namespace-scoped and cluster-scoped resources (e.g services, deployments, RBACs, etc.) necessary to uninstall Linkerd control plane.`,
-		Example: ` linkerd uninstall | kubectl delete -f -`,
+This command provides all Kubernetes namespace-scoped and cluster-scoped resources (e.g services, deployments, RBACs, etc.) necessary to uninstall Linkerd control plane.
+
+The command fails if some pods outside of the control plane namespace are still injected, as their proxies would lose their identity and destination services; uninject them first, or use --force to uninstall anyway.`,
+		Example: `  linkerd uninstall | kubectl delete -f -
+
+  # Uninstall even if some workloads are still injected
+  linkerd uninstall --force | kubectl delete -f -`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return uninstallRunE()
+			return uninstallRunE(options)
 		},
 	}
 
+	cmd.Flags().BoolVar(&options.force, "force", options.force, "Output the resources even if some workloads are still injected")
+
 	return cmd
 }
 
-func uninstallRunE() error {
+func uninstallRunE(options uninstallOptions) error {
 	k8sAPI, err := k8s.NewAPI(kubeconfigPath, kubeContext, impersonate, impersonateGroup, 0)
 	if err != nil {
 		return err
 	}
 
+	if !options.force {
+		if err := checkNoInjectedPods(k8sAPI); err != nil {
+			return err
+		}
+	}
+
 	resources, err := fetchKubernetesResources(k8sAPI)
 	if err != nil {
 		return err
@@ -136,6 +164,12 @@ func fetchKubernetesResources(k *k8s.KubernetesAPI) ([]kubernetesResource, error
 	}
 	resources = append(resources, psps...)
 
+	sccs, err := fetchSecurityContextConstraints(k, options)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch SecurityContextConstraints resources:%v", err)
+	}
+	resources = append(resources, sccs...)
+
 	mutatinghooks, err := fetchMutatingWebhooksConfiguration(k, options)
 	if err != nil {
 		return nil, fmt.Errorf("could not fetch MutatingWebhookConfigurations:%v", err)
@@ -148,6 +182,24 @@ func fetchKubernetesResources(k *k8s.KubernetesAPI) ([]kubernetesResource, error
 	}
 	resources = append(resources, validationhooks...)
 
+	configMaps, err := fetchControlPlaneConfigMaps(k, options)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch ConfigMaps from %s namespace:%v", controlPlaneNamespace, err)
+	}
+	resources = append(resources, configMaps...)
+
+	secrets, err := fetchControlPlaneSecrets(k, options)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch Secrets from %s namespace:%v", controlPlaneNamespace, err)
+	}
+	resources = append(resources, secrets...)
+
+	networkPolicies, err := fetchDataPlaneNetworkPolicies(k)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch NetworkPolicies:%v", err)
+	}
+	resources = append(resources, networkPolicies...)
+
 	namespace, err := fetchNamespaceResource(k)
 	if err != nil {
 		return nil, fmt.Errorf("could not fetch Namespace %s:%v", controlPlaneNamespace, err)
@@ -220,6 +272,87 @@ func fetchCustomResourceDefinitions(k *k8s.KubernetesAPI, options metav1.ListOpt
 	return resources, nil
 }
 
+// checkNoInjectedPods returns an error listing the pods outside of the control
+// plane namespace whose proxies still depend on the control plane
+func checkNoInjectedPods(k *k8s.KubernetesAPI) error {
+	list, err := k.CoreV1().Pods("").List(metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", k8s.ControllerNSLabel, controlPlaneNamespace),
+	})
+	if err != nil {
+		return fmt.Errorf("could not list injected pods:%v", err)
+	}
+
+	var pods []string
+	for _, pod := range list.Items {
+		if pod.Namespace != controlPlaneNamespace {
+			pods = append(pods, fmt.Sprintf("\t* %s/%s", pod.Namespace, pod.Name))
+		}
+	}
+	if len(pods) > 0 {
+		return errors.New("Some pods are still injected, uninject them first or use --force:\n" + strings.Join(pods, "\n"))
+	}
+
+	return nil
+}
+
+// fetchControlPlaneConfigMaps returns the ConfigMaps of the control plane.
+// They live in the control plane namespace, but they are listed as well so
+// that they're deleted when the namespace wasn't created by the install (e.g.
+// `installNamespace: false` with Helm), just like fetchControlPlaneSecrets
+// does for the identity issuer resources
+func fetchControlPlaneConfigMaps(k *k8s.KubernetesAPI, options metav1.ListOptions) ([]kubernetesResource, error) {
+	list, err := k.CoreV1().ConfigMaps(controlPlaneNamespace).List(options)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]kubernetesResource, len(list.Items))
+	for i, item := range list.Items {
+		r := newKubernetesResource(core.SchemeGroupVersion.String(), "ConfigMap", item.Name)
+		r.Namespace = item.Namespace
+		resources[i] = r
+	}
+	return resources, nil
+}
+
+func fetchControlPlaneSecrets(k *k8s.KubernetesAPI, options metav1.ListOptions) ([]kubernetesResource, error) {
+	list, err := k.CoreV1().Secrets(controlPlaneNamespace).List(options)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]kubernetesResource, len(list.Items))
+	for i, item := range list.Items {
+		r := newKubernetesResource(core.SchemeGroupVersion.String(), "Secret", item.Name)
+		r.Namespace = item.Namespace
+		resources[i] = r
+	}
+	return resources, nil
+}
+
+// fetchDataPlaneNetworkPolicies returns the NetworkPolicies rendered in the
+// namespaces listed in dataPlaneNetworkPolicyNamespaces, which aren't deleted
+// along with the control plane namespace
+func fetchDataPlaneNetworkPolicies(k *k8s.KubernetesAPI) ([]kubernetesResource, error) {
+	list, err := k.NetworkingV1().NetworkPolicies("").List(metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", k8s.ControllerNSLabel, controlPlaneNamespace),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var resources []kubernetesResource
+	for _, item := range list.Items {
+		if item.Namespace == controlPlaneNamespace {
+			continue
+		}
+		r := newKubernetesResource(networking.SchemeGroupVersion.String(), "NetworkPolicy", item.Name)
+		r.Namespace = item.Namespace
+		resources = append(resources, r)
+	}
+	return resources, nil
+}
+
 func fetchNamespaceResource(k *k8s.KubernetesAPI) (kubernetesResource, error) {
 	obj, err := k.CoreV1().Namespaces().Get(controlPlaneNamespace, metav1.GetOptions{})
 	if err != nil {
@@ -246,6 +379,30 @@ func fetchPodSecurityPolicy(k *k8s.KubernetesAPI, options metav1.ListOptions) ([
 	return resources, nil
 }
 
+// fetchSecurityContextConstraints returns the SCCs rendered by
+// `linkerd install --openshift`. There are none on clusters that don't serve
+// the OpenShift security API, nor in manifests read with --from-manifests.
+func fetchSecurityContextConstraints(k *k8s.KubernetesAPI, options metav1.ListOptions) ([]kubernetesResource, error) {
+	if k.DynamicClient == nil {
+		return nil, nil
+	}
+
+	list, err := k.DynamicClient.Resource(securityContextConstraintsResource).List(options)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	resources := make([]kubernetesResource, len(list.Items))
+	for i, item := range list.Items {
+		resources[i] = newKubernetesResource(securityContextConstraintsResource.GroupVersion().String(), "SecurityContextConstraints", item.GetName())
+	}
+
+	return resources, nil
+}
+
 func fetchValidatingWebhooksConfiguration(k *k8s.KubernetesAPI, options metav1.ListOptions) ([]kubernetesResource, error) {
 	list, err := k.AdmissionregistrationV1beta1().ValidatingWebhookConfigurations().List(options)
 	if err != nil {
@@ -274,12 +431,7 @@ func fetchMutatingWebhooksConfiguration(k *k8s.KubernetesAPI, options metav1.Lis
 	return resources, nil
 }
 func fetchAPIRegistrationResources(k *k8s.KubernetesAPI, options metav1.ListOptions) ([]kubernetesResource, error) {
-	apiClient, err := apiregistrationv1client.NewForConfig(k.Config)
-	if err != nil {
-		return nil, err
-	}
-
-	list, err := apiClient.APIServices().List(options)
+	list, err := k.Apiregistration.ApiregistrationV1().APIServices().List(options)
 	if err != nil {
 		return nil, err
 	}
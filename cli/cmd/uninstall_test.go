@@ -58,3 +58,68 @@ metadata:
 		t.Errorf("mismatch in resource name: expected %s and got %s", expName, rbacResource.Name)
 	}
 }
+
+func TestCheckNoInjectedPods(t *testing.T) {
+	controlPlanePod := `apiVersion: v1
+kind: Pod
+metadata:
+  name: linkerd-controller
+  namespace: linkerd
+  labels:
+    linkerd.io/control-plane-ns: linkerd`
+	injectedPod := `apiVersion: v1
+kind: Pod
+metadata:
+  name: emoji
+  namespace: emojivoto
+  labels:
+    linkerd.io/control-plane-ns: linkerd`
+
+	fakeK8sAPI, err := k8s.NewFakeAPI(controlPlanePod)
+	if err != nil {
+		t.Fatalf("Unexpected error creating fake k8s clientset:%v", err)
+	}
+	if err := checkNoInjectedPods(fakeK8sAPI); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	fakeK8sAPI, err = k8s.NewFakeAPI(controlPlanePod, injectedPod)
+	if err != nil {
+		t.Fatalf("Unexpected error creating fake k8s clientset:%v", err)
+	}
+	expected := "Some pods are still injected, uninject them first or use --force:\n\t* emojivoto/emoji"
+	if err := checkNoInjectedPods(fakeK8sAPI); err == nil || err.Error() != expected {
+		t.Fatalf("Expected error %q, got %v", expected, err)
+	}
+}
+
+func TestFetchDataPlaneNetworkPolicies(t *testing.T) {
+	fakeK8sAPI, err := k8s.NewFakeAPI(`apiVersion: networking.k8s.io/v1
+kind: NetworkPolicy
+metadata:
+  name: linkerd-control-plane
+  namespace: linkerd
+  labels:
+    linkerd.io/control-plane-ns: linkerd`, `apiVersion: networking.k8s.io/v1
+kind: NetworkPolicy
+metadata:
+  name: linkerd-proxy-admin-access
+  namespace: emojivoto
+  labels:
+    linkerd.io/control-plane-ns: linkerd`, `apiVersion: networking.k8s.io/v1
+kind: NetworkPolicy
+metadata:
+  name: default-deny
+  namespace: emojivoto`)
+	if err != nil {
+		t.Fatalf("Unexpected error creating fake k8s clientset:%v", err)
+	}
+
+	resources, err := fetchDataPlaneNetworkPolicies(fakeK8sAPI)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if len(resources) != 1 || resources[0].Namespace != "emojivoto" || resources[0].Name != "linkerd-proxy-admin-access" {
+		t.Fatalf("Expected only emojivoto/linkerd-proxy-admin-access, got %v", resources)
+	}
+}
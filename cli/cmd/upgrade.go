@@ -9,6 +9,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/golang/protobuf/ptypes"
 	pb "github.com/linkerd/linkerd2/controller/gen/config"
 	charts "github.com/linkerd/linkerd2/pkg/charts/linkerd2"
 	"github.com/linkerd/linkerd2/pkg/config"
@@ -27,15 +28,30 @@ import (
 )
 
 const (
+	configMessage          = "Don't forget to run `linkerd upgrade config`!"
 	controlPlaneMessage    = "Don't forget to run `linkerd upgrade control-plane`!"
 	failMessage            = "For troubleshooting help, visit: https://linkerd.io/upgrade/#troubleshooting\n"
 	trustRootChangeMessage = "Rotating the trust anchors will affect existing proxies\nSee https://linkerd.io/2/tasks/rotating_identity_certificates/ for more information"
+	issuerRotationMessage  = "The new issuer certificate is staged. The linkerd-identity-rotation cronjob restarts the meshed workloads\nso that they trust it, switches to it once they all do, and removes the previous trust anchor after --rotation-overlap"
+
+	defaultRotationOverlap = 48 * time.Hour
 )
 
 type upgradeOptions struct {
-	addOnOverwrite bool
-	manifests      string
-	force          bool
+	addOnOverwrite  bool
+	manifests       string
+	force           bool
+	rotateIssuer    bool
+	rotationOverlap time.Duration
+	history         bool
+	toRevision      int
+	rollback        bool
+	ignoreSkew      bool
+	prune           bool
+	diff            bool
+	progressive     bool
+	pauseAfter      string
+	mergeLiveEdits  bool
 	*installOptions
 
 	verifyTLS func(tls *charts.TLS, service string) error
@@ -48,9 +64,11 @@ func newUpgradeOptionsWithDefaults() (*upgradeOptions, error) {
 	}
 
 	return &upgradeOptions{
-		manifests:      "",
-		installOptions: installOptions,
-		verifyTLS:      verifyWebhookTLS,
+		manifests:       "",
+		rotationOverlap: defaultRotationOverlap,
+		mergeLiveEdits:  false,
+		installOptions:  installOptions,
+		verifyTLS:       verifyWebhookTLS,
 	}, nil
 }
 
@@ -62,19 +80,94 @@ func (options *upgradeOptions) upgradeOnlyFlagSet() *pflag.FlagSet {
 
 	flags.StringVar(
 		&options.manifests, "from-manifests", options.manifests,
-		"Read config from a Linkerd install YAML rather than from Kubernetes",
+		"Read config from a Linkerd install YAML, a directory of manifests or an HTTPS URL rather than from Kubernetes (\"-\" for stdin)",
 	)
 	flags.BoolVar(
 		&options.force, "force", options.force,
 		"Force upgrade operation even when issuer certificate does not work with the trust anchors of all proxies",
 	)
+	flags.BoolVar(
+		&options.rotateIssuer, "rotate-issuer", options.rotateIssuer,
+		"Generate a new issuer certificate and trust anchor, and stage them to replace the current ones once all the meshed pods trust them",
+	)
+	flags.DurationVar(
+		&options.rotationOverlap, "rotation-overlap", options.rotationOverlap,
+		"How long to keep trusting the current trust anchors once the issuer rotated with --rotate-issuer is in use; it must exceed the identity issuance lifetime",
+	)
+	flags.BoolVar(
+		&options.history, "history", options.history,
+		"List the configurations recorded by the prior installs and upgrades, and exit",
+	)
+	flags.IntVar(
+		&options.toRevision, "to-revision", options.toRevision,
+		"Upgrade using the configuration recorded by the given revision (see --history) instead of the current one; the identity issuer and the add-on configuration are kept as is",
+	)
+	flags.BoolVar(
+		&options.rollback, "rollback", options.rollback,
+		"Output the manifest recorded by the revision prior to the current one (see --history) as it was applied, to go back to it; the Secrets aren't recorded, so the current ones are output along with it",
+	)
+	flags.BoolVar(
+		&options.ignoreSkew, "ignore-version-skew", options.ignoreSkew,
+		"Upgrade even if the target version is further from the current control plane or proxies versions than supported",
+	)
+	flags.BoolVar(
+		&options.prune, "prune", options.prune,
+		"Output the resources of the control plane that the upgrade doesn't render anymore, to be deleted, instead of the upgrade manifest",
+	)
+	flags.BoolVar(
+		&options.mergeLiveEdits, "merge-live-edits", options.mergeLiveEdits,
+		"Preserve the changes made to the resources since they were last applied with kubectl, e.g. added tolerations, by merging them into the upgrade manifest, which takes precedence on conflicts",
+	)
+	flags.BoolVar(
+		&options.diff, "diff", options.diff,
+		"Output the differences between the resources of the control plane and the upgrade manifest, instead of the upgrade manifest",
+	)
+	flags.BoolVar(
+		&options.progressive, "progressive", options.progressive,
+		"Apply the upgrade to the cluster one control plane component at a time, checking the control plane health after each one, instead of printing it",
+	)
+	flags.StringVar(
+		&options.pauseAfter, "pause-after", options.pauseAfter,
+		fmt.Sprintf("Pause a --progressive upgrade after the given step; one of: %s, %s, %s", progressiveConfigStep, strings.Join(progressiveComponents, ", "), progressiveAddOnsStep),
+	)
+	flags.DurationVar(
+		&options.applyTimeout, "apply-timeout", options.applyTimeout,
+		"How long to wait for each step of a --progressive upgrade to be ready and healthy",
+	)
 	flags.BoolVar(
 		&options.addOnOverwrite, "addon-overwrite", options.addOnOverwrite,
 		"Overwrite (instead of merge) existing add-ons config with file in --addon-config (or reset to defaults if no new config is passed)",
 	)
+	flags.AddFlagSet(options.valuesOptions.valueFilesFlagSet())
+	flags.AddFlagSet(options.postRendererFlagSet())
+	flags.StringVarP(
+		&options.output, "output", "o", options.output,
+		fmt.Sprintf("Output format. One of: %s, %s (a stream of JSON objects, one per line)", yamlOutput, jsonOutput),
+	)
 	return flags
 }
 
+// newCmdUpgradeCRDs is a subcommand for `linkerd upgrade crds`
+func newCmdUpgradeCRDs(options *upgradeOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "crds [flags]",
+		Args:  cobra.NoArgs,
+		Short: "Output Kubernetes CustomResourceDefinitions to upgrade an existing Linkerd",
+		Long: `Output Kubernetes CustomResourceDefinitions to upgrade an existing Linkerd.
+
+Note that this command should be followed by "linkerd upgrade config".`,
+		Example: `  # Default upgrade.
+  linkerd upgrade crds | kubectl apply -f -`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return upgradeRunE(options, crdsStage, options.recordableFlagSet())
+		},
+	}
+
+	cmd.Flags().AddFlagSet(options.allStageFlagSet())
+
+	return cmd
+}
+
 // newCmdUpgradeConfig is a subcommand for `linkerd upgrade config`
 func newCmdUpgradeConfig(options *upgradeOptions) *cobra.Command {
 	cmd := &cobra.Command{
@@ -83,7 +176,8 @@ func newCmdUpgradeConfig(options *upgradeOptions) *cobra.Command {
 		Short: "Output Kubernetes cluster-wide resources to upgrade an existing Linkerd",
 		Long: `Output Kubernetes cluster-wide resources to upgrade an existing Linkerd.
 
-Note that this command should be followed by "linkerd upgrade control-plane".`,
+Note that this command should be run after "linkerd upgrade crds", and followed
+by "linkerd upgrade control-plane".`,
 		Example: `  # Default upgrade.
   linkerd upgrade config | kubectl apply -f -`,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -143,8 +237,34 @@ install command.`,
 		Example: `  # Default upgrade.
   linkerd upgrade | kubectl apply --prune -l linkerd.io/control-plane-ns=linkerd -f -
 
-  # Similar to install, upgrade may also be broken up into two stages, by user
-  # privilege.`,
+  # Upgrade using the same values file as the install.
+  linkerd upgrade -f linkerd-values.yaml | kubectl apply --prune -l linkerd.io/control-plane-ns=linkerd -f -
+
+  # List the prior configurations, and go back to one of them.
+  linkerd upgrade --history
+  linkerd upgrade --to-revision 3 | kubectl apply --prune -l linkerd.io/control-plane-ns=linkerd -f -
+
+  # Recover from a bad upgrade by going back to the previous revision.
+  linkerd upgrade --rollback | kubectl apply --prune -l linkerd.io/control-plane-ns=linkerd -f -
+
+  # Review the changes before applying them.
+  linkerd upgrade --diff
+
+  # Roll the upgrade out one component at a time, checking the control plane
+  # health in between, and pause once the destination is upgraded.
+  linkerd upgrade --progressive --pause-after destination
+
+  # Upgrade offline, from the manifests of a GitOps repository checkout.
+  linkerd upgrade --from-manifests ./clusters/prod/linkerd > linkerd-upgrade.yaml
+
+  # Delete the control plane resources that the upgrade doesn't render anymore.
+  linkerd upgrade --prune | kubectl delete -f -
+
+  # Similar to install, upgrade may also be broken up into three stages, by user
+  # privilege.
+  linkerd upgrade crds | kubectl apply -f -
+  linkerd upgrade config | kubectl apply -f -
+  linkerd upgrade control-plane | kubectl apply -f -`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return upgradeRunE(options, "", flags)
 		},
@@ -153,6 +273,7 @@ install command.`,
 	cmd.Flags().AddFlagSet(flags)
 	cmd.PersistentFlags().AddFlagSet(upgradeOnlyFlags)
 
+	cmd.AddCommand(newCmdUpgradeCRDs(options))
 	cmd.AddCommand(newCmdUpgradeConfig(options))
 	cmd.AddCommand(newCmdUpgradeControlPlane(options))
 
@@ -164,11 +285,31 @@ func upgradeRunE(options *upgradeOptions, stage string, flags *pflag.FlagSet) er
 		panic("ignore cluster must be unset") // Programmer error.
 	}
 
+	// The resources of the other stages would be reported as prunable
+	if options.prune && stage != "" {
+		upgradeErrorf("--prune can't be used with the %s stage", stage)
+	}
+	if options.progressive {
+		if stage != "" {
+			upgradeErrorf("--progressive can't be used with the %s stage", stage)
+		}
+		if options.manifests != "" {
+			upgradeErrorf("--progressive can't be used with --from-manifests")
+		}
+		if options.pauseAfter != "" {
+			if err := validateProgressiveStep(options.pauseAfter); err != nil {
+				upgradeErrorf("%s", err)
+			}
+		}
+	} else if options.pauseAfter != "" {
+		upgradeErrorf("--pause-after can only be used with --progressive")
+	}
+
 	// We need a Kubernetes client to fetch configs and issuer secrets.
 	var k *k8s.KubernetesAPI
 	var err error
 	if options.manifests != "" {
-		readers, err := read(options.manifests)
+		readers, err := readUpgradeManifests(options.manifests)
 		if err != nil {
 			upgradeErrorf("Failed to parse manifests from %s: %s", options.manifests, err)
 		}
@@ -184,31 +325,140 @@ func upgradeRunE(options *upgradeOptions, stage string, flags *pflag.FlagSet) er
 		}
 	}
 
-	values, err := options.validateAndBuild(stage, k, flags)
+	history, err := fetchConfigHistory(k)
 	if err != nil {
-		upgradeErrorf("Failed to build upgrade configuration: %s", err)
+		upgradeErrorf("Failed to fetch the configuration history: %s", err)
+	}
+
+	if options.history {
+		writeConfigHistory(os.Stdout, history)
+		return nil
 	}
 
 	// rendering to a buffer and printing full contents of buffer after
 	// render is complete, to ensure that okStatus prints separately
 	var buf bytes.Buffer
-	if err = render(&buf, values); err != nil {
-		upgradeErrorf("Could not render upgrade configuration: %s", err)
+	var configs charts.ConfigJSONs
+	recordedFlags := options.recordedFlags
+	command := "upgrade"
+	if options.rollback {
+		previous, err := options.rollbackRevision(stage, flags, history)
+		if err != nil {
+			upgradeErrorf("Failed to roll back: %s", err)
+		}
+		manifest, err := revisionManifest(previous)
+		if err != nil {
+			upgradeErrorf("Failed to roll back: %s", err)
+		}
+		secrets, err := currentSecrets(k)
+		if err != nil {
+			upgradeErrorf("Failed to fetch the current Secrets: %s", err)
+		}
+		buf.Write(manifest)
+		buf.Write(secrets)
+		configs, recordedFlags, command = previous.Configs, previous.Flags, "rollback"
+	} else {
+		values, err := options.validateAndBuild(stage, k, flags)
+		if err != nil {
+			upgradeErrorf("Failed to build upgrade configuration: %s", err)
+		}
+
+		if err = render(&buf, values); err != nil {
+			upgradeErrorf("Could not render upgrade configuration: %s", err)
+		}
+
+		if options.postRenderer != "" {
+			if err = postRender(options.postRenderer, &buf); err != nil {
+				upgradeErrorf("Could not post-render upgrade configuration: %s", err)
+			}
+		}
+		configs = values.Configs
+	}
+
+	if options.diff {
+		if err = writeUpgradeDiff(os.Stdout, k, buf.Bytes(), stage == ""); err != nil {
+			upgradeErrorf("Could not diff the upgrade configuration: %s", err)
+		}
+		return nil
+	}
+
+	if options.prune {
+		if err = writePrunableResources(os.Stdout, k, buf.Bytes()); err != nil {
+			upgradeErrorf("Could not list the resources to prune: %s", err)
+		}
+		return nil
+	}
+
+	// The diff above compares the manifest with the configuration last
+	// applied, as kubectl apply does, so edits made out of band are merged
+	// afterwards
+	if options.mergeLiveEdits {
+		merged, err := mergeLiveEdits(k, buf.Bytes())
+		if err != nil {
+			upgradeErrorf("Could not merge the live resources into the upgrade configuration: %s", err)
+		}
+		buf.Reset()
+		buf.Write(merged)
+	}
+
+	if stage == "" || stage == controlPlaneStage {
+		if err = appendConfigHistory(&buf, history, command, configs, recordedFlags, time.Now()); err != nil {
+			upgradeErrorf("Could not record the configuration history: %s", err)
+		}
 	}
 
 	if options.identityOptions.trustPEMFile != "" {
 		fmt.Fprintf(os.Stderr, "\n%s %s\n\n", warnStatus, trustRootChangeMessage)
 	}
 
-	if stage == configStage {
+	if options.rotateIssuer {
+		fmt.Fprintf(os.Stderr, "\n%s %s\n\n", warnStatus, issuerRotationMessage)
+	}
+
+	switch stage {
+	case crdsStage:
+		fmt.Fprintf(os.Stderr, "%s\n\n", configMessage)
+	case configStage:
 		fmt.Fprintf(os.Stderr, "%s\n\n", controlPlaneMessage)
 	}
 
-	buf.WriteTo(os.Stdout)
+	if options.progressive {
+		if err = upgradeProgressively(os.Stdout, k, buf.Bytes(), options.applyTimeout, options.pauseAfter, controlPlaneHealthy(os.Stdout, options.applyTimeout)); err != nil {
+			upgradeErrorf("Progressive upgrade failed: %s", err)
+		}
+		return nil
+	}
+
+	if err = writeManifest(os.Stdout, buf.Bytes(), options.output); err != nil {
+		upgradeErrorf("Could not write upgrade configuration: %s", err)
+	}
 
 	return nil
 }
 
+// rollbackRevision returns the revision prior to the current one, whose
+// manifest is output as is: nothing can be changed or rendered on top of it.
+func (options *upgradeOptions) rollbackRevision(stage string, flags *pflag.FlagSet, history []configRevision) (configRevision, error) {
+	if stage != "" {
+		return configRevision{}, fmt.Errorf("--rollback can't be used with the %s stage", stage)
+	}
+	if options.toRevision != 0 {
+		return configRevision{}, errors.New("--rollback can't be used with --to-revision")
+	}
+	if options.mergeLiveEdits || options.rotateIssuer {
+		return configRevision{}, errors.New("--rollback can't be used with --merge-live-edits or --rotate-issuer")
+	}
+	var changed []string
+	flags.Visit(func(f *pflag.Flag) {
+		changed = append(changed, "--"+f.Name)
+	})
+	if len(changed) > 0 {
+		return configRevision{}, fmt.Errorf("--rollback can't be used with %s", strings.Join(changed, ", "))
+	}
+
+	return previousConfigRevision(history)
+}
+
 func (options *upgradeOptions) validateAndBuild(stage string, k *k8s.KubernetesAPI, flags *pflag.FlagSet) (*charts.Values, error) {
 	if err := options.validate(); err != nil {
 		return nil, err
@@ -222,6 +472,29 @@ func (options *upgradeOptions) validateAndBuild(stage string, k *k8s.KubernetesA
 	if err != nil {
 		return nil, fmt.Errorf("could not fetch configs from kubernetes: %s", err)
 	}
+	currentVersion := configs.GetGlobal().GetVersion()
+
+	// Going back to a prior revision replaces the current configs, and thus the
+	// recorded flags, with the ones it recorded. The identity context is kept,
+	// as the issuer may have been rotated since.
+	if options.toRevision != 0 {
+		history, err := fetchConfigHistory(k)
+		if err != nil {
+			return nil, fmt.Errorf("could not fetch the configuration history: %s", err)
+		}
+		restored, err := findConfigRevision(history, options.toRevision)
+		if err != nil {
+			return nil, err
+		}
+		restored.GetGlobal().IdentityContext = configs.GetGlobal().GetIdentityContext()
+		configs = restored
+	}
+
+	if !options.ignoreSkew {
+		if err := checkVersionSkew(k, currentVersion, options.controlPlaneVersion); err != nil {
+			return nil, fmt.Errorf("%s; use --ignore-version-skew to upgrade anyway", err)
+		}
+	}
 
 	// If the configs need to be repaired--either because sections did not
 	// exist or because it is missing expected fields, repair it.
@@ -234,6 +507,12 @@ func (options *upgradeOptions) validateAndBuild(stage string, k *k8s.KubernetesA
 	// from the control-plane, and not from the defaults specified in the FlagSet.
 	setFlagsFromInstall(flags, configs.GetInstall().GetFlags())
 
+	// Values files only provide the defaults for the flags that were neither
+	// specified now nor recorded during a prior install.
+	if err := options.applyValueFiles(flags); err != nil {
+		return nil, err
+	}
+
 	// Save off the updated set of flags into the installOptions so it gets
 	// persisted with the upgraded config.
 	options.recordFlags(flags)
@@ -241,6 +520,7 @@ func (options *upgradeOptions) validateAndBuild(stage string, k *k8s.KubernetesA
 	// Update the configs from the synthesized options.
 	// The overrideConfigs() is used to override proxy configs only.
 	options.overrideConfigs(configs, map[string]string{})
+	omitDefaultConnectTimeouts(configs.Proxy)
 
 	if options.enableEndpointSlices {
 		if err = validateEndpointSlicesFeature(); err != nil {
@@ -266,6 +546,14 @@ func (options *upgradeOptions) validateAndBuild(stage string, k *k8s.KubernetesA
 			return nil, errors.New("cannot update issuer certificates if you are using external cert management solution")
 		}
 
+		if configs.Global.IdentityContext.Scheme == k8s.IdentityIssuerSchemeSpire {
+			return nil, errors.New("cannot update issuer certificates if the issuer certificate is obtained from a SPIRE server")
+		}
+
+		if options.rotateIssuer {
+			return nil, errors.New("--rotate-issuer can't be used with --identity-issuer-certificate-file or --identity-issuer-key-file")
+		}
+
 		if options.identityOptions.crtPEMFile == "" {
 			return nil, errors.New("a certificate file must be specified if a private key is provided")
 		}
@@ -278,11 +566,18 @@ func (options *upgradeOptions) validateAndBuild(stage string, k *k8s.KubernetesA
 	}
 
 	if options.identityOptions.trustPEMFile != "" {
+		if options.rotateIssuer {
+			return nil, errors.New("--rotate-issuer can't be used with --identity-trust-anchors-file")
+		}
 		if err := checkFilesExist([]string{options.identityOptions.trustPEMFile}); err != nil {
 			return nil, err
 		}
 	}
 
+	if options.rotateIssuer && configs.GetGlobal().GetIdentityContext().GetScheme() == string(corev1.SecretTypeTLS) {
+		return nil, errors.New("cannot rotate the issuer certificate if you are using external cert management solution")
+	}
+
 	var identity *identityWithAnchorsAndTrustDomain
 	idctx := configs.GetGlobal().GetIdentityContext()
 	if idctx.GetTrustDomain() == "" || idctx.GetTrustAnchorsPem() == "" {
@@ -299,6 +594,9 @@ func (options *upgradeOptions) validateAndBuild(stage string, k *k8s.KubernetesA
 			return nil, err
 		}
 	}
+	if err = checkIdentityCryptoPolicy(identity, options.cryptoPolicy); err != nil {
+		return nil, err
+	}
 
 	// Values have to be generated after any missing identity is generated,
 	// otherwise it will be missing from the generated configmap.
@@ -327,7 +625,7 @@ func (options *upgradeOptions) validateAndBuild(stage string, k *k8s.KubernetesA
 		}
 		proxyInjectorTLS = &charts.TLS{}
 	}
-	values.ProxyInjector = &charts.ProxyInjector{TLS: proxyInjectorTLS}
+	values.ProxyInjector.TLS = proxyInjectorTLS
 
 	profileValidatorTLS, err := fetchTLSSecret(k, k8s.SPValidatorWebhookServiceName, options)
 	if err != nil {
@@ -382,18 +680,40 @@ func (options *upgradeOptions) validateAndBuild(stage string, k *k8s.KubernetesA
 		return nil, err
 	}
 
+	// Apply the recorded --set* overrides, followed by the new ones, last
+	if err = options.valuesOptions.applyTo(values, configs); err != nil {
+		return nil, err
+	}
+
+	if options.rotateIssuer && !values.EnableIdentityRotation {
+		return nil, errors.New("--rotate-issuer requires the linkerd-identity-rotation cronjob; enable it with --enable-identity-rotation")
+	}
+
 	return values, nil
 }
 
 func setFlagsFromInstall(flags *pflag.FlagSet, installFlags []*pb.Install_Flag) {
+	// Flags that can be repeated, like --set, are recorded once per value.
+	// The recorded values are replayed before the ones specified now, so that
+	// the latter take precedence.
+	repeated := map[string][]string{}
+
 	for _, i := range installFlags {
-		if f := flags.Lookup(i.GetName()); f != nil && !f.Changed {
+		f := flags.Lookup(i.GetName())
+		if f == nil {
+			continue
+		}
+		if f.Value.Type() == "stringArray" {
+			repeated[f.Name] = append(repeated[f.Name], i.GetValue())
+			continue
+		}
+		if !f.Changed {
 			// The function recordFlags() stores the string representation of flags in the ConfigMap
-			// so a stringSlice is stored e.g. as [a,b].
+			// so a stringSlice is stored e.g. as [a,b], and a stringToString as [a=b].
 			// To avoid having f.Value.Set() interpreting that as a string we need to remove
 			// the brackets
 			value := i.GetValue()
-			if f.Value.Type() == "stringSlice" {
+			if f.Value.Type() == "stringSlice" || f.Value.Type() == "stringToString" {
 				value = strings.Trim(value, "[]")
 			}
 
@@ -401,6 +721,16 @@ func setFlagsFromInstall(flags *pflag.FlagSet, installFlags []*pb.Install_Flag)
 			f.Changed = true
 		}
 	}
+
+	for name, values := range repeated {
+		f := flags.Lookup(name)
+		slice := f.Value.(pflag.SliceValue)
+		if f.Changed {
+			values = append(values, slice.GetSlice()...)
+		}
+		slice.Replace(values)
+		f.Changed = true
+	}
 }
 
 func repairConfigs(configs *pb.All) {
@@ -564,8 +894,24 @@ func (options *upgradeOptions) fetchIdentityValues(k kubernetes.Interface, idctx
 		trustAnchorsPEM = idctx.GetTrustAnchorsPem()
 	}
 
+	if idctx.Scheme == k8s.IdentityIssuerSchemeSpire {
+		return options.fetchSpireIdentityValues(idctx, trustAnchorsPEM)
+	} else if options.identityOptions.spire.ServerAddr != "" {
+		return nil, fmt.Errorf("--identity-spire-server-addr can't be set when upgrading a control plane whose issuer scheme is %s", idctx.Scheme)
+	}
+
 	updatingIssuerCert := options.identityOptions.crtPEMFile != "" && options.identityOptions.keyPEMFile != ""
 
+	if idctx.Scheme == k8s.IdentityIssuerSchemeLinkerd && !updatingIssuerCert {
+		secret, err := k.CoreV1().Secrets(controlPlaneNamespace).Get(k8s.IdentityIssuerSecretName, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		if keyURI := secret.Annotations[k8s.IdentityIssuerKeyURIAnnotation]; keyURI != "" {
+			return options.fetchKMSIdentityValues(idctx, secret, trustAnchorsPEM, keyURI)
+		}
+	}
+
 	if updatingIssuerCert {
 		issuerData, err = readIssuer(trustAnchorsPEM, options.identityOptions.crtPEMFile, options.identityOptions.keyPEMFile)
 	} else {
@@ -581,12 +927,47 @@ func (options *upgradeOptions) fetchIdentityValues(k kubernetes.Interface, idctx
 	}
 	issuerData.Expiry = &cred.Crt.Certificate.NotAfter
 
+	if updatingIssuerCert {
+		if err := tls.CheckKeyAlgorithm(cred.Crt.Certificate.PublicKey, idctx.GetIssuerKeyAlgorithm()); err != nil {
+			return nil, fmt.Errorf("invalid issuer certificate: %s", err)
+		}
+	}
+
 	if updatingIssuerCert && !options.force {
 		if err := ensureIssuerCertWorksWithAllProxies(k, cred); err != nil {
 			return nil, err
 		}
 	}
 
+	var nextTLS *charts.IssuerTLS
+	var rotationOverlap string
+	if idctx.Scheme == k8s.IdentityIssuerSchemeLinkerd {
+		// Keep any issuer staged by a previous --rotate-issuer
+		nextTLS, rotationOverlap, err = fetchStagedIssuer(k)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if options.rotateIssuer {
+		if nextTLS != nil {
+			return nil, errors.New("an issuer rotation is already in progress; wait for the linkerd-identity-rotation cronjob to complete it")
+		}
+		nextTLS, trustAnchorsPEM, err = options.stageIssuer(idctx, cred, trustAnchorsPEM)
+		if err != nil {
+			return nil, err
+		}
+		rotationOverlap = options.rotationOverlap.String()
+	}
+
+	var certManager *charts.IssuerCertManager
+	if idctx.Scheme == string(corev1.SecretTypeTLS) {
+		certManager, err = options.fetchCertManagerValues(k, idctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &identityWithAnchorsAndTrustDomain{
 		TrustDomain:     idctx.GetTrustDomain(),
 		TrustAnchorsPEM: trustAnchorsPEM,
@@ -596,18 +977,194 @@ func (options *upgradeOptions) fetchIdentityValues(k kubernetes.Interface, idctx
 				Scheme:              idctx.Scheme,
 				ClockSkewAllowance:  idctx.GetClockSkewAllowance().String(),
 				IssuanceLifetime:    idctx.GetIssuanceLifetime().String(),
+				RenewalJitter:       renewalJitter(idctx),
+				KeyAlgorithm:        idctx.GetIssuerKeyAlgorithm(),
 				CrtExpiry:           *issuerData.Expiry,
 				CrtExpiryAnnotation: k8s.IdentityIssuerExpiryAnnotation,
 				TLS: &charts.IssuerTLS{
 					KeyPEM: issuerData.IssuerKey,
 					CrtPEM: issuerData.IssuerCrt,
 				},
+				CertManager:     certManager,
+				NextTLS:         nextTLS,
+				RotationOverlap: rotationOverlap,
 			},
 		},
 	}, nil
 
 }
 
+// fetchKMSIdentityValues keeps the issuer certificate of an issuer whose
+// private key is held by a KMS, as the key isn't in the issuer secret.
+func (options *upgradeOptions) fetchKMSIdentityValues(idctx *pb.IdentityContext, secret *corev1.Secret, trustAnchorsPEM, keyURI string) (*identityWithAnchorsAndTrustDomain, error) {
+	if options.rotateIssuer {
+		return nil, errors.New("--rotate-issuer isn't supported when the issuer private key is held by a KMS; use --identity-issuer-certificate-file and --identity-issuer-key-file to replace the issuer")
+	}
+
+	crt, err := tls.DecodePEMCrt(string(secret.Data[k8s.IdentityIssuerCrtName]))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the issuer certificate from %s: %s", k8s.IdentityIssuerSecretName, err)
+	}
+	roots, err := tls.DecodePEMCertPool(trustAnchorsPEM)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode the trust anchors: %s", err)
+	}
+	if err := crt.Verify(roots, "", time.Time{}); err != nil {
+		return nil, fmt.Errorf("issuer certificate does not work with the provided anchors: %s\nFor more information: https://linkerd.io/2/tasks/rotating_identity_certificates/", err)
+	}
+
+	return &identityWithAnchorsAndTrustDomain{
+		TrustDomain:     idctx.GetTrustDomain(),
+		TrustAnchorsPEM: trustAnchorsPEM,
+		Identity: &charts.Identity{
+			Issuer: &charts.Issuer{
+				Scheme:              idctx.Scheme,
+				ClockSkewAllowance:  idctx.GetClockSkewAllowance().String(),
+				IssuanceLifetime:    idctx.GetIssuanceLifetime().String(),
+				RenewalJitter:       renewalJitter(idctx),
+				KeyAlgorithm:        idctx.GetIssuerKeyAlgorithm(),
+				CrtExpiry:           crt.Certificate.NotAfter,
+				CrtExpiryAnnotation: k8s.IdentityIssuerExpiryAnnotation,
+				KeyURI:              keyURI,
+				TLS: &charts.IssuerTLS{
+					CrtPEM: crt.EncodeCertificatePEM(),
+				},
+			},
+		},
+	}, nil
+}
+
+// fetchSpireIdentityValues keeps the SPIRE server settings of an identity
+// service that obtains its issuer certificate from SPIRE, as recorded by the
+// install flags, there being no issuer secret.
+func (options *upgradeOptions) fetchSpireIdentityValues(idctx *pb.IdentityContext, trustAnchorsPEM string) (*identityWithAnchorsAndTrustDomain, error) {
+	if options.rotateIssuer {
+		return nil, errors.New("--rotate-issuer isn't supported when the issuer certificate is obtained from a SPIRE server")
+	}
+	if options.identityOptions.spire.ServerAddr == "" {
+		return nil, errors.New("the address of the SPIRE server wasn't recorded during install; specify it with --identity-spire-server-addr")
+	}
+	if _, err := tls.DecodePEMCertPool(trustAnchorsPEM); err != nil {
+		return nil, fmt.Errorf("failed to decode the trust anchors: %s", err)
+	}
+
+	spire := options.identityOptions.spire
+	return &identityWithAnchorsAndTrustDomain{
+		TrustDomain:     idctx.GetTrustDomain(),
+		TrustAnchorsPEM: trustAnchorsPEM,
+		Identity: &charts.Identity{
+			Issuer: &charts.Issuer{
+				Scheme:             idctx.Scheme,
+				ClockSkewAllowance: idctx.GetClockSkewAllowance().String(),
+				IssuanceLifetime:   idctx.GetIssuanceLifetime().String(),
+				RenewalJitter:      renewalJitter(idctx),
+				KeyAlgorithm:       idctx.GetIssuerKeyAlgorithm(),
+			},
+			Spire: &spire,
+		},
+	}, nil
+}
+
+// renewalJitter returns the renewal jitter of the proxies' certificates as a
+// chart value
+func renewalJitter(idctx *pb.IdentityContext) string {
+	if idctx.GetRenewalJitter() == nil {
+		return "0s"
+	}
+	jitter, err := ptypes.Duration(idctx.GetRenewalJitter())
+	if err != nil {
+		return "0s"
+	}
+	return jitter.String()
+}
+
+// stageIssuer generates a new self-signed issuer to replace the current one,
+// and returns it along with the trust anchors bundling it with the current
+// ones, so that the certificates issued by either issuer are trusted during the
+// rotation.
+func (options *upgradeOptions) stageIssuer(idctx *pb.IdentityContext, current *tls.Cred, trustAnchorsPEM string) (*charts.IssuerTLS, string, error) {
+	// A self-signed issuer is its own trust anchor, as generated by install;
+	// otherwise the new issuer would need to be signed by the user's trust anchor
+	if err := current.Crt.Certificate.CheckSignatureFrom(current.Crt.Certificate); err != nil {
+		return nil, "", errors.New("--rotate-issuer only supports the self-signed issuers generated by \"linkerd install\"; use --identity-issuer-certificate-file and --identity-issuer-key-file to replace an issuer signed by your own trust anchor")
+	}
+
+	lifetime, err := ptypes.Duration(idctx.GetIssuanceLifetime())
+	if err != nil {
+		lifetime = defaultIdentityIssuanceLifetime
+	}
+	skew, err := ptypes.Duration(idctx.GetClockSkewAllowance())
+	if err != nil {
+		skew = defaultIdentityClockSkewAllowance
+	}
+	minOverlap := lifetime + skew
+	if options.rotationOverlap <= minOverlap {
+		return nil, "", fmt.Errorf("--rotation-overlap must be longer than the issuance lifetime plus the clock skew allowance (%s), so that the certificates issued by the current issuer expire before it's no longer trusted", minOverlap)
+	}
+
+	anchors, err := tls.DecodePEMCertificates(trustAnchorsPEM)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to decode the trust anchors: %s", err)
+	}
+
+	next, err := tls.GenerateRootCA(fmt.Sprintf("identity.%s.%s", controlPlaneNamespace, idctx.GetTrustDomain()), idctx.GetIssuerKeyAlgorithm())
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to generate the new issuer certificate: %s", err)
+	}
+
+	nextTLS := &charts.IssuerTLS{
+		KeyPEM: next.Cred.EncodePrivateKeyPEM(),
+		CrtPEM: next.Cred.Crt.EncodeCertificatePEM(),
+	}
+	return nextTLS, tls.EncodeCertificatesPEM(append(anchors, next.Cred.Crt.Certificate)...), nil
+}
+
+// fetchStagedIssuer returns the issuer staged in the issuer secret, and the
+// overlap it was staged with, if any.
+func fetchStagedIssuer(k kubernetes.Interface) (*charts.IssuerTLS, string, error) {
+	secret, err := k.CoreV1().Secrets(controlPlaneNamespace).Get(k8s.IdentityIssuerSecretName, metav1.GetOptions{})
+	if err != nil {
+		return nil, "", err
+	}
+
+	crt, ok := secret.Data[k8s.IdentityIssuerNextCrtName]
+	if !ok {
+		return nil, "", nil
+	}
+	return &charts.IssuerTLS{
+		CrtPEM: string(crt),
+		KeyPEM: string(secret.Data[k8s.IdentityIssuerNextKeyName]),
+	}, secret.Annotations[k8s.IdentityIssuerRotationOverlapAnnotation], nil
+}
+
+// fetchCertManagerValues returns the values of the cert-manager resources
+// managing the external issuer secret, if it was issued by the Certificate
+// rendered through --identity-external-issuer=cert-manager, so that they keep
+// being rendered with the settings recorded at install. Installs that predate
+// the recorded settings get the defaults.
+func (options *upgradeOptions) fetchCertManagerValues(k kubernetes.Interface, idctx *pb.IdentityContext) (*charts.IssuerCertManager, error) {
+	if recorded := idctx.GetCertManager(); recorded != nil {
+		return &charts.IssuerCertManager{
+			Enabled:           true,
+			TrustAnchorSecret: recorded.GetTrustAnchorSecret(),
+			Duration:          recorded.GetDuration(),
+			RenewBefore:       recorded.GetRenewBefore(),
+		}, nil
+	}
+
+	secret, err := k.CoreV1().Secrets(controlPlaneNamespace).Get(k8s.IdentityIssuerSecretName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if secret.Annotations[k8s.CertManagerCertificateNameAnnotation] != k8s.IdentityIssuerSecretName {
+		return nil, nil
+	}
+
+	certManager := options.identityOptions.certManager
+	certManager.Enabled = true
+	return &certManager, nil
+}
+
 func readIssuer(trustPEM, issuerCrtPath, issuerKeyPath string) (*issuercerts.IssuerCertData, error) {
 	key, crt, err := issuercerts.LoadIssuerCrtAndKeyFromFiles(issuerKeyPath, issuerCrtPath)
 	if err != nil {
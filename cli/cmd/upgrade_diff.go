@@ -0,0 +1,318 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+)
+
+const lastAppliedAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+var errUnsupportedKind = errors.New("unsupported kind")
+
+var (
+	diffAdded   = color.New(color.FgGreen).SprintFunc()
+	diffRemoved = color.New(color.FgRed).SprintFunc()
+	diffChanged = color.New(color.FgYellow).SprintFunc()
+)
+
+// resourceDiff is the difference between the live version of a resource and
+// its rendered version. live is nil for the resources that don't exist yet,
+// and rendered is nil for the ones that aren't rendered anymore.
+type resourceDiff struct {
+	ref      string
+	live     manifest
+	rendered manifest
+	diffs    []diff
+}
+
+// upgradeDiff compares each resource of the upgrade manifest with its live
+// version. Resources that are labeled with the control plane namespace but
+// aren't rendered anymore are reported as well if prune is set.
+func upgradeDiff(k *k8s.KubernetesAPI, upgradeManifest []byte, prune bool) ([]resourceDiff, int, error) {
+	objs, err := splitManifest(upgradeManifest)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var diffs []resourceDiff
+	unchanged := 0
+	for _, obj := range objs {
+		rendered := manifest{}
+		if err := yaml.Unmarshal(obj.YAML, &rendered); err != nil {
+			return nil, 0, err
+		}
+
+		live, err := fetchLiveManifest(k, obj, rendered)
+		if err != nil {
+			return nil, 0, fmt.Errorf("could not fetch %s: %s", obj.ref(), err)
+		}
+		if live == nil {
+			diffs = append(diffs, resourceDiff{ref: obj.ref(), rendered: rendered})
+			continue
+		}
+
+		if obj.Kind == "Secret" {
+			redactSecretData(live)
+			redactSecretData(rendered)
+		}
+		d := significantDiffs(diffManifest(live, rendered, []string{}))
+		if len(d) == 0 {
+			unchanged++
+			continue
+		}
+		sort.Slice(d, func(i, j int) bool {
+			return strings.Join(d[i].path, ".") < strings.Join(d[j].path, ".")
+		})
+		diffs = append(diffs, resourceDiff{ref: obj.ref(), live: live, rendered: rendered, diffs: d})
+	}
+
+	if prune {
+		resources, err := prunableResources(k, upgradeManifest)
+		if err != nil {
+			return nil, 0, err
+		}
+		for _, r := range resources {
+			ref := manifestObject{Kind: r.Kind, Namespace: r.Namespace, Name: r.Name}.ref()
+			diffs = append(diffs, resourceDiff{ref: ref, live: manifest{}})
+		}
+	}
+
+	return diffs, unchanged, nil
+}
+
+// writeUpgradeDiff writes the output of upgradeDiff to w, colorized unless
+// color is disabled
+func writeUpgradeDiff(w io.Writer, k *k8s.KubernetesAPI, upgradeManifest []byte, prune bool) error {
+	diffs, unchanged, err := upgradeDiff(k, upgradeManifest, prune)
+	if err != nil {
+		return err
+	}
+
+	created, changed, deleted := 0, 0, 0
+	for _, d := range diffs {
+		switch {
+		case d.live == nil:
+			created++
+			fmt.Fprintf(w, "%s\n", diffAdded("+ "+d.ref+" (created)"))
+		case d.rendered == nil:
+			deleted++
+			fmt.Fprintf(w, "%s\n", diffRemoved("- "+d.ref+" (not rendered anymore, see --prune)"))
+		default:
+			changed++
+			fmt.Fprintf(w, "%s\n", diffChanged("~ "+d.ref))
+			for _, fd := range d.diffs {
+				fmt.Fprintf(w, "  %s:\n", strings.Join(fd.path, "."))
+				if fd.a != nil {
+					fmt.Fprint(w, diffRemoved(indentValue("    - ", fd.a)))
+				}
+				if fd.b != nil {
+					fmt.Fprint(w, diffAdded(indentValue("    + ", fd.b)))
+				}
+			}
+		}
+	}
+
+	_, err = fmt.Fprintf(w, "\n%d to create, %d to change, %d to delete, %d unchanged\n", created, changed, deleted, unchanged)
+	return err
+}
+
+// significantDiffs filters out the differences between empty values, e.g. a
+// field set to false in the manifest that the API server omits
+func significantDiffs(diffs []diff) []diff {
+	var significant []diff
+	for _, d := range diffs {
+		if !isEmptyValue(d.a) || !isEmptyValue(d.b) {
+			significant = append(significant, d)
+		}
+	}
+	return significant
+}
+
+func isEmptyValue(v interface{}) bool {
+	switch val := v.(type) {
+	case nil:
+		return true
+	case bool:
+		return !val
+	case float64:
+		return val == 0
+	case string:
+		return val == ""
+	case manifest:
+		return len(val) == 0
+	case []interface{}:
+		return len(val) == 0
+	default:
+		return false
+	}
+}
+
+// indentValue renders v as YAML, prefixing its first line with prefix and
+// aligning the other ones with it
+func indentValue(prefix string, v interface{}) string {
+	out, err := yaml.Marshal(v)
+	if err != nil {
+		out = []byte(fmt.Sprintf("%v\n", v))
+	}
+	lines := strings.Split(strings.TrimSuffix(string(out), "\n"), "\n")
+	padding := strings.Repeat(" ", len(prefix))
+	for i := range lines {
+		if i == 0 {
+			lines[i] = prefix + lines[i]
+		} else {
+			lines[i] = padding + lines[i]
+		}
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// fetchLiveManifest returns the live version of obj, or nil if it doesn't
+// exist. If obj was applied with kubectl, that's the configuration it recorded;
+// otherwise it's the live object restricted to the fields set in rendered, so
+// that the fields defaulted or managed by the API server aren't reported.
+func fetchLiveManifest(k *k8s.KubernetesAPI, obj manifestObject, rendered manifest) (manifest, error) {
+	liveObj, err := fetchLiveObject(k, obj)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	accessor, err := meta.Accessor(liveObj)
+	if err != nil {
+		return nil, err
+	}
+	live := manifest{}
+	if lastApplied, ok := accessor.GetAnnotations()[lastAppliedAnnotation]; ok {
+		if err := json.Unmarshal([]byte(lastApplied), &live); err != nil {
+			return nil, fmt.Errorf("invalid %s annotation: %s", lastAppliedAnnotation, err)
+		}
+		return live, nil
+	}
+
+	b, err := json.Marshal(liveObj)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, &live); err != nil {
+		return nil, err
+	}
+	// typed objects returned by the API don't have their type meta set
+	live["apiVersion"] = rendered["apiVersion"]
+	live["kind"] = rendered["kind"]
+	return restrictManifest(live, rendered).(manifest), nil
+}
+
+// restrictManifest returns the parts of live that have a counterpart in
+// rendered. Lists are only restricted element-wise if they have the same
+// length.
+func restrictManifest(live, rendered interface{}) interface{} {
+	switch r := rendered.(type) {
+	case manifest:
+		l, ok := live.(manifest)
+		if !ok {
+			return live
+		}
+		restricted := manifest{}
+		for key, v := range l {
+			if rv, ok := r[key]; ok {
+				restricted[key] = restrictManifest(v, rv)
+			}
+		}
+		return restricted
+	case []interface{}:
+		l, ok := live.([]interface{})
+		if !ok || len(l) != len(r) {
+			return live
+		}
+		restricted := make([]interface{}, len(l))
+		for i := range l {
+			restricted[i] = restrictManifest(l[i], r[i])
+		}
+		return restricted
+	default:
+		return live
+	}
+}
+
+// redactSecretData replaces the values of a Secret with a digest, so that
+// changes are reported without disclosing the values
+func redactSecretData(secret manifest) {
+	for _, field := range []string{"data", "stringData"} {
+		data, ok := secret[field].(manifest)
+		if !ok {
+			continue
+		}
+		for key, value := range data {
+			sum := sha256.Sum256([]byte(fmt.Sprint(value)))
+			data[key] = fmt.Sprintf("<redacted sha256:%x>", sum[:8])
+		}
+	}
+}
+
+// fetchLiveObject gets the live version of obj, for the kinds rendered by
+// install
+func fetchLiveObject(k *k8s.KubernetesAPI, obj manifestObject) (runtime.Object, error) {
+	ns, name, opts := obj.Namespace, obj.Name, metav1.GetOptions{}
+	switch obj.Kind {
+	case "Namespace":
+		return k.CoreV1().Namespaces().Get(name, opts)
+	case "CustomResourceDefinition":
+		return k.Apiextensions.ApiextensionsV1beta1().CustomResourceDefinitions().Get(name, opts)
+	case "ServiceAccount":
+		return k.CoreV1().ServiceAccounts(ns).Get(name, opts)
+	case "ClusterRole":
+		return k.RbacV1().ClusterRoles().Get(name, opts)
+	case "ClusterRoleBinding":
+		return k.RbacV1().ClusterRoleBindings().Get(name, opts)
+	case "Role":
+		return k.RbacV1().Roles(ns).Get(name, opts)
+	case "RoleBinding":
+		return k.RbacV1().RoleBindings(ns).Get(name, opts)
+	case "PodSecurityPolicy":
+		return k.PolicyV1beta1().PodSecurityPolicies().Get(name, opts)
+	case "ConfigMap":
+		return k.CoreV1().ConfigMaps(ns).Get(name, opts)
+	case "Secret":
+		return k.CoreV1().Secrets(ns).Get(name, opts)
+	case "NetworkPolicy":
+		return k.NetworkingV1().NetworkPolicies(ns).Get(name, opts)
+	case "Service":
+		return k.CoreV1().Services(ns).Get(name, opts)
+	case "Deployment":
+		return k.AppsV1().Deployments(ns).Get(name, opts)
+	case "DaemonSet":
+		return k.AppsV1().DaemonSets(ns).Get(name, opts)
+	case "CronJob":
+		return k.BatchV1beta1().CronJobs(ns).Get(name, opts)
+	case "PodDisruptionBudget":
+		return k.PolicyV1beta1().PodDisruptionBudgets(ns).Get(name, opts)
+	case "MutatingWebhookConfiguration":
+		return k.AdmissionregistrationV1beta1().MutatingWebhookConfigurations().Get(name, opts)
+	case "ValidatingWebhookConfiguration":
+		return k.AdmissionregistrationV1beta1().ValidatingWebhookConfigurations().Get(name, opts)
+	case "APIService":
+		return k.Apiregistration.ApiregistrationV1().APIServices().Get(name, opts)
+	case "SecurityContextConstraints":
+		if k.DynamicClient == nil {
+			return nil, kerrors.NewNotFound(securityContextConstraintsResource.GroupResource(), name)
+		}
+		return k.DynamicClient.Resource(securityContextConstraintsResource).Get(name, opts)
+	default:
+		return nil, fmt.Errorf("%w %s", errUnsupportedKind, obj.Kind)
+	}
+}
@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+)
+
+func TestWriteUpgradeDiff(t *testing.T) {
+	color.NoColor = true
+
+	installOpts, installFlags, _, _ := testOptionsAndFlags(t)
+	install := renderInstall(t, installValues(t, installOpts, installFlags))
+	k, err := k8s.NewFakeAPIFromManifests([]io.Reader{bytes.NewReader(install.Bytes())})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	var buf bytes.Buffer
+	if err := writeUpgradeDiff(&buf, k, install.Bytes(), true); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if !strings.HasPrefix(buf.String(), "\n0 to create, 0 to change, 0 to delete, ") {
+		t.Fatalf("Expected no differences, got:\n%s", buf.String())
+	}
+
+	haOpts, haFlags, _, _ := testOptionsAndFlags(t)
+	haFlags.Set("ha", "true")
+	haFlags.Set("skip-addons", "grafana")
+	upgrade := renderInstall(t, installValues(t, haOpts, haFlags))
+
+	buf.Reset()
+	if err := writeUpgradeDiff(&buf, k, upgrade.Bytes(), true); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	out := buf.String()
+	for _, expected := range []string{
+		"~ Deployment linkerd/linkerd-controller\n  spec.replicas:\n    - 1\n    + 3\n",
+		"+ PodDisruptionBudget linkerd/linkerd-controller (created)\n",
+		"- Deployment linkerd/linkerd-grafana (not rendered anymore, see --prune)\n",
+		"~ Secret linkerd/linkerd-proxy-injector-tls\n  data.crt.pem:\n    - <redacted sha256:",
+	} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("Expected the diff to contain %q, got:\n%s", expected, out)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/runtime"
+	yamlDecoder "k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// manifestExtensions are the extensions of the files read from a directory
+// passed to --from-manifests
+var manifestExtensions = map[string]bool{".yaml": true, ".yml": true, ".json": true}
+
+// readUpgradeManifests reads the manifests upgrade gets its configuration
+// from, instead of the cluster. path is either "-" for stdin, an HTTPS URL, a
+// file, or a directory such as a snapshot of a GitOps repository: its YAML
+// and JSON files are read recursively, ignoring hidden directories like .git.
+// Documents of kinds unknown to Kubernetes, e.g. a kustomization.yaml, are
+// skipped.
+func readUpgradeManifests(path string) ([]io.Reader, error) {
+	var in []io.Reader
+	switch {
+	case path == "-":
+		in = []io.Reader{os.Stdin}
+	case isValidURL(path):
+		r, err := fetchManifestURL(path)
+		if err != nil {
+			return nil, err
+		}
+		in = []io.Reader{r}
+	default:
+		var err error
+		if in, err = walkManifests(path); err != nil {
+			return nil, err
+		}
+	}
+
+	var buf bytes.Buffer
+	for _, r := range in {
+		if err := filterKnownKinds(&buf, r); err != nil {
+			return nil, err
+		}
+		if c, ok := r.(io.Closer); ok && r != os.Stdin {
+			c.Close()
+		}
+	}
+	return []io.Reader{&buf}, nil
+}
+
+// fetchManifestURL downloads the manifests at rawURL, which must use HTTPS as
+// they contain the control plane's private keys
+func fetchManifestURL(rawURL string) (io.Reader, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "https" {
+		return nil, fmt.Errorf("manifests can only be fetched over HTTPS, got %q", rawURL)
+	}
+
+	resp, err := http.Get(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unable to read URL %q, server reported %s, status code=%d", rawURL, resp.Status, resp.StatusCode)
+	}
+
+	buf := new(bytes.Buffer)
+	if _, err := buf.ReadFrom(resp.Body); err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+// walkManifests is similar to walk, but only reads the manifest files and
+// skips the hidden directories
+func walkManifests(path string) ([]io.Reader, error) {
+	stat, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !stat.IsDir() {
+		return walk(path)
+	}
+
+	var in []io.Reader
+	err = filepath.Walk(path, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if p != path && strings.HasPrefix(info.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !manifestExtensions[strings.ToLower(filepath.Ext(p))] {
+			return nil
+		}
+
+		file, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		in = append(in, file)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(in) == 0 {
+		return nil, fmt.Errorf("no YAML or JSON files found in %s", path)
+	}
+	return in, nil
+}
+
+// filterKnownKinds copies the documents of r to w, except the ones whose
+// kind isn't registered with the Kubernetes scheme
+func filterKnownKinds(w io.Writer, r io.Reader) error {
+	yamlReader := yamlDecoder.NewYAMLReader(bufio.NewReader(r))
+	for {
+		doc, err := yamlReader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("error reading manifest: %s", err)
+		}
+
+		objs, err := splitManifest(doc)
+		if err != nil {
+			return err
+		}
+		if len(objs) == 0 {
+			continue
+		}
+		// lists are expanded when the manifests are parsed
+		if objs[0].Kind != "List" {
+			if _, err := k8s.ToRuntimeObject(string(doc)); runtime.IsNotRegisteredError(err) {
+				log.Debugf("Skipping %s: %s", objs[0].ref(), err)
+				continue
+			}
+		}
+		fmt.Fprintf(w, "%s%s\n", yamlSep, doc)
+	}
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+)
+
+func TestReadUpgradeManifests(t *testing.T) {
+	installOpts, installFlags, upgradeOpts, upgradeFlags := testOptionsAndFlags(t)
+	install := renderInstall(t, installValues(t, installOpts, installFlags))
+
+	// a GitOps repository, with the install manifest split across two
+	// directories, alongside files that aren't Kubernetes resources
+	dir, err := ioutil.TempDir("", "linkerd-manifests")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	manifests := splitManifests(install.String())
+	half := len(manifests) / 2
+	files := map[string]string{
+		"config/linkerd-config.yaml":   strings.Join(manifests[:half], "\n"+yamlSep),
+		"control-plane/linkerd.yml":    strings.Join(manifests[half:], "\n"+yamlSep),
+		"kustomization.yaml":           "apiVersion: kustomize.config.k8s.io/v1beta1\nkind: Kustomization\nresources:\n- config\n- control-plane\n",
+		"README.md":                    "# Linkerd\n",
+		".git/config":                  "[core]\n\tbare = false\n",
+		"control-plane/.hidden/x.yaml": "not: [valid",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+	}
+
+	readers, err := readUpgradeManifests(dir)
+	if err != nil {
+		t.Fatalf("Unexpected error reading %s: %s", dir, err)
+	}
+	k, err := k8s.NewFakeAPIFromManifests(readers)
+	if err != nil {
+		t.Fatalf("Unexpected error parsing the manifests: %s", err)
+	}
+	if _, err := upgradeOpts.validateAndBuild("", k, upgradeFlags); err != nil {
+		t.Fatalf("Unexpected error upgrading from %s: %s", dir, err)
+	}
+
+	empty, err := ioutil.TempDir("", "linkerd-manifests")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	defer os.RemoveAll(empty)
+	if _, err := readUpgradeManifests(empty); err == nil {
+		t.Errorf("Expected an error reading a directory without manifests")
+	}
+
+	if _, err := readUpgradeManifests("http://example.com/linkerd.yaml"); err == nil {
+		t.Errorf("Expected an error reading manifests over plain HTTP")
+	}
+}
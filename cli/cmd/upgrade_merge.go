@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/jsonmergepatch"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	yamlDecoder "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/yaml"
+)
+
+// serverMetadataFields are the metadata fields set by the API server, which
+// must not be part of a manifest
+var serverMetadataFields = []string{"uid", "resourceVersion", "creationTimestamp", "generation", "selfLink", "managedFields"}
+
+// mergeLiveEdits performs, for each resource of the upgrade manifest, the
+// three-way merge `kubectl apply` would: the changes between the configuration
+// it was last applied with and the upgrade manifest are applied to the live
+// resource. Changes made to the live resource out of band, e.g. added
+// tolerations, are thus preserved unless the upgrade changes the same fields.
+// Resources that don't exist yet, or that weren't applied with kubectl, are
+// kept as rendered.
+func mergeLiveEdits(k *k8s.KubernetesAPI, upgradeManifest []byte) ([]byte, error) {
+	var out bytes.Buffer
+	changed := false
+	yamlReader := yamlDecoder.NewYAMLReader(bufio.NewReader(bytes.NewReader(upgradeManifest)))
+	for {
+		doc, err := yamlReader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error reading manifest: %s", err)
+		}
+
+		merged, err := mergeLiveObject(k, doc)
+		if err != nil {
+			return nil, err
+		}
+		changed = changed || !bytes.Equal(merged, doc)
+		out.WriteString(yamlSep)
+		out.Write(merged)
+	}
+
+	if !changed {
+		return upgradeManifest, nil
+	}
+	return out.Bytes(), nil
+}
+
+func mergeLiveObject(k *k8s.KubernetesAPI, doc []byte) ([]byte, error) {
+	objs, err := splitManifest(doc)
+	if err != nil {
+		return nil, err
+	}
+	if len(objs) == 0 {
+		return doc, nil
+	}
+	obj := objs[0]
+
+	liveObj, err := fetchLiveObject(k, obj)
+	if err != nil {
+		if kerrors.IsNotFound(err) || errors.Is(err, errUnsupportedKind) {
+			return doc, nil
+		}
+		return nil, fmt.Errorf("could not fetch %s: %s", obj.ref(), err)
+	}
+	accessor, err := meta.Accessor(liveObj)
+	if err != nil {
+		return nil, err
+	}
+	original, ok := accessor.GetAnnotations()[lastAppliedAnnotation]
+	if !ok {
+		return doc, nil
+	}
+
+	modified, err := yaml.YAMLToJSON(doc)
+	if err != nil {
+		return nil, err
+	}
+	current, err := json.Marshal(liveObj)
+	if err != nil {
+		return nil, err
+	}
+	if current, err = stripServerFields(current); err != nil {
+		return nil, err
+	}
+
+	var typeMeta struct {
+		APIVersion string `json:"apiVersion"`
+		Kind       string `json:"kind"`
+	}
+	if err := json.Unmarshal(modified, &typeMeta); err != nil {
+		return nil, err
+	}
+	gvk := schema.FromAPIVersionAndKind(typeMeta.APIVersion, typeMeta.Kind)
+
+	var merged []byte
+	if versioned, err := scheme.Scheme.New(gvk); err == nil {
+		// built-in kinds are merged according to their patch strategies, e.g.
+		// containers are merged by name
+		patchMeta, err := strategicpatch.NewPatchMetaFromStruct(versioned)
+		if err != nil {
+			return nil, err
+		}
+		patch, err := strategicpatch.CreateThreeWayMergePatch([]byte(original), modified, current, patchMeta, true)
+		if err != nil {
+			return nil, fmt.Errorf("could not merge %s: %s", obj.ref(), err)
+		}
+		if merged, err = strategicpatch.StrategicMergePatch(current, patch, versioned); err != nil {
+			return nil, fmt.Errorf("could not merge %s: %s", obj.ref(), err)
+		}
+	} else {
+		patch, err := jsonmergepatch.CreateThreeWayJSONMergePatch([]byte(original), modified, current)
+		if err != nil {
+			return nil, fmt.Errorf("could not merge %s: %s", obj.ref(), err)
+		}
+		if merged, err = jsonpatch.MergePatch(current, patch); err != nil {
+			return nil, fmt.Errorf("could not merge %s: %s", obj.ref(), err)
+		}
+	}
+
+	return yaml.JSONToYAML(merged)
+}
+
+// stripServerFields removes the status and the metadata set by the API server
+// from a live object
+func stripServerFields(obj []byte) ([]byte, error) {
+	m := manifest{}
+	if err := json.Unmarshal(obj, &m); err != nil {
+		return nil, err
+	}
+	delete(m, "status")
+	if metadata, ok := m["metadata"].(manifest); ok {
+		for _, field := range serverMetadataFields {
+			delete(metadata, field)
+		}
+		if annotations, ok := metadata["annotations"].(manifest); ok {
+			delete(annotations, lastAppliedAnnotation)
+			delete(annotations, "deployment.kubernetes.io/revision")
+			if len(annotations) == 0 {
+				delete(metadata, "annotations")
+			}
+		}
+	}
+	return json.Marshal(m)
+}
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+func TestMergeLiveEdits(t *testing.T) {
+	installOpts, installFlags, _, _ := testOptionsAndFlags(t)
+	install := renderInstall(t, installValues(t, installOpts, installFlags))
+
+	haOpts, haFlags, _, _ := testOptionsAndFlags(t)
+	haFlags.Set("ha", "true")
+	upgrade := renderInstall(t, installValues(t, haOpts, haFlags))
+
+	k, err := k8s.NewFakeAPIFromManifests([]io.Reader{bytes.NewReader(install.Bytes())})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	merged, err := mergeLiveEdits(k, upgrade.Bytes())
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if !bytes.Equal(merged, upgrade.Bytes()) {
+		t.Fatal("Expected the resources that weren't applied with kubectl to be kept as rendered")
+	}
+
+	// The controller was applied with kubectl, and then edited to add a
+	// toleration
+	var controller appsv1.Deployment
+	for _, obj := range mustSplitManifest(t, install.Bytes()) {
+		if obj.Kind == "Deployment" && obj.Name == "linkerd-controller" {
+			if err := yaml.Unmarshal(obj.YAML, &controller); err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			lastApplied, err := yaml.YAMLToJSON(obj.YAML)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			controller.Annotations[lastAppliedAnnotation] = string(lastApplied)
+		}
+	}
+	toleration := corev1.Toleration{Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "linkerd", Effect: corev1.TaintEffectNoSchedule}
+	controller.Spec.Template.Spec.Tolerations = []corev1.Toleration{toleration}
+	if _, err := k.AppsV1().Deployments(controller.Namespace).Update(&controller); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	merged, err = mergeLiveEdits(k, upgrade.Bytes())
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	var mergedController appsv1.Deployment
+	for _, obj := range mustSplitManifest(t, merged) {
+		if obj.Kind == "Deployment" && obj.Name == "linkerd-controller" {
+			if err := yaml.Unmarshal(obj.YAML, &mergedController); err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+		}
+	}
+	if *mergedController.Spec.Replicas != 3 {
+		t.Errorf("Expected the upgrade to set 3 replicas, got %d", *mergedController.Spec.Replicas)
+	}
+	tolerations, _ := json.Marshal(mergedController.Spec.Template.Spec.Tolerations)
+	if len(mergedController.Spec.Template.Spec.Tolerations) != 1 || mergedController.Spec.Template.Spec.Tolerations[0] != toleration {
+		t.Errorf("Expected the live toleration to be preserved, got %s", tolerations)
+	}
+	if _, ok := mergedController.Annotations[lastAppliedAnnotation]; ok {
+		t.Error("Expected the last applied configuration not to be part of the manifest")
+	}
+}
+
+func mustSplitManifest(t *testing.T, manifest []byte) []manifestObject {
+	objs, err := splitManifest(manifest)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	return objs
+}
@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/linkerd/linkerd2/pkg/healthcheck"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+)
+
+const (
+	progressiveConfigStep   = "config"
+	progressiveAddOnsStep   = "add-ons"
+	progressiveWebhooksStep = "webhooks"
+)
+
+// progressiveComponents is the order in which `upgrade --progressive` rolls
+// out the control plane components: identity first, as the proxies of the
+// other components need it to get their certificates, then the components
+// serving the data plane, and the webhooks' backends last.
+var progressiveComponents = []string{"identity", "destination", "controller", "sp-validator", "tap", "proxy-injector", "web"}
+
+// progressiveStep is a set of resources applied together by `upgrade
+// --progressive`, before the control plane health is checked
+type progressiveStep struct {
+	name string
+	objs []manifestObject
+}
+
+// progressiveSteps splits manifest into the steps of a progressive upgrade:
+// the configuration first (i.e. all the resources but the workloads and the
+// webhooks), then each control plane component in turn, then the add-ons, and
+// finally the webhooks, once the workloads serving them are up to date.
+// Empty steps are omitted.
+func progressiveSteps(manifest []byte) ([]progressiveStep, error) {
+	objs, err := splitManifest(manifest)
+	if err != nil {
+		return nil, err
+	}
+	sortByApplyOrder(objs)
+
+	byName := map[string]*progressiveStep{}
+	names := append(append([]string{progressiveConfigStep}, progressiveComponents...), progressiveAddOnsStep, progressiveWebhooksStep)
+	for _, name := range names {
+		byName[name] = &progressiveStep{name: name}
+	}
+
+	for _, obj := range objs {
+		step := byName[progressiveConfigStep]
+		if _, stage := applyStageIndex(obj.Kind); stage == "webhooks" {
+			step = byName[progressiveWebhooksStep]
+		} else if isWorkload(obj.Kind) {
+			step = byName[progressiveAddOnsStep]
+			if component := strings.TrimPrefix(obj.Name, "linkerd-"); byName[component] != nil && component != obj.Name {
+				step = byName[component]
+			}
+		}
+		step.objs = append(step.objs, obj)
+	}
+
+	var steps []progressiveStep
+	for _, name := range names {
+		if len(byName[name].objs) > 0 {
+			steps = append(steps, *byName[name])
+		}
+	}
+	return steps, nil
+}
+
+// validateProgressiveStep returns an error if step isn't a step name
+// accepted by --pause-after
+func validateProgressiveStep(step string) error {
+	names := append(append([]string{progressiveConfigStep}, progressiveComponents...), progressiveAddOnsStep)
+	for _, name := range names {
+		if step == name {
+			return nil
+		}
+	}
+	return fmt.Errorf("--pause-after must be one of: %s", strings.Join(names, ", "))
+}
+
+// upgradeProgressively applies manifest step by step. After each step
+// updating workloads, it waits for them to be ready and checks the health of
+// the control plane with healthy, aborting the upgrade if it fails. It pauses
+// after the pauseAfter step, if set. As the resources are applied with
+// server-side apply, running it again resumes the upgrade: the steps already
+// applied are left unchanged.
+func upgradeProgressively(w io.Writer, k *k8s.KubernetesAPI, manifest []byte, timeout time.Duration, pauseAfter string, healthy func() bool) error {
+	steps, err := progressiveSteps(manifest)
+	if err != nil {
+		return err
+	}
+
+	for i, step := range steps {
+		fmt.Fprintf(w, "Step %d/%d: %s\n", i+1, len(steps), step.name)
+		if err := applyObjects(w, k, step.objs); err != nil {
+			return err
+		}
+		if err := waitForWorkloads(w, k, step.objs, timeout); err != nil {
+			return err
+		}
+
+		hasWorkloads := false
+		for _, obj := range step.objs {
+			hasWorkloads = hasWorkloads || isWorkload(obj.Kind)
+		}
+		if hasWorkloads && !healthy() {
+			return fmt.Errorf("the control plane is unhealthy after the %s step, aborting the upgrade; once fixed, run the same command to resume it, or roll it back with `linkerd upgrade --rollback --progressive`", step.name)
+		}
+		fmt.Fprintln(w)
+
+		if step.name == pauseAfter && i < len(steps)-1 {
+			fmt.Fprintf(w, "%s Upgrade paused after the %s step; run the same command without --pause-after to resume it\n", warnStatus, step.name)
+			return nil
+		}
+	}
+
+	fmt.Fprintf(w, "%s Upgrade complete\n", okStatus)
+	return nil
+}
+
+// controlPlaneHealthy runs the checks validating that the control plane is
+// up and serving, retrying them until timeout
+func controlPlaneHealthy(w io.Writer, timeout time.Duration) func() bool {
+	return func() bool {
+		hc := healthcheck.NewHealthChecker([]healthcheck.CategoryID{
+			healthcheck.KubernetesAPIChecks,
+			healthcheck.LinkerdConfigChecks,
+			healthcheck.LinkerdControlPlaneExistenceChecks,
+			healthcheck.LinkerdAPIChecks,
+			healthcheck.LinkerdIdentity,
+		}, &healthcheck.Options{
+			ControlPlaneNamespace: controlPlaneNamespace,
+			KubeConfig:            kubeconfigPath,
+			KubeContext:           kubeContext,
+			Impersonate:           impersonate,
+			ImpersonateGroup:      impersonateGroup,
+			APIAddr:               apiAddr,
+			RetryDeadline:         time.Now().Add(timeout),
+		})
+		return runChecksTable(w, hc)
+	}
+}
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestProgressiveSteps(t *testing.T) {
+	installOpts, installFlags, _, _ := testOptionsAndFlags(t)
+	install := renderInstall(t, installValues(t, installOpts, installFlags))
+
+	steps, err := progressiveSteps(install.Bytes())
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	var names []string
+	for _, step := range steps {
+		names = append(names, step.name)
+	}
+	expected := []string{"config", "identity", "destination", "controller", "sp-validator", "tap", "proxy-injector", "web", "add-ons", "webhooks"}
+	if !reflect.DeepEqual(names, expected) {
+		t.Fatalf("Expected steps %v, got %v", expected, names)
+	}
+
+	for _, step := range steps {
+		for _, obj := range step.objs {
+			switch step.name {
+			case "config":
+				if isWorkload(obj.Kind) {
+					t.Errorf("Unexpected workload %s in the config step", obj.ref())
+				}
+			case "webhooks":
+				if obj.Kind != "MutatingWebhookConfiguration" && obj.Kind != "ValidatingWebhookConfiguration" && obj.Kind != "APIService" {
+					t.Errorf("Unexpected %s in the webhooks step", obj.ref())
+				}
+			case "add-ons":
+				if obj.Name != "linkerd-grafana" && obj.Name != "linkerd-prometheus" {
+					t.Errorf("Unexpected %s in the add-ons step", obj.ref())
+				}
+			default:
+				if obj.Kind != "Deployment" || obj.Name != "linkerd-"+step.name {
+					t.Errorf("Unexpected %s in the %s step", obj.ref(), step.name)
+				}
+			}
+		}
+	}
+}
+
+func TestValidateProgressiveStep(t *testing.T) {
+	if err := validateProgressiveStep("destination"); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if err := validateProgressiveStep("webhooks"); err == nil {
+		t.Fatal("Expected the last step not to be accepted by --pause-after")
+	}
+}
@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	core "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policy "k8s.io/api/policy/v1beta1"
+	rbac "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// prunableResources returns the resources labeled with the control plane
+// namespace that aren't part of the given upgrade manifest anymore, e.g. the
+// RBAC of a component that has been removed, or of a disabled add-on. The
+// namespace itself, and the configuration history which isn't rendered, are
+// never pruned.
+func prunableResources(k *k8s.KubernetesAPI, manifest []byte) ([]kubernetesResource, error) {
+	rendered, err := splitManifest(manifest)
+	if err != nil {
+		return nil, err
+	}
+	keep := map[string]bool{
+		resourceKey("ConfigMap", controlPlaneNamespace, k8s.ConfigHistoryConfigMapName): true,
+	}
+	for _, obj := range rendered {
+		keep[resourceKey(obj.Kind, obj.Namespace, obj.Name)] = true
+	}
+
+	resources, err := fetchControlPlaneResources(k)
+	if err != nil {
+		return nil, err
+	}
+
+	var prunable []kubernetesResource
+	for _, r := range resources {
+		if !keep[resourceKey(r.Kind, r.Namespace, r.Name)] {
+			prunable = append(prunable, r)
+		}
+	}
+	return prunable, nil
+}
+
+// writePrunableResources writes the resources returned by prunableResources
+// to w, in a format suitable for `kubectl delete -f -`
+func writePrunableResources(w io.Writer, k *k8s.KubernetesAPI, manifest []byte) error {
+	resources, err := prunableResources(k, manifest)
+	if err != nil {
+		return err
+	}
+
+	for _, r := range resources {
+		if err := r.renderResource(w); err != nil {
+			return fmt.Errorf("error rendering Kubernetes resource:%v", err)
+		}
+	}
+	return nil
+}
+
+func resourceKey(kind, namespace, name string) string {
+	return fmt.Sprintf("%s/%s/%s", kind, namespace, name)
+}
+
+// fetchControlPlaneResources returns all the resources of the kinds rendered by
+// install that are labeled with the control plane namespace, but the namespace
+// itself
+func fetchControlPlaneResources(k *k8s.KubernetesAPI) ([]kubernetesResource, error) {
+	options := metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", k8s.ControllerNSLabel, controlPlaneNamespace),
+	}
+
+	var resources []kubernetesResource
+	for _, fetch := range []func(*k8s.KubernetesAPI, metav1.ListOptions) ([]kubernetesResource, error){
+		fetchClusterRoles,
+		fetchClusterRoleBindings,
+		fetchKubeSystemRoleBindings,
+		fetchCustomResourceDefinitions,
+		fetchAPIRegistrationResources,
+		fetchPodSecurityPolicy,
+		fetchSecurityContextConstraints,
+		fetchMutatingWebhooksConfiguration,
+		fetchValidatingWebhooksConfiguration,
+		fetchControlPlaneNamespacedResources,
+	} {
+		r, err := fetch(k, options)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, r...)
+	}
+	return resources, nil
+}
+
+func fetchControlPlaneNamespacedResources(k *k8s.KubernetesAPI, options metav1.ListOptions) ([]kubernetesResource, error) {
+	ns := controlPlaneNamespace
+	lists := []struct {
+		apiVersion string
+		kind       string
+		list       func() (runtime.Object, error)
+	}{
+		{core.SchemeGroupVersion.String(), "ServiceAccount", func() (runtime.Object, error) {
+			return k.CoreV1().ServiceAccounts(ns).List(options)
+		}},
+		{rbac.SchemeGroupVersion.String(), "Role", func() (runtime.Object, error) {
+			return k.RbacV1().Roles(ns).List(options)
+		}},
+		{rbac.SchemeGroupVersion.String(), "RoleBinding", func() (runtime.Object, error) {
+			return k.RbacV1().RoleBindings(ns).List(options)
+		}},
+		{core.SchemeGroupVersion.String(), "ConfigMap", func() (runtime.Object, error) {
+			return k.CoreV1().ConfigMaps(ns).List(options)
+		}},
+		{core.SchemeGroupVersion.String(), "Secret", func() (runtime.Object, error) {
+			return k.CoreV1().Secrets(ns).List(options)
+		}},
+		{networkingv1.SchemeGroupVersion.String(), "NetworkPolicy", func() (runtime.Object, error) {
+			return k.NetworkingV1().NetworkPolicies(ns).List(options)
+		}},
+		{core.SchemeGroupVersion.String(), "Service", func() (runtime.Object, error) {
+			return k.CoreV1().Services(ns).List(options)
+		}},
+		{appsv1.SchemeGroupVersion.String(), "Deployment", func() (runtime.Object, error) {
+			return k.AppsV1().Deployments(ns).List(options)
+		}},
+		{batchv1beta1.SchemeGroupVersion.String(), "CronJob", func() (runtime.Object, error) {
+			return k.BatchV1beta1().CronJobs(ns).List(options)
+		}},
+		{policy.SchemeGroupVersion.String(), "PodDisruptionBudget", func() (runtime.Object, error) {
+			return k.PolicyV1beta1().PodDisruptionBudgets(ns).List(options)
+		}},
+	}
+
+	var resources []kubernetesResource
+	for _, l := range lists {
+		list, err := l.list()
+		if err != nil {
+			return nil, fmt.Errorf("could not fetch %s resources:%v", l.kind, err)
+		}
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			obj, err := meta.Accessor(item)
+			if err != nil {
+				return nil, err
+			}
+			r := newKubernetesResource(l.apiVersion, l.kind, obj.GetName())
+			r.Namespace = obj.GetNamespace()
+			resources = append(resources, r)
+		}
+	}
+	return resources, nil
+}
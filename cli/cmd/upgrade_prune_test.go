@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+)
+
+func TestPrunableResources(t *testing.T) {
+	installOpts, installFlags, _, _ := testOptionsAndFlags(t)
+	install := renderInstall(t, installValues(t, installOpts, installFlags))
+	k, err := k8s.NewFakeAPI(splitManifests(install.String())...)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	resources, err := prunableResources(k, install.Bytes())
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if len(resources) != 0 {
+		t.Fatalf("Expected no resources to prune, got %v", resources)
+	}
+
+	upgradeOpts, upgradeFlags, _, _ := testOptionsAndFlags(t)
+	upgradeFlags.Set("skip-addons", "grafana")
+	upgrade := renderInstall(t, installValues(t, upgradeOpts, upgradeFlags))
+
+	resources, err = prunableResources(k, upgrade.Bytes())
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	var deployment bool
+	for _, r := range resources {
+		if !strings.Contains(r.Name, "grafana") {
+			t.Errorf("Unexpected resource to prune: %s %s", r.Kind, r.Name)
+		}
+		if r.Kind == "Deployment" && r.Namespace == controlPlaneNamespace && r.Name == "linkerd-grafana" {
+			deployment = true
+		}
+	}
+	if !deployment {
+		t.Fatalf("Expected the grafana deployment to be pruned, got %v", resources)
+	}
+}
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/linkerd/linkerd2/pkg/version"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// checkVersionSkew returns an error if upgrading the control plane from the
+// current version to the target one isn't supported, either because it skips
+// releases or because some proxies would be too old for the target version.
+func checkVersionSkew(k kubernetes.Interface, current, target string) error {
+	if err := version.CheckSkew(current, target); err != nil {
+		return fmt.Errorf("upgrading the control plane from %s to %s isn't supported (%s), upgrade to an intermediate version first", current, target, err)
+	}
+
+	outdated, err := outdatedProxyNamespaces(k, target)
+	if err != nil {
+		return err
+	}
+	if len(outdated) > 0 {
+		return fmt.Errorf("some namespaces run proxies too old for %s, re-inject them before upgrading the control plane:\n%s", target, strings.Join(outdated, "\n"))
+	}
+
+	return nil
+}
+
+// outdatedProxyNamespaces reports, for each namespace, the meshed pods whose
+// proxy version is too old for the target control plane version
+func outdatedProxyNamespaces(k kubernetes.Interface, target string) ([]string, error) {
+	pods, err := k.CoreV1().Pods("").List(metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", k8s.ControllerNSLabel, controlPlaneNamespace),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not list the meshed pods: %s", err)
+	}
+
+	// namespace -> proxy version -> number of pods
+	outdated := map[string]map[string]int{}
+	for _, pod := range pods.Items {
+		proxyVersion := pod.Annotations[k8s.ProxyVersionAnnotation]
+		if version.CheckSkew(proxyVersion, target) == nil {
+			continue
+		}
+		if outdated[pod.Namespace] == nil {
+			outdated[pod.Namespace] = map[string]int{}
+		}
+		outdated[pod.Namespace][proxyVersion]++
+	}
+
+	var report []string
+	for ns, versions := range outdated {
+		var counts []string
+		for v, count := range versions {
+			counts = append(counts, fmt.Sprintf("%d pods running %s", count, v))
+		}
+		sort.Strings(counts)
+		report = append(report, fmt.Sprintf("\t* %s: %s", ns, strings.Join(counts, ", ")))
+	}
+	sort.Strings(report)
+	return report, nil
+}
@@ -7,14 +7,19 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/linkerd/linkerd2/pkg/charts/linkerd2"
+	"github.com/linkerd/linkerd2/pkg/config"
+	"github.com/linkerd/linkerd2/pkg/healthcheck"
 	"github.com/linkerd/linkerd2/pkg/k8s"
 	"github.com/linkerd/linkerd2/pkg/tls"
 	"github.com/spf13/pflag"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 const (
@@ -118,6 +123,121 @@ func TestUpgradeExternalIssuer(t *testing.T) {
 	}
 }
 
+func TestUpgradeCertManagerExternalIssuer(t *testing.T) {
+	installOpts, installFlags, upgradeOpts, upgradeFlags := testOptionsAndFlags(t)
+
+	issuer := generateIssuerCerts(t, true)
+	defer issuer.cleanup()
+
+	// non-default settings, which must survive the upgrade
+	certManager := linkerd2.IssuerCertManager{
+		Enabled:           true,
+		TrustAnchorSecret: "custom-trust-anchor",
+		Duration:          "72h",
+		RenewBefore:       "36h",
+	}
+	identity := identityWithAnchorsAndTrustDomain{
+		TrustDomain:     "cluster.local",
+		TrustAnchorsPEM: issuer.ca,
+		Identity: &linkerd2.Identity{
+			Issuer: &linkerd2.Issuer{
+				Scheme: string(corev1.SecretTypeTLS),
+				TLS: &linkerd2.IssuerTLS{
+					CrtPEM: issuer.crt,
+					KeyPEM: issuer.key,
+				},
+				CertManager: &certManager,
+			},
+		},
+	}
+	installOpts.recordFlags(installFlags)
+	values, _, err := installOpts.validateAndBuildWithIdentity("", &identity)
+	if err != nil {
+		t.Fatalf("Failed to build install values: %s", err)
+	}
+	install := renderInstall(t, values)
+	if !strings.Contains(install.String(), "kind: Certificate") {
+		t.Fatal("Expected the install manifest to contain a cert-manager Certificate")
+	}
+
+	// cert-manager annotates the secrets it issues with the Certificate name
+	secret := strings.Replace(externalIssuerSecret(issuer), "  namespace: linkerd\n",
+		"  namespace: linkerd\n  annotations:\n    cert-manager.io/certificate-name: linkerd-identity-issuer\n", 1)
+	// The fake API doesn't know about the cert-manager resources
+	var clusterManifests []string
+	for _, m := range splitManifests(install.String() + secret) {
+		if !strings.Contains(m, "apiVersion: cert-manager.io/") {
+			clusterManifests = append(clusterManifests, m)
+		}
+	}
+	upgrade, err := renderUpgrade(t, strings.Join(clusterManifests, "\n---\n"), upgradeOpts, upgradeFlags)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Install and upgrade manifests should be identical except for the version.
+	expected := replaceVersions(install.String())
+	expectedManifests := parseManifestList(expected)
+	upgradeManifests := parseManifestList(upgrade.String())
+	for id, diffs := range diffManifestLists(expectedManifests, upgradeManifests) {
+		for _, diff := range diffs {
+			t.Errorf("Unexpected diff in %s:\n%s", id, diff.String())
+		}
+	}
+}
+
+func TestUpgradeSpireIssuer(t *testing.T) {
+	installOpts, installFlags, upgradeOpts, upgradeFlags := testOptionsAndFlags(t)
+
+	issuer := generateIssuerCerts(t, false)
+	defer issuer.cleanup()
+
+	installFlags.Set("identity-spire-server-addr", "spire-server.spire:8081")
+	installFlags.Set("identity-spire-agent-socket-path", "/run/spire/agent-sockets/api.sock")
+	spire := installOpts.identityOptions.spire
+	identity := identityWithAnchorsAndTrustDomain{
+		TrustDomain:     "cluster.local",
+		TrustAnchorsPEM: issuer.ca,
+		Identity: &linkerd2.Identity{
+			Issuer: &linkerd2.Issuer{
+				Scheme: k8s.IdentityIssuerSchemeSpire,
+			},
+			Spire: &spire,
+		},
+	}
+	installOpts.recordFlags(installFlags)
+	values, _, err := installOpts.validateAndBuildWithIdentity("", &identity)
+	if err != nil {
+		t.Fatalf("Failed to build install values: %s", err)
+	}
+	install := renderInstall(t, values)
+	if !strings.Contains(install.String(), "-spire-server-addr=spire-server.spire:8081") {
+		t.Fatal("Expected the identity service to obtain its issuer from the SPIRE server")
+	}
+
+	upgrade, err := renderUpgrade(t, install.String(), upgradeOpts, upgradeFlags)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Install and upgrade manifests should be identical except for the version.
+	expected := replaceVersions(install.String())
+	expectedManifests := parseManifestList(expected)
+	upgradeManifests := parseManifestList(upgrade.String())
+	for id, diffs := range diffManifestLists(expectedManifests, upgradeManifests) {
+		for _, diff := range diffs {
+			t.Errorf("Unexpected diff in %s:\n%s", id, diff.String())
+		}
+	}
+
+	upgradeOpts.rotateIssuer = true
+	_, err = renderUpgrade(t, install.String(), upgradeOpts, upgradeFlags)
+	expectedErr := "--rotate-issuer isn't supported when the issuer certificate is obtained from a SPIRE server"
+	if err == nil || err.Error() != expectedErr {
+		t.Errorf("Expected error: %s but got %v", expectedErr, err)
+	}
+}
+
 func TestUpgradeIssuerWithExternalIssuerFails(t *testing.T) {
 	installOpts, installFlags, upgradeOpts, upgradeFlags := testOptionsAndFlags(t)
 
@@ -209,6 +329,116 @@ func TestUpgradeOverwriteIssuer(t *testing.T) {
 	}
 }
 
+func TestUpgradeRotateIssuer(t *testing.T) {
+	installOpts, installFlags, upgradeOpts, upgradeFlags := testOptionsAndFlags(t)
+
+	// Have install generate a self-signed issuer
+	installOpts.identityOptions.crtPEMFile = ""
+	installOpts.identityOptions.keyPEMFile = ""
+	installOpts.identityOptions.trustPEMFile = ""
+	installFlags.Set("enable-identity-rotation", "true")
+	install := renderInstall(t, installValues(t, installOpts, installFlags))
+
+	upgradeOpts.rotateIssuer = true
+	upgrade, err := renderUpgrade(t, install.String(), upgradeOpts, upgradeFlags)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	k, err := k8s.NewFakeAPI(splitManifests(upgrade.String())...)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	secret, err := k.CoreV1().Secrets(controlPlaneNamespace).Get(k8s.IdentityIssuerSecretName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	next, err := tls.DecodePEMCrt(string(secret.Data[k8s.IdentityIssuerNextCrtName]))
+	if err != nil {
+		t.Fatalf("Expected a staged issuer certificate, got: %s", err)
+	}
+	if overlap := secret.Annotations[k8s.IdentityIssuerRotationOverlapAnnotation]; overlap != "48h0m0s" {
+		t.Fatalf("Expected the rotation overlap to be 48h0m0s, got %q", overlap)
+	}
+
+	_, configs, err := healthcheck.FetchLinkerdConfigMap(k, controlPlaneNamespace)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	anchorsPEM := configs.GetGlobal().GetIdentityContext().GetTrustAnchorsPem()
+	anchors, err := tls.DecodePEMCertificates(anchorsPEM)
+	if err != nil || len(anchors) != 2 {
+		t.Fatalf("Expected the current and new trust anchors, got %d (%v)", len(anchors), err)
+	}
+	current, err := tls.DecodePEMCrt(string(secret.Data[k8s.IdentityIssuerCrtName]))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	pool := tls.CertificatesToPool(anchors)
+	for _, crt := range []*tls.Crt{current, next} {
+		if err := crt.Verify(pool, "", time.Time{}); err != nil {
+			t.Fatalf("Expected the trust anchors to validate both issuers: %s", err)
+		}
+	}
+
+	t.Run("Keeps the staged issuer on subsequent upgrades", func(t *testing.T) {
+		_, _, upgradeOpts, upgradeFlags := testOptionsAndFlags(t)
+		reupgrade, err := renderUpgrade(t, upgrade.String(), upgradeOpts, upgradeFlags)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if !strings.Contains(reupgrade.String(), "next-crt.pem") {
+			t.Fatal("Expected the staged issuer to be kept")
+		}
+	})
+
+	t.Run("Rejects a second rotation while one is in progress", func(t *testing.T) {
+		_, _, upgradeOpts, upgradeFlags := testOptionsAndFlags(t)
+		upgradeOpts.rotateIssuer = true
+		_, err := renderUpgrade(t, upgrade.String(), upgradeOpts, upgradeFlags)
+		expectedErr := "an issuer rotation is already in progress; wait for the linkerd-identity-rotation cronjob to complete it"
+		if err == nil || err.Error() != expectedErr {
+			t.Fatalf("Expected error: %s but got %v", expectedErr, err)
+		}
+	})
+}
+
+func TestUpgradeRotateIssuerFails(t *testing.T) {
+	t.Run("With an issuer signed by another trust anchor", func(t *testing.T) {
+		installOpts, installFlags, upgradeOpts, upgradeFlags := testOptionsAndFlags(t)
+		upgradeOpts.rotateIssuer = true
+		_, _, err := renderInstallAndUpgrade(t, installOpts, installFlags, upgradeOpts, upgradeFlags)
+		if err == nil || !strings.HasPrefix(err.Error(), "--rotate-issuer only supports the self-signed issuers") {
+			t.Fatalf("Expected a self-signed issuer error, got %v", err)
+		}
+	})
+
+	t.Run("With an overlap shorter than the issuance lifetime", func(t *testing.T) {
+		installOpts, installFlags, upgradeOpts, upgradeFlags := testOptionsAndFlags(t)
+		installOpts.identityOptions.crtPEMFile = ""
+		installOpts.identityOptions.keyPEMFile = ""
+		installOpts.identityOptions.trustPEMFile = ""
+		upgradeOpts.rotateIssuer = true
+		upgradeOpts.rotationOverlap = time.Hour
+		_, _, err := renderInstallAndUpgrade(t, installOpts, installFlags, upgradeOpts, upgradeFlags)
+		if err == nil || !strings.HasPrefix(err.Error(), "--rotation-overlap must be longer than the issuance lifetime plus the clock skew allowance (24h0m20s)") {
+			t.Fatalf("Expected an overlap error, got %v", err)
+		}
+	})
+
+	t.Run("Without the identity rotation cronjob", func(t *testing.T) {
+		installOpts, installFlags, upgradeOpts, upgradeFlags := testOptionsAndFlags(t)
+		installOpts.identityOptions.crtPEMFile = ""
+		installOpts.identityOptions.keyPEMFile = ""
+		installOpts.identityOptions.trustPEMFile = ""
+		upgradeOpts.rotateIssuer = true
+		_, _, err := renderInstallAndUpgrade(t, installOpts, installFlags, upgradeOpts, upgradeFlags)
+		if err == nil || err.Error() != "--rotate-issuer requires the linkerd-identity-rotation cronjob; enable it with --enable-identity-rotation" {
+			t.Fatalf("Expected an identity rotation error, got %v", err)
+		}
+	})
+}
+
 func TestUpgradeFailsWithOnlyIssuerCert(t *testing.T) {
 	installOpts, installFlags, upgradeOpts, upgradeFlags := testOptionsAndFlags(t)
 
@@ -475,6 +705,251 @@ func TestUpgradeOverwriteRemoveAddonKeys(t *testing.T) {
 	}
 }
 
+func TestUpgradeToRevision(t *testing.T) {
+	installOpts, installFlags, upgradeOpts, upgradeFlags := testOptionsAndFlags(t)
+	defaultValues := installValues(t, installOpts, installFlags)
+
+	haOpts, haFlags, _, _ := testOptionsAndFlags(t)
+	haFlags.Set("ha", "true")
+	haValues := installValues(t, haOpts, haFlags)
+
+	history := []configRevision{{Revision: 1, Command: "install", Configs: defaultValues.Configs}}
+	install := renderInstall(t, haValues)
+	if err := appendConfigHistory(&install, history, "upgrade", haValues.Configs, haOpts.recordedFlags, time.Now()); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	k, err := k8s.NewFakeAPI(splitManifests(install.String())...)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	upgradeOpts.toRevision = 1
+	values, err := upgradeOpts.validateAndBuild("", k, upgradeFlags)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if values.EnablePodAntiAffinity || values.ControllerReplicas != defaultValues.ControllerReplicas {
+		t.Fatal("Expected the HA flags recorded after revision 1 not to be used")
+	}
+	if values.Global.IdentityTrustAnchorsPEM != haValues.Global.IdentityTrustAnchorsPEM {
+		t.Fatal("Expected the current trust anchors to be kept")
+	}
+
+	upgradeOpts, err = testUpgradeOptions()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	upgradeOpts.toRevision = 3
+	if _, err := upgradeOpts.validateAndBuild("", k, upgradeOpts.recordableFlagSet()); err == nil || !strings.Contains(err.Error(), "revision 3 not found") {
+		t.Fatalf("Expected an unknown revision to be rejected, got %v", err)
+	}
+}
+
+func TestUpgradeRollback(t *testing.T) {
+	installOpts, installFlags, upgradeOpts, upgradeFlags := testOptionsAndFlags(t)
+	installOpts.controlPlaneVersion = "install-version"
+	defaultValues := installValues(t, installOpts, installFlags)
+
+	haOpts, haFlags, _, _ := testOptionsAndFlags(t)
+	haFlags.Set("ha", "true")
+	haValues := installValues(t, haOpts, haFlags)
+
+	install := renderInstall(t, defaultValues)
+	manifest := recordedManifest(t, install.Bytes())
+	if err := appendConfigHistory(&install, nil, "install", defaultValues.Configs, installOpts.recordedFlags, time.Now()); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	upgrade := renderInstall(t, haValues)
+	if err := appendConfigHistory(&upgrade, historyFromManifest(t, install.String()), "upgrade", haValues.Configs, haOpts.recordedFlags, time.Now()); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	history := historyFromManifest(t, upgrade.String())
+
+	upgradeOpts.rollback = true
+	previous, err := upgradeOpts.rollbackRevision("", upgradeFlags, history)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if previous.Revision != 1 || !reflect.DeepEqual(previous.Flags, installOpts.recordedFlags) {
+		t.Fatalf("Expected revision 1 to be rolled back to, got %+v", previous)
+	}
+	recorded, err := revisionManifest(previous)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if string(recorded) != manifest {
+		t.Fatal("Expected the manifest of revision 1 to be output as it was applied")
+	}
+
+	// The recorded manifest doesn't include the Secrets, so the current ones
+	// are output along with it, for the rollback to be safely pruned
+	k, err := k8s.NewFakeAPI(splitManifests(upgrade.String())...)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	secrets, err := currentSecrets(k)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	prunableSecrets := func(manifest []byte) []string {
+		resources, err := prunableResources(k, manifest)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		var names []string
+		for _, r := range resources {
+			if r.Kind == "Secret" {
+				names = append(names, r.Name)
+			}
+		}
+		return names
+	}
+	if names := prunableSecrets(recorded); len(names) == 0 {
+		t.Fatal("Expected the Secrets to be missing from the recorded manifest")
+	}
+	if names := prunableSecrets(append(recorded, secrets...)); len(names) != 0 {
+		t.Fatalf("Expected no Secret to be pruned by the rollback, got %v", names)
+	}
+	if strings.Contains(string(secrets), corev1.LastAppliedConfigAnnotation) || strings.Contains(string(secrets), "resourceVersion") {
+		t.Fatalf("Expected the Secrets to be output without their server-side metadata, got %s", secrets)
+	}
+
+	upgradeOpts.prune = true
+	if _, err := upgradeOpts.rollbackRevision("", upgradeFlags, history); err != nil {
+		t.Fatalf("Expected --rollback to be allowed with --prune, got %v", err)
+	}
+	upgradeOpts.prune = false
+
+	upgradeOpts.toRevision = 1
+	if _, err := upgradeOpts.rollbackRevision("", upgradeFlags, history); err == nil || err.Error() != "--rollback can't be used with --to-revision" {
+		t.Fatalf("Expected --rollback and --to-revision to be rejected, got %v", err)
+	}
+	upgradeOpts.toRevision = 0
+
+	if _, err := upgradeOpts.rollbackRevision(controlPlaneStage, upgradeFlags, history); err == nil {
+		t.Fatal("Expected --rollback to be rejected with a stage")
+	}
+
+	upgradeFlags.Set("ha", "true")
+	if _, err := upgradeOpts.rollbackRevision("", upgradeFlags, history); err == nil || err.Error() != "--rollback can't be used with --ha" {
+		t.Fatalf("Expected --rollback to be rejected with changed flags, got %v", err)
+	}
+
+	if _, err := previousConfigRevision(history[:1]); err == nil {
+		t.Fatal("Expected a single revision not to have a previous one")
+	}
+}
+
+func TestUpgradeVersionSkew(t *testing.T) {
+	installOpts, installFlags, upgradeOpts, upgradeFlags := testOptionsAndFlags(t)
+	installOpts.controlPlaneVersion = "stable-2.7.1"
+	install := renderInstall(t, installValues(t, installOpts, installFlags))
+	pod := `apiVersion: v1
+kind: Pod
+metadata:
+  name: emoji
+  namespace: emojivoto
+  labels:
+    linkerd.io/control-plane-ns: linkerd
+  annotations:
+    linkerd.io/proxy-version: stable-2.6.0`
+	k, err := k8s.NewFakeAPI(append(splitManifests(install.String()), pod)...)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	upgradeOpts.controlPlaneVersion = "stable-2.9.0"
+	expected := "upgrading the control plane from stable-2.7.1 to stable-2.9.0 isn't supported (stable-2.7.1 is more than 1 minor releases behind stable-2.9.0), upgrade to an intermediate version first; use --ignore-version-skew to upgrade anyway"
+	if _, err := upgradeOpts.validateAndBuild("", k, upgradeFlags); err == nil || err.Error() != expected {
+		t.Fatalf("Expected error %q, got %v", expected, err)
+	}
+
+	upgradeOpts, err = testUpgradeOptions()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	upgradeOpts.controlPlaneVersion = "stable-2.8.0"
+	expected = "some namespaces run proxies too old for stable-2.8.0, re-inject them before upgrading the control plane:\n\t* emojivoto: 1 pods running stable-2.6.0; use --ignore-version-skew to upgrade anyway"
+	if _, err := upgradeOpts.validateAndBuild("", k, upgradeOpts.recordableFlagSet()); err == nil || err.Error() != expected {
+		t.Fatalf("Expected error %q, got %v", expected, err)
+	}
+
+	upgradeOpts, err = testUpgradeOptions()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	upgradeOpts.controlPlaneVersion = "stable-2.8.0"
+	upgradeOpts.ignoreSkew = true
+	if _, err := upgradeOpts.validateAndBuild("", k, upgradeOpts.recordableFlagSet()); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+}
+
+func TestUpgradeValuesOverrides(t *testing.T) {
+	installOpts, installFlags, upgradeOpts, upgradeFlags := testOptionsAndFlags(t)
+
+	dir, err := ioutil.TempDir("", "linkerd-values")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	defer os.RemoveAll(dir)
+	selector := "tier notin (edge,gateway)"
+	selectorFile := filepath.Join(dir, "selector")
+	if err := ioutil.WriteFile(selectorFile, []byte(selector), 0600); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	installFlags.Set("set", "global.clusterDomain=example.local,global.proxy.logLevel=debug")
+	installFlags.Set("set-file", "global.proxy.excludedNodeSelector="+selectorFile)
+	install := renderInstall(t, installValues(t, installOpts, installFlags))
+
+	k, err := k8s.NewFakeAPI(splitManifests(install.String())...)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	upgradeFlags.Set("set", "global.proxy.logLevel=trace")
+	// The content of the file isn't recorded, so it must be provided again
+	upgradeFlags.Set("set-file", "global.proxy.excludedNodeSelector="+selectorFile)
+	values, err := upgradeOpts.validateAndBuild("", k, upgradeFlags)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	configs, err := config.FromConfigMap(map[string]string{
+		"global":  values.Configs.Global,
+		"proxy":   values.Configs.Proxy,
+		"install": values.Configs.Install,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if values.Global.ClusterDomain != "example.local" || configs.GetGlobal().GetClusterDomain() != "example.local" {
+		t.Errorf("Expected the cluster domain to be replayed, got %q in the values and %q in the config", values.Global.ClusterDomain, configs.GetGlobal().GetClusterDomain())
+	}
+	// The overrides given on upgrade take precedence over the recorded ones
+	if values.Global.Proxy.LogLevel != "trace" || configs.GetProxy().GetLogLevel().GetLevel() != "trace" {
+		t.Errorf("Expected the proxy log level to be overridden, got %q in the values and %q in the config", values.Global.Proxy.LogLevel, configs.GetProxy().GetLogLevel().GetLevel())
+	}
+	if values.Global.Proxy.ExcludedNodeSelector != selector || configs.GetProxy().GetExcludedNodeSelector() != selector {
+		t.Errorf("Expected the excluded node selector to be set, got %q in the values and %q in the config", values.Global.Proxy.ExcludedNodeSelector, configs.GetProxy().GetExcludedNodeSelector())
+	}
+
+	expectedFlags := []string{
+		"set=global.clusterDomain=example.local,global.proxy.logLevel=debug",
+		"set=global.proxy.logLevel=trace",
+	}
+	var flags []string
+	for _, f := range configs.GetInstall().GetFlags() {
+		if strings.HasPrefix(f.GetName(), "set") {
+			flags = append(flags, fmt.Sprintf("%s=%s", f.GetName(), f.GetValue()))
+		}
+	}
+	if !reflect.DeepEqual(flags, expectedFlags) {
+		t.Errorf("Expected recorded flags %v, got %v", expectedFlags, flags)
+	}
+}
+
 /* Helpers */
 
 func testUpgradeOptions() (*upgradeOptions, error) {
@@ -10,8 +10,7 @@ import (
 	"github.com/linkerd/linkerd2/pkg/k8s"
 	logging "github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
 )
 
 const defaultWeight uint32 = 10000
@@ -36,7 +35,7 @@ func newEndpointTranslator(
 	enableH2Upgrade bool,
 	service string,
 	srcNodeName string,
-	k8sClient kubernetes.Interface,
+	nodes corelisters.NodeLister,
 	stream pb.Destination_GetServer,
 	log *logging.Entry,
 ) *endpointTranslator {
@@ -45,7 +44,7 @@ func newEndpointTranslator(
 		"service":   service,
 	})
 
-	nodeTopologyLabels, err := getK8sNodeTopology(k8sClient, srcNodeName)
+	nodeTopologyLabels, err := getK8sNodeTopology(nodes, srcNodeName)
 	if err != nil {
 		log.Errorf("Failed to get node topology for node %s: %s", srcNodeName, err)
 	}
@@ -218,7 +217,7 @@ func (et *endpointTranslator) sendClientAdd(set watcher.AddressSet) {
 			addr, err = et.toAddr(address)
 			wa = &pb.WeightedAddr{
 				Addr:              addr,
-				Weight:            defaultWeight,
+				Weight:            addressWeight(address),
 				AuthorityOverride: authOverride,
 			}
 
@@ -283,6 +282,13 @@ func (et *endpointTranslator) sendClientRemove(set watcher.AddressSet) {
 	}
 }
 
+func addressWeight(address watcher.Address) uint32 {
+	if address.Weight != 0 {
+		return address.Weight
+	}
+	return defaultWeight
+}
+
 func (et *endpointTranslator) toAddr(address watcher.Address) (*net.TcpAddress, error) {
 	ip, err := addr.ParseProxyIPV4(address.IP)
 	if err != nil {
@@ -337,16 +343,18 @@ func (et *endpointTranslator) toWeightedAddr(address watcher.Address) (*pb.Weigh
 
 	return &pb.WeightedAddr{
 		Addr:         tcpAddr,
-		Weight:       defaultWeight,
+		Weight:       addressWeight(address),
 		MetricLabels: labels,
 		TlsIdentity:  identity,
 		ProtocolHint: hint,
 	}, nil
 }
 
-func getK8sNodeTopology(k8sClient kubernetes.Interface, srcNode string) (map[string]string, error) {
+// getK8sNodeTopology returns the topology labels of the node from the shared
+// informer cache, so that each stream doesn't query the API server
+func getK8sNodeTopology(nodes corelisters.NodeLister, srcNode string) (map[string]string, error) {
 	nodeTopology := make(map[string]string)
-	node, err := k8sClient.CoreV1().Nodes().Get(srcNode, metav1.GetOptions{})
+	node, err := nodes.Get(srcNode)
 	if err != nil {
 		return nodeTopology, err
 	}
@@ -146,7 +146,7 @@ metadata:
 		true,
 		"service-name.service-ns",
 		"test-123",
-		k8sAPI.Client,
+		k8sAPI.Node().Lister(),
 		mockGetServer,
 		logging.WithField("test", t.Name()),
 	)
@@ -389,6 +389,42 @@ func TestEndpointTranslatorForPods(t *testing.T) {
 	})
 }
 
+func TestEndpointTranslatorTopologyAwareRouting(t *testing.T) {
+	inZone := normalPod
+	inZone.TopologyLabels = map[string]string{corev1.LabelZoneFailureDomainStable: "west-1a"}
+	otherZone := tlsOptionalPod
+	otherZone.TopologyLabels = map[string]string{corev1.LabelZoneFailureDomainStable: "west-1b"}
+	pref := []string{corev1.LabelZoneFailureDomainStable, "*"}
+
+	t.Run("Sends only the endpoints in the zone of the client", func(t *testing.T) {
+		mockGetServer, translator := makeEndpointTranslator(t)
+
+		set := mkAddressSetForPods(inZone, otherZone)
+		set.TopologicalPref = pref
+		translator.Add(set)
+
+		addrs := mockGetServer.updatesReceived[0].GetAdd().GetAddrs()
+		if len(addrs) != 1 {
+			t.Fatalf("Expected [1] address returned, got %v", addrs)
+		}
+		checkAddressAndWeight(t, addrs[0], inZone)
+	})
+
+	t.Run("Falls back to all the endpoints if none is in the zone of the client", func(t *testing.T) {
+		mockGetServer, translator := makeEndpointTranslator(t)
+
+		set := mkAddressSetForPods(otherZone)
+		set.TopologicalPref = pref
+		translator.Add(set)
+
+		addrs := mockGetServer.updatesReceived[0].GetAdd().GetAddrs()
+		if len(addrs) != 1 {
+			t.Fatalf("Expected [1] address returned, got %v", addrs)
+		}
+		checkAddressAndWeight(t, addrs[0], otherZone)
+	})
+}
+
 func mkAddressSetForServices(gatewayAddresses ...watcher.Address) watcher.AddressSet {
 	set := watcher.AddressSet{
 		Addresses:       make(map[watcher.ServiceID]watcher.Address),
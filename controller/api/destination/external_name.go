@@ -0,0 +1,122 @@
+package destination
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/linkerd/linkerd2/controller/api/destination/watcher"
+	logging "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// externalNameRefreshInterval is how often the hostname of an ExternalName
+// Service pointing outside of the cluster is resolved again
+const externalNameRefreshInterval = 30 * time.Second
+
+// externalNameResolver resolves the hostname of an ExternalName Service
+// pointing outside of the cluster, and sends its addresses to a listener.
+// These addresses have no identity, so the proxies reach them in plaintext,
+// like they would after resolving the hostname themselves.
+type externalNameResolver struct {
+	service   watcher.ServiceID
+	hostname  string
+	port      watcher.Port
+	listener  watcher.EndpointUpdateListener
+	lookupIP  func(host string) ([]net.IP, error)
+	addresses map[watcher.ServiceID]watcher.Address
+	log       *logging.Entry
+}
+
+// getExternalName returns the external name of the service, if it's an
+// ExternalName Service
+func (s *server) getExternalName(service watcher.ServiceID) string {
+	svc, err := s.k8sAPI.Svc().Lister().Services(service.Namespace).Get(service.Name)
+	if err != nil || svc.Spec.Type != corev1.ServiceTypeExternalName {
+		return ""
+	}
+	return strings.TrimSuffix(svc.Spec.ExternalName, ".")
+}
+
+func newExternalNameResolver(
+	service watcher.ServiceID,
+	hostname string,
+	port watcher.Port,
+	listener watcher.EndpointUpdateListener,
+	lookupIP func(host string) ([]net.IP, error),
+	log *logging.Entry,
+) *externalNameResolver {
+	return &externalNameResolver{
+		service:   service,
+		hostname:  hostname,
+		port:      port,
+		listener:  listener,
+		lookupIP:  lookupIP,
+		addresses: make(map[watcher.ServiceID]watcher.Address),
+		log:       log.WithField("external-name", hostname),
+	}
+}
+
+// run resolves the hostname periodically until shutdown or done is closed
+func (r *externalNameResolver) run(shutdown, done <-chan struct{}) {
+	ticker := time.NewTicker(externalNameRefreshInterval)
+	defer ticker.Stop()
+
+	r.resolve()
+	for {
+		select {
+		case <-shutdown:
+			return
+		case <-done:
+			return
+		case <-ticker.C:
+			r.resolve()
+		}
+	}
+}
+
+// resolve looks up the IPv4 addresses of the hostname and sends the ones
+// added and removed since the previous lookup to the listener. Lookup errors
+// are logged, keeping the previous addresses.
+func (r *externalNameResolver) resolve() {
+	ips, err := r.lookupIP(r.hostname)
+	if err != nil {
+		r.log.Warnf("Failed to resolve %s: %s", r.hostname, err)
+		return
+	}
+
+	addresses := make(map[watcher.ServiceID]watcher.Address)
+	for _, ip := range ips {
+		if ip.To4() == nil {
+			continue
+		}
+		id := watcher.ServiceID{Name: fmt.Sprintf("%s-%d", ip, r.port)}
+		addresses[id] = watcher.Address{IP: ip.String(), Port: r.port}
+	}
+
+	labels := map[string]string{"service": r.service.Name, "namespace": r.service.Namespace}
+	add := watcher.AddressSet{Addresses: make(map[watcher.ServiceID]watcher.Address), Labels: labels}
+	remove := watcher.AddressSet{Addresses: make(map[watcher.ServiceID]watcher.Address), Labels: labels}
+	for id, address := range addresses {
+		if _, ok := r.addresses[id]; !ok {
+			add.Addresses[id] = address
+		}
+	}
+	for id, address := range r.addresses {
+		if _, ok := addresses[id]; !ok {
+			remove.Addresses[id] = address
+		}
+	}
+	r.addresses = addresses
+
+	if len(remove.Addresses) > 0 {
+		r.listener.Remove(remove)
+	}
+	if len(add.Addresses) > 0 {
+		r.listener.Add(add)
+	}
+	if len(addresses) == 0 {
+		r.listener.NoEndpoints(true)
+	}
+}
@@ -0,0 +1,237 @@
+package destination
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/linkerd/linkerd2/controller/api/destination/watcher"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	logging "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// localCluster is the key of the local cluster in a federatedService
+const localCluster = ""
+
+// federatedService merges the endpoints of a local service with the ones of
+// its mirrors from the linked clusters, whether they point to the gateway of
+// their cluster or directly to the remote pods in a flat network. The
+// addresses of each linked cluster get the weight configured for it. Clusters
+// with a weight of 0 are kept for failover: their endpoints are only sent to
+// the listener when the local cluster and the other linked clusters have none.
+type federatedService struct {
+	listener watcher.EndpointUpdateListener
+	weights  map[string]uint32
+	clusters map[string]watcher.AddressSet
+	failover bool
+	log      *logging.Entry
+
+	// All access to the federatedService is explicitly synchronized by this
+	// mutex, as updates for each cluster come from different publishers.
+	sync.Mutex
+}
+
+// federatedClusterListener receives the endpoint updates of one of the
+// clusters of a federatedService
+type federatedClusterListener struct {
+	federated *federatedService
+	cluster   string
+}
+
+// getFederatedMirrors returns the mirrors of the service, indexed by cluster
+// name, and the weights of their clusters, if the service is federated
+func (s *server) getFederatedMirrors(service watcher.ServiceID) (map[string]watcher.ServiceID, map[string]uint32) {
+	svc, err := s.k8sAPI.Svc().Lister().Services(service.Namespace).Get(service.Name)
+	if err != nil || svc.Annotations[k8s.FederatedServiceAnnotation] != "true" {
+		return nil, nil
+	}
+
+	selector := labels.SelectorFromSet(labels.Set{k8s.MirroredResourceLabel: "true"})
+	services, err := s.k8sAPI.Svc().Lister().Services(service.Namespace).List(selector)
+	if err != nil {
+		s.log.Errorf("Failed to list the mirrors of %s: %s", service, err)
+		return nil, nil
+	}
+
+	mirrors := make(map[string]watcher.ServiceID)
+	for _, mirror := range services {
+		cluster := mirror.Labels[k8s.RemoteClusterNameLabel]
+		if cluster == "" || mirror.Name != service.Name+"-"+cluster {
+			continue
+		}
+		mirrors[cluster] = watcher.ServiceID{Name: mirror.Name, Namespace: mirror.Namespace}
+	}
+
+	return mirrors, parseClusterWeights(svc.Annotations[k8s.FederatedClusterWeightsAnnotation], s.log)
+}
+
+// parseClusterWeights parses a comma-separated list of cluster=weight pairs,
+// skipping the invalid ones, i.e. the ones without a cluster name or with a
+// weight that isn't a uint32. The last weight of a cluster listed more than
+// once wins.
+func parseClusterWeights(value string, log *logging.Entry) map[string]uint32 {
+	weights := make(map[string]uint32)
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+			log.Warnf("Ignoring invalid cluster weight %q", pair)
+			continue
+		}
+		weight, err := strconv.ParseUint(strings.TrimSpace(parts[1]), 10, 32)
+		if err != nil {
+			log.Warnf("Ignoring invalid cluster weight %q: %s", pair, err)
+			continue
+		}
+		weights[strings.TrimSpace(parts[0])] = uint32(weight)
+	}
+	return weights
+}
+
+func newFederatedService(listener watcher.EndpointUpdateListener, weights map[string]uint32, log *logging.Entry) *federatedService {
+	return &federatedService{
+		listener: listener,
+		weights:  weights,
+		clusters: make(map[string]watcher.AddressSet),
+		log:      log.WithField("component", "federated-service"),
+	}
+}
+
+// clusterListener returns a listener for the endpoints of the given cluster
+func (fs *federatedService) clusterListener(cluster string) *federatedClusterListener {
+	return &federatedClusterListener{federated: fs, cluster: cluster}
+}
+
+func (l *federatedClusterListener) Add(set watcher.AddressSet) {
+	l.federated.add(l.cluster, set)
+}
+
+func (l *federatedClusterListener) Remove(set watcher.AddressSet) {
+	l.federated.remove(l.cluster, set)
+}
+
+func (l *federatedClusterListener) NoEndpoints(exists bool) {
+	l.federated.noEndpoints(l.cluster, exists)
+}
+
+func (fs *federatedService) add(cluster string, set watcher.AddressSet) {
+	fs.Lock()
+	defer fs.Unlock()
+
+	addresses := fs.clusters[cluster]
+	if addresses.Addresses == nil {
+		addresses.Addresses = make(map[watcher.ID]watcher.Address)
+	}
+	for id, address := range set.Addresses {
+		addresses.Addresses[id] = address
+	}
+	addresses.Labels = set.Labels
+	fs.clusters[cluster] = addresses
+
+	if fs.active(cluster) {
+		fs.listener.Add(fs.weighted(cluster, set.Addresses))
+	}
+	fs.updateFailover()
+}
+
+func (fs *federatedService) remove(cluster string, set watcher.AddressSet) {
+	fs.Lock()
+	defer fs.Unlock()
+
+	for id := range set.Addresses {
+		delete(fs.clusters[cluster].Addresses, id)
+	}
+
+	if fs.active(cluster) {
+		fs.listener.Remove(fs.weighted(cluster, set.Addresses))
+	}
+	fs.updateFailover()
+}
+
+func (fs *federatedService) noEndpoints(cluster string, exists bool) {
+	fs.Lock()
+	defer fs.Unlock()
+
+	if removed := fs.clusters[cluster]; fs.active(cluster) && len(removed.Addresses) > 0 {
+		fs.listener.Remove(fs.weighted(cluster, removed.Addresses))
+	}
+	delete(fs.clusters, cluster)
+	fs.updateFailover()
+
+	for cluster, addresses := range fs.clusters {
+		if fs.active(cluster) && len(addresses.Addresses) > 0 {
+			return
+		}
+	}
+	fs.listener.NoEndpoints(exists)
+}
+
+// primary returns whether the endpoints of the cluster are used when it has
+// some, as opposed to clusters only used for failover
+func (fs *federatedService) primary(cluster string) bool {
+	weight, ok := fs.weights[cluster]
+	return cluster == localCluster || !ok || weight > 0
+}
+
+// active returns whether the endpoints of the cluster are currently sent to
+// the listener
+func (fs *federatedService) active(cluster string) bool {
+	return fs.primary(cluster) != fs.failover
+}
+
+// updateFailover switches to the failover clusters when the primary ones
+// have no endpoints left, and back when they have some again
+func (fs *federatedService) updateFailover() {
+	primaryEndpoints, failoverEndpoints := false, false
+	for cluster, addresses := range fs.clusters {
+		if len(addresses.Addresses) == 0 {
+			continue
+		}
+		if fs.primary(cluster) {
+			primaryEndpoints = true
+		} else {
+			failoverEndpoints = true
+		}
+	}
+
+	failover := !primaryEndpoints && failoverEndpoints
+	if failover == fs.failover {
+		return
+	}
+
+	fs.log.Debugf("Switching failover to %t", failover)
+	for cluster, addresses := range fs.clusters {
+		if fs.active(cluster) && len(addresses.Addresses) > 0 {
+			fs.listener.Remove(fs.weighted(cluster, addresses.Addresses))
+		}
+	}
+	fs.failover = failover
+	for cluster, addresses := range fs.clusters {
+		if fs.active(cluster) && len(addresses.Addresses) > 0 {
+			fs.listener.Add(fs.weighted(cluster, addresses.Addresses))
+		}
+	}
+}
+
+// weighted returns an AddressSet with the addresses of the cluster, weighted
+// as configured for that cluster. The addresses of the local cluster and of
+// the unlisted clusters are left with a Weight of 0, for which the
+// endpointTranslator uses defaultWeight; the failover clusters have a weight
+// of 0 too, as they're only active when no other cluster has endpoints.
+func (fs *federatedService) weighted(cluster string, addresses map[watcher.ID]watcher.Address) watcher.AddressSet {
+	set := watcher.AddressSet{
+		Addresses: make(map[watcher.ID]watcher.Address),
+		Labels:    fs.clusters[cluster].Labels,
+	}
+	for id, address := range addresses {
+		if cluster != localCluster {
+			address.Weight = fs.weights[cluster]
+		}
+		set.Addresses[id] = address
+	}
+	return set
+}
@@ -0,0 +1,185 @@
+package destination
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/linkerd/linkerd2/controller/api/destination/watcher"
+	logging "github.com/sirupsen/logrus"
+)
+
+// recordingEndpointListener keeps the addresses it's been sent, along with
+// the number of NoEndpoints updates
+type recordingEndpointListener struct {
+	addresses   map[watcher.ID]watcher.Address
+	noEndpoints int
+}
+
+func newRecordingEndpointListener() *recordingEndpointListener {
+	return &recordingEndpointListener{addresses: make(map[watcher.ID]watcher.Address)}
+}
+
+func (l *recordingEndpointListener) Add(set watcher.AddressSet) {
+	for id, address := range set.Addresses {
+		l.addresses[id] = address
+	}
+}
+
+func (l *recordingEndpointListener) Remove(set watcher.AddressSet) {
+	for id := range set.Addresses {
+		delete(l.addresses, id)
+	}
+}
+
+func (l *recordingEndpointListener) NoEndpoints(exists bool) {
+	l.noEndpoints++
+}
+
+func (l *recordingEndpointListener) weights() map[string]uint32 {
+	weights := make(map[string]uint32)
+	for id, address := range l.addresses {
+		weights[id.Name] = address.Weight
+	}
+	return weights
+}
+
+func (l *recordingEndpointListener) names() []string {
+	names := []string{}
+	for id := range l.addresses {
+		names = append(names, id.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func addressSet(names ...string) watcher.AddressSet {
+	set := watcher.AddressSet{Addresses: make(map[watcher.ID]watcher.Address)}
+	for _, name := range names {
+		set.Addresses[watcher.ID{Name: name, Namespace: "ns"}] = watcher.Address{IP: "1.1.1.1", Port: 8989}
+	}
+	return set
+}
+
+func TestFederatedService(t *testing.T) {
+	log := logging.WithField("test", t.Name())
+
+	t.Run("Merges the endpoints of all the clusters with their weights", func(t *testing.T) {
+		listener := newRecordingEndpointListener()
+		federated := newFederatedService(listener, map[string]uint32{"east": 5000}, log)
+
+		federated.clusterListener(localCluster).Add(addressSet("local-1"))
+		federated.clusterListener("east").Add(addressSet("east-1"))
+		federated.clusterListener("west").Add(addressSet("west-1"))
+
+		expected := map[string]uint32{"local-1": 0, "east-1": 5000, "west-1": 0}
+		testCompare(t, expected, listener.weights())
+
+		federated.clusterListener("east").Remove(addressSet("east-1"))
+
+		expected = map[string]uint32{"local-1": 0, "west-1": 0}
+		testCompare(t, expected, listener.weights())
+	})
+
+	t.Run("Gives the local and unlisted clusters the default weight", func(t *testing.T) {
+		listener := newRecordingEndpointListener()
+		federated := newFederatedService(listener, map[string]uint32{"east": 5000}, log)
+
+		federated.clusterListener(localCluster).Add(addressSet("local-1"))
+		federated.clusterListener("east").Add(addressSet("east-1"))
+		federated.clusterListener("west").Add(addressSet("west-1"))
+
+		actual := make(map[string]uint32)
+		for id, address := range listener.addresses {
+			actual[id.Name] = addressWeight(address)
+		}
+		expected := map[string]uint32{"local-1": defaultWeight, "east-1": 5000, "west-1": defaultWeight}
+		testCompare(t, expected, actual)
+	})
+
+	t.Run("Fails over to the clusters with a weight of 0", func(t *testing.T) {
+		listener := newRecordingEndpointListener()
+		federated := newFederatedService(listener, map[string]uint32{"east": 0}, log)
+
+		federated.clusterListener(localCluster).Add(addressSet("local-1", "local-2"))
+		federated.clusterListener("east").Add(addressSet("east-1"))
+		testCompare(t, []string{"local-1", "local-2"}, listener.names())
+
+		federated.clusterListener(localCluster).Remove(addressSet("local-1"))
+		testCompare(t, []string{"local-2"}, listener.names())
+
+		federated.clusterListener(localCluster).NoEndpoints(true)
+		testCompare(t, []string{"east-1"}, listener.names())
+		if listener.noEndpoints != 0 {
+			t.Fatalf("Expected no NoEndpoints update while failing over, got %d", listener.noEndpoints)
+		}
+
+		federated.clusterListener(localCluster).Add(addressSet("local-3"))
+		testCompare(t, []string{"local-3"}, listener.names())
+	})
+
+	t.Run("Fails over right away when only the clusters with a weight of 0 have endpoints", func(t *testing.T) {
+		listener := newRecordingEndpointListener()
+		federated := newFederatedService(listener, map[string]uint32{"east": 0, "west": 0}, log)
+
+		federated.clusterListener("east").Add(addressSet("east-1"))
+		federated.clusterListener("west").Add(addressSet("west-1"))
+		testCompare(t, []string{"east-1", "west-1"}, listener.names())
+
+		federated.clusterListener("north").Add(addressSet("north-1"))
+		testCompare(t, []string{"north-1"}, listener.names())
+	})
+
+	t.Run("Sends NoEndpoints when the failover clusters have no endpoints left", func(t *testing.T) {
+		listener := newRecordingEndpointListener()
+		federated := newFederatedService(listener, map[string]uint32{"east": 0}, log)
+
+		federated.clusterListener(localCluster).Add(addressSet("local-1"))
+		federated.clusterListener("east").Add(addressSet("east-1"))
+		federated.clusterListener(localCluster).NoEndpoints(true)
+		testCompare(t, []string{"east-1"}, listener.names())
+
+		federated.clusterListener("east").NoEndpoints(true)
+		if listener.noEndpoints != 1 {
+			t.Fatalf("Expected 1 NoEndpoints update, got %d", listener.noEndpoints)
+		}
+		testCompare(t, []string{}, listener.names())
+	})
+
+	t.Run("Sends NoEndpoints when no cluster has endpoints", func(t *testing.T) {
+		listener := newRecordingEndpointListener()
+		federated := newFederatedService(listener, map[string]uint32{}, log)
+
+		federated.clusterListener(localCluster).Add(addressSet("local-1"))
+		federated.clusterListener("east").NoEndpoints(true)
+		if listener.noEndpoints != 0 {
+			t.Fatalf("Expected no NoEndpoints update, got %d", listener.noEndpoints)
+		}
+
+		federated.clusterListener(localCluster).NoEndpoints(true)
+		if listener.noEndpoints != 1 {
+			t.Fatalf("Expected 1 NoEndpoints update, got %d", listener.noEndpoints)
+		}
+		testCompare(t, []string{}, listener.names())
+	})
+}
+
+func TestParseClusterWeights(t *testing.T) {
+	log := logging.WithField("test", t.Name())
+
+	testCases := []struct {
+		value    string
+		expected map[string]uint32
+	}{
+		{"", map[string]uint32{}},
+		{"east=5000, west = 0,invalid,north=-1,", map[string]uint32{"east": 5000, "west": 0}},
+		{"east=1,east=2", map[string]uint32{"east": 2}},
+		{"=5,east=,west=4294967295,north=4294967296", map[string]uint32{"west": 4294967295}},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.value, func(t *testing.T) {
+			testCompare(t, tc.expected, parseClusterWeights(tc.value, log))
+		})
+	}
+}
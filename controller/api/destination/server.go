@@ -33,6 +33,9 @@ type (
 		k8sAPI   *k8s.API
 		log      *logging.Entry
 		shutdown <-chan struct{}
+
+		// lookupIP resolves the external names of the ExternalName Services
+		lookupIP func(host string) ([]net.IP, error)
 	}
 )
 
@@ -79,6 +82,7 @@ func NewServer(
 		k8sAPI,
 		log,
 		shutdown,
+		net.LookupIP,
 	}
 
 	s := prometheus.NewGrpcServer()
@@ -107,7 +111,7 @@ func (s *server) Get(dest *pb.GetDestination, stream pb.Destination_GetServer) e
 		s.enableH2Upgrade,
 		dest.GetPath(),
 		token.NodeName,
-		s.k8sAPI.Client,
+		s.k8sAPI.Node().Lister(),
 		stream,
 		log,
 	)
@@ -135,7 +139,41 @@ func (s *server) Get(dest *pb.GetDestination, stream pb.Destination_GetServer) e
 			return status.Errorf(codes.InvalidArgument, "Invalid authority: %s", dest.GetPath())
 		}
 
-		err = s.endpoints.Subscribe(service, port, instanceID, translator)
+		// ExternalName Services pointing to another Service of the cluster
+		// resolve to its endpoints; the other ones resolve to the addresses
+		// of their external name.
+		if externalName := s.getExternalName(service); externalName != "" {
+			target, targetInstanceID, err := parseK8sServiceName(externalName, s.clusterDomain)
+			if err != nil {
+				log.Debugf("Resolving %s to the addresses of %s", dest.GetPath(), externalName)
+				resolver := newExternalNameResolver(service, externalName, port, translator, s.lookupIP, log)
+				resolver.run(s.shutdown, stream.Context().Done())
+				return nil
+			}
+			log.Debugf("Resolving %s to the endpoints of %s", dest.GetPath(), externalName)
+			service, instanceID = target, targetInstanceID
+		}
+
+		// Federated services also resolve to the endpoints of their mirrors
+		// from the linked clusters.
+		var listener watcher.EndpointUpdateListener = translator
+		if instanceID == "" {
+			if mirrors, weights := s.getFederatedMirrors(service); len(mirrors) > 0 {
+				federated := newFederatedService(translator, weights, log)
+				for cluster, mirror := range mirrors {
+					mirrorListener := federated.clusterListener(cluster)
+					err := s.endpoints.Subscribe(mirror, port, "", mirrorListener)
+					if err != nil {
+						log.Warnf("Failed to subscribe to %s on cluster %s: %s", mirror, cluster, err)
+						continue
+					}
+					defer s.endpoints.Unsubscribe(mirror, port, "", mirrorListener)
+				}
+				listener = federated.clusterListener(localCluster)
+			}
+		}
+
+		err = s.endpoints.Subscribe(service, port, instanceID, listener)
 		if err != nil {
 			if _, ok := err.(watcher.InvalidService); ok {
 				log.Debugf("Invalid service %s", dest.GetPath())
@@ -144,7 +182,7 @@ func (s *server) Get(dest *pb.GetDestination, stream pb.Destination_GetServer) e
 			log.Errorf("Failed to subscribe to %s: %s", dest.GetPath(), err)
 			return err
 		}
-		defer s.endpoints.Unsubscribe(service, port, instanceID, translator)
+		defer s.endpoints.Unsubscribe(service, port, instanceID, listener)
 	}
 
 	select {
@@ -1,6 +1,7 @@
 package destination
 
 import (
+	"net"
 	"testing"
 
 	pb "github.com/linkerd/linkerd2-proxy-api/go/destination"
@@ -44,6 +45,24 @@ spec:
   - port: 8989`,
 		`
 apiVersion: v1
+kind: Service
+metadata:
+  name: alias
+  namespace: ns
+spec:
+  type: ExternalName
+  externalName: name1.ns.svc.mycluster.local`,
+		`
+apiVersion: v1
+kind: Service
+metadata:
+  name: external
+  namespace: ns
+spec:
+  type: ExternalName
+  externalName: example.com.`,
+		`
+apiVersion: v1
 kind: Endpoints
 metadata:
   name: name1
@@ -118,6 +137,7 @@ spec:
 		k8sAPI,
 		log,
 		make(<-chan struct{}),
+		net.LookupIP,
 	}
 }
 
@@ -185,6 +205,62 @@ func TestGet(t *testing.T) {
 		}
 
 	})
+
+	t.Run("Returns endpoints of the Service targeted by an ExternalName Service", func(t *testing.T) {
+		server := makeServer(t)
+
+		stream := &bufferingGetStream{
+			updates:          []*pb.Update{},
+			MockServerStream: util.NewMockServerStream(),
+		}
+		stream.Cancel()
+
+		err := server.Get(&pb.GetDestination{Scheme: "k8s", Path: "alias.ns.svc.mycluster.local:8989"}, stream)
+		if err != nil {
+			t.Fatalf("Got error: %s", err)
+		}
+
+		if len(stream.updates) != 1 {
+			t.Fatalf("Expected 1 update but got %d: %v", len(stream.updates), stream.updates)
+		}
+
+		if updateAddAddress(t, stream.updates[0])[0] != "172.17.0.12:8989" {
+			t.Fatalf("Expected 172.17.0.12:8989 but got %s", updateAddAddress(t, stream.updates[0])[0])
+		}
+	})
+
+	t.Run("Returns the addresses of the external name of an ExternalName Service", func(t *testing.T) {
+		server := makeServer(t)
+		server.lookupIP = func(host string) ([]net.IP, error) {
+			if host != "example.com" {
+				t.Fatalf("Expected to resolve example.com but got %s", host)
+			}
+			return []net.IP{net.ParseIP("192.0.2.10"), net.ParseIP("2001:db8::10")}, nil
+		}
+
+		stream := &bufferingGetStream{
+			updates:          []*pb.Update{},
+			MockServerStream: util.NewMockServerStream(),
+		}
+		stream.Cancel()
+
+		err := server.Get(&pb.GetDestination{Scheme: "k8s", Path: "external.ns.svc.mycluster.local:443"}, stream)
+		if err != nil {
+			t.Fatalf("Got error: %s", err)
+		}
+
+		if len(stream.updates) != 1 {
+			t.Fatalf("Expected 1 update but got %d: %v", len(stream.updates), stream.updates)
+		}
+
+		addrs := updateAddAddress(t, stream.updates[0])
+		if len(addrs) != 1 || addrs[0] != "192.0.2.10:443" {
+			t.Fatalf("Expected [192.0.2.10:443] but got %v", addrs)
+		}
+		if identity := stream.updates[0].GetAdd().GetAddrs()[0].GetTlsIdentity(); identity != nil {
+			t.Fatalf("Expected no TLS identity but got %v", identity)
+		}
+	})
 }
 
 func TestGetProfiles(t *testing.T) {
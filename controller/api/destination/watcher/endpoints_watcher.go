@@ -5,6 +5,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/linkerd/linkerd2/controller/k8s"
 	consts "github.com/linkerd/linkerd2/pkg/k8s"
@@ -48,6 +49,8 @@ type (
 		Identity          string
 		AuthorityOverride string
 		TopologyLabels    map[string]string
+		// Weight overrides the default weight of the address when non-zero
+		Weight uint32
 	}
 
 	// AddressSet is a set of Address, indexed by ID.
@@ -65,7 +68,10 @@ type (
 	// EndpointsWatcher watches all endpoints and services in the Kubernetes
 	// cluster.  Listeners can subscribe to a particular service and port and
 	// EndpointsWatcher will publish the address set and all future changes for
-	// that service:port.
+	// that service:port.  Only the services with subscribers have a
+	// servicePublisher, built from the informer caches on the first
+	// subscription and dropped with the last one, so that its memory grows
+	// with the services watched rather than with the size of the cluster.
 	EndpointsWatcher struct {
 		publishers map[ServiceID]*servicePublisher
 		k8sAPI     *k8s.API
@@ -92,6 +98,9 @@ type (
 
 		TopologyPref []string
 		ports        map[portAndHostname]*portPublisher
+		// deleted is set once the servicePublisher is removed from the
+		// EndpointsWatcher, after its last subscriber left
+		deleted bool
 		// All access to the servicePublisher and its portPublishers is explicitly synchronized by
 		// this mutex.
 		sync.Mutex
@@ -191,10 +200,14 @@ func (ew *EndpointsWatcher) Subscribe(id ServiceID, port Port, hostname string,
 		ew.log.Infof("Establishing watch on endpoint [%s.%s:%d]", hostname, id, port)
 	}
 
-	sp := ew.getOrNewServicePublisher(id)
-
-	sp.subscribe(port, hostname, listener)
-	return nil
+	// The servicePublisher may be removed by a concurrent Unsubscribe before
+	// the listener is registered, in which case a new one is created
+	for {
+		sp := ew.getOrNewServicePublisher(id)
+		if sp.subscribe(port, hostname, listener) {
+			return nil
+		}
+	}
 }
 
 // Unsubscribe removes a listener from the subscribers list for this authority.
@@ -210,7 +223,9 @@ func (ew *EndpointsWatcher) Unsubscribe(id ServiceID, port Port, hostname string
 		ew.log.Errorf("Cannot unsubscribe from unknown service [%s:%d]", id, port)
 		return
 	}
-	sp.unsubscribe(port, hostname, listener)
+	if sp.unsubscribe(port, hostname, listener) {
+		ew.deleteServicePublisher(sp)
+	}
 }
 
 func (ew *EndpointsWatcher) addService(obj interface{}) {
@@ -223,9 +238,10 @@ func (ew *EndpointsWatcher) addService(obj interface{}) {
 		Name:      service.Name,
 	}
 
-	sp := ew.getOrNewServicePublisher(id)
-
-	sp.updateService(service)
+	sp, ok := ew.getServicePublisher(id)
+	if ok {
+		sp.updateService(service)
+	}
 }
 
 func (ew *EndpointsWatcher) deleteService(obj interface{}) {
@@ -268,8 +284,10 @@ func (ew *EndpointsWatcher) addEndpoints(obj interface{}) {
 		return
 	}
 	id := ServiceID{endpoints.Namespace, endpoints.Name}
-	sp := ew.getOrNewServicePublisher(id)
-	sp.updateEndpoints(endpoints)
+	sp, ok := ew.getServicePublisher(id)
+	if ok {
+		sp.updateEndpoints(endpoints)
+	}
 }
 
 func (ew *EndpointsWatcher) deleteEndpoints(obj interface{}) {
@@ -318,8 +336,10 @@ func (ew *EndpointsWatcher) addEndpointSlice(obj interface{}) {
 		return
 	}
 
-	sp := ew.getOrNewServicePublisher(id)
-	sp.addEndpointSlice(newSlice)
+	sp, ok := ew.getServicePublisher(id)
+	if ok {
+		sp.addEndpointSlice(newSlice)
+	}
 }
 
 func (ew *EndpointsWatcher) updateEndpointSlice(oldObj interface{}, newObj interface{}) {
@@ -401,11 +421,34 @@ func (ew *EndpointsWatcher) getOrNewServicePublisher(id ServiceID) *servicePubli
 			ports:                make(map[portAndHostname]*portPublisher),
 			enableEndpointSlices: ew.enableEndpointSlices,
 		}
+		// The updates of the service prior to the servicePublisher's creation
+		// weren't seen
+		if svc, err := ew.k8sAPI.Svc().Lister().Services(id.Namespace).Get(id.Name); err == nil && ew.enableEndpointSlices {
+			sp.TopologyPref = topologyPreference(svc)
+		}
 		ew.publishers[id] = sp
+		endpointsPublishers.Inc()
 	}
 	return sp
 }
 
+// deleteServicePublisher removes the servicePublisher if it's still without
+// subscribers, so that it gets built again from the informer caches on the
+// next subscription.
+func (ew *EndpointsWatcher) deleteServicePublisher(sp *servicePublisher) {
+	ew.Lock()
+	defer ew.Unlock()
+	sp.Lock()
+	defer sp.Unlock()
+
+	if len(sp.ports) != 0 || ew.publishers[sp.id] != sp {
+		return
+	}
+	delete(ew.publishers, sp.id)
+	sp.deleted = true
+	endpointsPublishers.Dec()
+}
+
 func (ew *EndpointsWatcher) getServicePublisher(id ServiceID) (sp *servicePublisher, ok bool) {
 	ew.RLock()
 	defer ew.RUnlock()
@@ -468,8 +511,7 @@ func (sp *servicePublisher) updateService(newService *corev1.Service) {
 	sp.log.Debugf("Updating service for %s", sp.id)
 
 	if sp.enableEndpointSlices {
-		sp.TopologyPref = make([]string, len(newService.Spec.TopologyKeys))
-		copy(sp.TopologyPref, newService.Spec.TopologyKeys)
+		sp.TopologyPref = topologyPreference(newService)
 	}
 
 	for key, port := range sp.ports {
@@ -486,10 +528,31 @@ func (sp *servicePublisher) updateService(newService *corev1.Service) {
 
 }
 
-func (sp *servicePublisher) subscribe(srcPort Port, hostname string, listener EndpointUpdateListener) {
+// topologyPreference returns the topology keys of the Service if any.
+// Otherwise, if topology-aware routing is enabled on it, it prefers the
+// endpoints in the zone of the client, then any endpoint.
+func topologyPreference(svc *corev1.Service) []string {
+	if len(svc.Spec.TopologyKeys) != 0 {
+		pref := make([]string, len(svc.Spec.TopologyKeys))
+		copy(pref, svc.Spec.TopologyKeys)
+		return pref
+	}
+	if svc.Annotations[consts.TopologyAwareRoutingAnnotation] == "enabled" ||
+		svc.Annotations[consts.TopologyAwareHintsAnnotation] == "auto" {
+		return []string{corev1.LabelZoneFailureDomainStable, "*"}
+	}
+	return []string{}
+}
+
+// subscribe registers the listener, unless the servicePublisher was deleted
+func (sp *servicePublisher) subscribe(srcPort Port, hostname string, listener EndpointUpdateListener) bool {
 	sp.Lock()
 	defer sp.Unlock()
 
+	if sp.deleted {
+		return false
+	}
+
 	key := portAndHostname{
 		port:     srcPort,
 		hostname: hostname,
@@ -500,9 +563,12 @@ func (sp *servicePublisher) subscribe(srcPort Port, hostname string, listener En
 		sp.ports[key] = port
 	}
 	port.subscribe(listener)
+	return true
 }
 
-func (sp *servicePublisher) unsubscribe(srcPort Port, hostname string, listener EndpointUpdateListener) {
+// unsubscribe removes the listener, and returns whether the servicePublisher
+// is left without subscribers
+func (sp *servicePublisher) unsubscribe(srcPort Port, hostname string, listener EndpointUpdateListener) bool {
 	sp.Lock()
 	defer sp.Unlock()
 
@@ -518,6 +584,7 @@ func (sp *servicePublisher) unsubscribe(srcPort Port, hostname string, listener
 			delete(sp.ports, key)
 		}
 	}
+	return len(sp.ports) == 0
 }
 
 func (sp *servicePublisher) newPortPublisher(srcPort Port, hostname string) *portPublisher {
@@ -586,6 +653,7 @@ func (sp *servicePublisher) metricsLabels(port Port, hostname string) prometheus
 // portPublisher.
 
 func (pp *portPublisher) updateEndpoints(endpoints *corev1.Endpoints) {
+	start := time.Now()
 	newAddressSet := pp.endpointsToAddresses(endpoints)
 	if len(newAddressSet.Addresses) == 0 {
 		for _, listener := range pp.listeners {
@@ -604,12 +672,13 @@ func (pp *portPublisher) updateEndpoints(endpoints *corev1.Endpoints) {
 	}
 	pp.addresses = newAddressSet
 	pp.exists = true
-	pp.metrics.incUpdates()
+	pp.metrics.observeUpdate(start)
 	pp.metrics.setPods(len(pp.addresses.Addresses))
 	pp.metrics.setExists(true)
 }
 
 func (pp *portPublisher) addEndpointSlice(slice *discovery.EndpointSlice) {
+	start := time.Now()
 	newAddressSet := pp.endpointSliceToAddresses(slice)
 	for id, addr := range pp.addresses.Addresses {
 		newAddressSet.Addresses[id] = addr
@@ -624,12 +693,13 @@ func (pp *portPublisher) addEndpointSlice(slice *discovery.EndpointSlice) {
 
 	pp.addresses = newAddressSet
 	pp.exists = true
-	pp.metrics.incUpdates()
+	pp.metrics.observeUpdate(start)
 	pp.metrics.setPods(len(pp.addresses.Addresses))
 	pp.metrics.setExists(true)
 }
 
 func (pp *portPublisher) updateEndpointSlice(oldSlice *discovery.EndpointSlice, newSlice *discovery.EndpointSlice) {
+	start := time.Now()
 	updatedAddressSet := AddressSet{
 		Addresses:       make(map[ID]Address),
 		Labels:          pp.addresses.Labels,
@@ -662,7 +732,7 @@ func (pp *portPublisher) updateEndpointSlice(oldSlice *discovery.EndpointSlice,
 
 	pp.addresses = updatedAddressSet
 	pp.exists = true
-	pp.metrics.incUpdates()
+	pp.metrics.observeUpdate(start)
 	pp.metrics.setPods(len(pp.addresses.Addresses))
 	pp.metrics.setExists(true)
 }
@@ -702,6 +772,20 @@ func metricLabels(resource interface{}) map[string]string {
 	return labels
 }
 
+// matchesHostname returns true if the endpoint with the given hostname and
+// target is selected by the hostname of the subscription, if any. Endpoints
+// without a hostname match by the name of their pod, which is the hostname of
+// the pods of StatefulSets.
+func (pp *portPublisher) matchesHostname(hostname string, targetRef *corev1.ObjectReference) bool {
+	if pp.hostname == "" {
+		return true
+	}
+	if hostname != "" {
+		return hostname == pp.hostname
+	}
+	return targetRef != nil && targetRef.Kind == "Pod" && targetRef.Name == pp.hostname
+}
+
 func (pp *portPublisher) endpointSliceToAddresses(es *discovery.EndpointSlice) AddressSet {
 	addresses := make(map[ID]Address)
 	resolvedPort := pp.resolveESTargetPort(es.Ports)
@@ -714,10 +798,12 @@ func (pp *portPublisher) endpointSliceToAddresses(es *discovery.EndpointSlice) A
 	}
 
 	for _, endpoint := range es.Endpoints {
+		hostname := ""
 		if endpoint.Hostname != nil {
-			if pp.hostname != "" && pp.hostname != *endpoint.Hostname {
-				continue
-			}
+			hostname = *endpoint.Hostname
+		}
+		if !pp.matchesHostname(hostname, endpoint.TargetRef) {
+			continue
 		}
 		if endpoint.Conditions.Ready != nil && !*endpoint.Conditions.Ready {
 			continue
@@ -774,7 +860,7 @@ func (pp *portPublisher) endpointsToAddresses(endpoints *corev1.Endpoints) Addre
 	for _, subset := range endpoints.Subsets {
 		resolvedPort := pp.resolveTargetPort(subset)
 		for _, endpoint := range subset.Addresses {
-			if pp.hostname != "" && pp.hostname != endpoint.Hostname {
+			if !pp.matchesHostname(endpoint.Hostname, endpoint.TargetRef) {
 				continue
 			}
 
@@ -933,13 +1019,14 @@ func (pp *portPublisher) deleteEndpointSlice(es *discovery.EndpointSlice) {
 }
 
 func (pp *portPublisher) noEndpoints(exists bool) {
+	start := time.Now()
 	pp.exists = exists
 	pp.addresses = AddressSet{}
 	for _, listener := range pp.listeners {
 		listener.NoEndpoints(exists)
 	}
 
-	pp.metrics.incUpdates()
+	pp.metrics.observeUpdate(start)
 	pp.metrics.setExists(exists)
 	pp.metrics.setPods(0)
 }
@@ -957,6 +1044,7 @@ func (pp *portPublisher) subscribe(listener EndpointUpdateListener) {
 	pp.listeners = append(pp.listeners, listener)
 
 	pp.metrics.setSubscribers(len(pp.listeners))
+	endpointsSubscriptions.Inc()
 }
 
 func (pp *portPublisher) unsubscribe(listener EndpointUpdateListener) {
@@ -966,6 +1054,7 @@ func (pp *portPublisher) unsubscribe(listener EndpointUpdateListener) {
 			pp.listeners[i] = pp.listeners[n-1]
 			pp.listeners[n-1] = nil
 			pp.listeners = pp.listeners[:n-1]
+			endpointsSubscriptions.Dec()
 			break
 		}
 	}
@@ -2,12 +2,15 @@ package watcher
 
 import (
 	"fmt"
+	"reflect"
 	"sort"
 	"sync"
 	"testing"
 
 	"github.com/linkerd/linkerd2/controller/k8s"
 	consts "github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
 	logging "github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
 	dv1beta1 "k8s.io/api/discovery/v1beta1"
@@ -538,6 +541,87 @@ status:
 				`
 apiVersion: v1
 kind: Pod
+metadata:
+  name: name1-3
+  namespace: ns
+  ownerReferences:
+  - kind: ReplicaSet
+    name: rs-1
+status:
+  phase: Running
+  podIP: 172.17.0.20`,
+			},
+			id:                               ServiceID{Name: "name1", Namespace: "ns"},
+			hostname:                         "name1-3",
+			port:                             5959,
+			expectedAddresses:                []string{"172.17.0.20:5959"},
+			expectedNoEndpoints:              false,
+			expectedNoEndpointsServiceExists: false,
+		},
+		{
+			serviceType: "stateful sets without published hostnames",
+			k8sConfigs: []string{`
+apiVersion: v1
+kind: Service
+metadata:
+  name: name1
+  namespace: ns
+spec:
+  type: LoadBalancer
+  ports:
+  - port: 8989`,
+				`
+apiVersion: v1
+kind: Endpoints
+metadata:
+  name: name1
+  namespace: ns
+subsets:
+- addresses:
+  - ip: 172.17.0.12
+    targetRef:
+      kind: Pod
+      name: name1-1
+      namespace: ns
+  - ip: 172.17.0.19
+    targetRef:
+      kind: Pod
+      name: name1-2
+      namespace: ns
+  - ip: 172.17.0.20
+    targetRef:
+      kind: Pod
+      name: name1-3
+      namespace: ns
+  ports:
+  - port: 8989`,
+				`
+apiVersion: v1
+kind: Pod
+metadata:
+  name: name1-1
+  namespace: ns
+  ownerReferences:
+  - kind: ReplicaSet
+    name: rs-1
+status:
+  phase: Running
+  podIP: 172.17.0.12`,
+				`
+apiVersion: v1
+kind: Pod
+metadata:
+  name: name1-2
+  namespace: ns
+  ownerReferences:
+  - kind: ReplicaSet
+    name: rs-1
+status:
+  phase: Running
+  podIP: 172.17.0.19`,
+				`
+apiVersion: v1
+kind: Pod
 metadata:
   name: name1-3
   namespace: ns
@@ -1009,6 +1093,122 @@ status:
 				`
 apiVersion: v1
 kind: Pod
+metadata:
+  name: name-1-3
+  namespace: ns
+  ownerReferences:
+  - kind: ReplicaSet
+    name: rs-1
+status:
+  phase: Running
+  podIP: 172.17.0.20`,
+			},
+			id:                               ServiceID{Name: "name-1", Namespace: "ns"},
+			hostname:                         "name-1-3",
+			port:                             6000,
+			expectedAddresses:                []string{"172.17.0.20:6000"},
+			expectedNoEndpoints:              false,
+			expectedNoEndpointsServiceExists: false,
+			expectedError:                    false,
+		},
+		{
+			serviceType: "stateful sets with EndpointSlices without hostnames",
+			k8sConfigs: []string{`
+kind: APIResourceList
+apiVersion: v1
+groupVersion: discovery.k8s.io/v1beta1
+resources:
+  - name: endpointslices
+    singularName: endpointslice
+    namespaced: true
+    kind: EndpointSlice
+    verbs:
+      - delete
+      - deletecollection
+      - get
+      - list
+      - patch
+      - create
+      - update
+      - watch
+`, `
+apiVersion: v1
+kind: Service
+metadata:
+  name: name-1
+  namespace: ns
+spec:
+  type: LoadBalancer
+  ports:
+  - port: 8989`, `
+addressType: IPv4
+apiVersion: discovery.k8s.io/v1beta1
+endpoints:
+- addresses:
+  - 172.17.0.12
+  conditions:
+    ready: true
+  targetRef:
+    kind: Pod
+    name: name-1-1
+    namespace: ns
+  topology:
+    kubernetes.io/hostname: node-1
+- addresses:
+  - 172.17.0.19
+  conditions:
+    ready: true
+  targetRef:
+    kind: Pod
+    name: name-1-2
+    namespace: ns
+  topology:
+    kubernetes.io/hostname: node-1
+- addresses:
+  - 172.17.0.20
+  conditions:
+    ready: true
+  targetRef:
+    kind: Pod
+    name: name-1-3
+    namespace: ns
+  topology:
+    kubernetes.io/hostname: node-2
+kind: EndpointSlice
+metadata:
+  labels:
+    kubernetes.io/service-name: name-1
+  name: name-1-f5fad
+  namespace: ns
+ports:
+- name: ""
+  port: 8989`, `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: name-1-1
+  namespace: ns
+  ownerReferences:
+  - kind: ReplicaSet
+    name: rs-1
+status:
+  phase: Running
+  podIP: 172.17.0.12`,
+				`
+apiVersion: v1
+kind: Pod
+metadata:
+  name: name-1-2
+  namespace: ns
+  ownerReferences:
+  - kind: ReplicaSet
+    name: rs-1
+status:
+  phase: Running
+  podIP: 172.17.0.19`,
+				`
+apiVersion: v1
+kind: Pod
 metadata:
   name: name-1-3
   namespace: ns
@@ -1909,3 +2109,177 @@ status:
 		})
 	}
 }
+
+func TestTopologyPreference(t *testing.T) {
+	for _, tt := range []struct {
+		name         string
+		topologyKeys []string
+		annotations  map[string]string
+		expected     []string
+	}{
+		{
+			name:     "no preference",
+			expected: []string{},
+		},
+		{
+			name:         "topology keys",
+			topologyKeys: []string{corev1.LabelHostname, "*"},
+			annotations:  map[string]string{consts.TopologyAwareRoutingAnnotation: "enabled"},
+			expected:     []string{corev1.LabelHostname, "*"},
+		},
+		{
+			name:        "topology-aware routing",
+			annotations: map[string]string{consts.TopologyAwareRoutingAnnotation: "enabled"},
+			expected:    []string{corev1.LabelZoneFailureDomainStable, "*"},
+		},
+		{
+			name:        "topology-aware hints",
+			annotations: map[string]string{consts.TopologyAwareHintsAnnotation: "auto"},
+			expected:    []string{corev1.LabelZoneFailureDomainStable, "*"},
+		},
+		{
+			name:        "topology-aware routing disabled",
+			annotations: map[string]string{consts.TopologyAwareRoutingAnnotation: "disabled"},
+			expected:    []string{},
+		},
+	} {
+		tt := tt // pin
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Annotations: tt.annotations},
+				Spec:       corev1.ServiceSpec{TopologyKeys: tt.topologyKeys},
+			}
+			if actual := topologyPreference(svc); !reflect.DeepEqual(actual, tt.expected) {
+				t.Fatalf("Expected topology preference %v, got %v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestEndpointsSubscriptionsMetrics(t *testing.T) {
+	k8sAPI, err := k8s.NewFakeAPI(`
+apiVersion: v1
+kind: Service
+metadata:
+  name: name1
+  namespace: ns
+spec:
+  type: LoadBalancer
+  ports:
+  - port: 8989`, `
+apiVersion: v1
+kind: Endpoints
+metadata:
+  name: name1
+  namespace: ns
+subsets:
+- addresses:
+  - ip: 172.17.0.12
+  ports:
+  - port: 8989`)
+	if err != nil {
+		t.Fatalf("NewFakeAPI returned an error: %s", err)
+	}
+
+	watcher := NewEndpointsWatcher(k8sAPI, logging.WithField("test", t.Name()), false)
+	k8sAPI.Sync(nil)
+
+	id := ServiceID{Name: "name1", Namespace: "ns"}
+	subscriptions := testutil.ToFloat64(endpointsSubscriptions)
+	latencies := histogramCount(t)
+
+	first := newBufferingEndpointListener()
+	second := newBufferingEndpointListener()
+	for _, listener := range []*bufferingEndpointListener{first, second} {
+		if err := watcher.Subscribe(id, 8989, "", listener); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if actual := testutil.ToFloat64(endpointsSubscriptions) - subscriptions; actual != 2 {
+		t.Fatalf("Expected 2 more subscriptions, got %v", actual)
+	}
+
+	endpoints, err := k8sAPI.Endpoint().Lister().Endpoints("ns").Get("name1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	watcher.addEndpoints(endpoints)
+	if actual := histogramCount(t) - latencies; actual < 1 {
+		t.Fatalf("Expected the update latency to be observed, got %d more observations", actual)
+	}
+
+	watcher.Unsubscribe(id, 8989, "", first)
+	watcher.Unsubscribe(id, 8989, "", second)
+	if actual := testutil.ToFloat64(endpointsSubscriptions) - subscriptions; actual != 0 {
+		t.Fatalf("Expected no more subscriptions, got %v", actual)
+	}
+}
+
+func TestEndpointsPublishersLifecycle(t *testing.T) {
+	k8sAPI, err := k8s.NewFakeAPI(`
+apiVersion: v1
+kind: Service
+metadata:
+  name: name1
+  namespace: ns
+spec:
+  type: LoadBalancer
+  ports:
+  - port: 8989`, `
+apiVersion: v1
+kind: Endpoints
+metadata:
+  name: name1
+  namespace: ns
+subsets:
+- addresses:
+  - ip: 172.17.0.12
+  ports:
+  - port: 8989`)
+	if err != nil {
+		t.Fatalf("NewFakeAPI returned an error: %s", err)
+	}
+
+	watcher := NewEndpointsWatcher(k8sAPI, logging.WithField("test", t.Name()), false)
+	k8sAPI.Sync(nil)
+
+	id := ServiceID{Name: "name1", Namespace: "ns"}
+	if _, ok := watcher.getServicePublisher(id); ok {
+		t.Fatal("Expected no publisher for a service without subscribers")
+	}
+
+	listener := newBufferingEndpointListener()
+	if err := watcher.Subscribe(id, 8989, "", listener); err != nil {
+		t.Fatal(err)
+	}
+	listener.ExpectAdded([]string{"172.17.0.12:8989"}, t)
+	sp, ok := watcher.getServicePublisher(id)
+	if !ok {
+		t.Fatal("Expected a publisher for a subscribed service")
+	}
+
+	watcher.Unsubscribe(id, 8989, "", listener)
+	if _, ok := watcher.getServicePublisher(id); ok {
+		t.Fatal("Expected the publisher to be removed with its last subscriber")
+	}
+	if !sp.deleted || sp.subscribe(8989, "", listener) {
+		t.Fatal("Expected a removed publisher to reject new subscribers")
+	}
+
+	endpoints, err := k8sAPI.Endpoint().Lister().Endpoints("ns").Get("name1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	watcher.addEndpoints(endpoints)
+	if _, ok := watcher.getServicePublisher(id); ok {
+		t.Fatal("Expected the updates of a service without subscribers to be ignored")
+	}
+}
+
+func histogramCount(t *testing.T) uint64 {
+	var m dto.Metric
+	if err := endpointsUpdateLatency.Write(&m); err != nil {
+		t.Fatal(err)
+	}
+	return m.GetHistogram().GetSampleCount()
+}
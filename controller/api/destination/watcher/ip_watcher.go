@@ -57,7 +57,7 @@ func NewIPWatcher(k8sAPI *k8s.API, endpoints *EndpointsWatcher, log *logging.Ent
 
 	k8sAPI.Svc().Informer().AddIndexers(cache.Indexers{podIPIndex: func(obj interface{}) ([]string, error) {
 		if svc, ok := obj.(*corev1.Service); ok {
-			return []string{svc.Spec.ClusterIP}, nil
+			return append([]string{svc.Spec.ClusterIP}, svc.Spec.ExternalIPs...), nil
 		}
 		return []string{""}, fmt.Errorf("object is not a service")
 	}})
@@ -130,13 +130,14 @@ func (iw *IPWatcher) GetSvc(clusterIP string) (*ServiceID, error) {
 
 func (iw *IPWatcher) addService(obj interface{}) {
 	service := obj.(*corev1.Service)
-	if service.Namespace == kubeSystem || service.Spec.ClusterIP == "None" {
+	if service.Namespace == kubeSystem {
 		return
 	}
 
-	ss := iw.getOrNewServiceSubscriptions(service.Spec.ClusterIP)
-
-	ss.updateService(service)
+	for _, ip := range serviceIPs(service) {
+		ss := iw.getOrNewServiceSubscriptions(ip)
+		ss.updateService(service)
+	}
 }
 
 func (iw *IPWatcher) deleteService(obj interface{}) {
@@ -158,10 +159,23 @@ func (iw *IPWatcher) deleteService(obj interface{}) {
 		return
 	}
 
-	ss, ok := iw.getServiceSubscriptions(service.Spec.ClusterIP)
-	if ok {
-		ss.deleteService()
+	for _, ip := range serviceIPs(service) {
+		ss, ok := iw.getServiceSubscriptions(ip)
+		if ok {
+			ss.deleteService()
+		}
+	}
+}
+
+// serviceIPs returns the cluster IP of the service, unless it's headless, and
+// its external IPs, whose traffic is routed to the service like the one of its
+// cluster IP
+func serviceIPs(service *corev1.Service) []string {
+	ips := []string{}
+	if service.Spec.ClusterIP != "" && service.Spec.ClusterIP != "None" {
+		ips = append(ips, service.Spec.ClusterIP)
 	}
+	return append(ips, service.Spec.ExternalIPs...)
 }
 
 func (iw *IPWatcher) addPod(obj interface{}) {
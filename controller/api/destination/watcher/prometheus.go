@@ -2,6 +2,7 @@ package watcher
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
@@ -34,6 +35,33 @@ type (
 	}
 )
 
+var (
+	// endpointsSubscriptions counts all the subscriptions to endpoints without
+	// the labels of endpoints_subscribers, whose series grow with the number
+	// of services and hostnames watched
+	endpointsSubscriptions = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "endpoints_subscriptions",
+			Help: "A gauge for the current number of subscriptions to all endpoints.",
+		},
+	)
+
+	endpointsPublishers = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "endpoints_publishers",
+			Help: "A gauge for the current number of services with subscribers to their endpoints.",
+		},
+	)
+
+	endpointsUpdateLatency = promauto.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "endpoints_update_latency_seconds",
+			Help:    "A histogram of the time taken to send an endpoints update to all its subscribers.",
+			Buckets: prometheus.ExponentialBuckets(0.0001, 4, 10),
+		},
+	)
+)
+
 func newMetricsVecs(name string, labels []string) metricsVecs {
 	subscribers := promauto.NewGaugeVec(
 		prometheus.GaugeOpts{
@@ -142,6 +170,13 @@ func (m metrics) incUpdates() {
 	m.updates.Inc()
 }
 
+// observeUpdate counts an endpoints update, sent to all its subscribers since
+// start
+func (em endpointsMetrics) observeUpdate(start time.Time) {
+	em.incUpdates()
+	endpointsUpdateLatency.Observe(time.Since(start).Seconds())
+}
+
 func (em endpointsMetrics) setPods(n int) {
 	em.pods.Set(float64(n))
 }
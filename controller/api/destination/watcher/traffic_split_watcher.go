@@ -7,9 +7,8 @@ import (
 	"github.com/linkerd/linkerd2/controller/k8s"
 	"github.com/prometheus/client_golang/prometheus"
 	ts "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/split/v1alpha1"
-	tslisters "github.com/servicemeshinterface/smi-sdk-go/pkg/gen/client/split/listers/split/v1alpha1"
 	logging "github.com/sirupsen/logrus"
-	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/client-go/tools/cache"
 )
 
@@ -17,8 +16,12 @@ type (
 	// TrafficSplitWatcher watches all TrafficSplits in the Kubernetes cluster.
 	// Listeners can subscribe to a particular apex service and
 	// TrafficSplitWatcher will publish all TrafficSplits for that apex service.
+	// Backends which are themselves the apex service of another TrafficSplit
+	// are replaced by the backends of that TrafficSplit, so listeners get
+	// updated when any of the nested TrafficSplits changes.
 	TrafficSplitWatcher struct {
-		tsLister   tslisters.TrafficSplitLister
+		// tsIndexer indexes the TrafficSplits by their apex service
+		tsIndexer  cache.Indexer
 		publishers map[ServiceID]*trafficSplitPublisher
 
 		log          *logging.Entry
@@ -41,17 +44,31 @@ type (
 	}
 )
 
+// trafficSplitApexIndex is the name of the index of the TrafficSplits by
+// their apex service
+const trafficSplitApexIndex = "apex"
+
 var splitVecs = newMetricsVecs("trafficsplit", []string{"namespace", "service"})
 
 // NewTrafficSplitWatcher creates a TrafficSplitWatcher and begins watching the k8sAPI for
 // TrafficSplit changes.
 func NewTrafficSplitWatcher(k8sAPI *k8s.API, log *logging.Entry) *TrafficSplitWatcher {
 	watcher := &TrafficSplitWatcher{
-		tsLister:   k8sAPI.TS().Lister(),
+		tsIndexer:  k8sAPI.TS().Informer().GetIndexer(),
 		publishers: make(map[ServiceID]*trafficSplitPublisher),
 		log:        log.WithField("component", "traffic-split-watcher"),
 	}
 
+	err := k8sAPI.TS().Informer().AddIndexers(cache.Indexers{trafficSplitApexIndex: func(obj interface{}) ([]string, error) {
+		if split, ok := obj.(*ts.TrafficSplit); ok {
+			return []string{apexServiceID(split).String()}, nil
+		}
+		return []string{""}, fmt.Errorf("object is not a TrafficSplit")
+	}})
+	if err != nil {
+		watcher.log.Errorf("Failed to add the %s index of the TrafficSplits: %s", trafficSplitApexIndex, err)
+	}
+
 	k8sAPI.TS().Informer().AddEventHandler(
 		cache.ResourceEventHandlerFuncs{
 			AddFunc:    watcher.addTrafficSplit,
@@ -73,7 +90,7 @@ func NewTrafficSplitWatcher(k8sAPI *k8s.API, log *logging.Entry) *TrafficSplitWa
 func (tsw *TrafficSplitWatcher) Subscribe(id ServiceID, listener TrafficSplitUpdateListener) error {
 	tsw.log.Infof("Establishing watch on service %s", id)
 
-	publisher := tsw.getOrNewTrafficSplitPublisher(id, nil)
+	publisher := tsw.getOrNewTrafficSplitPublisher(id)
 
 	publisher.subscribe(listener)
 	return nil
@@ -92,18 +109,17 @@ func (tsw *TrafficSplitWatcher) Unsubscribe(id ServiceID, listener TrafficSplitU
 }
 
 func (tsw *TrafficSplitWatcher) addTrafficSplit(obj interface{}) {
-	split := obj.(*ts.TrafficSplit)
-	id := ServiceID{
-		Name:      split.Spec.Service,
-		Namespace: split.Namespace,
-	}
+	id := apexServiceID(obj.(*ts.TrafficSplit))
 
-	publisher := tsw.getOrNewTrafficSplitPublisher(id, split)
-
-	publisher.update(split)
+	tsw.getOrNewTrafficSplitPublisher(id)
+	tsw.publishSplitsInvolving(id)
 }
 
 func (tsw *TrafficSplitWatcher) updateTrafficSplit(old interface{}, new interface{}) {
+	// The TrafficSplit doesn't apply to its former apex service anymore
+	if oldID := apexServiceID(old.(*ts.TrafficSplit)); oldID != apexServiceID(new.(*ts.TrafficSplit)) {
+		tsw.publishSplitsInvolving(oldID)
+	}
 	tsw.addTrafficSplit(new)
 }
 
@@ -122,33 +138,144 @@ func (tsw *TrafficSplitWatcher) deleteTrafficSplit(obj interface{}) {
 		}
 	}
 
-	id := ServiceID{
+	tsw.publishSplitsInvolving(apexServiceID(split))
+}
+
+func apexServiceID(split *ts.TrafficSplit) ServiceID {
+	return ServiceID{
 		Name:      split.Spec.Service,
 		Namespace: split.Namespace,
 	}
+}
 
-	publisher, ok := tsw.getTrafficSplitPublisher(id)
-	if ok {
-		publisher.update(nil)
+// publishSplitsInvolving updates the publishers of all the TrafficSplits
+// involving the apex service id, either directly or as a nested TrafficSplit
+func (tsw *TrafficSplitWatcher) publishSplitsInvolving(id ServiceID) {
+	type update struct {
+		publisher *trafficSplitPublisher
+		split     *ts.TrafficSplit
+	}
+
+	tsw.RLock()
+	updates := []update{}
+	for apex, publisher := range tsw.publishers {
+		split, involved := tsw.resolveTrafficSplit(apex)
+		if _, ok := involved[id]; ok {
+			updates = append(updates, update{publisher, split})
+		}
+	}
+	tsw.RUnlock()
+
+	for _, u := range updates {
+		u.publisher.update(u.split)
+	}
+}
+
+// resolveTrafficSplit returns the TrafficSplit of the apex service id with
+// its nested TrafficSplits flattened, along with all the services whose
+// TrafficSplits were involved in the resolution.
+func (tsw *TrafficSplitWatcher) resolveTrafficSplit(id ServiceID) (*ts.TrafficSplit, map[ServiceID]struct{}) {
+	involved := map[ServiceID]struct{}{id: {}}
+	split := tsw.getSplit(id)
+	if split == nil {
+		return nil, involved
 	}
+
+	resolved := split.DeepCopy()
+	path := map[ServiceID]bool{id: true}
+	resolved.Spec.Backends = tsw.flattenBackends(id.Namespace, split.Spec.Backends, path, involved)
+	return resolved, involved
 }
 
-func (tsw *TrafficSplitWatcher) getOrNewTrafficSplitPublisher(id ServiceID, split *ts.TrafficSplit) *trafficSplitPublisher {
+// flattenBackends replaces the backends that are the apex service of another
+// TrafficSplit by the backends of that TrafficSplit, splitting the weight of
+// the replaced backend among them in proportion to their own weights. Services
+// already in path aren't expanded, to guard against cycles.
+func (tsw *TrafficSplitWatcher) flattenBackends(
+	namespace string,
+	backends []ts.TrafficSplitBackend,
+	path map[ServiceID]bool,
+	involved map[ServiceID]struct{},
+) []ts.TrafficSplitBackend {
+	flattened := []ts.TrafficSplitBackend{}
+	positions := map[string]int{}
+	add := func(backend ts.TrafficSplitBackend) {
+		i, ok := positions[backend.Service]
+		if !ok {
+			positions[backend.Service] = len(flattened)
+			flattened = append(flattened, *backend.DeepCopy())
+			return
+		}
+		weight := backendWeight(flattened[i]) + backendWeight(backend)
+		flattened[i].Weight = resource.NewMilliQuantity(weight, resource.DecimalSI)
+	}
+
+	for _, backend := range backends {
+		id := ServiceID{Name: backend.Service, Namespace: namespace}
+		involved[id] = struct{}{}
+
+		nested := tsw.getSplit(id)
+		if nested == nil || path[id] {
+			add(backend)
+			continue
+		}
+
+		path[id] = true
+		nestedBackends := tsw.flattenBackends(namespace, nested.Spec.Backends, path, involved)
+		delete(path, id)
+
+		var total int64
+		for _, nestedBackend := range nestedBackends {
+			total += backendWeight(nestedBackend)
+		}
+		if total == 0 {
+			add(backend)
+			continue
+		}
+		for _, nestedBackend := range nestedBackends {
+			weight := backendWeight(backend) * backendWeight(nestedBackend) / total
+			add(ts.TrafficSplitBackend{
+				Service: nestedBackend.Service,
+				Weight:  resource.NewMilliQuantity(weight, resource.DecimalSI),
+			})
+		}
+	}
+	return flattened
+}
+
+// getSplit returns the TrafficSplit of the apex service id, or nil if there
+// is none. If several TrafficSplits have the same apex service, the first one
+// by name is returned.
+func (tsw *TrafficSplitWatcher) getSplit(id ServiceID) *ts.TrafficSplit {
+	objs, err := tsw.tsIndexer.ByIndex(trafficSplitApexIndex, id.String())
+	if err != nil {
+		tsw.log.Errorf("error getting TrafficSplit: %s", err)
+		return nil
+	}
+
+	var split *ts.TrafficSplit
+	for _, obj := range objs {
+		if s, ok := obj.(*ts.TrafficSplit); ok && (split == nil || s.Name < split.Name) {
+			split = s
+		}
+	}
+	return split
+}
+
+func backendWeight(backend ts.TrafficSplitBackend) int64 {
+	if backend.Weight == nil {
+		return 0
+	}
+	return backend.Weight.MilliValue()
+}
+
+func (tsw *TrafficSplitWatcher) getOrNewTrafficSplitPublisher(id ServiceID) *trafficSplitPublisher {
 	tsw.Lock()
 	defer tsw.Unlock()
 
 	publisher, ok := tsw.publishers[id]
 	if !ok {
-		if split == nil {
-			var err error
-			split, err = tsw.tsLister.TrafficSplits(id.Namespace).Get(id.Name)
-			if err != nil && !apierrors.IsNotFound(err) {
-				tsw.log.Errorf("error getting TrafficSplit: %s", err)
-			}
-			if err != nil {
-				split = nil
-			}
-		}
+		split, _ := tsw.resolveTrafficSplit(id)
 
 		publisher = &trafficSplitPublisher{
 			split:     split,
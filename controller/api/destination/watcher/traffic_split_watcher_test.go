@@ -179,6 +179,11 @@ func TestTrafficSplitWatcherDelete(t *testing.T) {
 
 			watcher.Subscribe(tt.service, listener)
 
+			// the informer removes the TrafficSplit from its store before
+			// calling the handlers
+			if err := k8sAPI.TS().Informer().GetStore().Delete(&testTrafficSplit); err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
 			watcher.deleteTrafficSplit(tt.objectToDelete)
 			if listener.NumDeletes != 1 {
 				t.Fatalf("Expected to get 1 deletes but got %v", listener.NumDeletes)
@@ -186,3 +191,126 @@ func TestTrafficSplitWatcherDelete(t *testing.T) {
 		})
 	}
 }
+
+func TestTrafficSplitWatcherNestedSplits(t *testing.T) {
+	nestedTrafficSplitResource := `
+apiVersion: split.smi-spec.io/v1alpha1
+kind: TrafficSplit
+metadata:
+  name: nested-split
+  namespace: ns
+spec:
+  service: foo-v2
+  backends:
+  - service: foo-v2a
+    weight: 750m
+  - service: foo-v2b
+    weight: 250m`
+
+	k8sAPI, err := k8s.NewFakeAPI(testTrafficSplitResource, nestedTrafficSplitResource)
+	if err != nil {
+		t.Fatalf("NewFakeAPI returned an error: %s", err)
+	}
+
+	watcher := NewTrafficSplitWatcher(k8sAPI, logging.WithField("test", t.Name()))
+
+	k8sAPI.Sync(nil)
+
+	listener := newBufferingTrafficSplitListener()
+
+	watcher.Subscribe(ServiceID{Name: "foo", Namespace: "ns"}, listener)
+
+	// the informer updates its store before calling the handlers
+	store := k8sAPI.TS().Informer().GetStore()
+	nested, _, err := store.GetByKey("ns/nested-split")
+	if err != nil || nested == nil {
+		t.Fatalf("Expected the nested TrafficSplit to be in the store, got %v (%v)", nested, err)
+	}
+
+	expected := map[string]int64{"foo-v1": 500, "foo-v2a": 375, "foo-v2b": 125}
+	testCompare(t, expected, backendMilliWeights(t, listener.splits))
+
+	updatedWeight := resource.MustParse("0")
+	updated := &ts.TrafficSplit{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "nested-split",
+			Namespace: "ns",
+		},
+		Spec: ts.TrafficSplitSpec{
+			Service: "foo-v2",
+			Backends: []ts.TrafficSplitBackend{
+				{
+					Service: "foo-v2a",
+					Weight:  &weight,
+				},
+				{
+					Service: "foo-v2b",
+					Weight:  &updatedWeight,
+				},
+			},
+		},
+	}
+	if err := store.Update(updated); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	watcher.updateTrafficSplit(nested, updated)
+
+	expected = map[string]int64{"foo-v1": 500, "foo-v2a": 500, "foo-v2b": 0}
+	testCompare(t, expected, backendMilliWeights(t, listener.splits))
+
+	if err := store.Delete(updated); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	watcher.deleteTrafficSplit(updated)
+
+	expected = map[string]int64{"foo-v1": 500, "foo-v2": 500}
+	testCompare(t, expected, backendMilliWeights(t, listener.splits))
+}
+
+func TestTrafficSplitWatcherApexChange(t *testing.T) {
+	k8sAPI, err := k8s.NewFakeAPI(testTrafficSplitResource)
+	if err != nil {
+		t.Fatalf("NewFakeAPI returned an error: %s", err)
+	}
+
+	watcher := NewTrafficSplitWatcher(k8sAPI, logging.WithField("test", t.Name()))
+
+	k8sAPI.Sync(nil)
+
+	fooListener := newBufferingTrafficSplitListener()
+	watcher.Subscribe(ServiceID{Name: "foo", Namespace: "ns"}, fooListener)
+	barListener := newBufferingTrafficSplitListener()
+	watcher.Subscribe(ServiceID{Name: "bar", Namespace: "ns"}, barListener)
+
+	// the TrafficSplit named split is looked up by its apex service foo
+	if len(fooListener.splits) != 1 || fooListener.splits[0] == nil {
+		t.Fatalf("Expected the TrafficSplit of foo, got %v", fooListener.splits)
+	}
+
+	updated := testTrafficSplit.DeepCopy()
+	updated.Spec.Service = "bar"
+	if err := k8sAPI.TS().Informer().GetStore().Update(updated); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	watcher.updateTrafficSplit(&testTrafficSplit, updated)
+
+	if last := fooListener.splits[len(fooListener.splits)-1]; last != nil {
+		t.Fatalf("Expected foo to have no TrafficSplit anymore, got %v", last)
+	}
+	if last := barListener.splits[len(barListener.splits)-1]; last == nil || last.Spec.Service != "bar" {
+		t.Fatalf("Expected the TrafficSplit of bar, got %v", last)
+	}
+}
+
+// backendMilliWeights returns the weights of the backends of the last split
+// received
+func backendMilliWeights(t *testing.T, splits []*ts.TrafficSplit) map[string]int64 {
+	if len(splits) == 0 || splits[len(splits)-1] == nil {
+		t.Fatalf("Expected a traffic split, got %v", splits)
+	}
+	weights := map[string]int64{}
+	for _, backend := range splits[len(splits)-1].Spec.Backends {
+		weights[backend.Service] = backend.Weight.MilliValue()
+	}
+	return weights
+}
@@ -13,10 +13,21 @@ import (
 )
 
 const (
-	inboundIdentityQuery  = "count(response_total%s) by (%s, client_id, namespace, no_tls_reason)"
-	outboundIdentityQuery = "count(response_total%s) by (%s, dst_%s, server_id, namespace, dst_namespace, no_tls_reason)"
+	inboundIdentityQuery  = "count(%s%s) by (%s, client_id, namespace, no_tls_reason)"
+	outboundIdentityQuery = "count(%s%s) by (%s, dst_%s, server_id, namespace, dst_namespace, no_tls_reason)"
+
+	edgeTCPConnectionsQuery = "sum(tcp_open_connections%s) by (%s, dst_%s, namespace, dst_namespace, tls)"
+	edgeTCPReadBytesQuery   = "sum(increase(tcp_read_bytes_total%s[%s])) by (%s, dst_%s, namespace, dst_namespace)"
+	edgeTCPWriteBytesQuery  = "sum(increase(tcp_write_bytes_total%s[%s])) by (%s, dst_%s, namespace, dst_namespace)"
+
+	defaultEdgesTimeWindow = "1m"
 )
 
+// identityMetrics are the metrics the identities of the edges are read from:
+// the HTTP responses, and the TCP connections so that the edges of non-HTTP
+// traffic are found too
+var identityMetrics = []string{"response_total", "tcp_open_total"}
+
 var formatMsg = map[string]string{
 	"disabled":                          "Disabled",
 	"loopback":                          "Loopback",
@@ -71,21 +82,106 @@ func (s *grpcServer) getEdges(ctx context.Context, req *pb.EdgesRequest) ([]*pb.
 	labelsOutboundStr := generateLabelStringWithExclusion(labelsOutbound, resourceType)
 	labelsInboundStr := generateLabelStringWithExclusion(labelsInbound, resourceType)
 
-	outboundQuery := fmt.Sprintf(outboundIdentityQuery, labelsOutboundStr, resourceType, resourceType)
-	inboundQuery := fmt.Sprintf(inboundIdentityQuery, labelsInboundStr, resourceType)
+	var inboundResults, outboundResults []model.Vector
+	for _, metric := range identityMetrics {
+		inboundResult, err := s.queryProm(ctx, fmt.Sprintf(inboundIdentityQuery, metric, labelsInboundStr, resourceType))
+		if err != nil {
+			return nil, err
+		}
+		inboundResults = append(inboundResults, inboundResult)
 
-	inboundResult, err := s.queryProm(ctx, inboundQuery)
-	if err != nil {
-		return nil, err
+		outboundResult, err := s.queryProm(ctx, fmt.Sprintf(outboundIdentityQuery, metric, labelsOutboundStr, resourceType, resourceType))
+		if err != nil {
+			return nil, err
+		}
+		outboundResults = append(outboundResults, outboundResult)
 	}
 
-	outboundResult, err := s.queryProm(ctx, outboundQuery)
+	edges := processEdgeMetrics(mergeVectors(inboundResults...), mergeVectors(outboundResults...), resourceType, selectedNamespace)
+
+	tcpStats, err := s.getEdgeTCPStats(ctx, req, resourceType)
 	if err != nil {
 		return nil, err
 	}
+	for _, edge := range edges {
+		edge.TcpStats = tcpStats[edgeKey(edge.Src.Namespace, edge.Src.Name, edge.Dst.Namespace, edge.Dst.Name)]
+	}
 
-	edge := processEdgeMetrics(inboundResult, outboundResult, resourceType, selectedNamespace)
-	return edge, nil
+	return edges, nil
+}
+
+// getEdgeTCPStats returns the TCP stats of the connections between resources,
+// as seen by the outbound proxies, indexed by edgeKey
+func (s *grpcServer) getEdgeTCPStats(ctx context.Context, req *pb.EdgesRequest, resourceType string) (map[string]*pb.TcpStats, error) {
+	timeWindow := req.GetTimeWindow()
+	if timeWindow == "" {
+		timeWindow = defaultEdgesTimeWindow
+	}
+
+	labels := promDirectionLabels("outbound").Merge(model.LabelSet{"peer": "dst"})
+	labelsStr := generateLabelStringWithExclusion(labels, resourceType)
+
+	queries := map[promType]string{
+		promTCPConnections: fmt.Sprintf(edgeTCPConnectionsQuery, labelsStr, resourceType, resourceType),
+		promTCPReadBytes:   fmt.Sprintf(edgeTCPReadBytesQuery, labelsStr, timeWindow, resourceType, resourceType),
+		promTCPWriteBytes:  fmt.Sprintf(edgeTCPWriteBytesQuery, labelsStr, timeWindow, resourceType, resourceType),
+	}
+
+	tcpStats := map[string]*pb.TcpStats{}
+	for prom, query := range queries {
+		result, err := s.queryProm(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, sample := range result {
+			key := edgeKey(
+				string(sample.Metric[model.LabelName("namespace")]),
+				string(sample.Metric[model.LabelName(resourceType)]),
+				string(sample.Metric[model.LabelName("dst_namespace")]),
+				string(sample.Metric[model.LabelName("dst_"+resourceType)]),
+			)
+			if tcpStats[key] == nil {
+				tcpStats[key] = &pb.TcpStats{}
+			}
+
+			value := extractSampleValue(sample)
+			switch prom {
+			case promTCPConnections:
+				tcpStats[key].OpenConnections += value
+				if sample.Metric[model.LabelName("tls")] == "true" {
+					tcpStats[key].TlsConnections += value
+				}
+			case promTCPReadBytes:
+				tcpStats[key].ReadBytesTotal = value
+			case promTCPWriteBytes:
+				tcpStats[key].WriteBytesTotal = value
+			}
+		}
+	}
+
+	return tcpStats, nil
+}
+
+func edgeKey(srcNamespace, src, dstNamespace, dst string) string {
+	return fmt.Sprintf("%s/%s/%s/%s", srcNamespace, src, dstNamespace, dst)
+}
+
+// mergeVectors returns the samples of vectors, without the duplicated series
+func mergeVectors(vectors ...model.Vector) model.Vector {
+	merged := model.Vector{}
+	seen := map[model.Fingerprint]struct{}{}
+	for _, vector := range vectors {
+		for _, sample := range vector {
+			fingerprint := sample.Metric.Fingerprint()
+			if _, ok := seen[fingerprint]; ok {
+				continue
+			}
+			seen[fingerprint] = struct{}{}
+			merged = append(merged, sample)
+		}
+	}
+	return merged
 }
 
 func processEdgeMetrics(inbound, outbound model.Vector, resourceType, selectedNamespace string) []*pb.Edge {
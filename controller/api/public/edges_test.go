@@ -111,6 +111,15 @@ func TestEdges(t *testing.T) {
 				expectedStatRPC: expectedStatRPC{
 					err:              nil,
 					mockPromResponse: mockPromResponse,
+					expectedPrometheusQueries: []string{
+						`count(response_total{deployment!="", direction="inbound"}) by (deployment, client_id, namespace, no_tls_reason)`,
+						`count(response_total{deployment!="", direction="outbound"}) by (deployment, dst_deployment, server_id, namespace, dst_namespace, no_tls_reason)`,
+						`count(tcp_open_total{deployment!="", direction="inbound"}) by (deployment, client_id, namespace, no_tls_reason)`,
+						`count(tcp_open_total{deployment!="", direction="outbound"}) by (deployment, dst_deployment, server_id, namespace, dst_namespace, no_tls_reason)`,
+						`sum(increase(tcp_read_bytes_total{deployment!="", direction="outbound", peer="dst"}[1m])) by (deployment, dst_deployment, namespace, dst_namespace)`,
+						`sum(increase(tcp_write_bytes_total{deployment!="", direction="outbound", peer="dst"}[1m])) by (deployment, dst_deployment, namespace, dst_namespace)`,
+						`sum(tcp_open_connections{deployment!="", direction="outbound", peer="dst"}) by (deployment, dst_deployment, namespace, dst_namespace, tls)`,
+					},
 				},
 				req: &pb.EdgesRequest{
 					Selector: &pb.ResourceSelection{
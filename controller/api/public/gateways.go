@@ -120,7 +120,7 @@ func (s *grpcServer) getGatewaysMetrics(ctx context.Context, req *pb.GatewaysReq
 		promGatewayAlive: gatewayAliveQuery,
 	}
 
-	metricsResp, err := s.getPrometheusMetrics(ctx, promQueries, gatewayLatencyQuantileQuery, labels.String(), timeWindow, groupBy.String())
+	metricsResp, err := s.getPrometheusMetrics(ctx, promQueries, gatewayLatencyQuantileQuery, labels.String(), promWindow{window: timeWindow}, groupBy.String(), nil)
 
 	if err != nil {
 		return nil, err
@@ -6,11 +6,17 @@ import (
 	"fmt"
 	"math"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/duration"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/linkerd/linkerd2/controller/api/util"
 	pb "github.com/linkerd/linkerd2/controller/gen/public"
 	"github.com/linkerd/linkerd2/pkg/k8s"
+	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
 	"github.com/prometheus/common/model"
 	log "github.com/sirupsen/logrus"
 	"go.opencensus.io/trace"
@@ -21,6 +27,9 @@ type promResult struct {
 	prom promType
 	vec  model.Vector
 	err  error
+	// percentile is the latency percentile of the latency queries, when the
+	// request sets its own percentiles
+	percentile float64
 }
 
 const (
@@ -42,6 +51,10 @@ const (
 	remoteClusterNameLabel = model.LabelName("target_cluster_name")
 )
 
+// defaultLatencyPercentiles are the latency percentiles queried when a request
+// doesn't set its own
+var defaultLatencyPercentiles = []float64{50, 95, 99}
+
 func extractSampleValue(sample *model.Sample) uint64 {
 	value := uint64(0)
 	if !math.IsNaN(float64(sample.Value)) {
@@ -50,7 +63,144 @@ func extractSampleValue(sample *model.Sample) uint64 {
 	return value
 }
 
+// promWindow is the time window over which the metrics of a request are
+// queried
+type promWindow struct {
+	// window is the duration of the time window, e.g. "1m"
+	window string
+	// end is the end of the time window; the zero value means now
+	end time.Time
+	// step, if set, splits the time window in steps evaluated by a range
+	// query, whose results are then reduced to a single data point
+	step time.Duration
+}
+
+func newPromWindow(window string, end *timestamp.Timestamp, step *duration.Duration) (promWindow, error) {
+	w := promWindow{window: window}
+	if end != nil {
+		t, err := ptypes.Timestamp(end)
+		if err != nil {
+			return promWindow{}, err
+		}
+		w.end = t
+	}
+	if step != nil {
+		d, err := ptypes.Duration(step)
+		if err != nil {
+			return promWindow{}, err
+		}
+		w.step = d
+	}
+	return w, nil
+}
+
+// queryWindow returns the range of the range vectors of the queries, which is
+// the step if the window is split in steps
+func (w promWindow) queryWindow() string {
+	if w.step > 0 {
+		return util.FormatTimeWindow(w.step)
+	}
+	return w.window
+}
+
+// queryPromWindow queries the data point of query over window. If the window
+// has steps, the data points of every step are reduced to one by reduce.
+func (s *grpcServer) queryPromWindow(ctx context.Context, query string, window promWindow, reduce func(values []model.SampleValue) model.SampleValue) (model.Vector, error) {
+	if window.step == 0 {
+		return s.queryPromAt(ctx, query, window.end)
+	}
+
+	if s.prometheusAPI == nil {
+		return nil, errors.New("No prometheus instance to connect")
+	}
+
+	end := window.end
+	if end.IsZero() {
+		end = time.Now()
+	}
+	duration, err := time.ParseDuration(window.window)
+	if err != nil {
+		return nil, err
+	}
+	// the first step ends one step after the start of the window, so that the
+	// steps cover the window exactly
+	r := promv1.Range{Start: end.Add(-duration).Add(window.step), End: end, Step: window.step}
+
+	log.Debugf("Query range request:\n\t%+v %+v", query, r)
+
+	_, span := trace.StartSpan(ctx, "query.prometheus")
+	defer span.End()
+	span.AddAttributes(trace.StringAttribute("queryString", query))
+
+	res, warn, err := s.prometheusAPI.QueryRange(ctx, query, r)
+	if err != nil {
+		log.Errorf("QueryRange(%+v) failed with: %+v", query, err)
+		return nil, err
+	}
+	if warn != nil {
+		log.Warnf("%v", warn)
+	}
+	log.Debugf("Query range response:\n\t%+v", res)
+
+	if res.Type() != model.ValMatrix {
+		err = fmt.Errorf("Unexpected query result type (expected Matrix): %s", res.Type())
+		log.Error(err)
+		return nil, err
+	}
+
+	vec := model.Vector{}
+	for _, series := range res.(model.Matrix) {
+		if len(series.Values) == 0 {
+			continue
+		}
+		values := make([]model.SampleValue, len(series.Values))
+		for i, v := range series.Values {
+			values[i] = v.Value
+		}
+		last := series.Values[len(series.Values)-1]
+		vec = append(vec, &model.Sample{
+			Metric:    series.Metric,
+			Value:     reduce(values),
+			Timestamp: last.Timestamp,
+		})
+	}
+	return vec, nil
+}
+
+// sumValues reduces the steps of counter increases to the increase over the
+// whole window
+func sumValues(values []model.SampleValue) model.SampleValue {
+	sum := model.SampleValue(0)
+	for _, v := range values {
+		if !math.IsNaN(float64(v)) {
+			sum += v
+		}
+	}
+	return sum
+}
+
+// maxValue reduces the steps of latency quantiles to the worst one
+func maxValue(values []model.SampleValue) model.SampleValue {
+	worst := model.SampleValue(math.NaN())
+	for _, v := range values {
+		if math.IsNaN(float64(worst)) || v > worst {
+			worst = v
+		}
+	}
+	return worst
+}
+
+// lastValue reduces the steps of gauges to the last one
+func lastValue(values []model.SampleValue) model.SampleValue {
+	return values[len(values)-1]
+}
+
 func (s *grpcServer) queryProm(ctx context.Context, query string) (model.Vector, error) {
+	return s.queryPromAt(ctx, query, time.Time{})
+}
+
+// queryPromAt queries the data point of query at ts, or now if ts is zero
+func (s *grpcServer) queryPromAt(ctx context.Context, query string, ts time.Time) (model.Vector, error) {
 	log.Debugf("Query request:\n\t%+v", query)
 
 	_, span := trace.StartSpan(ctx, "query.prometheus")
@@ -62,7 +212,7 @@ func (s *grpcServer) queryProm(ctx context.Context, query string) (model.Vector,
 	}
 
 	// single data point (aka summary) query
-	res, warn, err := s.prometheusAPI.Query(ctx, query, time.Time{})
+	res, warn, err := s.prometheusAPI.Query(ctx, query, ts)
 	if err != nil {
 		log.Errorf("Query(%+v) failed with: %+v", query, err)
 		return nil, err
@@ -180,47 +330,64 @@ func promResourceType(resource *pb.Resource) model.LabelName {
 	return model.LabelName(l5dLabel)
 }
 
-func (s *grpcServer) getPrometheusMetrics(ctx context.Context, requestQueryTemplates map[promType]string, latencyQueryTemplate, labels, timeWindow, groupBy string) ([]promResult, error) {
+func (s *grpcServer) getPrometheusMetrics(ctx context.Context, requestQueryTemplates map[promType]string, latencyQueryTemplate, labels string, window promWindow, groupBy string, percentiles []float64) ([]promResult, error) {
+	requestedPercentiles := len(percentiles) != 0
+	if !requestedPercentiles {
+		percentiles = defaultLatencyPercentiles
+	}
+	for _, percentile := range percentiles {
+		if percentile <= 0 || percentile >= 100 {
+			return nil, fmt.Errorf("latency percentiles must be between 0 and 100, got %s", util.FormatPercentile(percentile))
+		}
+	}
+
 	resultChan := make(chan promResult)
+	timeWindow := window.queryWindow()
 
-	// kick off asynchronous queries: request count queries + 3 latency queries
+	// kick off asynchronous queries: request count queries + a latency query
+	// per percentile
 	for pt, requestQueryTemplate := range requestQueryTemplates {
 		var query string
+		reduce := sumValues
 		if pt == promTCPConnections || pt == promGatewayAlive || pt == promNumMirroredServices {
 			query = fmt.Sprintf(requestQueryTemplate, labels, groupBy)
+			reduce = lastValue
 		} else {
 			query = fmt.Sprintf(requestQueryTemplate, labels, timeWindow, groupBy)
 		}
 
-		go func(typ promType, promQuery string) {
-			resultVector, err := s.queryProm(ctx, promQuery)
+		go func(typ promType, promQuery string, reduce func([]model.SampleValue) model.SampleValue) {
+			resultVector, err := s.queryPromWindow(ctx, promQuery, window, reduce)
 			resultChan <- promResult{
 				prom: typ,
 				vec:  resultVector,
 				err:  err,
 			}
-		}(pt, query)
+		}(pt, query, reduce)
 	}
 
-	quantiles := []promType{promLatencyP50, promLatencyP95, promLatencyP99}
-
-	for _, quantile := range quantiles {
-		go func(quantile promType) {
+	for _, percentile := range percentiles {
+		go func(percentile float64) {
+			quantile := latencyQuantile(percentile)
 			latencyQuery := fmt.Sprintf(latencyQueryTemplate, quantile, labels, timeWindow, groupBy)
-			latencyResult, err := s.queryProm(ctx, latencyQuery)
+			latencyResult, err := s.queryPromWindow(ctx, latencyQuery, window, maxValue)
 
-			resultChan <- promResult{
+			result := promResult{
 				prom: quantile,
 				vec:  latencyResult,
 				err:  err,
 			}
-		}(quantile)
+			if requestedPercentiles {
+				result.percentile = percentile
+			}
+			resultChan <- result
+		}(percentile)
 	}
 
 	// process results, receive one message per prometheus query type
 	var err error
 	results := []promResult{}
-	for i := 0; i < len(quantiles)+len(requestQueryTemplates); i++ {
+	for i := 0; i < len(percentiles)+len(requestQueryTemplates); i++ {
 		result := <-resultChan
 		if result.err != nil {
 			log.Errorf("queryProm failed with: %s", result.err)
@@ -235,3 +402,21 @@ func (s *grpcServer) getPrometheusMetrics(ctx context.Context, requestQueryTempl
 
 	return results, nil
 }
+
+// latencyQuantile returns the quantile of a latency percentile, which is also
+// the promType of its query, e.g. promLatencyP95 for 95
+func latencyQuantile(percentile float64) promType {
+	return promType(strconv.FormatFloat(percentile/100, 'g', 10, 64))
+}
+
+// addLatencyPercentile records the latency of a requested percentile, keeping
+// the percentiles sorted
+func addLatencyPercentile(stats *pb.BasicStats, percentile float64, latencyMs uint64) {
+	latency := &pb.LatencyPercentile{Percentile: percentile, LatencyMs: latencyMs}
+	i := sort.Search(len(stats.LatencyMsPercentiles), func(i int) bool {
+		return stats.LatencyMsPercentiles[i].GetPercentile() >= percentile
+	})
+	stats.LatencyMsPercentiles = append(stats.LatencyMsPercentiles, nil)
+	copy(stats.LatencyMsPercentiles[i+1:], stats.LatencyMsPercentiles[i:])
+	stats.LatencyMsPercentiles[i] = latency
+}
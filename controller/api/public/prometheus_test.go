@@ -0,0 +1,72 @@
+package public
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/prometheus/common/model"
+)
+
+func TestQueryPromWindow(t *testing.T) {
+	series := model.Matrix{
+		{
+			Metric: model.Metric{"deployment": "web"},
+			Values: []model.SamplePair{
+				{Timestamp: 1000, Value: 10},
+				{Timestamp: 2000, Value: 30},
+				{Timestamp: 3000, Value: 20},
+			},
+		},
+	}
+
+	testCases := []struct {
+		reduce   func([]model.SampleValue) model.SampleValue
+		expected model.SampleValue
+	}{
+		{sumValues, 60},
+		{maxValue, 30},
+		{lastValue, 20},
+	}
+
+	for _, tc := range testCases {
+		s := &grpcServer{prometheusAPI: &MockProm{Res: series}}
+		window, err := newPromWindow("3h", ptypes.TimestampNow(), ptypes.DurationProto(time.Hour))
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		vec, err := s.queryPromWindow(context.Background(), "query", window, tc.reduce)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if len(vec) != 1 {
+			t.Fatalf("Expected 1 sample, got %d", len(vec))
+		}
+		if vec[0].Value != tc.expected {
+			t.Errorf("Expected %v, got %v", tc.expected, vec[0].Value)
+		}
+		if vec[0].Metric["deployment"] != "web" {
+			t.Errorf("Unexpected metric %v", vec[0].Metric)
+		}
+	}
+}
+
+func TestPromWindowQueryWindow(t *testing.T) {
+	window, err := newPromWindow("24h", nil, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if window.queryWindow() != "24h" {
+		t.Errorf("Expected the query window to be the time window, got %s", window.queryWindow())
+	}
+
+	window, err = newPromWindow("24h", nil, ptypes.DurationProto(5*time.Minute))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if window.queryWindow() != "5m" {
+		t.Errorf("Expected the query window to be the step, got %s", window.queryWindow())
+	}
+}
@@ -50,7 +50,7 @@ const (
 
 	reqQuery             = "sum(increase(response_total%s[%s])) by (%s, classification, tls)"
 	latencyQuantileQuery = "histogram_quantile(%s, sum(irate(response_latency_ms_bucket%s[%s])) by (le, %s))"
-	tcpConnectionsQuery  = "sum(tcp_open_connections%s) by (%s)"
+	tcpConnectionsQuery  = "sum(tcp_open_connections%s) by (%s, tls)"
 	tcpReadBytesQuery    = "sum(increase(tcp_read_bytes_total%s[%s])) by (%s)"
 	tcpWriteBytesQuery   = "sum(increase(tcp_write_bytes_total%s[%s])) by (%s)"
 )
@@ -213,7 +213,7 @@ func (s *grpcServer) k8sResourceQuery(ctx context.Context, req *pb.StatSummaryRe
 	}
 
 	rows := make([]*pb.StatTable_PodGroup_Row, 0)
-	keys := getResultKeys(req, k8sObjects, requestMetrics)
+	keys := getResultKeys(req, k8sObjects, requestMetrics, tcpMetrics)
 
 	for _, key := range keys {
 		objInfo, ok := k8sObjects[key]
@@ -417,6 +417,7 @@ func getResultKeys(
 	req *pb.StatSummaryRequest,
 	k8sObjects map[rKey]k8sStat,
 	metricResults map[rKey]*pb.BasicStats,
+	tcpResults map[rKey]*pb.TcpStats,
 ) []rKey {
 	var keys []rKey
 
@@ -427,10 +428,16 @@ func getResultKeys(
 		}
 	} else {
 		// if the request does have outbound filtering,
-		// only return rows for which we have stats
+		// only return rows for which we have stats, including rows with TCP
+		// traffic only
 		for key := range metricResults {
 			keys = append(keys, key)
 		}
+		for key := range tcpResults {
+			if _, ok := metricResults[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
 	}
 	return keys
 }
@@ -501,7 +508,11 @@ func (s *grpcServer) getStatMetrics(ctx context.Context, req *pb.StatSummaryRequ
 		promQueries[promTCPReadBytes] = tcpReadBytesQuery
 		promQueries[promTCPWriteBytes] = tcpWriteBytesQuery
 	}
-	results, err := s.getPrometheusMetrics(ctx, promQueries, latencyQuantileQuery, reqLabels.String(), timeWindow, groupBy.String())
+	window, err := newPromWindow(timeWindow, req.GetEndTime(), req.GetStep())
+	if err != nil {
+		return nil, nil, err
+	}
+	results, err := s.getPrometheusMetrics(ctx, promQueries, latencyQuantileQuery, reqLabels.String(), window, groupBy.String(), req.GetLatencyPercentiles())
 
 	if err != nil {
 		return nil, nil, err
@@ -526,7 +537,11 @@ func (s *grpcServer) getTrafficSplitMetrics(ctx context.Context, req *pb.StatSum
 		promRequests: reqQuery,
 	}
 
-	results, err := s.getPrometheusMetrics(ctx, promQueries, latencyQuantileQuery, reqLabels, timeWindow, groupBy.String())
+	window, err := newPromWindow(timeWindow, req.GetEndTime(), req.GetStep())
+	if err != nil {
+		return nil, err
+	}
+	results, err := s.getPrometheusMetrics(ctx, promQueries, latencyQuantileQuery, reqLabels, window, groupBy.String(), req.GetLatencyPercentiles())
 
 	if err != nil {
 		return nil, err
@@ -567,6 +582,11 @@ func processPrometheusMetrics(req *pb.StatSummaryRequest, results []promResult,
 
 			value := extractSampleValue(sample)
 
+			if result.percentile != 0 {
+				addBasicStats()
+				addLatencyPercentile(basicStats[resource], result.percentile, value)
+			}
+
 			switch result.prom {
 			case promRequests:
 				addBasicStats()
@@ -587,7 +607,10 @@ func processPrometheusMetrics(req *pb.StatSummaryRequest, results []promResult,
 				basicStats[resource].LatencyMsP99 = value
 			case promTCPConnections:
 				addTCPStats()
-				tcpStats[resource].OpenConnections = value
+				tcpStats[resource].OpenConnections += value
+				if sample.Metric[model.LabelName("tls")] == "true" {
+					tcpStats[resource].TlsConnections += value
+				}
 			case promTCPReadBytes:
 				addTCPStats()
 				tcpStats[resource].ReadBytesTotal = value
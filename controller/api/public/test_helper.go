@@ -37,6 +37,9 @@ type MockAPIClient struct {
 	APITapClientToReturn           pb.Api_TapClient
 	APITapByResourceClientToReturn pb.Api_TapByResourceClient
 	DestinationGetClientToReturn   destinationPb.Destination_GetClient
+	// GetProfile isn't served by the public API; this is only used to mock
+	// a client of the destination service.
+	DestinationGetProfileClientToReturn destinationPb.Destination_GetProfileClient
 }
 
 // StatSummary provides a mock of a Public API method.
@@ -92,7 +95,10 @@ func (c *MockAPIClient) Get(ctx context.Context, in *destinationPb.GetDestinatio
 // GetProfile provides a mock of a Public API method
 func (c *MockAPIClient) GetProfile(ctx context.Context, _ *destinationPb.GetDestination, _ ...grpc.CallOption) (destinationPb.Destination_GetProfileClient, error) {
 	// Not implemented through this client. The proxies use the gRPC server directly instead.
-	return nil, errors.New("Not implemented")
+	if c.DestinationGetProfileClientToReturn == nil {
+		return nil, errors.New("Not implemented")
+	}
+	return c.DestinationGetProfileClientToReturn, c.ErrorToReturn
 }
 
 // SelfCheck provides a mock of a Public API method.
@@ -132,6 +138,26 @@ func (a *MockDestinationGetClient) Recv() (*destinationPb.Update, error) {
 	return updatePopped, errorPopped
 }
 
+// MockDestinationGetProfileClient satisfies the Destination_GetProfileClient
+// gRPC interface.
+type MockDestinationGetProfileClient struct {
+	ProfilesToReturn []*destinationPb.DestinationProfile
+	grpc.ClientStream
+	sync.Mutex
+}
+
+// Recv satisfies the Destination_GetProfileClient.Recv() gRPC method.
+func (a *MockDestinationGetProfileClient) Recv() (*destinationPb.DestinationProfile, error) {
+	a.Lock()
+	defer a.Unlock()
+	if len(a.ProfilesToReturn) == 0 {
+		return nil, io.EOF
+	}
+	var profile *destinationPb.DestinationProfile
+	profile, a.ProfilesToReturn = a.ProfilesToReturn[0], a.ProfilesToReturn[1:]
+	return profile, nil
+}
+
 // AuthorityEndpoints holds the details for the Endpoints associated to an authority
 type AuthorityEndpoints struct {
 	Namespace string
@@ -299,6 +325,7 @@ func GenStatSummaryResponse(resName, resType string, resNs []string, counts *Pod
 				OpenConnections: 123,
 				ReadBytesTotal:  123,
 				WriteBytesTotal: 123,
+				TlsConnections:  123,
 			}
 		}
 
@@ -483,6 +510,11 @@ func GenEdgesResponse(resourceType string, edgeRowNamespace string) *pb.EdgesRes
 			ClientId:      row.clientID,
 			ServerId:      row.serverID,
 			NoIdentityMsg: row.msg,
+			TcpStats: &pb.TcpStats{
+				OpenConnections: 123,
+				ReadBytesTotal:  123,
+				WriteBytesTotal: 123,
+			},
 		}
 		edges = append(edges, edge)
 	}
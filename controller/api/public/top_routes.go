@@ -248,7 +248,11 @@ func (s *grpcServer) getRouteMetrics(ctx context.Context, req *pb.TopRoutesReque
 		queries[promActualRequests] = actualRouteReqQuery
 	}
 
-	results, err := s.getPrometheusMetrics(ctx, queries, routeLatencyQuantileQuery, reqLabels, timeWindow, groupBy)
+	window, err := newPromWindow(timeWindow, req.GetEndTime(), req.GetStep())
+	if err != nil {
+		return nil, err
+	}
+	results, err := s.getPrometheusMetrics(ctx, queries, routeLatencyQuantileQuery, reqLabels, window, groupBy, req.GetLatencyPercentiles())
 	if err != nil {
 		return nil, err
 	}
@@ -329,6 +333,10 @@ func processRouteMetrics(results []promResult, timeWindow string, table indexedT
 			table[key].TimeWindow = timeWindow
 			value := extractSampleValue(sample)
 
+			if result.percentile != 0 {
+				addLatencyPercentile(table[key].Stats, result.percentile, value)
+			}
+
 			switch result.prom {
 			case promRequests:
 				switch string(sample.Metric[model.LabelName("classification")]) {
@@ -4,9 +4,14 @@ import (
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/duration"
+	"github.com/golang/protobuf/ptypes/timestamp"
 	pb "github.com/linkerd/linkerd2/controller/gen/public"
 	"github.com/linkerd/linkerd2/pkg/k8s"
 	"google.golang.org/grpc/codes"
@@ -23,6 +28,11 @@ import (
 var (
 	defaultMetricTimeWindow    = "1m"
 	metricTimeWindowLowerBound = time.Second * 15 //the window value needs to equal or larger than that
+	// maxMetricSteps is the maximum number of steps of a time window, which is
+	// the maximum number of points per series of a Prometheus range query
+	maxMetricSteps = 11000
+
+	timeNow = time.Now
 
 	// ValidTargets specifies resource types allowed as a target:
 	// target resource on an inbound query
@@ -60,11 +70,21 @@ var (
 // StatsBaseRequestParams contains parameters that are used to build requests
 // for metrics data.  This includes requests to StatSummary and TopRoutes.
 type StatsBaseRequestParams struct {
-	TimeWindow    string
-	Namespace     string
-	ResourceType  string
-	ResourceName  string
-	AllNamespaces bool
+	TimeWindow string
+	// StartTime and EndTime select a past time window instead of the last
+	// TimeWindow. They're RFC3339 timestamps, or durations before now such as
+	// "2h". StartTime replaces TimeWindow; EndTime defaults to now.
+	StartTime string
+	EndTime   string
+	// Step evaluates the time window in steps of this duration, e.g. "5m"
+	Step string
+	// LatencyPercentiles replaces the default p50, p95 and p99 latencies, e.g.
+	// "p50", "p90" and "p99.9"
+	LatencyPercentiles []string
+	Namespace          string
+	ResourceType       string
+	ResourceName       string
+	AllNamespaces      bool
 }
 
 // StatsSummaryRequestParams contains parameters that are used to build
@@ -88,6 +108,8 @@ type EdgesRequestParams struct {
 	Namespace     string
 	ResourceType  string
 	AllNamespaces bool
+	// TimeWindow is the window of the TCP stats of the edges
+	TimeWindow string
 }
 
 // TopRoutesRequestParams contains parameters that are used to build TopRoutes
@@ -114,6 +136,14 @@ type TapRequestParams struct {
 	Path          string
 	Extract       bool
 	LabelSelector string
+	GrpcMethod    string
+	// StatusClass filters the streams by response status class, e.g. "5xx"
+	StatusClass string
+	PathRegex   string
+	MinLatency  time.Duration
+	// SampleRate is the fraction of the streams to report; 0 reports all of
+	// them
+	SampleRate float32
 }
 
 // GatewayRequestParams contains parameters that are used to build a
@@ -159,18 +189,16 @@ func GRPCError(err error) error {
 // BuildStatSummaryRequest builds a Public API StatSummaryRequest from a
 // StatsSummaryRequestParams.
 func BuildStatSummaryRequest(p StatsSummaryRequestParams) (*pb.StatSummaryRequest, error) {
-	window := defaultMetricTimeWindow
-	if p.TimeWindow != "" {
-		w, err := time.ParseDuration(p.TimeWindow)
-		if err != nil {
-			return nil, err
-		}
-
-		if w < metricTimeWindowLowerBound {
-			return nil, errors.New("metrics time window needs to be at least 15s")
-		}
-
-		window = p.TimeWindow
+	window, endTime, step, err := buildTimeRange(p.StatsBaseRequestParams)
+	if err != nil {
+		return nil, err
+	}
+	percentiles, err := ParseLatencyPercentiles(p.LatencyPercentiles)
+	if err != nil {
+		return nil, err
+	}
+	if w, _ := time.ParseDuration(window); w < metricTimeWindowLowerBound {
+		return nil, errors.New("metrics time window needs to be at least 15s")
 	}
 
 	if p.AllNamespaces && p.ResourceName != "" {
@@ -198,9 +226,12 @@ func BuildStatSummaryRequest(p StatsSummaryRequestParams) (*pb.StatSummaryReques
 			},
 			LabelSelector: p.LabelSelector,
 		},
-		TimeWindow: window,
-		SkipStats:  p.SkipStats,
-		TcpStats:   p.TCPStats,
+		TimeWindow:         window,
+		SkipStats:          p.SkipStats,
+		TcpStats:           p.TCPStats,
+		EndTime:            endTime,
+		Step:               step,
+		LatencyPercentiles: percentiles,
 	}
 
 	if p.ToName != "" || p.ToType != "" || p.ToNamespace != "" {
@@ -252,6 +283,117 @@ func BuildStatSummaryRequest(p StatsSummaryRequestParams) (*pb.StatSummaryReques
 	return statRequest, nil
 }
 
+// buildTimeRange returns the time window, end time and step of a metrics
+// request. The end time is nil when the window ends now, and the step is nil
+// when the window is evaluated at once.
+func buildTimeRange(p StatsBaseRequestParams) (string, *timestamp.Timestamp, *duration.Duration, error) {
+	window := defaultMetricTimeWindow
+	if p.TimeWindow != "" {
+		if _, err := time.ParseDuration(p.TimeWindow); err != nil {
+			return "", nil, nil, err
+		}
+		window = p.TimeWindow
+	}
+
+	now := timeNow()
+	end := now
+	var endTime *timestamp.Timestamp
+	if p.EndTime != "" {
+		var err error
+		end, err = parseTime(p.EndTime, now)
+		if err != nil {
+			return "", nil, nil, fmt.Errorf("invalid end time: %s", err)
+		}
+		if end.After(now) {
+			return "", nil, nil, errors.New("end time cannot be in the future")
+		}
+		endTime, err = ptypes.TimestampProto(end)
+		if err != nil {
+			return "", nil, nil, err
+		}
+	}
+
+	if p.StartTime != "" {
+		if p.TimeWindow != "" {
+			return "", nil, nil, errors.New("start time and time window are mutually exclusive")
+		}
+		start, err := parseTime(p.StartTime, now)
+		if err != nil {
+			return "", nil, nil, fmt.Errorf("invalid start time: %s", err)
+		}
+		if !start.Before(end) {
+			return "", nil, nil, errors.New("start time must be before end time")
+		}
+		window = FormatTimeWindow(end.Sub(start))
+	}
+
+	var step *duration.Duration
+	if p.Step != "" {
+		s, err := time.ParseDuration(p.Step)
+		if err != nil {
+			return "", nil, nil, fmt.Errorf("invalid step: %s", err)
+		}
+		w, _ := time.ParseDuration(window)
+		if s <= 0 || s > w {
+			return "", nil, nil, fmt.Errorf("step must be positive and at most the time window (%s)", window)
+		}
+		if int(w/s) > maxMetricSteps {
+			return "", nil, nil, fmt.Errorf("step is too small: the time window cannot have more than %d steps", maxMetricSteps)
+		}
+		step = ptypes.DurationProto(s)
+	}
+
+	return window, endTime, step, nil
+}
+
+// FormatTimeWindow formats a time window in a format understood both by
+// Prometheus and time.ParseDuration, e.g. "5m", truncated to the second
+func FormatTimeWindow(d time.Duration) string {
+	switch {
+	case d%time.Hour == 0:
+		return fmt.Sprintf("%dh", d/time.Hour)
+	case d%time.Minute == 0:
+		return fmt.Sprintf("%dm", d/time.Minute)
+	default:
+		return fmt.Sprintf("%ds", d/time.Second)
+	}
+}
+
+// ParseLatencyPercentiles parses latency percentiles such as "p99.9" or "99.9"
+func ParseLatencyPercentiles(values []string) ([]float64, error) {
+	var percentiles []float64
+	seen := map[float64]struct{}{}
+	for _, value := range values {
+		percentile, err := strconv.ParseFloat(strings.TrimPrefix(strings.ToLower(value), "p"), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid latency percentile %q", value)
+		}
+		if percentile <= 0 || percentile >= 100 {
+			return nil, fmt.Errorf("latency percentiles must be between 0 and 100, got %q", value)
+		}
+		if _, ok := seen[percentile]; ok {
+			return nil, fmt.Errorf("duplicate latency percentile %q", value)
+		}
+		seen[percentile] = struct{}{}
+		percentiles = append(percentiles, percentile)
+	}
+	return percentiles, nil
+}
+
+// FormatPercentile formats a latency percentile without trailing zeros, e.g.
+// "99.9"
+func FormatPercentile(percentile float64) string {
+	return strconv.FormatFloat(percentile, 'f', -1, 64)
+}
+
+// parseTime parses an RFC3339 timestamp, or a duration before now
+func parseTime(value string, now time.Time) (time.Time, error) {
+	if ago, err := time.ParseDuration(value); err == nil {
+		return now.Add(-ago), nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
 // BuildEdgesRequest builds a Public API EdgesRequest from a
 // EdgesRequestParams.
 func BuildEdgesRequest(p EdgesRequestParams) (*pb.EdgesRequest, error) {
@@ -264,6 +406,12 @@ func BuildEdgesRequest(p EdgesRequestParams) (*pb.EdgesRequest, error) {
 		return nil, err
 	}
 
+	if p.TimeWindow != "" {
+		if _, err := time.ParseDuration(p.TimeWindow); err != nil {
+			return nil, err
+		}
+	}
+
 	edgesRequest := &pb.EdgesRequest{
 		Selector: &pb.ResourceSelection{
 			Resource: &pb.Resource{
@@ -271,6 +419,7 @@ func BuildEdgesRequest(p EdgesRequestParams) (*pb.EdgesRequest, error) {
 				Type:      resourceType,
 			},
 		},
+		TimeWindow: p.TimeWindow,
 	}
 
 	return edgesRequest, nil
@@ -279,13 +428,13 @@ func BuildEdgesRequest(p EdgesRequestParams) (*pb.EdgesRequest, error) {
 // BuildTopRoutesRequest builds a Public API TopRoutesRequest from a
 // TopRoutesRequestParams.
 func BuildTopRoutesRequest(p TopRoutesRequestParams) (*pb.TopRoutesRequest, error) {
-	window := defaultMetricTimeWindow
-	if p.TimeWindow != "" {
-		_, err := time.ParseDuration(p.TimeWindow)
-		if err != nil {
-			return nil, err
-		}
-		window = p.TimeWindow
+	window, endTime, step, err := buildTimeRange(p.StatsBaseRequestParams)
+	if err != nil {
+		return nil, err
+	}
+	percentiles, err := ParseLatencyPercentiles(p.LatencyPercentiles)
+	if err != nil {
+		return nil, err
 	}
 
 	if p.AllNamespaces && p.ResourceName != "" {
@@ -313,7 +462,10 @@ func BuildTopRoutesRequest(p TopRoutesRequestParams) (*pb.TopRoutesRequest, erro
 			},
 			LabelSelector: p.LabelSelector,
 		},
-		TimeWindow: window,
+		TimeWindow:         window,
+		EndTime:            endTime,
+		Step:               step,
+		LatencyPercentiles: percentiles,
 	}
 
 	if p.ToName != "" || p.ToType != "" || p.ToNamespace != "" {
@@ -512,6 +664,20 @@ func BuildTapByResourceRequest(params TapRequestParams) (*pb.TapByResourceReques
 		})
 		matches = append(matches, &match)
 	}
+	if params.GrpcMethod != "" {
+		match := buildMatchHTTP(&pb.TapByResourceRequest_Match_Http{
+			Match: &pb.TapByResourceRequest_Match_Http_GrpcMethod{GrpcMethod: strings.TrimPrefix(params.GrpcMethod, "/")},
+		})
+		matches = append(matches, &match)
+	}
+
+	filter, err := buildTapFilter(params)
+	if err != nil {
+		return nil, err
+	}
+	if params.SampleRate < 0 || params.SampleRate > 1 {
+		return nil, fmt.Errorf("sample rate must be between 0 and 1, got %v", params.SampleRate)
+	}
 
 	extract := &pb.TapByResourceRequest_Extract{}
 	if params.Extract {
@@ -535,10 +701,41 @@ func BuildTapByResourceRequest(params TapRequestParams) (*pb.TapByResourceReques
 				},
 			},
 		},
-		Extract: extract,
+		Extract:    extract,
+		Filter:     filter,
+		SampleRate: params.SampleRate,
 	}, nil
 }
 
+// buildTapFilter returns the filter of the TapRequestParams evaluated by the
+// tap server, or nil when there's none
+func buildTapFilter(params TapRequestParams) (*pb.TapByResourceRequest_Filter, error) {
+	if params.StatusClass == "" && params.PathRegex == "" && params.MinLatency == 0 {
+		return nil, nil
+	}
+
+	filter := &pb.TapByResourceRequest_Filter{PathRegex: params.PathRegex}
+	if params.StatusClass != "" {
+		class := strings.ToLower(params.StatusClass)
+		if len(class) != 3 || class[0] < '1' || class[0] > '5' || class[1:] != "xx" {
+			return nil, fmt.Errorf("invalid status class \"%s\", must be one of 1xx, 2xx, 3xx, 4xx or 5xx", params.StatusClass)
+		}
+		filter.StatusClass = uint32(class[0] - '0')
+	}
+	if params.PathRegex != "" {
+		if _, err := regexp.Compile(params.PathRegex); err != nil {
+			return nil, fmt.Errorf("invalid path regex \"%s\": %s", params.PathRegex, err)
+		}
+	}
+	if params.MinLatency < 0 {
+		return nil, fmt.Errorf("minimum latency must not be negative, got %s", params.MinLatency)
+	}
+	if params.MinLatency > 0 {
+		filter.MinLatency = ptypes.DurationProto(params.MinLatency)
+	}
+	return filter, nil
+}
+
 func buildMatchHTTP(match *pb.TapByResourceRequest_Match_Http) pb.TapByResourceRequest_Match {
 	return pb.TapByResourceRequest_Match{
 		Match: &pb.TapByResourceRequest_Match_Http_{
@@ -5,7 +5,12 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/duration"
+	"github.com/golang/protobuf/ptypes/timestamp"
 	pb "github.com/linkerd/linkerd2/controller/gen/public"
 	"github.com/linkerd/linkerd2/pkg/k8s"
 	"google.golang.org/grpc/codes"
@@ -191,6 +196,163 @@ func TestBuildTopRoutesRequest(t *testing.T) {
 	})
 }
 
+func TestBuildTimeRange(t *testing.T) {
+	now := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = time.Now }()
+
+	endTime, _ := ptypes.TimestampProto(now.Add(-2 * time.Hour))
+
+	testCases := []struct {
+		params  StatsBaseRequestParams
+		window  string
+		endTime *timestamp.Timestamp
+		step    *duration.Duration
+		err     string
+	}{
+		{
+			params: StatsBaseRequestParams{},
+			window: "1m",
+		},
+		{
+			params:  StatsBaseRequestParams{StartTime: "2020-05-31T12:00:00Z", EndTime: "2h"},
+			window:  "22h",
+			endTime: endTime,
+		},
+		{
+			params:  StatsBaseRequestParams{TimeWindow: "24h", EndTime: "2020-06-01T10:00:00Z", Step: "1h"},
+			window:  "24h",
+			endTime: endTime,
+			step:    ptypes.DurationProto(time.Hour),
+		},
+		{
+			params: StatsBaseRequestParams{StartTime: "90s"},
+			window: "90s",
+		},
+		{
+			params: StatsBaseRequestParams{StartTime: "1h", TimeWindow: "1m"},
+			err:    "start time and time window are mutually exclusive",
+		},
+		{
+			params: StatsBaseRequestParams{StartTime: "1h", EndTime: "2h"},
+			err:    "start time must be before end time",
+		},
+		{
+			params: StatsBaseRequestParams{EndTime: "2020-06-02T10:00:00Z"},
+			err:    "end time cannot be in the future",
+		},
+		{
+			params: StatsBaseRequestParams{EndTime: "yesterday"},
+			err:    `invalid end time: parsing time "yesterday" as "2006-01-02T15:04:05Z07:00": cannot parse "yesterday" as "2006"`,
+		},
+		{
+			params: StatsBaseRequestParams{TimeWindow: "1m", Step: "2m"},
+			err:    "step must be positive and at most the time window (1m)",
+		},
+		{
+			params: StatsBaseRequestParams{TimeWindow: "24h", Step: "1s"},
+			err:    "step is too small: the time window cannot have more than 11000 steps",
+		},
+	}
+
+	for i, tc := range testCases {
+		tc := tc // pin
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			window, endTime, step, err := buildTimeRange(tc.params)
+			if tc.err != "" {
+				if err == nil || err.Error() != tc.err {
+					t.Fatalf("Expected error %q, got %v", tc.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if window != tc.window {
+				t.Errorf("Expected window %s, got %s", tc.window, window)
+			}
+			if !proto.Equal(endTime, tc.endTime) {
+				t.Errorf("Expected end time %v, got %v", tc.endTime, endTime)
+			}
+			if !proto.Equal(step, tc.step) {
+				t.Errorf("Expected step %v, got %v", tc.step, step)
+			}
+		})
+	}
+}
+
+func TestParseLatencyPercentiles(t *testing.T) {
+	percentiles, err := ParseLatencyPercentiles([]string{"p50", "90", "P99.9"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if !reflect.DeepEqual(percentiles, []float64{50, 90, 99.9}) {
+		t.Errorf("Unexpected percentiles %v", percentiles)
+	}
+
+	for value, expectedErr := range map[string]string{
+		"p100": `latency percentiles must be between 0 and 100, got "p100"`,
+		"p0":   `latency percentiles must be between 0 and 100, got "p0"`,
+		"max":  `invalid latency percentile "max"`,
+	} {
+		_, err := ParseLatencyPercentiles([]string{value})
+		if err == nil || err.Error() != expectedErr {
+			t.Errorf("Expected error %q for %s, got %v", expectedErr, value, err)
+		}
+	}
+
+	_, err = ParseLatencyPercentiles([]string{"p99", "99"})
+	if err == nil || err.Error() != `duplicate latency percentile "99"` {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+func TestBuildTapByResourceRequest(t *testing.T) {
+	t.Run("Builds the filters", func(t *testing.T) {
+		req, err := BuildTapByResourceRequest(TapRequestParams{
+			Resource:    "deploy/web",
+			Namespace:   "emojivoto",
+			GrpcMethod:  "/emojivoto.v1.VotingService/VoteDoughnut",
+			StatusClass: "5XX",
+			PathRegex:   "^/api/",
+			MinLatency:  time.Second,
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		matches := req.GetMatch().GetAll().GetMatches()
+		if len(matches) != 1 || matches[0].GetHttp().GetGrpcMethod() != "emojivoto.v1.VotingService/VoteDoughnut" {
+			t.Fatalf("Unexpected matches: %v", matches)
+		}
+		expected := &pb.TapByResourceRequest_Filter{
+			StatusClass: 5,
+			PathRegex:   "^/api/",
+			MinLatency:  ptypes.DurationProto(time.Second),
+		}
+		if !proto.Equal(req.GetFilter(), expected) {
+			t.Fatalf("Expected filter %v, got %v", expected, req.GetFilter())
+		}
+	})
+
+	t.Run("Rejects invalid filters", func(t *testing.T) {
+		expectations := map[TapRequestParams]string{
+			{StatusClass: "6xx"}: "invalid status class \"6xx\", must be one of 1xx, 2xx, 3xx, 4xx or 5xx",
+			{PathRegex: "("}:     "invalid path regex \"(\": error parsing regexp: missing closing ): `(`",
+			{MinLatency: -1}:     "minimum latency must not be negative, got -1ns",
+			{SampleRate: 1.5}:    "sample rate must be between 0 and 1, got 1.5",
+		}
+
+		for params, msg := range expectations {
+			params.Resource = "deploy/web"
+			_, err := BuildTapByResourceRequest(params)
+			if err == nil || err.Error() != msg {
+				t.Fatalf("Expected error %q, got %v", msg, err)
+			}
+		}
+	})
+}
+
 type resourceExp struct {
 	namespace string
 	args      []string
@@ -15,6 +15,7 @@ import (
 	pkgK8s "github.com/linkerd/linkerd2/pkg/k8s"
 	"github.com/linkerd/linkerd2/pkg/trace"
 	log "github.com/sirupsen/logrus"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 )
 
 // Main executes the destination subcommand
@@ -84,12 +85,12 @@ func Main(args []string) {
 	if *enableEndpointSlices {
 		k8sAPI, err = k8s.InitializeAPI(
 			*kubeConfigPath, true,
-			k8s.Endpoint, k8s.ES, k8s.Pod, k8s.RS, k8s.Svc, k8s.SP, k8s.TS, k8s.Job,
+			k8s.Endpoint, k8s.ES, k8s.Pod, k8s.RS, k8s.Svc, k8s.SP, k8s.TS, k8s.Job, k8s.Node,
 		)
 	} else {
 		k8sAPI, err = k8s.InitializeAPI(
 			*kubeConfigPath, true,
-			k8s.Endpoint, k8s.Pod, k8s.RS, k8s.Svc, k8s.SP, k8s.TS, k8s.Job,
+			k8s.Endpoint, k8s.Pod, k8s.RS, k8s.Svc, k8s.SP, k8s.TS, k8s.Job, k8s.Node,
 		)
 	}
 	if err != nil {
@@ -107,18 +108,22 @@ func Main(args []string) {
 		done,
 	)
 
+	healthServer := admin.RegisterGrpcHealth(server)
+
 	k8sAPI.Sync(nil) // blocks until caches are synced
 
 	go func() {
 		log.Infof("starting gRPC server on %s", *addr)
 		server.Serve(lis)
 	}()
+	healthServer.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
 
-	go admin.StartServer(*metricsAddr)
+	go admin.StartServerWithHealth(*metricsAddr, healthServer)
 
 	<-stop
 
 	log.Infof("shutting down gRPC server on %s", *addr)
+	healthServer.Shutdown()
 	close(done)
 	server.GracefulStop()
 }
@@ -0,0 +1,105 @@
+package healthexporter
+
+import (
+	"flag"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	exporter "github.com/linkerd/linkerd2/controller/health-exporter"
+	"github.com/linkerd/linkerd2/pkg/admin"
+	"github.com/linkerd/linkerd2/pkg/flags"
+	"github.com/linkerd/linkerd2/pkg/healthcheck"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	log "github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+const componentName = "linkerd-health-exporter"
+
+// defaultCategories are the categories of `linkerd check` which only need
+// read access to the cluster, and don't reach outside of it
+var defaultCategories = []healthcheck.CategoryID{
+	healthcheck.KubernetesAPIChecks,
+	healthcheck.KubernetesVersionChecks,
+	healthcheck.LinkerdConfigChecks,
+	healthcheck.LinkerdIdentity,
+	healthcheck.LinkerdWebhooksAndAPISvcTLS,
+	healthcheck.LinkerdControlPlaneExistenceChecks,
+	healthcheck.LinkerdAPIChecks,
+	healthcheck.LinkerdDataPlaneChecks,
+}
+
+// Main executes the health-exporter subcommand
+func Main(args []string) {
+	cmd := flag.NewFlagSet("health-exporter", flag.ExitOnError)
+
+	metricsAddr := cmd.String("metrics-addr", ":9995", "address to serve scrapable metrics on")
+	kubeConfigPath := cmd.String("kubeconfig", "", "path to kube config")
+	controllerNamespace := cmd.String("controller-namespace", "linkerd", "namespace in which Linkerd is installed")
+	interval := cmd.Duration("interval", 5*time.Minute, "interval between two runs of the checks")
+	checkTimeout := cmd.Duration("check-timeout", 30*time.Second, "timeout of each check")
+	categories := cmd.String("categories", joinCategories(defaultCategories), "comma-separated list of the categories of checks to run")
+
+	flags.ConfigureAndParse(cmd, args)
+
+	k8sAPI, err := k8s.NewAPI(*kubeConfigPath, "", "", []string{}, 0)
+	if err != nil {
+		log.Fatalf("Failed to initialize k8s API: %s", err)
+	}
+
+	eventBroadcaster := record.NewBroadcaster()
+	eventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{
+		Interface: k8sAPI.CoreV1().Events(*controllerNamespace),
+	})
+	recorder := eventBroadcaster.NewRecorder(scheme.Scheme, v1.EventSource{Component: componentName})
+	deployment, err := k8sAPI.AppsV1().Deployments(*controllerNamespace).Get(componentName, metav1.GetOptions{})
+	if err != nil {
+		log.Fatalf("Failed to construct k8s event recorder: %s", err)
+	}
+	recordEventFunc := func(eventType, reason, message string) {
+		recorder.Event(deployment, eventType, reason, message)
+	}
+
+	options := &healthcheck.Options{
+		ControlPlaneNamespace: *controllerNamespace,
+		KubeConfig:            *kubeConfigPath,
+		CheckTimeout:          *checkTimeout,
+	}
+	e := exporter.NewExporter(splitCategories(*categories), options, recordEventFunc)
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+	done := make(chan struct{})
+
+	go e.Run(*interval, done)
+	go admin.StartServer(*metricsAddr)
+
+	<-stop
+	log.Info("shutting down health exporter")
+	close(done)
+}
+
+func joinCategories(categories []healthcheck.CategoryID) string {
+	ids := make([]string, len(categories))
+	for i, c := range categories {
+		ids[i] = string(c)
+	}
+	return strings.Join(ids, ",")
+}
+
+func splitCategories(s string) []healthcheck.CategoryID {
+	var categories []healthcheck.CategoryID
+	for _, id := range strings.Split(s, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			categories = append(categories, healthcheck.CategoryID(id))
+		}
+	}
+	return categories
+}
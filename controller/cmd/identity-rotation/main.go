@@ -0,0 +1,30 @@
+package identityrotation
+
+import (
+	"flag"
+	"time"
+
+	rotation "github.com/linkerd/linkerd2/controller/identity-rotation"
+	"github.com/linkerd/linkerd2/pkg/flags"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	log "github.com/sirupsen/logrus"
+)
+
+// Main executes the identity-rotation subcommand
+func Main(args []string) {
+	cmd := flag.NewFlagSet("identity-rotation", flag.ExitOnError)
+
+	kubeConfigPath := cmd.String("kubeconfig", "", "path to kube config")
+	controllerNamespace := cmd.String("controller-namespace", "linkerd", "namespace in which Linkerd is installed")
+
+	flags.ConfigureAndParse(cmd, args)
+
+	kubeAPI, err := k8s.NewAPI(*kubeConfigPath, "", "", []string{}, 0)
+	if err != nil {
+		log.Fatalf("Failed to initialize k8s API: %s", err)
+	}
+
+	if err := rotation.Rotate(kubeAPI, *controllerNamespace, time.Now()); err != nil {
+		log.Fatalf("Failed to rotate the identity issuer: %s", err)
+	}
+}
@@ -2,16 +2,21 @@ package identity
 
 import (
 	"context"
+	"crypto"
 	"flag"
 	"fmt"
+	"io/ioutil"
 	"net"
 	"os"
 	"os/signal"
 	"path/filepath"
 	"syscall"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
 
 	"github.com/golang/protobuf/ptypes"
@@ -22,17 +27,18 @@ import (
 	"github.com/linkerd/linkerd2/pkg/identity"
 	"github.com/linkerd/linkerd2/pkg/k8s"
 	consts "github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/linkerd/linkerd2/pkg/kms"
 	"github.com/linkerd/linkerd2/pkg/prometheus"
 	"github.com/linkerd/linkerd2/pkg/tls"
 	"github.com/linkerd/linkerd2/pkg/trace"
 	log "github.com/sirupsen/logrus"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	corev1 "k8s.io/api/core/v1"
 	v1machinery "k8s.io/apimachinery/pkg/apis/meta/v1"
 	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 )
 
 // TODO watch trustAnchorsPath for changes
-// TODO watch issuerPath for changes
 // TODO restrict servicetoken audiences (and lifetimes)
 
 // Main executes the identity subcommand
@@ -45,10 +51,18 @@ func Main(args []string) {
 	issuerPath := cmd.String("issuer",
 		"/var/run/linkerd/identity/issuer",
 		"path to directory containing issuer credentials")
+	issuerKeyURI := cmd.String("issuer-key-uri", "",
+		"URI of the issuer private key held by a KMS or an HSM (e.g. gcpkms://projects/<project>/locations/<location>/keyRings/<ring>/cryptoKeys/<key>/cryptoKeyVersions/<version>), used instead of the private key in the issuer directory")
+	spireServerAddr := cmd.String("spire-server-addr", "",
+		"address of a SPIRE server to obtain the issuer certificate from, instead of the issuer credentials")
+	spireCredsPath := cmd.String("spire-credentials",
+		"/var/run/linkerd/identity/spire",
+		"path to directory containing the X509-SVID of the identity service and the SPIRE bundle, used to authenticate to the SPIRE server")
 
 	var issuerPathCrt string
 	var issuerPathKey string
 	traceCollector := flags.AddTraceFlags(cmd)
+	cryptoPolicy := flags.AddCryptoPolicyFlag(cmd)
 	componentName := "linkerd-identity"
 
 	flags.ConfigureAndParse(cmd, args)
@@ -70,6 +84,10 @@ func Main(args []string) {
 		os.Exit(0)
 	}
 
+	if idctx.Scheme == k8s.IdentityIssuerSchemeSpire && *spireServerAddr == "" {
+		log.Fatalf("The %s issuer scheme requires -spire-server-addr", idctx.Scheme)
+	}
+
 	if idctx.Scheme == k8s.IdentityIssuerSchemeLinkerd {
 		issuerPathCrt = filepath.Join(*issuerPath, k8s.IdentityIssuerCrtName)
 		issuerPathKey = filepath.Join(*issuerPath, k8s.IdentityIssuerKeyName)
@@ -89,6 +107,18 @@ func Main(args []string) {
 		log.Fatalf("Failed to read trust anchors: %s", err)
 	}
 
+	if *cryptoPolicy != tls.CryptoPolicyDefault && *spireServerAddr == "" {
+		if err := checkCryptoPolicy(idctx.GetTrustAnchorsPem(), issuerPathCrt, *cryptoPolicy); err != nil {
+			log.Fatalf("Failed to apply the %s crypto policy: %s", *cryptoPolicy, err)
+		}
+	}
+
+	if *spireServerAddr == "" {
+		if err := checkIssuerKeyAlgorithm(issuerPathCrt, idctx.GetIssuerKeyAlgorithm()); err != nil {
+			log.Fatalf("Invalid issuer certificate: %s", err)
+		}
+	}
+
 	validity := tls.Validity{
 		ClockSkewAllowance: tls.DefaultClockSkewAllowance,
 		Lifetime:           identity.DefaultIssuanceLifetime,
@@ -109,20 +139,33 @@ func Main(args []string) {
 			validity.Lifetime = il
 		}
 	}
+	if pbd := idctx.GetRenewalJitter(); pbd != nil {
+		rj, err := ptypes.Duration(pbd)
+		if err != nil {
+			log.Warnf("Invalid renewal jitter: %s", err)
+		} else {
+			validity.RenewalJitter = rj
+		}
+	}
+	if err := identity.CheckValidity(validity); err != nil {
+		log.Fatalf("Invalid certificate lifetime: %s", err)
+	}
 
 	expectedName := fmt.Sprintf("identity.%s.%s", controllerNS, trustDomain)
 	issuerEvent := make(chan struct{})
 	issuerError := make(chan error)
 
 	//
-	// Create and start FS creds watcher
+	// Create and start FS creds watcher, unless the issuer comes from SPIRE
 	//
-	watcher := idctl.NewFsCredsWatcher(*issuerPath, issuerEvent, issuerError)
-	go func() {
-		if err := watcher.StartWatching(ctx); err != nil {
-			log.Fatalf("Failed to start creds watcher: %s", err)
-		}
-	}()
+	if *spireServerAddr == "" {
+		watcher := idctl.NewFsCredsWatcher(*issuerPath, issuerEvent, issuerError)
+		go func() {
+			if err := watcher.StartWatching(ctx); err != nil {
+				log.Fatalf("Failed to start creds watcher: %s", err)
+			}
+		}()
+	}
 
 	//
 	// Create k8s API
@@ -136,6 +179,18 @@ func Main(args []string) {
 		log.Fatalf("Failed to initialize identity service: %s", err)
 	}
 
+	// Cache the namespaces and service accounts, whose annotations override
+	// the certificate lifetime, instead of reading them on every request
+	informerFactory := informers.NewSharedInformerFactory(k8sAPI, 10*time.Minute)
+	namespaces := informerFactory.Core().V1().Namespaces()
+	serviceAccounts := informerFactory.Core().V1().ServiceAccounts()
+	nsSynced := namespaces.Informer().HasSynced
+	saSynced := serviceAccounts.Informer().HasSynced
+	informerFactory.Start(ctx.Done())
+	if !cache.WaitForCacheSync(ctx.Done(), nsSynced, saSynced) {
+		log.Fatal("Failed to sync the namespaces and service accounts caches")
+	}
+
 	// Create K8s event recorder
 	eventBroadcaster := record.NewBroadcaster()
 	eventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{
@@ -155,18 +210,32 @@ func Main(args []string) {
 	//
 	// Create, initialize and run service
 	//
-	svc := identity.NewService(v, trustAnchors, &validity, recordEventFunc, expectedName, issuerPathCrt, issuerPathKey)
-	if err = svc.Initialize(); err != nil {
-		log.Fatalf("Failed to initialize identity service: %s", err)
+	var issuerSigner crypto.Signer
+	if *issuerKeyURI != "" {
+		issuerSigner, err = kms.NewSigner(*issuerKeyURI)
+		if err != nil {
+			log.Fatalf("Failed to initialize the issuer key signer: %s", err)
+		}
+	}
+	svc := identity.NewService(v, trustAnchors, &validity, recordEventFunc, expectedName, issuerPathCrt, issuerPathKey, issuerSigner, identity.NewK8sValidityFunc(namespaces.Lister(), serviceAccounts.Lister(), validity))
+	if *spireServerAddr != "" {
+		upstream, err := identity.NewSpireUpstream(*spireServerAddr, *spireCredsPath)
+		if err != nil {
+			log.Fatalf("Failed to connect to the SPIRE server: %s", err)
+		}
+		go svc.RunSpire(ctx, upstream)
+	} else {
+		if err = svc.Initialize(); err != nil {
+			log.Fatalf("Failed to initialize identity service: %s", err)
+		}
+		go func() {
+			svc.Run(issuerEvent, issuerError)
+		}()
 	}
-	go func() {
-		svc.Run(issuerEvent, issuerError)
-	}()
 
 	//
 	// Bind and serve
 	//
-	go admin.StartServer(*adminAddr)
 	lis, err := net.Listen("tcp", *addr)
 	if err != nil {
 		log.Fatalf("Failed to listen on %s: %s", *addr, err)
@@ -179,11 +248,64 @@ func Main(args []string) {
 	}
 	srv := prometheus.NewGrpcServer()
 	identity.Register(srv, svc)
+	healthServer := admin.RegisterGrpcHealth(srv)
+	go admin.StartServerWithHealth(*adminAddr, healthServer)
 	go func() {
 		log.Infof("starting gRPC server on %s", *addr)
 		srv.Serve(lis)
 	}()
+	go func() {
+		// the SPIRE issuer is obtained asynchronously, so the service only
+		// reports SERVING once it can certify CSRs
+		select {
+		case <-svc.Ready():
+			healthServer.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+		case <-ctx.Done():
+		}
+	}()
 	<-stop
 	log.Infof("shutting down gRPC server on %s", *addr)
+	healthServer.Shutdown()
 	srv.GracefulStop()
 }
+
+// checkIssuerKeyAlgorithm validates that the issuer key uses the configured
+// algorithm, if any
+func checkIssuerKeyAlgorithm(issuerPathCrt, algorithm string) error {
+	if algorithm == "" {
+		return nil
+	}
+	issuerPEM, err := ioutil.ReadFile(issuerPathCrt)
+	if err != nil {
+		return err
+	}
+	issuer, err := tls.DecodePEMCrt(string(issuerPEM))
+	if err != nil {
+		return err
+	}
+	return tls.CheckKeyAlgorithm(issuer.Certificate.PublicKey, algorithm)
+}
+
+// checkCryptoPolicy validates that the trust anchors and the issuer use keys
+// approved by the crypto policy
+func checkCryptoPolicy(trustAnchorsPEM, issuerPathCrt, policy string) error {
+	anchors, err := tls.DecodePEMCertificates(trustAnchorsPEM)
+	if err != nil {
+		return err
+	}
+	issuerPEM, err := ioutil.ReadFile(issuerPathCrt)
+	if err != nil {
+		return err
+	}
+	issuer, err := tls.DecodePEMCrt(string(issuerPEM))
+	if err != nil {
+		return err
+	}
+
+	for _, crt := range append(anchors, issuer.Certificate) {
+		if err := tls.CheckCryptoPolicy(crt, policy); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -7,12 +7,15 @@ import (
 	servicemirror "github.com/linkerd/linkerd2/controller/cmd/service-mirror"
 
 	"github.com/linkerd/linkerd2/controller/cmd/destination"
+	healthexporter "github.com/linkerd/linkerd2/controller/cmd/health-exporter"
 	"github.com/linkerd/linkerd2/controller/cmd/heartbeat"
 	"github.com/linkerd/linkerd2/controller/cmd/identity"
+	identityrotation "github.com/linkerd/linkerd2/controller/cmd/identity-rotation"
 	proxyinjector "github.com/linkerd/linkerd2/controller/cmd/proxy-injector"
 	publicapi "github.com/linkerd/linkerd2/controller/cmd/public-api"
 	spvalidator "github.com/linkerd/linkerd2/controller/cmd/sp-validator"
 	"github.com/linkerd/linkerd2/controller/cmd/tap"
+	trustbundle "github.com/linkerd/linkerd2/controller/cmd/trust-bundle"
 )
 
 func main() {
@@ -24,10 +27,14 @@ func main() {
 	switch os.Args[1] {
 	case "destination":
 		destination.Main(os.Args[2:])
+	case "health-exporter":
+		healthexporter.Main(os.Args[2:])
 	case "heartbeat":
 		heartbeat.Main(os.Args[2:])
 	case "identity":
 		identity.Main(os.Args[2:])
+	case "identity-rotation":
+		identityrotation.Main(os.Args[2:])
 	case "proxy-injector":
 		proxyinjector.Main(os.Args[2:])
 	case "public-api":
@@ -36,6 +43,8 @@ func main() {
 		spvalidator.Main(os.Args[2:])
 	case "tap":
 		tap.Main(os.Args[2:])
+	case "trust-bundle":
+		trustbundle.Main(os.Args[2:])
 	case "service-mirror":
 		servicemirror.Main(os.Args[2:])
 	default:
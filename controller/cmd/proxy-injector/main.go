@@ -9,7 +9,7 @@ import (
 // Main executes the proxy-injector subcommand
 func Main(args []string) {
 	webhook.Launch(
-		[]k8s.APIResource{k8s.NS, k8s.Deploy, k8s.RC, k8s.RS, k8s.Job, k8s.DS, k8s.SS, k8s.Pod, k8s.CJ},
+		[]k8s.APIResource{k8s.NS, k8s.Deploy, k8s.RC, k8s.RS, k8s.Job, k8s.DS, k8s.SS, k8s.Pod, k8s.CJ, k8s.PC, k8s.Node},
 		9995,
 		injector.Inject,
 		"linkerd-proxy-injector",
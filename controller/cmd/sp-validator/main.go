@@ -1,6 +1,7 @@
 package spvalidator
 
 import (
+	"github.com/linkerd/linkerd2/controller/k8s"
 	validator "github.com/linkerd/linkerd2/controller/sp-validator"
 	"github.com/linkerd/linkerd2/controller/webhook"
 )
@@ -8,7 +9,7 @@ import (
 // Main executes the sp-validator subcommand
 func Main(args []string) {
 	webhook.Launch(
-		nil,
+		[]k8s.APIResource{k8s.Svc},
 		9997,
 		validator.AdmitSP,
 		"linkerd-sp-validator",
@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/tls"
 	"flag"
+	"net/url"
 	"os"
 	"os/signal"
 	"syscall"
@@ -14,6 +15,7 @@ import (
 	"github.com/linkerd/linkerd2/pkg/config"
 	"github.com/linkerd/linkerd2/pkg/flags"
 	pkgK8s "github.com/linkerd/linkerd2/pkg/k8s"
+	pkgTls "github.com/linkerd/linkerd2/pkg/tls"
 	"github.com/linkerd/linkerd2/pkg/trace"
 	log "github.com/sirupsen/logrus"
 )
@@ -32,8 +34,11 @@ func Main(args []string) {
 	tlsCertPath := cmd.String("tls-cert", pkgK8s.MountPathTLSCrtPEM, "path to TLS Cert PEM")
 	tlsKeyPath := cmd.String("tls-key", pkgK8s.MountPathTLSKeyPEM, "path to TLS Key PEM")
 	disableCommonNames := cmd.Bool("disable-common-names", false, "disable checks for Common Names (for development)")
+	maxEventsPerSecond := cmd.Float64("max-events-per-second", 0, "maximum number of events per second sent to each tap client; 0 for no limit")
+	otlpEndpoint := cmd.String("otlp-endpoint", "", "OTLP/HTTP collector to export the tapped requests to as spans, e.g. http://collector.tracing:4318; disabled if empty")
 
 	traceCollector := flags.AddTraceFlags(cmd)
+	cryptoPolicy := flags.AddCryptoPolicyFlag(cmd)
 
 	flags.ConfigureAndParse(cmd, args)
 
@@ -74,7 +79,16 @@ func Main(args []string) {
 			log.Warnf("failed to initialize tracing: %s", err)
 		}
 	}
-	grpcTapServer := tap.NewGrpcTapServer(*tapPort, *controllerNamespace, trustDomain, k8sAPI)
+	grpcTapServer := tap.NewGrpcTapServer(*tapPort, *controllerNamespace, trustDomain, *maxEventsPerSecond, k8sAPI)
+
+	exportCtx, stopExport := context.WithCancel(context.Background())
+	if *otlpEndpoint != "" {
+		if u, err := url.Parse(*otlpEndpoint); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			log.Fatalf("invalid OTLP endpoint %q: expected an http or https URL", *otlpEndpoint)
+		}
+		log.Infof("exporting tapped requests as spans to %s", *otlpEndpoint)
+		grpcTapServer.ExportSpans(exportCtx, *otlpEndpoint)
+	}
 
 	// TODO: make this configurable for local development
 	cert, err := tls.LoadX509KeyPair(*tlsCertPath, *tlsKeyPath)
@@ -86,6 +100,9 @@ func Main(args []string) {
 	if err != nil {
 		log.Fatal(err.Error())
 	}
+	if err := pkgTls.ApplyCryptoPolicy(apiServer.TLSConfig, *cryptoPolicy); err != nil {
+		log.Fatal(err.Error())
+	}
 
 	k8sAPI.Sync(nil) // blocks until caches are synced
 
@@ -100,4 +117,5 @@ func Main(args []string) {
 
 	log.Infof("shutting down APIServer on %s", *apiServerAddr)
 	apiServer.Shutdown(context.Background())
+	stopExport()
 }
@@ -0,0 +1,101 @@
+package trustbundle
+
+import (
+	"context"
+	"flag"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/linkerd/linkerd2/controller/k8s"
+	trustbundle "github.com/linkerd/linkerd2/controller/trust-bundle"
+	"github.com/linkerd/linkerd2/pkg/admin"
+	"github.com/linkerd/linkerd2/pkg/flags"
+	log "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+)
+
+// leaseName is the name of the Lease held by the replica running the
+// controller, in the control plane namespace
+const leaseName = "linkerd-trust-bundle"
+
+// Main executes the trust-bundle subcommand
+func Main(args []string) {
+	cmd := flag.NewFlagSet("trust-bundle", flag.ExitOnError)
+
+	metricsAddr := cmd.String("metrics-addr", ":9996", "address to serve scrapable metrics on")
+	kubeConfigPath := cmd.String("kubeconfig", "", "path to kube config")
+	controllerNamespace := cmd.String("controller-namespace", "linkerd", "namespace in which Linkerd is installed")
+	namespaceSelector := cmd.String("namespace-selector", "", "label selector of the namespaces to publish the trust bundle into; all the namespaces if empty")
+
+	flags.ConfigureAndParse(cmd, args)
+
+	selector, err := labels.Parse(*namespaceSelector)
+	if err != nil {
+		log.Fatalf("Invalid namespace selector: %s", err)
+	}
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+
+	k8sAPI, err := k8s.InitializeAPI(*kubeConfigPath, true, k8s.NS)
+	if err != nil {
+		log.Fatalf("Failed to initialize K8s API: %s", err)
+	}
+
+	controller := trustbundle.NewController(k8sAPI, *controllerNamespace, selector)
+
+	k8sAPI.Sync(nil) // blocks until caches are synced
+	if err := controller.Sync(nil); err != nil {
+		log.Fatalf("Failed to initialize the trust bundle controller: %s", err)
+	}
+
+	go admin.StartServer(*metricsAddr)
+
+	// In HA mode only one replica updates the trust bundles at a time; the
+	// others wait to acquire the lease
+	id, err := os.Hostname()
+	if err != nil {
+		log.Fatalf("Failed to get the hostname: %s", err)
+	}
+	lock := &resourcelock.LeaseLock{
+		LeaseMeta: metav1.ObjectMeta{
+			Name:      leaseName,
+			Namespace: *controllerNamespace,
+		},
+		Client:     k8sAPI.Client.CoordinationV1(),
+		LockConfig: resourcelock.ResourceLockConfig{Identity: id},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-stop
+		log.Info("shutting down trust bundle controller")
+		cancel()
+	}()
+
+	leaderelection.RunOrDie(ctx, leaderelection.LeaderElectionConfig{
+		Lock:            lock,
+		LeaseDuration:   15 * time.Second,
+		RenewDeadline:   10 * time.Second,
+		RetryPeriod:     2 * time.Second,
+		ReleaseOnCancel: true,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(ctx context.Context) {
+				log.Infof("acquired the %s lease", leaseName)
+				controller.Start(ctx.Done())
+			},
+			OnStoppedLeading: func() {
+				// the queue can't be restarted once shut down, so the
+				// replica exits and waits for the lease again once restarted
+				if ctx.Err() == nil {
+					log.Fatalf("lost the %s lease", leaseName)
+				}
+			},
+		},
+	})
+}
@@ -10,6 +10,7 @@ import (
 	context "context"
 	proto "github.com/golang/protobuf/proto"
 	duration "github.com/golang/protobuf/ptypes/duration"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
 	healthcheck "github.com/linkerd/linkerd2/controller/gen/common/healthcheck"
 	config "github.com/linkerd/linkerd2/controller/gen/config"
 	grpc "google.golang.org/grpc"
@@ -943,6 +944,14 @@ type TapByResourceRequest struct {
 	// Conditionally extracts components from requests and responses to include
 	// in tap events
 	Extract *TapByResourceRequest_Extract `protobuf:"bytes,4,opt,name=extract,proto3" json:"extract,omitempty"`
+	// Filters the streams by their whole lifecycle. Unlike `match`, which is
+	// evaluated by the proxies on the requests, filters are evaluated by the tap
+	// server, so the events of a stream are only reported once it's known to
+	// match all of them.
+	Filter *TapByResourceRequest_Filter `protobuf:"bytes,5,opt,name=filter,proto3" json:"filter,omitempty"`
+	// Fraction of the streams to tap, sampled at random by the tap server. The
+	// sampled streams still count towards maxRps. 0 taps all of them.
+	SampleRate float32 `protobuf:"fixed32,6,opt,name=sampleRate,proto3" json:"sampleRate,omitempty"`
 }
 
 func (x *TapByResourceRequest) Reset() {
@@ -1005,6 +1014,20 @@ func (x *TapByResourceRequest) GetExtract() *TapByResourceRequest_Extract {
 	return nil
 }
 
+func (x *TapByResourceRequest) GetFilter() *TapByResourceRequest_Filter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *TapByResourceRequest) GetSampleRate() float32 {
+	if x != nil {
+		return x.SampleRate
+	}
+	return 0
+}
+
 type HttpMethod struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1894,6 +1917,13 @@ type StatSummaryRequest struct {
 	Outbound  isStatSummaryRequest_Outbound `protobuf_oneof:"outbound"`
 	SkipStats bool                          `protobuf:"varint,6,opt,name=skip_stats,json=skipStats,proto3" json:"skip_stats,omitempty"` // true if we want to skip stats from Prometheus
 	TcpStats  bool                          `protobuf:"varint,7,opt,name=tcp_stats,json=tcpStats,proto3" json:"tcp_stats,omitempty"`
+	// end_time ends the time window at a past time instead of now
+	EndTime *timestamp.Timestamp `protobuf:"bytes,8,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	// step, if set, evaluates the time window in steps of this duration
+	Step *duration.Duration `protobuf:"bytes,9,opt,name=step,proto3" json:"step,omitempty"`
+	// latency_percentiles, if set, are the latency percentiles to report in
+	// BasicStats.latency_ms_percentiles, e.g. 50, 90 and 99.9
+	LatencyPercentiles []float64 `protobuf:"fixed64,10,rep,packed,name=latency_percentiles,json=latencyPercentiles,proto3" json:"latency_percentiles,omitempty"`
 }
 
 func (x *StatSummaryRequest) Reset() {
@@ -1984,6 +2014,27 @@ func (x *StatSummaryRequest) GetTcpStats() bool {
 	return false
 }
 
+func (x *StatSummaryRequest) GetEndTime() *timestamp.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *StatSummaryRequest) GetStep() *duration.Duration {
+	if x != nil {
+		return x.Step
+	}
+	return nil
+}
+
+func (x *StatSummaryRequest) GetLatencyPercentiles() []float64 {
+	if x != nil {
+		return x.LatencyPercentiles
+	}
+	return nil
+}
+
 type isStatSummaryRequest_Outbound interface {
 	isStatSummaryRequest_Outbound()
 }
@@ -2098,6 +2149,9 @@ type BasicStats struct {
 	LatencyMsP99       uint64 `protobuf:"varint,5,opt,name=latency_ms_p99,json=latencyMsP99,proto3" json:"latency_ms_p99,omitempty"`
 	ActualSuccessCount uint64 `protobuf:"varint,6,opt,name=actual_success_count,json=actualSuccessCount,proto3" json:"actual_success_count,omitempty"`
 	ActualFailureCount uint64 `protobuf:"varint,7,opt,name=actual_failure_count,json=actualFailureCount,proto3" json:"actual_failure_count,omitempty"`
+	// the latencies of the percentiles requested with latency_percentiles,
+	// sorted by percentile
+	LatencyMsPercentiles []*LatencyPercentile `protobuf:"bytes,8,rep,name=latency_ms_percentiles,json=latencyMsPercentiles,proto3" json:"latency_ms_percentiles,omitempty"`
 }
 
 func (x *BasicStats) Reset() {
@@ -2181,6 +2235,68 @@ func (x *BasicStats) GetActualFailureCount() uint64 {
 	return 0
 }
 
+func (x *BasicStats) GetLatencyMsPercentiles() []*LatencyPercentile {
+	if x != nil {
+		return x.LatencyMsPercentiles
+	}
+	return nil
+}
+
+type LatencyPercentile struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Percentile float64 `protobuf:"fixed64,1,opt,name=percentile,proto3" json:"percentile,omitempty"`
+	LatencyMs  uint64  `protobuf:"varint,2,opt,name=latency_ms,json=latencyMs,proto3" json:"latency_ms,omitempty"`
+}
+
+func (x *LatencyPercentile) Reset() {
+	*x = LatencyPercentile{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_public_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LatencyPercentile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LatencyPercentile) ProtoMessage() {}
+
+func (x *LatencyPercentile) ProtoReflect() protoreflect.Message {
+	mi := &file_public_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LatencyPercentile.ProtoReflect.Descriptor instead.
+func (*LatencyPercentile) Descriptor() ([]byte, []int) {
+	return file_public_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *LatencyPercentile) GetPercentile() float64 {
+	if x != nil {
+		return x.Percentile
+	}
+	return 0
+}
+
+func (x *LatencyPercentile) GetLatencyMs() uint64 {
+	if x != nil {
+		return x.LatencyMs
+	}
+	return 0
+}
+
 type TcpStats struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -2192,12 +2308,14 @@ type TcpStats struct {
 	ReadBytesTotal uint64 `protobuf:"varint,2,opt,name=read_bytes_total,json=readBytesTotal,proto3" json:"read_bytes_total,omitempty"`
 	// total count of bytes written to peers
 	WriteBytesTotal uint64 `protobuf:"varint,3,opt,name=write_bytes_total,json=writeBytesTotal,proto3" json:"write_bytes_total,omitempty"`
+	// number of currently open connections secured with mTLS
+	TlsConnections uint64 `protobuf:"varint,4,opt,name=tls_connections,json=tlsConnections,proto3" json:"tls_connections,omitempty"`
 }
 
 func (x *TcpStats) Reset() {
 	*x = TcpStats{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_public_proto_msgTypes[26]
+		mi := &file_public_proto_msgTypes[27]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2210,7 +2328,7 @@ func (x *TcpStats) String() string {
 func (*TcpStats) ProtoMessage() {}
 
 func (x *TcpStats) ProtoReflect() protoreflect.Message {
-	mi := &file_public_proto_msgTypes[26]
+	mi := &file_public_proto_msgTypes[27]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2223,7 +2341,7 @@ func (x *TcpStats) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TcpStats.ProtoReflect.Descriptor instead.
 func (*TcpStats) Descriptor() ([]byte, []int) {
-	return file_public_proto_rawDescGZIP(), []int{26}
+	return file_public_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *TcpStats) GetOpenConnections() uint64 {
@@ -2247,6 +2365,13 @@ func (x *TcpStats) GetWriteBytesTotal() uint64 {
 	return 0
 }
 
+func (x *TcpStats) GetTlsConnections() uint64 {
+	if x != nil {
+		return x.TlsConnections
+	}
+	return 0
+}
+
 type TrafficSplitStats struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -2260,7 +2385,7 @@ type TrafficSplitStats struct {
 func (x *TrafficSplitStats) Reset() {
 	*x = TrafficSplitStats{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_public_proto_msgTypes[27]
+		mi := &file_public_proto_msgTypes[28]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2273,7 +2398,7 @@ func (x *TrafficSplitStats) String() string {
 func (*TrafficSplitStats) ProtoMessage() {}
 
 func (x *TrafficSplitStats) ProtoReflect() protoreflect.Message {
-	mi := &file_public_proto_msgTypes[27]
+	mi := &file_public_proto_msgTypes[28]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2286,7 +2411,7 @@ func (x *TrafficSplitStats) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TrafficSplitStats.ProtoReflect.Descriptor instead.
 func (*TrafficSplitStats) Descriptor() ([]byte, []int) {
-	return file_public_proto_rawDescGZIP(), []int{27}
+	return file_public_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *TrafficSplitStats) GetApex() string {
@@ -2323,7 +2448,7 @@ type StatTable struct {
 func (x *StatTable) Reset() {
 	*x = StatTable{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_public_proto_msgTypes[28]
+		mi := &file_public_proto_msgTypes[29]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2336,7 +2461,7 @@ func (x *StatTable) String() string {
 func (*StatTable) ProtoMessage() {}
 
 func (x *StatTable) ProtoReflect() protoreflect.Message {
-	mi := &file_public_proto_msgTypes[28]
+	mi := &file_public_proto_msgTypes[29]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2349,7 +2474,7 @@ func (x *StatTable) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StatTable.ProtoReflect.Descriptor instead.
 func (*StatTable) Descriptor() ([]byte, []int) {
-	return file_public_proto_rawDescGZIP(), []int{28}
+	return file_public_proto_rawDescGZIP(), []int{29}
 }
 
 func (m *StatTable) GetTable() isStatTable_Table {
@@ -2382,12 +2507,14 @@ type EdgesRequest struct {
 	unknownFields protoimpl.UnknownFields
 
 	Selector *ResourceSelection `protobuf:"bytes,1,opt,name=selector,proto3" json:"selector,omitempty"`
+	// time_window is the window of the TCP stats of the edges, defaults to 1m
+	TimeWindow string `protobuf:"bytes,2,opt,name=time_window,json=timeWindow,proto3" json:"time_window,omitempty"`
 }
 
 func (x *EdgesRequest) Reset() {
 	*x = EdgesRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_public_proto_msgTypes[29]
+		mi := &file_public_proto_msgTypes[30]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2400,7 +2527,7 @@ func (x *EdgesRequest) String() string {
 func (*EdgesRequest) ProtoMessage() {}
 
 func (x *EdgesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_public_proto_msgTypes[29]
+	mi := &file_public_proto_msgTypes[30]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2413,7 +2540,7 @@ func (x *EdgesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EdgesRequest.ProtoReflect.Descriptor instead.
 func (*EdgesRequest) Descriptor() ([]byte, []int) {
-	return file_public_proto_rawDescGZIP(), []int{29}
+	return file_public_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *EdgesRequest) GetSelector() *ResourceSelection {
@@ -2423,6 +2550,13 @@ func (x *EdgesRequest) GetSelector() *ResourceSelection {
 	return nil
 }
 
+func (x *EdgesRequest) GetTimeWindow() string {
+	if x != nil {
+		return x.TimeWindow
+	}
+	return ""
+}
+
 type EdgesResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -2437,7 +2571,7 @@ type EdgesResponse struct {
 func (x *EdgesResponse) Reset() {
 	*x = EdgesResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_public_proto_msgTypes[30]
+		mi := &file_public_proto_msgTypes[31]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2450,7 +2584,7 @@ func (x *EdgesResponse) String() string {
 func (*EdgesResponse) ProtoMessage() {}
 
 func (x *EdgesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_public_proto_msgTypes[30]
+	mi := &file_public_proto_msgTypes[31]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2463,7 +2597,7 @@ func (x *EdgesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EdgesResponse.ProtoReflect.Descriptor instead.
 func (*EdgesResponse) Descriptor() ([]byte, []int) {
-	return file_public_proto_rawDescGZIP(), []int{30}
+	return file_public_proto_rawDescGZIP(), []int{31}
 }
 
 func (m *EdgesResponse) GetResponse() isEdgesResponse_Response {
@@ -2513,12 +2647,14 @@ type Edge struct {
 	ClientId      string    `protobuf:"bytes,3,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
 	ServerId      string    `protobuf:"bytes,4,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
 	NoIdentityMsg string    `protobuf:"bytes,5,opt,name=no_identity_msg,json=noIdentityMsg,proto3" json:"no_identity_msg,omitempty"`
+	// TCP stats of the connections from src to dst, as seen by src
+	TcpStats *TcpStats `protobuf:"bytes,6,opt,name=tcp_stats,json=tcpStats,proto3" json:"tcp_stats,omitempty"`
 }
 
 func (x *Edge) Reset() {
 	*x = Edge{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_public_proto_msgTypes[31]
+		mi := &file_public_proto_msgTypes[32]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2531,7 +2667,7 @@ func (x *Edge) String() string {
 func (*Edge) ProtoMessage() {}
 
 func (x *Edge) ProtoReflect() protoreflect.Message {
-	mi := &file_public_proto_msgTypes[31]
+	mi := &file_public_proto_msgTypes[32]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2544,7 +2680,7 @@ func (x *Edge) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Edge.ProtoReflect.Descriptor instead.
 func (*Edge) Descriptor() ([]byte, []int) {
-	return file_public_proto_rawDescGZIP(), []int{31}
+	return file_public_proto_rawDescGZIP(), []int{32}
 }
 
 func (x *Edge) GetSrc() *Resource {
@@ -2582,6 +2718,13 @@ func (x *Edge) GetNoIdentityMsg() string {
 	return ""
 }
 
+func (x *Edge) GetTcpStats() *TcpStats {
+	if x != nil {
+		return x.TcpStats
+	}
+	return nil
+}
+
 type TopRoutesRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -2593,12 +2736,19 @@ type TopRoutesRequest struct {
 	//	*TopRoutesRequest_None
 	//	*TopRoutesRequest_ToResource
 	Outbound isTopRoutesRequest_Outbound `protobuf_oneof:"outbound"`
+	// end_time ends the time window at a past time instead of now
+	EndTime *timestamp.Timestamp `protobuf:"bytes,8,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	// step, if set, evaluates the time window in steps of this duration
+	Step *duration.Duration `protobuf:"bytes,9,opt,name=step,proto3" json:"step,omitempty"`
+	// latency_percentiles, if set, are the latency percentiles to report in
+	// BasicStats.latency_ms_percentiles, e.g. 50, 90 and 99.9
+	LatencyPercentiles []float64 `protobuf:"fixed64,10,rep,packed,name=latency_percentiles,json=latencyPercentiles,proto3" json:"latency_percentiles,omitempty"`
 }
 
 func (x *TopRoutesRequest) Reset() {
 	*x = TopRoutesRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_public_proto_msgTypes[32]
+		mi := &file_public_proto_msgTypes[33]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2611,7 +2761,7 @@ func (x *TopRoutesRequest) String() string {
 func (*TopRoutesRequest) ProtoMessage() {}
 
 func (x *TopRoutesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_public_proto_msgTypes[32]
+	mi := &file_public_proto_msgTypes[33]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2624,7 +2774,7 @@ func (x *TopRoutesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TopRoutesRequest.ProtoReflect.Descriptor instead.
 func (*TopRoutesRequest) Descriptor() ([]byte, []int) {
-	return file_public_proto_rawDescGZIP(), []int{32}
+	return file_public_proto_rawDescGZIP(), []int{33}
 }
 
 func (x *TopRoutesRequest) GetSelector() *ResourceSelection {
@@ -2662,6 +2812,27 @@ func (x *TopRoutesRequest) GetToResource() *Resource {
 	return nil
 }
 
+func (x *TopRoutesRequest) GetEndTime() *timestamp.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *TopRoutesRequest) GetStep() *duration.Duration {
+	if x != nil {
+		return x.Step
+	}
+	return nil
+}
+
+func (x *TopRoutesRequest) GetLatencyPercentiles() []float64 {
+	if x != nil {
+		return x.LatencyPercentiles
+	}
+	return nil
+}
+
 type isTopRoutesRequest_Outbound interface {
 	isTopRoutesRequest_Outbound()
 }
@@ -2692,7 +2863,7 @@ type TopRoutesResponse struct {
 func (x *TopRoutesResponse) Reset() {
 	*x = TopRoutesResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_public_proto_msgTypes[33]
+		mi := &file_public_proto_msgTypes[34]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2705,7 +2876,7 @@ func (x *TopRoutesResponse) String() string {
 func (*TopRoutesResponse) ProtoMessage() {}
 
 func (x *TopRoutesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_public_proto_msgTypes[33]
+	mi := &file_public_proto_msgTypes[34]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2718,7 +2889,7 @@ func (x *TopRoutesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TopRoutesResponse.ProtoReflect.Descriptor instead.
 func (*TopRoutesResponse) Descriptor() ([]byte, []int) {
-	return file_public_proto_rawDescGZIP(), []int{33}
+	return file_public_proto_rawDescGZIP(), []int{34}
 }
 
 func (m *TopRoutesResponse) GetResponse() isTopRoutesResponse_Response {
@@ -2770,7 +2941,7 @@ type RouteTable struct {
 func (x *RouteTable) Reset() {
 	*x = RouteTable{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_public_proto_msgTypes[34]
+		mi := &file_public_proto_msgTypes[35]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2783,7 +2954,7 @@ func (x *RouteTable) String() string {
 func (*RouteTable) ProtoMessage() {}
 
 func (x *RouteTable) ProtoReflect() protoreflect.Message {
-	mi := &file_public_proto_msgTypes[34]
+	mi := &file_public_proto_msgTypes[35]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2796,7 +2967,7 @@ func (x *RouteTable) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RouteTable.ProtoReflect.Descriptor instead.
 func (*RouteTable) Descriptor() ([]byte, []int) {
-	return file_public_proto_rawDescGZIP(), []int{34}
+	return file_public_proto_rawDescGZIP(), []int{35}
 }
 
 func (x *RouteTable) GetRows() []*RouteTable_Row {
@@ -2824,7 +2995,7 @@ type GatewaysTable struct {
 func (x *GatewaysTable) Reset() {
 	*x = GatewaysTable{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_public_proto_msgTypes[35]
+		mi := &file_public_proto_msgTypes[36]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2837,7 +3008,7 @@ func (x *GatewaysTable) String() string {
 func (*GatewaysTable) ProtoMessage() {}
 
 func (x *GatewaysTable) ProtoReflect() protoreflect.Message {
-	mi := &file_public_proto_msgTypes[35]
+	mi := &file_public_proto_msgTypes[36]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2850,7 +3021,7 @@ func (x *GatewaysTable) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GatewaysTable.ProtoReflect.Descriptor instead.
 func (*GatewaysTable) Descriptor() ([]byte, []int) {
-	return file_public_proto_rawDescGZIP(), []int{35}
+	return file_public_proto_rawDescGZIP(), []int{36}
 }
 
 func (x *GatewaysTable) GetRows() []*GatewaysTable_Row {
@@ -2873,7 +3044,7 @@ type GatewaysRequest struct {
 func (x *GatewaysRequest) Reset() {
 	*x = GatewaysRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_public_proto_msgTypes[36]
+		mi := &file_public_proto_msgTypes[37]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2886,7 +3057,7 @@ func (x *GatewaysRequest) String() string {
 func (*GatewaysRequest) ProtoMessage() {}
 
 func (x *GatewaysRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_public_proto_msgTypes[36]
+	mi := &file_public_proto_msgTypes[37]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2899,7 +3070,7 @@ func (x *GatewaysRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GatewaysRequest.ProtoReflect.Descriptor instead.
 func (*GatewaysRequest) Descriptor() ([]byte, []int) {
-	return file_public_proto_rawDescGZIP(), []int{36}
+	return file_public_proto_rawDescGZIP(), []int{37}
 }
 
 func (x *GatewaysRequest) GetRemoteClusterName() string {
@@ -2937,7 +3108,7 @@ type GatewaysResponse struct {
 func (x *GatewaysResponse) Reset() {
 	*x = GatewaysResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_public_proto_msgTypes[37]
+		mi := &file_public_proto_msgTypes[38]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2950,7 +3121,7 @@ func (x *GatewaysResponse) String() string {
 func (*GatewaysResponse) ProtoMessage() {}
 
 func (x *GatewaysResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_public_proto_msgTypes[37]
+	mi := &file_public_proto_msgTypes[38]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2963,7 +3134,7 @@ func (x *GatewaysResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GatewaysResponse.ProtoReflect.Descriptor instead.
 func (*GatewaysResponse) Descriptor() ([]byte, []int) {
-	return file_public_proto_rawDescGZIP(), []int{37}
+	return file_public_proto_rawDescGZIP(), []int{38}
 }
 
 func (m *GatewaysResponse) GetResponse() isGatewaysResponse_Response {
@@ -3020,7 +3191,7 @@ type TapByResourceRequest_Match struct {
 func (x *TapByResourceRequest_Match) Reset() {
 	*x = TapByResourceRequest_Match{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_public_proto_msgTypes[38]
+		mi := &file_public_proto_msgTypes[39]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3033,7 +3204,7 @@ func (x *TapByResourceRequest_Match) String() string {
 func (*TapByResourceRequest_Match) ProtoMessage() {}
 
 func (x *TapByResourceRequest_Match) ProtoReflect() protoreflect.Message {
-	mi := &file_public_proto_msgTypes[38]
+	mi := &file_public_proto_msgTypes[39]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3143,7 +3314,7 @@ type TapByResourceRequest_Extract struct {
 func (x *TapByResourceRequest_Extract) Reset() {
 	*x = TapByResourceRequest_Extract{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_public_proto_msgTypes[39]
+		mi := &file_public_proto_msgTypes[40]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3156,7 +3327,7 @@ func (x *TapByResourceRequest_Extract) String() string {
 func (*TapByResourceRequest_Extract) ProtoMessage() {}
 
 func (x *TapByResourceRequest_Extract) ProtoReflect() protoreflect.Message {
-	mi := &file_public_proto_msgTypes[39]
+	mi := &file_public_proto_msgTypes[40]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3196,6 +3367,74 @@ type TapByResourceRequest_Extract_Http_ struct {
 
 func (*TapByResourceRequest_Extract_Http_) isTapByResourceRequest_Extract_Extract() {}
 
+type TapByResourceRequest_Filter struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Matches the streams whose response status is in this class, e.g. 5 for
+	// 5xx responses. 0 matches any status.
+	StatusClass uint32 `protobuf:"varint,1,opt,name=statusClass,proto3" json:"statusClass,omitempty"`
+	// Matches the requests whose path matches this RE2 regular expression.
+	PathRegex string `protobuf:"bytes,2,opt,name=pathRegex,proto3" json:"pathRegex,omitempty"`
+	// Matches the streams whose response ended at least this long after the
+	// request started.
+	MinLatency *duration.Duration `protobuf:"bytes,3,opt,name=minLatency,proto3" json:"minLatency,omitempty"`
+}
+
+func (x *TapByResourceRequest_Filter) Reset() {
+	*x = TapByResourceRequest_Filter{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_public_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TapByResourceRequest_Filter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TapByResourceRequest_Filter) ProtoMessage() {}
+
+func (x *TapByResourceRequest_Filter) ProtoReflect() protoreflect.Message {
+	mi := &file_public_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TapByResourceRequest_Filter.ProtoReflect.Descriptor instead.
+func (*TapByResourceRequest_Filter) Descriptor() ([]byte, []int) {
+	return file_public_proto_rawDescGZIP(), []int{9, 2}
+}
+
+func (x *TapByResourceRequest_Filter) GetStatusClass() uint32 {
+	if x != nil {
+		return x.StatusClass
+	}
+	return 0
+}
+
+func (x *TapByResourceRequest_Filter) GetPathRegex() string {
+	if x != nil {
+		return x.PathRegex
+	}
+	return ""
+}
+
+func (x *TapByResourceRequest_Filter) GetMinLatency() *duration.Duration {
+	if x != nil {
+		return x.MinLatency
+	}
+	return nil
+}
+
 type TapByResourceRequest_Match_Seq struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -3207,7 +3446,7 @@ type TapByResourceRequest_Match_Seq struct {
 func (x *TapByResourceRequest_Match_Seq) Reset() {
 	*x = TapByResourceRequest_Match_Seq{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_public_proto_msgTypes[40]
+		mi := &file_public_proto_msgTypes[42]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3220,7 +3459,7 @@ func (x *TapByResourceRequest_Match_Seq) String() string {
 func (*TapByResourceRequest_Match_Seq) ProtoMessage() {}
 
 func (x *TapByResourceRequest_Match_Seq) ProtoReflect() protoreflect.Message {
-	mi := &file_public_proto_msgTypes[40]
+	mi := &file_public_proto_msgTypes[42]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3253,13 +3492,14 @@ type TapByResourceRequest_Match_Http struct {
 	//	*TapByResourceRequest_Match_Http_Method
 	//	*TapByResourceRequest_Match_Http_Authority
 	//	*TapByResourceRequest_Match_Http_Path
+	//	*TapByResourceRequest_Match_Http_GrpcMethod
 	Match isTapByResourceRequest_Match_Http_Match `protobuf_oneof:"match"`
 }
 
 func (x *TapByResourceRequest_Match_Http) Reset() {
 	*x = TapByResourceRequest_Match_Http{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_public_proto_msgTypes[41]
+		mi := &file_public_proto_msgTypes[43]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3272,7 +3512,7 @@ func (x *TapByResourceRequest_Match_Http) String() string {
 func (*TapByResourceRequest_Match_Http) ProtoMessage() {}
 
 func (x *TapByResourceRequest_Match_Http) ProtoReflect() protoreflect.Message {
-	mi := &file_public_proto_msgTypes[41]
+	mi := &file_public_proto_msgTypes[43]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3323,6 +3563,13 @@ func (x *TapByResourceRequest_Match_Http) GetPath() string {
 	return ""
 }
 
+func (x *TapByResourceRequest_Match_Http) GetGrpcMethod() string {
+	if x, ok := x.GetMatch().(*TapByResourceRequest_Match_Http_GrpcMethod); ok {
+		return x.GrpcMethod
+	}
+	return ""
+}
+
 type isTapByResourceRequest_Match_Http_Match interface {
 	isTapByResourceRequest_Match_Http_Match()
 }
@@ -3343,6 +3590,12 @@ type TapByResourceRequest_Match_Http_Path struct {
 	Path string `protobuf:"bytes,4,opt,name=path,proto3,oneof"`
 }
 
+type TapByResourceRequest_Match_Http_GrpcMethod struct {
+	// Matches gRPC requests by their fully-qualified method, e.g.
+	// `emojivoto.v1.VotingService/VoteDoughnut`.
+	GrpcMethod string `protobuf:"bytes,5,opt,name=grpcMethod,proto3,oneof"`
+}
+
 func (*TapByResourceRequest_Match_Http_Scheme) isTapByResourceRequest_Match_Http_Match() {}
 
 func (*TapByResourceRequest_Match_Http_Method) isTapByResourceRequest_Match_Http_Match() {}
@@ -3351,6 +3604,8 @@ func (*TapByResourceRequest_Match_Http_Authority) isTapByResourceRequest_Match_H
 
 func (*TapByResourceRequest_Match_Http_Path) isTapByResourceRequest_Match_Http_Match() {}
 
+func (*TapByResourceRequest_Match_Http_GrpcMethod) isTapByResourceRequest_Match_Http_Match() {}
+
 type TapByResourceRequest_Extract_Http struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -3364,7 +3619,7 @@ type TapByResourceRequest_Extract_Http struct {
 func (x *TapByResourceRequest_Extract_Http) Reset() {
 	*x = TapByResourceRequest_Extract_Http{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_public_proto_msgTypes[42]
+		mi := &file_public_proto_msgTypes[44]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3377,7 +3632,7 @@ func (x *TapByResourceRequest_Extract_Http) String() string {
 func (*TapByResourceRequest_Extract_Http) ProtoMessage() {}
 
 func (x *TapByResourceRequest_Extract_Http) ProtoReflect() protoreflect.Message {
-	mi := &file_public_proto_msgTypes[42]
+	mi := &file_public_proto_msgTypes[44]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3426,7 +3681,7 @@ type TapByResourceRequest_Extract_Http_Headers struct {
 func (x *TapByResourceRequest_Extract_Http_Headers) Reset() {
 	*x = TapByResourceRequest_Extract_Http_Headers{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_public_proto_msgTypes[43]
+		mi := &file_public_proto_msgTypes[45]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3439,7 +3694,7 @@ func (x *TapByResourceRequest_Extract_Http_Headers) String() string {
 func (*TapByResourceRequest_Extract_Http_Headers) ProtoMessage() {}
 
 func (x *TapByResourceRequest_Extract_Http_Headers) ProtoReflect() protoreflect.Message {
-	mi := &file_public_proto_msgTypes[43]
+	mi := &file_public_proto_msgTypes[45]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3475,7 +3730,7 @@ type Headers_Header struct {
 func (x *Headers_Header) Reset() {
 	*x = Headers_Header{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_public_proto_msgTypes[44]
+		mi := &file_public_proto_msgTypes[46]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3488,7 +3743,7 @@ func (x *Headers_Header) String() string {
 func (*Headers_Header) ProtoMessage() {}
 
 func (x *Headers_Header) ProtoReflect() protoreflect.Message {
-	mi := &file_public_proto_msgTypes[44]
+	mi := &file_public_proto_msgTypes[46]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3559,7 +3814,7 @@ type TapEvent_EndpointMeta struct {
 func (x *TapEvent_EndpointMeta) Reset() {
 	*x = TapEvent_EndpointMeta{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_public_proto_msgTypes[45]
+		mi := &file_public_proto_msgTypes[47]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3572,7 +3827,7 @@ func (x *TapEvent_EndpointMeta) String() string {
 func (*TapEvent_EndpointMeta) ProtoMessage() {}
 
 func (x *TapEvent_EndpointMeta) ProtoReflect() protoreflect.Message {
-	mi := &file_public_proto_msgTypes[45]
+	mi := &file_public_proto_msgTypes[47]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3606,7 +3861,7 @@ type TapEvent_RouteMeta struct {
 func (x *TapEvent_RouteMeta) Reset() {
 	*x = TapEvent_RouteMeta{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_public_proto_msgTypes[46]
+		mi := &file_public_proto_msgTypes[48]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3619,7 +3874,7 @@ func (x *TapEvent_RouteMeta) String() string {
 func (*TapEvent_RouteMeta) ProtoMessage() {}
 
 func (x *TapEvent_RouteMeta) ProtoReflect() protoreflect.Message {
-	mi := &file_public_proto_msgTypes[46]
+	mi := &file_public_proto_msgTypes[48]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3657,7 +3912,7 @@ type TapEvent_Http struct {
 func (x *TapEvent_Http) Reset() {
 	*x = TapEvent_Http{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_public_proto_msgTypes[47]
+		mi := &file_public_proto_msgTypes[49]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3670,7 +3925,7 @@ func (x *TapEvent_Http) String() string {
 func (*TapEvent_Http) ProtoMessage() {}
 
 func (x *TapEvent_Http) ProtoReflect() protoreflect.Message {
-	mi := &file_public_proto_msgTypes[47]
+	mi := &file_public_proto_msgTypes[49]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3750,7 +4005,7 @@ type TapEvent_Http_StreamId struct {
 func (x *TapEvent_Http_StreamId) Reset() {
 	*x = TapEvent_Http_StreamId{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_public_proto_msgTypes[50]
+		mi := &file_public_proto_msgTypes[52]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3763,7 +4018,7 @@ func (x *TapEvent_Http_StreamId) String() string {
 func (*TapEvent_Http_StreamId) ProtoMessage() {}
 
 func (x *TapEvent_Http_StreamId) ProtoReflect() protoreflect.Message {
-	mi := &file_public_proto_msgTypes[50]
+	mi := &file_public_proto_msgTypes[52]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3809,7 +4064,7 @@ type TapEvent_Http_RequestInit struct {
 func (x *TapEvent_Http_RequestInit) Reset() {
 	*x = TapEvent_Http_RequestInit{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_public_proto_msgTypes[51]
+		mi := &file_public_proto_msgTypes[53]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3822,7 +4077,7 @@ func (x *TapEvent_Http_RequestInit) String() string {
 func (*TapEvent_Http_RequestInit) ProtoMessage() {}
 
 func (x *TapEvent_Http_RequestInit) ProtoReflect() protoreflect.Message {
-	mi := &file_public_proto_msgTypes[51]
+	mi := &file_public_proto_msgTypes[53]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3894,7 +4149,7 @@ type TapEvent_Http_ResponseInit struct {
 func (x *TapEvent_Http_ResponseInit) Reset() {
 	*x = TapEvent_Http_ResponseInit{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_public_proto_msgTypes[52]
+		mi := &file_public_proto_msgTypes[54]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3907,7 +4162,7 @@ func (x *TapEvent_Http_ResponseInit) String() string {
 func (*TapEvent_Http_ResponseInit) ProtoMessage() {}
 
 func (x *TapEvent_Http_ResponseInit) ProtoReflect() protoreflect.Message {
-	mi := &file_public_proto_msgTypes[52]
+	mi := &file_public_proto_msgTypes[54]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3967,7 +4222,7 @@ type TapEvent_Http_ResponseEnd struct {
 func (x *TapEvent_Http_ResponseEnd) Reset() {
 	*x = TapEvent_Http_ResponseEnd{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_public_proto_msgTypes[53]
+		mi := &file_public_proto_msgTypes[55]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3980,7 +4235,7 @@ func (x *TapEvent_Http_ResponseEnd) String() string {
 func (*TapEvent_Http_ResponseEnd) ProtoMessage() {}
 
 func (x *TapEvent_Http_ResponseEnd) ProtoReflect() protoreflect.Message {
-	mi := &file_public_proto_msgTypes[53]
+	mi := &file_public_proto_msgTypes[55]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4051,7 +4306,7 @@ type PodErrors_PodError struct {
 func (x *PodErrors_PodError) Reset() {
 	*x = PodErrors_PodError{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_public_proto_msgTypes[54]
+		mi := &file_public_proto_msgTypes[56]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -4064,7 +4319,7 @@ func (x *PodErrors_PodError) String() string {
 func (*PodErrors_PodError) ProtoMessage() {}
 
 func (x *PodErrors_PodError) ProtoReflect() protoreflect.Message {
-	mi := &file_public_proto_msgTypes[54]
+	mi := &file_public_proto_msgTypes[56]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4119,7 +4374,7 @@ type PodErrors_PodError_ContainerError struct {
 func (x *PodErrors_PodError_ContainerError) Reset() {
 	*x = PodErrors_PodError_ContainerError{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_public_proto_msgTypes[55]
+		mi := &file_public_proto_msgTypes[57]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -4132,7 +4387,7 @@ func (x *PodErrors_PodError_ContainerError) String() string {
 func (*PodErrors_PodError_ContainerError) ProtoMessage() {}
 
 func (x *PodErrors_PodError_ContainerError) ProtoReflect() protoreflect.Message {
-	mi := &file_public_proto_msgTypes[55]
+	mi := &file_public_proto_msgTypes[57]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4187,7 +4442,7 @@ type StatSummaryResponse_Ok struct {
 func (x *StatSummaryResponse_Ok) Reset() {
 	*x = StatSummaryResponse_Ok{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_public_proto_msgTypes[56]
+		mi := &file_public_proto_msgTypes[58]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -4200,7 +4455,7 @@ func (x *StatSummaryResponse_Ok) String() string {
 func (*StatSummaryResponse_Ok) ProtoMessage() {}
 
 func (x *StatSummaryResponse_Ok) ProtoReflect() protoreflect.Message {
-	mi := &file_public_proto_msgTypes[56]
+	mi := &file_public_proto_msgTypes[58]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4234,7 +4489,7 @@ type StatTable_PodGroup struct {
 func (x *StatTable_PodGroup) Reset() {
 	*x = StatTable_PodGroup{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_public_proto_msgTypes[57]
+		mi := &file_public_proto_msgTypes[59]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -4247,7 +4502,7 @@ func (x *StatTable_PodGroup) String() string {
 func (*StatTable_PodGroup) ProtoMessage() {}
 
 func (x *StatTable_PodGroup) ProtoReflect() protoreflect.Message {
-	mi := &file_public_proto_msgTypes[57]
+	mi := &file_public_proto_msgTypes[59]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4260,7 +4515,7 @@ func (x *StatTable_PodGroup) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StatTable_PodGroup.ProtoReflect.Descriptor instead.
 func (*StatTable_PodGroup) Descriptor() ([]byte, []int) {
-	return file_public_proto_rawDescGZIP(), []int{28, 0}
+	return file_public_proto_rawDescGZIP(), []int{29, 0}
 }
 
 func (x *StatTable_PodGroup) GetRows() []*StatTable_PodGroup_Row {
@@ -4295,7 +4550,7 @@ type StatTable_PodGroup_Row struct {
 func (x *StatTable_PodGroup_Row) Reset() {
 	*x = StatTable_PodGroup_Row{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_public_proto_msgTypes[58]
+		mi := &file_public_proto_msgTypes[60]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -4308,7 +4563,7 @@ func (x *StatTable_PodGroup_Row) String() string {
 func (*StatTable_PodGroup_Row) ProtoMessage() {}
 
 func (x *StatTable_PodGroup_Row) ProtoReflect() protoreflect.Message {
-	mi := &file_public_proto_msgTypes[58]
+	mi := &file_public_proto_msgTypes[60]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4321,7 +4576,7 @@ func (x *StatTable_PodGroup_Row) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StatTable_PodGroup_Row.ProtoReflect.Descriptor instead.
 func (*StatTable_PodGroup_Row) Descriptor() ([]byte, []int) {
-	return file_public_proto_rawDescGZIP(), []int{28, 0, 0}
+	return file_public_proto_rawDescGZIP(), []int{29, 0, 0}
 }
 
 func (x *StatTable_PodGroup_Row) GetResource() *Resource {
@@ -4405,7 +4660,7 @@ type EdgesResponse_Ok struct {
 func (x *EdgesResponse_Ok) Reset() {
 	*x = EdgesResponse_Ok{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_public_proto_msgTypes[60]
+		mi := &file_public_proto_msgTypes[62]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -4418,7 +4673,7 @@ func (x *EdgesResponse_Ok) String() string {
 func (*EdgesResponse_Ok) ProtoMessage() {}
 
 func (x *EdgesResponse_Ok) ProtoReflect() protoreflect.Message {
-	mi := &file_public_proto_msgTypes[60]
+	mi := &file_public_proto_msgTypes[62]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4431,7 +4686,7 @@ func (x *EdgesResponse_Ok) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EdgesResponse_Ok.ProtoReflect.Descriptor instead.
 func (*EdgesResponse_Ok) Descriptor() ([]byte, []int) {
-	return file_public_proto_rawDescGZIP(), []int{30, 0}
+	return file_public_proto_rawDescGZIP(), []int{31, 0}
 }
 
 func (x *EdgesResponse_Ok) GetEdges() []*Edge {
@@ -4452,7 +4707,7 @@ type TopRoutesResponse_Ok struct {
 func (x *TopRoutesResponse_Ok) Reset() {
 	*x = TopRoutesResponse_Ok{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_public_proto_msgTypes[61]
+		mi := &file_public_proto_msgTypes[63]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -4465,7 +4720,7 @@ func (x *TopRoutesResponse_Ok) String() string {
 func (*TopRoutesResponse_Ok) ProtoMessage() {}
 
 func (x *TopRoutesResponse_Ok) ProtoReflect() protoreflect.Message {
-	mi := &file_public_proto_msgTypes[61]
+	mi := &file_public_proto_msgTypes[63]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4478,7 +4733,7 @@ func (x *TopRoutesResponse_Ok) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TopRoutesResponse_Ok.ProtoReflect.Descriptor instead.
 func (*TopRoutesResponse_Ok) Descriptor() ([]byte, []int) {
-	return file_public_proto_rawDescGZIP(), []int{33, 0}
+	return file_public_proto_rawDescGZIP(), []int{34, 0}
 }
 
 func (x *TopRoutesResponse_Ok) GetRoutes() []*RouteTable {
@@ -4502,7 +4757,7 @@ type RouteTable_Row struct {
 func (x *RouteTable_Row) Reset() {
 	*x = RouteTable_Row{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_public_proto_msgTypes[62]
+		mi := &file_public_proto_msgTypes[64]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -4515,7 +4770,7 @@ func (x *RouteTable_Row) String() string {
 func (*RouteTable_Row) ProtoMessage() {}
 
 func (x *RouteTable_Row) ProtoReflect() protoreflect.Message {
-	mi := &file_public_proto_msgTypes[62]
+	mi := &file_public_proto_msgTypes[64]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4528,7 +4783,7 @@ func (x *RouteTable_Row) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RouteTable_Row.ProtoReflect.Descriptor instead.
 func (*RouteTable_Row) Descriptor() ([]byte, []int) {
-	return file_public_proto_rawDescGZIP(), []int{34, 0}
+	return file_public_proto_rawDescGZIP(), []int{35, 0}
 }
 
 func (x *RouteTable_Row) GetRoute() string {
@@ -4577,7 +4832,7 @@ type GatewaysTable_Row struct {
 func (x *GatewaysTable_Row) Reset() {
 	*x = GatewaysTable_Row{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_public_proto_msgTypes[63]
+		mi := &file_public_proto_msgTypes[65]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -4590,7 +4845,7 @@ func (x *GatewaysTable_Row) String() string {
 func (*GatewaysTable_Row) ProtoMessage() {}
 
 func (x *GatewaysTable_Row) ProtoReflect() protoreflect.Message {
-	mi := &file_public_proto_msgTypes[63]
+	mi := &file_public_proto_msgTypes[65]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4603,7 +4858,7 @@ func (x *GatewaysTable_Row) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GatewaysTable_Row.ProtoReflect.Descriptor instead.
 func (*GatewaysTable_Row) Descriptor() ([]byte, []int) {
-	return file_public_proto_rawDescGZIP(), []int{35, 0}
+	return file_public_proto_rawDescGZIP(), []int{36, 0}
 }
 
 func (x *GatewaysTable_Row) GetNamespace() string {
@@ -4673,7 +4928,7 @@ type GatewaysResponse_Ok struct {
 func (x *GatewaysResponse_Ok) Reset() {
 	*x = GatewaysResponse_Ok{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_public_proto_msgTypes[64]
+		mi := &file_public_proto_msgTypes[66]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -4686,7 +4941,7 @@ func (x *GatewaysResponse_Ok) String() string {
 func (*GatewaysResponse_Ok) ProtoMessage() {}
 
 func (x *GatewaysResponse_Ok) ProtoReflect() protoreflect.Message {
-	mi := &file_public_proto_msgTypes[64]
+	mi := &file_public_proto_msgTypes[66]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4699,7 +4954,7 @@ func (x *GatewaysResponse_Ok) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GatewaysResponse_Ok.ProtoReflect.Descriptor instead.
 func (*GatewaysResponse_Ok) Descriptor() ([]byte, []int) {
-	return file_public_proto_rawDescGZIP(), []int{37, 0}
+	return file_public_proto_rawDescGZIP(), []int{38, 0}
 }
 
 func (x *GatewaysResponse_Ok) GetGatewaysTable() *GatewaysTable {
@@ -4716,163 +4971,182 @@ var file_public_proto_rawDesc = []byte{
 	0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x1a,
 	0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
 	0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a,
-	0x18, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2f, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x63, 0x68,
-	0x65, 0x63, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x13, 0x63, 0x6f, 0x6e, 0x66, 0x69,
-	0x67, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x07,
-	0x0a, 0x05, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x71, 0x0a, 0x0b, 0x56, 0x65, 0x72, 0x73, 0x69,
-	0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x1c, 0x0a, 0x09, 0x67, 0x6f, 0x56, 0x65, 0x72, 0x73,
-	0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x67, 0x6f, 0x56, 0x65, 0x72,
-	0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x44, 0x61, 0x74,
-	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x44, 0x61,
-	0x74, 0x65, 0x12, 0x26, 0x0a, 0x0e, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x56, 0x65, 0x72,
-	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x72, 0x65, 0x6c, 0x65,
-	0x61, 0x73, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x33, 0x0a, 0x13, 0x4c, 0x69,
-	0x73, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x22,
-	0x4c, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x34, 0x0a, 0x08, 0x73, 0x65, 0x72, 0x76, 0x69,
-	0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6c, 0x69, 0x6e, 0x6b,
-	0x65, 0x72, 0x64, 0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x53, 0x65, 0x72, 0x76,
-	0x69, 0x63, 0x65, 0x52, 0x08, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x22, 0x3b, 0x0a,
-	0x07, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09,
-	0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x22, 0x73, 0x0a, 0x0f, 0x4c, 0x69,
-	0x73, 0x74, 0x50, 0x6f, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x20, 0x0a,
-	0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x42, 0x02, 0x18, 0x01, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12,
-	0x3e, 0x0a, 0x08, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x1a, 0x18, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2f, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x63,
+	0x68, 0x65, 0x63, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x13, 0x63, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22,
+	0x07, 0x0a, 0x05, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x71, 0x0a, 0x0b, 0x56, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x1c, 0x0a, 0x09, 0x67, 0x6f, 0x56, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x67, 0x6f, 0x56, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x44, 0x61,
+	0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x44,
+	0x61, 0x74, 0x65, 0x12, 0x26, 0x0a, 0x0e, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x56, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x72, 0x65, 0x6c,
+	0x65, 0x61, 0x73, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x33, 0x0a, 0x13, 0x4c,
+	0x69, 0x73, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65,
+	0x22, 0x4c, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x34, 0x0a, 0x08, 0x73, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6c, 0x69, 0x6e,
+	0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x52, 0x08, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x22, 0x3b,
+	0x0a, 0x07, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a,
+	0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x22, 0x73, 0x0a, 0x0f, 0x4c,
+	0x69, 0x73, 0x74, 0x50, 0x6f, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x20,
+	0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x42, 0x02, 0x18, 0x01, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65,
+	0x12, 0x3e, 0x0a, 0x08, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x70, 0x75,
+	0x62, 0x6c, 0x69, 0x63, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x53, 0x65, 0x6c,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72,
+	0x22, 0x3c, 0x0a, 0x10, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x6f, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x28, 0x0a, 0x04, 0x70, 0x6f, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x70, 0x75,
+	0x62, 0x6c, 0x69, 0x63, 0x2e, 0x50, 0x6f, 0x64, 0x52, 0x04, 0x70, 0x6f, 0x64, 0x73, 0x22, 0xfa,
+	0x04, 0x0a, 0x03, 0x50, 0x6f, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x6f,
+	0x64, 0x49, 0x50, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x70, 0x6f, 0x64, 0x49, 0x50,
+	0x12, 0x20, 0x0a, 0x0a, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0a, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65,
+	0x6e, 0x74, 0x12, 0x21, 0x0a, 0x0b, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x5f, 0x73, 0x65,
+	0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0a, 0x72, 0x65, 0x70, 0x6c, 0x69,
+	0x63, 0x61, 0x53, 0x65, 0x74, 0x12, 0x37, 0x0a, 0x16, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x18,
+	0x0b, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x15, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x12, 0x23,
+	0x0a, 0x0c, 0x73, 0x74, 0x61, 0x74, 0x65, 0x66, 0x75, 0x6c, 0x5f, 0x73, 0x65, 0x74, 0x18, 0x0c,
+	0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0b, 0x73, 0x74, 0x61, 0x74, 0x65, 0x66, 0x75, 0x6c,
+	0x53, 0x65, 0x74, 0x12, 0x1f, 0x0a, 0x0a, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x5f, 0x73, 0x65,
+	0x74, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x09, 0x64, 0x61, 0x65, 0x6d, 0x6f,
+	0x6e, 0x53, 0x65, 0x74, 0x12, 0x12, 0x0a, 0x03, 0x6a, 0x6f, 0x62, 0x18, 0x0e, 0x20, 0x01, 0x28,
+	0x09, 0x48, 0x00, 0x52, 0x03, 0x6a, 0x6f, 0x62, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x12, 0x14, 0x0a, 0x05, 0x61, 0x64, 0x64, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x05, 0x61, 0x64, 0x64, 0x65, 0x64, 0x12, 0x43, 0x0a, 0x0f, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x4c,
+	0x61, 0x73, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0f, 0x73, 0x69, 0x6e, 0x63,
+	0x65, 0x4c, 0x61, 0x73, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x30, 0x0a, 0x13, 0x63,
+	0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61,
+	0x63, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x13, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f,
+	0x6c, 0x6c, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x22, 0x0a,
+	0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x50, 0x6c, 0x61, 0x6e, 0x65, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x50, 0x6c, 0x61, 0x6e,
+	0x65, 0x12, 0x31, 0x0a, 0x06, 0x75, 0x70, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x75, 0x70,
+	0x74, 0x69, 0x6d, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x52, 0x65, 0x61,
+	0x64, 0x79, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x52,
+	0x65, 0x61, 0x64, 0x79, 0x12, 0x22, 0x0a, 0x0c, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x56, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x10, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x70, 0x72, 0x6f, 0x78,
+	0x79, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x28, 0x0a, 0x0f, 0x72, 0x65, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x11, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0f, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x42, 0x07, 0x0a, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x22, 0xaa, 0x02, 0x0a, 0x0a,
+	0x54, 0x61, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x03, 0x70, 0x6f,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x03, 0x70, 0x6f, 0x64, 0x12, 0x20,
+	0x0a, 0x0a, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x48, 0x00, 0x52, 0x0a, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74,
+	0x12, 0x16, 0x0a, 0x06, 0x6d, 0x61, 0x78, 0x52, 0x70, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x02,
+	0x52, 0x06, 0x6d, 0x61, 0x78, 0x52, 0x70, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x6f, 0x50, 0x6f,
+	0x72, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x74, 0x6f, 0x50, 0x6f, 0x72, 0x74,
+	0x12, 0x12, 0x0a, 0x04, 0x74, 0x6f, 0x49, 0x50, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x74, 0x6f, 0x49, 0x50, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x72, 0x6f, 0x6d, 0x50, 0x6f, 0x72, 0x74,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x66, 0x72, 0x6f, 0x6d, 0x50, 0x6f, 0x72, 0x74,
+	0x12, 0x16, 0x0a, 0x06, 0x66, 0x72, 0x6f, 0x6d, 0x49, 0x50, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x66, 0x72, 0x6f, 0x6d, 0x49, 0x50, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x63, 0x68, 0x65,
+	0x6d, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65,
+	0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x75, 0x74, 0x68,
+	0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x75, 0x74,
+	0x68, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x0b,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x3a, 0x02, 0x18, 0x01, 0x42, 0x08,
+	0x0a, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x22, 0x95, 0x0a, 0x0a, 0x14, 0x54, 0x61, 0x70,
+	0x42, 0x79, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x3a, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
 	0x0b, 0x32, 0x22, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x70, 0x75, 0x62,
 	0x6c, 0x69, 0x63, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x53, 0x65, 0x6c, 0x65,
-	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x22,
-	0x3c, 0x0a, 0x10, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x6f, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x28, 0x0a, 0x04, 0x70, 0x6f, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x14, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x70, 0x75, 0x62,
-	0x6c, 0x69, 0x63, 0x2e, 0x50, 0x6f, 0x64, 0x52, 0x04, 0x70, 0x6f, 0x64, 0x73, 0x22, 0xfa, 0x04,
-	0x0a, 0x03, 0x50, 0x6f, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x6f, 0x64,
-	0x49, 0x50, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x70, 0x6f, 0x64, 0x49, 0x50, 0x12,
-	0x20, 0x0a, 0x0a, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0a, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e,
-	0x74, 0x12, 0x21, 0x0a, 0x0b, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x5f, 0x73, 0x65, 0x74,
-	0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0a, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63,
-	0x61, 0x53, 0x65, 0x74, 0x12, 0x37, 0x0a, 0x16, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x18, 0x0b,
-	0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x15, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x12, 0x23, 0x0a,
-	0x0c, 0x73, 0x74, 0x61, 0x74, 0x65, 0x66, 0x75, 0x6c, 0x5f, 0x73, 0x65, 0x74, 0x18, 0x0c, 0x20,
-	0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0b, 0x73, 0x74, 0x61, 0x74, 0x65, 0x66, 0x75, 0x6c, 0x53,
-	0x65, 0x74, 0x12, 0x1f, 0x0a, 0x0a, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x5f, 0x73, 0x65, 0x74,
-	0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x09, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e,
-	0x53, 0x65, 0x74, 0x12, 0x12, 0x0a, 0x03, 0x6a, 0x6f, 0x62, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09,
-	0x48, 0x00, 0x52, 0x03, 0x6a, 0x6f, 0x62, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
-	0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
-	0x14, 0x0a, 0x05, 0x61, 0x64, 0x64, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05,
-	0x61, 0x64, 0x64, 0x65, 0x64, 0x12, 0x43, 0x0a, 0x0f, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x4c, 0x61,
-	0x73, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19,
-	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
-	0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0f, 0x73, 0x69, 0x6e, 0x63, 0x65,
-	0x4c, 0x61, 0x73, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x30, 0x0a, 0x13, 0x63, 0x6f,
-	0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63,
-	0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x13, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c,
-	0x6c, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x22, 0x0a, 0x0c,
-	0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x50, 0x6c, 0x61, 0x6e, 0x65, 0x18, 0x08, 0x20, 0x01,
-	0x28, 0x08, 0x52, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x50, 0x6c, 0x61, 0x6e, 0x65,
-	0x12, 0x31, 0x0a, 0x06, 0x75, 0x70, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
-	0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x75, 0x70, 0x74,
-	0x69, 0x6d, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x52, 0x65, 0x61, 0x64,
-	0x79, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x52, 0x65,
-	0x61, 0x64, 0x79, 0x12, 0x22, 0x0a, 0x0c, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x56, 0x65, 0x72, 0x73,
-	0x69, 0x6f, 0x6e, 0x18, 0x10, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x70, 0x72, 0x6f, 0x78, 0x79,
-	0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x28, 0x0a, 0x0f, 0x72, 0x65, 0x73, 0x6f, 0x75,
-	0x72, 0x63, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x11, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x0f, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f,
-	0x6e, 0x42, 0x07, 0x0a, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x22, 0xaa, 0x02, 0x0a, 0x0a, 0x54,
-	0x61, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x03, 0x70, 0x6f, 0x64,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x03, 0x70, 0x6f, 0x64, 0x12, 0x20, 0x0a,
-	0x0a, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x48, 0x00, 0x52, 0x0a, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x12,
-	0x16, 0x0a, 0x06, 0x6d, 0x61, 0x78, 0x52, 0x70, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x02, 0x52,
-	0x06, 0x6d, 0x61, 0x78, 0x52, 0x70, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x6f, 0x50, 0x6f, 0x72,
-	0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x74, 0x6f, 0x50, 0x6f, 0x72, 0x74, 0x12,
-	0x12, 0x0a, 0x04, 0x74, 0x6f, 0x49, 0x50, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74,
-	0x6f, 0x49, 0x50, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x72, 0x6f, 0x6d, 0x50, 0x6f, 0x72, 0x74, 0x18,
-	0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x66, 0x72, 0x6f, 0x6d, 0x50, 0x6f, 0x72, 0x74, 0x12,
-	0x16, 0x0a, 0x06, 0x66, 0x72, 0x6f, 0x6d, 0x49, 0x50, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x06, 0x66, 0x72, 0x6f, 0x6d, 0x49, 0x50, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d,
-	0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x12,
-	0x16, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x75, 0x74, 0x68, 0x6f,
-	0x72, 0x69, 0x74, 0x79, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x75, 0x74, 0x68,
-	0x6f, 0x72, 0x69, 0x74, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x0b, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x3a, 0x02, 0x18, 0x01, 0x42, 0x08, 0x0a,
-	0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x22, 0x86, 0x08, 0x0a, 0x14, 0x54, 0x61, 0x70, 0x42,
-	0x79, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x3a, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x22, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c,
-	0x69, 0x63, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x53, 0x65, 0x6c, 0x65, 0x63,
-	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x41, 0x0a, 0x05,
-	0x6d, 0x61, 0x74, 0x63, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x6c, 0x69,
-	0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x54, 0x61,
-	0x70, 0x42, 0x79, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x52, 0x05, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x12,
-	0x16, 0x0a, 0x06, 0x6d, 0x61, 0x78, 0x52, 0x70, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x02, 0x52,
-	0x06, 0x6d, 0x61, 0x78, 0x52, 0x70, 0x73, 0x12, 0x47, 0x0a, 0x07, 0x65, 0x78, 0x74, 0x72, 0x61,
-	0x63, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65,
-	0x72, 0x64, 0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x54, 0x61, 0x70, 0x42, 0x79,
-	0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e,
-	0x45, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x52, 0x07, 0x65, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74,
-	0x1a, 0xb6, 0x04, 0x0a, 0x05, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x43, 0x0a, 0x03, 0x61, 0x6c,
-	0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72,
-	0x64, 0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x54, 0x61, 0x70, 0x42, 0x79, 0x52,
-	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4d,
-	0x61, 0x74, 0x63, 0x68, 0x2e, 0x53, 0x65, 0x71, 0x48, 0x00, 0x52, 0x03, 0x61, 0x6c, 0x6c, 0x12,
-	0x43, 0x0a, 0x03, 0x61, 0x6e, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x6c,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x41, 0x0a,
+	0x05, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x6c,
 	0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x54,
 	0x61, 0x70, 0x42, 0x79, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x53, 0x65, 0x71, 0x48, 0x00, 0x52,
-	0x03, 0x61, 0x6e, 0x79, 0x12, 0x3f, 0x0a, 0x03, 0x6e, 0x6f, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x2b, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x70, 0x75, 0x62,
+	0x65, 0x73, 0x74, 0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x52, 0x05, 0x6d, 0x61, 0x74, 0x63, 0x68,
+	0x12, 0x16, 0x0a, 0x06, 0x6d, 0x61, 0x78, 0x52, 0x70, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x02,
+	0x52, 0x06, 0x6d, 0x61, 0x78, 0x52, 0x70, 0x73, 0x12, 0x47, 0x0a, 0x07, 0x65, 0x78, 0x74, 0x72,
+	0x61, 0x63, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x6c, 0x69, 0x6e, 0x6b,
+	0x65, 0x72, 0x64, 0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x54, 0x61, 0x70, 0x42,
+	0x79, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x2e, 0x45, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x52, 0x07, 0x65, 0x78, 0x74, 0x72, 0x61, 0x63,
+	0x74, 0x12, 0x44, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x2c, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x70, 0x75, 0x62,
 	0x6c, 0x69, 0x63, 0x2e, 0x54, 0x61, 0x70, 0x42, 0x79, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
-	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x48, 0x00,
-	0x52, 0x03, 0x6e, 0x6f, 0x74, 0x12, 0x48, 0x0a, 0x0c, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6c, 0x69,
-	0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x52, 0x65,
-	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x48,
-	0x00, 0x52, 0x0c, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12,
-	0x46, 0x0a, 0x04, 0x68, 0x74, 0x74, 0x70, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x30, 0x2e,
-	0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e,
-	0x54, 0x61, 0x70, 0x42, 0x79, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x48, 0x74, 0x74, 0x70, 0x48,
-	0x00, 0x52, 0x04, 0x68, 0x74, 0x74, 0x70, 0x1a, 0x4c, 0x0a, 0x03, 0x53, 0x65, 0x71, 0x12, 0x45,
-	0x0a, 0x07, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x2b, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69,
-	0x63, 0x2e, 0x54, 0x61, 0x70, 0x42, 0x79, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x52, 0x07, 0x6d, 0x61,
-	0x74, 0x63, 0x68, 0x65, 0x73, 0x1a, 0x79, 0x0a, 0x04, 0x48, 0x74, 0x74, 0x70, 0x12, 0x18, 0x0a,
-	0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52,
-	0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f,
-	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f,
-	0x64, 0x12, 0x1e, 0x0a, 0x09, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x09, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x74,
-	0x79, 0x12, 0x14, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x48,
-	0x00, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x42, 0x07, 0x0a, 0x05, 0x6d, 0x61, 0x74, 0x63, 0x68,
-	0x42, 0x07, 0x0a, 0x05, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x1a, 0xd4, 0x01, 0x0a, 0x07, 0x45, 0x78,
-	0x74, 0x72, 0x61, 0x63, 0x74, 0x12, 0x48, 0x0a, 0x04, 0x68, 0x74, 0x74, 0x70, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x32, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x70,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52,
+	0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x61, 0x6d, 0x70, 0x6c,
+	0x65, 0x52, 0x61, 0x74, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0a, 0x73, 0x61, 0x6d,
+	0x70, 0x6c, 0x65, 0x52, 0x61, 0x74, 0x65, 0x1a, 0xd9, 0x04, 0x0a, 0x05, 0x4d, 0x61, 0x74, 0x63,
+	0x68, 0x12, 0x43, 0x0a, 0x03, 0x61, 0x6c, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2f,
+	0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63,
+	0x2e, 0x54, 0x61, 0x70, 0x42, 0x79, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x53, 0x65, 0x71, 0x48,
+	0x00, 0x52, 0x03, 0x61, 0x6c, 0x6c, 0x12, 0x43, 0x0a, 0x03, 0x61, 0x6e, 0x79, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x70,
 	0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x54, 0x61, 0x70, 0x42, 0x79, 0x52, 0x65, 0x73, 0x6f, 0x75,
-	0x72, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x45, 0x78, 0x74, 0x72, 0x61,
-	0x63, 0x74, 0x2e, 0x48, 0x74, 0x74, 0x70, 0x48, 0x00, 0x52, 0x04, 0x68, 0x74, 0x74, 0x70, 0x1a,
-	0x74, 0x0a, 0x04, 0x48, 0x74, 0x74, 0x70, 0x12, 0x56, 0x0a, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65,
-	0x72, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x3a, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65,
+	0x72, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68,
+	0x2e, 0x53, 0x65, 0x71, 0x48, 0x00, 0x52, 0x03, 0x61, 0x6e, 0x79, 0x12, 0x3f, 0x0a, 0x03, 0x6e,
+	0x6f, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65,
 	0x72, 0x64, 0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x54, 0x61, 0x70, 0x42, 0x79,
 	0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e,
-	0x45, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x2e, 0x48, 0x74, 0x74, 0x70, 0x2e, 0x48, 0x65, 0x61,
-	0x64, 0x65, 0x72, 0x73, 0x48, 0x00, 0x52, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x1a,
-	0x09, 0x0a, 0x07, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x42, 0x09, 0x0a, 0x07, 0x65, 0x78,
-	0x74, 0x72, 0x61, 0x63, 0x74, 0x42, 0x09, 0x0a, 0x07, 0x65, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74,
+	0x4d, 0x61, 0x74, 0x63, 0x68, 0x48, 0x00, 0x52, 0x03, 0x6e, 0x6f, 0x74, 0x12, 0x48, 0x0a, 0x0c,
+	0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x70, 0x75,
+	0x62, 0x6c, 0x69, 0x63, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x53, 0x65, 0x6c,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x0c, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x46, 0x0a, 0x04, 0x68, 0x74, 0x74, 0x70, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x30, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e,
+	0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x54, 0x61, 0x70, 0x42, 0x79, 0x52, 0x65, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4d, 0x61, 0x74, 0x63,
+	0x68, 0x2e, 0x48, 0x74, 0x74, 0x70, 0x48, 0x00, 0x52, 0x04, 0x68, 0x74, 0x74, 0x70, 0x1a, 0x4c,
+	0x0a, 0x03, 0x53, 0x65, 0x71, 0x12, 0x45, 0x0a, 0x07, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64,
+	0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x54, 0x61, 0x70, 0x42, 0x79, 0x52, 0x65,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4d, 0x61,
+	0x74, 0x63, 0x68, 0x52, 0x07, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x1a, 0x9b, 0x01, 0x0a,
+	0x04, 0x48, 0x74, 0x74, 0x70, 0x12, 0x18, 0x0a, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x12,
+	0x18, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48,
+	0x00, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x1e, 0x0a, 0x09, 0x61, 0x75, 0x74,
+	0x68, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x09,
+	0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x12, 0x14, 0x0a, 0x04, 0x70, 0x61, 0x74,
+	0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12,
+	0x20, 0x0a, 0x0a, 0x67, 0x72, 0x70, 0x63, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0a, 0x67, 0x72, 0x70, 0x63, 0x4d, 0x65, 0x74, 0x68, 0x6f,
+	0x64, 0x42, 0x07, 0x0a, 0x05, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x42, 0x07, 0x0a, 0x05, 0x6d, 0x61,
+	0x74, 0x63, 0x68, 0x1a, 0xd4, 0x01, 0x0a, 0x07, 0x45, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x12,
+	0x48, 0x0a, 0x04, 0x68, 0x74, 0x74, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x32, 0x2e,
+	0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e,
+	0x54, 0x61, 0x70, 0x42, 0x79, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x2e, 0x45, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x2e, 0x48, 0x74, 0x74,
+	0x70, 0x48, 0x00, 0x52, 0x04, 0x68, 0x74, 0x74, 0x70, 0x1a, 0x74, 0x0a, 0x04, 0x48, 0x74, 0x74,
+	0x70, 0x12, 0x56, 0x0a, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x3a, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x70, 0x75,
+	0x62, 0x6c, 0x69, 0x63, 0x2e, 0x54, 0x61, 0x70, 0x42, 0x79, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x45, 0x78, 0x74, 0x72, 0x61, 0x63,
+	0x74, 0x2e, 0x48, 0x74, 0x74, 0x70, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x48, 0x00,
+	0x52, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x1a, 0x09, 0x0a, 0x07, 0x48, 0x65, 0x61,
+	0x64, 0x65, 0x72, 0x73, 0x42, 0x09, 0x0a, 0x07, 0x65, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x42,
+	0x09, 0x0a, 0x07, 0x65, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x1a, 0x83, 0x01, 0x0a, 0x06, 0x46,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x20, 0x0a, 0x0b, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43,
+	0x6c, 0x61, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x61, 0x74, 0x68, 0x52,
+	0x65, 0x67, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x74, 0x68,
+	0x52, 0x65, 0x67, 0x65, 0x78, 0x12, 0x39, 0x0a, 0x0a, 0x6d, 0x69, 0x6e, 0x4c, 0x61, 0x74, 0x65,
+	0x6e, 0x63, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x6d, 0x69, 0x6e, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79,
 	0x22, 0xf4, 0x01, 0x0a, 0x0a, 0x48, 0x74, 0x74, 0x70, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12,
 	0x48, 0x0a, 0x0a, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x65, 0x64, 0x18, 0x01, 0x20,
 	0x01, 0x28, 0x0e, 0x32, 0x26, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x70,
@@ -5095,7 +5369,7 @@ var file_public_proto_rawDesc = []byte{
 	0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63,
 	0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x75,
 	0x72, 0x63, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0xeb, 0x02, 0x0a, 0x12, 0x53, 0x74,
+	0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x82, 0x04, 0x0a, 0x12, 0x53, 0x74,
 	0x61, 0x74, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
 	0x12, 0x3e, 0x0a, 0x08, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01,
 	0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x70, 0x75,
@@ -5117,280 +5391,318 @@ var file_public_proto_rawDesc = []byte{
 	0x1d, 0x0a, 0x0a, 0x73, 0x6b, 0x69, 0x70, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x73, 0x18, 0x06, 0x20,
 	0x01, 0x28, 0x08, 0x52, 0x09, 0x73, 0x6b, 0x69, 0x70, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x1b,
 	0x0a, 0x09, 0x74, 0x63, 0x70, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28,
-	0x08, 0x52, 0x08, 0x74, 0x63, 0x70, 0x53, 0x74, 0x61, 0x74, 0x73, 0x42, 0x0a, 0x0a, 0x08, 0x6f,
-	0x75, 0x74, 0x62, 0x6f, 0x75, 0x6e, 0x64, 0x22, 0xd7, 0x01, 0x0a, 0x13, 0x53, 0x74, 0x61, 0x74,
-	0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x39, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x6c, 0x69,
-	0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x53, 0x74,
-	0x61, 0x74, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x2e, 0x4f, 0x6b, 0x48, 0x00, 0x52, 0x02, 0x6f, 0x6b, 0x12, 0x36, 0x0a, 0x05, 0x65, 0x72,
-	0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6c, 0x69, 0x6e, 0x6b,
-	0x65, 0x72, 0x64, 0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x52, 0x65, 0x73, 0x6f,
-	0x75, 0x72, 0x63, 0x65, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x48, 0x00, 0x52, 0x05, 0x65, 0x72, 0x72,
-	0x6f, 0x72, 0x1a, 0x41, 0x0a, 0x02, 0x4f, 0x6b, 0x12, 0x3b, 0x0a, 0x0b, 0x73, 0x74, 0x61, 0x74,
-	0x5f, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
-	0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e,
-	0x53, 0x74, 0x61, 0x74, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x0a, 0x73, 0x74, 0x61, 0x74, 0x54,
-	0x61, 0x62, 0x6c, 0x65, 0x73, 0x42, 0x0a, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x22, 0xac, 0x02, 0x0a, 0x0a, 0x42, 0x61, 0x73, 0x69, 0x63, 0x53, 0x74, 0x61, 0x74, 0x73,
-	0x12, 0x23, 0x0a, 0x0d, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x63, 0x6f, 0x75, 0x6e,
-	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73,
-	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65,
-	0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x66, 0x61,
-	0x69, 0x6c, 0x75, 0x72, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x24, 0x0a, 0x0e, 0x6c, 0x61,
-	0x74, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x6d, 0x73, 0x5f, 0x70, 0x35, 0x30, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x04, 0x52, 0x0c, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4d, 0x73, 0x50, 0x35, 0x30,
-	0x12, 0x24, 0x0a, 0x0e, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x6d, 0x73, 0x5f, 0x70,
-	0x39, 0x35, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63,
-	0x79, 0x4d, 0x73, 0x50, 0x39, 0x35, 0x12, 0x24, 0x0a, 0x0e, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63,
-	0x79, 0x5f, 0x6d, 0x73, 0x5f, 0x70, 0x39, 0x39, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c,
-	0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4d, 0x73, 0x50, 0x39, 0x39, 0x12, 0x30, 0x0a, 0x14,
-	0x61, 0x63, 0x74, 0x75, 0x61, 0x6c, 0x5f, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x63,
-	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x12, 0x61, 0x63, 0x74, 0x75,
-	0x61, 0x6c, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x30,
-	0x0a, 0x14, 0x61, 0x63, 0x74, 0x75, 0x61, 0x6c, 0x5f, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65,
-	0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x12, 0x61, 0x63,
-	0x74, 0x75, 0x61, 0x6c, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74,
-	0x22, 0x8b, 0x01, 0x0a, 0x08, 0x54, 0x63, 0x70, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x29, 0x0a,
-	0x10, 0x6f, 0x70, 0x65, 0x6e, 0x5f, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
-	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0f, 0x6f, 0x70, 0x65, 0x6e, 0x43, 0x6f, 0x6e,
-	0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x28, 0x0a, 0x10, 0x72, 0x65, 0x61, 0x64,
-	0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x5f, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x04, 0x52, 0x0e, 0x72, 0x65, 0x61, 0x64, 0x42, 0x79, 0x74, 0x65, 0x73, 0x54, 0x6f, 0x74,
-	0x61, 0x6c, 0x12, 0x2a, 0x0a, 0x11, 0x77, 0x72, 0x69, 0x74, 0x65, 0x5f, 0x62, 0x79, 0x74, 0x65,
-	0x73, 0x5f, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0f, 0x77,
-	0x72, 0x69, 0x74, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x22, 0x53,
-	0x0a, 0x11, 0x54, 0x72, 0x61, 0x66, 0x66, 0x69, 0x63, 0x53, 0x70, 0x6c, 0x69, 0x74, 0x53, 0x74,
-	0x61, 0x74, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x70, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x04, 0x61, 0x70, 0x65, 0x78, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x65, 0x61, 0x66, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6c, 0x65, 0x61, 0x66, 0x12, 0x16, 0x0a, 0x06, 0x77,
-	0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x77, 0x65, 0x69,
-	0x67, 0x68, 0x74, 0x22, 0xfe, 0x05, 0x0a, 0x09, 0x53, 0x74, 0x61, 0x74, 0x54, 0x61, 0x62, 0x6c,
-	0x65, 0x12, 0x42, 0x0a, 0x09, 0x70, 0x6f, 0x64, 0x5f, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e,
+	0x08, 0x52, 0x08, 0x74, 0x63, 0x70, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x35, 0x0a, 0x08, 0x65,
+	0x6e, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07, 0x65, 0x6e, 0x64, 0x54, 0x69,
+	0x6d, 0x65, 0x12, 0x2d, 0x0a, 0x04, 0x73, 0x74, 0x65, 0x70, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x04, 0x73, 0x74, 0x65,
+	0x70, 0x12, 0x2f, 0x0a, 0x13, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x70, 0x65, 0x72,
+	0x63, 0x65, 0x6e, 0x74, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x01, 0x52, 0x12,
+	0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x69, 0x6c,
+	0x65, 0x73, 0x42, 0x0a, 0x0a, 0x08, 0x6f, 0x75, 0x74, 0x62, 0x6f, 0x75, 0x6e, 0x64, 0x22, 0xd7,
+	0x01, 0x0a, 0x13, 0x53, 0x74, 0x61, 0x74, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x39, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x27, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x70, 0x75,
+	0x62, 0x6c, 0x69, 0x63, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x4f, 0x6b, 0x48, 0x00, 0x52, 0x02, 0x6f,
+	0x6b, 0x12, 0x36, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1e, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c,
+	0x69, 0x63, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x45, 0x72, 0x72, 0x6f, 0x72,
+	0x48, 0x00, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x1a, 0x41, 0x0a, 0x02, 0x4f, 0x6b, 0x12,
+	0x3b, 0x0a, 0x0b, 0x73, 0x74, 0x61, 0x74, 0x5f, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e,
 	0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x54, 0x61, 0x62, 0x6c, 0x65,
-	0x2e, 0x50, 0x6f, 0x64, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x48, 0x00, 0x52, 0x08, 0x70, 0x6f, 0x64,
-	0x47, 0x72, 0x6f, 0x75, 0x70, 0x1a, 0xa3, 0x05, 0x0a, 0x08, 0x50, 0x6f, 0x64, 0x47, 0x72, 0x6f,
-	0x75, 0x70, 0x12, 0x3b, 0x0a, 0x04, 0x72, 0x6f, 0x77, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x27, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c,
-	0x69, 0x63, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x2e, 0x50, 0x6f, 0x64,
-	0x47, 0x72, 0x6f, 0x75, 0x70, 0x2e, 0x52, 0x6f, 0x77, 0x52, 0x04, 0x72, 0x6f, 0x77, 0x73, 0x1a,
-	0xd9, 0x04, 0x0a, 0x03, 0x52, 0x6f, 0x77, 0x12, 0x35, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x75,
-	0x72, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6c, 0x69, 0x6e, 0x6b,
-	0x65, 0x72, 0x64, 0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x52, 0x65, 0x73, 0x6f,
-	0x75, 0x72, 0x63, 0x65, 0x52, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x1f,
-	0x0a, 0x0b, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x69, 0x6d, 0x65, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x12,
-	0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x28, 0x0a, 0x10, 0x6d, 0x65, 0x73, 0x68, 0x65,
-	0x64, 0x5f, 0x70, 0x6f, 0x64, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x04, 0x52, 0x0e, 0x6d, 0x65, 0x73, 0x68, 0x65, 0x64, 0x50, 0x6f, 0x64, 0x43, 0x6f, 0x75, 0x6e,
-	0x74, 0x12, 0x2a, 0x0a, 0x11, 0x72, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x5f, 0x70, 0x6f, 0x64,
-	0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0f, 0x72, 0x75,
-	0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x50, 0x6f, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x28, 0x0a,
-	0x10, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x5f, 0x70, 0x6f, 0x64, 0x5f, 0x63, 0x6f, 0x75, 0x6e,
-	0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x50,
-	0x6f, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x31, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73,
-	0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64,
-	0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x42, 0x61, 0x73, 0x69, 0x63, 0x53, 0x74,
-	0x61, 0x74, 0x73, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73, 0x12, 0x36, 0x0a, 0x09, 0x74, 0x63,
-	0x70, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e,
-	0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e,
-	0x54, 0x63, 0x70, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x08, 0x74, 0x63, 0x70, 0x53, 0x74, 0x61,
-	0x74, 0x73, 0x12, 0x3d, 0x0a, 0x08, 0x74, 0x73, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x73, 0x18, 0x0a,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e,
-	0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x54, 0x72, 0x61, 0x66, 0x66, 0x69, 0x63, 0x53, 0x70,
-	0x6c, 0x69, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x07, 0x74, 0x73, 0x53, 0x74, 0x61, 0x74,
-	0x73, 0x12, 0x5c, 0x0a, 0x0d, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x5f, 0x62, 0x79, 0x5f, 0x70,
-	0x6f, 0x64, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x38, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65,
-	0x72, 0x64, 0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x54,
-	0x61, 0x62, 0x6c, 0x65, 0x2e, 0x50, 0x6f, 0x64, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x2e, 0x52, 0x6f,
-	0x77, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x42, 0x79, 0x50, 0x6f, 0x64, 0x45, 0x6e, 0x74,
-	0x72, 0x79, 0x52, 0x0b, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x42, 0x79, 0x50, 0x6f, 0x64, 0x1a,
-	0x5a, 0x0a, 0x10, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x42, 0x79, 0x50, 0x6f, 0x64, 0x45, 0x6e,
-	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x30, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e,
-	0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x50, 0x6f, 0x64, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x73,
-	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x07, 0x0a, 0x05, 0x74,
-	0x61, 0x62, 0x6c, 0x65, 0x22, 0x4e, 0x0a, 0x0c, 0x45, 0x64, 0x67, 0x65, 0x73, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x3e, 0x0a, 0x08, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64,
-	0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
-	0x65, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x73, 0x65, 0x6c, 0x65,
-	0x63, 0x74, 0x6f, 0x72, 0x22, 0xbb, 0x01, 0x0a, 0x0d, 0x45, 0x64, 0x67, 0x65, 0x73, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x33, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x21, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x70, 0x75,
-	0x62, 0x6c, 0x69, 0x63, 0x2e, 0x45, 0x64, 0x67, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x2e, 0x4f, 0x6b, 0x48, 0x00, 0x52, 0x02, 0x6f, 0x6b, 0x12, 0x36, 0x0a, 0x05, 0x65,
-	0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6c, 0x69, 0x6e,
-	0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x52, 0x65, 0x73,
-	0x6f, 0x75, 0x72, 0x63, 0x65, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x48, 0x00, 0x52, 0x05, 0x65, 0x72,
-	0x72, 0x6f, 0x72, 0x1a, 0x31, 0x0a, 0x02, 0x4f, 0x6b, 0x12, 0x2b, 0x0a, 0x05, 0x65, 0x64, 0x67,
-	0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65,
-	0x72, 0x64, 0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x45, 0x64, 0x67, 0x65, 0x52,
-	0x05, 0x65, 0x64, 0x67, 0x65, 0x73, 0x42, 0x0a, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x22, 0xc2, 0x01, 0x0a, 0x04, 0x45, 0x64, 0x67, 0x65, 0x12, 0x2b, 0x0a, 0x03, 0x73,
-	0x72, 0x63, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65,
-	0x72, 0x64, 0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75,
-	0x72, 0x63, 0x65, 0x52, 0x03, 0x73, 0x72, 0x63, 0x12, 0x2b, 0x0a, 0x03, 0x64, 0x73, 0x74, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32,
-	0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
-	0x52, 0x03, 0x64, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f,
-	0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74,
-	0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x49, 0x64, 0x12,
-	0x26, 0x0a, 0x0f, 0x6e, 0x6f, 0x5f, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x5f, 0x6d,
-	0x73, 0x67, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x6f, 0x49, 0x64, 0x65, 0x6e,
-	0x74, 0x69, 0x74, 0x79, 0x4d, 0x73, 0x67, 0x22, 0xeb, 0x01, 0x0a, 0x10, 0x54, 0x6f, 0x70, 0x52,
-	0x6f, 0x75, 0x74, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3e, 0x0a, 0x08,
-	0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22,
+	0x52, 0x0a, 0x73, 0x74, 0x61, 0x74, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x42, 0x0a, 0x0a, 0x08,
+	0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x86, 0x03, 0x0a, 0x0a, 0x42, 0x61, 0x73,
+	0x69, 0x63, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x75, 0x63, 0x63, 0x65,
+	0x73, 0x73, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c,
+	0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x23, 0x0a, 0x0d,
+	0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x0c, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x43, 0x6f, 0x75, 0x6e,
+	0x74, 0x12, 0x24, 0x0a, 0x0e, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x6d, 0x73, 0x5f,
+	0x70, 0x35, 0x30, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x6c, 0x61, 0x74, 0x65, 0x6e,
+	0x63, 0x79, 0x4d, 0x73, 0x50, 0x35, 0x30, 0x12, 0x24, 0x0a, 0x0e, 0x6c, 0x61, 0x74, 0x65, 0x6e,
+	0x63, 0x79, 0x5f, 0x6d, 0x73, 0x5f, 0x70, 0x39, 0x35, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x0c, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4d, 0x73, 0x50, 0x39, 0x35, 0x12, 0x24, 0x0a,
+	0x0e, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x6d, 0x73, 0x5f, 0x70, 0x39, 0x39, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4d, 0x73,
+	0x50, 0x39, 0x39, 0x12, 0x30, 0x0a, 0x14, 0x61, 0x63, 0x74, 0x75, 0x61, 0x6c, 0x5f, 0x73, 0x75,
+	0x63, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x12, 0x61, 0x63, 0x74, 0x75, 0x61, 0x6c, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73,
+	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x30, 0x0a, 0x14, 0x61, 0x63, 0x74, 0x75, 0x61, 0x6c, 0x5f,
+	0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x12, 0x61, 0x63, 0x74, 0x75, 0x61, 0x6c, 0x46, 0x61, 0x69, 0x6c, 0x75,
+	0x72, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x58, 0x0a, 0x16, 0x6c, 0x61, 0x74, 0x65, 0x6e,
+	0x63, 0x79, 0x5f, 0x6d, 0x73, 0x5f, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x69, 0x6c, 0x65,
+	0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72,
+	0x64, 0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63,
+	0x79, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x69, 0x6c, 0x65, 0x52, 0x14, 0x6c, 0x61, 0x74,
+	0x65, 0x6e, 0x63, 0x79, 0x4d, 0x73, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x69, 0x6c, 0x65,
+	0x73, 0x22, 0x52, 0x0a, 0x11, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x50, 0x65, 0x72, 0x63,
+	0x65, 0x6e, 0x74, 0x69, 0x6c, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e,
+	0x74, 0x69, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x70, 0x65, 0x72, 0x63,
+	0x65, 0x6e, 0x74, 0x69, 0x6c, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63,
+	0x79, 0x5f, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x6c, 0x61, 0x74, 0x65,
+	0x6e, 0x63, 0x79, 0x4d, 0x73, 0x22, 0xb4, 0x01, 0x0a, 0x08, 0x54, 0x63, 0x70, 0x53, 0x74, 0x61,
+	0x74, 0x73, 0x12, 0x29, 0x0a, 0x10, 0x6f, 0x70, 0x65, 0x6e, 0x5f, 0x63, 0x6f, 0x6e, 0x6e, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0f, 0x6f, 0x70,
+	0x65, 0x6e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x28, 0x0a,
+	0x10, 0x72, 0x65, 0x61, 0x64, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x5f, 0x74, 0x6f, 0x74, 0x61,
+	0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x72, 0x65, 0x61, 0x64, 0x42, 0x79, 0x74,
+	0x65, 0x73, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x2a, 0x0a, 0x11, 0x77, 0x72, 0x69, 0x74, 0x65,
+	0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x5f, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x0f, 0x77, 0x72, 0x69, 0x74, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x54, 0x6f,
+	0x74, 0x61, 0x6c, 0x12, 0x27, 0x0a, 0x0f, 0x74, 0x6c, 0x73, 0x5f, 0x63, 0x6f, 0x6e, 0x6e, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x74, 0x6c,
+	0x73, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x53, 0x0a, 0x11,
+	0x54, 0x72, 0x61, 0x66, 0x66, 0x69, 0x63, 0x53, 0x70, 0x6c, 0x69, 0x74, 0x53, 0x74, 0x61, 0x74,
+	0x73, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x70, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x61, 0x70, 0x65, 0x78, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x65, 0x61, 0x66, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6c, 0x65, 0x61, 0x66, 0x12, 0x16, 0x0a, 0x06, 0x77, 0x65, 0x69,
+	0x67, 0x68, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68,
+	0x74, 0x22, 0xfe, 0x05, 0x0a, 0x09, 0x53, 0x74, 0x61, 0x74, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x12,
+	0x42, 0x0a, 0x09, 0x70, 0x6f, 0x64, 0x5f, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x70, 0x75,
+	0x62, 0x6c, 0x69, 0x63, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x2e, 0x50,
+	0x6f, 0x64, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x48, 0x00, 0x52, 0x08, 0x70, 0x6f, 0x64, 0x47, 0x72,
+	0x6f, 0x75, 0x70, 0x1a, 0xa3, 0x05, 0x0a, 0x08, 0x50, 0x6f, 0x64, 0x47, 0x72, 0x6f, 0x75, 0x70,
+	0x12, 0x3b, 0x0a, 0x04, 0x72, 0x6f, 0x77, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x27,
 	0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63,
-	0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69,
-	0x6f, 0x6e, 0x52, 0x08, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x1f, 0x0a, 0x0b,
+	0x2e, 0x53, 0x74, 0x61, 0x74, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x2e, 0x50, 0x6f, 0x64, 0x47, 0x72,
+	0x6f, 0x75, 0x70, 0x2e, 0x52, 0x6f, 0x77, 0x52, 0x04, 0x72, 0x6f, 0x77, 0x73, 0x1a, 0xd9, 0x04,
+	0x0a, 0x03, 0x52, 0x6f, 0x77, 0x12, 0x35, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72,
+	0x64, 0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x52, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x1f, 0x0a, 0x0b,
 	0x74, 0x69, 0x6d, 0x65, 0x5f, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x0a, 0x74, 0x69, 0x6d, 0x65, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x12, 0x2c, 0x0a,
-	0x04, 0x6e, 0x6f, 0x6e, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6c, 0x69,
-	0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x45, 0x6d,
-	0x70, 0x74, 0x79, 0x48, 0x00, 0x52, 0x04, 0x6e, 0x6f, 0x6e, 0x65, 0x12, 0x3c, 0x0a, 0x0b, 0x74,
-	0x6f, 0x5f, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x19, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c,
-	0x69, 0x63, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x48, 0x00, 0x52, 0x0a, 0x74,
-	0x6f, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x42, 0x0a, 0x0a, 0x08, 0x6f, 0x75, 0x74,
-	0x62, 0x6f, 0x75, 0x6e, 0x64, 0x22, 0xcb, 0x01, 0x0a, 0x11, 0x54, 0x6f, 0x70, 0x52, 0x6f, 0x75,
-	0x74, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x36, 0x0a, 0x05, 0x65,
-	0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6c, 0x69, 0x6e,
-	0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x52, 0x65, 0x73,
-	0x6f, 0x75, 0x72, 0x63, 0x65, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x48, 0x00, 0x52, 0x05, 0x65, 0x72,
-	0x72, 0x6f, 0x72, 0x12, 0x37, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x25, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69,
-	0x63, 0x2e, 0x54, 0x6f, 0x70, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x2e, 0x4f, 0x6b, 0x48, 0x00, 0x52, 0x02, 0x6f, 0x6b, 0x1a, 0x39, 0x0a, 0x02,
-	0x4f, 0x6b, 0x12, 0x33, 0x0a, 0x06, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x70, 0x75,
-	0x62, 0x6c, 0x69, 0x63, 0x2e, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x52,
-	0x06, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x42, 0x0a, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x22, 0xed, 0x01, 0x0a, 0x0a, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x54, 0x61, 0x62,
-	0x6c, 0x65, 0x12, 0x33, 0x0a, 0x04, 0x72, 0x6f, 0x77, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x1f, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c,
-	0x69, 0x63, 0x2e, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x2e, 0x52, 0x6f,
-	0x77, 0x52, 0x04, 0x72, 0x6f, 0x77, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x75,
-	0x72, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x75,
-	0x72, 0x63, 0x65, 0x1a, 0x8d, 0x01, 0x0a, 0x03, 0x52, 0x6f, 0x77, 0x12, 0x14, 0x0a, 0x05, 0x72,
-	0x6f, 0x75, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x72, 0x6f, 0x75, 0x74,
-	0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x69, 0x6d, 0x65, 0x57, 0x69, 0x6e, 0x64,
-	0x6f, 0x77, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18,
-	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x74, 0x79,
-	0x12, 0x31, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x1b, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69,
-	0x63, 0x2e, 0x42, 0x61, 0x73, 0x69, 0x63, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x05, 0x73, 0x74,
-	0x61, 0x74, 0x73, 0x22, 0xd5, 0x02, 0x0a, 0x0d, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x73,
-	0x54, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x36, 0x0a, 0x04, 0x72, 0x6f, 0x77, 0x73, 0x18, 0x01, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x70,
-	0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x73, 0x54, 0x61,
-	0x62, 0x6c, 0x65, 0x2e, 0x52, 0x6f, 0x77, 0x52, 0x04, 0x72, 0x6f, 0x77, 0x73, 0x1a, 0x8b, 0x02,
-	0x0a, 0x03, 0x52, 0x6f, 0x77, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61,
-	0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70,
-	0x61, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6c, 0x75, 0x73, 0x74,
-	0x65, 0x72, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63,
-	0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x70, 0x61,
-	0x69, 0x72, 0x65, 0x64, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x04, 0x52, 0x0e, 0x70, 0x61, 0x69, 0x72, 0x65, 0x64, 0x53, 0x65, 0x72, 0x76, 0x69,
-	0x63, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x6c, 0x69, 0x76, 0x65, 0x18, 0x05, 0x20, 0x01,
-	0x28, 0x08, 0x52, 0x05, 0x61, 0x6c, 0x69, 0x76, 0x65, 0x12, 0x24, 0x0a, 0x0e, 0x6c, 0x61, 0x74,
-	0x65, 0x6e, 0x63, 0x79, 0x5f, 0x6d, 0x73, 0x5f, 0x70, 0x35, 0x30, 0x18, 0x06, 0x20, 0x01, 0x28,
-	0x04, 0x52, 0x0c, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4d, 0x73, 0x50, 0x35, 0x30, 0x12,
-	0x24, 0x0a, 0x0e, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x6d, 0x73, 0x5f, 0x70, 0x39,
-	0x35, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79,
-	0x4d, 0x73, 0x50, 0x39, 0x35, 0x12, 0x24, 0x0a, 0x0e, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79,
-	0x5f, 0x6d, 0x73, 0x5f, 0x70, 0x39, 0x39, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x6c,
-	0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4d, 0x73, 0x50, 0x39, 0x39, 0x22, 0x8f, 0x01, 0x0a, 0x0f,
-	0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x2e, 0x0a, 0x13, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65,
-	0x72, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x72, 0x65,
-	0x6d, 0x6f, 0x74, 0x65, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x12,
-	0x2b, 0x0a, 0x11, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x73,
-	0x70, 0x61, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x67, 0x61, 0x74, 0x65,
-	0x77, 0x61, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x1f, 0x0a, 0x0b,
-	0x74, 0x69, 0x6d, 0x65, 0x5f, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x0a, 0x74, 0x69, 0x6d, 0x65, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x22, 0xdb, 0x01,
-	0x0a, 0x10, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x36, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24,
-	0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63,
-	0x2e, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x09, 0x52, 0x0a, 0x74, 0x69, 0x6d, 0x65, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x12, 0x16, 0x0a,
+	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x28, 0x0a, 0x10, 0x6d, 0x65, 0x73, 0x68, 0x65, 0x64, 0x5f,
+	0x70, 0x6f, 0x64, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x0e, 0x6d, 0x65, 0x73, 0x68, 0x65, 0x64, 0x50, 0x6f, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12,
+	0x2a, 0x0a, 0x11, 0x72, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x5f, 0x70, 0x6f, 0x64, 0x5f, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0f, 0x72, 0x75, 0x6e, 0x6e,
+	0x69, 0x6e, 0x67, 0x50, 0x6f, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x28, 0x0a, 0x10, 0x66,
+	0x61, 0x69, 0x6c, 0x65, 0x64, 0x5f, 0x70, 0x6f, 0x64, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x50, 0x6f, 0x64,
+	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x31, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e,
+	0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x42, 0x61, 0x73, 0x69, 0x63, 0x53, 0x74, 0x61, 0x74,
+	0x73, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73, 0x12, 0x36, 0x0a, 0x09, 0x74, 0x63, 0x70, 0x5f,
+	0x73, 0x74, 0x61, 0x74, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6c, 0x69,
+	0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x54, 0x63,
+	0x70, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x08, 0x74, 0x63, 0x70, 0x53, 0x74, 0x61, 0x74, 0x73,
+	0x12, 0x3d, 0x0a, 0x08, 0x74, 0x73, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x73, 0x18, 0x0a, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x70, 0x75,
+	0x62, 0x6c, 0x69, 0x63, 0x2e, 0x54, 0x72, 0x61, 0x66, 0x66, 0x69, 0x63, 0x53, 0x70, 0x6c, 0x69,
+	0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x07, 0x74, 0x73, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12,
+	0x5c, 0x0a, 0x0d, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x5f, 0x62, 0x79, 0x5f, 0x70, 0x6f, 0x64,
+	0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x38, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64,
+	0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x54, 0x61, 0x62,
+	0x6c, 0x65, 0x2e, 0x50, 0x6f, 0x64, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x2e, 0x52, 0x6f, 0x77, 0x2e,
+	0x45, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x42, 0x79, 0x50, 0x6f, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x52, 0x0b, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x42, 0x79, 0x50, 0x6f, 0x64, 0x1a, 0x5a, 0x0a,
+	0x10, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x42, 0x79, 0x50, 0x6f, 0x64, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x30, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x70, 0x75,
+	0x62, 0x6c, 0x69, 0x63, 0x2e, 0x50, 0x6f, 0x64, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x07, 0x0a, 0x05, 0x74, 0x61, 0x62,
+	0x6c, 0x65, 0x22, 0x6f, 0x0a, 0x0c, 0x45, 0x64, 0x67, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x3e, 0x0a, 0x08, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e,
+	0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x53,
+	0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74,
+	0x6f, 0x72, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x77, 0x69, 0x6e, 0x64, 0x6f,
+	0x77, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x69, 0x6d, 0x65, 0x57, 0x69, 0x6e,
+	0x64, 0x6f, 0x77, 0x22, 0xbb, 0x01, 0x0a, 0x0d, 0x45, 0x64, 0x67, 0x65, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x33, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x21, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x70, 0x75, 0x62,
+	0x6c, 0x69, 0x63, 0x2e, 0x45, 0x64, 0x67, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
 	0x65, 0x2e, 0x4f, 0x6b, 0x48, 0x00, 0x52, 0x02, 0x6f, 0x6b, 0x12, 0x36, 0x0a, 0x05, 0x65, 0x72,
 	0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6c, 0x69, 0x6e, 0x6b,
 	0x65, 0x72, 0x64, 0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x52, 0x65, 0x73, 0x6f,
 	0x75, 0x72, 0x63, 0x65, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x48, 0x00, 0x52, 0x05, 0x65, 0x72, 0x72,
-	0x6f, 0x72, 0x1a, 0x4b, 0x0a, 0x02, 0x4f, 0x6b, 0x12, 0x45, 0x0a, 0x0e, 0x67, 0x61, 0x74, 0x65,
-	0x77, 0x61, 0x79, 0x73, 0x5f, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x1e, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c,
-	0x69, 0x63, 0x2e, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x73, 0x54, 0x61, 0x62, 0x6c, 0x65,
-	0x52, 0x0d, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x73, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x42,
-	0x0a, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x32, 0x91, 0x07, 0x0a, 0x03,
-	0x41, 0x70, 0x69, 0x12, 0x5a, 0x0a, 0x0b, 0x53, 0x74, 0x61, 0x74, 0x53, 0x75, 0x6d, 0x6d, 0x61,
-	0x72, 0x79, 0x12, 0x23, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x70, 0x75,
-	0x62, 0x6c, 0x69, 0x63, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72,
-	0x64, 0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x53, 0x75,
-	0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
-	0x48, 0x0a, 0x05, 0x45, 0x64, 0x67, 0x65, 0x73, 0x12, 0x1d, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65,
-	0x72, 0x64, 0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x45, 0x64, 0x67, 0x65, 0x73,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72,
-	0x64, 0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x45, 0x64, 0x67, 0x65, 0x73, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x51, 0x0a, 0x08, 0x47, 0x61, 0x74,
-	0x65, 0x77, 0x61, 0x79, 0x73, 0x12, 0x20, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32,
+	0x6f, 0x72, 0x1a, 0x31, 0x0a, 0x02, 0x4f, 0x6b, 0x12, 0x2b, 0x0a, 0x05, 0x65, 0x64, 0x67, 0x65,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72,
+	0x64, 0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x45, 0x64, 0x67, 0x65, 0x52, 0x05,
+	0x65, 0x64, 0x67, 0x65, 0x73, 0x42, 0x0a, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0xfa, 0x01, 0x0a, 0x04, 0x45, 0x64, 0x67, 0x65, 0x12, 0x2b, 0x0a, 0x03, 0x73, 0x72,
+	0x63, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72,
+	0x64, 0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x52, 0x03, 0x73, 0x72, 0x63, 0x12, 0x2b, 0x0a, 0x03, 0x64, 0x73, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e,
+	0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52,
+	0x03, 0x64, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x69,
+	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49,
+	0x64, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x49, 0x64, 0x12, 0x26,
+	0x0a, 0x0f, 0x6e, 0x6f, 0x5f, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x5f, 0x6d, 0x73,
+	0x67, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x6f, 0x49, 0x64, 0x65, 0x6e, 0x74,
+	0x69, 0x74, 0x79, 0x4d, 0x73, 0x67, 0x12, 0x36, 0x0a, 0x09, 0x74, 0x63, 0x70, 0x5f, 0x73, 0x74,
+	0x61, 0x74, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6c, 0x69, 0x6e, 0x6b,
+	0x65, 0x72, 0x64, 0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x54, 0x63, 0x70, 0x53,
+	0x74, 0x61, 0x74, 0x73, 0x52, 0x08, 0x74, 0x63, 0x70, 0x53, 0x74, 0x61, 0x74, 0x73, 0x22, 0x82,
+	0x03, 0x0a, 0x10, 0x54, 0x6f, 0x70, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x3e, 0x0a, 0x08, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32,
+	0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x73, 0x65, 0x6c, 0x65, 0x63,
+	0x74, 0x6f, 0x72, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x77, 0x69, 0x6e, 0x64,
+	0x6f, 0x77, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x69, 0x6d, 0x65, 0x57, 0x69,
+	0x6e, 0x64, 0x6f, 0x77, 0x12, 0x2c, 0x0a, 0x04, 0x6e, 0x6f, 0x6e, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x70, 0x75,
+	0x62, 0x6c, 0x69, 0x63, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x48, 0x00, 0x52, 0x04, 0x6e, 0x6f,
+	0x6e, 0x65, 0x12, 0x3c, 0x0a, 0x0b, 0x74, 0x6f, 0x5f, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72,
+	0x64, 0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x48, 0x00, 0x52, 0x0a, 0x74, 0x6f, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x12, 0x35, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07,
+	0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x2d, 0x0a, 0x04, 0x73, 0x74, 0x65, 0x70, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x04, 0x73, 0x74, 0x65, 0x70, 0x12, 0x2f, 0x0a, 0x13, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63,
+	0x79, 0x5f, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x0a, 0x20,
+	0x03, 0x28, 0x01, 0x52, 0x12, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x50, 0x65, 0x72, 0x63,
+	0x65, 0x6e, 0x74, 0x69, 0x6c, 0x65, 0x73, 0x42, 0x0a, 0x0a, 0x08, 0x6f, 0x75, 0x74, 0x62, 0x6f,
+	0x75, 0x6e, 0x64, 0x22, 0xcb, 0x01, 0x0a, 0x11, 0x54, 0x6f, 0x70, 0x52, 0x6f, 0x75, 0x74, 0x65,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x36, 0x0a, 0x05, 0x65, 0x72, 0x72,
+	0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65,
+	0x72, 0x64, 0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x48, 0x00, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x12, 0x37, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e,
+	0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e,
+	0x54, 0x6f, 0x70, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x2e, 0x4f, 0x6b, 0x48, 0x00, 0x52, 0x02, 0x6f, 0x6b, 0x1a, 0x39, 0x0a, 0x02, 0x4f, 0x6b,
+	0x12, 0x33, 0x0a, 0x06, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x1b, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c,
+	0x69, 0x63, 0x2e, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x06, 0x72,
+	0x6f, 0x75, 0x74, 0x65, 0x73, 0x42, 0x0a, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0xed, 0x01, 0x0a, 0x0a, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x54, 0x61, 0x62, 0x6c, 0x65,
+	0x12, 0x33, 0x0a, 0x04, 0x72, 0x6f, 0x77, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f,
+	0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63,
+	0x2e, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x2e, 0x52, 0x6f, 0x77, 0x52,
+	0x04, 0x72, 0x6f, 0x77, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x1a, 0x8d, 0x01, 0x0a, 0x03, 0x52, 0x6f, 0x77, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x75,
+	0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x12,
+	0x1f, 0x0a, 0x0b, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x69, 0x6d, 0x65, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77,
+	0x12, 0x1c, 0x0a, 0x09, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x12, 0x31,
+	0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e,
+	0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e,
+	0x42, 0x61, 0x73, 0x69, 0x63, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74,
+	0x73, 0x22, 0xd5, 0x02, 0x0a, 0x0d, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x73, 0x54, 0x61,
+	0x62, 0x6c, 0x65, 0x12, 0x36, 0x0a, 0x04, 0x72, 0x6f, 0x77, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x22, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x70, 0x75, 0x62,
+	0x6c, 0x69, 0x63, 0x2e, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x73, 0x54, 0x61, 0x62, 0x6c,
+	0x65, 0x2e, 0x52, 0x6f, 0x77, 0x52, 0x04, 0x72, 0x6f, 0x77, 0x73, 0x1a, 0x8b, 0x02, 0x0a, 0x03,
+	0x52, 0x6f, 0x77, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63,
+	0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72,
+	0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6c, 0x75,
+	0x73, 0x74, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x70, 0x61, 0x69, 0x72,
+	0x65, 0x64, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x0e, 0x70, 0x61, 0x69, 0x72, 0x65, 0x64, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x73, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x6c, 0x69, 0x76, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x05, 0x61, 0x6c, 0x69, 0x76, 0x65, 0x12, 0x24, 0x0a, 0x0e, 0x6c, 0x61, 0x74, 0x65, 0x6e,
+	0x63, 0x79, 0x5f, 0x6d, 0x73, 0x5f, 0x70, 0x35, 0x30, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x0c, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4d, 0x73, 0x50, 0x35, 0x30, 0x12, 0x24, 0x0a,
+	0x0e, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x6d, 0x73, 0x5f, 0x70, 0x39, 0x35, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4d, 0x73,
+	0x50, 0x39, 0x35, 0x12, 0x24, 0x0a, 0x0e, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x6d,
+	0x73, 0x5f, 0x70, 0x39, 0x39, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x6c, 0x61, 0x74,
+	0x65, 0x6e, 0x63, 0x79, 0x4d, 0x73, 0x50, 0x39, 0x39, 0x22, 0x8f, 0x01, 0x0a, 0x0f, 0x47, 0x61,
+	0x74, 0x65, 0x77, 0x61, 0x79, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2e, 0x0a,
+	0x13, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x5f,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x72, 0x65, 0x6d, 0x6f,
+	0x74, 0x65, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x2b, 0x0a,
+	0x11, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61,
+	0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61,
+	0x79, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x69,
+	0x6d, 0x65, 0x5f, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x74, 0x69, 0x6d, 0x65, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x22, 0xdb, 0x01, 0x0a, 0x10,
+	0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x36, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6c,
+	0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x47,
+	0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e,
+	0x4f, 0x6b, 0x48, 0x00, 0x52, 0x02, 0x6f, 0x6b, 0x12, 0x36, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72,
+	0x64, 0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x48, 0x00, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72,
+	0x1a, 0x4b, 0x0a, 0x02, 0x4f, 0x6b, 0x12, 0x45, 0x0a, 0x0e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61,
+	0x79, 0x73, 0x5f, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e,
+	0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63,
+	0x2e, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x73, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x0d,
+	0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x73, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x42, 0x0a, 0x0a,
+	0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x32, 0x91, 0x07, 0x0a, 0x03, 0x41, 0x70,
+	0x69, 0x12, 0x5a, 0x0a, 0x0b, 0x53, 0x74, 0x61, 0x74, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79,
+	0x12, 0x23, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c,
+	0x69, 0x63, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32,
+	0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x53, 0x75, 0x6d, 0x6d,
+	0x61, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x48, 0x0a,
+	0x05, 0x45, 0x64, 0x67, 0x65, 0x73, 0x12, 0x1d, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64,
+	0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x45, 0x64, 0x67, 0x65, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32,
+	0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x45, 0x64, 0x67, 0x65, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x51, 0x0a, 0x08, 0x47, 0x61, 0x74, 0x65, 0x77,
+	0x61, 0x79, 0x73, 0x12, 0x20, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x70,
+	0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32,
 	0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x73,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72,
-	0x64, 0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61,
-	0x79, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x54, 0x0a, 0x09,
-	0x54, 0x6f, 0x70, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x12, 0x21, 0x2e, 0x6c, 0x69, 0x6e, 0x6b,
-	0x65, 0x72, 0x64, 0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x54, 0x6f, 0x70, 0x52,
-	0x6f, 0x75, 0x74, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x6c,
-	0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x54,
-	0x6f, 0x70, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x22, 0x00, 0x12, 0x51, 0x0a, 0x08, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x6f, 0x64, 0x73, 0x12, 0x20,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x54, 0x0a, 0x09, 0x54, 0x6f,
+	0x70, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x12, 0x21, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72,
+	0x64, 0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x54, 0x6f, 0x70, 0x52, 0x6f, 0x75,
+	0x74, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x6c, 0x69, 0x6e,
+	0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x54, 0x6f, 0x70,
+	0x52, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x12, 0x51, 0x0a, 0x08, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x6f, 0x64, 0x73, 0x12, 0x20, 0x2e, 0x6c,
+	0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x4c,
+	0x69, 0x73, 0x74, 0x50, 0x6f, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21,
 	0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63,
-	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x6f, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x21, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c,
-	0x69, 0x63, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x6f, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x5d, 0x0a, 0x0c, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x72,
-	0x76, 0x69, 0x63, 0x65, 0x73, 0x12, 0x24, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32,
-	0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x72, 0x76,
-	0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x6c, 0x69,
-	0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x4c, 0x69,
-	0x73, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x22, 0x00, 0x12, 0x44, 0x0a, 0x03, 0x54, 0x61, 0x70, 0x12, 0x1b, 0x2e, 0x6c, 0x69,
-	0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x54, 0x61,
-	0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65,
-	0x72, 0x64, 0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x54, 0x61, 0x70, 0x45, 0x76,
-	0x65, 0x6e, 0x74, 0x22, 0x03, 0x88, 0x02, 0x01, 0x30, 0x01, 0x12, 0x58, 0x0a, 0x0d, 0x54, 0x61,
-	0x70, 0x42, 0x79, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x25, 0x2e, 0x6c, 0x69,
-	0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x54, 0x61,
-	0x70, 0x42, 0x79, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x19, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x70, 0x75,
-	0x62, 0x6c, 0x69, 0x63, 0x2e, 0x54, 0x61, 0x70, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x22, 0x03, 0x88,
-	0x02, 0x01, 0x30, 0x01, 0x12, 0x41, 0x0a, 0x07, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12,
-	0x16, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69,
-	0x63, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x1c, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72,
-	0x64, 0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f,
-	0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x22, 0x00, 0x12, 0x6c, 0x0a, 0x09, 0x53, 0x65, 0x6c, 0x66, 0x43,
-	0x68, 0x65, 0x63, 0x6b, 0x12, 0x2d, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e,
-	0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x63, 0x68, 0x65,
-	0x63, 0x6b, 0x2e, 0x53, 0x65, 0x6c, 0x66, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x2e, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x63,
-	0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x63, 0x68, 0x65, 0x63,
-	0x6b, 0x2e, 0x53, 0x65, 0x6c, 0x66, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x38, 0x0a, 0x06, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12,
-	0x16, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69,
-	0x63, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x14, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72,
-	0x64, 0x32, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x41, 0x6c, 0x6c, 0x22, 0x00, 0x42,
-	0x33, 0x5a, 0x31, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6c, 0x69,
-	0x6e, 0x6b, 0x65, 0x72, 0x64, 0x2f, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2f, 0x63,
-	0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x70, 0x75,
-	0x62, 0x6c, 0x69, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x6f, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x12, 0x5d, 0x0a, 0x0c, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x73, 0x12, 0x24, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x70,
+	0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x6c, 0x69, 0x6e, 0x6b,
+	0x65, 0x72, 0x64, 0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x4c, 0x69, 0x73, 0x74,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x12, 0x44, 0x0a, 0x03, 0x54, 0x61, 0x70, 0x12, 0x1b, 0x2e, 0x6c, 0x69, 0x6e, 0x6b,
+	0x65, 0x72, 0x64, 0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x54, 0x61, 0x70, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64,
+	0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x54, 0x61, 0x70, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x22, 0x03, 0x88, 0x02, 0x01, 0x30, 0x01, 0x12, 0x58, 0x0a, 0x0d, 0x54, 0x61, 0x70, 0x42,
+	0x79, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x25, 0x2e, 0x6c, 0x69, 0x6e, 0x6b,
+	0x65, 0x72, 0x64, 0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x54, 0x61, 0x70, 0x42,
+	0x79, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x19, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c,
+	0x69, 0x63, 0x2e, 0x54, 0x61, 0x70, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x22, 0x03, 0x88, 0x02, 0x01,
+	0x30, 0x01, 0x12, 0x41, 0x0a, 0x07, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x2e,
+	0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x1c, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32,
+	0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x49,
+	0x6e, 0x66, 0x6f, 0x22, 0x00, 0x12, 0x6c, 0x0a, 0x09, 0x53, 0x65, 0x6c, 0x66, 0x43, 0x68, 0x65,
+	0x63, 0x6b, 0x12, 0x2d, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x63, 0x6f,
+	0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x63, 0x68, 0x65, 0x63, 0x6b,
+	0x2e, 0x53, 0x65, 0x6c, 0x66, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x2e, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x63, 0x6f, 0x6d,
+	0x6d, 0x6f, 0x6e, 0x2e, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x2e,
+	0x53, 0x65, 0x6c, 0x66, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x12, 0x38, 0x0a, 0x06, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x16, 0x2e,
+	0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2e,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x14, 0x2e, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32,
+	0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x41, 0x6c, 0x6c, 0x22, 0x00, 0x42, 0x33, 0x5a,
+	0x31, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6c, 0x69, 0x6e, 0x6b,
+	0x65, 0x72, 0x64, 0x2f, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x72, 0x64, 0x32, 0x2f, 0x63, 0x6f, 0x6e,
+	0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x70, 0x75, 0x62, 0x6c,
+	0x69, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -5406,7 +5718,7 @@ func file_public_proto_rawDescGZIP() []byte {
 }
 
 var file_public_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
-var file_public_proto_msgTypes = make([]protoimpl.MessageInfo, 65)
+var file_public_proto_msgTypes = make([]protoimpl.MessageInfo, 67)
 var file_public_proto_goTypes = []interface{}{
 	(HttpMethod_Registered)(0),                        // 0: linkerd2.public.HttpMethod.Registered
 	(Scheme_Registered)(0),                            // 1: linkerd2.public.Scheme.Registered
@@ -5437,160 +5749,171 @@ var file_public_proto_goTypes = []interface{}{
 	(*StatSummaryRequest)(nil),                        // 26: linkerd2.public.StatSummaryRequest
 	(*StatSummaryResponse)(nil),                       // 27: linkerd2.public.StatSummaryResponse
 	(*BasicStats)(nil),                                // 28: linkerd2.public.BasicStats
-	(*TcpStats)(nil),                                  // 29: linkerd2.public.TcpStats
-	(*TrafficSplitStats)(nil),                         // 30: linkerd2.public.TrafficSplitStats
-	(*StatTable)(nil),                                 // 31: linkerd2.public.StatTable
-	(*EdgesRequest)(nil),                              // 32: linkerd2.public.EdgesRequest
-	(*EdgesResponse)(nil),                             // 33: linkerd2.public.EdgesResponse
-	(*Edge)(nil),                                      // 34: linkerd2.public.Edge
-	(*TopRoutesRequest)(nil),                          // 35: linkerd2.public.TopRoutesRequest
-	(*TopRoutesResponse)(nil),                         // 36: linkerd2.public.TopRoutesResponse
-	(*RouteTable)(nil),                                // 37: linkerd2.public.RouteTable
-	(*GatewaysTable)(nil),                             // 38: linkerd2.public.GatewaysTable
-	(*GatewaysRequest)(nil),                           // 39: linkerd2.public.GatewaysRequest
-	(*GatewaysResponse)(nil),                          // 40: linkerd2.public.GatewaysResponse
-	(*TapByResourceRequest_Match)(nil),                // 41: linkerd2.public.TapByResourceRequest.Match
-	(*TapByResourceRequest_Extract)(nil),              // 42: linkerd2.public.TapByResourceRequest.Extract
-	(*TapByResourceRequest_Match_Seq)(nil),            // 43: linkerd2.public.TapByResourceRequest.Match.Seq
-	(*TapByResourceRequest_Match_Http)(nil),           // 44: linkerd2.public.TapByResourceRequest.Match.Http
-	(*TapByResourceRequest_Extract_Http)(nil),         // 45: linkerd2.public.TapByResourceRequest.Extract.Http
-	(*TapByResourceRequest_Extract_Http_Headers)(nil), // 46: linkerd2.public.TapByResourceRequest.Extract.Http.Headers
-	(*Headers_Header)(nil),                            // 47: linkerd2.public.Headers.Header
-	(*TapEvent_EndpointMeta)(nil),                     // 48: linkerd2.public.TapEvent.EndpointMeta
-	(*TapEvent_RouteMeta)(nil),                        // 49: linkerd2.public.TapEvent.RouteMeta
-	(*TapEvent_Http)(nil),                             // 50: linkerd2.public.TapEvent.Http
-	nil,                                               // 51: linkerd2.public.TapEvent.EndpointMeta.LabelsEntry
-	nil,                                               // 52: linkerd2.public.TapEvent.RouteMeta.LabelsEntry
-	(*TapEvent_Http_StreamId)(nil),                    // 53: linkerd2.public.TapEvent.Http.StreamId
-	(*TapEvent_Http_RequestInit)(nil),                 // 54: linkerd2.public.TapEvent.Http.RequestInit
-	(*TapEvent_Http_ResponseInit)(nil),                // 55: linkerd2.public.TapEvent.Http.ResponseInit
-	(*TapEvent_Http_ResponseEnd)(nil),                 // 56: linkerd2.public.TapEvent.Http.ResponseEnd
-	(*PodErrors_PodError)(nil),                        // 57: linkerd2.public.PodErrors.PodError
-	(*PodErrors_PodError_ContainerError)(nil),         // 58: linkerd2.public.PodErrors.PodError.ContainerError
-	(*StatSummaryResponse_Ok)(nil),                    // 59: linkerd2.public.StatSummaryResponse.Ok
-	(*StatTable_PodGroup)(nil),                        // 60: linkerd2.public.StatTable.PodGroup
-	(*StatTable_PodGroup_Row)(nil),                    // 61: linkerd2.public.StatTable.PodGroup.Row
-	nil,                                               // 62: linkerd2.public.StatTable.PodGroup.Row.ErrorsByPodEntry
-	(*EdgesResponse_Ok)(nil),                          // 63: linkerd2.public.EdgesResponse.Ok
-	(*TopRoutesResponse_Ok)(nil),                      // 64: linkerd2.public.TopRoutesResponse.Ok
-	(*RouteTable_Row)(nil),                            // 65: linkerd2.public.RouteTable.Row
-	(*GatewaysTable_Row)(nil),                         // 66: linkerd2.public.GatewaysTable.Row
-	(*GatewaysResponse_Ok)(nil),                       // 67: linkerd2.public.GatewaysResponse.Ok
-	(*duration.Duration)(nil),                         // 68: google.protobuf.Duration
-	(*healthcheck.SelfCheckRequest)(nil),              // 69: linkerd2.common.healthcheck.SelfCheckRequest
-	(*healthcheck.SelfCheckResponse)(nil),             // 70: linkerd2.common.healthcheck.SelfCheckResponse
-	(*config.All)(nil),                                // 71: linkerd2.config.All
+	(*LatencyPercentile)(nil),                         // 29: linkerd2.public.LatencyPercentile
+	(*TcpStats)(nil),                                  // 30: linkerd2.public.TcpStats
+	(*TrafficSplitStats)(nil),                         // 31: linkerd2.public.TrafficSplitStats
+	(*StatTable)(nil),                                 // 32: linkerd2.public.StatTable
+	(*EdgesRequest)(nil),                              // 33: linkerd2.public.EdgesRequest
+	(*EdgesResponse)(nil),                             // 34: linkerd2.public.EdgesResponse
+	(*Edge)(nil),                                      // 35: linkerd2.public.Edge
+	(*TopRoutesRequest)(nil),                          // 36: linkerd2.public.TopRoutesRequest
+	(*TopRoutesResponse)(nil),                         // 37: linkerd2.public.TopRoutesResponse
+	(*RouteTable)(nil),                                // 38: linkerd2.public.RouteTable
+	(*GatewaysTable)(nil),                             // 39: linkerd2.public.GatewaysTable
+	(*GatewaysRequest)(nil),                           // 40: linkerd2.public.GatewaysRequest
+	(*GatewaysResponse)(nil),                          // 41: linkerd2.public.GatewaysResponse
+	(*TapByResourceRequest_Match)(nil),                // 42: linkerd2.public.TapByResourceRequest.Match
+	(*TapByResourceRequest_Extract)(nil),              // 43: linkerd2.public.TapByResourceRequest.Extract
+	(*TapByResourceRequest_Filter)(nil),               // 44: linkerd2.public.TapByResourceRequest.Filter
+	(*TapByResourceRequest_Match_Seq)(nil),            // 45: linkerd2.public.TapByResourceRequest.Match.Seq
+	(*TapByResourceRequest_Match_Http)(nil),           // 46: linkerd2.public.TapByResourceRequest.Match.Http
+	(*TapByResourceRequest_Extract_Http)(nil),         // 47: linkerd2.public.TapByResourceRequest.Extract.Http
+	(*TapByResourceRequest_Extract_Http_Headers)(nil), // 48: linkerd2.public.TapByResourceRequest.Extract.Http.Headers
+	(*Headers_Header)(nil),                            // 49: linkerd2.public.Headers.Header
+	(*TapEvent_EndpointMeta)(nil),                     // 50: linkerd2.public.TapEvent.EndpointMeta
+	(*TapEvent_RouteMeta)(nil),                        // 51: linkerd2.public.TapEvent.RouteMeta
+	(*TapEvent_Http)(nil),                             // 52: linkerd2.public.TapEvent.Http
+	nil,                                               // 53: linkerd2.public.TapEvent.EndpointMeta.LabelsEntry
+	nil,                                               // 54: linkerd2.public.TapEvent.RouteMeta.LabelsEntry
+	(*TapEvent_Http_StreamId)(nil),                    // 55: linkerd2.public.TapEvent.Http.StreamId
+	(*TapEvent_Http_RequestInit)(nil),                 // 56: linkerd2.public.TapEvent.Http.RequestInit
+	(*TapEvent_Http_ResponseInit)(nil),                // 57: linkerd2.public.TapEvent.Http.ResponseInit
+	(*TapEvent_Http_ResponseEnd)(nil),                 // 58: linkerd2.public.TapEvent.Http.ResponseEnd
+	(*PodErrors_PodError)(nil),                        // 59: linkerd2.public.PodErrors.PodError
+	(*PodErrors_PodError_ContainerError)(nil),         // 60: linkerd2.public.PodErrors.PodError.ContainerError
+	(*StatSummaryResponse_Ok)(nil),                    // 61: linkerd2.public.StatSummaryResponse.Ok
+	(*StatTable_PodGroup)(nil),                        // 62: linkerd2.public.StatTable.PodGroup
+	(*StatTable_PodGroup_Row)(nil),                    // 63: linkerd2.public.StatTable.PodGroup.Row
+	nil,                                               // 64: linkerd2.public.StatTable.PodGroup.Row.ErrorsByPodEntry
+	(*EdgesResponse_Ok)(nil),                          // 65: linkerd2.public.EdgesResponse.Ok
+	(*TopRoutesResponse_Ok)(nil),                      // 66: linkerd2.public.TopRoutesResponse.Ok
+	(*RouteTable_Row)(nil),                            // 67: linkerd2.public.RouteTable.Row
+	(*GatewaysTable_Row)(nil),                         // 68: linkerd2.public.GatewaysTable.Row
+	(*GatewaysResponse_Ok)(nil),                       // 69: linkerd2.public.GatewaysResponse.Ok
+	(*duration.Duration)(nil),                         // 70: google.protobuf.Duration
+	(*timestamp.Timestamp)(nil),                       // 71: google.protobuf.Timestamp
+	(*healthcheck.SelfCheckRequest)(nil),              // 72: linkerd2.common.healthcheck.SelfCheckRequest
+	(*healthcheck.SelfCheckResponse)(nil),             // 73: linkerd2.common.healthcheck.SelfCheckResponse
+	(*config.All)(nil),                                // 74: linkerd2.config.All
 }
 var file_public_proto_depIdxs = []int32{
-	7,  // 0: linkerd2.public.ListServicesResponse.services:type_name -> linkerd2.public.Service
-	24, // 1: linkerd2.public.ListPodsRequest.selector:type_name -> linkerd2.public.ResourceSelection
-	10, // 2: linkerd2.public.ListPodsResponse.pods:type_name -> linkerd2.public.Pod
-	68, // 3: linkerd2.public.Pod.sinceLastReport:type_name -> google.protobuf.Duration
-	68, // 4: linkerd2.public.Pod.uptime:type_name -> google.protobuf.Duration
-	24, // 5: linkerd2.public.TapByResourceRequest.target:type_name -> linkerd2.public.ResourceSelection
-	41, // 6: linkerd2.public.TapByResourceRequest.match:type_name -> linkerd2.public.TapByResourceRequest.Match
-	42, // 7: linkerd2.public.TapByResourceRequest.extract:type_name -> linkerd2.public.TapByResourceRequest.Extract
-	0,  // 8: linkerd2.public.HttpMethod.registered:type_name -> linkerd2.public.HttpMethod.Registered
-	1,  // 9: linkerd2.public.Scheme.registered:type_name -> linkerd2.public.Scheme.Registered
-	47, // 10: linkerd2.public.Headers.headers:type_name -> linkerd2.public.Headers.Header
-	17, // 11: linkerd2.public.IPAddress.ipv6:type_name -> linkerd2.public.IPv6
-	16, // 12: linkerd2.public.TcpAddress.ip:type_name -> linkerd2.public.IPAddress
-	18, // 13: linkerd2.public.TapEvent.source:type_name -> linkerd2.public.TcpAddress
-	48, // 14: linkerd2.public.TapEvent.source_meta:type_name -> linkerd2.public.TapEvent.EndpointMeta
-	18, // 15: linkerd2.public.TapEvent.destination:type_name -> linkerd2.public.TcpAddress
-	48, // 16: linkerd2.public.TapEvent.destination_meta:type_name -> linkerd2.public.TapEvent.EndpointMeta
-	49, // 17: linkerd2.public.TapEvent.route_meta:type_name -> linkerd2.public.TapEvent.RouteMeta
-	2,  // 18: linkerd2.public.TapEvent.proxy_direction:type_name -> linkerd2.public.TapEvent.ProxyDirection
-	50, // 19: linkerd2.public.TapEvent.http:type_name -> linkerd2.public.TapEvent.Http
-	57, // 20: linkerd2.public.PodErrors.errors:type_name -> linkerd2.public.PodErrors.PodError
-	23, // 21: linkerd2.public.ResourceSelection.resource:type_name -> linkerd2.public.Resource
-	23, // 22: linkerd2.public.ResourceError.resource:type_name -> linkerd2.public.Resource
-	24, // 23: linkerd2.public.StatSummaryRequest.selector:type_name -> linkerd2.public.ResourceSelection
-	3,  // 24: linkerd2.public.StatSummaryRequest.none:type_name -> linkerd2.public.Empty
-	23, // 25: linkerd2.public.StatSummaryRequest.to_resource:type_name -> linkerd2.public.Resource
-	23, // 26: linkerd2.public.StatSummaryRequest.from_resource:type_name -> linkerd2.public.Resource
-	59, // 27: linkerd2.public.StatSummaryResponse.ok:type_name -> linkerd2.public.StatSummaryResponse.Ok
-	25, // 28: linkerd2.public.StatSummaryResponse.error:type_name -> linkerd2.public.ResourceError
-	60, // 29: linkerd2.public.StatTable.pod_group:type_name -> linkerd2.public.StatTable.PodGroup
-	24, // 30: linkerd2.public.EdgesRequest.selector:type_name -> linkerd2.public.ResourceSelection
-	63, // 31: linkerd2.public.EdgesResponse.ok:type_name -> linkerd2.public.EdgesResponse.Ok
-	25, // 32: linkerd2.public.EdgesResponse.error:type_name -> linkerd2.public.ResourceError
-	23, // 33: linkerd2.public.Edge.src:type_name -> linkerd2.public.Resource
-	23, // 34: linkerd2.public.Edge.dst:type_name -> linkerd2.public.Resource
-	24, // 35: linkerd2.public.TopRoutesRequest.selector:type_name -> linkerd2.public.ResourceSelection
-	3,  // 36: linkerd2.public.TopRoutesRequest.none:type_name -> linkerd2.public.Empty
-	23, // 37: linkerd2.public.TopRoutesRequest.to_resource:type_name -> linkerd2.public.Resource
-	25, // 38: linkerd2.public.TopRoutesResponse.error:type_name -> linkerd2.public.ResourceError
-	64, // 39: linkerd2.public.TopRoutesResponse.ok:type_name -> linkerd2.public.TopRoutesResponse.Ok
-	65, // 40: linkerd2.public.RouteTable.rows:type_name -> linkerd2.public.RouteTable.Row
-	66, // 41: linkerd2.public.GatewaysTable.rows:type_name -> linkerd2.public.GatewaysTable.Row
-	67, // 42: linkerd2.public.GatewaysResponse.ok:type_name -> linkerd2.public.GatewaysResponse.Ok
-	25, // 43: linkerd2.public.GatewaysResponse.error:type_name -> linkerd2.public.ResourceError
-	43, // 44: linkerd2.public.TapByResourceRequest.Match.all:type_name -> linkerd2.public.TapByResourceRequest.Match.Seq
-	43, // 45: linkerd2.public.TapByResourceRequest.Match.any:type_name -> linkerd2.public.TapByResourceRequest.Match.Seq
-	41, // 46: linkerd2.public.TapByResourceRequest.Match.not:type_name -> linkerd2.public.TapByResourceRequest.Match
-	24, // 47: linkerd2.public.TapByResourceRequest.Match.destinations:type_name -> linkerd2.public.ResourceSelection
-	44, // 48: linkerd2.public.TapByResourceRequest.Match.http:type_name -> linkerd2.public.TapByResourceRequest.Match.Http
-	45, // 49: linkerd2.public.TapByResourceRequest.Extract.http:type_name -> linkerd2.public.TapByResourceRequest.Extract.Http
-	41, // 50: linkerd2.public.TapByResourceRequest.Match.Seq.matches:type_name -> linkerd2.public.TapByResourceRequest.Match
-	46, // 51: linkerd2.public.TapByResourceRequest.Extract.Http.headers:type_name -> linkerd2.public.TapByResourceRequest.Extract.Http.Headers
-	51, // 52: linkerd2.public.TapEvent.EndpointMeta.labels:type_name -> linkerd2.public.TapEvent.EndpointMeta.LabelsEntry
-	52, // 53: linkerd2.public.TapEvent.RouteMeta.labels:type_name -> linkerd2.public.TapEvent.RouteMeta.LabelsEntry
-	54, // 54: linkerd2.public.TapEvent.Http.request_init:type_name -> linkerd2.public.TapEvent.Http.RequestInit
-	55, // 55: linkerd2.public.TapEvent.Http.response_init:type_name -> linkerd2.public.TapEvent.Http.ResponseInit
-	56, // 56: linkerd2.public.TapEvent.Http.response_end:type_name -> linkerd2.public.TapEvent.Http.ResponseEnd
-	53, // 57: linkerd2.public.TapEvent.Http.RequestInit.id:type_name -> linkerd2.public.TapEvent.Http.StreamId
-	13, // 58: linkerd2.public.TapEvent.Http.RequestInit.method:type_name -> linkerd2.public.HttpMethod
-	14, // 59: linkerd2.public.TapEvent.Http.RequestInit.scheme:type_name -> linkerd2.public.Scheme
-	15, // 60: linkerd2.public.TapEvent.Http.RequestInit.headers:type_name -> linkerd2.public.Headers
-	53, // 61: linkerd2.public.TapEvent.Http.ResponseInit.id:type_name -> linkerd2.public.TapEvent.Http.StreamId
-	68, // 62: linkerd2.public.TapEvent.Http.ResponseInit.since_request_init:type_name -> google.protobuf.Duration
-	15, // 63: linkerd2.public.TapEvent.Http.ResponseInit.headers:type_name -> linkerd2.public.Headers
-	53, // 64: linkerd2.public.TapEvent.Http.ResponseEnd.id:type_name -> linkerd2.public.TapEvent.Http.StreamId
-	68, // 65: linkerd2.public.TapEvent.Http.ResponseEnd.since_request_init:type_name -> google.protobuf.Duration
-	68, // 66: linkerd2.public.TapEvent.Http.ResponseEnd.since_response_init:type_name -> google.protobuf.Duration
-	19, // 67: linkerd2.public.TapEvent.Http.ResponseEnd.eos:type_name -> linkerd2.public.Eos
-	15, // 68: linkerd2.public.TapEvent.Http.ResponseEnd.trailers:type_name -> linkerd2.public.Headers
-	58, // 69: linkerd2.public.PodErrors.PodError.container:type_name -> linkerd2.public.PodErrors.PodError.ContainerError
-	31, // 70: linkerd2.public.StatSummaryResponse.Ok.stat_tables:type_name -> linkerd2.public.StatTable
-	61, // 71: linkerd2.public.StatTable.PodGroup.rows:type_name -> linkerd2.public.StatTable.PodGroup.Row
-	23, // 72: linkerd2.public.StatTable.PodGroup.Row.resource:type_name -> linkerd2.public.Resource
-	28, // 73: linkerd2.public.StatTable.PodGroup.Row.stats:type_name -> linkerd2.public.BasicStats
-	29, // 74: linkerd2.public.StatTable.PodGroup.Row.tcp_stats:type_name -> linkerd2.public.TcpStats
-	30, // 75: linkerd2.public.StatTable.PodGroup.Row.ts_stats:type_name -> linkerd2.public.TrafficSplitStats
-	62, // 76: linkerd2.public.StatTable.PodGroup.Row.errors_by_pod:type_name -> linkerd2.public.StatTable.PodGroup.Row.ErrorsByPodEntry
-	22, // 77: linkerd2.public.StatTable.PodGroup.Row.ErrorsByPodEntry.value:type_name -> linkerd2.public.PodErrors
-	34, // 78: linkerd2.public.EdgesResponse.Ok.edges:type_name -> linkerd2.public.Edge
-	37, // 79: linkerd2.public.TopRoutesResponse.Ok.routes:type_name -> linkerd2.public.RouteTable
-	28, // 80: linkerd2.public.RouteTable.Row.stats:type_name -> linkerd2.public.BasicStats
-	38, // 81: linkerd2.public.GatewaysResponse.Ok.gateways_table:type_name -> linkerd2.public.GatewaysTable
-	26, // 82: linkerd2.public.Api.StatSummary:input_type -> linkerd2.public.StatSummaryRequest
-	32, // 83: linkerd2.public.Api.Edges:input_type -> linkerd2.public.EdgesRequest
-	39, // 84: linkerd2.public.Api.Gateways:input_type -> linkerd2.public.GatewaysRequest
-	35, // 85: linkerd2.public.Api.TopRoutes:input_type -> linkerd2.public.TopRoutesRequest
-	8,  // 86: linkerd2.public.Api.ListPods:input_type -> linkerd2.public.ListPodsRequest
-	5,  // 87: linkerd2.public.Api.ListServices:input_type -> linkerd2.public.ListServicesRequest
-	11, // 88: linkerd2.public.Api.Tap:input_type -> linkerd2.public.TapRequest
-	12, // 89: linkerd2.public.Api.TapByResource:input_type -> linkerd2.public.TapByResourceRequest
-	3,  // 90: linkerd2.public.Api.Version:input_type -> linkerd2.public.Empty
-	69, // 91: linkerd2.public.Api.SelfCheck:input_type -> linkerd2.common.healthcheck.SelfCheckRequest
-	3,  // 92: linkerd2.public.Api.Config:input_type -> linkerd2.public.Empty
-	27, // 93: linkerd2.public.Api.StatSummary:output_type -> linkerd2.public.StatSummaryResponse
-	33, // 94: linkerd2.public.Api.Edges:output_type -> linkerd2.public.EdgesResponse
-	40, // 95: linkerd2.public.Api.Gateways:output_type -> linkerd2.public.GatewaysResponse
-	36, // 96: linkerd2.public.Api.TopRoutes:output_type -> linkerd2.public.TopRoutesResponse
-	9,  // 97: linkerd2.public.Api.ListPods:output_type -> linkerd2.public.ListPodsResponse
-	6,  // 98: linkerd2.public.Api.ListServices:output_type -> linkerd2.public.ListServicesResponse
-	20, // 99: linkerd2.public.Api.Tap:output_type -> linkerd2.public.TapEvent
-	20, // 100: linkerd2.public.Api.TapByResource:output_type -> linkerd2.public.TapEvent
-	4,  // 101: linkerd2.public.Api.Version:output_type -> linkerd2.public.VersionInfo
-	70, // 102: linkerd2.public.Api.SelfCheck:output_type -> linkerd2.common.healthcheck.SelfCheckResponse
-	71, // 103: linkerd2.public.Api.Config:output_type -> linkerd2.config.All
-	93, // [93:104] is the sub-list for method output_type
-	82, // [82:93] is the sub-list for method input_type
-	82, // [82:82] is the sub-list for extension type_name
-	82, // [82:82] is the sub-list for extension extendee
-	0,  // [0:82] is the sub-list for field type_name
+	7,   // 0: linkerd2.public.ListServicesResponse.services:type_name -> linkerd2.public.Service
+	24,  // 1: linkerd2.public.ListPodsRequest.selector:type_name -> linkerd2.public.ResourceSelection
+	10,  // 2: linkerd2.public.ListPodsResponse.pods:type_name -> linkerd2.public.Pod
+	70,  // 3: linkerd2.public.Pod.sinceLastReport:type_name -> google.protobuf.Duration
+	70,  // 4: linkerd2.public.Pod.uptime:type_name -> google.protobuf.Duration
+	24,  // 5: linkerd2.public.TapByResourceRequest.target:type_name -> linkerd2.public.ResourceSelection
+	42,  // 6: linkerd2.public.TapByResourceRequest.match:type_name -> linkerd2.public.TapByResourceRequest.Match
+	43,  // 7: linkerd2.public.TapByResourceRequest.extract:type_name -> linkerd2.public.TapByResourceRequest.Extract
+	44,  // 8: linkerd2.public.TapByResourceRequest.filter:type_name -> linkerd2.public.TapByResourceRequest.Filter
+	0,   // 9: linkerd2.public.HttpMethod.registered:type_name -> linkerd2.public.HttpMethod.Registered
+	1,   // 10: linkerd2.public.Scheme.registered:type_name -> linkerd2.public.Scheme.Registered
+	49,  // 11: linkerd2.public.Headers.headers:type_name -> linkerd2.public.Headers.Header
+	17,  // 12: linkerd2.public.IPAddress.ipv6:type_name -> linkerd2.public.IPv6
+	16,  // 13: linkerd2.public.TcpAddress.ip:type_name -> linkerd2.public.IPAddress
+	18,  // 14: linkerd2.public.TapEvent.source:type_name -> linkerd2.public.TcpAddress
+	50,  // 15: linkerd2.public.TapEvent.source_meta:type_name -> linkerd2.public.TapEvent.EndpointMeta
+	18,  // 16: linkerd2.public.TapEvent.destination:type_name -> linkerd2.public.TcpAddress
+	50,  // 17: linkerd2.public.TapEvent.destination_meta:type_name -> linkerd2.public.TapEvent.EndpointMeta
+	51,  // 18: linkerd2.public.TapEvent.route_meta:type_name -> linkerd2.public.TapEvent.RouteMeta
+	2,   // 19: linkerd2.public.TapEvent.proxy_direction:type_name -> linkerd2.public.TapEvent.ProxyDirection
+	52,  // 20: linkerd2.public.TapEvent.http:type_name -> linkerd2.public.TapEvent.Http
+	59,  // 21: linkerd2.public.PodErrors.errors:type_name -> linkerd2.public.PodErrors.PodError
+	23,  // 22: linkerd2.public.ResourceSelection.resource:type_name -> linkerd2.public.Resource
+	23,  // 23: linkerd2.public.ResourceError.resource:type_name -> linkerd2.public.Resource
+	24,  // 24: linkerd2.public.StatSummaryRequest.selector:type_name -> linkerd2.public.ResourceSelection
+	3,   // 25: linkerd2.public.StatSummaryRequest.none:type_name -> linkerd2.public.Empty
+	23,  // 26: linkerd2.public.StatSummaryRequest.to_resource:type_name -> linkerd2.public.Resource
+	23,  // 27: linkerd2.public.StatSummaryRequest.from_resource:type_name -> linkerd2.public.Resource
+	71,  // 28: linkerd2.public.StatSummaryRequest.end_time:type_name -> google.protobuf.Timestamp
+	70,  // 29: linkerd2.public.StatSummaryRequest.step:type_name -> google.protobuf.Duration
+	61,  // 30: linkerd2.public.StatSummaryResponse.ok:type_name -> linkerd2.public.StatSummaryResponse.Ok
+	25,  // 31: linkerd2.public.StatSummaryResponse.error:type_name -> linkerd2.public.ResourceError
+	29,  // 32: linkerd2.public.BasicStats.latency_ms_percentiles:type_name -> linkerd2.public.LatencyPercentile
+	62,  // 33: linkerd2.public.StatTable.pod_group:type_name -> linkerd2.public.StatTable.PodGroup
+	24,  // 34: linkerd2.public.EdgesRequest.selector:type_name -> linkerd2.public.ResourceSelection
+	65,  // 35: linkerd2.public.EdgesResponse.ok:type_name -> linkerd2.public.EdgesResponse.Ok
+	25,  // 36: linkerd2.public.EdgesResponse.error:type_name -> linkerd2.public.ResourceError
+	23,  // 37: linkerd2.public.Edge.src:type_name -> linkerd2.public.Resource
+	23,  // 38: linkerd2.public.Edge.dst:type_name -> linkerd2.public.Resource
+	30,  // 39: linkerd2.public.Edge.tcp_stats:type_name -> linkerd2.public.TcpStats
+	24,  // 40: linkerd2.public.TopRoutesRequest.selector:type_name -> linkerd2.public.ResourceSelection
+	3,   // 41: linkerd2.public.TopRoutesRequest.none:type_name -> linkerd2.public.Empty
+	23,  // 42: linkerd2.public.TopRoutesRequest.to_resource:type_name -> linkerd2.public.Resource
+	71,  // 43: linkerd2.public.TopRoutesRequest.end_time:type_name -> google.protobuf.Timestamp
+	70,  // 44: linkerd2.public.TopRoutesRequest.step:type_name -> google.protobuf.Duration
+	25,  // 45: linkerd2.public.TopRoutesResponse.error:type_name -> linkerd2.public.ResourceError
+	66,  // 46: linkerd2.public.TopRoutesResponse.ok:type_name -> linkerd2.public.TopRoutesResponse.Ok
+	67,  // 47: linkerd2.public.RouteTable.rows:type_name -> linkerd2.public.RouteTable.Row
+	68,  // 48: linkerd2.public.GatewaysTable.rows:type_name -> linkerd2.public.GatewaysTable.Row
+	69,  // 49: linkerd2.public.GatewaysResponse.ok:type_name -> linkerd2.public.GatewaysResponse.Ok
+	25,  // 50: linkerd2.public.GatewaysResponse.error:type_name -> linkerd2.public.ResourceError
+	45,  // 51: linkerd2.public.TapByResourceRequest.Match.all:type_name -> linkerd2.public.TapByResourceRequest.Match.Seq
+	45,  // 52: linkerd2.public.TapByResourceRequest.Match.any:type_name -> linkerd2.public.TapByResourceRequest.Match.Seq
+	42,  // 53: linkerd2.public.TapByResourceRequest.Match.not:type_name -> linkerd2.public.TapByResourceRequest.Match
+	24,  // 54: linkerd2.public.TapByResourceRequest.Match.destinations:type_name -> linkerd2.public.ResourceSelection
+	46,  // 55: linkerd2.public.TapByResourceRequest.Match.http:type_name -> linkerd2.public.TapByResourceRequest.Match.Http
+	47,  // 56: linkerd2.public.TapByResourceRequest.Extract.http:type_name -> linkerd2.public.TapByResourceRequest.Extract.Http
+	70,  // 57: linkerd2.public.TapByResourceRequest.Filter.minLatency:type_name -> google.protobuf.Duration
+	42,  // 58: linkerd2.public.TapByResourceRequest.Match.Seq.matches:type_name -> linkerd2.public.TapByResourceRequest.Match
+	48,  // 59: linkerd2.public.TapByResourceRequest.Extract.Http.headers:type_name -> linkerd2.public.TapByResourceRequest.Extract.Http.Headers
+	53,  // 60: linkerd2.public.TapEvent.EndpointMeta.labels:type_name -> linkerd2.public.TapEvent.EndpointMeta.LabelsEntry
+	54,  // 61: linkerd2.public.TapEvent.RouteMeta.labels:type_name -> linkerd2.public.TapEvent.RouteMeta.LabelsEntry
+	56,  // 62: linkerd2.public.TapEvent.Http.request_init:type_name -> linkerd2.public.TapEvent.Http.RequestInit
+	57,  // 63: linkerd2.public.TapEvent.Http.response_init:type_name -> linkerd2.public.TapEvent.Http.ResponseInit
+	58,  // 64: linkerd2.public.TapEvent.Http.response_end:type_name -> linkerd2.public.TapEvent.Http.ResponseEnd
+	55,  // 65: linkerd2.public.TapEvent.Http.RequestInit.id:type_name -> linkerd2.public.TapEvent.Http.StreamId
+	13,  // 66: linkerd2.public.TapEvent.Http.RequestInit.method:type_name -> linkerd2.public.HttpMethod
+	14,  // 67: linkerd2.public.TapEvent.Http.RequestInit.scheme:type_name -> linkerd2.public.Scheme
+	15,  // 68: linkerd2.public.TapEvent.Http.RequestInit.headers:type_name -> linkerd2.public.Headers
+	55,  // 69: linkerd2.public.TapEvent.Http.ResponseInit.id:type_name -> linkerd2.public.TapEvent.Http.StreamId
+	70,  // 70: linkerd2.public.TapEvent.Http.ResponseInit.since_request_init:type_name -> google.protobuf.Duration
+	15,  // 71: linkerd2.public.TapEvent.Http.ResponseInit.headers:type_name -> linkerd2.public.Headers
+	55,  // 72: linkerd2.public.TapEvent.Http.ResponseEnd.id:type_name -> linkerd2.public.TapEvent.Http.StreamId
+	70,  // 73: linkerd2.public.TapEvent.Http.ResponseEnd.since_request_init:type_name -> google.protobuf.Duration
+	70,  // 74: linkerd2.public.TapEvent.Http.ResponseEnd.since_response_init:type_name -> google.protobuf.Duration
+	19,  // 75: linkerd2.public.TapEvent.Http.ResponseEnd.eos:type_name -> linkerd2.public.Eos
+	15,  // 76: linkerd2.public.TapEvent.Http.ResponseEnd.trailers:type_name -> linkerd2.public.Headers
+	60,  // 77: linkerd2.public.PodErrors.PodError.container:type_name -> linkerd2.public.PodErrors.PodError.ContainerError
+	32,  // 78: linkerd2.public.StatSummaryResponse.Ok.stat_tables:type_name -> linkerd2.public.StatTable
+	63,  // 79: linkerd2.public.StatTable.PodGroup.rows:type_name -> linkerd2.public.StatTable.PodGroup.Row
+	23,  // 80: linkerd2.public.StatTable.PodGroup.Row.resource:type_name -> linkerd2.public.Resource
+	28,  // 81: linkerd2.public.StatTable.PodGroup.Row.stats:type_name -> linkerd2.public.BasicStats
+	30,  // 82: linkerd2.public.StatTable.PodGroup.Row.tcp_stats:type_name -> linkerd2.public.TcpStats
+	31,  // 83: linkerd2.public.StatTable.PodGroup.Row.ts_stats:type_name -> linkerd2.public.TrafficSplitStats
+	64,  // 84: linkerd2.public.StatTable.PodGroup.Row.errors_by_pod:type_name -> linkerd2.public.StatTable.PodGroup.Row.ErrorsByPodEntry
+	22,  // 85: linkerd2.public.StatTable.PodGroup.Row.ErrorsByPodEntry.value:type_name -> linkerd2.public.PodErrors
+	35,  // 86: linkerd2.public.EdgesResponse.Ok.edges:type_name -> linkerd2.public.Edge
+	38,  // 87: linkerd2.public.TopRoutesResponse.Ok.routes:type_name -> linkerd2.public.RouteTable
+	28,  // 88: linkerd2.public.RouteTable.Row.stats:type_name -> linkerd2.public.BasicStats
+	39,  // 89: linkerd2.public.GatewaysResponse.Ok.gateways_table:type_name -> linkerd2.public.GatewaysTable
+	26,  // 90: linkerd2.public.Api.StatSummary:input_type -> linkerd2.public.StatSummaryRequest
+	33,  // 91: linkerd2.public.Api.Edges:input_type -> linkerd2.public.EdgesRequest
+	40,  // 92: linkerd2.public.Api.Gateways:input_type -> linkerd2.public.GatewaysRequest
+	36,  // 93: linkerd2.public.Api.TopRoutes:input_type -> linkerd2.public.TopRoutesRequest
+	8,   // 94: linkerd2.public.Api.ListPods:input_type -> linkerd2.public.ListPodsRequest
+	5,   // 95: linkerd2.public.Api.ListServices:input_type -> linkerd2.public.ListServicesRequest
+	11,  // 96: linkerd2.public.Api.Tap:input_type -> linkerd2.public.TapRequest
+	12,  // 97: linkerd2.public.Api.TapByResource:input_type -> linkerd2.public.TapByResourceRequest
+	3,   // 98: linkerd2.public.Api.Version:input_type -> linkerd2.public.Empty
+	72,  // 99: linkerd2.public.Api.SelfCheck:input_type -> linkerd2.common.healthcheck.SelfCheckRequest
+	3,   // 100: linkerd2.public.Api.Config:input_type -> linkerd2.public.Empty
+	27,  // 101: linkerd2.public.Api.StatSummary:output_type -> linkerd2.public.StatSummaryResponse
+	34,  // 102: linkerd2.public.Api.Edges:output_type -> linkerd2.public.EdgesResponse
+	41,  // 103: linkerd2.public.Api.Gateways:output_type -> linkerd2.public.GatewaysResponse
+	37,  // 104: linkerd2.public.Api.TopRoutes:output_type -> linkerd2.public.TopRoutesResponse
+	9,   // 105: linkerd2.public.Api.ListPods:output_type -> linkerd2.public.ListPodsResponse
+	6,   // 106: linkerd2.public.Api.ListServices:output_type -> linkerd2.public.ListServicesResponse
+	20,  // 107: linkerd2.public.Api.Tap:output_type -> linkerd2.public.TapEvent
+	20,  // 108: linkerd2.public.Api.TapByResource:output_type -> linkerd2.public.TapEvent
+	4,   // 109: linkerd2.public.Api.Version:output_type -> linkerd2.public.VersionInfo
+	73,  // 110: linkerd2.public.Api.SelfCheck:output_type -> linkerd2.common.healthcheck.SelfCheckResponse
+	74,  // 111: linkerd2.public.Api.Config:output_type -> linkerd2.config.All
+	101, // [101:112] is the sub-list for method output_type
+	90,  // [90:101] is the sub-list for method input_type
+	90,  // [90:90] is the sub-list for extension type_name
+	90,  // [90:90] is the sub-list for extension extendee
+	0,   // [0:90] is the sub-list for field type_name
 }
 
 func init() { file_public_proto_init() }
@@ -5912,7 +6235,7 @@ func file_public_proto_init() {
 			}
 		}
 		file_public_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TcpStats); i {
+			switch v := v.(*LatencyPercentile); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -5924,7 +6247,7 @@ func file_public_proto_init() {
 			}
 		}
 		file_public_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TrafficSplitStats); i {
+			switch v := v.(*TcpStats); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -5936,7 +6259,7 @@ func file_public_proto_init() {
 			}
 		}
 		file_public_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StatTable); i {
+			switch v := v.(*TrafficSplitStats); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -5948,7 +6271,7 @@ func file_public_proto_init() {
 			}
 		}
 		file_public_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*EdgesRequest); i {
+			switch v := v.(*StatTable); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -5960,7 +6283,7 @@ func file_public_proto_init() {
 			}
 		}
 		file_public_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*EdgesResponse); i {
+			switch v := v.(*EdgesRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -5972,7 +6295,7 @@ func file_public_proto_init() {
 			}
 		}
 		file_public_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Edge); i {
+			switch v := v.(*EdgesResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -5984,7 +6307,7 @@ func file_public_proto_init() {
 			}
 		}
 		file_public_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TopRoutesRequest); i {
+			switch v := v.(*Edge); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -5996,7 +6319,7 @@ func file_public_proto_init() {
 			}
 		}
 		file_public_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TopRoutesResponse); i {
+			switch v := v.(*TopRoutesRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -6008,7 +6331,7 @@ func file_public_proto_init() {
 			}
 		}
 		file_public_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RouteTable); i {
+			switch v := v.(*TopRoutesResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -6020,7 +6343,7 @@ func file_public_proto_init() {
 			}
 		}
 		file_public_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GatewaysTable); i {
+			switch v := v.(*RouteTable); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -6032,7 +6355,7 @@ func file_public_proto_init() {
 			}
 		}
 		file_public_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GatewaysRequest); i {
+			switch v := v.(*GatewaysTable); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -6044,7 +6367,7 @@ func file_public_proto_init() {
 			}
 		}
 		file_public_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GatewaysResponse); i {
+			switch v := v.(*GatewaysRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -6056,7 +6379,7 @@ func file_public_proto_init() {
 			}
 		}
 		file_public_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TapByResourceRequest_Match); i {
+			switch v := v.(*GatewaysResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -6068,7 +6391,7 @@ func file_public_proto_init() {
 			}
 		}
 		file_public_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TapByResourceRequest_Extract); i {
+			switch v := v.(*TapByResourceRequest_Match); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -6080,7 +6403,7 @@ func file_public_proto_init() {
 			}
 		}
 		file_public_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TapByResourceRequest_Match_Seq); i {
+			switch v := v.(*TapByResourceRequest_Extract); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -6092,7 +6415,7 @@ func file_public_proto_init() {
 			}
 		}
 		file_public_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TapByResourceRequest_Match_Http); i {
+			switch v := v.(*TapByResourceRequest_Filter); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -6104,7 +6427,7 @@ func file_public_proto_init() {
 			}
 		}
 		file_public_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TapByResourceRequest_Extract_Http); i {
+			switch v := v.(*TapByResourceRequest_Match_Seq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -6116,7 +6439,7 @@ func file_public_proto_init() {
 			}
 		}
 		file_public_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TapByResourceRequest_Extract_Http_Headers); i {
+			switch v := v.(*TapByResourceRequest_Match_Http); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -6128,7 +6451,7 @@ func file_public_proto_init() {
 			}
 		}
 		file_public_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Headers_Header); i {
+			switch v := v.(*TapByResourceRequest_Extract_Http); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -6140,7 +6463,7 @@ func file_public_proto_init() {
 			}
 		}
 		file_public_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TapEvent_EndpointMeta); i {
+			switch v := v.(*TapByResourceRequest_Extract_Http_Headers); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -6152,7 +6475,7 @@ func file_public_proto_init() {
 			}
 		}
 		file_public_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TapEvent_RouteMeta); i {
+			switch v := v.(*Headers_Header); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -6164,6 +6487,30 @@ func file_public_proto_init() {
 			}
 		}
 		file_public_proto_msgTypes[47].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TapEvent_EndpointMeta); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_public_proto_msgTypes[48].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TapEvent_RouteMeta); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_public_proto_msgTypes[49].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*TapEvent_Http); i {
 			case 0:
 				return &v.state
@@ -6175,7 +6522,7 @@ func file_public_proto_init() {
 				return nil
 			}
 		}
-		file_public_proto_msgTypes[50].Exporter = func(v interface{}, i int) interface{} {
+		file_public_proto_msgTypes[52].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*TapEvent_Http_StreamId); i {
 			case 0:
 				return &v.state
@@ -6187,7 +6534,7 @@ func file_public_proto_init() {
 				return nil
 			}
 		}
-		file_public_proto_msgTypes[51].Exporter = func(v interface{}, i int) interface{} {
+		file_public_proto_msgTypes[53].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*TapEvent_Http_RequestInit); i {
 			case 0:
 				return &v.state
@@ -6199,7 +6546,7 @@ func file_public_proto_init() {
 				return nil
 			}
 		}
-		file_public_proto_msgTypes[52].Exporter = func(v interface{}, i int) interface{} {
+		file_public_proto_msgTypes[54].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*TapEvent_Http_ResponseInit); i {
 			case 0:
 				return &v.state
@@ -6211,7 +6558,7 @@ func file_public_proto_init() {
 				return nil
 			}
 		}
-		file_public_proto_msgTypes[53].Exporter = func(v interface{}, i int) interface{} {
+		file_public_proto_msgTypes[55].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*TapEvent_Http_ResponseEnd); i {
 			case 0:
 				return &v.state
@@ -6223,7 +6570,7 @@ func file_public_proto_init() {
 				return nil
 			}
 		}
-		file_public_proto_msgTypes[54].Exporter = func(v interface{}, i int) interface{} {
+		file_public_proto_msgTypes[56].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*PodErrors_PodError); i {
 			case 0:
 				return &v.state
@@ -6235,7 +6582,7 @@ func file_public_proto_init() {
 				return nil
 			}
 		}
-		file_public_proto_msgTypes[55].Exporter = func(v interface{}, i int) interface{} {
+		file_public_proto_msgTypes[57].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*PodErrors_PodError_ContainerError); i {
 			case 0:
 				return &v.state
@@ -6247,7 +6594,7 @@ func file_public_proto_init() {
 				return nil
 			}
 		}
-		file_public_proto_msgTypes[56].Exporter = func(v interface{}, i int) interface{} {
+		file_public_proto_msgTypes[58].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*StatSummaryResponse_Ok); i {
 			case 0:
 				return &v.state
@@ -6259,7 +6606,7 @@ func file_public_proto_init() {
 				return nil
 			}
 		}
-		file_public_proto_msgTypes[57].Exporter = func(v interface{}, i int) interface{} {
+		file_public_proto_msgTypes[59].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*StatTable_PodGroup); i {
 			case 0:
 				return &v.state
@@ -6271,7 +6618,7 @@ func file_public_proto_init() {
 				return nil
 			}
 		}
-		file_public_proto_msgTypes[58].Exporter = func(v interface{}, i int) interface{} {
+		file_public_proto_msgTypes[60].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*StatTable_PodGroup_Row); i {
 			case 0:
 				return &v.state
@@ -6283,7 +6630,7 @@ func file_public_proto_init() {
 				return nil
 			}
 		}
-		file_public_proto_msgTypes[60].Exporter = func(v interface{}, i int) interface{} {
+		file_public_proto_msgTypes[62].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*EdgesResponse_Ok); i {
 			case 0:
 				return &v.state
@@ -6295,7 +6642,7 @@ func file_public_proto_init() {
 				return nil
 			}
 		}
-		file_public_proto_msgTypes[61].Exporter = func(v interface{}, i int) interface{} {
+		file_public_proto_msgTypes[63].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*TopRoutesResponse_Ok); i {
 			case 0:
 				return &v.state
@@ -6307,7 +6654,7 @@ func file_public_proto_init() {
 				return nil
 			}
 		}
-		file_public_proto_msgTypes[62].Exporter = func(v interface{}, i int) interface{} {
+		file_public_proto_msgTypes[64].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*RouteTable_Row); i {
 			case 0:
 				return &v.state
@@ -6319,7 +6666,7 @@ func file_public_proto_init() {
 				return nil
 			}
 		}
-		file_public_proto_msgTypes[63].Exporter = func(v interface{}, i int) interface{} {
+		file_public_proto_msgTypes[65].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GatewaysTable_Row); i {
 			case 0:
 				return &v.state
@@ -6331,7 +6678,7 @@ func file_public_proto_init() {
 				return nil
 			}
 		}
-		file_public_proto_msgTypes[64].Exporter = func(v interface{}, i int) interface{} {
+		file_public_proto_msgTypes[66].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GatewaysResponse_Ok); i {
 			case 0:
 				return &v.state
@@ -6384,54 +6731,55 @@ func file_public_proto_init() {
 		(*StatSummaryResponse_Ok_)(nil),
 		(*StatSummaryResponse_Error)(nil),
 	}
-	file_public_proto_msgTypes[28].OneofWrappers = []interface{}{
+	file_public_proto_msgTypes[29].OneofWrappers = []interface{}{
 		(*StatTable_PodGroup_)(nil),
 	}
-	file_public_proto_msgTypes[30].OneofWrappers = []interface{}{
+	file_public_proto_msgTypes[31].OneofWrappers = []interface{}{
 		(*EdgesResponse_Ok_)(nil),
 		(*EdgesResponse_Error)(nil),
 	}
-	file_public_proto_msgTypes[32].OneofWrappers = []interface{}{
+	file_public_proto_msgTypes[33].OneofWrappers = []interface{}{
 		(*TopRoutesRequest_None)(nil),
 		(*TopRoutesRequest_ToResource)(nil),
 	}
-	file_public_proto_msgTypes[33].OneofWrappers = []interface{}{
+	file_public_proto_msgTypes[34].OneofWrappers = []interface{}{
 		(*TopRoutesResponse_Error)(nil),
 		(*TopRoutesResponse_Ok_)(nil),
 	}
-	file_public_proto_msgTypes[37].OneofWrappers = []interface{}{
+	file_public_proto_msgTypes[38].OneofWrappers = []interface{}{
 		(*GatewaysResponse_Ok_)(nil),
 		(*GatewaysResponse_Error)(nil),
 	}
-	file_public_proto_msgTypes[38].OneofWrappers = []interface{}{
+	file_public_proto_msgTypes[39].OneofWrappers = []interface{}{
 		(*TapByResourceRequest_Match_All)(nil),
 		(*TapByResourceRequest_Match_Any)(nil),
 		(*TapByResourceRequest_Match_Not)(nil),
 		(*TapByResourceRequest_Match_Destinations)(nil),
 		(*TapByResourceRequest_Match_Http_)(nil),
 	}
-	file_public_proto_msgTypes[39].OneofWrappers = []interface{}{
+	file_public_proto_msgTypes[40].OneofWrappers = []interface{}{
 		(*TapByResourceRequest_Extract_Http_)(nil),
 	}
-	file_public_proto_msgTypes[41].OneofWrappers = []interface{}{
+	file_public_proto_msgTypes[43].OneofWrappers = []interface{}{
 		(*TapByResourceRequest_Match_Http_Scheme)(nil),
 		(*TapByResourceRequest_Match_Http_Method)(nil),
 		(*TapByResourceRequest_Match_Http_Authority)(nil),
 		(*TapByResourceRequest_Match_Http_Path)(nil),
+		(*TapByResourceRequest_Match_Http_GrpcMethod)(nil),
 	}
-	file_public_proto_msgTypes[42].OneofWrappers = []interface{}{
+	file_public_proto_msgTypes[44].OneofWrappers = []interface{}{
 		(*TapByResourceRequest_Extract_Http_Headers_)(nil),
 	}
-	file_public_proto_msgTypes[44].OneofWrappers = []interface{}{
+	file_public_proto_msgTypes[46].OneofWrappers = []interface{}{
 		(*Headers_Header_ValueStr)(nil),
 		(*Headers_Header_ValueBin)(nil),
 	}
-	file_public_proto_msgTypes[47].OneofWrappers = []interface{}{
+	file_public_proto_msgTypes[49].OneofWrappers = []interface{}{
 		(*TapEvent_Http_RequestInit_)(nil),
 		(*TapEvent_Http_ResponseInit_)(nil),
 		(*TapEvent_Http_ResponseEnd_)(nil),
 	}
-	file_public_proto_msgTypes[54].OneofWrappers = []interface{}{
+	file_public_proto_msgTypes[56].OneofWrappers = []interface{}{
 		(*PodErrors_PodError_Container)(nil),
 	}
 	type x struct{}
@@ -6440,7 +6788,7 @@ func file_public_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_public_proto_rawDesc,
 			NumEnums:      3,
-			NumMessages:   65,
+			NumMessages:   67,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -0,0 +1,159 @@
+package healthexporter
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/linkerd/linkerd2/pkg/healthcheck"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	labelCategory = "category"
+	labelCheck    = "check"
+	labelWarning  = "warning"
+
+	// eventReasonCheckFailed is the reason of the events recorded when a check
+	// starts failing
+	eventReasonCheckFailed = "CheckFailed"
+
+	// eventReasonCheckRecovered is the reason of the events recorded when a
+	// failing check passes again
+	eventReasonCheckRecovered = "CheckRecovered"
+)
+
+var (
+	checkPassing = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "health_check_passing",
+		Help: "A gauge which is 1 if the check passed during the last run and 0 if it failed.",
+	}, []string{labelCategory, labelCheck, labelWarning})
+
+	runTimestamp = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "health_check_last_run_timestamp_seconds",
+		Help: "The time the last run of the checks completed, in seconds since the epoch.",
+	})
+
+	runDuration = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "health_check_last_run_duration_seconds",
+		Help: "The time the last run of the checks took, in seconds.",
+	})
+
+	runSuccess = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "health_check_last_run_success",
+		Help: "A gauge which is 1 if all the checks but the warnings passed during the last run and 0 otherwise.",
+	})
+)
+
+type checkKey struct {
+	category    healthcheck.CategoryID
+	description string
+}
+
+// Exporter runs the health checks on an interval. It exposes their results as
+// Prometheus metrics, and records an event when a check starts failing and
+// when it recovers.
+type Exporter struct {
+	categories  []healthcheck.CategoryID
+	options     *healthcheck.Options
+	recordEvent func(eventType, reason, message string)
+
+	// failing holds the checks that failed during the last run
+	failing map[checkKey]bool
+}
+
+// NewExporter returns an Exporter running the checks of categories
+func NewExporter(
+	categories []healthcheck.CategoryID,
+	options *healthcheck.Options,
+	recordEvent func(eventType, reason, message string),
+) *Exporter {
+	return &Exporter{
+		categories:  categories,
+		options:     options,
+		recordEvent: recordEvent,
+		failing:     map[checkKey]bool{},
+	}
+}
+
+// Run runs the checks right away and then every interval, until stop is
+// closed
+func (e *Exporter) Run(interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		e.runChecks()
+
+		select {
+		case <-ticker.C:
+		case <-stop:
+			return
+		}
+	}
+}
+
+func (e *Exporter) runChecks() {
+	start := time.Now()
+
+	// a new HealthChecker is needed for each run, as the checks cache the
+	// resources they fetch
+	hc := healthcheck.NewHealthChecker(e.categories, e.options)
+	var results []*healthcheck.CheckResult
+	success := hc.RunChecks(func(result *healthcheck.CheckResult) {
+		// retried checks report their intermediate results too
+		if !result.Retry {
+			results = append(results, result)
+		}
+	})
+
+	// the checks which didn't run, for instance because a fatal check failed
+	// before them, aren't reported anymore
+	checkPassing.Reset()
+	failing := map[checkKey]bool{}
+	for _, result := range results {
+		passing := 1.0
+		if result.Err != nil {
+			passing = 0
+		}
+		checkPassing.With(prometheus.Labels{
+			labelCategory: string(result.Category),
+			labelCheck:    result.Description,
+			labelWarning:  strconv.FormatBool(result.Warning),
+		}).Set(passing)
+
+		key := checkKey{result.Category, result.Description}
+		switch {
+		case result.Err != nil:
+			failing[key] = true
+			if !e.failing[key] {
+				e.checkFailed(result)
+			}
+		case e.failing[key]:
+			e.recordEvent(corev1.EventTypeNormal, eventReasonCheckRecovered,
+				fmt.Sprintf("[%s] %s: check passes again", result.Category, result.Description))
+		}
+	}
+	e.failing = failing
+
+	if success {
+		runSuccess.Set(1)
+	} else {
+		runSuccess.Set(0)
+	}
+	runDuration.Set(time.Since(start).Seconds())
+	runTimestamp.Set(float64(time.Now().Unix()))
+	log.Debugf("ran %d checks in %s, success: %t", len(results), time.Since(start), success)
+}
+
+func (e *Exporter) checkFailed(result *healthcheck.CheckResult) {
+	message := fmt.Sprintf("[%s] %s: %s", result.Category, result.Description, result.Err)
+	if result.HintAnchor != "" {
+		message = fmt.Sprintf("%s (see %s%s for hints)", message, healthcheck.HintBaseURL, result.HintAnchor)
+	}
+	log.Warn(message)
+	e.recordEvent(corev1.EventTypeWarning, eventReasonCheckFailed, message)
+}
@@ -0,0 +1,109 @@
+package healthexporter
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/linkerd/linkerd2/pkg/healthcheck"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+type event struct {
+	eventType string
+	reason    string
+	message   string
+}
+
+func TestExporter(t *testing.T) {
+	var brokenErr error
+	healthcheck.RegisterCategory("health-exporter-test", []healthcheck.Checker{
+		{
+			Description: "always passes",
+			Check: func(context.Context, *healthcheck.HealthChecker) error {
+				return nil
+			},
+		},
+		{
+			Description: "sometimes fails",
+			HintAnchor:  "l5d-sometimes",
+			Check: func(context.Context, *healthcheck.HealthChecker) error {
+				return brokenErr
+			},
+		},
+	})
+
+	var events []event
+	exporter := NewExporter(
+		[]healthcheck.CategoryID{"health-exporter-test"},
+		&healthcheck.Options{},
+		func(eventType, reason, message string) {
+			events = append(events, event{eventType, reason, message})
+		},
+	)
+
+	passing := func(check string) float64 {
+		return testutil.ToFloat64(checkPassing.With(prometheus.Labels{
+			labelCategory: "health-exporter-test",
+			labelCheck:    check,
+			labelWarning:  "false",
+		}))
+	}
+
+	testCases := []struct {
+		name           string
+		err            error
+		expectedEvents []event
+		passing        float64
+		success        float64
+	}{
+		{
+			name:    "passing checks don't record events",
+			passing: 1,
+			success: 1,
+		},
+		{
+			name: "failing check records an event",
+			err:  errors.New("it's broken"),
+			expectedEvents: []event{
+				{"Warning", eventReasonCheckFailed, "[health-exporter-test] sometimes fails: it's broken (see https://linkerd.io/checks/#l5d-sometimes for hints)"},
+			},
+		},
+		{
+			name: "still failing check doesn't record another event",
+			err:  errors.New("it's still broken"),
+		},
+		{
+			name: "recovered check records an event",
+			expectedEvents: []event{
+				{"Normal", eventReasonCheckRecovered, "[health-exporter-test] sometimes fails: check passes again"},
+			},
+			passing: 1,
+			success: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.name, func(t *testing.T) {
+			events = nil
+			brokenErr = tc.err
+			exporter.runChecks()
+
+			if !reflect.DeepEqual(events, tc.expectedEvents) {
+				t.Errorf("Expected events %v, got %v", tc.expectedEvents, events)
+			}
+			if v := passing("always passes"); v != 1 {
+				t.Errorf("Expected the passing check's gauge to be 1, got %v", v)
+			}
+			if v := passing("sometimes fails"); v != tc.passing {
+				t.Errorf("Expected the failing check's gauge to be %v, got %v", tc.passing, v)
+			}
+			if v := testutil.ToFloat64(runSuccess); v != tc.success {
+				t.Errorf("Expected the run success gauge to be %v, got %v", tc.success, v)
+			}
+		})
+	}
+}
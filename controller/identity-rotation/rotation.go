@@ -0,0 +1,317 @@
+package rotation
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/linkerd/linkerd2/pkg/config"
+	"github.com/linkerd/linkerd2/pkg/healthcheck"
+	"github.com/linkerd/linkerd2/pkg/identity"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/linkerd/linkerd2/pkg/tls"
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Rotate advances the rotation of the identity issuer certificate staged in
+// the issuer secret by "linkerd upgrade --rotate-issuer", in two steps:
+//
+//  1. Once all the meshed pods trust the staged issuer, it replaces the current
+//     one. Until then, the workloads of the pods that don't trust it yet are
+//     restarted, so that they pick up the trust anchors bundling the previous
+//     and the new anchors.
+//  2. Once the overlap window has passed, i.e. once all the certificates issued
+//     by the previous issuer have expired, the trust anchors the current issuer
+//     doesn't validate against are removed from the linkerd-config ConfigMap,
+//     and the data plane workloads are restarted to pick up the remaining ones.
+//
+// Each call makes as much progress as possible, so it's meant to be called
+// periodically until no rotation is in progress.
+func Rotate(k kubernetes.Interface, controlPlaneNamespace string, now time.Time) error {
+	secret, err := k.CoreV1().Secrets(controlPlaneNamespace).Get(k8s.IdentityIssuerSecretName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to fetch the issuer secret: %s", err)
+	}
+
+	if _, ok := secret.Data[k8s.IdentityIssuerNextCrtName]; ok {
+		return promoteNextIssuer(k, controlPlaneNamespace, secret, now)
+	}
+
+	if until, ok := secret.Annotations[k8s.IdentityTrustAnchorsOverlapUntilAnnotation]; ok {
+		return pruneTrustAnchors(k, controlPlaneNamespace, secret, until, now)
+	}
+
+	log.Info("No issuer rotation in progress")
+	return nil
+}
+
+func promoteNextIssuer(k kubernetes.Interface, controlPlaneNamespace string, secret *corev1.Secret, now time.Time) error {
+	next, err := tls.DecodePEMCrt(string(secret.Data[k8s.IdentityIssuerNextCrtName]))
+	if err != nil {
+		return fmt.Errorf("failed to decode the staged issuer certificate: %s", err)
+	}
+	overlap, err := time.ParseDuration(secret.Annotations[k8s.IdentityIssuerRotationOverlapAnnotation])
+	if err != nil {
+		return fmt.Errorf("invalid %s annotation: %s", k8s.IdentityIssuerRotationOverlapAnnotation, err)
+	}
+
+	pending, err := meshedPods(k, func(anchorsPEM string) bool {
+		anchors, err := tls.DecodePEMCertPool(anchorsPEM)
+		return err != nil || next.Verify(anchors, "", time.Time{}) != nil
+	})
+	if err != nil {
+		return err
+	}
+	if len(pending) > 0 {
+		log.Infof("Waiting for %d pods to trust the staged issuer before using it", len(pending))
+		_, configs, err := healthcheck.FetchLinkerdConfigMap(k, controlPlaneNamespace)
+		if err != nil {
+			return err
+		}
+		return restartWorkloads(k, controlPlaneNamespace, pending, anchorsRevision(configs.GetGlobal().GetIdentityContext().GetTrustAnchorsPem()))
+	}
+
+	secret = secret.DeepCopy()
+	secret.Data[k8s.IdentityIssuerCrtName] = secret.Data[k8s.IdentityIssuerNextCrtName]
+	secret.Data[k8s.IdentityIssuerKeyName] = secret.Data[k8s.IdentityIssuerNextKeyName]
+	delete(secret.Data, k8s.IdentityIssuerNextCrtName)
+	delete(secret.Data, k8s.IdentityIssuerNextKeyName)
+	delete(secret.Annotations, k8s.IdentityIssuerRotationOverlapAnnotation)
+	secret.Annotations[k8s.IdentityIssuerExpiryAnnotation] = next.Certificate.NotAfter.UTC().Format(time.RFC3339)
+	until := now.Add(overlap).UTC()
+	secret.Annotations[k8s.IdentityTrustAnchorsOverlapUntilAnnotation] = until.Format(time.RFC3339)
+
+	if _, err := k.CoreV1().Secrets(controlPlaneNamespace).Update(secret); err != nil {
+		return fmt.Errorf("failed to update the issuer secret: %s", err)
+	}
+	log.Infof("All the meshed pods trust the staged issuer; it is now in use, and the previous trust anchors will be removed after %s", until)
+	return nil
+}
+
+func pruneTrustAnchors(k kubernetes.Interface, controlPlaneNamespace string, secret *corev1.Secret, until string, now time.Time) error {
+	overlapUntil, err := time.Parse(time.RFC3339, until)
+	if err != nil {
+		return fmt.Errorf("invalid %s annotation: %s", k8s.IdentityTrustAnchorsOverlapUntilAnnotation, err)
+	}
+	if now.Before(overlapUntil) {
+		log.Infof("Keeping the previous trust anchors until %s", overlapUntil)
+		return nil
+	}
+
+	issuer, err := tls.DecodePEMCrt(string(secret.Data[k8s.IdentityIssuerCrtName]))
+	if err != nil {
+		return fmt.Errorf("failed to decode the issuer certificate: %s", err)
+	}
+
+	cm, configs, err := healthcheck.FetchLinkerdConfigMap(k, controlPlaneNamespace)
+	if err != nil {
+		return err
+	}
+	idctx := configs.GetGlobal().GetIdentityContext()
+	anchors, err := tls.DecodePEMCertificates(idctx.GetTrustAnchorsPem())
+	if err != nil {
+		return fmt.Errorf("failed to decode the trust anchors: %s", err)
+	}
+
+	var kept []*x509.Certificate
+	for _, anchor := range anchors {
+		if issuer.Verify(tls.CertificatesToPool([]*x509.Certificate{anchor}), "", time.Time{}) == nil {
+			kept = append(kept, anchor)
+		}
+	}
+	if len(kept) == 0 {
+		return errors.New("the issuer certificate doesn't validate against any of the trust anchors, which are left untouched")
+	}
+
+	if len(kept) < len(anchors) {
+		idctx.TrustAnchorsPem = tls.EncodeCertificatesPEM(kept...)
+		global, _, _, err := config.ToJSON(configs)
+		if err != nil {
+			return err
+		}
+		cm = cm.DeepCopy()
+		cm.Data["global"] = global
+		if _, err := k.CoreV1().ConfigMaps(controlPlaneNamespace).Update(cm); err != nil {
+			return fmt.Errorf("failed to update the trust anchors: %s", err)
+		}
+		log.Infof("Removed %d trust anchors the issuer doesn't validate against", len(anchors)-len(kept))
+
+		stale, err := meshedPods(k, func(anchorsPEM string) bool {
+			return anchorsPEM != strings.TrimSpace(idctx.TrustAnchorsPem)
+		})
+		if err != nil {
+			return err
+		}
+		if err := restartWorkloads(k, controlPlaneNamespace, stale, anchorsRevision(idctx.TrustAnchorsPem)); err != nil {
+			return err
+		}
+	}
+
+	secret = secret.DeepCopy()
+	delete(secret.Annotations, k8s.IdentityTrustAnchorsOverlapUntilAnnotation)
+	if _, err := k.CoreV1().Secrets(controlPlaneNamespace).Update(secret); err != nil {
+		return fmt.Errorf("failed to update the issuer secret: %s", err)
+	}
+	log.Info("Issuer rotation complete")
+	return nil
+}
+
+// meshedPods returns the running meshed pods for which pending returns true,
+// given the trust anchors of their proxy.
+func meshedPods(k kubernetes.Interface, pending func(anchorsPEM string) bool) ([]corev1.Pod, error) {
+	podList, err := k.CoreV1().Pods("").List(metav1.ListOptions{LabelSelector: k8s.ControllerNSLabel})
+	if err != nil {
+		return nil, err
+	}
+
+	var pods []corev1.Pod
+	for _, pod := range podList.Items {
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		anchorsPEM, ok := proxyTrustAnchors(pod)
+		if !ok {
+			continue
+		}
+		if pending(anchorsPEM) {
+			pods = append(pods, pod)
+		}
+	}
+	return pods, nil
+}
+
+func proxyTrustAnchors(pod corev1.Pod) (string, bool) {
+	for _, container := range pod.Spec.Containers {
+		if container.Name != k8s.ProxyContainerName {
+			continue
+		}
+		for _, env := range container.Env {
+			if env.Name == identity.EnvTrustAnchors {
+				return strings.TrimSpace(env.Value), true
+			}
+		}
+	}
+	return "", false
+}
+
+// restartWorkloads triggers a rolling restart of the workloads owning pods,
+// unless they were already restarted for the given trust anchors revision.
+// The control plane workloads are skipped, as their trust anchors come from
+// the manifest rendered by "linkerd upgrade" rather than from the proxy
+// injector.
+func restartWorkloads(k kubernetes.Interface, controlPlaneNamespace string, pods []corev1.Pod, revision string) error {
+	seen := map[string]struct{}{}
+	for _, pod := range pods {
+		if pod.Namespace == controlPlaneNamespace {
+			log.Warnf("Pod %s/%s doesn't have the current trust anchors; run \"linkerd upgrade\" to update the control plane", pod.Namespace, pod.Name)
+			continue
+		}
+
+		kind, name, err := podWorkload(k, pod)
+		if err != nil {
+			return err
+		}
+		if kind == "" {
+			log.Warnf("Pod %s/%s doesn't have the current trust anchors and can't be restarted automatically; delete it to proceed", pod.Namespace, pod.Name)
+			continue
+		}
+
+		id := fmt.Sprintf("%s/%s/%s", kind, pod.Namespace, name)
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+
+		restarted, err := restartWorkload(k, kind, pod.Namespace, name, revision)
+		if err != nil {
+			return fmt.Errorf("failed to restart %s %s/%s: %s", kind, pod.Namespace, name, err)
+		}
+		if restarted {
+			log.Infof("Restarted %s %s/%s", kind, pod.Namespace, name)
+		}
+	}
+	return nil
+}
+
+// podWorkload returns the kind and name of the workload that can be restarted
+// to replace pod, or an empty kind if there's none.
+func podWorkload(k kubernetes.Interface, pod corev1.Pod) (string, string, error) {
+	owner := metav1.GetControllerOf(&pod)
+	if owner == nil {
+		return "", "", nil
+	}
+
+	switch owner.Kind {
+	case "ReplicaSet":
+		rs, err := k.AppsV1().ReplicaSets(pod.Namespace).Get(owner.Name, metav1.GetOptions{})
+		if err != nil {
+			return "", "", err
+		}
+		if rsOwner := metav1.GetControllerOf(rs); rsOwner != nil && rsOwner.Kind == "Deployment" {
+			return rsOwner.Kind, rsOwner.Name, nil
+		}
+	case "StatefulSet", "DaemonSet":
+		return owner.Kind, owner.Name, nil
+	}
+
+	return "", "", nil
+}
+
+func restartWorkload(k kubernetes.Interface, kind, namespace, name, revision string) (bool, error) {
+	var template corev1.PodTemplateSpec
+	var patch func(data []byte) error
+
+	switch kind {
+	case "Deployment":
+		obj, err := k.AppsV1().Deployments(namespace).Get(name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		template = obj.Spec.Template
+		patch = func(data []byte) error {
+			_, err := k.AppsV1().Deployments(namespace).Patch(name, types.StrategicMergePatchType, data)
+			return err
+		}
+	case "StatefulSet":
+		obj, err := k.AppsV1().StatefulSets(namespace).Get(name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		template = obj.Spec.Template
+		patch = func(data []byte) error {
+			_, err := k.AppsV1().StatefulSets(namespace).Patch(name, types.StrategicMergePatchType, data)
+			return err
+		}
+	case "DaemonSet":
+		obj, err := k.AppsV1().DaemonSets(namespace).Get(name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		template = obj.Spec.Template
+		patch = func(data []byte) error {
+			_, err := k.AppsV1().DaemonSets(namespace).Patch(name, types.StrategicMergePatchType, data)
+			return err
+		}
+	default:
+		return false, fmt.Errorf("unsupported workload kind %s", kind)
+	}
+
+	// The rollout triggered by a previous run may still be in progress
+	if template.Annotations[k8s.TrustAnchorsRevisionAnnotation] == revision {
+		return false, nil
+	}
+
+	data := fmt.Sprintf(`{"spec":{"template":{"metadata":{"annotations":{%q:%q}}}}}`, k8s.TrustAnchorsRevisionAnnotation, revision)
+	return true, patch([]byte(data))
+}
+
+func anchorsRevision(anchorsPEM string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(strings.TrimSpace(anchorsPEM))))[:16]
+}
@@ -0,0 +1,208 @@
+package rotation
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/linkerd/linkerd2/pkg/healthcheck"
+	"github.com/linkerd/linkerd2/pkg/identity"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/linkerd/linkerd2/pkg/tls"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/yaml"
+)
+
+func TestRotate(t *testing.T) {
+	current, err := tls.GenerateRootCAWithDefaults("identity.linkerd.cluster.local")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	next, err := tls.GenerateRootCAWithDefaults("identity.linkerd.cluster.local")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	currentPEM := current.Cred.Crt.EncodeCertificatePEM()
+	nextPEM := next.Cred.Crt.EncodeCertificatePEM()
+	bundlePEM := currentPEM + nextPEM
+
+	now := time.Date(2020, 9, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Restarts the workloads that don't trust the staged issuer yet", func(t *testing.T) {
+		k := fakeAPI(t, append(meshedDeployment(t, currentPEM), stagedSecret(t, current, next), linkerdConfig(t, bundlePEM))...)
+
+		if err := Rotate(k, "linkerd", now); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		secret := getSecret(t, k)
+		if string(secret.Data[k8s.IdentityIssuerCrtName]) != currentPEM {
+			t.Fatal("Expected the staged issuer not to be in use yet")
+		}
+		deploy, err := k.AppsV1().Deployments("emojivoto").Get("web", metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if rev := deploy.Spec.Template.Annotations[k8s.TrustAnchorsRevisionAnnotation]; rev != anchorsRevision(bundlePEM) {
+			t.Fatalf("Expected the deployment to be restarted for revision %s, got %q", anchorsRevision(bundlePEM), rev)
+		}
+	})
+
+	t.Run("Uses the staged issuer once all the pods trust it", func(t *testing.T) {
+		k := fakeAPI(t, append(meshedDeployment(t, bundlePEM), stagedSecret(t, current, next), linkerdConfig(t, bundlePEM))...)
+
+		if err := Rotate(k, "linkerd", now); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		secret := getSecret(t, k)
+		if string(secret.Data[k8s.IdentityIssuerCrtName]) != nextPEM {
+			t.Fatal("Expected the staged issuer to be in use")
+		}
+		if _, ok := secret.Data[k8s.IdentityIssuerNextCrtName]; ok {
+			t.Fatal("Expected the staged issuer to be removed")
+		}
+		expected := "2020-09-03T00:00:00Z"
+		if until := secret.Annotations[k8s.IdentityTrustAnchorsOverlapUntilAnnotation]; until != expected {
+			t.Fatalf("Expected the previous trust anchors to be kept until %s, got %q", expected, until)
+		}
+	})
+
+	t.Run("Removes the previous trust anchors after the overlap window", func(t *testing.T) {
+		secret := issuerSecret(t, map[string]string{
+			k8s.IdentityTrustAnchorsOverlapUntilAnnotation: "2020-09-03T00:00:00Z",
+		}, map[string]string{
+			k8s.IdentityIssuerCrtName: nextPEM,
+			k8s.IdentityIssuerKeyName: next.Cred.EncodePrivateKeyPEM(),
+		})
+
+		k := fakeAPI(t, append(meshedDeployment(t, bundlePEM), secret, linkerdConfig(t, bundlePEM))...)
+
+		if err := Rotate(k, "linkerd", now.Add(24*time.Hour)); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if _, configs, _ := healthcheck.FetchLinkerdConfigMap(k, "linkerd"); configs.GetGlobal().GetIdentityContext().GetTrustAnchorsPem() != bundlePEM {
+			t.Fatal("Expected the trust anchors to be kept during the overlap window")
+		}
+
+		if err := Rotate(k, "linkerd", now.Add(49*time.Hour)); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		_, configs, err := healthcheck.FetchLinkerdConfigMap(k, "linkerd")
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if anchors := configs.GetGlobal().GetIdentityContext().GetTrustAnchorsPem(); anchors != nextPEM {
+			t.Fatalf("Expected only the new trust anchor to be kept, got:\n%s", anchors)
+		}
+		if _, ok := getSecret(t, k).Annotations[k8s.IdentityTrustAnchorsOverlapUntilAnnotation]; ok {
+			t.Fatal("Expected the overlap annotation to be removed")
+		}
+		deploy, err := k.AppsV1().Deployments("emojivoto").Get("web", metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if rev := deploy.Spec.Template.Annotations[k8s.TrustAnchorsRevisionAnnotation]; rev != anchorsRevision(nextPEM) {
+			t.Fatalf("Expected the deployment to be restarted for revision %s, got %q", anchorsRevision(nextPEM), rev)
+		}
+	})
+}
+
+func fakeAPI(t *testing.T, manifests ...string) kubernetes.Interface {
+	k, err := k8s.NewFakeAPI(manifests...)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	return k
+}
+
+func getSecret(t *testing.T, k kubernetes.Interface) *corev1.Secret {
+	secret, err := k.CoreV1().Secrets("linkerd").Get(k8s.IdentityIssuerSecretName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	return secret
+}
+
+func stagedSecret(t *testing.T, current, next *tls.CA) string {
+	return issuerSecret(t, map[string]string{
+		k8s.IdentityIssuerRotationOverlapAnnotation: "48h",
+	}, map[string]string{
+		k8s.IdentityIssuerCrtName:     current.Cred.Crt.EncodeCertificatePEM(),
+		k8s.IdentityIssuerKeyName:     current.Cred.EncodePrivateKeyPEM(),
+		k8s.IdentityIssuerNextCrtName: next.Cred.Crt.EncodeCertificatePEM(),
+		k8s.IdentityIssuerNextKeyName: next.Cred.EncodePrivateKeyPEM(),
+	})
+}
+
+func issuerSecret(t *testing.T, annotations, data map[string]string) string {
+	secret := &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{Kind: "Secret", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        k8s.IdentityIssuerSecretName,
+			Namespace:   "linkerd",
+			Annotations: annotations,
+		},
+		Data: map[string][]byte{},
+	}
+	for k, v := range data {
+		secret.Data[k] = []byte(v)
+	}
+	return toYAML(t, secret)
+}
+
+func linkerdConfig(t *testing.T, anchorsPEM string) string {
+	global := fmt.Sprintf(`{"linkerdNamespace":"linkerd","identityContext":{"trustDomain":"cluster.local","trustAnchorsPem":%q}}`, anchorsPEM)
+	return toYAML(t, &corev1.ConfigMap{
+		TypeMeta:   metav1.TypeMeta{Kind: "ConfigMap", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{Name: k8s.ConfigConfigMapName, Namespace: "linkerd"},
+		Data:       map[string]string{"global": global, "proxy": "{}", "install": "{}"},
+	})
+}
+
+// meshedDeployment returns a deployment, its replicaset and a pod whose proxy
+// has the given trust anchors
+func meshedDeployment(t *testing.T, anchorsPEM string) []string {
+	controller := true
+	deploy := &appsv1.Deployment{
+		TypeMeta:   metav1.TypeMeta{Kind: "Deployment", APIVersion: "apps/v1"},
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "emojivoto"},
+	}
+	rs := &appsv1.ReplicaSet{
+		TypeMeta: metav1.TypeMeta{Kind: "ReplicaSet", APIVersion: "apps/v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "web-5f8b7c6d9",
+			Namespace:       "emojivoto",
+			OwnerReferences: []metav1.OwnerReference{{Kind: "Deployment", Name: "web", Controller: &controller}},
+		},
+	}
+	pod := &corev1.Pod{
+		TypeMeta: metav1.TypeMeta{Kind: "Pod", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "web-5f8b7c6d9-abcde",
+			Namespace:       "emojivoto",
+			Labels:          map[string]string{k8s.ControllerNSLabel: "linkerd"},
+			OwnerReferences: []metav1.OwnerReference{{Kind: "ReplicaSet", Name: "web-5f8b7c6d9", Controller: &controller}},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name: k8s.ProxyContainerName,
+				Env:  []corev1.EnvVar{{Name: identity.EnvTrustAnchors, Value: anchorsPEM}},
+			}},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+
+	return []string{toYAML(t, deploy), toYAML(t, rs), toYAML(t, pod)}
+}
+
+func toYAML(t *testing.T, obj interface{}) string {
+	data, err := yaml.Marshal(obj)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	return string(data)
+}
@@ -28,6 +28,8 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
 	"k8s.io/client-go/informers"
 	arinformers "k8s.io/client-go/informers/admissionregistration/v1beta1"
 	appv1informers "k8s.io/client-go/informers/apps/v1"
@@ -63,6 +65,7 @@ const (
 	Node
 	Secret
 	ES // EndpointSlice resource
+	PC // ProxyConfig and ClusterProxyConfig resources
 )
 
 // API provides shared informers for all Kubernetes objects
@@ -87,11 +90,14 @@ type API struct {
 	ts       tsinformers.TrafficSplitInformer
 	node     coreinformers.NodeInformer
 	secret   coreinformers.SecretInformer
-
-	syncChecks        []cache.InformerSynced
-	sharedInformers   informers.SharedInformerFactory
-	spSharedInformers sp.SharedInformerFactory
-	tsSharedInformers ts.SharedInformerFactory
+	pc       informers.GenericInformer
+	cpc      informers.GenericInformer
+
+	syncChecks             []cache.InformerSynced
+	sharedInformers        informers.SharedInformerFactory
+	spSharedInformers      sp.SharedInformerFactory
+	tsSharedInformers      ts.SharedInformerFactory
+	dynamicSharedInformers dynamicinformer.DynamicSharedInformerFactory
 }
 
 // InitializeAPI creates Kubernetes clients and returns an initialized API wrapper.
@@ -160,7 +166,20 @@ func initAPI(k8sClient *k8s.KubernetesAPI, kubeConfig *rest.Config, ensureCluste
 			break
 		}
 	}
-	return NewAPI(k8sClient, spClient, tsClient, resources...), nil
+
+	// ProxyConfigs
+	var dynamicClient dynamic.Interface
+	for _, res := range resources {
+		if res == PC {
+			dynamicClient, err = dynamic.NewForConfig(kubeConfig)
+			if err != nil {
+				return nil, err
+			}
+
+			break
+		}
+	}
+	return NewAPI(k8sClient, spClient, tsClient, dynamicClient, resources...), nil
 }
 
 // NewAPI takes a Kubernetes client and returns an initialized API.
@@ -168,6 +187,7 @@ func NewAPI(
 	k8sClient kubernetes.Interface,
 	spClient spclient.Interface,
 	tsClient tsclient.Interface,
+	dynamicClient dynamic.Interface,
 	resources ...APIResource,
 ) *API {
 	sharedInformers := informers.NewSharedInformerFactory(k8sClient, 10*time.Minute)
@@ -182,12 +202,18 @@ func NewAPI(
 		tsSharedInformers = ts.NewSharedInformerFactory(tsClient, 10*time.Minute)
 	}
 
+	var dynamicSharedInformers dynamicinformer.DynamicSharedInformerFactory
+	if dynamicClient != nil {
+		dynamicSharedInformers = dynamicinformer.NewDynamicSharedInformerFactory(dynamicClient, 10*time.Minute)
+	}
+
 	api := &API{
-		Client:            k8sClient,
-		syncChecks:        make([]cache.InformerSynced, 0),
-		sharedInformers:   sharedInformers,
-		spSharedInformers: spSharedInformers,
-		tsSharedInformers: tsSharedInformers,
+		Client:                 k8sClient,
+		syncChecks:             make([]cache.InformerSynced, 0),
+		sharedInformers:        sharedInformers,
+		spSharedInformers:      spSharedInformers,
+		tsSharedInformers:      tsSharedInformers,
+		dynamicSharedInformers: dynamicSharedInformers,
 	}
 
 	for _, resource := range resources {
@@ -246,6 +272,10 @@ func NewAPI(
 		case Secret:
 			api.secret = sharedInformers.Core().V1().Secrets()
 			api.syncChecks = append(api.syncChecks, api.secret.Informer().HasSynced)
+		case PC:
+			api.pc = dynamicSharedInformers.ForResource(k8s.ProxyConfigGVR)
+			api.cpc = dynamicSharedInformers.ForResource(k8s.ClusterProxyConfigGVR)
+			api.syncChecks = append(api.syncChecks, api.pc.Informer().HasSynced, api.cpc.Informer().HasSynced)
 		}
 	}
 	return api
@@ -256,6 +286,9 @@ func (api *API) Sync(stopCh <-chan struct{}) {
 	api.sharedInformers.Start(stopCh)
 	api.spSharedInformers.Start(stopCh)
 	api.tsSharedInformers.Start(stopCh)
+	if api.dynamicSharedInformers != nil {
+		api.dynamicSharedInformers.Start(stopCh)
+	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
 	defer cancel()
@@ -410,6 +443,29 @@ func (api *API) Secret() coreinformers.SecretInformer {
 	return api.secret
 }
 
+// PC provides access to a shared informer and lister for ProxyConfigs.
+func (api *API) PC() informers.GenericInformer {
+	if api.pc == nil {
+		panic("PC informer not configured")
+	}
+	return api.pc
+}
+
+// CPC provides access to a shared informer and lister for
+// ClusterProxyConfigs.
+func (api *API) CPC() informers.GenericInformer {
+	if api.cpc == nil {
+		panic("CPC informer not configured")
+	}
+	return api.cpc
+}
+
+// PCAvailable informs the caller whether this API is configured to retrieve
+// ProxyConfigs and ClusterProxyConfigs
+func (api *API) PCAvailable() bool {
+	return api.pc != nil
+}
+
 // CJ provides access to a shared informer and lister for CronJobs.
 func (api *API) CJ() batchv1beta1informers.CronJobInformer {
 	if api.cj == nil {
@@ -16,6 +16,7 @@ func NewFakeAPI(configs ...string) (*API, error) {
 		clientSet,
 		spClientSet,
 		tsClientSet,
+		nil,
 		CJ,
 		CM,
 		Deploy,
@@ -0,0 +1,149 @@
+package injector
+
+import (
+	"fmt"
+
+	"github.com/linkerd/linkerd2/controller/k8s"
+	"github.com/linkerd/linkerd2/pkg/inject"
+	pkgK8s "github.com/linkerd/linkerd2/pkg/k8s"
+	log "github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+)
+
+var nodeSelectorOperators = map[v1.NodeSelectorOperator]selection.Operator{
+	v1.NodeSelectorOpIn:           selection.In,
+	v1.NodeSelectorOpNotIn:        selection.NotIn,
+	v1.NodeSelectorOpExists:       selection.Exists,
+	v1.NodeSelectorOpDoesNotExist: selection.DoesNotExist,
+	v1.NodeSelectorOpGt:           selection.GreaterThan,
+	v1.NodeSelectorOpLt:           selection.LessThan,
+}
+
+// cniDetector returns a function detecting whether the linkerd CNI plugin is
+// active on all the nodes matching a pod's nodeSelector and required node
+// affinity: either because they have the CNIReadyNodeLabel, or because a ready
+// pod of the linkerd-cni DaemonSet runs on them. If no node matches, the CNI
+// isn't considered active.
+func cniDetector(api *k8s.API) inject.CNIDetectorFunc {
+	return func(spec *v1.PodSpec) bool {
+		nodes, err := api.Node().Lister().List(labels.SelectorFromSet(spec.NodeSelector))
+		if err != nil {
+			log.Warnf("couldn't retrieve the nodes: %s", err)
+			return false
+		}
+
+		cniNodes := cniPluginNodes(api)
+		matched := false
+		for _, node := range nodes {
+			ok, err := nodeAffinityMatches(node, spec.Affinity)
+			if err != nil {
+				log.Warnf("invalid node affinity: %s", err)
+				return false
+			}
+			if !ok {
+				continue
+			}
+			if node.Labels[pkgK8s.CNIReadyNodeLabel] != "true" && !cniNodes[node.Name] {
+				return false
+			}
+			matched = true
+		}
+		return matched
+	}
+}
+
+// nodeAffinityMatches returns true if the node matches one of the terms of
+// the required node affinity, if any
+func nodeAffinityMatches(node *v1.Node, affinity *v1.Affinity) (bool, error) {
+	if affinity == nil || affinity.NodeAffinity == nil || affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		return true, nil
+	}
+
+	for _, term := range affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms {
+		ok, err := nodeSelectorTermMatches(node, term)
+		if err != nil || ok {
+			return ok, err
+		}
+	}
+	return false, nil
+}
+
+// nodeSelectorTermMatches returns true if the node matches all the
+// expressions of the term. Just like for the scheduler, an empty term doesn't
+// match any node, and the only field supported is metadata.name.
+func nodeSelectorTermMatches(node *v1.Node, term v1.NodeSelectorTerm) (bool, error) {
+	if len(term.MatchExpressions) == 0 && len(term.MatchFields) == 0 {
+		return false, nil
+	}
+
+	for _, expr := range term.MatchFields {
+		if expr.Key != "metadata.name" {
+			return false, fmt.Errorf("unsupported node selector field %s", expr.Key)
+		}
+	}
+
+	ok, err := requirementsMatch(term.MatchExpressions, labels.Set(node.Labels))
+	if err != nil || !ok {
+		return false, err
+	}
+	return requirementsMatch(term.MatchFields, labels.Set{"metadata.name": node.Name})
+}
+
+func requirementsMatch(requirements []v1.NodeSelectorRequirement, set labels.Set) (bool, error) {
+	for _, expr := range requirements {
+		op, ok := nodeSelectorOperators[expr.Operator]
+		if !ok {
+			return false, fmt.Errorf("unknown node selector operator %s", expr.Operator)
+		}
+		requirement, err := labels.NewRequirement(expr.Key, op, expr.Values)
+		if err != nil {
+			return false, err
+		}
+		if !requirement.Matches(set) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// cniPluginNodes returns the names of the nodes running a ready pod of a
+// DaemonSet installed by `linkerd install-cni`
+func cniPluginNodes(api *k8s.API) map[string]bool {
+	nodes := map[string]bool{}
+	daemonSets, err := api.DS().Lister().List(labels.SelectorFromSet(labels.Set{pkgK8s.CNIResourceLabel: "true"}))
+	if err != nil {
+		log.Warnf("couldn't retrieve the linkerd-cni DaemonSets: %s", err)
+		return nodes
+	}
+
+	for _, ds := range daemonSets {
+		selector, err := metav1.LabelSelectorAsSelector(ds.Spec.Selector)
+		if err != nil {
+			log.Warnf("invalid selector of DaemonSet %s/%s: %s", ds.Namespace, ds.Name, err)
+			continue
+		}
+		pods, err := api.Pod().Lister().Pods(ds.Namespace).List(selector)
+		if err != nil {
+			log.Warnf("couldn't retrieve the pods of DaemonSet %s/%s: %s", ds.Namespace, ds.Name, err)
+			continue
+		}
+		for _, pod := range pods {
+			if pod.Spec.NodeName != "" && podReady(pod) {
+				nodes[pod.Spec.NodeName] = true
+			}
+		}
+	}
+	return nodes
+}
+
+func podReady(pod *v1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == v1.PodReady {
+			return condition.Status == v1.ConditionTrue
+		}
+	}
+	return false
+}
@@ -0,0 +1,135 @@
+package injector
+
+import (
+	"testing"
+
+	"github.com/linkerd/linkerd2/controller/k8s"
+	v1 "k8s.io/api/core/v1"
+)
+
+func requiredNodeAffinity(terms ...v1.NodeSelectorTerm) *v1.Affinity {
+	return &v1.Affinity{
+		NodeAffinity: &v1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &v1.NodeSelector{NodeSelectorTerms: terms},
+		},
+	}
+}
+
+func poolTerm(op v1.NodeSelectorOperator, pools ...string) v1.NodeSelectorTerm {
+	return v1.NodeSelectorTerm{
+		MatchExpressions: []v1.NodeSelectorRequirement{{Key: "pool", Operator: op, Values: pools}},
+	}
+}
+
+func nodeNameTerm(names ...string) v1.NodeSelectorTerm {
+	return v1.NodeSelectorTerm{
+		MatchFields: []v1.NodeSelectorRequirement{{Key: "metadata.name", Operator: v1.NodeSelectorOpIn, Values: names}},
+	}
+}
+
+func TestCNIDetector(t *testing.T) {
+	k8sConfigs := []string{`
+apiVersion: v1
+kind: Node
+metadata:
+  name: node-cni-pod
+  labels:
+    pool: cni
+`, `
+apiVersion: v1
+kind: Node
+metadata:
+  name: node-cni-label
+  labels:
+    pool: cni
+    linkerd.io/cni-ready: "true"
+`, `
+apiVersion: v1
+kind: Node
+metadata:
+  name: node-no-cni
+  labels:
+    pool: legacy
+`, `
+apiVersion: v1
+kind: Node
+metadata:
+  name: node-cni-not-ready
+  labels:
+    pool: starting
+`, `
+apiVersion: apps/v1
+kind: DaemonSet
+metadata:
+  name: linkerd-cni
+  namespace: linkerd-cni
+  labels:
+    linkerd.io/cni-resource: "true"
+spec:
+  selector:
+    matchLabels:
+      k8s-app: linkerd-cni
+`, `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: linkerd-cni-ready
+  namespace: linkerd-cni
+  labels:
+    k8s-app: linkerd-cni
+spec:
+  nodeName: node-cni-pod
+status:
+  conditions:
+  - type: Ready
+    status: "True"
+`, `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: linkerd-cni-starting
+  namespace: linkerd-cni
+  labels:
+    k8s-app: linkerd-cni
+spec:
+  nodeName: node-cni-not-ready
+status:
+  conditions:
+  - type: Ready
+    status: "False"
+`,
+	}
+	api, err := k8s.NewFakeAPI(k8sConfigs...)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	api.Sync(nil)
+
+	detect := cniDetector(api)
+	testCases := []struct {
+		spec     v1.PodSpec
+		expected bool
+	}{
+		{v1.PodSpec{NodeSelector: map[string]string{"pool": "cni"}}, true},
+		{v1.PodSpec{NodeSelector: map[string]string{"pool": "legacy"}}, false},
+		{v1.PodSpec{NodeSelector: map[string]string{"pool": "starting"}}, false},
+		{v1.PodSpec{NodeSelector: map[string]string{"pool": "missing"}}, false},
+		{v1.PodSpec{}, false},
+		{v1.PodSpec{Affinity: requiredNodeAffinity(poolTerm(v1.NodeSelectorOpIn, "cni"))}, true},
+		{v1.PodSpec{Affinity: requiredNodeAffinity(poolTerm(v1.NodeSelectorOpIn, "cni", "legacy"))}, false},
+		{v1.PodSpec{Affinity: requiredNodeAffinity(poolTerm(v1.NodeSelectorOpNotIn, "legacy", "starting"))}, true},
+		{v1.PodSpec{Affinity: requiredNodeAffinity(poolTerm(v1.NodeSelectorOpIn, "cni"), nodeNameTerm("node-no-cni"))}, false},
+		{v1.PodSpec{Affinity: requiredNodeAffinity(nodeNameTerm("node-cni-label"))}, true},
+		{v1.PodSpec{Affinity: requiredNodeAffinity(v1.NodeSelectorTerm{})}, false},
+		{v1.PodSpec{
+			NodeSelector: map[string]string{"pool": "cni"},
+			Affinity:     requiredNodeAffinity(nodeNameTerm("node-no-cni")),
+		}, false},
+	}
+	for i, tc := range testCases {
+		tc := tc // pin
+		if actual := detect(&tc.spec); actual != tc.expected {
+			t.Errorf("Test case %d: expected CNI detected on nodes %v (affinity %v) to be %t, got %t", i, tc.spec.NodeSelector, tc.spec.Affinity, tc.expected, actual)
+		}
+	}
+}
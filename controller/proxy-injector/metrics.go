@@ -16,6 +16,11 @@ const (
 	labelSkip         = "skip"
 	labelAnnotationAt = "annotation_at"
 	labelReason       = "skip_reason"
+	labelOutcome      = "outcome"
+
+	outcomeInjected = "injected"
+	outcomeSkipped  = "skipped"
+	outcomeError    = "error"
 )
 
 var (
@@ -31,6 +36,17 @@ var (
 		Name: "proxy_inject_admission_responses_total",
 		Help: "A counter for number of admission responses from proxy injector.",
 	}, append(responseLabels, validLabelNames(inject.ProxyAnnotations)...))
+
+	proxyInjectionAdmissionErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "proxy_inject_admission_errors_total",
+		Help: "A counter for number of admission requests the proxy injector failed to process.",
+	}, []string{labelNamespace})
+
+	proxyInjectionAdmissionDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "proxy_inject_admission_duration_seconds",
+		Help:    "A histogram of the time taken by the proxy injector to process admission requests, by outcome: injected, skipped or error.",
+		Buckets: []float64{0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5},
+	}, []string{labelNamespace, labelOutcome})
 )
 
 func admissionRequestLabels(ownerKind, namespace, annotationAt string, configLabels prometheus.Labels) prometheus.Labels {
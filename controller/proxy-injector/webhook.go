@@ -2,7 +2,10 @@ package injector
 
 import (
 	"fmt"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	"k8s.io/apimachinery/pkg/labels"
 
@@ -12,9 +15,11 @@ import (
 	"github.com/linkerd/linkerd2/pkg/inject"
 	pkgK8s "github.com/linkerd/linkerd2/pkg/k8s"
 	"github.com/linkerd/linkerd2/pkg/version"
+	"github.com/prometheus/client_golang/prometheus"
 	log "github.com/sirupsen/logrus"
 	admissionv1beta1 "k8s.io/api/admission/v1beta1"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/record"
 )
@@ -23,42 +28,77 @@ const (
 	eventTypeSkipped  = "InjectionSkipped"
 	eventTypeInjected = "Injected"
 	eventTypeTracing  = "Tracing"
+	eventTypeFailed   = "InjectionFailed"
 )
 
+// nativeSidecars caches whether the cluster runs native sidecar containers,
+// once its version has been retrieved
+var nativeSidecars struct {
+	sync.Mutex
+	checked   bool
+	supported bool
+}
+
 // Inject returns an AdmissionResponse containing the patch, if any, to apply
 // to the pod (proxy sidecar and eventually the init container to set it up)
 func Inject(api *k8s.API,
 	request *admissionv1beta1.AdmissionRequest,
 	recorder record.EventRecorder,
 ) (*admissionv1beta1.AdmissionResponse, error) {
+	start := time.Now()
+	audit := &auditor{recorder: recorder, resource: request.Namespace}
+
+	response, outcome, err := injectPod(api, request, audit)
+	if err != nil {
+		outcome = outcomeError
+		proxyInjectionAdmissionErrors.With(prometheus.Labels{labelNamespace: request.Namespace}).Inc()
+		audit.event(v1.EventTypeWarning, eventTypeFailed, "Linkerd sidecar proxy injection failed: %s", err)
+	}
+	proxyInjectionAdmissionDuration.With(prometheus.Labels{
+		labelNamespace: request.Namespace,
+		labelOutcome:   outcome,
+	}).Observe(time.Since(start).Seconds())
+	return response, err
+}
+
+// injectPod returns the AdmissionResponse of Inject, along with the outcome of
+// the request for the metrics
+func injectPod(api *k8s.API,
+	request *admissionv1beta1.AdmissionRequest,
+	audit *auditor,
+) (*admissionv1beta1.AdmissionResponse, string, error) {
 	log.Debugf("request object bytes: %s", request.Object.Raw)
 
 	globalConfig, err := config.Global(pkgK8s.MountPathGlobalConfig)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
 	proxyConfig, err := config.Proxy(pkgK8s.MountPathProxyConfig)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
 	namespace, err := api.NS().Lister().Get(request.Namespace)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
-	nsAnnotations := namespace.GetAnnotations()
+	audit.namespace = namespace
+	nsAnnotations := proxyConfigDefaults(api, request.Namespace, namespace.GetAnnotations())
 
 	configs := &pb.All{Global: globalConfig, Proxy: proxyConfig}
 	resourceConfig := inject.NewResourceConfig(configs, inject.OriginWebhook).
 		WithOwnerRetriever(ownerRetriever(api, request.Namespace)).
+		WithCNIDetector(cniDetector(api)).
 		WithNsAnnotations(nsAnnotations).
-		WithKind(request.Kind.Kind)
+		WithKind(request.Kind.Kind).
+		WithNativeSidecars(supportsNativeSidecars(api))
 	report, err := resourceConfig.ParseMetaAndYAML(request.Object.Raw)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 	log.Infof("received %s", report.ResName())
+	audit.resource = report.ResName()
 
 	admissionResponse := &admissionv1beta1.AdmissionResponse{
 		UID:     request.UID,
@@ -66,7 +106,6 @@ func Inject(api *k8s.API,
 	}
 
 	configLabels := configToPrometheusLabels(resourceConfig)
-	var parent *runtime.Object
 	ownerKind := ""
 	if ownerRef := resourceConfig.GetOwnerRef(); ownerRef != nil {
 		objs, err := api.GetObjects(request.Namespace, ownerRef.Kind, ownerRef.Name, labels.Everything())
@@ -75,7 +114,7 @@ func Inject(api *k8s.API,
 		} else if len(objs) == 0 {
 			log.Warnf("couldn't retrieve parent object %s-%s-%s", request.Namespace, ownerRef.Kind, ownerRef.Name)
 		} else {
-			parent = &objs[0]
+			audit.parent = objs[0]
 		}
 		ownerKind = strings.ToLower(ownerRef.Kind)
 	}
@@ -89,12 +128,10 @@ func Inject(api *k8s.API,
 		}
 		// removing the initial comma, space
 		readableReasons = readableReasons[2:]
-		if parent != nil {
-			recorder.Eventf(*parent, v1.EventTypeNormal, eventTypeSkipped, "Linkerd sidecar proxy injection skipped: %s", readableReasons)
-		}
+		audit.event(v1.EventTypeNormal, eventTypeSkipped, "Linkerd sidecar proxy injection skipped: %s", readableReasons)
 		log.Infof("skipped %s: %s", report.ResName(), readableReasons)
 		proxyInjectionAdmissionResponses.With(admissionResponseLabels(ownerKind, request.Namespace, "true", metricReasons, report.InjectAnnotationAt, configLabels)).Inc()
-		return admissionResponse, nil
+		return admissionResponse, outcomeSkipped, nil
 	}
 
 	resourceConfig.AppendPodAnnotations(map[string]string{
@@ -102,18 +139,16 @@ func Inject(api *k8s.API,
 	})
 	patchJSON, err := resourceConfig.GetPatch(true)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
 	if len(patchJSON) == 0 {
-		return admissionResponse, nil
+		return admissionResponse, outcomeSkipped, nil
 	}
 
-	if parent != nil {
-		recorder.Event(*parent, v1.EventTypeNormal, eventTypeInjected, "Linkerd sidecar proxy injected")
-		if report.TracingEnabled {
-			recorder.Event(*parent, v1.EventTypeNormal, eventTypeTracing, "Tracing Enabled")
-		}
+	audit.event(v1.EventTypeNormal, eventTypeInjected, "Linkerd sidecar proxy injected, as enabled by the %s annotation of the %s", pkgK8s.ProxyInjectAnnotation, report.InjectAnnotationAt)
+	if report.TracingEnabled {
+		audit.event(v1.EventTypeNormal, eventTypeTracing, "Tracing Enabled")
 	}
 	log.Infof("patch generated for: %s", report.ResName())
 	log.Debugf("patch: %s", patchJSON)
@@ -122,7 +157,93 @@ func Inject(api *k8s.API,
 	admissionResponse.Patch = patchJSON
 	admissionResponse.PatchType = &patchType
 
-	return admissionResponse, nil
+	return admissionResponse, outcomeInjected, nil
+}
+
+// auditor records the injection decisions as events on the parent of the
+// pod, or on its namespace if it has none, like bare pods or the pods whose
+// parent couldn't be retrieved
+type auditor struct {
+	recorder  record.EventRecorder
+	parent    runtime.Object
+	namespace *v1.Namespace
+
+	// resource is the kind and name of the pod, or its namespace until
+	// it's parsed
+	resource string
+}
+
+func (a *auditor) event(eventType, reason, messageFmt string, args ...interface{}) {
+	switch {
+	case a.parent != nil:
+		a.recorder.Eventf(a.parent, eventType, reason, messageFmt, args...)
+	case a.namespace != nil:
+		// the pod doesn't exist yet, so the event names it
+		a.recorder.Eventf(a.namespace, eventType, reason, "%s: %s", a.resource, fmt.Sprintf(messageFmt, args...))
+	}
+}
+
+// supportsNativeSidecars returns true if the cluster runs native sidecar
+// containers, retrieving its version on the first successful call
+func supportsNativeSidecars(api *k8s.API) bool {
+	nativeSidecars.Lock()
+	defer nativeSidecars.Unlock()
+	if !nativeSidecars.checked {
+		versionInfo, err := api.Client.Discovery().ServerVersion()
+		if err != nil {
+			log.Warnf("couldn't retrieve the Kubernetes version: %s", err)
+			return false
+		}
+		nativeSidecars.checked = true
+		nativeSidecars.supported = pkgK8s.SupportsNativeSidecars(versionInfo)
+	}
+	return nativeSidecars.supported
+}
+
+// proxyConfigDefaults returns the annotations of the namespace, completed with
+// the proxy configuration defaults held by the ClusterProxyConfigs and by the
+// ProxyConfigs of the namespace, which take precedence over the former. Each
+// kind is applied in name order.
+func proxyConfigDefaults(api *k8s.API, namespace string, nsAnnotations map[string]string) map[string]string {
+	if !api.PCAvailable() {
+		return nsAnnotations
+	}
+
+	clusterConfigs, err := api.CPC().Lister().List(labels.Everything())
+	if err != nil {
+		log.Warnf("couldn't retrieve the ClusterProxyConfigs: %s", err)
+	}
+	nsConfigs, err := api.PC().Lister().ByNamespace(namespace).List(labels.Everything())
+	if err != nil {
+		log.Warnf("couldn't retrieve the ProxyConfigs of %s: %s", namespace, err)
+	}
+
+	annotations := map[string]string{}
+	for _, objs := range [][]runtime.Object{clusterConfigs, nsConfigs} {
+		configs := make([]*unstructured.Unstructured, 0, len(objs))
+		for _, obj := range objs {
+			if u, ok := obj.(*unstructured.Unstructured); ok {
+				configs = append(configs, u)
+			}
+		}
+		sort.Slice(configs, func(i, j int) bool { return configs[i].GetName() < configs[j].GetName() })
+
+		for _, config := range configs {
+			spec, err := inject.NewProxyConfigSpec(config)
+			if err != nil {
+				log.Warnf("ignoring %s %s: %s", config.GetKind(), config.GetName(), err)
+				continue
+			}
+			for k, v := range spec.Annotations() {
+				annotations[k] = v
+			}
+		}
+	}
+
+	for k, v := range nsAnnotations {
+		annotations[k] = v
+	}
+	return annotations
 }
 
 func ownerRetriever(api *k8s.API, ns string) inject.OwnerRetrieverFunc {
@@ -8,14 +8,19 @@ import (
 	"testing"
 
 	"github.com/linkerd/linkerd2/controller/gen/config"
+	"github.com/linkerd/linkerd2/controller/k8s"
 	"github.com/linkerd/linkerd2/controller/proxy-injector/fake"
 	"github.com/linkerd/linkerd2/pkg/inject"
 	pkgK8s "github.com/linkerd/linkerd2/pkg/k8s"
 	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/tools/record"
 )
 
 type unmarshalledPatch []map[string]interface{}
@@ -177,6 +182,41 @@ func TestGetPatch(t *testing.T) {
 
 	})
 
+	t.Run("by checking annotations with native sidecar", func(t *testing.T) {
+		expectedPatchBytes, err := factory.FileContents("pod-with-native-sidecar.patch.json")
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		expectedPatch, err := unmarshalPatch(expectedPatchBytes)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		pod, err := factory.FileContents("pod-with-native-sidecar.yaml")
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		fakeReq := getFakeReq(pod)
+		conf := confNsEnabled().WithKind(fakeReq.Kind.Kind).WithOwnerRetriever(ownerRetrieverFake).WithNativeSidecars(true)
+		_, err = conf.ParseMetaAndYAML(fakeReq.Object.Raw)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		patchJSON, err := conf.GetPatch(true)
+		if err != nil {
+			t.Fatalf("Unexpected PatchForAdmissionRequest error: %s", err)
+		}
+		actualPatch, err := unmarshalPatch(patchJSON)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if !reflect.DeepEqual(expectedPatch, actualPatch) {
+			t.Fatalf("The actual patch didn't match what was expected.\nExpected: %s\nActual: %s",
+				expectedPatchBytes, patchJSON)
+		}
+	})
+
 	t.Run("by checking container spec", func(t *testing.T) {
 		deployment, err := factory.FileContents("deployment-with-injected-proxy.yaml")
 		if err != nil {
@@ -218,3 +258,109 @@ func unmarshalPatch(patchJSON []byte) (unmarshalledPatch, error) {
 
 	return actualPatch, nil
 }
+
+func proxyConfig(kind, namespace, name string, spec map[string]interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": pkgK8s.ProxyConfigAPIGroupVersion,
+			"kind":       kind,
+			"metadata": map[string]interface{}{
+				"name":      name,
+				"namespace": namespace,
+			},
+			"spec": spec,
+		},
+	}
+}
+
+func TestProxyConfigDefaults(t *testing.T) {
+	clientSet, _, _, spClientSet, tsClientSet, err := pkgK8s.NewFakeClientSets()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	dynamicClient := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(),
+		proxyConfig(pkgK8s.ClusterProxyConfigKind, "", "a-defaults", map[string]interface{}{
+			"logLevel": "warn",
+			"resources": map[string]interface{}{
+				"cpu": map[string]interface{}{"request": "100m"},
+			},
+		}),
+		proxyConfig(pkgK8s.ClusterProxyConfigKind, "", "b-invalid", map[string]interface{}{
+			"resources": map[string]interface{}{
+				"memory": map[string]interface{}{"limit": "lots"},
+			},
+		}),
+		proxyConfig(pkgK8s.ProxyConfigKind, "emojivoto", "defaults", map[string]interface{}{
+			"logLevel":          "info",
+			"skipOutboundPorts": []interface{}{"3306", "6379"},
+		}),
+		proxyConfig(pkgK8s.ProxyConfigKind, "other", "defaults", map[string]interface{}{
+			"logLevel": "debug",
+		}),
+	)
+	api := k8s.NewAPI(clientSet, spClientSet, tsClientSet, dynamicClient, k8s.PC)
+	api.Sync(nil)
+
+	nsAnnotations := map[string]string{
+		pkgK8s.ProxyInjectAnnotation:        pkgK8s.ProxyInjectEnabled,
+		pkgK8s.ProxyCPURequestAnnotation:    "200m",
+		pkgK8s.ProxyMemoryRequestAnnotation: "64Mi",
+	}
+	expected := map[string]string{
+		pkgK8s.ProxyInjectAnnotation:              pkgK8s.ProxyInjectEnabled,
+		pkgK8s.ProxyLogLevelAnnotation:            "info",
+		pkgK8s.ProxyCPURequestAnnotation:          "200m",
+		pkgK8s.ProxyMemoryRequestAnnotation:       "64Mi",
+		pkgK8s.ProxyIgnoreOutboundPortsAnnotation: "3306,6379",
+	}
+	actual := proxyConfigDefaults(api, "emojivoto", nsAnnotations)
+	if !reflect.DeepEqual(expected, actual) {
+		t.Fatalf("Expected annotations %v, got %v", expected, actual)
+	}
+}
+
+func TestAuditorEvent(t *testing.T) {
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "emojivoto"}}
+	parent := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "emojivoto"}}
+
+	testCases := []struct {
+		name     string
+		audit    *auditor
+		expected string
+	}{
+		{
+			name:     "pod with a parent",
+			audit:    &auditor{parent: parent, namespace: namespace, resource: "pod/web-"},
+			expected: "Normal Injected Linkerd sidecar proxy injected",
+		},
+		{
+			name:     "bare pod",
+			audit:    &auditor{namespace: namespace, resource: "pod/web-"},
+			expected: "Normal Injected pod/web-: Linkerd sidecar proxy injected",
+		},
+		{
+			name:  "unknown namespace",
+			audit: &auditor{resource: "emojivoto"},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.name, func(t *testing.T) {
+			recorder := record.NewFakeRecorder(1)
+			tc.audit.recorder = recorder
+			tc.audit.event(corev1.EventTypeNormal, eventTypeInjected, "Linkerd sidecar proxy injected")
+
+			select {
+			case event := <-recorder.Events:
+				if event != tc.expected {
+					t.Fatalf("Expected event %q, got %q", tc.expected, event)
+				}
+			default:
+				if tc.expected != "" {
+					t.Fatalf("Expected event %q, got none", tc.expected)
+				}
+			}
+		})
+	}
+}
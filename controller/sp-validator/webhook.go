@@ -1,22 +1,98 @@
 package validator
 
 import (
+	"fmt"
+	"net"
+	"strings"
+
+	sp "github.com/linkerd/linkerd2/controller/gen/apis/serviceprofile/v1alpha2"
 	"github.com/linkerd/linkerd2/controller/k8s"
 	"github.com/linkerd/linkerd2/pkg/profiles"
+	log "github.com/sirupsen/logrus"
 	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/yaml"
 )
 
+const eventTypeServiceNotFound = "ServiceNotFound"
+
 // AdmitSP verifies that the received Admission Request contains a valid
-// Service Profile definition
+// Service Profile definition. References to Services that don't exist don't
+// deny the request, since the Service may be created afterwards; they are
+// reported as warning events on the Service Profile instead
 func AdmitSP(
-	_ *k8s.API, request *admissionv1beta1.AdmissionRequest, _ record.EventRecorder,
+	api *k8s.API, request *admissionv1beta1.AdmissionRequest, recorder record.EventRecorder,
 ) (*admissionv1beta1.AdmissionResponse, error) {
 	admissionResponse := &admissionv1beta1.AdmissionResponse{Allowed: true}
-	if err := profiles.Validate(request.Object.Raw); err != nil {
+	serviceProfile, err := validate(request.Object.Raw)
+	if err != nil {
 		admissionResponse.Allowed = false
 		admissionResponse.Result = &metav1.Status{Message: err.Error(), Code: 400}
+		return admissionResponse, nil
+	}
+
+	if serviceProfile.Namespace == "" {
+		serviceProfile.Namespace = request.Namespace
+	}
+	for _, warning := range serviceRefWarnings(api, serviceProfile) {
+		log.Warn(warning)
+		if recorder != nil {
+			recorder.Event(serviceProfile, v1.EventTypeWarning, eventTypeServiceNotFound, warning)
+		}
 	}
 	return admissionResponse, nil
 }
+
+func validate(data []byte) (*sp.ServiceProfile, error) {
+	if err := profiles.Validate(data); err != nil {
+		return nil, err
+	}
+
+	var serviceProfile sp.ServiceProfile
+	if err := yaml.Unmarshal(data, &serviceProfile); err != nil {
+		return nil, fmt.Errorf("failed to validate ServiceProfile: %s", err)
+	}
+	return &serviceProfile, nil
+}
+
+// serviceRefWarnings returns a warning for the Service Profile's name and for
+// each of its dstOverrides authorities that refers to a missing Service
+func serviceRefWarnings(api *k8s.API, serviceProfile *sp.ServiceProfile) []string {
+	var warnings []string
+	if err := validateServiceRef(api, serviceProfile.Name); err != nil {
+		warnings = append(warnings, fmt.Sprintf("ServiceProfile \"%s\" metadata.name: %s", serviceProfile.Name, err))
+	}
+
+	for i, dst := range serviceProfile.Spec.DstOverrides {
+		if err := validateServiceRef(api, dst.Authority); err != nil {
+			warnings = append(warnings, fmt.Sprintf("ServiceProfile \"%s\" spec.dstOverrides[%d].authority: %s", serviceProfile.Name, i, err))
+		}
+	}
+
+	return warnings
+}
+
+// validateServiceRef returns an error if the authority is the fully qualified
+// name of a Service that doesn't exist. Other authorities, like those of
+// services outside the cluster, are ignored.
+func validateServiceRef(api *k8s.API, authority string) error {
+	host := authority
+	if h, _, err := net.SplitHostPort(authority); err == nil {
+		host = h
+	}
+
+	labels := strings.Split(host, ".")
+	if len(labels) < 4 || labels[2] != "svc" {
+		return nil
+	}
+	name, namespace := labels[0], labels[1]
+
+	_, err := api.Svc().Lister().Services(namespace).Get(name)
+	if kerrors.IsNotFound(err) {
+		return fmt.Errorf("Service \"%s\" not found in namespace \"%s\"", name, namespace)
+	}
+	return err
+}
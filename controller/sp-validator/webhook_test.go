@@ -0,0 +1,146 @@
+package validator
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/linkerd/linkerd2/controller/k8s"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+)
+
+const booksService = `
+apiVersion: v1
+kind: Service
+metadata:
+  name: books
+  namespace: booksapp
+spec:
+  ports:
+  - port: 7002`
+
+func TestAdmitSP(t *testing.T) {
+	api, err := k8s.NewFakeAPI(booksService)
+	if err != nil {
+		t.Fatalf("NewFakeAPI returned an error: %s", err)
+	}
+	api.Sync(nil)
+
+	testCases := []struct {
+		sp      string
+		err     string
+		warning string
+	}{
+		{
+			sp: `apiVersion: linkerd.io/v1alpha2
+kind: ServiceProfile
+metadata:
+  name: books.booksapp.svc.cluster.local
+  namespace: booksapp
+spec:
+  routes:
+  - name: GET /books
+    condition:
+      method: GET
+      pathRegex: /books`,
+		},
+		{
+			sp: `apiVersion: linkerd.io/v1alpha2
+kind: ServiceProfile
+metadata:
+  name: api.example.com
+  namespace: booksapp
+spec:
+  routes:
+  - name: GET /books
+    condition:
+      method: GET
+      pathRegex: /books
+  dstOverrides:
+  - authority: books.booksapp.svc.cluster.local:7002
+    weight: 1`,
+		},
+		{
+			sp: `apiVersion: linkerd.io/v1alpha2
+kind: ServiceProfile
+metadata:
+  name: authors.booksapp.svc.cluster.local
+  namespace: booksapp
+spec:
+  routes:
+  - name: GET /authors
+    condition:
+      method: GET
+      pathRegex: /authors`,
+			warning: `ServiceProfile "authors.booksapp.svc.cluster.local" metadata.name: Service "authors" not found in namespace "booksapp"`,
+		},
+		{
+			sp: `apiVersion: linkerd.io/v1alpha2
+kind: ServiceProfile
+metadata:
+  name: books.booksapp.svc.cluster.local
+  namespace: booksapp
+spec:
+  routes:
+  - name: GET /books
+    condition:
+      method: GET
+      pathRegex: /books
+  dstOverrides:
+  - authority: books.booksapp.svc.cluster.local:7002
+    weight: 500m
+  - authority: books-v2.booksapp.svc.cluster.local:7002
+    weight: 500m`,
+			warning: `ServiceProfile "books.booksapp.svc.cluster.local" spec.dstOverrides[1].authority: Service "books-v2" not found in namespace "booksapp"`,
+		},
+		{
+			sp: `apiVersion: linkerd.io/v1alpha2
+kind: ServiceProfile
+metadata:
+  name: books.booksapp.svc.cluster.local
+  namespace: booksapp
+spec:
+  routes:
+  - name: GET /books
+    condition:
+      method: GET
+      pathRegex: /books(`,
+			err: `ServiceProfile "books.booksapp.svc.cluster.local" has a route with an invalid condition: invalid pathRegex "/books(": error parsing regexp: missing closing ): ` + "`/books(`",
+		},
+	}
+
+	for i, tc := range testCases {
+		request := &admissionv1beta1.AdmissionRequest{
+			Object: runtime.RawExtension{Raw: []byte(tc.sp)},
+		}
+		recorder := record.NewFakeRecorder(1)
+		rsp, err := AdmitSP(api, request, recorder)
+		if err != nil {
+			t.Fatalf("test case %d: unexpected error: %s", i, err)
+		}
+
+		if tc.err == "" {
+			if !rsp.Allowed {
+				t.Errorf("test case %d: expected the ServiceProfile to be allowed, got: %s", i, rsp.Result.Message)
+			}
+			select {
+			case event := <-recorder.Events:
+				expected := fmt.Sprintf("Warning %s %s", eventTypeServiceNotFound, tc.warning)
+				if tc.warning == "" || event != expected {
+					t.Errorf("test case %d: expected event %q, got %q", i, expected, event)
+				}
+			default:
+				if tc.warning != "" {
+					t.Errorf("test case %d: expected a warning event", i)
+				}
+			}
+			continue
+		}
+		if rsp.Allowed {
+			t.Errorf("test case %d: expected the ServiceProfile to be denied", i)
+		} else if rsp.Result.Message != tc.err {
+			t.Errorf("test case %d: expected %q, got %q", i, tc.err, rsp.Result.Message)
+		}
+	}
+}
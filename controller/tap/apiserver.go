@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"os"
 
 	"github.com/julienschmidt/httprouter"
 	"github.com/linkerd/linkerd2/controller/gen/controller/tap"
@@ -51,6 +52,7 @@ func NewAPIServer(
 		usernameHeader: usernameHeader,
 		groupHeader:    groupHeader,
 		grpcTapServer:  grpcTapServer,
+		audit:          newAuditLog(os.Stdout),
 		log:            log,
 	}
 
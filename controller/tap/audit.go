@@ -0,0 +1,90 @@
+package tap
+
+import (
+	"io"
+	"time"
+
+	"github.com/linkerd/linkerd2/controller/gen/public"
+	"github.com/sirupsen/logrus"
+)
+
+// auditLog records every tap session, whether it was authorized or not, as a
+// JSON line. It has its own logger so that the records are kept regardless of
+// the log level of the tap component.
+type auditLog struct {
+	log *logrus.Logger
+}
+
+// auditSession is the record of a tap session
+type auditSession struct {
+	user      string
+	groups    []string
+	namespace string
+	resource  string
+	name      string
+	req       *public.TapByResourceRequest
+	start     time.Time
+	events    int
+}
+
+func newAuditLog(w io.Writer) *auditLog {
+	log := logrus.New()
+	log.SetOutput(w)
+	log.SetFormatter(&logrus.JSONFormatter{})
+	log.SetLevel(logrus.InfoLevel)
+	return &auditLog{log: log}
+}
+
+// denied records a tap session that was not authorized
+func (a *auditLog) denied(session *auditSession, reason error) {
+	if a == nil {
+		return
+	}
+	a.log.WithFields(session.fields()).WithField("reason", reason.Error()).Warn("tap denied")
+}
+
+// started records a tap session that was authorized, along with its target
+// and filters, before any event is sent
+func (a *auditLog) started(session *auditSession) {
+	if a == nil {
+		return
+	}
+	a.log.WithFields(session.fields()).Info("tap started")
+}
+
+// ended records a tap session that was authorized, once it's over
+func (a *auditLog) ended(session *auditSession, err error) {
+	if a == nil {
+		return
+	}
+	entry := a.log.WithFields(session.fields()).WithFields(logrus.Fields{
+		"duration": time.Since(session.start).String(),
+		"events":   session.events,
+	})
+	if err != nil {
+		entry = entry.WithField("error", err.Error())
+	}
+	entry.Info("tap ended")
+}
+
+func (s *auditSession) fields() logrus.Fields {
+	fields := logrus.Fields{
+		"audit":     "tap",
+		"user":      s.user,
+		"groups":    s.groups,
+		"namespace": s.namespace,
+		"resource":  s.resource,
+		"name":      s.name,
+		"start":     s.start.UTC().Format(time.RFC3339),
+	}
+	if s.req != nil {
+		fields["selector"] = s.req.GetTarget().GetLabelSelector()
+		if match := s.req.GetMatch(); match != nil {
+			fields["match"] = match.String()
+		}
+		if filter := s.req.GetFilter(); filter != nil {
+			fields["filter"] = filter.String()
+		}
+	}
+	return fields
+}
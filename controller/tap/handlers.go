@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/go-openapi/spec"
 	"github.com/julienschmidt/httprouter"
@@ -28,6 +29,7 @@ type handler struct {
 	usernameHeader string
 	groupHeader    string
 	grpcTapServer  pb.TapServer
+	audit          *auditLog
 	log            *logrus.Entry
 }
 
@@ -125,6 +127,15 @@ func (h *handler) handleTap(w http.ResponseWriter, req *http.Request, p httprout
 		namespace, resource, name, req.Header.Get(h.usernameHeader), req.Header[h.groupHeader],
 	)
 
+	session := &auditSession{
+		user:      req.Header.Get(h.usernameHeader),
+		groups:    req.Header[h.groupHeader],
+		namespace: namespace,
+		resource:  resource,
+		name:      name,
+		start:     time.Now(),
+	}
+
 	// TODO: it's possible this SubjectAccessReview is redundant, consider
 	// removing, more info at https://github.com/linkerd/linkerd2/issues/3182
 	err := pkgK8s.ResourceAuthzForUser(
@@ -140,6 +151,7 @@ func (h *handler) handleTap(w http.ResponseWriter, req *http.Request, p httprout
 		req.Header[h.groupHeader],
 	)
 	if err != nil {
+		h.audit.denied(session, err)
 		err = fmt.Errorf("tap authorization failed (%s), visit %s for more information", err, tap.TapRbacURL)
 		h.log.Error(err)
 		renderJSONError(w, err, http.StatusForbidden)
@@ -170,8 +182,12 @@ func (h *handler) handleTap(w http.ResponseWriter, req *http.Request, p httprout
 		return
 	}
 
+	session.req = &tapReq
+	h.audit.started(session)
 	serverStream := serverStream{w: flushableWriter, req: req, log: h.log}
 	err = h.grpcTapServer.TapByResource(&tapReq, &serverStream)
+	session.events = serverStream.events
+	h.audit.ended(session, err)
 	if err != nil {
 		h.log.Error(err)
 		protohttp.WriteErrorToHTTPResponse(flushableWriter, err)
@@ -373,6 +389,9 @@ type serverStream struct {
 	w   protohttp.FlushableResponseWriter
 	req *http.Request
 	log *logrus.Entry
+
+	// events is the number of events sent
+	events int
 }
 
 // Satisfy the grpc.ServerStream interface
@@ -393,5 +412,6 @@ func (s *serverStream) Send(m *public.TapEvent) error {
 	}
 
 	s.w.Flush()
+	s.events++
 	return nil
 }
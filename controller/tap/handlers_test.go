@@ -1,14 +1,18 @@
 package tap
 
 import (
+	"bytes"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/julienschmidt/httprouter"
+	"github.com/linkerd/linkerd2/controller/gen/public"
 	"github.com/linkerd/linkerd2/controller/k8s"
 	"github.com/sirupsen/logrus"
 )
@@ -20,6 +24,7 @@ func TestHandleTap(t *testing.T) {
 		code   int
 		header http.Header
 		body   string
+		audit  []string
 	}{
 		{
 			req: &http.Request{
@@ -40,6 +45,7 @@ func TestHandleTap(t *testing.T) {
 			code:   http.StatusForbidden,
 			header: http.Header{"Content-Type": []string{"application/json"}},
 			body:   `{"error":"tap authorization failed (not authorized to access namespaces.tap.linkerd.io), visit https://linkerd.io/tap-rbac for more information"}`,
+			audit:  []string{`"audit":"tap"`, `"msg":"tap denied"`, `"resource":"namespaces"`, `"reason":"not authorized to access namespaces.tap.linkerd.io"`},
 		},
 	}
 
@@ -52,8 +58,10 @@ func TestHandleTap(t *testing.T) {
 				t.Fatalf("NewFakeAPI returned an error: %s", err)
 			}
 
+			audit := &bytes.Buffer{}
 			h := &handler{
 				k8sAPI: k8sAPI,
+				audit:  newAuditLog(audit),
 				log:    logrus.WithField("test", t.Name()),
 			}
 			recorder := httptest.NewRecorder()
@@ -68,6 +76,41 @@ func TestHandleTap(t *testing.T) {
 			if recorder.Body.String() != exp.body {
 				t.Errorf("Unexpected body: %s, expected: %s", recorder.Body.String(), exp.body)
 			}
+			if len(exp.audit) == 0 && audit.Len() != 0 {
+				t.Errorf("Unexpected audit record: %s", audit.String())
+			}
+			for _, field := range exp.audit {
+				if !strings.Contains(audit.String(), field) {
+					t.Errorf("Expected audit record to contain %s, got: %s", field, audit.String())
+				}
+			}
 		})
 	}
 }
+
+func TestAuditLog(t *testing.T) {
+	var buf bytes.Buffer
+	audit := newAuditLog(&buf)
+	session := &auditSession{
+		user:      "alice",
+		groups:    []string{"devs"},
+		namespace: "emojivoto",
+		resource:  "deployments",
+		name:      "web",
+		req: &public.TapByResourceRequest{
+			Target: &public.ResourceSelection{LabelSelector: "app=web"},
+			Filter: &public.TapByResourceRequest_Filter{StatusClass: 5},
+		},
+		start: time.Now(),
+	}
+
+	audit.started(session)
+	for _, field := range []string{`"msg":"tap started"`, `"user":"alice"`, `"namespace":"emojivoto"`, `"resource":"deployments"`, `"name":"web"`, `"selector":"app=web"`, `"filter":"statusClass:5"`} {
+		if !strings.Contains(buf.String(), field) {
+			t.Errorf("Expected the audit record to contain %s, got: %s", field, buf.String())
+		}
+	}
+
+	var nilAudit *auditLog
+	nilAudit.started(session)
+}
@@ -0,0 +1,398 @@
+package tap
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/linkerd/linkerd2/controller/gen/public"
+	"github.com/linkerd/linkerd2/pkg/addr"
+	pkgK8s "github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/linkerd/linkerd2/pkg/tap"
+	log "github.com/sirupsen/logrus"
+)
+
+// The exporter below sends the tap events to an OpenTelemetry collector, as
+// spans encoded in the JSON flavour of OTLP/HTTP:
+// https://github.com/open-telemetry/opentelemetry-proto/blob/main/docs/specification.md
+
+const (
+	otlpTracesPath     = "/v1/traces"
+	otlpScopeName      = "linkerd-tap"
+	otlpBatchSize      = 512
+	otlpFlushInterval  = 5 * time.Second
+	otlpQueueSize      = 4096
+	otlpExportTimeout  = 10 * time.Second
+	otlpDefaultService = "linkerd-proxy"
+
+	spanKindServer = 2
+	spanKindClient = 3
+
+	statusCodeError = 2
+)
+
+// resourceLabels are the labels naming the workload of a proxy, in order of
+// precedence, used as the service name of its spans
+var resourceLabels = []string{
+	pkgK8s.Deployment,
+	pkgK8s.StatefulSet,
+	pkgK8s.DaemonSet,
+	pkgK8s.ReplicationController,
+	pkgK8s.Job,
+	pkgK8s.CronJob,
+	pkgK8s.Pod,
+}
+
+type otlpKeyValue struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+type otlpAnyValue struct {
+	StringValue *string `json:"stringValue,omitempty"`
+	IntValue    *string `json:"intValue,omitempty"`
+}
+
+type otlpSpan struct {
+	TraceID           string         `json:"traceId"`
+	SpanID            string         `json:"spanId"`
+	ParentSpanID      string         `json:"parentSpanId,omitempty"`
+	Name              string         `json:"name"`
+	Kind              int            `json:"kind"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+	Attributes        []otlpKeyValue `json:"attributes"`
+	Status            otlpStatus     `json:"status"`
+
+	service string
+	started time.Time
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope   `json:"scope"`
+	Spans []*otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpExportRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+// spanExporter batches the spans built from tap events and sends them to an
+// OTLP/HTTP endpoint. Spans are dropped when the queue is full, so that a slow
+// collector never slows tap down.
+type spanExporter struct {
+	endpoint string
+	client   *http.Client
+	spans    chan *otlpSpan
+}
+
+// newSpanExporter returns a spanExporter sending the spans to the collector
+// at endpoint, e.g. http://collector:4318. It returns nil if endpoint is
+// empty.
+func newSpanExporter(endpoint string) *spanExporter {
+	if endpoint == "" {
+		return nil
+	}
+	return &spanExporter{
+		endpoint: strings.TrimSuffix(endpoint, "/") + otlpTracesPath,
+		client:   &http.Client{Timeout: otlpExportTimeout},
+		spans:    make(chan *otlpSpan, otlpQueueSize),
+	}
+}
+
+// Run sends the queued spans in batches until ctx is done
+func (e *spanExporter) Run(ctx context.Context) {
+	ticker := time.NewTicker(otlpFlushInterval)
+	defer ticker.Stop()
+
+	var batch []*otlpSpan
+	for {
+		select {
+		case <-ctx.Done():
+			e.export(batch)
+			return
+		case span := <-e.spans:
+			batch = append(batch, span)
+			if len(batch) >= otlpBatchSize {
+				e.export(batch)
+				batch = nil
+			}
+		case <-ticker.C:
+			e.export(batch)
+			batch = nil
+		}
+	}
+}
+
+func (e *spanExporter) enqueue(span *otlpSpan) {
+	select {
+	case e.spans <- span:
+	default:
+		log.Debugf("dropping span: the export queue is full")
+	}
+}
+
+func (e *spanExporter) export(batch []*otlpSpan) {
+	if len(batch) == 0 {
+		return
+	}
+
+	body, err := json.Marshal(buildExportRequest(batch))
+	if err != nil {
+		log.Errorf("failed to encode %d spans: %s", len(batch), err)
+		return
+	}
+	rsp, err := e.client.Post(e.endpoint, "application/json", bytes.NewReader(body))
+	if err != nil {
+		log.Warnf("failed to export %d spans to %s: %s", len(batch), e.endpoint, err)
+		return
+	}
+	rsp.Body.Close()
+	if rsp.StatusCode != http.StatusOK {
+		log.Warnf("failed to export %d spans to %s: %s", len(batch), e.endpoint, rsp.Status)
+	}
+}
+
+// buildExportRequest groups the spans by the workload that reported them
+func buildExportRequest(batch []*otlpSpan) otlpExportRequest {
+	req := otlpExportRequest{}
+	byService := map[string]int{}
+	for _, span := range batch {
+		i, ok := byService[span.service]
+		if !ok {
+			i = len(req.ResourceSpans)
+			byService[span.service] = i
+			req.ResourceSpans = append(req.ResourceSpans, otlpResourceSpans{
+				Resource: otlpResource{
+					Attributes: []otlpKeyValue{stringAttribute("service.name", span.service)},
+				},
+				ScopeSpans: []otlpScopeSpans{{Scope: otlpScope{Name: otlpScopeName}}},
+			})
+		}
+		scope := &req.ResourceSpans[i].ScopeSpans[0]
+		scope.Spans = append(scope.Spans, span)
+	}
+	return req
+}
+
+// spanSession pairs the events of the streams of a tap session into spans
+type spanSession struct {
+	exporter *spanExporter
+	now      func() time.Time
+	pending  map[string]*otlpSpan
+}
+
+// newSession returns a spanSession for a new tap session, or nil if e is nil
+func (e *spanExporter) newSession() *spanSession {
+	if e == nil {
+		return nil
+	}
+	return &spanSession{
+		exporter: e,
+		now:      time.Now,
+		pending:  map[string]*otlpSpan{},
+	}
+}
+
+// observe builds the span of the stream of event, and queues it for export
+// once the stream ended
+func (s *spanSession) observe(event *public.TapEvent) {
+	if s == nil {
+		return
+	}
+
+	switch ev := event.GetHttp().GetEvent().(type) {
+	case *public.TapEvent_Http_RequestInit_:
+		if len(s.pending) >= maxPendingSpans {
+			s.prune()
+		}
+		if len(s.pending) >= maxPendingSpans {
+			log.Debugf("dropping span: too many pending spans")
+			return
+		}
+		s.pending[spanKey(event, ev.RequestInit.GetId())] = s.startSpan(event, ev.RequestInit)
+
+	case *public.TapEvent_Http_ResponseInit_:
+		span, ok := s.pending[spanKey(event, ev.ResponseInit.GetId())]
+		if !ok {
+			return
+		}
+		status := ev.ResponseInit.GetHttpStatus()
+		span.Attributes = append(span.Attributes, intAttribute("http.status_code", int64(status)))
+		if status >= 500 {
+			span.Status = otlpStatus{Code: statusCodeError}
+		}
+
+	case *public.TapEvent_Http_ResponseEnd_:
+		key := spanKey(event, ev.ResponseEnd.GetId())
+		span, ok := s.pending[key]
+		if !ok {
+			return
+		}
+		delete(s.pending, key)
+
+		start, _ := strconv.ParseInt(span.StartTimeUnixNano, 10, 64)
+		if latency, err := ptypes.Duration(ev.ResponseEnd.GetSinceRequestInit()); err == nil {
+			span.EndTimeUnixNano = strconv.FormatInt(start+latency.Nanoseconds(), 10)
+		} else {
+			span.EndTimeUnixNano = strconv.FormatInt(s.now().UnixNano(), 10)
+		}
+		span.Attributes = append(span.Attributes, intAttribute("http.response_content_length", int64(ev.ResponseEnd.GetResponseBytes())))
+		switch eos := ev.ResponseEnd.GetEos().GetEnd().(type) {
+		case *public.Eos_GrpcStatusCode:
+			span.Attributes = append(span.Attributes, intAttribute("rpc.grpc.status_code", int64(eos.GrpcStatusCode)))
+			if eos.GrpcStatusCode != 0 {
+				span.Status = otlpStatus{Code: statusCodeError}
+			}
+		case *public.Eos_ResetErrorCode:
+			span.Status = otlpStatus{Code: statusCodeError, Message: fmt.Sprintf("stream reset with error code %d", eos.ResetErrorCode)}
+		}
+
+		s.exporter.enqueue(span)
+	}
+}
+
+const (
+	// maxPendingSpans bounds the number of streams a session waits for the
+	// end of
+	maxPendingSpans = 1000
+
+	// pendingSpanTimeout is how long a session waits for the end of a stream,
+	// e.g. when the proxy ended the tap in between
+	pendingSpanTimeout = time.Minute
+)
+
+// prune drops the spans of the streams pending for longer than
+// pendingSpanTimeout
+func (s *spanSession) prune() {
+	for key, span := range s.pending {
+		if s.now().Sub(span.started) > pendingSpanTimeout {
+			delete(s.pending, key)
+		}
+	}
+}
+
+func (s *spanSession) startSpan(event *public.TapEvent, req *public.TapEvent_Http_RequestInit) *otlpSpan {
+	method := tap.MethodString(req.GetMethod())
+	scheme := strings.ToLower(tap.SchemeString(req.GetScheme()))
+
+	started := s.now()
+	span := &otlpSpan{
+		Name:              fmt.Sprintf("%s %s", method, req.GetPath()),
+		Kind:              spanKindClient,
+		StartTimeUnixNano: strconv.FormatInt(started.UnixNano(), 10),
+		Attributes: []otlpKeyValue{
+			stringAttribute("http.method", method),
+			stringAttribute("http.scheme", scheme),
+			stringAttribute("http.host", req.GetAuthority()),
+			stringAttribute("http.target", req.GetPath()),
+			stringAttribute("linkerd.proxy_direction", event.GetProxyDirection().String()),
+			stringAttribute("net.peer.ip", addr.PublicIPToString(event.GetDestination().GetIp())),
+		},
+	}
+	labels := event.GetSourceMeta().GetLabels()
+	peerLabels := event.GetDestinationMeta().GetLabels()
+	if event.GetProxyDirection() == public.TapEvent_INBOUND {
+		span.Kind = spanKindServer
+		labels, peerLabels = peerLabels, labels
+		span.Attributes[len(span.Attributes)-1] = stringAttribute("net.peer.ip", addr.PublicIPToString(event.GetSource().GetIp()))
+	}
+	span.service = serviceName(labels)
+	span.started = started
+	if peer := serviceName(peerLabels); peer != otlpDefaultService {
+		span.Attributes = append(span.Attributes, stringAttribute("peer.service", peer))
+	}
+	if ns := labels[pkgK8s.Namespace]; ns != "" {
+		span.Attributes = append(span.Attributes, stringAttribute("k8s.namespace.name", ns))
+	}
+
+	span.TraceID, span.ParentSpanID = traceContext(req.GetHeaders())
+	if span.TraceID == "" {
+		span.TraceID = randomID(16)
+		span.ParentSpanID = ""
+	}
+	span.SpanID = randomID(8)
+
+	return span
+}
+
+// traceContext returns the trace and span IDs propagated by a request, with
+// the W3C or B3 headers, so that its span joins the trace of the application
+func traceContext(headers *public.Headers) (traceID, parentSpanID string) {
+	values := map[string]string{}
+	for _, h := range headers.GetHeaders() {
+		values[strings.ToLower(h.GetName())] = h.GetValueStr()
+	}
+
+	// traceparent: version-traceid-parentid-flags
+	if parts := strings.Split(values["traceparent"], "-"); len(parts) == 4 && len(parts[1]) == 32 && len(parts[2]) == 16 {
+		return parts[1], parts[2]
+	}
+	if traceID := values["x-b3-traceid"]; traceID != "" {
+		if len(traceID) == 16 {
+			traceID = strings.Repeat("0", 16) + traceID
+		}
+		return traceID, values["x-b3-spanid"]
+	}
+	return "", ""
+}
+
+func serviceName(labels map[string]string) string {
+	for _, label := range resourceLabels {
+		if name := labels[label]; name != "" {
+			return name
+		}
+	}
+	return otlpDefaultService
+}
+
+func spanKey(event *public.TapEvent, id *public.TapEvent_Http_StreamId) string {
+	return fmt.Sprintf("%s/%s/%s/%d/%d",
+		event.GetProxyDirection(),
+		addr.PublicAddressToString(event.GetSource()),
+		addr.PublicAddressToString(event.GetDestination()),
+		id.GetBase(), id.GetStream())
+}
+
+func randomID(n int) string {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		log.Errorf("failed to generate a span ID: %s", err)
+	}
+	return hex.EncodeToString(b)
+}
+
+func stringAttribute(key, value string) otlpKeyValue {
+	return otlpKeyValue{Key: key, Value: otlpAnyValue{StringValue: &value}}
+}
+
+func intAttribute(key string, value int64) otlpKeyValue {
+	s := strconv.FormatInt(value, 10)
+	return otlpKeyValue{Key: key, Value: otlpAnyValue{IntValue: &s}}
+}
@@ -0,0 +1,224 @@
+package tap
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/linkerd/linkerd2/controller/gen/public"
+	"github.com/linkerd/linkerd2/pkg/addr"
+)
+
+func otlpTestEvents() []*public.TapEvent {
+	id := &public.TapEvent_Http_StreamId{Base: 1, Stream: 2}
+	event := func(http *public.TapEvent_Http) *public.TapEvent {
+		return &public.TapEvent{
+			Source:          &public.TcpAddress{Ip: addr.PublicIPV4(10, 0, 0, 1), Port: 34000},
+			Destination:     &public.TcpAddress{Ip: addr.PublicIPV4(10, 0, 0, 2), Port: 8080},
+			SourceMeta:      &public.TapEvent_EndpointMeta{Labels: map[string]string{"deployment": "web", "namespace": "emojivoto"}},
+			DestinationMeta: &public.TapEvent_EndpointMeta{Labels: map[string]string{"deployment": "voting", "namespace": "emojivoto"}},
+			ProxyDirection:  public.TapEvent_INBOUND,
+			Event:           &public.TapEvent_Http_{Http: http},
+		}
+	}
+
+	return []*public.TapEvent{
+		event(&public.TapEvent_Http{
+			Event: &public.TapEvent_Http_RequestInit_{
+				RequestInit: &public.TapEvent_Http_RequestInit{
+					Id:        id,
+					Method:    &public.HttpMethod{Type: &public.HttpMethod_Registered_{Registered: public.HttpMethod_POST}},
+					Scheme:    &public.Scheme{Type: &public.Scheme_Registered_{Registered: public.Scheme_HTTP}},
+					Authority: "voting-svc:8080",
+					Path:      "/emojivoto.v1.VotingService/VoteDoughnut",
+					Headers: &public.Headers{Headers: []*public.Headers_Header{
+						{Name: "traceparent", Value: &public.Headers_Header_ValueStr{ValueStr: "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"}},
+					}},
+				},
+			},
+		}),
+		event(&public.TapEvent_Http{
+			Event: &public.TapEvent_Http_ResponseInit_{
+				ResponseInit: &public.TapEvent_Http_ResponseInit{Id: id, HttpStatus: 200},
+			},
+		}),
+		event(&public.TapEvent_Http{
+			Event: &public.TapEvent_Http_ResponseEnd_{
+				ResponseEnd: &public.TapEvent_Http_ResponseEnd{
+					Id:               id,
+					SinceRequestInit: ptypes.DurationProto(1500 * time.Microsecond),
+					Eos:              &public.Eos{End: &public.Eos_GrpcStatusCode{GrpcStatusCode: 2}},
+					ResponseBytes:    42,
+				},
+			},
+		}),
+	}
+}
+
+func TestSpanExporter(t *testing.T) {
+	requests := make(chan otlpExportRequest, 1)
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != otlpTracesPath {
+			t.Errorf("Expected the spans to be sent to %s, got %s", otlpTracesPath, r.URL.Path)
+		}
+		req := otlpExportRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("Unexpected error decoding the spans: %s", err)
+		}
+		requests <- req
+	}))
+	defer collector.Close()
+
+	exporter := newSpanExporter(collector.URL + "/")
+	session := exporter.newSession()
+	session.now = func() time.Time { return time.Unix(1000, 0) }
+	for _, event := range otlpTestEvents() {
+		session.observe(event)
+	}
+	if len(session.pending) != 0 {
+		t.Fatalf("Expected no pending span, got %d", len(session.pending))
+	}
+
+	exporter.export([]*otlpSpan{<-exporter.spans})
+	req := <-requests
+
+	if len(req.ResourceSpans) != 1 {
+		t.Fatalf("Expected 1 resource, got %d", len(req.ResourceSpans))
+	}
+	resource := req.ResourceSpans[0]
+	if service := *resource.Resource.Attributes[0].Value.StringValue; service != "voting" {
+		t.Errorf("Expected service.name to be voting, got %s", service)
+	}
+	if len(resource.ScopeSpans) != 1 || len(resource.ScopeSpans[0].Spans) != 1 {
+		t.Fatalf("Expected 1 span, got %+v", resource.ScopeSpans)
+	}
+
+	span := resource.ScopeSpans[0].Spans[0]
+	if span.TraceID != "0af7651916cd43dd8448eb211c80319c" || span.ParentSpanID != "b7ad6b7169203331" {
+		t.Errorf("Expected the span to join the propagated trace, got trace %s and parent %s", span.TraceID, span.ParentSpanID)
+	}
+	if len(span.SpanID) != 16 {
+		t.Errorf("Expected a 8 bytes span ID, got %s", span.SpanID)
+	}
+	if span.Name != "POST /emojivoto.v1.VotingService/VoteDoughnut" {
+		t.Errorf("Unexpected span name %s", span.Name)
+	}
+	if span.Kind != spanKindServer {
+		t.Errorf("Expected an inbound span to be a server span, got kind %d", span.Kind)
+	}
+	if span.StartTimeUnixNano != "1000000000000" || span.EndTimeUnixNano != "1000001500000" {
+		t.Errorf("Unexpected span times %s-%s", span.StartTimeUnixNano, span.EndTimeUnixNano)
+	}
+	if span.Status.Code != statusCodeError {
+		t.Errorf("Expected a failed gRPC call to be an error span, got status %+v", span.Status)
+	}
+
+	attributes := map[string]string{}
+	for _, kv := range span.Attributes {
+		if kv.Value.StringValue != nil {
+			attributes[kv.Key] = *kv.Value.StringValue
+		} else {
+			attributes[kv.Key] = *kv.Value.IntValue
+		}
+	}
+	expectedAttributes := map[string]string{
+		"http.method":                  "POST",
+		"http.scheme":                  "http",
+		"http.host":                    "voting-svc:8080",
+		"http.target":                  "/emojivoto.v1.VotingService/VoteDoughnut",
+		"http.status_code":             "200",
+		"http.response_content_length": "42",
+		"rpc.grpc.status_code":         "2",
+		"linkerd.proxy_direction":      "INBOUND",
+		"net.peer.ip":                  "10.0.0.1",
+		"peer.service":                 "web",
+		"k8s.namespace.name":           "emojivoto",
+	}
+	for key, expected := range expectedAttributes {
+		if attributes[key] != expected {
+			t.Errorf("Expected attribute %s to be %q, got %q", key, expected, attributes[key])
+		}
+	}
+}
+
+func TestTraceContext(t *testing.T) {
+	header := func(name, value string) *public.Headers_Header {
+		return &public.Headers_Header{Name: name, Value: &public.Headers_Header_ValueStr{ValueStr: value}}
+	}
+
+	testCases := []struct {
+		headers []*public.Headers_Header
+		traceID string
+		spanID  string
+	}{
+		{
+			headers: []*public.Headers_Header{header("Traceparent", "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01")},
+			traceID: "0af7651916cd43dd8448eb211c80319c",
+			spanID:  "b7ad6b7169203331",
+		},
+		{
+			headers: []*public.Headers_Header{header("x-b3-traceid", "463ac35c9f6413ad"), header("x-b3-spanid", "a2fb4a1d1a96d312")},
+			traceID: "0000000000000000463ac35c9f6413ad",
+			spanID:  "a2fb4a1d1a96d312",
+		},
+		{
+			headers: []*public.Headers_Header{header("traceparent", "invalid")},
+		},
+		{},
+	}
+
+	for i, tc := range testCases {
+		tc := tc // pin
+		traceID, spanID := traceContext(&public.Headers{Headers: tc.headers})
+		if traceID != tc.traceID || spanID != tc.spanID {
+			t.Errorf("test case %d: expected %q/%q, got %q/%q", i, tc.traceID, tc.spanID, traceID, spanID)
+		}
+	}
+}
+
+func TestSpanSessionExpiresPendingSpans(t *testing.T) {
+	exporter := newSpanExporter("http://collector:4318")
+	session := exporter.newSession()
+	now := time.Unix(1000, 0)
+	session.now = func() time.Time { return now }
+
+	requestInit := func(stream uint64) *public.TapEvent {
+		return &public.TapEvent{
+			Event: &public.TapEvent_Http_{Http: &public.TapEvent_Http{
+				Event: &public.TapEvent_Http_RequestInit_{
+					RequestInit: &public.TapEvent_Http_RequestInit{Id: &public.TapEvent_Http_StreamId{Stream: stream}},
+				},
+			}},
+		}
+	}
+
+	// streams that never get a response end
+	for i := 0; i < maxPendingSpans; i++ {
+		session.observe(requestInit(uint64(i)))
+	}
+	session.observe(requestInit(maxPendingSpans))
+	if len(session.pending) != maxPendingSpans {
+		t.Fatalf("Expected %d pending spans, got %d", maxPendingSpans, len(session.pending))
+	}
+
+	now = now.Add(pendingSpanTimeout + time.Second)
+	session.observe(requestInit(maxPendingSpans))
+	if len(session.pending) != 1 {
+		t.Fatalf("Expected the stale spans to expire, got %d pending spans", len(session.pending))
+	}
+}
+
+func TestNewSpanExporterDisabled(t *testing.T) {
+	exporter := newSpanExporter("")
+	if exporter != nil {
+		t.Fatalf("Expected no exporter without endpoint, got %+v", exporter)
+	}
+	// a nil session ignores the events
+	session := exporter.newSession()
+	for _, event := range otlpTestEvents() {
+		session.observe(event)
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/linkerd/linkerd2/pkg/addr"
 	pkgK8s "github.com/linkerd/linkerd2/pkg/k8s"
 	"github.com/linkerd/linkerd2/pkg/prometheus"
+	"github.com/linkerd/linkerd2/pkg/tap"
 	"github.com/linkerd/linkerd2/pkg/util"
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
@@ -38,6 +39,8 @@ type GRPCTapServer struct {
 	k8sAPI              *k8s.API
 	controllerNamespace string
 	trustDomain         string
+	maxEventsPerSecond  float64
+	spans               *spanExporter
 }
 
 var (
@@ -107,17 +110,18 @@ func (s *GRPCTapServer) TapByResource(req *public.TapByResourceRequest, stream p
 
 	events := make(chan *public.TapEvent)
 
-	// divide the rps evenly between all pods to tap
-	rpsPerPod := req.GetMaxRps() / float32(len(pods))
-	if rpsPerPod < 1 {
-		rpsPerPod = 1
-	}
+	rpsPerPod := tappedRpsPerPod(req, len(pods))
 
 	match, err := makeByResourceMatch(req.GetMatch())
 	if err != nil {
 		return apiUtil.GRPCError(err)
 	}
 
+	filter, err := tap.NewFilter(req, s.maxEventsPerSecond)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
 	extract := &proxy.ObserveRequest_Extract{}
 
 	// HTTP is the only protocol supported for extracting metadata, so this is
@@ -141,15 +145,18 @@ func (s *GRPCTapServer) TapByResource(req *public.TapByResourceRequest, stream p
 		ctx = metadata.AppendToOutgoingContext(ctx, pkgK8s.RequireIDHeader, name)
 
 		// initiate a tap on the pod
-		go s.tapProxy(ctx, rpsPerPod, match, extract, pod.Status.PodIP, events)
+		go s.tapProxy(ctx, rpsPerPod, match, extract, filter.NewStreams(), pod.Status.PodIP, events)
 	}
 
+	spans := s.spans.newSession()
+
 	// read events from the taps and send them back
 	for {
 		select {
 		case <-stream.Context().Done():
 			return nil
 		case event := <-events:
+			spans.observe(event)
 			err := stream.Send(event)
 			if err != nil {
 				return apiUtil.GRPCError(err)
@@ -219,6 +226,17 @@ func makeByResourceMatch(match *public.TapByResourceRequest_Match) (*proxy.Obser
 						},
 					},
 				}
+			case *public.TapByResourceRequest_Match_Http_GrpcMethod:
+				// gRPC requests are routed by the :path pseudo-header
+				httpMatch = proxy.ObserveRequest_Match_Http{
+					Match: &proxy.ObserveRequest_Match_Http_Path{
+						Path: &proxy.ObserveRequest_Match_Http_StringMatch{
+							Match: &proxy.ObserveRequest_Match_Http_StringMatch_Exact{
+								Exact: "/" + httpTyped.GrpcMethod,
+							},
+						},
+					},
+				}
 			default:
 				return nil, status.Errorf(codes.Unimplemented, "unknown HTTP match type: %v", httpTyped)
 			}
@@ -256,6 +274,20 @@ func destinationLabels(resource *public.Resource) map[string]string {
 	return dstLabels
 }
 
+// tappedRpsPerPod divides the rps evenly between all the pods to tap. The
+// streams are sampled once reported by the proxies, so they report enough of
+// them for maxRps to be left after sampling.
+func tappedRpsPerPod(req *public.TapByResourceRequest, pods int) float32 {
+	rpsPerPod := req.GetMaxRps() / float32(pods)
+	if rate := req.GetSampleRate(); rate > 0 && rate < 1 {
+		rpsPerPod /= rate
+	}
+	if rpsPerPod < 1 {
+		rpsPerPod = 1
+	}
+	return rpsPerPod
+}
+
 func buildExtractHTTP(extract *public.TapByResourceRequest_Extract_Http) *proxy.ObserveRequest_Extract {
 	if extract.GetHeaders() != nil {
 		return &proxy.ObserveRequest_Extract{
@@ -279,7 +311,9 @@ func buildExtractHTTP(extract *public.TapByResourceRequest_Extract_Http) *proxy.
 // of maxRps * 1s at most once per 1s window.  If this limit is reached in
 // less than 1s, we sleep until the end of the window before calling Observe
 // again.
-func (s *GRPCTapServer) tapProxy(ctx context.Context, maxRps float32, match *proxy.ObserveRequest_Match, extract *proxy.ObserveRequest_Extract, addr string, events chan *public.TapEvent) {
+// The events are then filtered and sampled by streams, if the request has a
+// filter or a sample rate.
+func (s *GRPCTapServer) tapProxy(ctx context.Context, maxRps float32, match *proxy.ObserveRequest_Match, extract *proxy.ObserveRequest_Extract, streams *tap.Streams, addr string, events chan *public.TapEvent) {
 	tapAddr := fmt.Sprintf("%s:%d", addr, s.tapPort)
 	log.Infof("Establishing tap on %s", tapAddr)
 	conn, err := grpc.DialContext(ctx, tapAddr, grpc.WithInsecure())
@@ -317,12 +351,14 @@ func (s *GRPCTapServer) tapProxy(ctx context.Context, maxRps float32, match *pro
 
 			translatedEvent := s.translateEvent(event)
 
-			select {
-			case <-ctx.Done():
-				log.Debugf("[%s] client terminated the stream", addr)
-				return
-			default:
-				events <- translatedEvent
+			for _, filteredEvent := range streams.Filter(translatedEvent) {
+				select {
+				case <-ctx.Done():
+					log.Debugf("[%s] client terminated the stream", addr)
+					return
+				default:
+					events <- filteredEvent
+				}
 			}
 		}
 		if time.Now().Before(windowEnd) {
@@ -509,23 +545,26 @@ func (s *GRPCTapServer) translateEvent(orig *proxy.TapEvent) *public.TapEvent {
 	return ev
 }
 
-// NewGrpcTapServer creates a new gRPC Tap server
+// NewGrpcTapServer creates a new gRPC Tap server, limiting the events sent to
+// each client to maxEventsPerSecond, unless it's 0
 func NewGrpcTapServer(
 	tapPort uint,
 	controllerNamespace string,
 	trustDomain string,
+	maxEventsPerSecond float64,
 	k8sAPI *k8s.API,
 ) *GRPCTapServer {
 	k8sAPI.Pod().Informer().AddIndexers(cache.Indexers{ipIndex: indexByIP})
 	k8sAPI.Node().Informer().AddIndexers(cache.Indexers{ipIndex: indexByIP})
 
-	return newGRPCTapServer(tapPort, controllerNamespace, trustDomain, k8sAPI)
+	return newGRPCTapServer(tapPort, controllerNamespace, trustDomain, maxEventsPerSecond, k8sAPI)
 }
 
 func newGRPCTapServer(
 	tapPort uint,
 	controllerNamespace string,
 	trustDomain string,
+	maxEventsPerSecond float64,
 	k8sAPI *k8s.API,
 ) *GRPCTapServer {
 	srv := &GRPCTapServer{
@@ -533,6 +572,7 @@ func newGRPCTapServer(
 		k8sAPI:              k8sAPI,
 		controllerNamespace: controllerNamespace,
 		trustDomain:         trustDomain,
+		maxEventsPerSecond:  maxEventsPerSecond,
 	}
 
 	s := prometheus.NewGrpcServer()
@@ -541,6 +581,16 @@ func newGRPCTapServer(
 	return srv
 }
 
+// ExportSpans makes the server export the tapped requests as spans to the
+// OTLP/HTTP collector at endpoint, until ctx is done. It must be called before
+// the server starts serving.
+func (s *GRPCTapServer) ExportSpans(ctx context.Context, endpoint string) {
+	s.spans = newSpanExporter(endpoint)
+	if s.spans != nil {
+		go s.spans.Run(ctx)
+	}
+}
+
 func indexByIP(obj interface{}) ([]string, error) {
 	switch v := obj.(type) {
 	case *corev1.Pod:
@@ -376,7 +376,7 @@ status:
 				t.Fatalf("Invalid port: %s", port)
 			}
 
-			fakeGrpcServer := newGRPCTapServer(uint(tapPort), "controller-ns", "cluster.local", k8sAPI)
+			fakeGrpcServer := newGRPCTapServer(uint(tapPort), "controller-ns", "cluster.local", 0, k8sAPI)
 
 			k8sAPI.Sync(nil)
 
@@ -404,6 +404,26 @@ status:
 	}
 }
 
+func TestTappedRpsPerPod(t *testing.T) {
+	testCases := []struct {
+		req      *public.TapByResourceRequest
+		pods     int
+		expected float32
+	}{
+		{&public.TapByResourceRequest{MaxRps: 100}, 4, 25},
+		{&public.TapByResourceRequest{MaxRps: 100, SampleRate: 0.1}, 4, 250},
+		{&public.TapByResourceRequest{MaxRps: 100, SampleRate: 1}, 4, 25},
+		{&public.TapByResourceRequest{MaxRps: 1, SampleRate: 0.5}, 4, 1},
+		{&public.TapByResourceRequest{MaxRps: 1}, 4, 1},
+	}
+
+	for i, tc := range testCases {
+		if actual := tappedRpsPerPod(tc.req, tc.pods); actual != tc.expected {
+			t.Errorf("test case %d: expected %v rps per pod, got %v", i, tc.expected, actual)
+		}
+	}
+}
+
 func TestHydrateIPLabels(t *testing.T) {
 	expectations := []struct {
 		k8sRes      []string
@@ -611,7 +631,7 @@ status:
 			if err != nil {
 				t.Fatalf("NewFakeAPI returned an error: %s", err)
 			}
-			s := NewGrpcTapServer(4190, "controller-ns", "cluster.local", k8sAPI)
+			s := NewGrpcTapServer(4190, "controller-ns", "cluster.local", 0, k8sAPI)
 			k8sAPI.Sync(nil)
 
 			labels := make(map[string]string)
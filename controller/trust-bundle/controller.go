@@ -0,0 +1,269 @@
+package trustbundle
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/linkerd/linkerd2/controller/k8s"
+	"github.com/linkerd/linkerd2/pkg/config"
+	pkgK8s "github.com/linkerd/linkerd2/pkg/k8s"
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/informers"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+)
+
+const (
+	// allNamespaces is queued when the trust anchors change, to update the
+	// bundle of every selected namespace
+	allNamespaces = ""
+
+	maxRetries = 5
+
+	resyncPeriod = 10 * time.Minute
+)
+
+// Controller publishes the trust anchors of the control plane into a
+// ConfigMap in every namespace matching a selector, and keeps these ConfigMaps
+// in sync with the linkerd-config ConfigMap, e.g. during trust anchor
+// rotations.
+type Controller struct {
+	k8sAPI            *k8s.API
+	controllerNS      string
+	namespaceSelector labels.Selector
+	queue             workqueue.RateLimitingInterface
+	log               *log.Entry
+
+	// The ConfigMaps are watched by name, so that the controller only needs
+	// access to the trust bundles and to linkerd-config
+	informerFactories []informers.SharedInformerFactory
+	bundles           coreinformers.ConfigMapInformer
+	config            coreinformers.ConfigMapInformer
+}
+
+// NewController returns a Controller publishing the trust anchors of the
+// control plane in controllerNS into the namespaces matching
+// namespaceSelector. It registers its event handlers on the NS informer of
+// k8sAPI, and on its own ConfigMap informers, started by Sync.
+func NewController(k8sAPI *k8s.API, controllerNS string, namespaceSelector labels.Selector) *Controller {
+	bundleFactory := informers.NewSharedInformerFactoryWithOptions(k8sAPI.Client, resyncPeriod,
+		informers.WithTweakListOptions(byName(pkgK8s.TrustBundleConfigMapName)))
+	configFactory := informers.NewSharedInformerFactoryWithOptions(k8sAPI.Client, resyncPeriod,
+		informers.WithNamespace(controllerNS),
+		informers.WithTweakListOptions(byName(pkgK8s.ConfigConfigMapName)))
+
+	c := &Controller{
+		k8sAPI:            k8sAPI,
+		controllerNS:      controllerNS,
+		namespaceSelector: namespaceSelector,
+		queue:             workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+		log:               log.WithField("component", "trust-bundle"),
+		informerFactories: []informers.SharedInformerFactory{bundleFactory, configFactory},
+		bundles:           bundleFactory.Core().V1().ConfigMaps(),
+		config:            configFactory.Core().V1().ConfigMaps(),
+	}
+
+	k8sAPI.NS().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			c.queue.Add(obj.(*corev1.Namespace).Name)
+		},
+		UpdateFunc: func(old, new interface{}) {
+			oldNS, newNS := old.(*corev1.Namespace), new.(*corev1.Namespace)
+			if !reflect.DeepEqual(oldNS.Labels, newNS.Labels) {
+				c.queue.Add(newNS.Name)
+			}
+		},
+	})
+	for _, informer := range []coreinformers.ConfigMapInformer{c.bundles, c.config} {
+		informer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    c.handleConfigMap,
+			UpdateFunc: func(_, new interface{}) { c.handleConfigMap(new) },
+			DeleteFunc: c.handleConfigMap,
+		})
+	}
+
+	return c
+}
+
+func byName(name string) func(*metav1.ListOptions) {
+	return func(options *metav1.ListOptions) {
+		options.FieldSelector = fields.OneTermEqualSelector("metadata.name", name).String()
+	}
+}
+
+// Sync starts the ConfigMap informers and waits for their caches to be synced
+func (c *Controller) Sync(stopCh <-chan struct{}) error {
+	for _, factory := range c.informerFactories {
+		factory.Start(stopCh)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+	if !cache.WaitForCacheSync(ctx.Done(), c.bundles.Informer().HasSynced, c.config.Informer().HasSynced) {
+		return fmt.Errorf("failed to sync the ConfigMap caches")
+	}
+	return nil
+}
+
+// Start processes the events until stopCh is closed
+func (c *Controller) Start(stopCh <-chan struct{}) {
+	go func() {
+		<-stopCh
+		c.queue.ShutDown()
+	}()
+
+	for c.processNextItem() {
+	}
+}
+
+// handleConfigMap queues all the namespaces when linkerd-config changes, and
+// the namespace of a trust bundle when it's changed by someone else
+func (c *Controller) handleConfigMap(obj interface{}) {
+	cm, ok := obj.(*corev1.ConfigMap)
+	if !ok {
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			return
+		}
+		if cm, ok = tombstone.Obj.(*corev1.ConfigMap); !ok {
+			return
+		}
+	}
+
+	switch {
+	case cm.Namespace == c.controllerNS && cm.Name == pkgK8s.ConfigConfigMapName:
+		c.queue.Add(allNamespaces)
+	case cm.Name == pkgK8s.TrustBundleConfigMapName:
+		c.queue.Add(cm.Namespace)
+	}
+}
+
+func (c *Controller) processNextItem() bool {
+	item, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	defer c.queue.Done(item)
+
+	namespace := item.(string)
+	var err error
+	if namespace == allNamespaces {
+		err = c.syncAll()
+	} else {
+		err = c.sync(namespace)
+	}
+
+	switch {
+	case err == nil:
+		c.queue.Forget(item)
+	case c.queue.NumRequeues(item) < maxRetries:
+		c.log.Errorf("Error syncing the trust bundle of %q (will retry): %s", namespace, err)
+		c.queue.AddRateLimited(item)
+	default:
+		c.log.Errorf("Error syncing the trust bundle of %q (giving up): %s", namespace, err)
+		c.queue.Forget(item)
+	}
+	return true
+}
+
+// trustAnchors returns the PEM-encoded trust anchors of the control plane
+func (c *Controller) trustAnchors() (string, error) {
+	cm, err := c.config.Lister().ConfigMaps(c.controllerNS).Get(pkgK8s.ConfigConfigMapName)
+	if err != nil {
+		return "", err
+	}
+	configs, err := config.FromConfigMap(cm.Data)
+	if err != nil {
+		return "", err
+	}
+	pem := configs.GetGlobal().GetIdentityContext().GetTrustAnchorsPem()
+	if pem == "" {
+		return "", fmt.Errorf("%s has no trust anchors", pkgK8s.ConfigConfigMapName)
+	}
+	return pem, nil
+}
+
+func (c *Controller) syncAll() error {
+	namespaces, err := c.k8sAPI.NS().Lister().List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	for _, ns := range namespaces {
+		c.queue.Add(ns.Name)
+	}
+	return nil
+}
+
+// sync creates, updates or deletes the trust bundle of a namespace, depending
+// on whether it matches the namespace selector
+func (c *Controller) sync(namespace string) error {
+	ns, err := c.k8sAPI.NS().Lister().Get(namespace)
+	if kerrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	current, err := c.bundles.Lister().ConfigMaps(namespace).Get(pkgK8s.TrustBundleConfigMapName)
+	if err != nil && !kerrors.IsNotFound(err) {
+		return err
+	}
+	if current != nil && current.Labels[pkgK8s.TrustBundleLabel] != "true" {
+		c.log.Warnf("Not updating %s/%s, which isn't managed by the trust bundle controller", namespace, pkgK8s.TrustBundleConfigMapName)
+		return nil
+	}
+
+	if ns.DeletionTimestamp != nil {
+		return nil
+	}
+	if !c.namespaceSelector.Matches(labels.Set(ns.Labels)) {
+		if current == nil {
+			return nil
+		}
+		c.log.Infof("Deleting the trust bundle of %s", namespace)
+		err := c.k8sAPI.Client.CoreV1().ConfigMaps(namespace).Delete(pkgK8s.TrustBundleConfigMapName, &metav1.DeleteOptions{})
+		if kerrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	anchors, err := c.trustAnchors()
+	if err != nil {
+		return err
+	}
+
+	if current == nil {
+		c.log.Infof("Creating the trust bundle of %s", namespace)
+		_, err := c.k8sAPI.Client.CoreV1().ConfigMaps(namespace).Create(&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      pkgK8s.TrustBundleConfigMapName,
+				Namespace: namespace,
+				Labels: map[string]string{
+					pkgK8s.TrustBundleLabel:  "true",
+					pkgK8s.ControllerNSLabel: c.controllerNS,
+				},
+			},
+			Data: map[string]string{pkgK8s.TrustBundleKey: anchors},
+		})
+		return err
+	}
+
+	if current.Data[pkgK8s.TrustBundleKey] == anchors {
+		return nil
+	}
+	c.log.Infof("Updating the trust bundle of %s", namespace)
+	updated := current.DeepCopy()
+	updated.Data = map[string]string{pkgK8s.TrustBundleKey: anchors}
+	_, err = c.k8sAPI.Client.CoreV1().ConfigMaps(namespace).Update(updated)
+	return err
+}
@@ -0,0 +1,164 @@
+package trustbundle
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/linkerd/linkerd2/controller/k8s"
+	pkgK8s "github.com/linkerd/linkerd2/pkg/k8s"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func linkerdConfig(anchors string) string {
+	return fmt.Sprintf(`
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: linkerd-config
+  namespace: linkerd
+data:
+  global: |
+    {"linkerdNamespace":"linkerd","identityContext":{"trustAnchorsPem":"%s"}}
+`, anchors)
+}
+
+const (
+	linkerdNamespace = `
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: linkerd
+`
+	selectedNamespace = `
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: emojivoto
+  labels:
+    trust-bundle: enabled
+`
+	otherNamespace = `
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: other
+`
+)
+
+func newTestController(t *testing.T, configs ...string) *Controller {
+	k8sAPI, err := k8s.NewFakeAPI(configs...)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	k8sAPI.Sync(nil)
+
+	selector, err := labels.Parse("trust-bundle=enabled")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	c := NewController(k8sAPI, "linkerd", selector)
+	if err := c.Sync(nil); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	return c
+}
+
+func TestSync(t *testing.T) {
+	t.Run("Publishes the trust anchors in the selected namespaces", func(t *testing.T) {
+		c := newTestController(t, linkerdConfig("anchors"), linkerdNamespace, selectedNamespace, otherNamespace)
+
+		for _, ns := range []string{"linkerd", "emojivoto", "other"} {
+			if err := c.sync(ns); err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+		}
+
+		cm, err := c.k8sAPI.Client.CoreV1().ConfigMaps("emojivoto").Get(pkgK8s.TrustBundleConfigMapName, metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if cm.Data[pkgK8s.TrustBundleKey] != "anchors" {
+			t.Fatalf("Expected the trust anchors in the bundle, got %v", cm.Data)
+		}
+		if cm.Labels[pkgK8s.TrustBundleLabel] != "true" {
+			t.Fatalf("Expected the bundle to be labeled as managed, got %v", cm.Labels)
+		}
+
+		for _, ns := range []string{"linkerd", "other"} {
+			if _, err := c.k8sAPI.Client.CoreV1().ConfigMaps(ns).Get(pkgK8s.TrustBundleConfigMapName, metav1.GetOptions{}); err == nil {
+				t.Fatalf("Expected no trust bundle in %s", ns)
+			}
+		}
+	})
+
+	t.Run("Updates the bundles when the trust anchors are rotated", func(t *testing.T) {
+		bundle := `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: linkerd-trust-bundle
+  namespace: emojivoto
+  labels:
+    linkerd.io/trust-bundle: "true"
+data:
+  ca-bundle.crt: previous
+`
+		c := newTestController(t, linkerdConfig("previous\\ncurrent"), linkerdNamespace, selectedNamespace, bundle)
+		if err := c.sync("emojivoto"); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		cm, err := c.k8sAPI.Client.CoreV1().ConfigMaps("emojivoto").Get(pkgK8s.TrustBundleConfigMapName, metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if cm.Data[pkgK8s.TrustBundleKey] != "previous\ncurrent" {
+			t.Fatalf("Expected the rotated trust anchors in the bundle, got %v", cm.Data)
+		}
+	})
+
+	t.Run("Deletes the bundles of the namespaces no longer selected", func(t *testing.T) {
+		bundle := `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: linkerd-trust-bundle
+  namespace: other
+  labels:
+    linkerd.io/trust-bundle: "true"
+data:
+  ca-bundle.crt: anchors
+`
+		c := newTestController(t, linkerdConfig("anchors"), linkerdNamespace, otherNamespace, bundle)
+		if err := c.sync("other"); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if _, err := c.k8sAPI.Client.CoreV1().ConfigMaps("other").Get(pkgK8s.TrustBundleConfigMapName, metav1.GetOptions{}); err == nil {
+			t.Fatal("Expected the trust bundle to be deleted")
+		}
+	})
+
+	t.Run("Leaves the ConfigMaps it doesn't manage alone", func(t *testing.T) {
+		unmanaged := `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: linkerd-trust-bundle
+  namespace: emojivoto
+data:
+  ca-bundle.crt: mine
+`
+		c := newTestController(t, linkerdConfig("anchors"), linkerdNamespace, selectedNamespace, unmanaged)
+		if err := c.sync("emojivoto"); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		cm, err := c.k8sAPI.Client.CoreV1().ConfigMaps("emojivoto").Get(pkgK8s.TrustBundleConfigMapName, metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if cm.Data[pkgK8s.TrustBundleKey] != "mine" {
+			t.Fatalf("Expected the ConfigMap to be left alone, got %v", cm.Data)
+		}
+	})
+}
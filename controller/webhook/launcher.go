@@ -24,6 +24,7 @@ func Launch(APIResources []k8s.APIResource, metricsPort uint32, handler handlerF
 	metricsAddr := cmd.String("metrics-addr", fmt.Sprintf(":%d", metricsPort), "address to serve scrapable metrics on")
 	addr := cmd.String("addr", ":8443", "address to serve on")
 	kubeconfig := cmd.String("kubeconfig", "", "path to kubeconfig")
+	cryptoPolicy := flags.AddCryptoPolicyFlag(cmd)
 
 	flags.ConfigureAndParse(cmd, args)
 
@@ -45,6 +46,9 @@ func Launch(APIResources []k8s.APIResource, metricsPort uint32, handler handlerF
 	if err != nil {
 		log.Fatalf("failed to initialize the webhook server: %s", err)
 	}
+	if err := tls.ApplyCryptoPolicy(s.TLSConfig, *cryptoPolicy); err != nil {
+		log.Fatalf("failed to initialize the webhook server: %s", err)
+	}
 
 	k8sAPI.Sync(nil)
 
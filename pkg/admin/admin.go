@@ -1,6 +1,7 @@
 package admin
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/pprof"
@@ -8,23 +9,48 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 )
 
 type handler struct {
-	promHandler http.Handler
+	promHandler  http.Handler
+	healthServer *health.Server
 }
 
 // StartServer starts an admin server listening on a given address.
 func StartServer(addr string) {
+	StartServerWithHealth(addr, nil)
+}
+
+// StartServerWithHealth starts an admin server listening on a given address,
+// whose /ready endpoint reports the overall serving status of the given gRPC
+// health server.
+func StartServerWithHealth(addr string, healthServer *health.Server) {
 	log.Infof("starting admin server on %s", addr)
 
 	h := &handler{
-		promHandler: promhttp.Handler(),
+		promHandler:  promhttp.Handler(),
+		healthServer: healthServer,
 	}
 
 	log.Fatal(http.ListenAndServe(addr, h))
 }
 
+// RegisterGrpcHealth registers the grpc.health.v1 and server reflection
+// services on a gRPC server, so that it can be queried with standard tooling
+// such as grpc_health_probe and grpcurl. The returned health server reports
+// NOT_SERVING until its status is set to SERVING.
+func RegisterGrpcHealth(server *grpc.Server) *health.Server {
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	healthpb.RegisterHealthServer(server, healthServer)
+	reflection.Register(server)
+	return healthServer
+}
+
 func (h *handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	debugPathPrefix := "/debug/pprof/"
 	switch req.URL.Path {
@@ -56,5 +82,12 @@ func (h *handler) servePing(w http.ResponseWriter) {
 }
 
 func (h *handler) serveReady(w http.ResponseWriter) {
+	if h.healthServer != nil {
+		rsp, err := h.healthServer.Check(context.Background(), &healthpb.HealthCheckRequest{})
+		if err != nil || rsp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+			http.Error(w, "not ready", http.StatusServiceUnavailable)
+			return
+		}
+	}
 	w.Write([]byte("ok\n"))
 }
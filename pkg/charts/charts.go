@@ -81,6 +81,7 @@ func (c *Chart) Render() (bytes.Buffer, error) {
 		{Name: "charts/partials/templates/_metadata.tpl"},
 		{Name: "charts/partials/templates/_helpers.tpl"},
 		{Name: "charts/partials/templates/_debug.tpl"},
+		{Name: "charts/partials/templates/_proxy-shutdown.tpl"},
 		{Name: "charts/partials/templates/_capabilities.tpl"},
 		{Name: "charts/partials/templates/_trace.tpl"},
 		{Name: "charts/partials/templates/_nodeselector.tpl"},
@@ -89,6 +90,7 @@ func (c *Chart) Render() (bytes.Buffer, error) {
 		{Name: "charts/partials/templates/_addons.tpl"},
 		{Name: "charts/partials/templates/_validate.tpl"},
 		{Name: "charts/partials/templates/_pull-secrets.tpl"},
+		{Name: "charts/partials/templates/_prometheus.tpl"},
 	}
 	return c.render(l5dPartials)
 }
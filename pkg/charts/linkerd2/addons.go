@@ -2,10 +2,14 @@ package linkerd2
 
 import (
 	"fmt"
+	"strings"
 
 	"k8s.io/helm/pkg/chartutil"
 )
 
+// AddOnNames are the names of the add-ons bundled with the chart
+var AddOnNames = []string{GrafanaAddOn, PrometheusAddOn, TracingAddOn}
+
 // AddOn includes the general functions required by add-on, provides
 // a common abstraction for install, etc
 type AddOn interface {
@@ -45,3 +49,29 @@ func ParseAddOnValues(values *Values) ([]AddOn, error) {
 
 	return addOns, nil
 }
+
+// SetAddOnEnabled enables or disables the add-on with the given name, keeping
+// the rest of its configuration
+func SetAddOnEnabled(values *Values, name string, enabled bool) error {
+	switch name {
+	case GrafanaAddOn:
+		if values.Grafana == nil {
+			values.Grafana = Grafana{}
+		}
+		values.Grafana["enabled"] = enabled
+	case PrometheusAddOn:
+		if values.Prometheus == nil {
+			values.Prometheus = Prometheus{}
+		}
+		values.Prometheus["enabled"] = enabled
+	case TracingAddOn:
+		if values.Tracing == nil {
+			values.Tracing = Tracing{}
+		}
+		values.Tracing["enabled"] = enabled
+	default:
+		return fmt.Errorf("unknown add-on '%s', must be one of: %s", name, strings.Join(AddOnNames, ", "))
+	}
+
+	return nil
+}
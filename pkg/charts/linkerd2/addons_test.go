@@ -32,3 +32,30 @@ Tracing:
 		t.Fatal("expected tracing add-on to be present")
 	}
 }
+
+func TestSetAddOnEnabled(t *testing.T) {
+	values := Values{Grafana: Grafana{"enabled": true, "image": map[string]interface{}{"name": "grafana"}}}
+
+	if err := SetAddOnEnabled(&values, GrafanaAddOn, false); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if err := SetAddOnEnabled(&values, TracingAddOn, true); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	addOns, err := ParseAddOnValues(&values)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if len(addOns) != 1 || addOns[0].Name() != TracingAddOn {
+		t.Fatalf("expected only the tracing add-on to be enabled, got %v", addOns)
+	}
+	if values.Grafana["image"] == nil {
+		t.Fatal("expected the grafana configuration to be kept")
+	}
+
+	expected := "unknown add-on 'jaeger', must be one of: grafana, prometheus, tracing"
+	if err := SetAddOnEnabled(&values, "jaeger", true); err == nil || err.Error() != expected {
+		t.Fatalf("expected error %q, got %v", expected, err)
+	}
+}
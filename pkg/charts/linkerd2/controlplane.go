@@ -0,0 +1,153 @@
+package linkerd2
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// ControlPlaneGVR is the Group Version and Resource of the LinkerdControlPlane
+// custom resource.
+var ControlPlaneGVR = schema.GroupVersionResource{
+	Group:    k8s.ControlPlaneAPIGroup,
+	Version:  k8s.ControlPlaneAPIVersion,
+	Resource: "linkerdcontrolplanes",
+}
+
+// NewControlPlaneResource returns a cluster-scoped LinkerdControlPlane custom
+// resource whose spec holds values, so that the control plane can be rendered
+// and applied in-cluster by `linkerd operator`.
+//
+// The spec doesn't hold any private key, as the resource can be read by anyone
+// allowed to read cluster-scoped resources. The keys are returned in the
+// Secrets of the control plane namespace the control plane reads them from,
+// which must be created along with the resource.
+func NewControlPlaneResource(name string, values *Values) (unstructured.Unstructured, []corev1.Secret, error) {
+	values, err := values.DeepCopy()
+	if err != nil {
+		return unstructured.Unstructured{}, nil, err
+	}
+	secrets := controlPlaneSecrets(values)
+
+	data, err := json.Marshal(values)
+	if err != nil {
+		return unstructured.Unstructured{}, nil, err
+	}
+	spec := make(map[string]interface{})
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return unstructured.Unstructured{}, nil, err
+	}
+	removeKeyPEM(spec)
+
+	return unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": k8s.ControlPlaneAPIGroupVersion,
+			"kind":       k8s.ControlPlaneKind,
+			"metadata": map[string]interface{}{
+				"name": name,
+			},
+			"spec": spec,
+		},
+	}, secrets, nil
+}
+
+// controlPlaneSecrets returns the Secrets holding the private keys of values:
+// the identity issuer's, and those of the webhooks whose keys were provided.
+// The webhooks are switched to their external secret, so that they keep
+// using these keys.
+func controlPlaneSecrets(values *Values) []corev1.Secret {
+	secrets := make([]corev1.Secret, 0)
+
+	if issuer := values.Identity.Issuer; issuer.Scheme == k8s.IdentityIssuerSchemeLinkerd && issuer.TLS != nil && issuer.TLS.KeyPEM != "" {
+		data := map[string][]byte{
+			k8s.IdentityIssuerCrtName: []byte(issuer.TLS.CrtPEM),
+			k8s.IdentityIssuerKeyName: []byte(issuer.TLS.KeyPEM),
+		}
+		if issuer.NextTLS != nil {
+			data[k8s.IdentityIssuerNextCrtName] = []byte(issuer.NextTLS.CrtPEM)
+			data[k8s.IdentityIssuerNextKeyName] = []byte(issuer.NextTLS.KeyPEM)
+		}
+		secrets = append(secrets, newSecret(values, k8s.IdentityIssuerSecretName, "identity", data))
+	}
+
+	webhooks := []struct {
+		name      string
+		component string
+		tls       *TLS
+	}{
+		{"linkerd-proxy-injector-tls", "proxy-injector", values.ProxyInjector.TLS},
+		{"linkerd-sp-validator-tls", "sp-validator", values.ProfileValidator.TLS},
+		{"linkerd-tap-tls", "tap", values.Tap.TLS},
+	}
+	for _, webhook := range webhooks {
+		if webhook.tls == nil || webhook.tls.ExternalSecret || webhook.tls.KeyPEM == "" {
+			continue
+		}
+		secrets = append(secrets, newSecret(values, webhook.name, webhook.component, map[string][]byte{
+			"crt.pem": []byte(webhook.tls.CrtPEM),
+			"key.pem": []byte(webhook.tls.KeyPEM),
+		}))
+		webhook.tls.ExternalSecret = true
+	}
+
+	return secrets
+}
+
+func newSecret(values *Values, name, component string, data map[string][]byte) corev1.Secret {
+	return corev1.Secret{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: values.Global.Namespace,
+			Labels: map[string]string{
+				values.Global.ControllerComponentLabel: component,
+				values.Global.ControllerNamespaceLabel: values.Global.Namespace,
+			},
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: data,
+	}
+}
+
+// removeKeyPEM removes the keyPEM fields from obj and from all the objects it
+// holds.
+func removeKeyPEM(obj map[string]interface{}) {
+	delete(obj, "keyPEM")
+	for _, value := range obj {
+		if child, ok := value.(map[string]interface{}); ok {
+			removeKeyPEM(child)
+		}
+	}
+}
+
+// ValuesFromControlPlaneResource parses the spec of an unstructured
+// LinkerdControlPlane custom resource into the values used to render the
+// chart.
+func ValuesFromControlPlaneResource(u unstructured.Unstructured) (*Values, error) {
+	spec, ok := u.Object["spec"]
+	if !ok {
+		return nil, errors.New("Field 'spec' is missing")
+	}
+	if _, ok := spec.(map[string]interface{}); !ok {
+		return nil, errors.New("Field 'spec' is not an object")
+	}
+
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return nil, err
+	}
+	var values Values
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, err
+	}
+	if values.Global == nil {
+		return nil, errors.New("Field 'spec.global' is missing")
+	}
+
+	return &values, nil
+}
@@ -0,0 +1,85 @@
+package linkerd2
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+func TestControlPlaneResource(t *testing.T) {
+	values, err := NewValues(true)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v\n", err)
+	}
+	values.Global.IdentityTrustAnchorsPEM = "trust-anchors"
+	values.Identity.Issuer.TLS = &IssuerTLS{CrtPEM: "issuer-crt", KeyPEM: "issuer-key"}
+	values.Tap.TLS = &TLS{CrtPEM: "tap-crt", KeyPEM: "tap-key", CaBundle: "tap-ca"}
+
+	u, secrets, err := NewControlPlaneResource("linkerd", values)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v\n", err)
+	}
+	if u.GetKind() != "LinkerdControlPlane" || u.GetName() != "linkerd" {
+		t.Fatalf("Unexpected resource %s/%s", u.GetKind(), u.GetName())
+	}
+
+	data, err := yaml.Marshal(u.Object)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v\n", err)
+	}
+	if strings.Contains(string(data), "keyPEM") || strings.Contains(string(data), "-key") {
+		t.Fatalf("Expected the resource not to hold any private key, got:\n%s", data)
+	}
+	if values.Identity.Issuer.TLS.KeyPEM != "issuer-key" || values.Tap.ExternalSecret {
+		t.Fatal("Expected the values not to be modified")
+	}
+
+	expectedSecrets := map[string]map[string]string{
+		"linkerd-identity-issuer": {"crt.pem": "issuer-crt", "key.pem": "issuer-key"},
+		"linkerd-tap-tls":         {"crt.pem": "tap-crt", "key.pem": "tap-key"},
+	}
+	if len(secrets) != len(expectedSecrets) {
+		t.Fatalf("Expected %d secrets, got %d", len(expectedSecrets), len(secrets))
+	}
+	for _, secret := range secrets {
+		expected, ok := expectedSecrets[secret.Name]
+		if !ok {
+			t.Fatalf("Unexpected secret %s", secret.Name)
+		}
+		if secret.Namespace != values.Global.Namespace {
+			t.Errorf("Expected secret %s in namespace %s, got %s", secret.Name, values.Global.Namespace, secret.Namespace)
+		}
+		for k, v := range expected {
+			if string(secret.Data[k]) != v {
+				t.Errorf("Expected %s in secret %s to be %q, got %q", k, secret.Name, v, secret.Data[k])
+			}
+		}
+	}
+
+	actual, err := ValuesFromControlPlaneResource(u)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v\n", err)
+	}
+
+	// The keys are read from the secrets; the tap webhook uses its secret
+	expected, err := values.DeepCopy()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v\n", err)
+	}
+	expected.Identity.Issuer.TLS.KeyPEM = ""
+	expected.Tap.TLS.KeyPEM = ""
+	expected.Tap.TLS.ExternalSecret = true
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("Expected values to be preserved.\nExpected: %+v\nActual: %+v", expected, actual)
+	}
+
+	t.Run("Rejects resources without a spec", func(t *testing.T) {
+		u := unstructured.Unstructured{Object: map[string]interface{}{"kind": "LinkerdControlPlane"}}
+		if _, err := ValuesFromControlPlaneResource(u); err == nil {
+			t.Fatal("Expected an error, got nothing")
+		}
+	})
+}
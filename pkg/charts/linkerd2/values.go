@@ -7,6 +7,7 @@ import (
 	"github.com/imdario/mergo"
 	"github.com/linkerd/linkerd2/pkg/charts"
 	"github.com/linkerd/linkerd2/pkg/k8s"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/helm/pkg/chartutil"
 	"sigs.k8s.io/yaml"
 )
@@ -19,39 +20,60 @@ const (
 type (
 	// Values contains the top-level elements in the Helm charts
 	Values struct {
-		Stage                       string            `json:"stage"`
-		ControllerImage             string            `json:"controllerImage"`
-		ControllerImageVersion      string            `json:"controllerImageVersion"`
-		WebImage                    string            `json:"webImage"`
-		ControllerReplicas          uint              `json:"controllerReplicas"`
-		ControllerUID               int64             `json:"controllerUID"`
-		EnableH2Upgrade             bool              `json:"enableH2Upgrade"`
-		EnablePodAntiAffinity       bool              `json:"enablePodAntiAffinity"`
-		WebhookFailurePolicy        string            `json:"webhookFailurePolicy"`
-		OmitWebhookSideEffects      bool              `json:"omitWebhookSideEffects"`
-		RestrictDashboardPrivileges bool              `json:"restrictDashboardPrivileges"`
-		DisableHeartBeat            bool              `json:"disableHeartBeat"`
-		HeartbeatSchedule           string            `json:"heartbeatSchedule"`
-		InstallNamespace            bool              `json:"installNamespace"`
-		Configs                     ConfigJSONs       `json:"configs"`
-		Global                      *Global           `json:"global"`
-		Identity                    *Identity         `json:"identity"`
-		Dashboard                   *Dashboard        `json:"dashboard"`
-		DebugContainer              *DebugContainer   `json:"debugContainer"`
-		ProxyInjector               *ProxyInjector    `json:"proxyInjector"`
-		ProfileValidator            *ProfileValidator `json:"profileValidator"`
-		Tap                         *Tap              `json:"tap"`
-		NodeSelector                map[string]string `json:"nodeSelector"`
-		Tolerations                 []interface{}     `json:"tolerations"`
-
-		DestinationResources   *Resources `json:"destinationResources"`
-		HeartbeatResources     *Resources `json:"heartbeatResources"`
-		IdentityResources      *Resources `json:"identityResources"`
-		ProxyInjectorResources *Resources `json:"proxyInjectorResources"`
-		PublicAPIResources     *Resources `json:"publicAPIResources"`
-		SPValidatorResources   *Resources `json:"spValidatorResources"`
-		TapResources           *Resources `json:"tapResources"`
-		WebResources           *Resources `json:"webResources"`
+		Stage                        string            `json:"stage"`
+		ControllerImage              string            `json:"controllerImage"`
+		ControllerImageVersion       string            `json:"controllerImageVersion"`
+		WebImage                     string            `json:"webImage"`
+		ControllerReplicas           uint              `json:"controllerReplicas"`
+		ControllerUID                int64             `json:"controllerUID"`
+		EnableH2Upgrade              bool              `json:"enableH2Upgrade"`
+		EnablePodAntiAffinity        bool              `json:"enablePodAntiAffinity"`
+		EnablePodDisruptionBudget    bool              `json:"enablePodDisruptionBudget"`
+		EnablePodTopologySpread      bool              `json:"enablePodTopologySpread"`
+		EnableNetworkPolicies        bool              `json:"enableNetworkPolicies"`
+		WebhookFailurePolicy         string            `json:"webhookFailurePolicy"`
+		OmitWebhookSideEffects       bool              `json:"omitWebhookSideEffects"`
+		RestrictDashboardPrivileges  bool              `json:"restrictDashboardPrivileges"`
+		DisableHeartBeat             bool              `json:"disableHeartBeat"`
+		HeartbeatSchedule            string            `json:"heartbeatSchedule"`
+		EnableIdentityRotation       bool              `json:"enableIdentityRotation"`
+		IdentityRotationSchedule     string            `json:"identityRotationSchedule"`
+		EnableHealthExporter         bool              `json:"enableHealthExporter"`
+		HealthExporterInterval       string            `json:"healthExporterInterval"`
+		HealthExporterCategories     []string          `json:"healthExporterCategories"`
+		EnableTrustBundle            bool              `json:"enableTrustBundle"`
+		TrustBundleNamespaceSelector string            `json:"trustBundleNamespaceSelector"`
+		InstallNamespace             bool              `json:"installNamespace"`
+		Configs                      ConfigJSONs       `json:"configs"`
+		Global                       *Global           `json:"global"`
+		Identity                     *Identity         `json:"identity"`
+		Dashboard                    *Dashboard        `json:"dashboard"`
+		DebugContainer               *DebugContainer   `json:"debugContainer"`
+		ProxyInjector                *ProxyInjector    `json:"proxyInjector"`
+		ProfileValidator             *ProfileValidator `json:"profileValidator"`
+		Tap                          *Tap              `json:"tap"`
+		OpenShiftSCC                 *OpenShiftSCC     `json:"openshiftSCC,omitempty"`
+		NodeSelector                 map[string]string `json:"nodeSelector"`
+		Tolerations                  []interface{}     `json:"tolerations"`
+
+		DataPlaneNetworkPolicyNamespaces []string               `json:"dataPlaneNetworkPolicyNamespaces"`
+		NetworkPolicyPrometheusSelector  map[string]interface{} `json:"networkPolicyPrometheusSelector,omitempty"`
+
+		ComponentNodeSelectors map[string]map[string]string `json:"componentNodeSelectors"`
+		ComponentTolerations   map[string][]interface{}     `json:"componentTolerations"`
+		ComponentLogLevels     map[string]string            `json:"componentLogLevels"`
+
+		DestinationResources      *Resources `json:"destinationResources"`
+		HealthExporterResources   *Resources `json:"healthExporterResources"`
+		HeartbeatResources        *Resources `json:"heartbeatResources"`
+		IdentityResources         *Resources `json:"identityResources"`
+		IdentityRotationResources *Resources `json:"identityRotationResources"`
+		TrustBundleResources      *Resources `json:"trustBundleResources"`
+		ProxyInjectorResources    *Resources `json:"proxyInjectorResources"`
+		PublicAPIResources        *Resources `json:"publicAPIResources"`
+		SPValidatorResources      *Resources `json:"spValidatorResources"`
+		TapResources              *Resources `json:"tapResources"`
+		WebResources              *Resources `json:"webResources"`
 
 		DestinationProxyResources   *Resources `json:"destinationProxyResources"`
 		IdentityProxyResources      *Resources `json:"identityProxyResources"`
@@ -86,15 +108,28 @@ type (
 		HighAvailability         bool                `json:"highAvailability"`
 		CNIEnabled               bool                `json:"cniEnabled"`
 		EnableEndpointSlices     bool                `json:"enableEndpointSlices"`
+		OpenShift                bool                `json:"openshift"`
+		CryptoPolicy             string              `json:"cryptoPolicy"`
+		ImageArchitectures       []string            `json:"imageArchitectures"`
 		ControlPlaneTracing      bool                `json:"controlPlaneTracing"`
 		IdentityTrustAnchorsPEM  string              `json:"identityTrustAnchorsPEM"`
 		IdentityTrustDomain      string              `json:"identityTrustDomain"`
 		PrometheusURL            string              `json:"prometheusUrl"`
 		GrafanaURL               string              `json:"grafanaUrl"`
 		ImagePullSecrets         []map[string]string `json:"imagePullSecrets"`
+		Registry                 string              `json:"registry"`
 
-		Proxy     *Proxy     `json:"proxy"`
-		ProxyInit *ProxyInit `json:"proxyInit"`
+		ControlPlaneMetrics *ControlPlaneMetrics `json:"controlPlaneMetrics"`
+		Proxy               *Proxy               `json:"proxy"`
+		ProxyInit           *ProxyInit           `json:"proxyInit"`
+	}
+
+	// ControlPlaneMetrics contains the settings of the metrics endpoint served
+	// by each control plane component. A zero Port keeps the components'
+	// default admin ports.
+	ControlPlaneMetrics struct {
+		Port   uint32 `json:"port"`
+		Scheme string `json:"scheme"`
 	}
 
 	// ConfigJSONs is the JSON encoding of the Linkerd configuration
@@ -123,8 +158,20 @@ type (
 		WaitBeforeExitSeconds         uint64           `json:"waitBeforeExitSeconds"`
 		IsGateway                     bool             `json:"isGateway"`
 		RequireIdentityOnInboundPorts string           `json:"requireIdentityOnInboundPorts"`
+		OpaquePorts                   string           `json:"opaquePorts"`
 		OutboundConnectTimeout        string           `json:"outboundConnectTimeout"`
 		InboundConnectTimeout         string           `json:"inboundConnectTimeout"`
+		OutboundConnectBackoffMin     string           `json:"outboundConnectBackoffMin"`
+		OutboundConnectBackoffMax     string           `json:"outboundConnectBackoffMax"`
+		OutboundConnectBackoffJitter  string           `json:"outboundConnectBackoffJitter"`
+		ExcludedNodeSelector          string           `json:"excludedNodeSelector"`
+		AdditionalEnv                 []EnvVar         `json:"additionalEnv"`
+	}
+
+	// EnvVar is an extra environment variable of the proxy container
+	EnvVar struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
 	}
 
 	// ProxyInit contains the fields to set the proxy-init container
@@ -144,6 +191,14 @@ type (
 		Image *Image `json:"image"`
 	}
 
+	// OpenShiftSCC overrides the strategies of the SecurityContextConstraints
+	// rendered when Global.OpenShift is set; unset fields keep their default
+	OpenShiftSCC struct {
+		RunAsUser          map[string]interface{} `json:"runAsUser,omitempty"`
+		SupplementalGroups map[string]interface{} `json:"supplementalGroups,omitempty"`
+		FSGroup            map[string]interface{} `json:"fsGroup,omitempty"`
+	}
+
 	// Image contains the details to define a container image
 	Image struct {
 		Name       string `json:"name"`
@@ -193,22 +248,59 @@ type (
 	// Identity contains the fields to set the identity variables in the proxy
 	// sidecar container
 	Identity struct {
-		Issuer *Issuer `json:"issuer"`
+		Issuer *Issuer        `json:"issuer"`
+		Spire  *IdentitySpire `json:"spire"`
+	}
+
+	// IdentitySpire has the Helm variables of the SPIRE server the identity
+	// service obtains its issuer certificate from, when the issuer scheme is
+	// spiffe.io/spire
+	IdentitySpire struct {
+		ServerAddr      string `json:"serverAddr"`
+		AgentSocketPath string `json:"agentSocketPath"`
+		HelperImage     *Image `json:"helperImage"`
 	}
 
 	// Issuer has the Helm variables of the identity issuer
 	Issuer struct {
-		Scheme              string     `json:"scheme"`
-		ClockSkewAllowance  string     `json:"clockSkewAllowance"`
-		IssuanceLifetime    string     `json:"issuanceLifetime"`
-		CrtExpiryAnnotation string     `json:"crtExpiryAnnotation"`
-		CrtExpiry           time.Time  `json:"crtExpiry"`
-		TLS                 *IssuerTLS `json:"tls"`
+		Scheme              string             `json:"scheme"`
+		ClockSkewAllowance  string             `json:"clockSkewAllowance"`
+		IssuanceLifetime    string             `json:"issuanceLifetime"`
+		RenewalJitter       string             `json:"renewalJitter"`
+		CrtExpiryAnnotation string             `json:"crtExpiryAnnotation"`
+		CrtExpiry           time.Time          `json:"crtExpiry"`
+		TLS                 *IssuerTLS         `json:"tls"`
+		CertManager         *IssuerCertManager `json:"certManager"`
+		KeyAlgorithm        string             `json:"keyAlgorithm"`
+
+		// KeyURI identifies the issuer private key when it's held by a KMS or
+		// an HSM, in which case TLS has no private key
+		KeyURI string `json:"keyURI"`
+
+		// NextTLS holds the issuer staged by "linkerd upgrade --rotate-issuer",
+		// which replaces TLS once all the proxies trust it
+		NextTLS         *IssuerTLS `json:"nextTLS"`
+		RotationOverlap string     `json:"rotationOverlap"`
+	}
+
+	// IssuerCertManager has the Helm variables of the cert-manager Issuer and
+	// Certificate that manage the identity issuer secret
+	IssuerCertManager struct {
+		Enabled           bool   `json:"enabled"`
+		TrustAnchorSecret string `json:"trustAnchorSecret"`
+		Duration          string `json:"duration"`
+		RenewBefore       string `json:"renewBefore"`
 	}
 
 	// ProxyInjector has all the proxy injector's Helm variables
 	ProxyInjector struct {
 		*TLS
+
+		// FailurePolicy overrides WebhookFailurePolicy for the proxy
+		// injector, if not empty
+		FailurePolicy     string                `json:"failurePolicy"`
+		NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector"`
+		ObjectSelector    *metav1.LabelSelector `json:"objectSelector"`
 	}
 
 	// ProfileValidator has all the profile validator's Helm variables
@@ -219,6 +311,9 @@ type (
 	// Tap has all the Tap's Helm variables
 	Tap struct {
 		*TLS
+		OTLPEndpoint       string  `json:"otlpEndpoint"`
+		AggregateToAdmin   bool    `json:"aggregateToAdmin"`
+		MaxEventsPerSecond float64 `json:"maxEventsPerSecond"`
 	}
 
 	// TLS has a pair of PEM-encoded key and certificate variables used in the
@@ -254,7 +349,7 @@ func NewValues(ha bool) (*Values, error) {
 
 	v.Global.CliVersion = k8s.CreatedByAnnotationValue()
 	v.ProfileValidator = &ProfileValidator{TLS: &TLS{}}
-	v.ProxyInjector = &ProxyInjector{TLS: &TLS{}}
+	v.ProxyInjector.TLS = &TLS{}
 	v.Global.ProxyContainerName = k8s.ProxyContainerName
 	v.Tap = &Tap{TLS: &TLS{}}
 
@@ -309,3 +404,26 @@ func (v Values) merge(src Values) (Values, error) {
 
 	return src, nil
 }
+
+// Validate checks the values against the chart's JSON schema, so that invalid
+// settings are reported by their path before rendering the templates.
+func (v *Values) Validate() error {
+	schema, err := charts.ReadSchema(fmt.Sprintf("%s/", helmDefaultChartDir))
+	if err != nil {
+		return err
+	}
+	return schema.Validate(v)
+}
+
+// DeepCopy returns a copy of the values that shares no data with them.
+func (v *Values) DeepCopy() (*Values, error) {
+	data, err := yaml.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var values Values
+	if err := yaml.Unmarshal(data, &values); err != nil {
+		return nil, err
+	}
+	return &values, nil
+}
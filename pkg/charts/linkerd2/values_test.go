@@ -3,6 +3,8 @@ package linkerd2
 import (
 	"reflect"
 	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestNewValues(t *testing.T) {
@@ -21,11 +23,19 @@ func TestNewValues(t *testing.T) {
 		ControllerUID:               2103,
 		EnableH2Upgrade:             true,
 		EnablePodAntiAffinity:       false,
+		EnablePodDisruptionBudget:   false,
+		EnablePodTopologySpread:     false,
+		EnableNetworkPolicies:       false,
 		WebhookFailurePolicy:        "Ignore",
 		OmitWebhookSideEffects:      false,
 		RestrictDashboardPrivileges: false,
 		DisableHeartBeat:            false,
 		HeartbeatSchedule:           "0 0 * * *",
+		EnableIdentityRotation:      false,
+		IdentityRotationSchedule:    "*/10 * * * *",
+		EnableHealthExporter:        false,
+		HealthExporterInterval:      "5m",
+		EnableTrustBundle:           false,
 		InstallNamespace:            true,
 		Prometheus: Prometheus{
 			"enabled": true,
@@ -47,8 +57,13 @@ func TestNewValues(t *testing.T) {
 			ProxyContainerName:       "linkerd-proxy",
 			CNIEnabled:               false,
 			ControlPlaneTracing:      false,
+			ImageArchitectures:       []string{"amd64", "arm64", "arm"},
+			CryptoPolicy:             "default",
 			HighAvailability:         false,
 			IdentityTrustDomain:      "cluster.local",
+			ControlPlaneMetrics: &ControlPlaneMetrics{
+				Scheme: "http",
+			},
 			Proxy: &Proxy{
 				EnableExternalProfiles: false,
 				Image: &Image{
@@ -110,9 +125,24 @@ func TestNewValues(t *testing.T) {
 			Issuer: &Issuer{
 				ClockSkewAllowance:  "20s",
 				IssuanceLifetime:    "86400s",
+				RenewalJitter:       "0s",
+				KeyAlgorithm:        "ecdsa-p256",
 				CrtExpiryAnnotation: "linkerd.io/identity-issuer-expiry",
 				TLS:                 &IssuerTLS{},
 				Scheme:              "linkerd.io/tls",
+				CertManager: &IssuerCertManager{
+					TrustAnchorSecret: "linkerd-trust-anchor",
+					Duration:          "48h",
+					RenewBefore:       "25h",
+				},
+			},
+			Spire: &IdentitySpire{
+				AgentSocketPath: "/run/spire/sockets/agent.sock",
+				HelperImage: &Image{
+					Name:       "ghcr.io/spiffe/spiffe-helper",
+					PullPolicy: "IfNotPresent",
+					Version:    "0.8.0",
+				},
 			},
 		},
 		NodeSelector: map[string]string{
@@ -129,7 +159,11 @@ func TestNewValues(t *testing.T) {
 			},
 		},
 
-		ProxyInjector:    &ProxyInjector{TLS: &TLS{}},
+		ProxyInjector: &ProxyInjector{
+			TLS:               &TLS{},
+			NamespaceSelector: &metav1.LabelSelector{},
+			ObjectSelector:    &metav1.LabelSelector{},
+		},
 		ProfileValidator: &ProfileValidator{TLS: &TLS{}},
 		Tap:              &Tap{TLS: &TLS{}},
 		Grafana: Grafana{
@@ -162,6 +196,8 @@ func TestNewValues(t *testing.T) {
 
 		expected.ControllerReplicas = 3
 		expected.EnablePodAntiAffinity = true
+		expected.EnablePodDisruptionBudget = true
+		expected.EnablePodTopologySpread = true
 		expected.WebhookFailurePolicy = "Fail"
 
 		controllerResources := &Resources{
@@ -181,6 +217,9 @@ func TestNewValues(t *testing.T) {
 		expected.TapResources = controllerResources
 		expected.WebResources = controllerResources
 		expected.HeartbeatResources = controllerResources
+		expected.IdentityRotationResources = controllerResources
+		expected.HealthExporterResources = controllerResources
+		expected.TrustBundleResources = controllerResources
 
 		expected.Grafana = Grafana{
 			"enabled": true,
@@ -247,3 +286,58 @@ func TestNewValues(t *testing.T) {
 		}
 	})
 }
+
+func TestValidate(t *testing.T) {
+	for _, ha := range []bool{false, true} {
+		values, err := NewValues(ha)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v\n", err)
+		}
+		if err := values.Validate(); err != nil {
+			t.Errorf("Expected the default values (ha=%t) to be valid, got: %s", ha, err)
+		}
+	}
+
+	testCases := []struct {
+		update   func(*Values)
+		expected string
+	}{
+		{
+			func(v *Values) { v.WebhookFailurePolicy = "Always" },
+			`webhookFailurePolicy: must be one of "Ignore", "Fail", got "Always"`,
+		},
+		{
+			func(v *Values) { v.Global.Proxy.Ports.Admin = 70000 },
+			"global.proxy.ports.admin: must be less than or equal to 65535, got 70000",
+		},
+		{
+			func(v *Values) { v.ControllerReplicas = 0 },
+			"controllerReplicas: must be greater than or equal to 1, got 0",
+		},
+		{
+			func(v *Values) { v.Global.ImagePullSecrets = []map[string]string{{"secret": "regcred"}} },
+			`global.imagePullSecrets[0]: missing required property "name"`,
+		},
+		{
+			func(v *Values) { v.Prometheus["enabled"] = "yes" },
+			"prometheus.enabled: must be of type boolean, got string",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.expected, func(t *testing.T) {
+			values, err := NewValues(false)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v\n", err)
+			}
+			tc.update(values)
+
+			err = values.Validate()
+			expected := "invalid values:\n  * " + tc.expected
+			if err == nil || err.Error() != expected {
+				t.Errorf("Expected error %q, got %v", expected, err)
+			}
+		})
+	}
+}
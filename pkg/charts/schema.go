@@ -0,0 +1,217 @@
+package charts
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"k8s.io/helm/pkg/chartutil"
+)
+
+// SchemaFileName is the name of the JSON schema file at the root of a chart,
+// as expected by Helm 3
+const SchemaFileName = "values.schema.json"
+
+const definitionsRefPrefix = "#/definitions/"
+
+// Schema holds the subset of JSON Schema (draft 7) keywords that is used to
+// validate the values of the charts
+type Schema struct {
+	Ref                  string             `json:"$ref"`
+	Type                 string             `json:"type"`
+	Properties           map[string]*Schema `json:"properties"`
+	AdditionalProperties *Schema            `json:"additionalProperties"`
+	Items                *Schema            `json:"items"`
+	Required             []string           `json:"required"`
+	Enum                 []interface{}      `json:"enum"`
+	Minimum              *float64           `json:"minimum"`
+	Maximum              *float64           `json:"maximum"`
+	Pattern              string             `json:"pattern"`
+	Definitions          map[string]*Schema `json:"definitions"`
+}
+
+// ReadSchema reads and parses the JSON schema of the chart in dir
+func ReadSchema(dir string) (*Schema, error) {
+	file := &chartutil.BufferedFile{Name: SchemaFileName}
+	if err := ReadFile(dir, file); err != nil {
+		return nil, err
+	}
+
+	var schema Schema
+	if err := json.Unmarshal(file.Data, &schema); err != nil {
+		return nil, fmt.Errorf("failed to parse %s%s: %s", dir, SchemaFileName, err)
+	}
+	return &schema, nil
+}
+
+// Validate checks values against the schema, and returns an error listing all
+// the violations found, each one prefixed with the path of the offending
+// value (e.g. "global.proxy.ports.admin"). Null values are considered absent,
+// as that's how Go encodes nil pointers, maps and slices.
+func (s *Schema) Validate(values interface{}) error {
+	// Round-trip the values through JSON, so that they're validated under the
+	// same names and types the templates see them with
+	data, err := json.Marshal(values)
+	if err != nil {
+		return err
+	}
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+
+	var violations []string
+	s.validate(s, "", doc, &violations)
+	if len(violations) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("invalid values:\n  * %s", strings.Join(violations, "\n  * "))
+}
+
+func (s *Schema) validate(root *Schema, path string, value interface{}, violations *[]string) {
+	if s.Ref != "" {
+		ref, ok := root.Definitions[strings.TrimPrefix(s.Ref, definitionsRefPrefix)]
+		if !strings.HasPrefix(s.Ref, definitionsRefPrefix) || !ok {
+			*violations = append(*violations, fmt.Sprintf("%s: unresolvable schema reference %q", displayPath(path), s.Ref))
+			return
+		}
+		s = ref
+	}
+
+	if value == nil {
+		return
+	}
+
+	violation := func(format string, args ...interface{}) {
+		*violations = append(*violations, fmt.Sprintf("%s: %s", displayPath(path), fmt.Sprintf(format, args...)))
+	}
+
+	if s.Type != "" && !hasType(value, s.Type) {
+		violation("must be of type %s, got %s", s.Type, jsonType(value))
+		return
+	}
+
+	if len(s.Enum) > 0 && !inEnum(value, s.Enum) {
+		violation("must be one of %s, got %s", formatEnum(s.Enum), format(value))
+	}
+
+	switch v := value.(type) {
+	case float64:
+		if s.Minimum != nil && v < *s.Minimum {
+			violation("must be greater than or equal to %v, got %v", *s.Minimum, v)
+		}
+		if s.Maximum != nil && v > *s.Maximum {
+			violation("must be less than or equal to %v, got %v", *s.Maximum, v)
+		}
+	case string:
+		if s.Pattern != "" {
+			re, err := regexp.Compile(s.Pattern)
+			if err != nil {
+				violation("invalid schema pattern %q: %s", s.Pattern, err)
+			} else if !re.MatchString(v) {
+				violation("must match the pattern %q, got %q", s.Pattern, v)
+			}
+		}
+	case []interface{}:
+		if s.Items != nil {
+			for i, item := range v {
+				s.Items.validate(root, fmt.Sprintf("%s[%d]", path, i), item, violations)
+			}
+		}
+	case map[string]interface{}:
+		for _, name := range s.Required {
+			if v[name] == nil {
+				violation("missing required property %q", name)
+			}
+		}
+
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			propSchema, ok := s.Properties[k]
+			if !ok {
+				propSchema = s.AdditionalProperties
+			}
+			if propSchema != nil {
+				propSchema.validate(root, joinPath(path, k), v[k], violations)
+			}
+		}
+	}
+}
+
+func hasType(value interface{}, typ string) bool {
+	switch typ {
+	case "integer":
+		n, ok := value.(float64)
+		return ok && n == math.Trunc(n)
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	default:
+		return jsonType(value) == typ
+	}
+}
+
+func jsonType(value interface{}) string {
+	switch value.(type) {
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	default:
+		return "null"
+	}
+}
+
+func inEnum(value interface{}, enum []interface{}) bool {
+	for _, e := range enum {
+		if reflect.DeepEqual(value, e) {
+			return true
+		}
+	}
+	return false
+}
+
+func formatEnum(enum []interface{}) string {
+	values := make([]string, len(enum))
+	for i, e := range enum {
+		values[i] = format(e)
+	}
+	return strings.Join(values, ", ")
+}
+
+func format(value interface{}) string {
+	if s, ok := value.(string); ok {
+		return fmt.Sprintf("%q", s)
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func displayPath(path string) string {
+	if path == "" {
+		return "<root>"
+	}
+	return path
+}
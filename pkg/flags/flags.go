@@ -41,6 +41,14 @@ func AddTraceFlags(cmd *flag.FlagSet) *string {
 	return traceCollector
 }
 
+// AddCryptoPolicyFlag adds the crypto-policy flag to the flagSet and returns
+// its pointer for usage
+func AddCryptoPolicyFlag(cmd *flag.FlagSet) *string {
+	cryptoPolicy := cmd.String("crypto-policy", "default", "crypto policy restricting the TLS versions, cipher suites and keys used, must be one of: default, fips")
+
+	return cryptoPolicy
+}
+
 func setLogLevel(logLevel string) {
 	level, err := log.ParseLevel(logLevel)
 	if err != nil {
@@ -1,3 +1,22 @@
+// Package healthcheck implements the checks run by `linkerd check`, and can be
+// imported to run them from other tools:
+//
+//	hc := healthcheck.NewHealthChecker(
+//		[]healthcheck.CategoryID{
+//			healthcheck.KubernetesAPIChecks,
+//			healthcheck.LinkerdControlPlaneExistenceChecks,
+//		},
+//		&healthcheck.Options{
+//			ControlPlaneNamespace: "linkerd",
+//			RetryDeadline:         time.Now().Add(time.Minute),
+//		},
+//	)
+//	results := hc.RunChecksWithResults(nil)
+//
+// The checks of a category rely on the clients and resources fetched by the
+// categories it depends on, which must therefore be enabled too; most depend
+// on KubernetesAPIChecks. Running the checks doesn't print anything nor exit
+// the process.
 package healthcheck
 
 import (
@@ -77,6 +96,22 @@ const (
 	// to determine if a control plane is already installed.
 	LinkerdPreInstallGlobalResourcesChecks CategoryID = "pre-linkerd-global-resources"
 
+	// LinkerdPreInstallCRDsChecks adds a check to determine the existence of the
+	// Linkerd CustomResourceDefinitions during the pre-install phase. It is kept
+	// apart from LinkerdPreInstallGlobalResourcesChecks because the CRDs are
+	// installed in their own stage, by `linkerd install crds`, which precedes
+	// `linkerd install config`.
+	LinkerdPreInstallCRDsChecks CategoryID = "pre-linkerd-crds"
+
+	// LinkerdPreInstallCapacityChecks adds checks to validate that the cluster
+	// runs at least the minimum Kubernetes version (warning if it's more recent
+	// than the ones Linkerd is tested with), and that its schedulable nodes have
+	// enough allocatable CPU and memory left, summed across all of them, to fit
+	// the ControlPlaneRequests. These checks are run by `linkerd install`.
+	// These checks are dependent on the output of KubernetesAPIChecks, so those
+	// checks must be added first.
+	LinkerdPreInstallCapacityChecks CategoryID = "pre-kubernetes-capacity"
+
 	// LinkerdConfigChecks enabled by `linkerd check config`
 
 	// LinkerdConfigChecks adds a series of checks to validate that the Linkerd
@@ -149,6 +184,7 @@ const (
 	LinkerdCNIResourceLabel = "linkerd.io/cni-resource"
 
 	linkerdCNIDisabledSkipReason = "skipping check because CNI is not enabled"
+	spireIssuerSkipReason        = "skipping check because the issuer certificate is obtained from a SPIRE server"
 	linkerdCNIResourceName       = "linkerd-cni"
 	linkerdCNIConfigMapName      = "linkerd-cni-config"
 
@@ -266,6 +302,17 @@ func (e *SkipError) Error() string {
 	return e.Reason
 }
 
+// WarningError is returned by a check to report its failure as a warning,
+// for checks whose severity depends on the problem found.
+type WarningError struct {
+	Err error
+}
+
+// Error satisfies the error interface for WarningError.
+func (e *WarningError) Error() string {
+	return e.Err.Error()
+}
+
 // VerboseSuccess implements the error interface but represents a success with
 // a message.
 type VerboseSuccess struct {
@@ -316,7 +363,7 @@ type checker struct {
 }
 
 // CheckResult encapsulates a check's identifying information and output
-// Note there exists an analogous user-facing type, `cmd.check`, for output via
+// Note there exists an analogous serializable type, `Check`, for output via
 // `linkerd check -o json`.
 type CheckResult struct {
 	Category    CategoryID
@@ -351,6 +398,85 @@ type Options struct {
 	CNIEnabled            bool
 	InstallManifest       string
 	MultiCluster          bool
+	// DataPlaneSelector restricts the data plane checks to the pods of
+	// DataPlaneNamespace matching this label selector
+	DataPlaneSelector string
+	// CertExpiryWarning is the time before their expiry the trust anchors,
+	// issuer and webhook certificates are reported as expiring soon (default
+	// 60 days), and CertExpiryError the time before their expiry the trust
+	// anchors and issuer certificate are reported as not valid anymore
+	// (default: once expired)
+	CertExpiryWarning time.Duration
+	CertExpiryError   time.Duration
+	// ProxyCertExpiryWarning is the time before their expiry the proxies'
+	// certificates are reported as expiring soon (default 1 hour)
+	ProxyCertExpiryWarning time.Duration
+	// RetryAll makes every failing check but the warnings retried until
+	// RetryDeadline, instead of only the ones waiting for resources to become
+	// ready
+	RetryAll bool
+	// RetryBackoff configures the delays between the retries of a check
+	RetryBackoff Backoff
+	// CheckTimeout bounds each run of a check (default 30s)
+	CheckTimeout time.Duration
+	// CategoryTimeouts bounds the time the checks of a category are retried
+	// for, counted from the start of the category
+	CategoryTimeouts map[CategoryID]time.Duration
+	// ControlPlaneRequests holds the CPU and memory requested by all the
+	// control plane pods. It is checked against the cluster's allocatable
+	// resources by LinkerdPreInstallCapacityChecks.
+	ControlPlaneRequests corev1.ResourceList
+	// ImageArchitectures are the architectures the control plane and proxy
+	// images are built for, and ControlPlaneScheduling the scheduling
+	// constraints of each control plane component. The nodes' architectures
+	// are checked against them by LinkerdPreInstallCapacityChecks.
+	ImageArchitectures     []string
+	ControlPlaneScheduling []ComponentScheduling
+	// NetworkProbeImage is the image of the pod run by LinkerdNetworkChecks,
+	// which needs a shell and nc (default DefaultNetworkProbeImage)
+	NetworkProbeImage string
+	// GatewayName and GatewayNamespace locate the gateway service checked by
+	// LinkerdMulticlusterPreLinkChecks (default DefaultGatewayName and
+	// DefaultGatewayNamespace), and SourceKubeContext is the kubeconfig
+	// context of the cluster that will link to it
+	GatewayName       string
+	GatewayNamespace  string
+	SourceKubeContext string
+}
+
+// Backoff configures the delays between the retries of a check: the first
+// retry happens after Interval (default 5s), and each subsequent delay is
+// Factor times the previous one, up to MaxInterval if set.
+type Backoff struct {
+	Interval    time.Duration
+	Factor      float64
+	MaxInterval time.Duration
+}
+
+// delays returns a function returning the successive delays between retries
+func (b Backoff) delays() func() time.Duration {
+	interval := b.Interval
+	if interval == 0 {
+		interval = retryWindow
+	}
+	return func() time.Duration {
+		delay := interval
+		if b.Factor > 1 {
+			interval = time.Duration(float64(interval) * b.Factor)
+			if b.MaxInterval > 0 && interval > b.MaxInterval {
+				interval = b.MaxInterval
+			}
+		}
+		return delay
+	}
+}
+
+// ComponentScheduling holds the constraints that determine the nodes a
+// control plane component can be scheduled on
+type ComponentScheduling struct {
+	Component    string
+	NodeSelector map[string]string
+	Tolerations  []corev1.Toleration
 }
 
 // HealthChecker encapsulates all health check checkers, and clients required to
@@ -373,6 +499,15 @@ type HealthChecker struct {
 	cniDaemonSet     *appsv1.DaemonSet
 	links            []multicluster.Link
 	addOns           map[string]interface{}
+
+	// networkProbeResults holds whether each control plane component could be
+	// reached by the network probe pod
+	networkProbeResults map[string]bool
+
+	// gateway is the multicluster gateway service, and sourceAPI the client of
+	// the cluster that will link to it
+	gateway   *corev1.Service
+	sourceAPI *k8s.KubernetesAPI
 }
 
 // NewHealthChecker returns an initialized HealthChecker
@@ -381,8 +516,7 @@ func NewHealthChecker(categoryIDs []CategoryID, options *Options) *HealthChecker
 		Options: options,
 	}
 
-	hc.categories = append(hc.allCategories(), hc.addOnCategories()...)
-	hc.categories = append(hc.categories, hc.multiClusterCategory()...)
+	hc.categories = append(hc.builtinCategories(), hc.extensionCategories()...)
 
 	checkMap := map[CategoryID]struct{}{}
 	for _, category := range categoryIDs {
@@ -397,6 +531,21 @@ func NewHealthChecker(categoryIDs []CategoryID, options *Options) *HealthChecker
 	return hc
 }
 
+// builtinCategories returns all the categories defined by this package, as
+// opposed to the ones registered by extensions
+func (hc *HealthChecker) builtinCategories() []category {
+	categories := append(hc.allCategories(), hc.addOnCategories()...)
+	categories = append(categories, hc.multiClusterCategory()...)
+	categories = append(categories, hc.multiClusterPreLinkCategories()...)
+	categories = append(categories, hc.openShiftCategories()...)
+	categories = append(categories, hc.conflictsCategories()...)
+	categories = append(categories, hc.fipsCategories()...)
+	categories = append(categories, hc.certificatesCategories()...)
+	categories = append(categories, hc.podSecurityCategories()...)
+	categories = append(categories, hc.networkCategories()...)
+	return categories
+}
+
 // allCategories is the global, ordered list of all checkers, grouped by
 // category. This method is attached to the HealthChecker struct because the
 // checkers directly reference other members of the struct, such as kubeAPI,
@@ -573,13 +722,6 @@ func (hc *HealthChecker) allCategories() []category {
 						return hc.checkClusterRoleBindings(false, hc.expectedRBACNames(), hc.controlPlaneComponentsSelector())
 					},
 				},
-				{
-					description: "no CustomResourceDefinitions exist",
-					hintAnchor:  "pre-l5d-existence",
-					check: func(context.Context) error {
-						return hc.checkCustomResourceDefinitions(false)
-					},
-				},
 				{
 					description: "no MutatingWebhookConfigurations exist",
 					hintAnchor:  "pre-l5d-existence",
@@ -603,6 +745,60 @@ func (hc *HealthChecker) allCategories() []category {
 				},
 			},
 		},
+		{
+			id: LinkerdPreInstallCRDsChecks,
+			checkers: []checker{
+				{
+					description: "no CustomResourceDefinitions exist",
+					hintAnchor:  "pre-l5d-existence",
+					check: func(context.Context) error {
+						return hc.checkCustomResourceDefinitions(false)
+					},
+				},
+			},
+		},
+		{
+			id: LinkerdPreInstallCapacityChecks,
+			checkers: []checker{
+				{
+					description: "is running the minimum Kubernetes API version",
+					hintAnchor:  "pre-k8s-version",
+					check: func(context.Context) error {
+						return hc.kubeAPI.CheckVersion(hc.kubeVersion)
+					},
+				},
+				{
+					description: "is running a Kubernetes version Linkerd is tested with",
+					hintAnchor:  "pre-k8s-version",
+					warning:     true,
+					check: func(context.Context) error {
+						return hc.kubeAPI.CheckTestedVersion(hc.kubeVersion)
+					},
+				},
+				{
+					description: "has enough allocatable CPU and memory across its nodes",
+					hintAnchor:  "pre-k8s-capacity",
+					check: func(context.Context) error {
+						return hc.checkAllocatableResources()
+					},
+				},
+				{
+					description: "control plane images support the architecture of its nodes",
+					hintAnchor:  "pre-k8s-arch",
+					check: func(context.Context) error {
+						return hc.checkControlPlaneArchitectures()
+					},
+				},
+				{
+					description: "proxy images support the architecture of all the nodes",
+					hintAnchor:  "pre-k8s-arch",
+					warning:     true,
+					check: func(context.Context) error {
+						return hc.checkNodeArchitectures()
+					},
+				},
+			},
+		},
 		{
 			id: LinkerdControlPlaneExistenceChecks,
 			checkers: []checker{
@@ -951,7 +1147,7 @@ func (hc *HealthChecker) allCategories() []category {
 					check: func(ctx context.Context) error {
 						var expiredAnchors []string
 						for _, anchor := range hc.trustAnchors {
-							if err := issuercerts.CheckCertValidityPeriod(anchor); err != nil {
+							if err := hc.checkCertValidity(anchor); err != nil {
 								expiredAnchors = append(expiredAnchors, fmt.Sprintf("* %v %s %s", anchor.SerialNumber, anchor.Subject.CommonName, err))
 							}
 						}
@@ -963,13 +1159,13 @@ func (hc *HealthChecker) allCategories() []category {
 					},
 				},
 				{
-					description: "trust anchors are valid for at least 60 days",
+					description: fmt.Sprintf("trust anchors are valid for at least %s", formatExpiryWindow(hc.certExpiryWarning())),
 					hintAnchor:  "l5d-identity-trustAnchors-not-expiring-soon",
 					warning:     true,
 					check: func(ctx context.Context) error {
 						var expiringAnchors []string
 						for _, anchor := range hc.trustAnchors {
-							if err := issuercerts.CheckExpiringSoon(anchor); err != nil {
+							if err := issuercerts.CheckExpiringWithin(anchor.NotAfter, hc.certExpiryWarning()); err != nil {
 								expiringAnchors = append(expiringAnchors, fmt.Sprintf("* %v %s %s", anchor.SerialNumber, anchor.Subject.CommonName, err))
 							}
 						}
@@ -984,6 +1180,9 @@ func (hc *HealthChecker) allCategories() []category {
 					hintAnchor:  "l5d-identity-issuer-cert-uses-supported-crypto",
 					fatal:       true,
 					check: func(context.Context) error {
+						if hc.issuerCert == nil {
+							return &SkipError{Reason: spireIssuerSkipReason}
+						}
 						if err := issuercerts.CheckCertAlgoRequirements(hc.issuerCert.Certificate); err != nil {
 							return fmt.Errorf("issuer certificate %s", err)
 						}
@@ -995,18 +1194,24 @@ func (hc *HealthChecker) allCategories() []category {
 					hintAnchor:  "l5d-identity-issuer-cert-is-time-valid",
 					fatal:       true,
 					check: func(ctx context.Context) error {
-						if err := issuercerts.CheckCertValidityPeriod(hc.issuerCert.Certificate); err != nil {
+						if hc.issuerCert == nil {
+							return &SkipError{Reason: spireIssuerSkipReason}
+						}
+						if err := hc.checkCertValidity(hc.issuerCert.Certificate); err != nil {
 							return fmt.Errorf("issuer certificate is %s", err)
 						}
 						return nil
 					},
 				},
 				{
-					description: "issuer cert is valid for at least 60 days",
-					warning:     true,
+					description: fmt.Sprintf("issuer cert is valid for at least %s", formatExpiryWindow(hc.certExpiryWarning())),
 					hintAnchor:  "l5d-identity-issuer-cert-not-expiring-soon",
+					warning:     true,
 					check: func(context.Context) error {
-						if err := issuercerts.CheckExpiringSoon(hc.issuerCert.Certificate); err != nil {
+						if hc.issuerCert == nil {
+							return &SkipError{Reason: spireIssuerSkipReason}
+						}
+						if err := issuercerts.CheckExpiringWithin(hc.issuerCert.Certificate.NotAfter, hc.certExpiryWarning()); err != nil {
 							return fmt.Errorf("issuer certificate %s", err)
 						}
 						return nil
@@ -1016,6 +1221,9 @@ func (hc *HealthChecker) allCategories() []category {
 					description: "issuer cert is issued by the trust anchor",
 					hintAnchor:  "l5d-identity-issuer-cert-issued-by-trust-anchor",
 					check: func(ctx context.Context) error {
+						if hc.issuerCert == nil {
+							return &SkipError{Reason: spireIssuerSkipReason}
+						}
 						return hc.issuerCert.Verify(tls.CertificatesToPool(hc.trustAnchors), hc.issuerIdentity(), time.Time{})
 					},
 				},
@@ -1183,6 +1391,17 @@ func (hc *HealthChecker) allCategories() []category {
 						return nil
 					},
 				},
+				{
+					description: "control plane and cli versions are within the supported skew",
+					hintAnchor:  "l5d-version-skew",
+					warning:     true,
+					check: func(context.Context) error {
+						if err := version.CheckSkew(hc.serverVersion, version.Version); err != nil {
+							return err
+						}
+						return version.CheckSkew(version.Version, hc.serverVersion)
+					},
+				},
 			},
 		},
 		{
@@ -1269,6 +1488,31 @@ func (hc *HealthChecker) allCategories() []category {
 						return nil
 					},
 				},
+				{
+					// covers the supported version skew between the proxies
+					// and the control plane as well
+					description:   "data plane pods are healthy",
+					hintAnchor:    "l5d-data-plane-pods",
+					warning:       true,
+					retryDeadline: hc.RetryDeadline,
+					check: func(context.Context) error {
+						pods, err := hc.getDataPlaneK8sPods()
+						if err != nil {
+							return err
+						}
+
+						trustAnchorsPem := hc.linkerdConfig.GetGlobal().GetIdentityContext().GetTrustAnchorsPem()
+						return validateDataPlanePodsHealth(pods, hc.serverVersion, trustAnchorsPem)
+					},
+				},
+				{
+					description: "no pods enabled for injection run on Windows or excluded nodes",
+					hintAnchor:  "l5d-data-plane-excluded-nodes",
+					warning:     true,
+					check: func(context.Context) error {
+						return hc.checkExcludedNodes()
+					},
+				},
 			},
 		},
 		{
@@ -1323,7 +1567,7 @@ func (hc *HealthChecker) checkCertAndAnchors(cert *tls.Cred, trustAnchors []*x50
 	var expiringAnchors []string
 	for _, anchor := range trustAnchors {
 		anchor := anchor
-		if err := issuercerts.CheckExpiringSoon(anchor); err != nil {
+		if err := issuercerts.CheckExpiringWithin(anchor.NotAfter, hc.certExpiryWarning()); err != nil {
 			expiringAnchors = append(expiringAnchors, fmt.Sprintf("* %v %s %s", anchor.SerialNumber, anchor.Subject.CommonName, err))
 		}
 	}
@@ -1337,7 +1581,7 @@ func (hc *HealthChecker) checkCertAndAnchors(cert *tls.Cred, trustAnchors []*x50
 	}
 
 	// check cert not expiring soon
-	if err := issuercerts.CheckExpiringSoon(cert.Certificate); err != nil {
+	if err := issuercerts.CheckExpiringWithin(cert.Certificate.NotAfter, hc.certExpiryWarning()); err != nil {
 		return fmt.Errorf("certificate %s", err)
 	}
 
@@ -1405,8 +1649,10 @@ func (hc *HealthChecker) RunChecks(observer CheckObserver) bool {
 	success := true
 	for _, c := range hc.categories {
 		if c.enabled {
+			start := time.Now()
 			for _, checker := range c.checkers {
 				checker := checker // pin
+				checker.retryDeadline = hc.retryDeadline(c.id, &checker, start)
 				if checker.check != nil {
 					if !hc.runCheck(c.id, &checker, observer) {
 						if !checker.warning {
@@ -1435,9 +1681,43 @@ func (hc *HealthChecker) RunChecks(observer CheckObserver) bool {
 	return success
 }
 
+// retryDeadline returns the deadline before which c, a checker of the
+// categoryID category whose checks started at start, is retried
+func (hc *HealthChecker) retryDeadline(categoryID CategoryID, c *checker, start time.Time) time.Time {
+	deadline := c.retryDeadline
+	if hc.RetryAll && !c.warning && hc.RetryDeadline.After(deadline) {
+		deadline = hc.RetryDeadline
+	}
+	if timeout, ok := hc.CategoryTimeouts[categoryID]; ok && !deadline.IsZero() {
+		if categoryDeadline := start.Add(timeout); categoryDeadline.Before(deadline) {
+			deadline = categoryDeadline
+		}
+	}
+	return deadline
+}
+
+// checkTimeout returns the timeout of each run of a check
+func (hc *HealthChecker) checkTimeout() time.Duration {
+	if hc.CheckTimeout > 0 {
+		return hc.CheckTimeout
+	}
+	return requestTimeout
+}
+
+// sleepUntilRetry waits for the next delay of backoff, without going past
+// deadline
+func sleepUntilRetry(backoff func() time.Duration, deadline time.Time) {
+	delay := backoff()
+	if untilDeadline := time.Until(deadline); untilDeadline < delay {
+		delay = untilDeadline
+	}
+	time.Sleep(delay)
+}
+
 func (hc *HealthChecker) runCheck(categoryID CategoryID, c *checker, observer CheckObserver) bool {
+	backoff := hc.RetryBackoff.delays()
 	for {
-		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), hc.checkTimeout())
 		defer cancel()
 		err := c.check(ctx)
 		if se, ok := err.(*SkipError); ok {
@@ -1451,6 +1731,10 @@ func (hc *HealthChecker) runCheck(categoryID CategoryID, c *checker, observer Ch
 			HintAnchor:  c.hintAnchor,
 			Warning:     c.warning,
 		}
+		if we, ok := err.(*WarningError); ok {
+			checkResult.Warning = true
+			err = we.Err
+		}
 		if vs, ok := err.(*VerboseSuccess); ok {
 			checkResult.Description = fmt.Sprintf("%s\n%s", checkResult.Description, vs.Message)
 		} else if err != nil {
@@ -1465,12 +1749,13 @@ func (hc *HealthChecker) runCheck(categoryID CategoryID, c *checker, observer Ch
 			log.Debugf("Retrying on error: %s", err)
 
 			observer(checkResult)
-			time.Sleep(retryWindow)
+			sleepUntilRetry(backoff, c.retryDeadline)
 			continue
 		}
 
 		observer(checkResult)
-		return checkResult.Err == nil
+		// checks reporting a WarningError don't affect the outcome
+		return checkResult.Err == nil || (checkResult.Warning && !c.warning)
 	}
 }
 
@@ -1484,8 +1769,9 @@ func (hc *HealthChecker) runCheck(categoryID CategoryID, c *checker, observer Ch
 // while making sure no duplicate messages are sent.
 func (hc *HealthChecker) runCheckRPC(categoryID CategoryID, c *checker, observer CheckObserver) bool {
 	observedResults := []CheckResult{}
+	backoff := hc.RetryBackoff.delays()
 	for {
-		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), hc.checkTimeout())
 		defer cancel()
 		checkRsp, err := c.checkRPC(ctx)
 		if se, ok := err.(*SkipError); ok {
@@ -1537,7 +1823,7 @@ func (hc *HealthChecker) runCheckRPC(categoryID CategoryID, c *checker, observer
 
 		if checkResult.Retry {
 			log.Debug("Retrying on error")
-			time.Sleep(retryWindow)
+			sleepUntilRetry(backoff, c.retryDeadline)
 			continue
 		}
 
@@ -1569,7 +1855,10 @@ func (hc *HealthChecker) checkLinkerdConfigConfigMap() (string, *configPb.All, e
 // 1. There is a config map present with identity context
 // 2. The scheme in the identity context corresponds to the format of the issuer secret
 // 3. The trust anchors (if scheme == kubernetes.io/tls) in the secret equal the ones in config
-// 4. The certs and key are parsable
+// 4. The certs and key are parsable (only the cert if the key is held by a KMS)
+//
+// When the issuer certificate is obtained from a SPIRE server, there's no
+// issuer secret and only the trust anchors are returned.
 func (hc *HealthChecker) checkCertificatesConfig() (*tls.Cred, []*x509.Certificate, error) {
 	_, configPB, err := FetchLinkerdConfigMap(hc.kubeAPI, hc.ControlPlaneNamespace)
 	if err != nil {
@@ -1579,6 +1868,14 @@ func (hc *HealthChecker) checkCertificatesConfig() (*tls.Cred, []*x509.Certifica
 	idctx := configPB.Global.IdentityContext
 	var data *issuercerts.IssuerCertData
 
+	if idctx.Scheme == k8s.IdentityIssuerSchemeSpire {
+		anchors, err := tls.DecodePEMCertificates(idctx.TrustAnchorsPem)
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, anchors, nil
+	}
+
 	if idctx.Scheme == "" || idctx.Scheme == k8s.IdentityIssuerSchemeLinkerd {
 		data, err = issuercerts.FetchIssuerData(hc.kubeAPI, idctx.TrustAnchorsPem, hc.ControlPlaneNamespace)
 	} else {
@@ -1594,7 +1891,7 @@ func (hc *HealthChecker) checkCertificatesConfig() (*tls.Cred, []*x509.Certifica
 		return nil, nil, err
 	}
 
-	issuerCreds, err := tls.ValidateAndCreateCreds(data.IssuerCrt, data.IssuerKey)
+	issuerCreds, err := data.Creds()
 	if err != nil {
 		return nil, nil, err
 	}
@@ -1938,7 +2235,17 @@ type MeshedPodIdentityData struct {
 
 // GetMeshedPodsIdentityData obtains the identity data (trust anchors) for all meshed pods
 func GetMeshedPodsIdentityData(api kubernetes.Interface, dataPlaneNamespace string) ([]MeshedPodIdentityData, error) {
-	podList, err := api.CoreV1().Pods(dataPlaneNamespace).List(metav1.ListOptions{LabelSelector: k8s.ControllerNSLabel})
+	return getMeshedPodsIdentityData(api, dataPlaneNamespace, "")
+}
+
+// getMeshedPodsIdentityData is similar to GetMeshedPodsIdentityData, only
+// returning the pods matching selector if set
+func getMeshedPodsIdentityData(api kubernetes.Interface, dataPlaneNamespace, selector string) ([]MeshedPodIdentityData, error) {
+	labelSelector := k8s.ControllerNSLabel
+	if selector != "" {
+		labelSelector += "," + selector
+	}
+	podList, err := api.CoreV1().Pods(dataPlaneNamespace).List(metav1.ListOptions{LabelSelector: labelSelector})
 	if err != nil {
 		return nil, err
 	}
@@ -1965,7 +2272,7 @@ func GetMeshedPodsIdentityData(api kubernetes.Interface, dataPlaneNamespace stri
 }
 
 func (hc *HealthChecker) checkDataPlaneProxiesCertificate() error {
-	meshedPods, err := GetMeshedPodsIdentityData(hc.kubeAPI.Interface, hc.DataPlaneNamespace)
+	meshedPods, err := getMeshedPodsIdentityData(hc.kubeAPI.Interface, hc.DataPlaneNamespace, hc.DataPlaneSelector)
 	if err != nil {
 		return err
 	}
@@ -2046,11 +2353,12 @@ func checkResources(resourceName string, objects []runtime.Object, expectedNames
 
 func (hc *HealthChecker) getDataPlanePods(ctx context.Context) ([]*pb.Pod, error) {
 	req := &pb.ListPodsRequest{}
-	if hc.DataPlaneNamespace != "" {
+	if hc.DataPlaneNamespace != "" || hc.DataPlaneSelector != "" {
 		req.Selector = &pb.ResourceSelection{
 			Resource: &pb.Resource{
 				Namespace: hc.DataPlaneNamespace,
 			},
+			LabelSelector: hc.DataPlaneSelector,
 		}
 	}
 
@@ -2069,6 +2377,96 @@ func (hc *HealthChecker) getDataPlanePods(ctx context.Context) ([]*pb.Pod, error
 	return pods, nil
 }
 
+// getDataPlaneK8sPods returns the pods of the data plane targeted by the
+// checks, i.e. those of DataPlaneNamespace matching DataPlaneSelector
+func (hc *HealthChecker) getDataPlaneK8sPods() ([]corev1.Pod, error) {
+	selector := fmt.Sprintf("%s=%s", k8s.ControllerNSLabel, hc.ControlPlaneNamespace)
+	if hc.DataPlaneSelector != "" {
+		selector += "," + hc.DataPlaneSelector
+	}
+	podList, err := hc.kubeAPI.CoreV1().Pods(hc.DataPlaneNamespace).List(metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, err
+	}
+	return podList.Items, nil
+}
+
+// validateDataPlanePodsHealth checks each data plane pod for the issues
+// preventing its proxy from working with the control plane: the proxy isn't
+// ready, its version isn't supported by the control plane, it doesn't have
+// the current trust anchors, or the pod's injection annotations are stale.
+// Unlike the other data plane checks, all the issues are reported, grouped by
+// pod.
+func validateDataPlanePodsHealth(pods []corev1.Pod, controlPlaneVersion, trustAnchorsPem string) error {
+	var errs []string
+	for _, pod := range pods {
+		// the pods of completed jobs don't run their proxy anymore
+		if pod.Status.Phase == corev1.PodSucceeded {
+			continue
+		}
+
+		var issues []string
+
+		if status := k8s.GetPodStatus(pod); status != "Running" {
+			issues = append(issues, fmt.Sprintf("pod is %s", status))
+		}
+		// native sidecar proxies run as restartable init containers
+		for _, statuses := range [][]corev1.ContainerStatus{pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses} {
+			for _, status := range statuses {
+				if status.Name == k8s.ProxyContainerName && !status.Ready {
+					issues = append(issues, fmt.Sprintf("the %s container isn't ready", k8s.ProxyContainerName))
+				}
+			}
+		}
+
+		var proxy *corev1.Container
+		for _, containers := range [][]corev1.Container{pod.Spec.InitContainers, pod.Spec.Containers} {
+			for i := range containers {
+				if containers[i].Name == k8s.ProxyContainerName {
+					proxy = &containers[i]
+				}
+			}
+		}
+		if proxy == nil {
+			issues = append(issues, fmt.Sprintf("the %s container is missing, re-inject the pod", k8s.ProxyContainerName))
+		} else {
+			if parts := strings.Split(proxy.Image, ":"); len(parts) > 1 {
+				proxyVersion := parts[len(parts)-1]
+				err := version.CheckSkew(proxyVersion, controlPlaneVersion)
+				if err == nil {
+					err = version.CheckSkew(controlPlaneVersion, proxyVersion)
+				}
+				if err != nil {
+					issues = append(issues, fmt.Sprintf("proxy version isn't supported by the control plane: %s", err))
+				}
+			}
+
+			if trustAnchorsPem != "" {
+				for _, env := range proxy.Env {
+					if env.Name == identity.EnvTrustAnchors && strings.TrimSpace(env.Value) != strings.TrimSpace(trustAnchorsPem) {
+						issues = append(issues, "proxy doesn't have the current trust anchors, restart the pod")
+					}
+				}
+			}
+		}
+
+		if pod.Annotations[k8s.ProxyInjectAnnotation] == k8s.ProxyInjectDisabled && proxy != nil {
+			issues = append(issues, fmt.Sprintf("pod is annotated with %s: %s but has a proxy, restart it", k8s.ProxyInjectAnnotation, k8s.ProxyInjectDisabled))
+		}
+		if _, ok := pod.Annotations[k8s.ProxyVersionAnnotation]; !ok && proxy != nil {
+			issues = append(issues, fmt.Sprintf("pod is missing the %s annotation, re-inject it", k8s.ProxyVersionAnnotation))
+		}
+
+		if len(issues) > 0 {
+			errs = append(errs, fmt.Sprintf("\t* %s/%s:\n\t\t- %s", pod.Namespace, pod.Name, strings.Join(issues, "\n\t\t- ")))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("Some data plane pods aren't healthy:\n%s", strings.Join(errs, "\n"))
+	}
+	return nil
+}
+
 func (hc *HealthChecker) checkCanPerformAction(api *k8s.KubernetesAPI, verb, namespace, group, version, resource string) error {
 	if api == nil {
 		// we should never get here
@@ -2264,6 +2662,208 @@ func (hc *HealthChecker) checkClockSkew() error {
 	return nil
 }
 
+// checkAllocatableResources verifies that the schedulable nodes have enough
+// allocatable CPU and memory left, once the requests of the pods already
+// running on them are accounted for, to fit the ControlPlaneRequests. The
+// resources are summed across the nodes, so this doesn't guarantee that each
+// control plane pod fits on a single node.
+func (hc *HealthChecker) checkAllocatableResources() error {
+	if len(hc.ControlPlaneRequests) == 0 {
+		return &SkipError{Reason: "the control plane doesn't request any resources"}
+	}
+
+	nodeList, err := hc.kubeAPI.CoreV1().Nodes().List(metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	available := corev1.ResourceList{}
+	nodes := map[string]struct{}{}
+	for _, node := range nodeList.Items {
+		if !nodeSchedulable(node) {
+			continue
+		}
+		nodes[node.Name] = struct{}{}
+		addResources(available, node.Status.Allocatable)
+	}
+
+	podList, err := hc.kubeAPI.CoreV1().Pods("").List(metav1.ListOptions{
+		FieldSelector: "status.phase!=Succeeded,status.phase!=Failed",
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, pod := range podList.Items {
+		if _, ok := nodes[pod.Spec.NodeName]; !ok {
+			continue
+		}
+		for _, c := range pod.Spec.Containers {
+			subtractResources(available, c.Resources.Requests)
+		}
+	}
+
+	var shortages []string
+	for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+		requested, ok := hc.ControlPlaneRequests[name]
+		if !ok || requested.IsZero() {
+			continue
+		}
+		left := available[name]
+		if left.Sign() < 0 {
+			left.Set(0)
+		}
+		if requested.Cmp(left) > 0 {
+			shortages = append(shortages, fmt.Sprintf("%s: the control plane requests %s, but only %s is allocatable", name, requested.String(), left.String()))
+		}
+	}
+
+	if len(shortages) > 0 {
+		return fmt.Errorf("not enough resources left on the schedulable nodes combined:\n    %s", strings.Join(shortages, "\n    "))
+	}
+
+	return nil
+}
+
+// checkControlPlaneArchitectures validates that every node a control plane
+// component can be scheduled on has an architecture the images are built for
+func (hc *HealthChecker) checkControlPlaneArchitectures() error {
+	if len(hc.ImageArchitectures) == 0 {
+		return &SkipError{Reason: "the image architectures are unknown"}
+	}
+
+	nodeList, err := hc.kubeAPI.CoreV1().Nodes().List(metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	var unsupported []string
+	for _, node := range nodeList.Items {
+		arch := nodeArchitecture(node)
+		if !nodeSchedulable(node) || containsString(hc.ImageArchitectures, arch) {
+			continue
+		}
+
+		var components []string
+		for _, c := range hc.ControlPlaneScheduling {
+			if nodeMatches(node, c) {
+				components = append(components, c.Component)
+			}
+		}
+		if len(components) > 0 {
+			unsupported = append(unsupported, fmt.Sprintf("%s (%s): %s", node.Name, arch, strings.Join(components, ", ")))
+		}
+	}
+
+	if len(unsupported) > 0 {
+		return fmt.Errorf("these nodes can run control plane components, but the images are only built for %s:\n    %s\nset the components' node selectors, e.g. with --component-arch", strings.Join(hc.ImageArchitectures, ", "), strings.Join(unsupported, "\n    "))
+	}
+
+	return nil
+}
+
+// checkNodeArchitectures validates that every schedulable node has an
+// architecture the proxy images are built for, since meshed pods can be
+// scheduled on any of them
+func (hc *HealthChecker) checkNodeArchitectures() error {
+	if len(hc.ImageArchitectures) == 0 {
+		return &SkipError{Reason: "the image architectures are unknown"}
+	}
+
+	nodeList, err := hc.kubeAPI.CoreV1().Nodes().List(metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	var unsupported []string
+	for _, node := range nodeList.Items {
+		if arch := nodeArchitecture(node); nodeSchedulable(node) && !containsString(hc.ImageArchitectures, arch) {
+			unsupported = append(unsupported, fmt.Sprintf("%s (%s)", node.Name, arch))
+		}
+	}
+
+	if len(unsupported) > 0 {
+		return fmt.Errorf("meshed pods can't run on these nodes, the images are only built for %s:\n    %s", strings.Join(hc.ImageArchitectures, ", "), strings.Join(unsupported, "\n    "))
+	}
+
+	return nil
+}
+
+func nodeArchitecture(node corev1.Node) string {
+	if arch := node.Status.NodeInfo.Architecture; arch != "" {
+		return arch
+	}
+	return node.Labels[k8s.ArchLabel]
+}
+
+// nodeMatches returns true if the component's node selector matches the
+// node, and its tolerations tolerate the node's NoSchedule and NoExecute
+// taints
+func nodeMatches(node corev1.Node, c ComponentScheduling) bool {
+	for k, v := range c.NodeSelector {
+		if node.Labels[k] != v {
+			return false
+		}
+	}
+
+	for _, taint := range node.Spec.Taints {
+		taint := taint // pin
+		if taint.Effect == corev1.TaintEffectPreferNoSchedule {
+			continue
+		}
+		tolerated := false
+		for _, toleration := range c.Tolerations {
+			if toleration.ToleratesTaint(&taint) {
+				tolerated = true
+				break
+			}
+		}
+		if !tolerated {
+			return false
+		}
+	}
+
+	return true
+}
+
+func containsString(slice []string, s string) bool {
+	for _, item := range slice {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// nodeSchedulable returns true if node is ready and accepts new pods
+func nodeSchedulable(node corev1.Node) bool {
+	if node.Spec.Unschedulable {
+		return false
+	}
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+func addResources(total, resources corev1.ResourceList) {
+	for name, quantity := range resources {
+		q := total[name]
+		q.Add(quantity)
+		total[name] = q
+	}
+}
+
+func subtractResources(total, resources corev1.ResourceList) {
+	for name, quantity := range resources {
+		q := total[name]
+		q.Sub(quantity)
+		total[name] = q
+	}
+}
+
 func (cr *CheckResult) alreadyObserved(previousResults []CheckResult) bool {
 	for _, result := range previousResults {
 		if result.Description == cr.Description && result.Err == cr.Err {
@@ -0,0 +1,323 @@
+package healthcheck
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/linkerd/linkerd2/pkg/identity"
+	"github.com/linkerd/linkerd2/pkg/issuercerts"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/linkerd/linkerd2/pkg/tls"
+	"github.com/prometheus/common/expfmt"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	// LinkerdProxyCertificatesChecks adds checks to validate that the
+	// certificates of the data plane proxies aren't about to expire, i.e. that
+	// they are renewed by the identity service. These checks scrape every
+	// targeted proxy, and are dependent on the output of KubernetesAPIChecks
+	// and LinkerdControlPlaneExistenceChecks, so those checks must be added
+	// first.
+	LinkerdProxyCertificatesChecks CategoryID = "linkerd-proxy-certificates"
+
+	// proxyCertExpiryMetric is the proxy metric holding the expiry time of its
+	// certificate
+	proxyCertExpiryMetric = "identity_cert_expiration_timestamp_seconds"
+
+	defaultProxyCertExpiryWarning = time.Hour
+
+	// proxyCertRenewalShare is the share of their lifetime certificates can
+	// have left before being reported as expiring soon, regardless of the
+	// expiry window, as the proxies only renew them after ~70% of it
+	proxyCertRenewalShare = 4
+)
+
+func (hc *HealthChecker) certificatesCategories() []category {
+	return []category{
+		{
+			id: LinkerdProxyCertificatesChecks,
+			checkers: []checker{
+				{
+					description: "data plane proxies certificate lifetime overrides are valid",
+					hintAnchor:  "l5d-proxy-cert-lifetimes-valid",
+					warning:     true,
+					check: func(context.Context) error {
+						return hc.checkProxyCertLifetimeAnnotations()
+					},
+				},
+				{
+					description: fmt.Sprintf("data plane proxies certificates are valid for at least %s", formatExpiryWindow(hc.proxyCertExpiryWarning())),
+					hintAnchor:  "l5d-proxy-certs-not-expiring-soon",
+					warning:     true,
+					check: func(context.Context) error {
+						pods, err := hc.getDataPlaneK8sPods()
+						if err != nil {
+							return err
+						}
+						return hc.checkProxiesCertExpiry(pods)
+					},
+				},
+			},
+		},
+	}
+}
+
+// certExpiryWarning returns the time before their expiry the control plane
+// certificates are reported as expiring soon
+func (hc *HealthChecker) certExpiryWarning() time.Duration {
+	if hc.CertExpiryWarning > 0 {
+		return hc.CertExpiryWarning
+	}
+	return issuercerts.ExpirationWarningThreshold
+}
+
+func (hc *HealthChecker) proxyCertExpiryWarning() time.Duration {
+	if hc.ProxyCertExpiryWarning > 0 {
+		return hc.ProxyCertExpiryWarning
+	}
+	return defaultProxyCertExpiryWarning
+}
+
+// checkCertValidity returns an error if a control plane certificate isn't
+// within its validity period, or expires within the CertExpiryError window
+func (hc *HealthChecker) checkCertValidity(cert *x509.Certificate) error {
+	if err := issuercerts.CheckCertValidityPeriod(cert); err != nil {
+		return err
+	}
+	if hc.CertExpiryError > 0 {
+		return issuercerts.CheckExpiringWithin(cert.NotAfter, hc.CertExpiryError)
+	}
+	return nil
+}
+
+// defaultProxyCertValidity returns the validity of the proxies certificates
+// configured for the control plane
+func (hc *HealthChecker) defaultProxyCertValidity() tls.Validity {
+	validity := tls.Validity{Lifetime: identity.DefaultIssuanceLifetime}
+	idctx := hc.linkerdConfig.GetGlobal().GetIdentityContext()
+	if lifetime, err := ptypes.Duration(idctx.GetIssuanceLifetime()); err == nil {
+		validity.Lifetime = lifetime
+	}
+	if jitter, err := ptypes.Duration(idctx.GetRenewalJitter()); err == nil {
+		validity.RenewalJitter = jitter
+	}
+	return validity
+}
+
+// checkProxyCertLifetimeAnnotations returns an error listing the service
+// accounts whose certificate lifetime annotations, or the ones of their
+// namespace, are invalid and are thus ignored by the identity service
+func (hc *HealthChecker) checkProxyCertLifetimeAnnotations() error {
+	namespaces, serviceAccounts, err := hc.proxyCertLifetimeListers()
+	if err != nil {
+		return err
+	}
+	nsList, err := namespaces.List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	annotatedNamespaces := map[string]bool{}
+	for _, ns := range nsList {
+		annotatedNamespaces[ns.Name] = hasProxyCertLifetimeAnnotations(ns.ObjectMeta)
+	}
+	saList, err := serviceAccounts.List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	sort.Slice(saList, func(i, j int) bool {
+		return saList[i].Namespace+"/"+saList[i].Name < saList[j].Namespace+"/"+saList[j].Name
+	})
+
+	defaults := hc.defaultProxyCertValidity()
+	var errs []string
+	for _, sa := range saList {
+		if !annotatedNamespaces[sa.Namespace] && !hasProxyCertLifetimeAnnotations(sa.ObjectMeta) {
+			continue
+		}
+		if _, err := identity.WorkloadValidity(namespaces, serviceAccounts, defaults, sa.Namespace, sa.Name); err != nil {
+			errs = append(errs, fmt.Sprintf("* %s", err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("Some certificate lifetime annotations are invalid, the control plane defaults are used instead:\n\t%s", strings.Join(errs, "\n\t"))
+	}
+	return nil
+}
+
+// proxyCertLifetimeListers returns listers of the namespaces, and of the
+// service accounts of the data plane namespace, whose annotations can override
+// the proxies' certificate lifetime
+func (hc *HealthChecker) proxyCertLifetimeListers() (corelisters.NamespaceLister, corelisters.ServiceAccountLister, error) {
+	namespaces, err := hc.kubeAPI.CoreV1().Namespaces().List(metav1.ListOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+	nsIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for i := range namespaces.Items {
+		if err := nsIndexer.Add(&namespaces.Items[i]); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	serviceAccounts, err := hc.kubeAPI.CoreV1().ServiceAccounts(hc.DataPlaneNamespace).List(metav1.ListOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+	saIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for i := range serviceAccounts.Items {
+		if err := saIndexer.Add(&serviceAccounts.Items[i]); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return corelisters.NewNamespaceLister(nsIndexer), corelisters.NewServiceAccountLister(saIndexer), nil
+}
+
+func hasProxyCertLifetimeAnnotations(meta metav1.ObjectMeta) bool {
+	_, lifetime := meta.Annotations[k8s.ProxyCertLifetimeAnnotation]
+	_, jitter := meta.Annotations[k8s.ProxyCertRenewalJitterAnnotation]
+	return lifetime || jitter
+}
+
+// proxyCertExpiryWindow returns the window in which the certificate of a
+// proxy whose certificates have the given validity is reported as expiring
+// soon: the expiry warning window, shortened for short-lived certificates as
+// they're renewed less than that before their expiry
+func (hc *HealthChecker) proxyCertExpiryWindow(validity tls.Validity) time.Duration {
+	window := hc.proxyCertExpiryWarning()
+	if share := (validity.Lifetime - validity.RenewalJitter) / proxyCertRenewalShare; share > 0 && share < window {
+		window = share
+	}
+	return window
+}
+
+// checkProxiesCertExpiry scrapes the proxies of pods to get the expiry of
+// their certificate
+func (hc *HealthChecker) checkProxiesCertExpiry(pods []corev1.Pod) error {
+	defaults := hc.defaultProxyCertValidity()
+	validities := map[string]tls.Validity{}
+	namespaces, serviceAccounts, err := hc.proxyCertLifetimeListers()
+	if err != nil {
+		return err
+	}
+
+	var errs []string
+	for _, pod := range pods {
+		if pod.Status.Phase != corev1.PodRunning {
+			continue
+		}
+
+		sa := pod.Spec.ServiceAccountName
+		if sa == "" {
+			sa = "default"
+		}
+		key := pod.Namespace + "/" + sa
+		validity, ok := validities[key]
+		if !ok {
+			// invalid overrides are reported by their own check
+			validity, _ = identity.WorkloadValidity(namespaces, serviceAccounts, defaults, pod.Namespace, sa)
+			validities[key] = validity
+		}
+
+		notAfter, err := hc.proxyCertExpiry(pod)
+		if err == nil {
+			err = issuercerts.CheckExpiringWithin(notAfter, hc.proxyCertExpiryWindow(validity))
+		}
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("* %s/%s %s", pod.Namespace, pod.Name, err))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("Some proxies certificates are expiring soon, check that the identity service is renewing them:\n\t%s", strings.Join(errs, "\n\t"))
+	}
+	return nil
+}
+
+func (hc *HealthChecker) proxyCertExpiry(pod corev1.Pod) (time.Time, error) {
+	var proxy *corev1.Container
+	for i := range pod.Spec.Containers {
+		if pod.Spec.Containers[i].Name == k8s.ProxyContainerName {
+			proxy = &pod.Spec.Containers[i]
+		}
+	}
+	if proxy == nil {
+		return time.Time{}, fmt.Errorf("has no %s container", k8s.ProxyContainerName)
+	}
+
+	portForward, err := k8s.NewContainerMetricsForward(hc.kubeAPI, pod, *proxy, false, k8s.ProxyAdminPortName)
+	if err != nil {
+		return time.Time{}, err
+	}
+	defer portForward.Stop()
+	if err := portForward.Init(); err != nil {
+		return time.Time{}, err
+	}
+
+	resp, err := http.Get(portForward.URLFor("/metrics"))
+	if err != nil {
+		return time.Time{}, err
+	}
+	defer resp.Body.Close()
+	metrics, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return parseProxyCertExpiry(metrics)
+}
+
+// parseProxyCertExpiry returns the expiry of a proxy's certificate, from its
+// metrics
+func parseProxyCertExpiry(metrics []byte) (time.Time, error) {
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(bytes.NewReader(metrics))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid metrics: %s", err)
+	}
+	family, ok := families[proxyCertExpiryMetric]
+	if !ok || len(family.GetMetric()) == 0 {
+		return time.Time{}, fmt.Errorf("has no %s metric, its identity may be disabled", proxyCertExpiryMetric)
+	}
+	seconds := family.GetMetric()[0].GetGauge().GetValue()
+	if seconds == 0 {
+		return time.Time{}, fmt.Errorf("hasn't been issued a certificate yet")
+	}
+	return time.Unix(int64(seconds), 0), nil
+}
+
+// formatExpiryWindow formats windows of whole days, hours or minutes in
+// those units, e.g. "60 days", and other ones as durations
+func formatExpiryWindow(window time.Duration) string {
+	units := []struct {
+		name     string
+		duration time.Duration
+	}{
+		{"day", 24 * time.Hour},
+		{"hour", time.Hour},
+		{"minute", time.Minute},
+	}
+	for _, unit := range units {
+		if window < unit.duration || window%unit.duration != 0 {
+			continue
+		}
+		n := int64(window / unit.duration)
+		if n == 1 {
+			return fmt.Sprintf("1 %s", unit.name)
+		}
+		return fmt.Sprintf("%d %ss", n, unit.name)
+	}
+	return window.String()
+}
@@ -0,0 +1,195 @@
+package healthcheck
+
+import (
+	"crypto/x509"
+	"testing"
+	"time"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/linkerd/linkerd2/pkg/tls"
+)
+
+func TestParseProxyCertExpiry(t *testing.T) {
+	metrics := `# HELP identity_cert_expiration_timestamp_seconds Time when the this proxy's current mTLS identity certificate will expire (in seconds since the UNIX epoch).
+# TYPE identity_cert_expiration_timestamp_seconds gauge
+identity_cert_expiration_timestamp_seconds 1600000000
+# HELP request_total Total count of HTTP requests.
+# TYPE request_total counter
+request_total{direction="inbound"} 3
+`
+	expiry, err := parseProxyCertExpiry([]byte(metrics))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if !expiry.Equal(time.Unix(1600000000, 0)) {
+		t.Fatalf("Expected expiry %s, got %s", time.Unix(1600000000, 0), expiry)
+	}
+
+	if _, err := parseProxyCertExpiry([]byte("request_total 3\n")); err == nil {
+		t.Fatalf("Expected an error parsing metrics without the certificate expiry")
+	}
+}
+
+func TestFormatExpiryWindow(t *testing.T) {
+	testCases := map[time.Duration]string{
+		60 * 24 * time.Hour: "60 days",
+		24 * time.Hour:      "1 day",
+		36 * time.Hour:      "36 hours",
+		time.Hour:           "1 hour",
+		90 * time.Minute:    "90 minutes",
+		90 * time.Second:    "1m30s",
+	}
+	for window, expected := range testCases {
+		if actual := formatExpiryWindow(window); actual != expected {
+			t.Errorf("Expected %s to be formatted as %q, got %q", window, expected, actual)
+		}
+	}
+}
+
+func TestCheckCertValidity(t *testing.T) {
+	hc := NewHealthChecker([]CategoryID{}, &Options{
+		CertExpiryWarning: 30 * 24 * time.Hour,
+		CertExpiryError:   7 * 24 * time.Hour,
+	})
+
+	testCases := []struct {
+		notAfter time.Time
+		valid    bool
+	}{
+		{time.Now().Add(60 * 24 * time.Hour), true},
+		// expiring soon is only reported by the warnings
+		{time.Now().Add(20 * 24 * time.Hour), true},
+		{time.Now().Add(3 * 24 * time.Hour), false},
+		{time.Now().Add(-time.Hour), false},
+	}
+	for _, tc := range testCases {
+		cert := &x509.Certificate{NotBefore: time.Now().Add(-24 * time.Hour), NotAfter: tc.notAfter}
+		if err := hc.checkCertValidity(cert); (err == nil) != tc.valid {
+			t.Errorf("Expected a certificate expiring on %s to be valid: %t, got %v", tc.notAfter, tc.valid, err)
+		}
+	}
+
+	// the expiring soon checks are warnings, described with the warning window
+	expected := map[string]string{
+		"l5d-identity-trustAnchors-not-expiring-soon": "trust anchors are valid for at least 30 days",
+		"l5d-identity-issuer-cert-not-expiring-soon":  "issuer cert is valid for at least 30 days",
+	}
+	for _, c := range NewHealthChecker([]CategoryID{}, &Options{CertExpiryWarning: 30 * 24 * time.Hour}).categories {
+		for _, checker := range c.checkers {
+			description, ok := expected[checker.hintAnchor]
+			if !ok {
+				continue
+			}
+			if checker.description != description {
+				t.Errorf("Expected description %q, got %q", description, checker.description)
+			}
+			if !checker.warning {
+				t.Errorf("Expected the %q check to be a warning", checker.description)
+			}
+		}
+	}
+}
+
+func TestProxyCertExpiryWindow(t *testing.T) {
+	hc := NewHealthChecker([]CategoryID{}, &Options{})
+
+	testCases := []struct {
+		validity tls.Validity
+		window   time.Duration
+	}{
+		{tls.Validity{Lifetime: 24 * time.Hour}, time.Hour},
+		{tls.Validity{Lifetime: 2 * time.Hour}, 30 * time.Minute},
+		{tls.Validity{Lifetime: 2 * time.Hour, RenewalJitter: time.Hour}, 15 * time.Minute},
+	}
+	for _, tc := range testCases {
+		if window := hc.proxyCertExpiryWindow(tc.validity); window != tc.window {
+			t.Errorf("Expected a %s window for certificates valid for %+v, got %s", tc.window, tc.validity, window)
+		}
+	}
+}
+
+func TestCheckProxyCertLifetimeAnnotations(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		resources []string
+		err       string
+	}{
+		{
+			desc: "valid annotations",
+			resources: []string{`
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: emojivoto
+  annotations:
+    config.linkerd.io/proxy-cert-lifetime: 12h
+`, `
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: web
+  namespace: emojivoto
+  annotations:
+    config.linkerd.io/proxy-cert-renewal-jitter: 1h
+`},
+		},
+		{
+			desc: "invalid namespace annotation",
+			resources: []string{`
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: emojivoto
+  annotations:
+    config.linkerd.io/proxy-cert-lifetime: 1h
+`, `
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: web
+  namespace: emojivoto
+  annotations:
+    config.linkerd.io/proxy-cert-renewal-jitter: 2h
+`},
+			err: "Some certificate lifetime annotations are invalid, the control plane defaults are used instead:\n\t* invalid certificate lifetime for emojivoto/web: renewal jitter 2h0m0s must be shorter than the lifetime 1h0m0s",
+		},
+		{
+			desc: "lifetime longer than the issuance lifetime",
+			resources: []string{`
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: emojivoto
+`, `
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: web
+  namespace: emojivoto
+  annotations:
+    config.linkerd.io/proxy-cert-lifetime: 8760h
+`},
+			err: "Some certificate lifetime annotations are invalid, the control plane defaults are used instead:\n\t* invalid certificate lifetime for emojivoto/web: 8760h0m0s exceeds the issuance lifetime 24h0m0s",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.desc, func(t *testing.T) {
+			hc := NewHealthChecker([]CategoryID{}, &Options{})
+			var err error
+			hc.kubeAPI, err = k8s.NewFakeAPI(tc.resources...)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+
+			err = hc.checkProxyCertLifetimeAnnotations()
+			if tc.err == "" && err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if tc.err != "" && (err == nil || err.Error() != tc.err) {
+				t.Fatalf("Expected error %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,209 @@
+package healthcheck
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	admissionRegistration "k8s.io/api/admissionregistration/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// LinkerdPreInstallConflictsChecks adds checks to detect the service
+	// meshes and sidecar injectors already installed in the cluster, whose
+	// webhooks, sidecars and iptables rules would conflict with Linkerd's.
+	// These checks are dependent on the output of KubernetesAPIChecks, so
+	// those checks must be added first.
+	LinkerdPreInstallConflictsChecks CategoryID = "pre-mesh-conflicts"
+)
+
+// meshSignature holds the resources revealing that a service mesh is
+// installed
+type meshSignature struct {
+	name string
+	// webhooks are substrings of the names of the mesh's mutating webhooks
+	webhooks []string
+	// sidecars are the names of the containers the mesh injects, and
+	// initContainers the names of the init containers setting up its iptables
+	// rules
+	sidecars       []string
+	initContainers []string
+	// namespaceLabels are labels enabling the mesh's injection in a
+	// namespace, and the values enabling it (any value if empty)
+	namespaceLabels map[string]string
+}
+
+var conflictingMeshes = []meshSignature{
+	{
+		name:            "Istio",
+		webhooks:        []string{"sidecar-injector.istio.io"},
+		sidecars:        []string{"istio-proxy"},
+		initContainers:  []string{"istio-init", "istio-validation"},
+		namespaceLabels: map[string]string{"istio-injection": "enabled", "istio.io/rev": ""},
+	},
+	{
+		name:           "Consul",
+		webhooks:       []string{"consul-connect-injector"},
+		sidecars:       []string{"envoy-sidecar", "consul-dataplane"},
+		initContainers: []string{"consul-connect-inject-init"},
+	},
+	{
+		name:            "Open Service Mesh",
+		webhooks:        []string{"osm-inject.k8s.io"},
+		initContainers:  []string{"osm-init"},
+		namespaceLabels: map[string]string{"openservicemesh.io/monitored-by": ""},
+	},
+	{
+		name:            "Kuma",
+		webhooks:        []string{"kuma-admission.kuma.io", "kuma-injector"},
+		sidecars:        []string{"kuma-sidecar"},
+		initContainers:  []string{"kuma-init"},
+		namespaceLabels: map[string]string{"kuma.io/sidecar-injection": "enabled"},
+	},
+	{
+		name:            "AWS App Mesh",
+		webhooks:        []string{"appmesh.k8s.aws", "aws-app-mesh-inject"},
+		initContainers:  []string{"proxyinit"},
+		namespaceLabels: map[string]string{"appmesh.k8s.aws/sidecarInjectorWebhook": "enabled"},
+	},
+}
+
+func (hc *HealthChecker) conflictsCategories() []category {
+	return []category{
+		{
+			id: LinkerdPreInstallConflictsChecks,
+			checkers: []checker{
+				{
+					description: "no other service mesh webhook is installed",
+					hintAnchor:  "pre-mesh-conflicts",
+					warning:     true,
+					check: func(context.Context) error {
+						mwcs, err := hc.kubeAPI.AdmissionregistrationV1beta1().MutatingWebhookConfigurations().List(metav1.ListOptions{})
+						if err != nil {
+							return err
+						}
+						return checkConflictingWebhooks(mwcs.Items)
+					},
+				},
+				{
+					description: "no namespace enables another service mesh's injection",
+					hintAnchor:  "pre-mesh-conflicts",
+					warning:     true,
+					check: func(context.Context) error {
+						namespaces, err := hc.kubeAPI.CoreV1().Namespaces().List(metav1.ListOptions{})
+						if err != nil {
+							return err
+						}
+						return checkConflictingNamespaces(namespaces.Items)
+					},
+				},
+				{
+					description: "no pod runs another service mesh's sidecar or iptables rules",
+					hintAnchor:  "pre-mesh-conflicts",
+					warning:     true,
+					check: func(context.Context) error {
+						pods, err := hc.kubeAPI.CoreV1().Pods("").List(metav1.ListOptions{})
+						if err != nil {
+							return err
+						}
+						return checkConflictingPods(pods.Items)
+					},
+				},
+			},
+		},
+	}
+}
+
+func checkConflictingWebhooks(mwcs []admissionRegistration.MutatingWebhookConfiguration) error {
+	var errs []string
+	for _, mwc := range mwcs {
+		if _, ok := mwc.Labels[k8s.ControllerNSLabel]; ok {
+			continue
+		}
+		for _, webhook := range mwc.Webhooks {
+			if mesh := meshOfWebhook(mwc.Name, webhook.Name); mesh != "" {
+				errs = append(errs, fmt.Sprintf("* %s: %s webhook %s", mesh, mwc.Name, webhook.Name))
+			}
+		}
+	}
+	if len(errs) > 0 {
+		sort.Strings(errs)
+		return fmt.Errorf("Some mutating webhooks of other service meshes are installed, and will inject their own sidecars alongside the Linkerd proxy:\n\t%s", strings.Join(errs, "\n\t"))
+	}
+	return nil
+}
+
+func meshOfWebhook(configName, webhookName string) string {
+	for _, mesh := range conflictingMeshes {
+		for _, w := range mesh.webhooks {
+			if strings.Contains(webhookName, w) || strings.Contains(configName, w) {
+				return mesh.name
+			}
+		}
+	}
+	return ""
+}
+
+func checkConflictingNamespaces(namespaces []corev1.Namespace) error {
+	var errs []string
+	for _, ns := range namespaces {
+		for _, mesh := range conflictingMeshes {
+			for label, enabled := range mesh.namespaceLabels {
+				if value, ok := ns.Labels[label]; ok && (enabled == "" || value == enabled) {
+					errs = append(errs, fmt.Sprintf("* %s: %s (label %s=%s)", ns.Name, mesh.name, label, value))
+				}
+			}
+		}
+	}
+	if len(errs) > 0 {
+		sort.Strings(errs)
+		return fmt.Errorf("Some namespaces enable the injection of other service meshes, whose pods shouldn't also be injected by Linkerd:\n\t%s", strings.Join(errs, "\n\t"))
+	}
+	return nil
+}
+
+func checkConflictingPods(pods []corev1.Pod) error {
+	// the number of pods of each mesh, and the name of the first one
+	count := map[string]int{}
+	example := map[string]string{}
+	for _, pod := range pods {
+		mesh := meshOfPod(pod.Spec)
+		if mesh == "" {
+			continue
+		}
+		if count[mesh] == 0 {
+			example[mesh] = fmt.Sprintf("%s/%s", pod.Namespace, pod.Name)
+		}
+		count[mesh]++
+	}
+
+	var errs []string
+	for mesh, n := range count {
+		errs = append(errs, fmt.Sprintf("* %s: %d pods, e.g. %s", mesh, n, example[mesh]))
+	}
+	if len(errs) > 0 {
+		sort.Strings(errs)
+		return fmt.Errorf("Some pods run the sidecars or iptables rules of other service meshes, which intercept the traffic before the Linkerd proxy:\n\t%s", strings.Join(errs, "\n\t"))
+	}
+	return nil
+}
+
+func meshOfPod(spec corev1.PodSpec) string {
+	for _, mesh := range conflictingMeshes {
+		for _, c := range spec.Containers {
+			if containsString(mesh.sidecars, c.Name) {
+				return mesh.name
+			}
+		}
+		for _, c := range spec.InitContainers {
+			if containsString(mesh.initContainers, c.Name) {
+				return mesh.name
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,96 @@
+package healthcheck
+
+import (
+	"testing"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	admissionRegistration "k8s.io/api/admissionregistration/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCheckConflictingWebhooks(t *testing.T) {
+	mwcs := []admissionRegistration.MutatingWebhookConfiguration{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "linkerd-proxy-injector-webhook-config", Labels: map[string]string{k8s.ControllerNSLabel: "linkerd"}},
+			Webhooks:   []admissionRegistration.MutatingWebhook{{Name: "linkerd-proxy-injector.linkerd.io"}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "cert-manager-webhook"},
+			Webhooks:   []admissionRegistration.MutatingWebhook{{Name: "webhook.cert-manager.io"}},
+		},
+	}
+	if err := checkConflictingWebhooks(mwcs); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	mwcs = append(mwcs,
+		admissionRegistration.MutatingWebhookConfiguration{
+			ObjectMeta: metav1.ObjectMeta{Name: "istio-sidecar-injector"},
+			Webhooks:   []admissionRegistration.MutatingWebhook{{Name: "sidecar-injector.istio.io"}},
+		},
+		admissionRegistration.MutatingWebhookConfiguration{
+			ObjectMeta: metav1.ObjectMeta{Name: "osm-webhook-osm"},
+			Webhooks:   []admissionRegistration.MutatingWebhook{{Name: "osm-inject.k8s.io"}},
+		},
+	)
+	expected := `Some mutating webhooks of other service meshes are installed, and will inject their own sidecars alongside the Linkerd proxy:
+	* Istio: istio-sidecar-injector webhook sidecar-injector.istio.io
+	* Open Service Mesh: osm-webhook-osm webhook osm-inject.k8s.io`
+	if err := checkConflictingWebhooks(mwcs); err == nil || err.Error() != expected {
+		t.Fatalf("Expected error:\n%s\ngot:\n%v", expected, err)
+	}
+}
+
+func TestCheckConflictingNamespaces(t *testing.T) {
+	namespaces := []corev1.Namespace{
+		{ObjectMeta: metav1.ObjectMeta{Name: "emojivoto"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "legacy", Labels: map[string]string{"istio-injection": "disabled"}}},
+	}
+	if err := checkConflictingNamespaces(namespaces); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	namespaces = append(namespaces,
+		corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "books", Labels: map[string]string{"istio.io/rev": "1-8"}}},
+		corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "shop", Labels: map[string]string{"kuma.io/sidecar-injection": "enabled"}}},
+	)
+	expected := `Some namespaces enable the injection of other service meshes, whose pods shouldn't also be injected by Linkerd:
+	* books: Istio (label istio.io/rev=1-8)
+	* shop: Kuma (label kuma.io/sidecar-injection=enabled)`
+	if err := checkConflictingNamespaces(namespaces); err == nil || err.Error() != expected {
+		t.Fatalf("Expected error:\n%s\ngot:\n%v", expected, err)
+	}
+}
+
+func TestCheckConflictingPods(t *testing.T) {
+	pod := func(ns, name string, initContainers, containers []string) corev1.Pod {
+		p := corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: name}}
+		for _, c := range initContainers {
+			p.Spec.InitContainers = append(p.Spec.InitContainers, corev1.Container{Name: c})
+		}
+		for _, c := range containers {
+			p.Spec.Containers = append(p.Spec.Containers, corev1.Container{Name: c})
+		}
+		return p
+	}
+
+	pods := []corev1.Pod{
+		pod("emojivoto", "web", []string{k8s.InitContainerName}, []string{"web", k8s.ProxyContainerName}),
+	}
+	if err := checkConflictingPods(pods); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	pods = append(pods,
+		pod("books", "authors", []string{"istio-init"}, []string{"authors", "istio-proxy"}),
+		pod("books", "webapp", []string{"istio-init"}, []string{"webapp", "istio-proxy"}),
+		pod("shop", "cart", []string{"consul-connect-inject-init"}, []string{"cart"}),
+	)
+	expected := `Some pods run the sidecars or iptables rules of other service meshes, which intercept the traffic before the Linkerd proxy:
+	* Consul: 1 pods, e.g. shop/cart
+	* Istio: 2 pods, e.g. books/authors`
+	if err := checkConflictingPods(pods); err == nil || err.Error() != expected {
+		t.Fatalf("Expected error:\n%s\ngot:\n%v", expected, err)
+	}
+}
@@ -0,0 +1,123 @@
+package healthcheck
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+)
+
+// Checker is a check contributed by an extension, see RegisterCategory
+type Checker struct {
+	// Description is the short description that's printed to the command line
+	// when the check is executed
+	Description string
+
+	// HintAnchor, when appended to `HintBaseURL`, provides a URL to more
+	// information about the check
+	HintAnchor string
+
+	// Fatal indicates that all remaining checks should be aborted if this check
+	// fails
+	Fatal bool
+
+	// Warning indicates that if this check fails, it should be reported, but it
+	// should not impact the overall outcome of the health check
+	Warning bool
+
+	// Check is the function that's called to execute the check; if it returns
+	// an error, the check fails. It's given the HealthChecker running it, to
+	// access the clients set up by the categories run before it.
+	Check func(context.Context, *HealthChecker) error
+}
+
+var (
+	extensionsMu         sync.Mutex
+	extensionCategories  []CategoryID
+	extensionCheckersMap = map[CategoryID][]Checker{}
+)
+
+// RegisterCategory makes the checkers of an extension available to the
+// HealthCheckers created afterwards, under the categoryID category. As with
+// the built-in categories, it's only run if categoryID is passed to
+// NewHealthChecker, after the built-in categories. Registering a category
+// again replaces its checkers, and the IDs of the built-in categories can't be
+// registered.
+func RegisterCategory(categoryID CategoryID, checkers []Checker) error {
+	if isBuiltinCategory(categoryID) {
+		return fmt.Errorf("category %s is a built-in category", categoryID)
+	}
+
+	extensionsMu.Lock()
+	defer extensionsMu.Unlock()
+
+	if _, ok := extensionCheckersMap[categoryID]; !ok {
+		extensionCategories = append(extensionCategories, categoryID)
+	}
+	extensionCheckersMap[categoryID] = checkers
+	return nil
+}
+
+func isBuiltinCategory(categoryID CategoryID) bool {
+	hc := &HealthChecker{Options: &Options{}}
+	for _, c := range hc.builtinCategories() {
+		if c.id == categoryID {
+			return true
+		}
+	}
+	return false
+}
+
+// IsKnownCategory returns whether categoryID is the ID of a built-in category
+// or of one registered with RegisterCategory
+func IsKnownCategory(categoryID CategoryID) bool {
+	if isBuiltinCategory(categoryID) {
+		return true
+	}
+
+	extensionsMu.Lock()
+	defer extensionsMu.Unlock()
+
+	_, ok := extensionCheckersMap[categoryID]
+	return ok
+}
+
+// RegisteredCategories returns the IDs of the categories registered with
+// RegisterCategory, in registration order
+func RegisteredCategories() []CategoryID {
+	extensionsMu.Lock()
+	defer extensionsMu.Unlock()
+
+	return append([]CategoryID{}, extensionCategories...)
+}
+
+func (hc *HealthChecker) extensionCategories() []category {
+	extensionsMu.Lock()
+	defer extensionsMu.Unlock()
+
+	categories := make([]category, 0, len(extensionCategories))
+	for _, id := range extensionCategories {
+		c := category{id: id}
+		for _, ext := range extensionCheckersMap[id] {
+			ext := ext // pin
+			c.checkers = append(c.checkers, checker{
+				description: ext.Description,
+				hintAnchor:  ext.HintAnchor,
+				fatal:       ext.Fatal,
+				warning:     ext.Warning,
+				check: func(ctx context.Context) error {
+					return ext.Check(ctx, hc)
+				},
+			})
+		}
+		categories = append(categories, c)
+	}
+	return categories
+}
+
+// KubeAPIClient returns the Kubernetes client set up by the
+// KubernetesAPIChecks category, or nil if it hasn't run
+func (hc *HealthChecker) KubeAPIClient() *k8s.KubernetesAPI {
+	return hc.kubeAPI
+}
@@ -0,0 +1,132 @@
+package healthcheck
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/linkerd/linkerd2/pkg/tls"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// LinkerdFIPSChecks adds checks to validate that a control plane installed
+	// with `--crypto-policy fips`, and its data plane, comply with it. These
+	// checks are dependent on the output of KubernetesAPIChecks,
+	// LinkerdConfigChecks and LinkerdIdentity, so those checks must be added
+	// first.
+	LinkerdFIPSChecks CategoryID = "linkerd-fips"
+
+	fipsImageSuffix = "-fips"
+
+	linkerdNonFIPSSkipReason = "not run for non FIPS installs"
+)
+
+func (hc *HealthChecker) fipsCategories() []category {
+	return []category{
+		{
+			id: LinkerdFIPSChecks,
+			checkers: []checker{
+				{
+					description: "identity issuer and trust anchors have FIPS-approved keys",
+					hintAnchor:  "l5d-fips-identity-keys",
+					check: func(context.Context) error {
+						if !hc.isFIPS() {
+							return &SkipError{Reason: linkerdNonFIPSSkipReason}
+						}
+						return hc.checkIdentityCryptoPolicy()
+					},
+				},
+				{
+					description: "control plane components run the FIPS controller image",
+					hintAnchor:  "l5d-fips-images",
+					check: func(context.Context) error {
+						if !hc.isFIPS() {
+							return &SkipError{Reason: linkerdNonFIPSSkipReason}
+						}
+						return hc.checkFIPSImages(hc.ControlPlaneNamespace, isControllerContainer)
+					},
+				},
+				{
+					description: "data plane proxies run the FIPS proxy image",
+					hintAnchor:  "l5d-fips-images",
+					check: func(context.Context) error {
+						if !hc.isFIPS() {
+							return &SkipError{Reason: linkerdNonFIPSSkipReason}
+						}
+						return hc.checkFIPSImages(hc.DataPlaneNamespace, isProxyContainer)
+					},
+				},
+			},
+		},
+	}
+}
+
+func (hc *HealthChecker) isFIPS() bool {
+	for _, flag := range hc.linkerdConfig.GetInstall().GetFlags() {
+		if flag.GetName() == "crypto-policy" && flag.GetValue() == tls.CryptoPolicyFIPS {
+			return true
+		}
+	}
+	return false
+}
+
+func (hc *HealthChecker) checkIdentityCryptoPolicy() error {
+	if hc.issuerCert == nil {
+		return fmt.Errorf("the identity issuer certificate couldn't be read")
+	}
+
+	for _, crt := range append(hc.trustAnchors, hc.issuerCert.Certificate) {
+		if err := tls.CheckCryptoPolicy(crt, tls.CryptoPolicyFIPS); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkFIPSImages validates that the containers selected by match, in the
+// meshed pods of the given namespace (or all namespaces if empty), run a FIPS
+// image
+func (hc *HealthChecker) checkFIPSImages(namespace string, match func(name, image string) bool) error {
+	pods, err := hc.kubeAPI.CoreV1().Pods(namespace).List(metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", k8s.ControllerNSLabel, hc.ControlPlaneNamespace),
+	})
+	if err != nil {
+		return err
+	}
+
+	var errs []string
+	for _, pod := range pods.Items {
+		for _, container := range pod.Spec.Containers {
+			if match(container.Name, container.Image) && !strings.HasSuffix(imageRepository(container.Image), fipsImageSuffix) {
+				errs = append(errs, fmt.Sprintf("\t* %s/%s (%s): %s", pod.Namespace, pod.Name, container.Name, container.Image))
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("Some containers don't run a FIPS image:\n%s", strings.Join(errs, "\n"))
+	}
+
+	return nil
+}
+
+func isProxyContainer(name, _ string) bool {
+	return name == k8s.ProxyContainerName
+}
+
+func isControllerContainer(_, image string) bool {
+	return strings.HasPrefix(path.Base(imageRepository(image)), "controller")
+}
+
+// imageRepository strips the tag and digest from an image reference
+func imageRepository(image string) string {
+	if i := strings.Index(image, "@"); i >= 0 {
+		image = image[:i]
+	}
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		image = image[:i]
+	}
+	return image
+}
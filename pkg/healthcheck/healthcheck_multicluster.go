@@ -380,25 +380,8 @@ func (hc *HealthChecker) checkRemoteClusterAnchors() error {
 			continue
 		}
 
-		// we fail early if the lens are not the same. If they are the
-		// same, we can only compare certs one way and be sure we have
-		// identical anchors
-		if len(remoteAnchors) != len(localAnchors) {
+		if !sameAnchors(localAnchors, remoteAnchors) {
 			errors = append(errors, fmt.Sprintf("* %s", link.TargetClusterName))
-			continue
-		}
-
-		localAnchorsMap := make(map[string]*x509.Certificate)
-		for _, c := range localAnchors {
-			localAnchorsMap[string(c.Signature)] = c
-		}
-
-		for _, remote := range remoteAnchors {
-			local, ok := localAnchorsMap[string(remote.Signature)]
-			if !ok || !local.Equal(remote) {
-				errors = append(errors, fmt.Sprintf("* %s", link.TargetClusterName))
-				break
-			}
 		}
 		links = append(links, fmt.Sprintf("\t* %s", link.TargetClusterName))
 	}
@@ -414,6 +397,28 @@ func (hc *HealthChecker) checkRemoteClusterAnchors() error {
 	return &VerboseSuccess{Message: strings.Join(links, "\n")}
 }
 
+// sameAnchors returns whether two sets of trust anchors are identical
+func sameAnchors(local, remote []*x509.Certificate) bool {
+	// we fail early if the lens are not the same. If they are the same, we
+	// can only compare certs one way and be sure we have identical anchors
+	if len(remote) != len(local) {
+		return false
+	}
+
+	localAnchorsMap := make(map[string]*x509.Certificate)
+	for _, c := range local {
+		localAnchorsMap[string(c.Signature)] = c
+	}
+
+	for _, r := range remote {
+		l, ok := localAnchorsMap[string(r.Signature)]
+		if !ok || !l.Equal(r) {
+			return false
+		}
+	}
+	return true
+}
+
 /* Gateway mirror checks */
 
 func (hc *HealthChecker) checkIfGatewayMirrorsHaveEndpoints(ctx context.Context) error {
@@ -0,0 +1,250 @@
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/linkerd/linkerd2/pkg/multicluster"
+	"github.com/linkerd/linkerd2/pkg/tls"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// LinkerdMulticlusterPreLinkChecks adds checks to validate that the
+	// cluster can be linked from the SourceKubeContext cluster with `linkerd
+	// multicluster link`: its gateway is exposed by a provisioned load
+	// balancer, reachable from a probe pod run in the source cluster, and both
+	// clusters share their trust anchors. These checks are dependent on the
+	// output of KubernetesAPIChecks, so those checks must be added first.
+	LinkerdMulticlusterPreLinkChecks CategoryID = "pre-linkerd-multicluster-link"
+
+	// DefaultGatewayName and DefaultGatewayNamespace locate the gateway
+	// installed by `linkerd multicluster install`
+	DefaultGatewayName      = "linkerd-gateway"
+	DefaultGatewayNamespace = "linkerd-multicluster"
+)
+
+func (hc *HealthChecker) multiClusterPreLinkCategories() []category {
+	return []category{
+		{
+			id: LinkerdMulticlusterPreLinkChecks,
+			checkers: []checker{
+				{
+					description: "gateway service is valid",
+					hintAnchor:  "pre-l5d-multicluster-gateway",
+					fatal:       true,
+					check: func(context.Context) error {
+						gateway, err := hc.kubeAPI.CoreV1().Services(hc.gatewayNamespace()).Get(hc.gatewayName(), metav1.GetOptions{})
+						if err != nil {
+							return err
+						}
+						if err := checkGatewayService(gateway); err != nil {
+							return err
+						}
+						hc.gateway = gateway
+						return nil
+					},
+				},
+				{
+					description:   "gateway load balancer is provisioned",
+					hintAnchor:    "pre-l5d-multicluster-gateway-lb",
+					fatal:         true,
+					retryDeadline: hc.RetryDeadline,
+					check: func(context.Context) error {
+						gateway, err := hc.kubeAPI.CoreV1().Services(hc.gatewayNamespace()).Get(hc.gatewayName(), metav1.GetOptions{})
+						if err != nil {
+							return err
+						}
+						if _, err := gatewayAddresses(gateway); err != nil {
+							return err
+						}
+						hc.gateway = gateway
+						return nil
+					},
+				},
+				{
+					description: "can access the source cluster",
+					hintAnchor:  "pre-l5d-multicluster-source",
+					fatal:       true,
+					check: func(context.Context) error {
+						if hc.SourceKubeContext == "" {
+							return &SkipError{Reason: "no source cluster context given"}
+						}
+						api, err := k8s.NewAPI(hc.KubeConfig, hc.SourceKubeContext, hc.Impersonate, hc.ImpersonateGroup, requestTimeout)
+						if err != nil {
+							return err
+						}
+						if _, err := api.Discovery().ServerVersion(); err != nil {
+							return err
+						}
+						hc.sourceAPI = api
+						return nil
+					},
+				},
+				{
+					description: "clusters share trust anchors",
+					hintAnchor:  "pre-l5d-multicluster-anchors",
+					check: func(context.Context) error {
+						if hc.sourceAPI == nil {
+							return &SkipError{Reason: "no source cluster context given"}
+						}
+						return hc.checkSourceClusterAnchors()
+					},
+				},
+				{
+					description: "gateway is reachable from the source cluster",
+					hintAnchor:  "pre-l5d-multicluster-gateway-reachable",
+					check: func(ctx context.Context) error {
+						if hc.sourceAPI == nil {
+							return &SkipError{Reason: "no source cluster context given"}
+						}
+						return hc.checkGatewayReachable(ctx)
+					},
+				},
+			},
+		},
+	}
+}
+
+func (hc *HealthChecker) gatewayName() string {
+	if hc.GatewayName != "" {
+		return hc.GatewayName
+	}
+	return DefaultGatewayName
+}
+
+func (hc *HealthChecker) gatewayNamespace() string {
+	if hc.GatewayNamespace != "" {
+		return hc.GatewayNamespace
+	}
+	return DefaultGatewayNamespace
+}
+
+// checkGatewayService validates the fields of the gateway service read by
+// `linkerd multicluster link`
+func checkGatewayService(gateway *corev1.Service) error {
+	var errs []string
+	if gateway.Spec.Type != corev1.ServiceTypeLoadBalancer {
+		errs = append(errs, fmt.Sprintf("* the service type is %s, but the source cluster can only reach a gateway of type %s", gateway.Spec.Type, corev1.ServiceTypeLoadBalancer))
+	}
+	if gatewayPort(gateway, k8s.GatewayPortName) == 0 {
+		errs = append(errs, fmt.Sprintf("* no port is named %s", k8s.GatewayPortName))
+	}
+	if _, err := multicluster.ExtractProbeSpec(gateway); err != nil {
+		errs = append(errs, fmt.Sprintf("* invalid probe: %s", err))
+	}
+	if gateway.Annotations[k8s.GatewayIdentity] == "" {
+		errs = append(errs, fmt.Sprintf("* no %s annotation", k8s.GatewayIdentity))
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("The gateway service %s/%s can't be linked:\n\t%s", gateway.Namespace, gateway.Name, strings.Join(errs, "\n\t"))
+	}
+	return nil
+}
+
+func gatewayPort(gateway *corev1.Service, name string) int32 {
+	for _, p := range gateway.Spec.Ports {
+		if p.Name == name {
+			return p.Port
+		}
+	}
+	return 0
+}
+
+// gatewayAddresses returns the IP addresses of the gateway's load balancer,
+// which are the only ones `linkerd multicluster link` uses
+func gatewayAddresses(gateway *corev1.Service) ([]string, error) {
+	var ips, hostnames []string
+	for _, ingress := range gateway.Status.LoadBalancer.Ingress {
+		if ingress.IP != "" {
+			ips = append(ips, ingress.IP)
+		} else if ingress.Hostname != "" {
+			hostnames = append(hostnames, ingress.Hostname)
+		}
+	}
+	if len(ips) > 0 {
+		return ips, nil
+	}
+	if len(hostnames) > 0 {
+		return nil, fmt.Errorf("the load balancer of the gateway %s/%s only has the hostnames %s, but links require IP addresses", gateway.Namespace, gateway.Name, strings.Join(hostnames, ", "))
+	}
+	return nil, fmt.Errorf("the load balancer of the gateway %s/%s has no ingress address yet", gateway.Namespace, gateway.Name)
+}
+
+func (hc *HealthChecker) checkSourceClusterAnchors() error {
+	_, targetConfig, err := FetchLinkerdConfigMap(hc.kubeAPI, hc.ControlPlaneNamespace)
+	if err != nil {
+		return fmt.Errorf("unable to fetch the trust anchors of this cluster: %s", err)
+	}
+	_, sourceConfig, err := FetchLinkerdConfigMap(hc.sourceAPI, hc.ControlPlaneNamespace)
+	if err != nil {
+		return fmt.Errorf("unable to fetch the trust anchors of the source cluster: %s", err)
+	}
+
+	targetAnchors, err := tls.DecodePEMCertificates(targetConfig.Global.IdentityContext.TrustAnchorsPem)
+	if err != nil {
+		return fmt.Errorf("cannot parse the trust anchors of this cluster: %s", err)
+	}
+	sourceAnchors, err := tls.DecodePEMCertificates(sourceConfig.Global.IdentityContext.TrustAnchorsPem)
+	if err != nil {
+		return fmt.Errorf("cannot parse the trust anchors of the source cluster: %s", err)
+	}
+	if !sameAnchors(sourceAnchors, targetAnchors) {
+		return errors.New("the source cluster doesn't have the same trust anchors as this cluster, so it won't trust the gateway's identity")
+	}
+	return nil
+}
+
+// checkGatewayReachable runs a probe pod in the source cluster, connecting to
+// the gateway and probe ports of each address of the gateway
+func (hc *HealthChecker) checkGatewayReachable(ctx context.Context) error {
+	addresses, err := gatewayAddresses(hc.gateway)
+	if err != nil {
+		return err
+	}
+	targets := gatewayProbeTargets(hc.gateway, addresses)
+
+	var script []string
+	for _, t := range targets {
+		script = append(script, fmt.Sprintf("if nc -z -w 5 %s; then echo '%s ok'; else echo '%s failed'; fi",
+			strings.Replace(t, ":", " ", 1), t, t))
+	}
+	results, err := runProbePod(ctx, hc.sourceAPI, hc.probePod(script))
+	if err != nil {
+		return err
+	}
+	return checkGatewayProbeResults(targets, results)
+}
+
+// gatewayProbeTargets returns the <address>:<port> pairs of the gateway and
+// probe ports of the gateway
+func gatewayProbeTargets(gateway *corev1.Service, addresses []string) []string {
+	var targets []string
+	for _, address := range addresses {
+		for _, name := range []string{k8s.GatewayPortName, k8s.ProbePortName} {
+			if port := gatewayPort(gateway, name); port != 0 {
+				targets = append(targets, fmt.Sprintf("%s:%d", address, port))
+			}
+		}
+	}
+	return targets
+}
+
+func checkGatewayProbeResults(targets []string, results map[string]bool) error {
+	var unreachable []string
+	for _, t := range targets {
+		if !results[t] {
+			unreachable = append(unreachable, fmt.Sprintf("* %s", t))
+		}
+	}
+	if len(unreachable) > 0 {
+		sort.Strings(unreachable)
+		return fmt.Errorf("The gateway can't be reached from the source cluster, check the firewall rules and the load balancer's source ranges:\n\t%s", strings.Join(unreachable, "\n\t"))
+	}
+	return nil
+}
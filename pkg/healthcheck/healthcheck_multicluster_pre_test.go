@@ -0,0 +1,108 @@
+package healthcheck
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func gatewayService(svcType corev1.ServiceType, ingress ...corev1.LoadBalancerIngress) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "linkerd-gateway",
+			Namespace: "linkerd-multicluster",
+			Annotations: map[string]string{
+				k8s.GatewayIdentity:    "linkerd-gateway.linkerd-multicluster.serviceaccount.identity.linkerd.cluster.local",
+				k8s.GatewayProbePath:   "/health",
+				k8s.GatewayProbePeriod: "3",
+			},
+		},
+		Spec: corev1.ServiceSpec{
+			Type: svcType,
+			Ports: []corev1.ServicePort{
+				{Name: k8s.GatewayPortName, Port: 4143},
+				{Name: k8s.ProbePortName, Port: 4181},
+			},
+		},
+		Status: corev1.ServiceStatus{LoadBalancer: corev1.LoadBalancerStatus{Ingress: ingress}},
+	}
+}
+
+func TestCheckGatewayService(t *testing.T) {
+	if err := checkGatewayService(gatewayService(corev1.ServiceTypeLoadBalancer)); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	gateway := gatewayService(corev1.ServiceTypeClusterIP)
+	gateway.Spec.Ports = gateway.Spec.Ports[1:]
+	delete(gateway.Annotations, k8s.GatewayIdentity)
+	expected := `The gateway service linkerd-multicluster/linkerd-gateway can't be linked:
+	* the service type is ClusterIP, but the source cluster can only reach a gateway of type LoadBalancer
+	* no port is named mc-gateway
+	* no mirror.linkerd.io/gateway-identity annotation`
+	if err := checkGatewayService(gateway); err == nil || err.Error() != expected {
+		t.Fatalf("Expected error:\n%s\ngot:\n%v", expected, err)
+	}
+}
+
+func TestGatewayAddresses(t *testing.T) {
+	testCases := []struct {
+		name     string
+		ingress  []corev1.LoadBalancerIngress
+		expected []string
+		err      string
+	}{
+		{
+			name:    "pending",
+			ingress: nil,
+			err:     "the load balancer of the gateway linkerd-multicluster/linkerd-gateway has no ingress address yet",
+		},
+		{
+			name:    "hostnames only",
+			ingress: []corev1.LoadBalancerIngress{{Hostname: "gw.elb.amazonaws.com"}},
+			err:     "the load balancer of the gateway linkerd-multicluster/linkerd-gateway only has the hostnames gw.elb.amazonaws.com, but links require IP addresses",
+		},
+		{
+			name:     "IPs",
+			ingress:  []corev1.LoadBalancerIngress{{IP: "10.0.0.1"}, {Hostname: "gw.example.com"}, {IP: "10.0.0.2"}},
+			expected: []string{"10.0.0.1", "10.0.0.2"},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.name, func(t *testing.T) {
+			addresses, err := gatewayAddresses(gatewayService(corev1.ServiceTypeLoadBalancer, tc.ingress...))
+			if tc.err != "" {
+				if err == nil || err.Error() != tc.err {
+					t.Fatalf("Expected error %q, got %v", tc.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if !reflect.DeepEqual(addresses, tc.expected) {
+				t.Fatalf("Expected addresses %v, got %v", tc.expected, addresses)
+			}
+		})
+	}
+}
+
+func TestCheckGatewayProbeResults(t *testing.T) {
+	targets := gatewayProbeTargets(gatewayService(corev1.ServiceTypeLoadBalancer), []string{"10.0.0.1"})
+	expectedTargets := []string{"10.0.0.1:4143", "10.0.0.1:4181"}
+	if !reflect.DeepEqual(targets, expectedTargets) {
+		t.Fatalf("Expected targets %v, got %v", expectedTargets, targets)
+	}
+
+	results := parseNetworkProbeOutput("10.0.0.1:4143 failed\n10.0.0.1:4181 ok\n")
+	expected := `The gateway can't be reached from the source cluster, check the firewall rules and the load balancer's source ranges:
+	* 10.0.0.1:4143`
+	if err := checkGatewayProbeResults(targets, results); err == nil || err.Error() != expected {
+		t.Fatalf("Expected error:\n%s\ngot:\n%v", expected, err)
+	}
+}
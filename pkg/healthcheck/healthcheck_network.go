@@ -0,0 +1,376 @@
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	// LinkerdNetworkChecks adds checks to validate that the network lets the
+	// data plane and the Kubernetes API server reach the control plane, by
+	// running a probe pod in the DataPlaneNamespace namespace (default:
+	// "default"). These checks are dependent on the output of
+	// KubernetesAPIChecks, so those checks must be added first.
+	LinkerdNetworkChecks CategoryID = "linkerd-network"
+
+	// DefaultNetworkProbeImage is the image of the network probe pod, which
+	// needs a shell and nc
+	DefaultNetworkProbeImage = "busybox:1.32"
+
+	networkProbeName      = "linkerd-network-probe"
+	networkProbeLabel     = k8s.Prefix + "/network-probe"
+	networkProbeNamespace = "default"
+
+	// slowWebhookThreshold is the time the proxy injector webhook takes to
+	// respond above which it's reported as slow
+	slowWebhookThreshold = 5 * time.Second
+)
+
+var errNoNetworkProbeResults = errors.New("the network probe pod didn't report any result")
+
+// networkProbeTarget is a control plane port probed by the network probe pod
+type networkProbeTarget struct {
+	component string
+	service   string
+	port      int32
+	podPort   int32
+}
+
+var networkProbeTargets = []networkProbeTarget{
+	{"identity", "linkerd-identity", 8080, 8080},
+	{"destination", "linkerd-dst", 8086, 8086},
+	{"proxy-injector", "linkerd-proxy-injector", 443, 8443},
+	{"tap", "linkerd-tap", 443, 8089},
+}
+
+func (hc *HealthChecker) networkCategories() []category {
+	return []category{
+		{
+			id: LinkerdNetworkChecks,
+			checkers: []checker{
+				{
+					description: "the proxy injector webhook responds in time",
+					hintAnchor:  "l5d-network-webhook-timeout",
+					check: func(context.Context) error {
+						return hc.checkInjectorWebhook()
+					},
+				},
+				{
+					description: "can run a network probe pod",
+					hintAnchor:  "l5d-network-probe",
+					check: func(ctx context.Context) error {
+						return hc.runNetworkProbe(ctx)
+					},
+				},
+				{
+					description: "control plane ports are reachable from the data plane",
+					hintAnchor:  "l5d-network-control-plane-ports",
+					check: func(context.Context) error {
+						if hc.networkProbeResults == nil {
+							return &SkipError{Reason: "the network probe pod didn't run"}
+						}
+						return hc.checkNetworkProbeResults()
+					},
+				},
+			},
+		},
+	}
+}
+
+func (hc *HealthChecker) networkProbeNamespace() string {
+	if hc.DataPlaneNamespace != "" {
+		return hc.DataPlaneNamespace
+	}
+	return networkProbeNamespace
+}
+
+func (hc *HealthChecker) networkProbePod() *corev1.Pod {
+	var script []string
+	for _, t := range networkProbeTargets {
+		script = append(script, fmt.Sprintf("if nc -z -w 5 %s.%s.svc %d; then echo '%s ok'; else echo '%s failed'; fi",
+			t.service, hc.ControlPlaneNamespace, t.port, t.component, t.component))
+	}
+	return hc.probePod(script)
+}
+
+// probePod returns a pod running script in the network probe namespace,
+// without a proxy
+func (hc *HealthChecker) probePod(script []string) *corev1.Pod {
+	image := hc.NetworkProbeImage
+	if image == "" {
+		image = DefaultNetworkProbeImage
+	}
+
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: networkProbeName + "-",
+			Namespace:    hc.networkProbeNamespace(),
+			Labels:       map[string]string{networkProbeLabel: "true"},
+			// the probe checks the network itself, without a proxy in the way
+			Annotations: map[string]string{k8s.ProxyInjectAnnotation: k8s.ProxyInjectDisabled},
+		},
+		Spec: corev1.PodSpec{
+			RestartPolicy: corev1.RestartPolicyNever,
+			Containers: []corev1.Container{
+				{
+					Name:    "probe",
+					Image:   image,
+					Command: []string{"sh", "-c", strings.Join(script, "; ")},
+				},
+			},
+		},
+	}
+}
+
+// checkInjectorWebhook creates the network probe pod with a dry run, which
+// makes the API server call the proxy injector webhook
+func (hc *HealthChecker) checkInjectorWebhook() error {
+	start := time.Now()
+	err := hc.kubeAPI.CoreV1().RESTClient().Post().
+		Namespace(hc.networkProbeNamespace()).
+		Resource("pods").
+		Param("dryRun", metav1.DryRunAll).
+		Body(hc.networkProbePod()).
+		Do().
+		Error()
+	elapsed := time.Since(start)
+	if err != nil {
+		if isWebhookTimeout(err) {
+			return fmt.Errorf("the Kubernetes API server timed out calling the proxy injector webhook: %s\n    check that the firewall rules or security groups let the API server reach the nodes on port 8443, and that no NetworkPolicy blocks it", err)
+		}
+		return err
+	}
+	if elapsed > slowWebhookThreshold {
+		return &WarningError{fmt.Errorf("the proxy injector webhook took %s to respond", elapsed.Round(time.Millisecond))}
+	}
+	return nil
+}
+
+func isWebhookTimeout(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, "webhook") &&
+		(strings.Contains(msg, "deadline exceeded") || strings.Contains(msg, "Timeout") || strings.Contains(msg, "i/o timeout"))
+}
+
+// runNetworkProbe runs the network probe pod and stores its results
+func (hc *HealthChecker) runNetworkProbe(ctx context.Context) error {
+	results, err := runProbePod(ctx, hc.kubeAPI, hc.networkProbePod())
+	if err != nil {
+		return err
+	}
+	hc.networkProbeResults = results
+	return nil
+}
+
+// runProbePod runs a probe pod until it completes, and returns the results
+// it output
+func runProbePod(ctx context.Context, api *k8s.KubernetesAPI, probe *corev1.Pod) (map[string]bool, error) {
+	pods := api.CoreV1().Pods(probe.Namespace)
+	pod, err := pods.Create(probe)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := pods.Delete(pod.Name, &metav1.DeleteOptions{}); err != nil {
+			log.Debugf("Failed to delete the %s pod: %s", pod.Name, err)
+		}
+	}()
+
+	for pod.Status.Phase != corev1.PodSucceeded && pod.Status.Phase != corev1.PodFailed {
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("the %s pod didn't complete in time, its image may be missing or slow to pull; retry with a longer --check-timeout or another --network-probe-image", pod.Name)
+		case <-time.After(time.Second):
+		}
+		if pod, err = pods.Get(pod.Name, metav1.GetOptions{}); err != nil {
+			return nil, err
+		}
+	}
+
+	logs, err := pods.GetLogs(pod.Name, &corev1.PodLogOptions{}).DoRaw()
+	if err != nil {
+		return nil, err
+	}
+	results := parseNetworkProbeOutput(string(logs))
+	if len(results) == 0 {
+		return nil, errNoNetworkProbeResults
+	}
+	return results, nil
+}
+
+// parseNetworkProbeOutput returns whether each target was reachable, from the
+// "<target> ok|failed" lines output by a probe pod
+func parseNetworkProbeOutput(output string) map[string]bool {
+	results := map[string]bool{}
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 2 {
+			results[fields[0]] = fields[1] == "ok"
+		}
+	}
+	return results
+}
+
+func (hc *HealthChecker) checkNetworkProbeResults() error {
+	var unreachable []networkProbeTarget
+	for _, t := range networkProbeTargets {
+		if !hc.networkProbeResults[t.component] {
+			unreachable = append(unreachable, t)
+		}
+	}
+	if len(unreachable) == 0 {
+		return nil
+	}
+
+	probeNs := hc.networkProbeNamespace()
+	diagnose, err := hc.networkPolicyDiagnosis(probeNs)
+	if err != nil {
+		return err
+	}
+	var errs []string
+	for _, t := range unreachable {
+		errs = append(errs, fmt.Sprintf("* %s.%s.svc:%d (%s): %s", t.service, hc.ControlPlaneNamespace, t.port, t.component, diagnose(t)))
+	}
+	return fmt.Errorf("Some control plane ports can't be reached from the %s namespace:\n\t%s", probeNs, strings.Join(errs, "\n\t"))
+}
+
+// networkPolicyDiagnosis returns a function explaining why a control plane
+// port can't be reached from the probeNs namespace
+func (hc *HealthChecker) networkPolicyDiagnosis(probeNs string) (func(networkProbeTarget) string, error) {
+	namespaces := hc.kubeAPI.CoreV1().Namespaces()
+	cpNs, err := namespaces.Get(hc.ControlPlaneNamespace, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	srcNs, err := namespaces.Get(probeNs, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	cpPolicies, err := hc.kubeAPI.NetworkingV1().NetworkPolicies(cpNs.Name).List(metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	srcPolicies, err := hc.kubeAPI.NetworkingV1().NetworkPolicies(srcNs.Name).List(metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	probeLabels := hc.networkProbePod().Labels
+	return func(t networkProbeTarget) string {
+		targetLabels := map[string]string{k8s.ControllerComponentLabel: t.component}
+		var reasons []string
+		if blocking := blockingPolicies(cpPolicies.Items, networkingv1.PolicyTypeIngress, targetLabels, srcNs.Labels, probeLabels, t.podPort); len(blocking) > 0 {
+			reasons = append(reasons, fmt.Sprintf("the NetworkPolicies %s of the %s namespace don't allow this ingress traffic", strings.Join(blocking, ", "), cpNs.Name))
+		}
+		if blocking := blockingPolicies(srcPolicies.Items, networkingv1.PolicyTypeEgress, probeLabels, cpNs.Labels, targetLabels, t.podPort); len(blocking) > 0 {
+			reasons = append(reasons, fmt.Sprintf("the NetworkPolicies %s of the %s namespace don't allow this egress traffic", strings.Join(blocking, ", "), srcNs.Name))
+		}
+		if len(reasons) == 0 {
+			return "no NetworkPolicy blocks it, check the firewall rules or security groups between the nodes"
+		}
+		return strings.Join(reasons, "; ")
+	}, nil
+}
+
+// blockingPolicies returns the names of the policies isolating the pods
+// labeled podLabels for the policyType traffic, if none of them allows the
+// traffic on port with the peers labeled peerPodLabels in the namespace
+// labeled peerNsLabels. Named ports and IP blocks are assumed to match.
+func blockingPolicies(
+	policies []networkingv1.NetworkPolicy,
+	policyType networkingv1.PolicyType,
+	podLabels, peerNsLabels, peerPodLabels map[string]string,
+	port int32,
+) []string {
+	var isolating []string
+	for _, p := range policies {
+		if !selects(&p.Spec.PodSelector, podLabels) || !hasPolicyType(p.Spec, policyType) {
+			continue
+		}
+		isolating = append(isolating, p.Name)
+
+		if policyType == networkingv1.PolicyTypeIngress {
+			for _, rule := range p.Spec.Ingress {
+				if allowsPort(rule.Ports, port) && allowsPeer(rule.From, peerNsLabels, peerPodLabels) {
+					return nil
+				}
+			}
+		} else {
+			for _, rule := range p.Spec.Egress {
+				if allowsPort(rule.Ports, port) && allowsPeer(rule.To, peerNsLabels, peerPodLabels) {
+					return nil
+				}
+			}
+		}
+	}
+	sort.Strings(isolating)
+	return isolating
+}
+
+func hasPolicyType(spec networkingv1.NetworkPolicySpec, policyType networkingv1.PolicyType) bool {
+	if len(spec.PolicyTypes) == 0 {
+		// policies without policyTypes only affect the egress traffic if they
+		// have egress rules
+		return policyType == networkingv1.PolicyTypeIngress || len(spec.Egress) > 0
+	}
+	for _, t := range spec.PolicyTypes {
+		if t == policyType {
+			return true
+		}
+	}
+	return false
+}
+
+func allowsPort(ports []networkingv1.NetworkPolicyPort, port int32) bool {
+	if len(ports) == 0 {
+		return true
+	}
+	for _, p := range ports {
+		if p.Protocol != nil && *p.Protocol != corev1.ProtocolTCP {
+			continue
+		}
+		if p.Port == nil || p.Port.StrVal != "" || p.Port.IntVal == port {
+			return true
+		}
+	}
+	return false
+}
+
+func allowsPeer(peers []networkingv1.NetworkPolicyPeer, nsLabels, podLabels map[string]string) bool {
+	if len(peers) == 0 {
+		return true
+	}
+	for _, peer := range peers {
+		if peer.IPBlock != nil {
+			return true
+		}
+		// a peer with only a podSelector selects pods of the policy's own
+		// namespace, which is never the peer's one here
+		if peer.NamespaceSelector == nil {
+			continue
+		}
+		if selects(peer.NamespaceSelector, nsLabels) && (peer.PodSelector == nil || selects(peer.PodSelector, podLabels)) {
+			return true
+		}
+	}
+	return false
+}
+
+func selects(selector *metav1.LabelSelector, l map[string]string) bool {
+	s, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return false
+	}
+	return s.Matches(labels.Set(l))
+}
@@ -0,0 +1,128 @@
+package healthcheck
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+)
+
+func TestParseNetworkProbeOutput(t *testing.T) {
+	output := "identity ok\ndestination failed\nnc: bad address 'linkerd-tap.linkerd.svc'\ntap failed\n"
+	expected := map[string]bool{"identity": true, "destination": false, "tap": false}
+	if actual := parseNetworkProbeOutput(output); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("Expected %v, got %v", expected, actual)
+	}
+}
+
+func TestCheckNetworkProbeResults(t *testing.T) {
+	namespaces := []string{`
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: linkerd
+  labels:
+    linkerd.io/is-control-plane: "true"
+`, `
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: app
+`,
+	}
+
+	testCases := []struct {
+		name     string
+		policies []string
+		results  map[string]bool
+		expected string
+	}{
+		{
+			name:    "all reachable",
+			results: map[string]bool{"identity": true, "destination": true, "proxy-injector": true, "tap": true},
+		},
+		{
+			name:    "no policy",
+			results: map[string]bool{"identity": false, "destination": true, "proxy-injector": true, "tap": true},
+			expected: `Some control plane ports can't be reached from the app namespace:
+	* linkerd-identity.linkerd.svc:8080 (identity): no NetworkPolicy blocks it, check the firewall rules or security groups between the nodes`,
+		},
+		{
+			name: "default deny ingress and egress",
+			policies: []string{`
+apiVersion: networking.k8s.io/v1
+kind: NetworkPolicy
+metadata:
+  name: default-deny
+  namespace: linkerd
+spec:
+  podSelector: {}
+  policyTypes: [Ingress]
+`, `
+apiVersion: networking.k8s.io/v1
+kind: NetworkPolicy
+metadata:
+  name: data-plane-access
+  namespace: linkerd
+spec:
+  podSelector:
+    matchLabels:
+      linkerd.io/control-plane-component: destination
+  ingress:
+  - from:
+    - namespaceSelector: {}
+    ports:
+    - port: 8086
+`, `
+apiVersion: networking.k8s.io/v1
+kind: NetworkPolicy
+metadata:
+  name: app-egress
+  namespace: app
+spec:
+  podSelector: {}
+  policyTypes: [Egress]
+  egress:
+  - to:
+    - namespaceSelector:
+        matchLabels:
+          linkerd.io/is-control-plane: "true"
+    ports:
+    - port: 8080
+    - port: 8086
+`,
+			},
+			results: map[string]bool{"identity": false, "destination": true, "proxy-injector": false, "tap": true},
+			expected: `Some control plane ports can't be reached from the app namespace:
+	* linkerd-identity.linkerd.svc:8080 (identity): the NetworkPolicies default-deny of the linkerd namespace don't allow this ingress traffic
+	* linkerd-proxy-injector.linkerd.svc:443 (proxy-injector): the NetworkPolicies default-deny of the linkerd namespace don't allow this ingress traffic; the NetworkPolicies app-egress of the app namespace don't allow this egress traffic`,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.name, func(t *testing.T) {
+			hc := NewHealthChecker([]CategoryID{}, &Options{
+				ControlPlaneNamespace: "linkerd",
+				DataPlaneNamespace:    "app",
+			})
+			var err error
+			hc.kubeAPI, err = k8s.NewFakeAPI(append(namespaces, tc.policies...)...)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			hc.networkProbeResults = tc.results
+
+			err = hc.checkNetworkProbeResults()
+			if tc.expected == "" {
+				if err != nil {
+					t.Fatalf("Unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tc.expected {
+				t.Fatalf("Expected error:\n%s\ngot:\n%v", tc.expected, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package healthcheck
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// checkExcludedNodes lists the pods the proxy-injector skipped because they
+// run on Windows nodes or on nodes matching the excluded node selector
+func (hc *HealthChecker) checkExcludedNodes() error {
+	pods, err := hc.kubeAPI.CoreV1().Pods(hc.DataPlaneNamespace).List(metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	namespaces, err := hc.kubeAPI.CoreV1().Namespaces().List(metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	nodes, err := hc.kubeAPI.CoreV1().Nodes().List(metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	return validateExcludedNodes(pods.Items, namespaces.Items, nodes.Items, hc.ControlPlaneNamespace, hc.linkerdConfig.GetProxy().GetExcludedNodeSelector())
+}
+
+// validateExcludedNodes returns an error listing the pods enabled for
+// injection that run without a proxy on Windows nodes or on nodes matching
+// excludedSelector
+func validateExcludedNodes(pods []corev1.Pod, namespaces []corev1.Namespace, nodes []corev1.Node, controlPlaneNamespace, excludedSelector string) error {
+	selector := labels.Nothing()
+	if excludedSelector != "" {
+		var err error
+		if selector, err = labels.Parse(excludedSelector); err != nil {
+			return fmt.Errorf("invalid excluded node selector %q: %s", excludedSelector, err)
+		}
+	}
+
+	nsInject := map[string]string{}
+	for _, ns := range namespaces {
+		nsInject[ns.Name] = ns.Annotations[k8s.ProxyInjectAnnotation]
+	}
+	nodeLabels := map[string]labels.Set{}
+	for _, node := range nodes {
+		nodeLabels[node.Name] = node.Labels
+	}
+
+	var skipped []string
+	for _, pod := range pods {
+		podInject := pod.Annotations[k8s.ProxyInjectAnnotation]
+		enabled := podInject == k8s.ProxyInjectEnabled ||
+			(nsInject[pod.Namespace] == k8s.ProxyInjectEnabled && podInject != k8s.ProxyInjectDisabled)
+		if !enabled || k8s.IsMeshed(&pod, controlPlaneNamespace) {
+			continue
+		}
+
+		node, ok := nodeLabels[pod.Spec.NodeName]
+		if !ok {
+			continue
+		}
+		switch {
+		case k8s.IsWindows(node):
+			skipped = append(skipped, fmt.Sprintf("* %s/%s (Windows node %s)", pod.Namespace, pod.Name, pod.Spec.NodeName))
+		case selector.Matches(node):
+			skipped = append(skipped, fmt.Sprintf("* %s/%s (excluded node %s)", pod.Namespace, pod.Name, pod.Spec.NodeName))
+		}
+	}
+
+	if len(skipped) > 0 {
+		sort.Strings(skipped)
+		return fmt.Errorf("Some pods enabled for injection run without a proxy on Windows or excluded nodes:\n\t%s", strings.Join(skipped, "\n\t"))
+	}
+	return nil
+}
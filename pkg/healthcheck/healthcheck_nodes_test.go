@@ -0,0 +1,60 @@
+package healthcheck
+
+import (
+	"testing"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestValidateExcludedNodes(t *testing.T) {
+	namespaces := []corev1.Namespace{
+		{ObjectMeta: metav1.ObjectMeta{Name: "emojivoto", Annotations: map[string]string{k8s.ProxyInjectAnnotation: k8s.ProxyInjectEnabled}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+	}
+	nodes := []corev1.Node{
+		{ObjectMeta: metav1.ObjectMeta{Name: "linux-1", Labels: map[string]string{k8s.OSLabel: "linux"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "linux-gpu", Labels: map[string]string{k8s.OSLabel: "linux", "example.com/pool": "gpu"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "windows-1", Labels: map[string]string{k8s.OSLabel: k8s.WindowsOS}}},
+	}
+	pod := func(namespace, name, node string, annotations, labels map[string]string) corev1.Pod {
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, Annotations: annotations, Labels: labels},
+			Spec:       corev1.PodSpec{NodeName: node},
+		}
+	}
+	meshed := map[string]string{k8s.ControllerNSLabel: "linkerd"}
+	disabled := map[string]string{k8s.ProxyInjectAnnotation: k8s.ProxyInjectDisabled}
+	enabled := map[string]string{k8s.ProxyInjectAnnotation: k8s.ProxyInjectEnabled}
+	pods := []corev1.Pod{
+		pod("emojivoto", "web-1", "linux-1", nil, meshed),
+		pod("emojivoto", "web-2", "windows-1", nil, nil),
+		pod("emojivoto", "web-3", "windows-1", disabled, nil),
+		pod("emojivoto", "web-4", "linux-gpu", nil, nil),
+		pod("default", "iis-1", "windows-1", nil, nil),
+		pod("default", "iis-2", "windows-1", enabled, nil),
+		pod("default", "pending", "", enabled, nil),
+	}
+
+	expected := `Some pods enabled for injection run without a proxy on Windows or excluded nodes:
+	* default/iis-2 (Windows node windows-1)
+	* emojivoto/web-2 (Windows node windows-1)
+	* emojivoto/web-4 (excluded node linux-gpu)`
+	err := validateExcludedNodes(pods, namespaces, nodes, "linkerd", "example.com/pool=gpu")
+	if err == nil || err.Error() != expected {
+		t.Fatalf("Expected error:\n%s\ngot:\n%v", expected, err)
+	}
+
+	expected = `Some pods enabled for injection run without a proxy on Windows or excluded nodes:
+	* default/iis-2 (Windows node windows-1)
+	* emojivoto/web-2 (Windows node windows-1)`
+	err = validateExcludedNodes(pods, namespaces, nodes, "linkerd", "")
+	if err == nil || err.Error() != expected {
+		t.Fatalf("Expected error:\n%s\ngot:\n%v", expected, err)
+	}
+
+	if err := validateExcludedNodes(pods[:1], namespaces, nodes, "linkerd", ""); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+}
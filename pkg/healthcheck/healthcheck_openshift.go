@@ -0,0 +1,113 @@
+package healthcheck
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+const (
+	// LinkerdPreInstallOpenShiftChecks adds checks to validate that the
+	// SecurityContextConstraints rendered by `linkerd install --openshift` can
+	// be created. These checks are dependent on the output of
+	// KubernetesAPIChecks, so those checks must be added first.
+	LinkerdPreInstallOpenShiftChecks CategoryID = "pre-openshift"
+
+	// LinkerdOpenShiftChecks adds checks to validate that the control plane
+	// pods are admitted under the linkerd SecurityContextConstraints. These
+	// checks are dependent on the output of KubernetesAPIChecks, so those checks
+	// must be added first.
+	LinkerdOpenShiftChecks CategoryID = "linkerd-openshift"
+
+	securityContextConstraintsGroupVersion = "security.openshift.io/v1"
+	securityContextConstraintsKind         = "SecurityContextConstraints"
+
+	// openShiftSCCAnnotation is set by OpenShift on every pod, to the name of
+	// the SecurityContextConstraints it was admitted under
+	openShiftSCCAnnotation = "openshift.io/scc"
+)
+
+func (hc *HealthChecker) openShiftCategories() []category {
+	return []category{
+		{
+			id: LinkerdPreInstallOpenShiftChecks,
+			checkers: []checker{
+				{
+					description: "SecurityContextConstraints API is available",
+					hintAnchor:  "pre-openshift-scc",
+					fatal:       true,
+					check: func(context.Context) error {
+						return hc.checkSecurityContextConstraintsAPI()
+					},
+				},
+				{
+					description: "can create SecurityContextConstraints",
+					hintAnchor:  "pre-openshift-scc",
+					check: func(context.Context) error {
+						return hc.checkCanCreate("", "security.openshift.io", "v1", "securitycontextconstraints")
+					},
+				},
+			},
+		},
+		{
+			id: LinkerdOpenShiftChecks,
+			checkers: []checker{
+				{
+					description: "SecurityContextConstraints API is available",
+					hintAnchor:  "l5d-openshift-scc",
+					fatal:       true,
+					check: func(context.Context) error {
+						return hc.checkSecurityContextConstraintsAPI()
+					},
+				},
+				{
+					description:   "control plane pods are admitted under the linkerd SecurityContextConstraints",
+					hintAnchor:    "l5d-openshift-scc",
+					retryDeadline: hc.RetryDeadline,
+					check: func(context.Context) error {
+						return hc.checkControlPlanePodsSCC()
+					},
+				},
+			},
+		},
+	}
+}
+
+func (hc *HealthChecker) checkSecurityContextConstraintsAPI() error {
+	res, err := hc.kubeAPI.Discovery().ServerResourcesForGroupVersion(securityContextConstraintsGroupVersion)
+	if err != nil {
+		return fmt.Errorf("cannot discover the %s API, make sure this is an OpenShift cluster: %s", securityContextConstraintsGroupVersion, err)
+	}
+
+	for _, apiRes := range res.APIResources {
+		if apiRes.Kind == securityContextConstraintsKind {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%s not found in %s", securityContextConstraintsKind, securityContextConstraintsGroupVersion)
+}
+
+// checkControlPlanePodsSCC validates that every control plane pod has been
+// admitted under the SecurityContextConstraints rendered by the install. A
+// pod admitted under a different SCC would have its UIDs and groups assigned
+// from the namespace's ranges instead of the ones set in the manifest.
+func (hc *HealthChecker) checkControlPlanePodsSCC() error {
+	pods, err := hc.kubeAPI.GetPodsByNamespace(hc.ControlPlaneNamespace)
+	if err != nil {
+		return err
+	}
+
+	expected := fmt.Sprintf("linkerd-%s-control-plane", hc.ControlPlaneNamespace)
+	var errs []string
+	for _, pod := range pods {
+		if scc := pod.Annotations[openShiftSCCAnnotation]; scc != expected {
+			errs = append(errs, fmt.Sprintf("\t* %s admitted under %q", pod.Name, scc))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("Some pods weren't admitted under the %s SecurityContextConstraints:\n%s", expected, strings.Join(errs, "\n"))
+	}
+
+	return nil
+}
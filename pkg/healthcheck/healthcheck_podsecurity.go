@@ -0,0 +1,138 @@
+package healthcheck
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/linkerd/linkerd2/pkg/podsecurity"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// LinkerdPodSecurityChecks adds checks to validate that the control plane
+	// pods and the injected pods are admitted by the PodSecurity admission
+	// levels enforced in their namespaces, and by the PodSecurityPolicies.
+	// These checks are dependent on the output of KubernetesAPIChecks and
+	// LinkerdControlPlaneExistenceChecks, so those checks must be added first.
+	LinkerdPodSecurityChecks CategoryID = "linkerd-pod-security"
+)
+
+func (hc *HealthChecker) podSecurityCategories() []category {
+	return []category{
+		{
+			id: LinkerdPodSecurityChecks,
+			checkers: []checker{
+				{
+					description: "control plane pods are allowed by the Pod Security level of their namespace",
+					hintAnchor:  "l5d-pod-security-control-plane",
+					check: func(context.Context) error {
+						ns, err := hc.kubeAPI.CoreV1().Namespaces().Get(hc.ControlPlaneNamespace, metav1.GetOptions{})
+						if err != nil {
+							return err
+						}
+						return checkControlPlanePodSecurity(ns, hc.controlPlanePods)
+					},
+				},
+				{
+					description: "injected pods are allowed by the Pod Security level of their namespace",
+					hintAnchor:  "l5d-pod-security-injected",
+					warning:     true,
+					check: func(context.Context) error {
+						pod := injectedPodSpec(hc.controlPlanePods)
+						if pod == nil {
+							return &SkipError{Reason: "no injected control plane pod found"}
+						}
+						namespaces, err := hc.kubeAPI.CoreV1().Namespaces().List(metav1.ListOptions{})
+						if err != nil {
+							return err
+						}
+						return checkInjectedPodSecurity(namespaces.Items, pod)
+					},
+				},
+				{
+					description: "injected pods are allowed by a PodSecurityPolicy",
+					hintAnchor:  "l5d-pod-security-psp",
+					warning:     true,
+					check: func(context.Context) error {
+						pod := injectedPodSpec(hc.controlPlanePods)
+						if pod == nil || len(pod.Spec.InitContainers) == 0 {
+							// the proxy-init container is the only one
+							// needing capabilities
+							return &SkipError{Reason: "no proxy-init container found"}
+						}
+						for _, capability := range []string{"NET_ADMIN", "NET_RAW"} {
+							if err := hc.checkCapability(capability); err != nil {
+								return err
+							}
+						}
+						return nil
+					},
+				},
+			},
+		},
+	}
+}
+
+// injectedPodSpec returns a pod holding the proxy and proxy-init containers of
+// the first injected control plane pod, which are rendered like the ones of
+// the other injected pods
+func injectedPodSpec(pods []corev1.Pod) *corev1.Pod {
+	for _, p := range pods {
+		pod := &corev1.Pod{}
+		for _, c := range p.Spec.Containers {
+			if c.Name == k8s.ProxyContainerName {
+				pod.Spec.Containers = append(pod.Spec.Containers, c)
+			}
+		}
+		for _, c := range p.Spec.InitContainers {
+			if c.Name == k8s.InitContainerName {
+				pod.Spec.InitContainers = append(pod.Spec.InitContainers, c)
+			}
+		}
+		if len(pod.Spec.Containers) > 0 {
+			return pod
+		}
+	}
+	return nil
+}
+
+func checkControlPlanePodSecurity(ns *corev1.Namespace, pods []corev1.Pod) error {
+	level, err := podsecurity.NamespaceLevel(ns.Labels)
+	if err != nil {
+		return err
+	}
+
+	var errs []string
+	for i := range pods {
+		if violations := podsecurity.Violations(level, &pods[i]); len(violations) > 0 {
+			errs = append(errs, fmt.Sprintf("* %s:\n\t\t- %s", pods[i].Name, strings.Join(violations, "\n\t\t- ")))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("The %q Pod Security level of the %s namespace rejects some control plane pods:\n\t%s", level, ns.Name, strings.Join(errs, "\n\t"))
+	}
+	return nil
+}
+
+func checkInjectedPodSecurity(namespaces []corev1.Namespace, pod *corev1.Pod) error {
+	var errs []string
+	for _, ns := range namespaces {
+		level, err := podsecurity.NamespaceLevel(ns.Labels)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("* %s: %s", ns.Name, err))
+			continue
+		}
+		if violations := podsecurity.Violations(level, pod); len(violations) > 0 {
+			errs = append(errs, fmt.Sprintf("* %s (%s):\n\t\t- %s", ns.Name, level, strings.Join(violations, "\n\t\t- ")))
+		}
+	}
+	if len(errs) > 0 {
+		sort.Strings(errs)
+		return fmt.Errorf("Some namespaces will reject injected pods, consider using the linkerd-cni plugin or a less restrictive level:\n\t%s", strings.Join(errs, "\n\t"))
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package healthcheck
+
+import (
+	"testing"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCheckInjectedPodSecurity(t *testing.T) {
+	root := int64(0)
+	pods := []corev1.Pod{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "linkerd-identity-1"},
+			Spec: corev1.PodSpec{
+				InitContainers: []corev1.Container{
+					{
+						Name: k8s.InitContainerName,
+						SecurityContext: &corev1.SecurityContext{
+							Capabilities: &corev1.Capabilities{Add: []corev1.Capability{"NET_ADMIN", "NET_RAW"}},
+							RunAsUser:    &root,
+						},
+					},
+				},
+				Containers: []corev1.Container{{Name: "identity"}, {Name: k8s.ProxyContainerName}},
+			},
+		},
+	}
+
+	pod := injectedPodSpec(pods)
+	if pod == nil || len(pod.Spec.Containers) != 1 || len(pod.Spec.InitContainers) != 1 {
+		t.Fatalf("Expected a pod with the proxy and proxy-init containers, got %+v", pod)
+	}
+
+	namespaces := []corev1.Namespace{
+		{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "emojivoto", Labels: map[string]string{"pod-security.kubernetes.io/enforce": "baseline"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "books", Labels: map[string]string{"pod-security.kubernetes.io/enforce": "privileged"}}},
+	}
+	expected := `Some namespaces will reject injected pods, consider using the linkerd-cni plugin or a less restrictive level:
+	* emojivoto (baseline):
+		- container linkerd-init adds the NET_ADMIN capability
+		- container linkerd-init adds the NET_RAW capability`
+	err := checkInjectedPodSecurity(namespaces, pod)
+	if err == nil || err.Error() != expected {
+		t.Fatalf("Expected error:\n%s\ngot:\n%v", expected, err)
+	}
+
+	if err := checkControlPlanePodSecurity(&namespaces[2], pods); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/linkerd/linkerd2/pkg/k8s"
 	corev1 "k8s.io/api/core/v1"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 )
@@ -503,6 +504,169 @@ status:
 
 }
 
+func TestCheckAllocatableResources(t *testing.T) {
+	node := func(name, cpu, memory string, ready bool) string {
+		status := "True"
+		if !ready {
+			status = "False"
+		}
+		return fmt.Sprintf(`apiVersion: v1
+kind: Node
+metadata:
+  name: %s
+status:
+  allocatable:
+    cpu: %s
+    memory: %s
+  conditions:
+  - status: "%s"
+    type: Ready`, name, cpu, memory, status)
+	}
+
+	pod := `apiVersion: v1
+kind: Pod
+metadata:
+  name: app
+  namespace: default
+spec:
+  nodeName: node-1
+  containers:
+  - name: app
+    resources:
+      requests:
+        cpu: 1500m
+        memory: 1Gi
+status:
+  phase: Running`
+
+	requests := corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("1"),
+		corev1.ResourceMemory: resource.MustParse("500Mi"),
+	}
+
+	tests := []struct {
+		k8sConfigs []string
+		err        error
+	}{
+		{
+			[]string{node("node-1", "2", "2Gi", true), node("node-2", "1", "1Gi", true)},
+			nil,
+		},
+		{
+			[]string{node("node-1", "2", "2Gi", true), pod},
+			fmt.Errorf("not enough resources left on the schedulable nodes combined:\n    cpu: the control plane requests 1, but only 500m is allocatable"),
+		},
+		{
+			[]string{node("node-1", "2", "2Gi", true), node("node-2", "4", "4Gi", false), pod},
+			fmt.Errorf("not enough resources left on the schedulable nodes combined:\n    cpu: the control plane requests 1, but only 500m is allocatable"),
+		},
+		{
+			[]string{node("node-1", "1", "256Mi", true), pod},
+			fmt.Errorf("not enough resources left on the schedulable nodes combined:\n    cpu: the control plane requests 1, but only 0 is allocatable\n    memory: the control plane requests 500Mi, but only 0 is allocatable"),
+		},
+	}
+
+	for i, test := range tests {
+		test := test // pin
+		t.Run(fmt.Sprintf("%d: returns expected allocatable resources check result", i), func(t *testing.T) {
+			hc := NewHealthChecker(
+				[]CategoryID{},
+				&Options{ControlPlaneRequests: requests},
+			)
+
+			var err error
+			hc.kubeAPI, err = k8s.NewFakeAPI(test.k8sConfigs...)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+
+			err = hc.checkAllocatableResources()
+			if err != nil || test.err != nil {
+				if (err == nil && test.err != nil) ||
+					(err != nil && test.err == nil) ||
+					(err.Error() != test.err.Error()) {
+					t.Fatalf("Unexpected error (Expected: %s, Got: %s)", test.err, err)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckControlPlaneArchitectures(t *testing.T) {
+	node := func(name, arch, taint string) string {
+		taints := ""
+		if taint != "" {
+			taints = fmt.Sprintf(`
+spec:
+  taints:
+  - key: kubernetes.io/arch
+    value: %s
+    effect: NoSchedule`, taint)
+		}
+		return fmt.Sprintf(`apiVersion: v1
+kind: Node
+metadata:
+  name: %s
+  labels:
+    kubernetes.io/arch: %s%s
+status:
+  nodeInfo:
+    architecture: %s
+  conditions:
+  - status: "True"
+    type: Ready`, name, arch, taints, arch)
+	}
+
+	scheduling := []ComponentScheduling{
+		{Component: "destination"},
+		{Component: "identity", NodeSelector: map[string]string{"kubernetes.io/arch": "amd64"}},
+	}
+
+	tests := []struct {
+		k8sConfigs []string
+		err        error
+	}{
+		{
+			[]string{node("node-1", "amd64", ""), node("node-2", "arm64", "")},
+			nil,
+		},
+		{
+			[]string{node("node-1", "amd64", ""), node("node-2", "s390x", "")},
+			fmt.Errorf("these nodes can run control plane components, but the images are only built for amd64, arm64:\n    node-2 (s390x): destination\nset the components' node selectors, e.g. with --component-arch"),
+		},
+		{
+			// the control plane pods don't tolerate the node's taint
+			[]string{node("node-1", "amd64", ""), node("node-2", "s390x", "s390x")},
+			nil,
+		},
+	}
+
+	for i, test := range tests {
+		test := test // pin
+		t.Run(fmt.Sprintf("%d: returns expected architectures check result", i), func(t *testing.T) {
+			hc := NewHealthChecker(
+				[]CategoryID{},
+				&Options{ImageArchitectures: []string{"amd64", "arm64"}, ControlPlaneScheduling: scheduling},
+			)
+
+			var err error
+			hc.kubeAPI, err = k8s.NewFakeAPI(test.k8sConfigs...)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+
+			err = hc.checkControlPlaneArchitectures()
+			if err != nil || test.err != nil {
+				if (err == nil && test.err != nil) ||
+					(err != nil && test.err == nil) ||
+					(err.Error() != test.err.Error()) {
+					t.Fatalf("Unexpected error (Expected: %s, Got: %s)", test.err, err)
+				}
+			}
+		})
+	}
+}
+
 func TestCheckCapability(t *testing.T) {
 	tests := []struct {
 		k8sConfigs []string
@@ -2194,9 +2358,104 @@ func TestValidateDataPlanePodReporting(t *testing.T) {
 	})
 }
 
+func TestValidateDataPlanePodsHealth(t *testing.T) {
+	meshedPod := func(name, proxyVersion, anchors string, ready bool, annotations map[string]string) corev1.Pod {
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "app", Annotations: annotations},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{Name: "app", Image: "app:1.0"},
+					{
+						Name:  k8s.ProxyContainerName,
+						Image: "ghcr.io/linkerd/proxy:" + proxyVersion,
+						Env:   []corev1.EnvVar{{Name: identity.EnvTrustAnchors, Value: anchors}},
+					},
+				},
+			},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodRunning,
+				ContainerStatuses: []corev1.ContainerStatus{
+					{Name: "app", Ready: true, State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}},
+					{Name: k8s.ProxyContainerName, Ready: ready, State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}},
+				},
+			},
+		}
+	}
+	injected := map[string]string{k8s.ProxyVersionAnnotation: "stable-2.8.1"}
+
+	t.Run("Returns success if all pods are healthy", func(t *testing.T) {
+		completed := meshedPod("job-1", "stable-2.6.0", "old-anchors", false, injected)
+		completed.Status.Phase = corev1.PodSucceeded
+		pods := []corev1.Pod{
+			meshedPod("web-1", "stable-2.8.1", "anchors", true, injected),
+			meshedPod("web-2", "stable-2.7.0", "anchors\n", true, injected),
+			completed,
+		}
+
+		err := validateDataPlanePodsHealth(pods, "stable-2.8.1", "anchors")
+		if err != nil {
+			t.Fatalf("Unexpected error message: %s", err.Error())
+		}
+	})
+
+	t.Run("Checks native sidecar proxies", func(t *testing.T) {
+		nativeSidecarPod := func(name, proxyVersion string, ready bool) corev1.Pod {
+			pod := meshedPod(name, proxyVersion, "anchors", ready, injected)
+			pod.Spec.InitContainers = pod.Spec.Containers[1:]
+			pod.Spec.Containers = pod.Spec.Containers[:1]
+			pod.Status.InitContainerStatuses = pod.Status.ContainerStatuses[1:]
+			pod.Status.ContainerStatuses = pod.Status.ContainerStatuses[:1]
+			return pod
+		}
+		pods := []corev1.Pod{
+			nativeSidecarPod("web-1", "stable-2.8.1", true),
+			nativeSidecarPod("web-2", "stable-2.6.0", true),
+			nativeSidecarPod("web-3", "stable-2.8.1", false),
+		}
+
+		err := validateDataPlanePodsHealth(pods, "stable-2.8.1", "anchors")
+		if err == nil {
+			t.Fatal("Expected error, got nothing")
+		}
+		expected := `Some data plane pods aren't healthy:
+	* app/web-2:
+		- proxy version isn't supported by the control plane: stable-2.6.0 is more than 1 minor releases behind stable-2.8.1
+	* app/web-3:
+		- pod is Init:0/1
+		- the linkerd-proxy container isn't ready`
+		if err.Error() != expected {
+			t.Fatalf("Unexpected error message: %s", err.Error())
+		}
+	})
+
+	t.Run("Returns all the issues grouped by pod", func(t *testing.T) {
+		pods := []corev1.Pod{
+			meshedPod("web-1", "stable-2.8.1", "anchors", true, injected),
+			meshedPod("web-2", "stable-2.6.0", "old-anchors", false, injected),
+			meshedPod("web-3", "stable-2.8.1", "anchors", true, map[string]string{k8s.ProxyInjectAnnotation: k8s.ProxyInjectDisabled}),
+		}
+
+		err := validateDataPlanePodsHealth(pods, "stable-2.8.1", "anchors")
+		if err == nil {
+			t.Fatal("Expected error, got nothing")
+		}
+		expected := `Some data plane pods aren't healthy:
+	* app/web-2:
+		- the linkerd-proxy container isn't ready
+		- proxy version isn't supported by the control plane: stable-2.6.0 is more than 1 minor releases behind stable-2.8.1
+		- proxy doesn't have the current trust anchors, restart the pod
+	* app/web-3:
+		- pod is annotated with linkerd.io/inject: disabled but has a proxy, restart it
+		- pod is missing the linkerd.io/proxy-version annotation, re-inject it`
+		if err.Error() != expected {
+			t.Fatalf("Unexpected error message: %s", err.Error())
+		}
+	})
+}
+
 func TestLinkerdPreInstallGlobalResourcesChecks(t *testing.T) {
 	hc := NewHealthChecker(
-		[]CategoryID{LinkerdPreInstallGlobalResourcesChecks},
+		[]CategoryID{LinkerdPreInstallGlobalResourcesChecks, LinkerdPreInstallCRDsChecks},
 		&Options{})
 
 	t.Run("global resources don't exist", func(t *testing.T) {
@@ -2214,10 +2473,10 @@ func TestLinkerdPreInstallGlobalResourcesChecks(t *testing.T) {
 		expected := []string{
 			"pre-linkerd-global-resources no ClusterRoles exist",
 			"pre-linkerd-global-resources no ClusterRoleBindings exist",
-			"pre-linkerd-global-resources no CustomResourceDefinitions exist",
 			"pre-linkerd-global-resources no MutatingWebhookConfigurations exist",
 			"pre-linkerd-global-resources no ValidatingWebhookConfigurations exist",
 			"pre-linkerd-global-resources no PodSecurityPolicies exist",
+			"pre-linkerd-crds no CustomResourceDefinitions exist",
 		}
 		if !reflect.DeepEqual(observer.results, expected) {
 			testutil.AnnotatedErrorf(t, "Mismatch result", "Mismatch result.\nExpected: %v\n Actual: %v\n", expected, observer.results)
@@ -2279,10 +2538,10 @@ metadata:
 		expected := []string{
 			"pre-linkerd-global-resources no ClusterRoles exist: ClusterRoles found but should not exist: cluster-role",
 			"pre-linkerd-global-resources no ClusterRoleBindings exist: ClusterRoleBindings found but should not exist: cluster-role-binding",
-			"pre-linkerd-global-resources no CustomResourceDefinitions exist: CustomResourceDefinitions found but should not exist: custom-resource-definition",
 			"pre-linkerd-global-resources no MutatingWebhookConfigurations exist: MutatingWebhookConfigurations found but should not exist: mutating-webhook-configuration",
 			"pre-linkerd-global-resources no ValidatingWebhookConfigurations exist: ValidatingWebhookConfigurations found but should not exist: validating-webhook-configuration",
 			"pre-linkerd-global-resources no PodSecurityPolicies exist: PodSecurityPolicies found but should not exist: pod-security-policy",
+			"pre-linkerd-crds no CustomResourceDefinitions exist: CustomResourceDefinitions found but should not exist: custom-resource-definition",
 		}
 		if !reflect.DeepEqual(observer.results, expected) {
 			t.Errorf("Mismatch result.\nExpected: %v\n Actual: %v\n", expected, observer.results)
@@ -2538,6 +2797,23 @@ data:
 }
 
 func getFakeSecret(scheme string, issuerCerts *issuercerts.IssuerCertData) string {
+	if scheme == k8s.IdentityIssuerSchemeSpire {
+		return ""
+	}
+	if scheme == k8s.IdentityIssuerSchemeLinkerd && issuerCerts.KeyURI != "" {
+		return fmt.Sprintf(`
+kind: Secret
+apiVersion: v1
+metadata:
+  name: linkerd-identity-issuer
+  namespace: linkerd
+  annotations:
+    linkerd.io/identity-issuer-key-uri: %s
+data:
+  crt.pem: %s
+---
+`, issuerCerts.KeyURI, base64.StdEncoding.EncodeToString([]byte(issuerCerts.IssuerCrt)))
+	}
 	if scheme == k8s.IdentityIssuerSchemeLinkerd {
 		return fmt.Sprintf(`
 kind: Secret
@@ -2598,7 +2874,11 @@ func runIdentityCheckTestCase(t *testing.T, testID int, testDescription string,
 		hc.addCheckAsCategory("linkerd-identity-test-cat", LinkerdIdentity, checkerToTest)
 		var err error
 		hc.ControlPlaneNamespace = "linkerd"
-		hc.kubeAPI, err = k8s.NewFakeAPI(fakeConfigMap, fakeSecret)
+		manifests := []string{fakeConfigMap}
+		if fakeSecret != "" {
+			manifests = append(manifests, fakeSecret)
+		}
+		hc.kubeAPI, err = k8s.NewFakeAPI(manifests...)
 		_, hc.linkerdConfig, _ = hc.checkLinkerdConfigConfigMap()
 
 		if testDescription != "certificate config is valid" {
@@ -2632,6 +2912,23 @@ func TestLinkerdIdentityCheckCertConfig(t *testing.T) {
 			schemeInConfig:   k8s.IdentityIssuerSchemeLinkerd,
 			expectedOutput:   []string{"linkerd-identity-test-cat certificate config is valid"},
 		},
+		{
+			checkDescription: "works with valid cert and no key when the key is held by a KMS",
+			tlsSecretScheme:  k8s.IdentityIssuerSchemeLinkerd,
+			schemeInConfig:   k8s.IdentityIssuerSchemeLinkerd,
+			expectedOutput:   []string{"linkerd-identity-test-cat certificate config is valid"},
+			tlsSecretIssuerDataModifier: func(issuerData issuercerts.IssuerCertData) issuercerts.IssuerCertData {
+				issuerData.IssuerKey = ""
+				issuerData.KeyURI = "gcpkms://projects/p/locations/l/keyRings/r/cryptoKeys/k/cryptoKeyVersions/1"
+				return issuerData
+			},
+		},
+		{
+			checkDescription: "works without issuer secret when the issuer certificate is obtained from SPIRE",
+			tlsSecretScheme:  k8s.IdentityIssuerSchemeSpire,
+			schemeInConfig:   k8s.IdentityIssuerSchemeSpire,
+			expectedOutput:   []string{"linkerd-identity-test-cat certificate config is valid"},
+		},
 		{
 			checkDescription: "works with valid cert and kubernetes.io/tls secret",
 			tlsSecretScheme:  string(corev1.SecretTypeTLS),
@@ -2789,6 +3086,19 @@ func TestLinkerdIdentityCheckWrongDns(t *testing.T) {
 
 }
 
+func TestLinkerdIdentityCheckSpireIssuer(t *testing.T) {
+	issuerData := createIssuerData("spire.example.org", time.Now().AddDate(-1, 0, 0), time.Now().AddDate(1, 0, 0))
+	fakeConfigMap := getFakeConfigMap(k8s.IdentityIssuerSchemeSpire, issuerData)
+	checkers := []string{
+		"issuer cert is using supported crypto algorithm",
+		"issuer cert is within its validity period",
+		"issuer cert is issued by the trust anchor",
+	}
+	for id, checker := range checkers {
+		runIdentityCheckTestCase(t, id, "skips "+checker, checker, fakeConfigMap, "", []string{})
+	}
+}
+
 type fakeCniResourcesOpts struct {
 	hasConfigMap          bool
 	hasPodSecurityPolicy  bool
@@ -3230,6 +3540,77 @@ func TestMinReplicaCheck(t *testing.T) {
 	}
 }
 
+func TestOpenShiftChecks(t *testing.T) {
+	sccAPI := `
+kind: APIResourceList
+apiVersion: v1
+groupVersion: security.openshift.io/v1
+resources:
+  - name: securitycontextconstraints
+    singularName: securitycontextconstraint
+    namespaced: false
+    kind: SecurityContextConstraints
+    verbs:
+      - get
+      - list
+`
+	pod := func(name, scc string) string {
+		return fmt.Sprintf(`
+apiVersion: v1
+kind: Pod
+metadata:
+  name: %s
+  namespace: linkerd
+  annotations:
+    openshift.io/scc: %s
+`, name, scc)
+	}
+
+	testCases := []struct {
+		resources []string
+		expected  error
+	}{
+		{
+			resources: []string{pod("linkerd-controller-6f78cbd47-bc557", "linkerd-linkerd-control-plane")},
+			expected:  errors.New("cannot discover the security.openshift.io/v1 API, make sure this is an OpenShift cluster: GroupVersion \"security.openshift.io/v1\" not found"),
+		},
+		{
+			resources: []string{sccAPI, pod("linkerd-controller-6f78cbd47-bc557", "linkerd-linkerd-control-plane")},
+			expected:  nil,
+		},
+		{
+			resources: []string{sccAPI, pod("linkerd-controller-6f78cbd47-bc557", "linkerd-linkerd-control-plane"), pod("linkerd-web-98c9ddbcd-7b5lh", "restricted")},
+			expected:  errors.New("Some pods weren't admitted under the linkerd-linkerd-control-plane SecurityContextConstraints:\n\t* linkerd-web-98c9ddbcd-7b5lh admitted under \"restricted\""),
+		},
+	}
+
+	for i, tc := range testCases {
+		tc := tc //pin
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			hc := NewHealthChecker(
+				[]CategoryID{LinkerdOpenShiftChecks},
+				&Options{
+					ControlPlaneNamespace: "linkerd",
+				},
+			)
+
+			var err error
+			hc.kubeAPI, err = k8s.NewFakeAPI(tc.resources...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = hc.checkSecurityContextConstraintsAPI()
+			if err == nil {
+				err = hc.checkControlPlanePodsSCC()
+			}
+			if (err == nil) != (tc.expected == nil) || (err != nil && err.Error() != tc.expected.Error()) {
+				t.Fatalf("Expected error: %v, received: %v", tc.expected, err)
+			}
+		})
+	}
+}
+
 func TestGetString(t *testing.T) {
 	testCases := []struct {
 		i             interface{}
@@ -3340,3 +3721,200 @@ func generateAllControlPlaneDef(replicaOptions *controlPlaneReplicaOptions, t *t
 	}
 	return resourceDefs
 }
+
+func TestCheckFIPSImages(t *testing.T) {
+	pod := func(namespace, name, proxyImage string) string {
+		return fmt.Sprintf(`
+apiVersion: v1
+kind: Pod
+metadata:
+  name: %s
+  namespace: %s
+  labels:
+    linkerd.io/control-plane-ns: linkerd
+spec:
+  containers:
+  - name: app
+    image: buoyantio/emojivoto-web:v10
+  - name: linkerd-proxy
+    image: %s
+`, name, namespace, proxyImage)
+	}
+
+	testCases := []struct {
+		resources []string
+		expected  error
+	}{
+		{
+			resources: []string{pod("emojivoto", "web-6f78cbd47-bc557", "ghcr.io/linkerd/proxy-fips:stable-2.9.0")},
+			expected:  nil,
+		},
+		{
+			resources: []string{
+				pod("emojivoto", "web-6f78cbd47-bc557", "ghcr.io/linkerd/proxy-fips:stable-2.9.0"),
+				pod("emojivoto", "voting-98c9ddbcd-7b5lh", "registry.example.com:5000/proxy:stable-2.9.0"),
+			},
+			expected: errors.New("Some containers don't run a FIPS image:\n\t* emojivoto/voting-98c9ddbcd-7b5lh (linkerd-proxy): registry.example.com:5000/proxy:stable-2.9.0"),
+		},
+	}
+
+	for i, tc := range testCases {
+		tc := tc //pin
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			hc := NewHealthChecker(
+				[]CategoryID{LinkerdFIPSChecks},
+				&Options{
+					ControlPlaneNamespace: "linkerd",
+				},
+			)
+
+			var err error
+			hc.kubeAPI, err = k8s.NewFakeAPI(tc.resources...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = hc.checkFIPSImages("", isProxyContainer)
+			if !reflect.DeepEqual(err, tc.expected) {
+				t.Fatalf("Expected error: %s, received: %s", tc.expected, err)
+			}
+		})
+	}
+}
+
+func TestRegisterCategory(t *testing.T) {
+	err := RegisterCategory(LinkerdConfigChecks, []Checker{})
+	if err == nil {
+		t.Fatalf("Expected the built-in category %s not to be registered", LinkerdConfigChecks)
+	}
+
+	err = RegisterCategory("extension-cat", []Checker{
+		{
+			Description: "extension desc1",
+			Check: func(context.Context, *HealthChecker) error {
+				return nil
+			},
+		},
+		{
+			Description: "extension desc2",
+			Warning:     true,
+			Check: func(context.Context, *HealthChecker) error {
+				return errors.New("extension warning")
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	found := false
+	for _, id := range RegisteredCategories() {
+		found = found || id == "extension-cat"
+	}
+	if !found {
+		t.Fatalf("Expected extension-cat to be registered, got %v", RegisteredCategories())
+	}
+
+	for id, known := range map[CategoryID]bool{LinkerdConfigChecks: true, "extension-cat": true, "unknown-cat": false} {
+		if IsKnownCategory(id) != known {
+			t.Errorf("Expected IsKnownCategory(%s) to be %t", id, known)
+		}
+	}
+
+	var observed []string
+	observer := func(result *CheckResult) {
+		observed = append(observed, fmt.Sprintf("%s %s %v", result.Category, result.Description, result.Err))
+	}
+
+	hc := NewHealthChecker([]CategoryID{}, &Options{})
+	if !hc.RunChecks(observer) || len(observed) != 0 {
+		t.Fatalf("Expected the unrequested extension category not to run, got %v", observed)
+	}
+
+	hc = NewHealthChecker([]CategoryID{"extension-cat"}, &Options{})
+	if !hc.RunChecks(observer) {
+		t.Fatalf("Expected the warning not to fail the checks")
+	}
+	expected := []string{
+		"extension-cat extension desc1 <nil>",
+		"extension-cat extension desc2 extension warning",
+	}
+	if !reflect.DeepEqual(observed, expected) {
+		t.Fatalf("Expected results %v, got %v", expected, observed)
+	}
+}
+
+func TestBackoffDelays(t *testing.T) {
+	testCases := []struct {
+		backoff  Backoff
+		expected []time.Duration
+	}{
+		{Backoff{Interval: time.Second}, []time.Duration{time.Second, time.Second, time.Second, time.Second}},
+		{Backoff{Interval: time.Second, Factor: 2}, []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second}},
+		{Backoff{Interval: time.Second, Factor: 3, MaxInterval: 5 * time.Second}, []time.Duration{time.Second, 3 * time.Second, 5 * time.Second, 5 * time.Second}},
+	}
+
+	for _, tc := range testCases {
+		next := tc.backoff.delays()
+		var delays []time.Duration
+		for range tc.expected {
+			delays = append(delays, next())
+		}
+		if !reflect.DeepEqual(delays, tc.expected) {
+			t.Errorf("Expected delays %v for %+v, got %v", tc.expected, tc.backoff, delays)
+		}
+	}
+}
+
+func TestRetryAll(t *testing.T) {
+	failures := 0
+	flakyCheck := category{
+		id: "flaky",
+		checkers: []checker{
+			{
+				description: "flaky desc",
+				check: func(context.Context) error {
+					if failures < 2 {
+						failures++
+						return errors.New("not yet")
+					}
+					return nil
+				},
+			},
+		},
+	}
+	observer := func(*CheckResult) {}
+
+	hc := NewHealthChecker([]CategoryID{}, &Options{RetryDeadline: time.Now().Add(time.Minute)})
+	hc.addCategory(flakyCheck)
+	if hc.RunChecks(observer) {
+		t.Fatalf("Expected the check not to be retried without RetryAll")
+	}
+
+	failures = 0
+	hc = NewHealthChecker([]CategoryID{}, &Options{
+		RetryDeadline: time.Now().Add(time.Minute),
+		RetryAll:      true,
+		RetryBackoff:  Backoff{Interval: time.Millisecond},
+	})
+	hc.addCategory(flakyCheck)
+	if !hc.RunChecks(observer) {
+		t.Fatalf("Expected the check to be retried until it passes with RetryAll")
+	}
+
+	failures = -1000
+	hc = NewHealthChecker([]CategoryID{}, &Options{
+		RetryDeadline:    time.Now().Add(time.Minute),
+		RetryAll:         true,
+		RetryBackoff:     Backoff{Interval: time.Millisecond},
+		CategoryTimeouts: map[CategoryID]time.Duration{"flaky": 50 * time.Millisecond},
+	})
+	hc.addCategory(flakyCheck)
+	start := time.Now()
+	if hc.RunChecks(observer) {
+		t.Fatalf("Expected the check to fail once its category timed out")
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Fatalf("Expected the category timeout to stop the retries, took %s", elapsed)
+	}
+}
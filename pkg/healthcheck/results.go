@@ -0,0 +1,94 @@
+package healthcheck
+
+import (
+	"fmt"
+)
+
+// Results holds the final results of the checks run by a HealthChecker,
+// grouped by category. It's the output of `linkerd check -o json`, which CI
+// systems parse, so fields may be added to it but not renamed or removed.
+type Results struct {
+	Success    bool               `json:"success"`
+	Categories []*CategoryResults `json:"categories"`
+}
+
+// CategoryResults holds the final results of the checks of a category, in the
+// order they were run
+type CategoryResults struct {
+	Name   CategoryID `json:"categoryName"`
+	Checks []*Check   `json:"checks"`
+}
+
+// Check is the final result of a check. Unlike CheckResult, it only holds
+// serializable fields, and the hint is a full URL.
+type Check struct {
+	Description string      `json:"description"`
+	Hint        string      `json:"hint,omitempty"`
+	Error       string      `json:"error,omitempty"`
+	Result      CheckStatus `json:"result"`
+}
+
+// CheckStatus is the outcome of a check
+type CheckStatus string
+
+const (
+	// CheckSuccess is the status of a check that passed
+	CheckSuccess CheckStatus = "success"
+	// CheckWarning is the status of a failed check that doesn't impact the
+	// overall outcome
+	CheckWarning CheckStatus = "warning"
+	// CheckError is the status of a failed check
+	CheckError CheckStatus = "error"
+)
+
+// RunChecksWithResults runs the checks of the enabled categories like
+// RunChecks, and returns their final results; the results of the checks that
+// are going to be retried are ignored. If observer isn't nil, it receives the
+// results of each check as they become available, including the retried ones.
+func (hc *HealthChecker) RunChecksWithResults(observer CheckObserver) *Results {
+	results := &Results{}
+
+	collect := func(result *CheckResult) {
+		if observer != nil {
+			observer(result)
+		}
+
+		categories := results.Categories
+		if len(categories) == 0 || categories[len(categories)-1].Name != result.Category {
+			results.Categories = append(results.Categories, &CategoryResults{
+				Name:   result.Category,
+				Checks: []*Check{},
+			})
+		}
+		if result.Retry {
+			return
+		}
+
+		current := results.Categories[len(results.Categories)-1]
+		current.Checks = append(current.Checks, NewCheck(result))
+	}
+
+	results.Success = hc.RunChecks(collect)
+	return results
+}
+
+// NewCheck returns the serializable version of a check's result
+func NewCheck(result *CheckResult) *Check {
+	check := &Check{
+		Description: result.Description,
+		Result:      CheckSuccess,
+	}
+	if result.Err == nil {
+		return check
+	}
+
+	check.Result = CheckError
+	if result.Warning {
+		check.Result = CheckWarning
+	}
+	check.Error = result.Err.Error()
+	if result.HintAnchor != "" {
+		check.Hint = fmt.Sprintf("%s%s", HintBaseURL, result.HintAnchor)
+	}
+	return check
+}
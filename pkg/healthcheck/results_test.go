@@ -0,0 +1,56 @@
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestRunChecksWithResults(t *testing.T) {
+	hc := NewHealthChecker([]CategoryID{}, &Options{})
+	hc.addCategory(category{
+		id: "cat1",
+		checkers: []checker{
+			{
+				description: "passes",
+				check:       func(context.Context) error { return nil },
+			},
+			{
+				description: "warns",
+				hintAnchor:  "cat1-warns",
+				warning:     true,
+				check:       func(context.Context) error { return errors.New("slow") },
+			},
+		},
+	})
+	hc.Add("cat2", "fails", "", func(context.Context) error { return errors.New("broken") })
+
+	observed := 0
+	results := hc.RunChecksWithResults(func(*CheckResult) { observed++ })
+
+	expected := &Results{
+		Success: false,
+		Categories: []*CategoryResults{
+			{
+				Name: "cat1",
+				Checks: []*Check{
+					{Description: "passes", Result: CheckSuccess},
+					{Description: "warns", Hint: HintBaseURL + "cat1-warns", Error: "slow", Result: CheckWarning},
+				},
+			},
+			{
+				Name: "cat2",
+				Checks: []*Check{
+					{Description: "fails", Error: "broken", Result: CheckError},
+				},
+			},
+		},
+	}
+	if !reflect.DeepEqual(results, expected) {
+		t.Fatalf("Expected results %+v, got %+v", expected, results)
+	}
+	if observed != 3 {
+		t.Fatalf("Expected the observer to receive 3 results, got %d", observed)
+	}
+}
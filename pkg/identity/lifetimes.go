@@ -0,0 +1,97 @@
+package identity
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/linkerd/linkerd2/pkg/tls"
+	corelisters "k8s.io/client-go/listers/core/v1"
+)
+
+// ValidityFunc returns the validity of the certificates issued to the proxies
+// of a service account
+type ValidityFunc func(namespace, serviceAccount string) (tls.Validity, error)
+
+// NewK8sValidityFunc returns a ValidityFunc overriding the lifetime and
+// renewal jitter of defaults with the annotations of the namespace, and then
+// of the service account, of the proxies, as found in the listers' caches
+func NewK8sValidityFunc(namespaces corelisters.NamespaceLister, serviceAccounts corelisters.ServiceAccountLister, defaults tls.Validity) ValidityFunc {
+	return func(namespace, serviceAccount string) (tls.Validity, error) {
+		return WorkloadValidity(namespaces, serviceAccounts, defaults, namespace, serviceAccount)
+	}
+}
+
+// WorkloadValidity returns defaults overridden by the certificate lifetime
+// annotations of a namespace, and then of one of its service accounts. The
+// annotations can shorten the lifetime of defaults, but not extend it, as
+// they can be set by the namespaces' owners.
+func WorkloadValidity(namespaces corelisters.NamespaceLister, serviceAccounts corelisters.ServiceAccountLister, defaults tls.Validity, namespace, serviceAccount string) (tls.Validity, error) {
+	validity := defaults
+
+	ns, err := namespaces.Get(namespace)
+	if err != nil {
+		return defaults, err
+	}
+	if err := applyValidityAnnotations(&validity, ns.GetAnnotations()); err != nil {
+		return defaults, fmt.Errorf("invalid annotation on namespace %s: %s", namespace, err)
+	}
+
+	sa, err := serviceAccounts.ServiceAccounts(namespace).Get(serviceAccount)
+	if err != nil {
+		return defaults, err
+	}
+	if err := applyValidityAnnotations(&validity, sa.GetAnnotations()); err != nil {
+		return defaults, fmt.Errorf("invalid annotation on service account %s/%s: %s", namespace, serviceAccount, err)
+	}
+
+	if err := CheckValidity(validity); err != nil {
+		return defaults, fmt.Errorf("invalid certificate lifetime for %s/%s: %s", namespace, serviceAccount, err)
+	}
+	if maxLifetime := lifetimeOf(defaults); lifetimeOf(validity) > maxLifetime {
+		return defaults, fmt.Errorf("invalid certificate lifetime for %s/%s: %s exceeds the issuance lifetime %s", namespace, serviceAccount, lifetimeOf(validity), maxLifetime)
+	}
+	return validity, nil
+}
+
+// CheckValidity returns an error if the renewal jitter of validity isn't
+// shorter than its lifetime
+func CheckValidity(validity tls.Validity) error {
+	if validity.Lifetime < 0 || validity.RenewalJitter < 0 {
+		return fmt.Errorf("durations must not be negative")
+	}
+	lifetime := lifetimeOf(validity)
+	if validity.RenewalJitter >= lifetime {
+		return fmt.Errorf("renewal jitter %s must be shorter than the lifetime %s", validity.RenewalJitter, lifetime)
+	}
+	return nil
+}
+
+// lifetimeOf returns the lifetime of the certificates issued with validity
+func lifetimeOf(validity tls.Validity) time.Duration {
+	if validity.Lifetime == 0 {
+		return DefaultIssuanceLifetime
+	}
+	return validity.Lifetime
+}
+
+func applyValidityAnnotations(validity *tls.Validity, annotations map[string]string) error {
+	for annotation, d := range map[string]*time.Duration{
+		k8s.ProxyCertLifetimeAnnotation:      &validity.Lifetime,
+		k8s.ProxyCertRenewalJitterAnnotation: &validity.RenewalJitter,
+	} {
+		value, ok := annotations[annotation]
+		if !ok {
+			continue
+		}
+		duration, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("%s: %s", annotation, err)
+		}
+		if duration < 0 || (duration == 0 && annotation == k8s.ProxyCertLifetimeAnnotation) {
+			return fmt.Errorf("%s: invalid duration %s", annotation, value)
+		}
+		*d = duration
+	}
+	return nil
+}
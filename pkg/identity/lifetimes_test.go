@@ -0,0 +1,87 @@
+package identity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/linkerd/linkerd2/pkg/tls"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestWorkloadValidity(t *testing.T) {
+	defaults := tls.Validity{Lifetime: 24 * time.Hour}
+	testCases := []struct {
+		desc          string
+		nsAnnotations map[string]string
+		saAnnotations map[string]string
+		expected      tls.Validity
+		expectedErr   string
+	}{
+		{
+			desc:     "defaults",
+			expected: defaults,
+		},
+		{
+			desc:          "namespace override",
+			nsAnnotations: map[string]string{k8s.ProxyCertLifetimeAnnotation: "12h", k8s.ProxyCertRenewalJitterAnnotation: "1h"},
+			expected:      tls.Validity{Lifetime: 12 * time.Hour, RenewalJitter: time.Hour},
+		},
+		{
+			desc:          "service account override",
+			nsAnnotations: map[string]string{k8s.ProxyCertLifetimeAnnotation: "12h", k8s.ProxyCertRenewalJitterAnnotation: "1h"},
+			saAnnotations: map[string]string{k8s.ProxyCertLifetimeAnnotation: "2h"},
+			expected:      tls.Validity{Lifetime: 2 * time.Hour, RenewalJitter: time.Hour},
+		},
+		{
+			desc:          "invalid duration",
+			saAnnotations: map[string]string{k8s.ProxyCertLifetimeAnnotation: "soon"},
+			expected:      defaults,
+			expectedErr:   "invalid annotation on service account emojivoto/web: config.linkerd.io/proxy-cert-lifetime: time: invalid duration \"soon\"",
+		},
+		{
+			desc:          "namespace lifetime longer than the issuance lifetime",
+			nsAnnotations: map[string]string{k8s.ProxyCertLifetimeAnnotation: "48h"},
+			expected:      defaults,
+			expectedErr:   "invalid certificate lifetime for emojivoto/web: 48h0m0s exceeds the issuance lifetime 24h0m0s",
+		},
+		{
+			desc:          "service account lifetime longer than the issuance lifetime",
+			nsAnnotations: map[string]string{k8s.ProxyCertLifetimeAnnotation: "12h"},
+			saAnnotations: map[string]string{k8s.ProxyCertLifetimeAnnotation: "8760h"},
+			expected:      defaults,
+			expectedErr:   "invalid certificate lifetime for emojivoto/web: 8760h0m0s exceeds the issuance lifetime 24h0m0s",
+		},
+		{
+			desc:          "jitter longer than the lifetime",
+			nsAnnotations: map[string]string{k8s.ProxyCertLifetimeAnnotation: "1h", k8s.ProxyCertRenewalJitterAnnotation: "2h"},
+			expected:      defaults,
+			expectedErr:   "invalid certificate lifetime for emojivoto/web: renewal jitter 2h0m0s must be shorter than the lifetime 1h0m0s",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.desc, func(t *testing.T) {
+			nsIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+			nsIndexer.Add(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "emojivoto", Annotations: tc.nsAnnotations}})
+			saIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+			saIndexer.Add(&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "emojivoto", Annotations: tc.saAnnotations}})
+
+			validityFor := NewK8sValidityFunc(corelisters.NewNamespaceLister(nsIndexer), corelisters.NewServiceAccountLister(saIndexer), defaults)
+			validity, err := validityFor("emojivoto", "web")
+			if tc.expectedErr == "" && err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if tc.expectedErr != "" && (err == nil || err.Error() != tc.expectedErr) {
+				t.Fatalf("Expected error \"%s\", got \"%v\"", tc.expectedErr, err)
+			}
+			if validity != tc.expected {
+				t.Fatalf("Expected validity %+v, got %+v", tc.expected, validity)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package identity
+
+import (
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	labelResult         = "result"
+	labelNamespace      = "namespace"
+	labelServiceAccount = "serviceaccount"
+)
+
+var (
+	certifyRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "identity_cert_requests_total",
+		Help: "A counter for number of certificate signing requests processed by the identity service, by result (the gRPC status code).",
+	}, []string{labelResult})
+
+	certifyDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "identity_cert_request_duration_seconds",
+		Help:    "A histogram of the time taken by the identity service to process certificate signing requests, by result (the gRPC status code).",
+		Buckets: []float64{0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5},
+	}, []string{labelResult})
+
+	certExpiry = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "identity_cert_expiration_timestamp_seconds",
+		Help: "The expiry time, in seconds since the epoch, of the last certificate issued for each proxy identity.",
+	}, []string{labelNamespace, labelServiceAccount})
+
+	auditLog = log.WithField("audit", "certify")
+)
+
+// identityLabels splits a proxy identity
+// (<sa>.<ns>.serviceaccount.identity.<controller-ns>.<trust-domain>) into its
+// namespace and service account, which are empty for other identities
+func identityLabels(identity string) (string, string) {
+	parts := strings.SplitN(identity, ".", 3)
+	if len(parts) != 3 || !strings.HasPrefix(parts[2], "serviceaccount.identity.") {
+		return "", ""
+	}
+	return parts[1], parts[0]
+}
+
+// recordCertify records the outcome of a certificate signing request in the
+// audit log and the metrics
+func recordCertify(identity, client string, serial string, expiry time.Time, latency time.Duration, err error) {
+	result := status.Code(err).String()
+	certifyRequests.WithLabelValues(result).Inc()
+	certifyDuration.WithLabelValues(result).Observe(latency.Seconds())
+
+	ns, sa := identityLabels(identity)
+	entry := auditLog.WithFields(log.Fields{
+		"identity":       identity,
+		"namespace":      ns,
+		"serviceaccount": sa,
+		"client":         client,
+		"result":         result,
+		"latency":        latency.String(),
+	})
+	if err != nil {
+		entry.WithError(err).Warn("Certificate signing request rejected")
+		return
+	}
+
+	if ns != "" {
+		certExpiry.WithLabelValues(ns, sa).Set(float64(expiry.Unix()))
+	}
+	entry.WithFields(log.Fields{
+		"serial": serial,
+		"expiry": expiry.UTC().Format(time.RFC3339),
+	}).Info("Certificate issued")
+}
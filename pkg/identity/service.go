@@ -2,9 +2,12 @@ package identity
 
 import (
 	"context"
+	"crypto"
 	"crypto/x509"
 	"errors"
 	"fmt"
+	"io/ioutil"
+	"net/url"
 	"sync"
 	"time"
 
@@ -16,6 +19,7 @@ import (
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 )
 
@@ -42,6 +46,18 @@ type (
 		validity                                   *tls.Validity
 		recordEvent                                func(eventType, reason, message string)
 		expectedName, issuerPathCrt, issuerPathKey string
+		// issuerSigner signs with the issuer private key when it is held by
+		// a KMS or an HSM, in which case only the certificate is read from disk
+		issuerSigner crypto.Signer
+		// spiffeTrustDomain is the SPIFFE trust domain of the issuer, when it
+		// has been obtained from a SPIRE server
+		spiffeTrustDomain string
+		// validityFor overrides the validity of the certificates issued to
+		// the proxies of a service account, if set
+		validityFor ValidityFunc
+		// ready is closed once the first issuer has been loaded
+		ready     chan struct{}
+		readyOnce *sync.Once
 	}
 
 	// Validator implementors accept a bearer token, validates it, and returns a
@@ -82,6 +98,17 @@ func (svc *Service) updateIssuer(newIssuer tls.Issuer) {
 	svc.issuer = &newIssuer
 	log.Debug("Issuer has been updated")
 	svc.issuerMutex.Unlock()
+	svc.markReady()
+}
+
+func (svc *Service) markReady() {
+	svc.readyOnce.Do(func() { close(svc.ready) })
+}
+
+// Ready returns a channel that's closed once the service has an issuer and
+// can certify CSRs
+func (svc *Service) Ready() <-chan struct{} {
+	return svc.ready
 }
 
 // Run reads from the issuer and error channels and reloads the issuer certs when necessary
@@ -105,10 +132,16 @@ func (svc *Service) Run(issuerEvent <-chan struct{}, issuerError <-chan error) {
 }
 
 func (svc *Service) loadCredentials() (tls.Issuer, error) {
-	creds, err := tls.ReadPEMCreds(
-		svc.issuerPathKey,
-		svc.issuerPathCrt,
-	)
+	var creds *tls.Cred
+	var err error
+	if svc.issuerSigner != nil {
+		creds, err = readSignerCreds(svc.issuerSigner, svc.issuerPathCrt)
+	} else {
+		creds, err = tls.ReadPEMCreds(
+			svc.issuerPathKey,
+			svc.issuerPathCrt,
+		)
+	}
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to read CA from disk: %s", err)
@@ -122,8 +155,23 @@ func (svc *Service) loadCredentials() (tls.Issuer, error) {
 	return tls.NewCA(*creds, *svc.validity), nil
 }
 
-// NewService creates a new identity service.
-func NewService(validator Validator, trustAnchors *x509.CertPool, validity *tls.Validity, recordEvent func(eventType, reason, message string), expectedName, issuerPathCrt, issuerPathKey string) *Service {
+func readSignerCreds(signer crypto.Signer, crtPath string) (*tls.Cred, error) {
+	crtb, err := ioutil.ReadFile(crtPath)
+	if err != nil {
+		return nil, err
+	}
+	crt, err := tls.DecodePEMCrt(string(crtb))
+	if err != nil {
+		return nil, err
+	}
+	return tls.NewSignerCred(signer, *crt)
+}
+
+// NewService creates a new identity service. If issuerSigner isn't nil, it
+// signs with the issuer private key instead of the key in issuerPathKey. If
+// validityFor isn't nil, it overrides the validity of the certificates issued
+// to each service account.
+func NewService(validator Validator, trustAnchors *x509.CertPool, validity *tls.Validity, recordEvent func(eventType, reason, message string), expectedName, issuerPathCrt, issuerPathKey string, issuerSigner crypto.Signer, validityFor ValidityFunc) *Service {
 	return &Service{
 		validator,
 		trustAnchors,
@@ -134,6 +182,11 @@ func NewService(validator Validator, trustAnchors *x509.CertPool, validity *tls.
 		expectedName,
 		issuerPathCrt,
 		issuerPathKey,
+		issuerSigner,
+		"",
+		validityFor,
+		make(chan struct{}),
+		&sync.Once{},
 	}
 }
 
@@ -149,38 +202,62 @@ func (svc *Service) ensureIssuerStillValid() error {
 	issuer := *svc.issuer
 	switch is := issuer.(type) {
 	case *tls.CA:
+		// Issuers obtained from a SPIRE server only have a SPIFFE ID
+		if svc.spiffeTrustDomain != "" {
+			return is.Cred.Verify(svc.trustAnchors, "", time.Time{})
+		}
 		return is.Cred.Verify(svc.trustAnchors, svc.expectedName, time.Time{})
 	default:
 		return fmt.Errorf("unsupported issuer type. Expected *tls.CA, got %v", is)
 	}
 }
 
-// Certify validates identity and signs certificates.
+// Certify validates identity and signs certificates, recording each request
+// in the audit log and the metrics.
 func (svc *Service) Certify(ctx context.Context, req *pb.CertifyRequest) (*pb.CertifyResponse, error) {
+	start := time.Now()
+	rsp, crt, err := svc.certify(ctx, req)
+
+	var client string
+	if p, ok := peer.FromContext(ctx); ok {
+		client = p.Addr.String()
+	}
+	var serial string
+	var expiry time.Time
+	if crt != nil {
+		serial = crt.SerialNumber.String()
+		expiry = crt.NotAfter
+	}
+	recordCertify(req.GetIdentity(), client, serial, expiry, time.Since(start), err)
+
+	return rsp, err
+}
+
+func (svc *Service) certify(ctx context.Context, req *pb.CertifyRequest) (*pb.CertifyResponse, *x509.Certificate, error) {
 	svc.issuerMutex.RLock()
 	defer svc.issuerMutex.RUnlock()
 
 	if svc.issuer == nil {
 		log.Warn("Certificate issuer is not ready")
-		return nil, status.Error(codes.Unavailable, "cert issuer not ready yet")
+		return nil, nil, status.Error(codes.Unavailable, "cert issuer not ready yet")
 	}
 
 	// Extract the relevant info from the request.
 	reqIdentity, tok, csr, err := checkRequest(req)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, err.Error())
+		return nil, nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
 	if err := svc.ensureIssuerStillValid(); err != nil {
 		log.Errorf("could not process CSR because of CA cert validation failure: %s - CSR Identity : %s", err, reqIdentity)
 		message := fmt.Sprintf("%s - CSR Identity : %s", err.Error(), reqIdentity)
 		svc.recordEvent(v1.EventTypeWarning, eventTypeFailed, message)
-		return nil, err
+		return nil, nil, err
 	}
 
 	if err = checkCSR(csr, reqIdentity); err != nil {
 		log.Debugf("requester sent invalid CSR: %s", err)
-		return nil, status.Error(codes.FailedPrecondition, err.Error())
+		return nil, nil, status.Error(codes.FailedPrecondition, err.Error())
 	}
 
 	// Authenticate the provided token against the Kubernetes API.
@@ -190,14 +267,14 @@ func (svc *Service) Certify(ctx context.Context, req *pb.CertifyRequest) (*pb.Ce
 		switch e := err.(type) {
 		case NotAuthenticated:
 			log.Infof("authentication failed for %s: %s", reqIdentity, e)
-			return nil, status.Error(codes.FailedPrecondition, e.Error())
+			return nil, nil, status.Error(codes.FailedPrecondition, e.Error())
 		case InvalidToken:
 			log.Debugf("invalid token provided for %s: %s", reqIdentity, e)
-			return nil, status.Error(codes.InvalidArgument, e.Error())
+			return nil, nil, status.Error(codes.InvalidArgument, e.Error())
 		default:
 			msg := fmt.Sprintf("error validating token for %s: %s", reqIdentity, e)
 			log.Error(msg)
-			return nil, status.Error(codes.Internal, msg)
+			return nil, nil, status.Error(codes.Internal, msg)
 		}
 	}
 
@@ -206,14 +283,30 @@ func (svc *Service) Certify(ctx context.Context, req *pb.CertifyRequest) (*pb.Ce
 		msg := fmt.Sprintf("requested identity did not match provided token: requested=%s; found=%s",
 			reqIdentity, tokIdentity)
 		log.Debug(msg)
-		return nil, status.Error(codes.FailedPrecondition, msg)
+		return nil, nil, status.Error(codes.FailedPrecondition, msg)
 	}
 
-	// Create a certificate
+	// Certificates issued by a SPIRE issuer also carry the SPIFFE ID of the
+	// proxy, so that they can be authorized by other SPIFFE workloads.
+	if svc.spiffeTrustDomain != "" {
+		id, err := spiffeID(svc.spiffeTrustDomain, tokIdentity)
+		if err != nil {
+			return nil, nil, status.Error(codes.Internal, err.Error())
+		}
+		csr.URIs = []*url.URL{id}
+	}
+
+	// Create a certificate, with the validity configured for the service
+	// account of the proxy if any
 	issuer := *svc.issuer
-	crt, err := issuer.IssueEndEntityCrt(csr)
+	var crt tls.Crt
+	if vi, ok := issuer.(tls.ValidityIssuer); ok && svc.validityFor != nil {
+		crt, err = vi.IssueEndEntityCrtWithValidity(csr, svc.workloadValidity(tokIdentity))
+	} else {
+		crt, err = issuer.IssueEndEntityCrt(csr)
+	}
 	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, nil, status.Error(codes.Internal, err.Error())
 	}
 	crts := crt.ExtractRaw()
 	if len(crts) == 0 {
@@ -221,11 +314,10 @@ func (svc *Service) Certify(ctx context.Context, req *pb.CertifyRequest) (*pb.Ce
 	}
 
 	// Bundle issuer crt with certificate so the trust path to the root can be verified.
-	log.Infof("certifying %s until %s", tokIdentity, crt.Certificate.NotAfter)
 	validUntil, err := ptypes.TimestampProto(crt.Certificate.NotAfter)
 	if err != nil {
 		log.Errorf("invalid expiry time: %s", err)
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, nil, status.Error(codes.Internal, err.Error())
 	}
 
 	rsp := &pb.CertifyResponse{
@@ -234,7 +326,22 @@ func (svc *Service) Certify(ctx context.Context, req *pb.CertifyRequest) (*pb.Ce
 
 		ValidUntil: validUntil,
 	}
-	return rsp, nil
+	return rsp, crt.Certificate, nil
+}
+
+// workloadValidity returns the validity of the certificates issued for a
+// proxy identity, falling back to the default one if it can't be resolved
+func (svc *Service) workloadValidity(identity string) tls.Validity {
+	ns, sa := identityLabels(identity)
+	if ns == "" {
+		return *svc.validity
+	}
+	validity, err := svc.validityFor(ns, sa)
+	if err != nil {
+		log.Warnf("Using the default certificate lifetime for %s: %s", identity, err)
+		return *svc.validity
+	}
+	return validity
 }
 
 func checkRequest(req *pb.CertifyRequest) (string, []byte, *x509.CertificateRequest, error) {
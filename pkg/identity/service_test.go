@@ -2,11 +2,15 @@ package identity
 
 import (
 	"context"
+	"crypto/rand"
 	"crypto/x509"
 	"testing"
+	"time"
 
+	"github.com/golang/protobuf/ptypes"
 	pb "github.com/linkerd/linkerd2-proxy-api/go/identity"
 	"github.com/linkerd/linkerd2/pkg/tls"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 )
 
 type fakeValidator struct {
@@ -29,7 +33,7 @@ func (fk *fakeValidator) Validate(context.Context, []byte) (string, error) {
 
 func TestServiceNotReady(t *testing.T) {
 	//ch := make(chan tls.Issuer, 1)
-	svc := NewService(&fakeValidator{"successful-result", nil}, nil, nil, nil, "", "", "")
+	svc := NewService(&fakeValidator{"successful-result", nil}, nil, nil, nil, "", "", "", nil, nil)
 	req := &pb.CertifyRequest{
 		Identity:                  "some-identity",
 		Token:                     []byte{},
@@ -49,8 +53,30 @@ func TestServiceNotReady(t *testing.T) {
 	}
 }
 
+func TestServiceReady(t *testing.T) {
+	root, err := tls.GenerateRootCAWithDefaults("identity.linkerd.cluster.local")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	svc := NewService(&fakeValidator{"successful-result", nil}, nil, nil, nil, "", "", "", nil, nil)
+
+	select {
+	case <-svc.Ready():
+		t.Fatalf("Expected the service not to be ready without an issuer")
+	default:
+	}
+
+	svc.updateIssuer(root)
+	svc.updateIssuer(root)
+	select {
+	case <-svc.Ready():
+	default:
+		t.Fatalf("Expected the service to be ready once the issuer is loaded")
+	}
+}
+
 func TestInvalidRequestArguments(t *testing.T) {
-	svc := NewService(&fakeValidator{"successful-result", nil}, nil, nil, nil, "", "", "")
+	svc := NewService(&fakeValidator{"successful-result", nil}, nil, nil, nil, "", "", "", nil, nil)
 	svc.updateIssuer(&fakeIssuer{tls.Crt{}, nil})
 	fakeData := "fake-data"
 	invalidCsr := func() *pb.CertifyRequest {
@@ -97,3 +123,70 @@ func TestInvalidRequestArguments(t *testing.T) {
 	}
 
 }
+
+func TestCertifyMetrics(t *testing.T) {
+	svc := NewService(&fakeValidator{"successful-result", nil}, nil, nil, nil, "", "", "", nil, nil)
+	unavailable := certifyRequests.WithLabelValues("Unavailable")
+	before := testutil.ToFloat64(unavailable)
+
+	if _, err := svc.Certify(context.TODO(), &pb.CertifyRequest{}); err == nil {
+		t.Fatal("Expected error but got nothing")
+	}
+
+	if after := testutil.ToFloat64(unavailable); after != before+1 {
+		t.Fatalf("Expected %v Unavailable requests, got %v", before+1, after)
+	}
+}
+
+func TestIdentityLabels(t *testing.T) {
+	ns, sa := identityLabels("web.emojivoto.serviceaccount.identity.linkerd.cluster.local")
+	if ns != "emojivoto" || sa != "web" {
+		t.Fatalf("Expected emojivoto/web, got %s/%s", ns, sa)
+	}
+
+	ns, sa = identityLabels("some-identity")
+	if ns != "" || sa != "" {
+		t.Fatalf("Expected no labels, got %s/%s", ns, sa)
+	}
+}
+
+func TestWorkloadLifetime(t *testing.T) {
+	root, err := tls.GenerateRootCAWithDefaults("identity.linkerd.cluster.local")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	identity := "foo.ns.serviceaccount.identity.linkerd.cluster.local"
+	validityFor := func(namespace, serviceAccount string) (tls.Validity, error) {
+		if namespace != "ns" || serviceAccount != "foo" {
+			t.Fatalf("Unexpected service account %s/%s", namespace, serviceAccount)
+		}
+		return tls.Validity{Lifetime: time.Hour}, nil
+	}
+	svc := NewService(&fakeValidator{identity, nil}, root.Cred.CertPool(), &tls.Validity{}, nil, "identity.linkerd.cluster.local", "", "", nil, validityFor)
+	svc.updateIssuer(root)
+
+	key, err := tls.GenerateKey()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{DNSNames: []string{identity}}, key)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	rsp, err := svc.Certify(context.Background(), &pb.CertifyRequest{
+		Identity:                  identity,
+		Token:                     []byte("token"),
+		CertificateSigningRequest: csr,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	validUntil, err := ptypes.Timestamp(rsp.GetValidUntil())
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if lifetime := time.Until(validUntil); lifetime > time.Hour+tls.DefaultClockSkewAllowance {
+		t.Fatalf("Expected a certificate valid for an hour, got %s", lifetime)
+	}
+}
@@ -0,0 +1,216 @@
+package identity
+
+import (
+	"context"
+	"crypto/rand"
+	cryptotls "crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"time"
+
+	svidpb "github.com/linkerd/linkerd2/controller/gen/spire/svid"
+	"github.com/linkerd/linkerd2/pkg/tls"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	// SpireSVIDFileName is the name of the file holding the PEM-encoded X509-SVID
+	// of the identity service, followed by its intermediates
+	SpireSVIDFileName = "svid.pem"
+
+	// SpireSVIDKeyFileName is the name of the file holding the PEM-encoded
+	// private key of the X509-SVID of the identity service
+	SpireSVIDKeyFileName = "svid_key.pem"
+
+	// SpireBundleFileName is the name of the file holding the PEM-encoded X.509
+	// authorities of the SPIRE trust domain
+	SpireBundleFileName = "bundle.pem"
+
+	// spireRetryInterval is how long to wait before asking the SPIRE server
+	// for a new issuer certificate after a failure
+	spireRetryInterval = 30 * time.Second
+
+	// spireMinRefreshInterval is the minimum delay before renewing the
+	// issuer certificate
+	spireMinRefreshInterval = 30 * time.Second
+
+	eventTypeSpireFailed = "IssuerSpireUpdateFailed"
+)
+
+// SpireUpstream obtains the issuer certificate of the identity service from a
+// SPIRE server, the identity service being registered there as a downstream
+// workload. The certificates issued to proxies then chain to the SPIRE trust
+// domain and carry a SPIFFE ID, so they're trusted by non-mesh SPIFFE
+// workloads.
+//
+// The identity service authenticates to the SPIRE server with its own
+// X509-SVID, which is read from a directory kept up to date by a SPIRE agent
+// helper, such as spiffe-helper.
+type SpireUpstream struct {
+	client svidpb.SVIDClient
+}
+
+// spireIssuer is an issuer obtained from a SPIRE server, along with the
+// SPIFFE trust domain of its certificate
+type spireIssuer struct {
+	ca          *tls.CA
+	trustDomain string
+}
+
+// NewSpireUpstream creates a SpireUpstream for the SPIRE server at addr, with
+// the credentials of the identity service in credsDir
+func NewSpireUpstream(addr, credsDir string) (*SpireUpstream, error) {
+	config := &cryptotls.Config{
+		GetClientCertificate: func(*cryptotls.CertificateRequestInfo) (*cryptotls.Certificate, error) {
+			crt, err := cryptotls.LoadX509KeyPair(
+				filepath.Join(credsDir, SpireSVIDFileName),
+				filepath.Join(credsDir, SpireSVIDKeyFileName),
+			)
+			return &crt, err
+		},
+		// SPIRE server certificates only have a SPIFFE ID, so the peer is
+		// verified against the bundle without checking the hostname.
+		InsecureSkipVerify: true,
+		VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			return verifySpireServer(rawCerts, filepath.Join(credsDir, SpireBundleFileName))
+		},
+	}
+
+	conn, err := grpc.Dial(addr, grpc.WithTransportCredentials(credentials.NewTLS(config)))
+	if err != nil {
+		return nil, err
+	}
+
+	return &SpireUpstream{client: svidpb.NewSVIDClient(conn)}, nil
+}
+
+func verifySpireServer(rawCerts [][]byte, bundlePath string) error {
+	bundle, err := ioutil.ReadFile(bundlePath)
+	if err != nil {
+		return fmt.Errorf("failed to read the SPIRE bundle: %s", err)
+	}
+	roots, err := tls.DecodePEMCertPool(string(bundle))
+	if err != nil {
+		return fmt.Errorf("failed to decode the SPIRE bundle: %s", err)
+	}
+	if len(rawCerts) == 0 {
+		return errors.New("the SPIRE server didn't present any certificate")
+	}
+
+	certs := make([]*x509.Certificate, len(rawCerts))
+	for i, raw := range rawCerts {
+		if certs[i], err = x509.ParseCertificate(raw); err != nil {
+			return err
+		}
+	}
+	intermediates := tls.CertificatesToPool(certs[1:])
+	_, err = certs[0].Verify(x509.VerifyOptions{Roots: roots, Intermediates: intermediates})
+	return err
+}
+
+// fetchIssuer generates a new key and has the SPIRE server sign a CA
+// certificate for it
+func (s *SpireUpstream) fetchIssuer(ctx context.Context, validity tls.Validity) (*spireIssuer, error) {
+	key, err := tls.GenerateKey()
+	if err != nil {
+		return nil, err
+	}
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{}, key)
+	if err != nil {
+		return nil, err
+	}
+
+	rsp, err := s.client.NewDownstreamX509CA(ctx, &svidpb.NewDownstreamX509CARequest{Csr: csr})
+	if err != nil {
+		return nil, err
+	}
+	if len(rsp.GetCaCertChain()) == 0 {
+		return nil, errors.New("the SPIRE server returned no CA certificate")
+	}
+
+	chain := make([]*x509.Certificate, len(rsp.GetCaCertChain()))
+	for i, raw := range rsp.GetCaCertChain() {
+		if chain[i], err = x509.ParseCertificate(raw); err != nil {
+			return nil, fmt.Errorf("invalid CA certificate: %s", err)
+		}
+	}
+	if len(chain[0].URIs) != 1 || chain[0].URIs[0].Scheme != "spiffe" {
+		return nil, errors.New("the CA certificate doesn't have a SPIFFE ID")
+	}
+
+	keyPEM, err := tls.EncodePrivateKeyPEM(key)
+	if err != nil {
+		return nil, err
+	}
+	cred, err := tls.ValidateAndCreateCreds(tls.EncodeCertificatesPEM(chain...), string(keyPEM))
+	if err != nil {
+		return nil, err
+	}
+
+	return &spireIssuer{
+		ca:          tls.NewCA(*cred, validity),
+		trustDomain: chain[0].URIs[0].Host,
+	}, nil
+}
+
+// spiffeID returns the SPIFFE ID of a proxy identity
+// (<sa>.<ns>.serviceaccount.identity.<controller-ns>.<trust-domain>) in the
+// given SPIFFE trust domain
+func spiffeID(trustDomain, identity string) (*url.URL, error) {
+	parts := strings.SplitN(identity, ".", 3)
+	if len(parts) != 3 || !strings.HasPrefix(parts[2], "serviceaccount.identity.") {
+		return nil, fmt.Errorf("unexpected identity format: %s", identity)
+	}
+	return &url.URL{
+		Scheme: "spiffe",
+		Host:   trustDomain,
+		Path:   fmt.Sprintf("/ns/%s/sa/%s", parts[1], parts[0]),
+	}, nil
+}
+
+// RunSpire obtains the issuer certificate from the SPIRE server, and renews
+// it when half of its lifetime has elapsed, until ctx is done
+func (svc *Service) RunSpire(ctx context.Context, upstream *SpireUpstream) {
+	for {
+		wait := spireRetryInterval
+		issuer, err := upstream.fetchIssuer(ctx, *svc.validity)
+		if err == nil {
+			err = issuer.ca.Cred.Verify(svc.trustAnchors, "", time.Time{})
+		}
+		if err != nil {
+			message := fmt.Sprintf("Failed to obtain the issuer certificate from the SPIRE server: %s", err)
+			log.Warn(message)
+			svc.recordEvent(v1.EventTypeWarning, eventTypeSpireFailed, message)
+		} else {
+			svc.updateSpireIssuer(issuer)
+			expiry := issuer.ca.Cred.Certificate.NotAfter
+			log.Infof("Updated identity issuer from the SPIRE server, valid until %s", expiry)
+			if wait = time.Until(expiry) / 2; wait < spireMinRefreshInterval {
+				wait = spireMinRefreshInterval
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+	}
+}
+
+func (svc *Service) updateSpireIssuer(issuer *spireIssuer) {
+	svc.issuerMutex.Lock()
+	var newIssuer tls.Issuer = issuer.ca
+	svc.issuer = &newIssuer
+	svc.spiffeTrustDomain = issuer.trustDomain
+	svc.issuerMutex.Unlock()
+	svc.markReady()
+}
@@ -0,0 +1,135 @@
+package identity
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/x509"
+	"math/big"
+	"net/url"
+	"testing"
+	"time"
+
+	pb "github.com/linkerd/linkerd2-proxy-api/go/identity"
+	svidpb "github.com/linkerd/linkerd2/controller/gen/spire/svid"
+	"github.com/linkerd/linkerd2/pkg/tls"
+	"google.golang.org/grpc"
+)
+
+// fakeSVIDClient signs downstream CA certificates with a root CA, like a
+// SPIRE server for the example.org trust domain
+type fakeSVIDClient struct {
+	root *tls.CA
+}
+
+func (c *fakeSVIDClient) NewDownstreamX509CA(ctx context.Context, req *svidpb.NewDownstreamX509CARequest, opts ...grpc.CallOption) (*svidpb.NewDownstreamX509CAResponse, error) {
+	csr, err := x509.ParseCertificateRequest(req.GetCsr())
+	if err != nil {
+		return nil, err
+	}
+	crt, err := c.root.Cred.SignCrt(&x509.Certificate{
+		SerialNumber:          big.NewInt(2),
+		NotBefore:             time.Now().Add(-time.Minute),
+		NotAfter:              time.Now().Add(time.Hour),
+		PublicKey:             csr.PublicKey.(*ecdsa.PublicKey),
+		URIs:                  []*url.URL{{Scheme: "spiffe", Host: "example.org"}},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &svidpb.NewDownstreamX509CAResponse{
+		CaCertChain:     [][]byte{crt.Certificate.Raw},
+		X509Authorities: [][]byte{c.root.Cred.Certificate.Raw},
+	}, nil
+}
+
+func TestSpireIssuer(t *testing.T) {
+	root, err := tls.GenerateRootCAWithDefaults("spire-root")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	upstream := &SpireUpstream{client: &fakeSVIDClient{root}}
+
+	issuer, err := upstream.fetchIssuer(context.Background(), tls.Validity{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if issuer.trustDomain != "example.org" {
+		t.Fatalf("Expected trust domain example.org, got %s", issuer.trustDomain)
+	}
+
+	identity := "foo.ns.serviceaccount.identity.linkerd.cluster.local"
+	svc := NewService(&fakeValidator{identity, nil}, root.Cred.CertPool(), &tls.Validity{}, nil, "", "", "", nil, nil)
+	svc.updateSpireIssuer(issuer)
+
+	key, err := tls.GenerateKey()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{DNSNames: []string{identity}}, key)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	rsp, err := svc.Certify(context.Background(), &pb.CertifyRequest{
+		Identity:                  identity,
+		Token:                     []byte("token"),
+		CertificateSigningRequest: csr,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	leaf, err := x509.ParseCertificate(rsp.GetLeafCertificate())
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	expectedID := "spiffe://example.org/ns/ns/sa/foo"
+	if len(leaf.URIs) != 1 || leaf.URIs[0].String() != expectedID {
+		t.Fatalf("Expected the SPIFFE ID %s, got %v", expectedID, leaf.URIs)
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, raw := range rsp.GetIntermediateCertificates() {
+		crt, err := x509.ParseCertificate(raw)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		intermediates.AddCert(crt)
+	}
+	_, err = leaf.Verify(x509.VerifyOptions{Roots: root.Cred.CertPool(), Intermediates: intermediates, DNSName: identity})
+	if err != nil {
+		t.Fatalf("Expected the certificate to chain to the SPIRE root: %s", err)
+	}
+}
+
+func TestSpiffeID(t *testing.T) {
+	testCases := []struct {
+		identity string
+		expected string
+	}{
+		{"foo.ns.serviceaccount.identity.linkerd.cluster.local", "spiffe://example.org/ns/ns/sa/foo"},
+		{"default.emojivoto.serviceaccount.identity.linkerd.cluster.local", "spiffe://example.org/ns/emojivoto/sa/default"},
+		{"identity.linkerd.cluster.local", ""},
+	}
+	for _, tc := range testCases {
+		tc := tc // pin
+		id, err := spiffeID("example.org", tc.identity)
+		if tc.expected == "" {
+			if err == nil {
+				t.Errorf("Expected an error for %s, got %s", tc.identity, id)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Unexpected error for %s: %s", tc.identity, err)
+			continue
+		}
+		if id.String() != tc.expected {
+			t.Errorf("Expected %s for %s, got %s", tc.expected, tc.identity, id)
+		}
+	}
+}
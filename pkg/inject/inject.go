@@ -25,7 +25,9 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	k8sResource "k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/helm/pkg/chartutil"
 	"sigs.k8s.io/yaml"
 )
@@ -50,6 +52,8 @@ var (
 		k8s.ProxyDestinationGetNetworks,
 		k8s.ProxyDisableTapAnnotation,
 		k8s.ProxyEnableDebugAnnotation,
+		k8s.ProxyJobShutdownAnnotation,
+		k8s.ProxyEnableNativeSidecarAnnotation,
 		k8s.ProxyEnableExternalProfilesAnnotation,
 		k8s.ProxyImagePullPolicyAnnotation,
 		k8s.ProxyInboundPortAnnotation,
@@ -62,17 +66,31 @@ var (
 		k8s.ProxyLogLevelAnnotation,
 		k8s.ProxyMemoryLimitAnnotation,
 		k8s.ProxyMemoryRequestAnnotation,
+		k8s.ProxyResourcesProfileAnnotation,
 		k8s.ProxyUIDAnnotation,
 		k8s.ProxyVersionOverrideAnnotation,
 		k8s.ProxyRequireIdentityOnInboundPortsAnnotation,
 		k8s.ProxyIgnoreInboundPortsAnnotation,
 		k8s.ProxyIgnoreOutboundPortsAnnotation,
+		k8s.ProxyOpaquePortsAnnotation,
 		k8s.ProxyTraceCollectorSvcAddrAnnotation,
 		k8s.ProxyOutboundConnectTimeout,
 		k8s.ProxyInboundConnectTimeout,
+		k8s.ProxyOutboundConnectBackoffMin,
+		k8s.ProxyOutboundConnectBackoffMax,
+		k8s.ProxyOutboundConnectBackoffJitter,
+		k8s.ProxyEnvAnnotation,
 	}
 )
 
+// DefaultPodTemplatePaths maps the lowercased kinds of the custom resources
+// that are injectable out of the box to the JSON pointers of their pod
+// templates
+var DefaultPodTemplatePaths = map[string]string{
+	// Argo Rollouts
+	"rollout": "/spec/template",
+}
+
 // Origin defines where the input YAML comes from. Refer the ResourceConfig's
 // 'origin' field
 type Origin int
@@ -95,12 +113,23 @@ const (
 // kind and name
 type OwnerRetrieverFunc func(*corev1.Pod) (string, string)
 
+// CNIDetectorFunc is a function that returns true if the linkerd CNI plugin is
+// active on all the nodes matching a pod's nodeSelector and required node
+// affinity
+type CNIDetectorFunc func(spec *corev1.PodSpec) bool
+
 // ResourceConfig contains the parsed information for a given workload
 type ResourceConfig struct {
 	configs        *config.All
 	nsAnnotations  map[string]string
 	ownerRetriever OwnerRetrieverFunc
+	cniDetector    CNIDetectorFunc
 	origin         Origin
+	nativeSidecars bool
+
+	// podTemplatePaths maps the lowercased kinds of the injectable custom
+	// resources to the JSON pointers of their pod templates
+	podTemplatePaths map[string]string
 
 	workload struct {
 		obj      runtime.Object
@@ -132,6 +161,9 @@ type patch struct {
 	AddRootVolumes        bool                      `json:"addRootVolumes"`
 	Labels                map[string]string         `json:"labels"`
 	DebugContainer        *l5dcharts.DebugContainer `json:"debugContainer"`
+
+	ProxyShutdown bool `json:"proxyShutdown"`
+	NativeSidecar bool `json:"nativeSidecar"`
 }
 
 // NewResourceConfig creates and initializes a ResourceConfig
@@ -144,6 +176,10 @@ func NewResourceConfig(configs *config.All, origin Origin) *ResourceConfig {
 	config.pod.meta = &metav1.ObjectMeta{}
 	config.pod.labels = map[string]string{k8s.ControllerNSLabel: configs.GetGlobal().GetLinkerdNamespace()}
 	config.pod.annotations = map[string]string{}
+	config.podTemplatePaths = map[string]string{}
+	for kind, path := range DefaultPodTemplatePaths {
+		config.podTemplatePaths[kind] = path
+	}
 	return config
 }
 
@@ -167,11 +203,48 @@ func (conf *ResourceConfig) WithOwnerRetriever(f OwnerRetrieverFunc) *ResourceCo
 	return conf
 }
 
+// WithNativeSidecars enriches ResourceConfig with whether the cluster runs
+// native sidecar containers, in which case the proxy is added as one if the
+// pod or its namespace have the ProxyEnableNativeSidecarAnnotation
+func (conf *ResourceConfig) WithNativeSidecars(supported bool) *ResourceConfig {
+	conf.nativeSidecars = supported
+	return conf
+}
+
+// WithCNIDetector enriches ResourceConfig with a function detecting whether
+// the linkerd CNI plugin is active on all the nodes matching the nodeSelector
+// and required node affinity of the pod, in which case the init container is
+// omitted even if the CNI isn't enabled globally
+func (conf *ResourceConfig) WithCNIDetector(f CNIDetectorFunc) *ResourceConfig {
+	conf.cniDetector = f
+	return conf
+}
+
+// WithPodTemplatePaths enriches ResourceConfig with the JSON pointers of the
+// pod templates of custom resources, keyed by kind, in addition to the
+// DefaultPodTemplatePaths
+func (conf *ResourceConfig) WithPodTemplatePaths(paths map[string]string) *ResourceConfig {
+	for kind, path := range paths {
+		conf.podTemplatePaths[strings.ToLower(kind)] = path
+	}
+	return conf
+}
+
 // GetOwnerRef returns a reference to the resource's owner resource, if any
 func (conf *ResourceConfig) GetOwnerRef() *metav1.OwnerReference {
 	return conf.workload.ownerRef
 }
 
+// GetPodTemplate returns the pod template of the workload in conf, holding the
+// metadata and spec of the pod itself if the workload is a pod, or nil if it
+// has none
+func (conf *ResourceConfig) GetPodTemplate() *corev1.PodTemplateSpec {
+	if conf.pod.spec == nil {
+		return nil
+	}
+	return &corev1.PodTemplateSpec{ObjectMeta: *conf.pod.meta, Spec: *conf.pod.spec}
+}
+
 // AppendPodAnnotations appends the given annotations to the pod spec in conf
 func (conf *ResourceConfig) AppendPodAnnotations(annotations map[string]string) {
 	for annotation, value := range annotations {
@@ -233,13 +306,7 @@ func (conf *ResourceConfig) GetPatch(injectProxy bool) ([]byte, error) {
 		Annotations: map[string]string{},
 		Labels:      map[string]string{},
 	}
-	switch strings.ToLower(conf.workload.metaType.Kind) {
-	case k8s.Pod:
-	case k8s.CronJob:
-		values.PathPrefix = "/spec/jobTemplate/spec/template"
-	default:
-		values.PathPrefix = "/spec/template"
-	}
+	values.PathPrefix = conf.podPathPrefix()
 
 	if conf.pod.spec != nil {
 		conf.injectPodAnnotations(values)
@@ -278,6 +345,22 @@ func (conf *ResourceConfig) GetPatch(injectProxy bool) ([]byte, error) {
 	return res, nil
 }
 
+// podPathPrefix returns the JSON pointer to the pod template of the workload,
+// or the empty string if it's a pod
+func (conf *ResourceConfig) podPathPrefix() string {
+	if _, ok := conf.workload.obj.(*unstructured.Unstructured); ok {
+		return conf.podTemplatePaths[strings.ToLower(conf.workload.metaType.Kind)]
+	}
+	switch strings.ToLower(conf.workload.metaType.Kind) {
+	case k8s.Pod:
+		return ""
+	case k8s.CronJob:
+		return "/spec/jobTemplate/spec/template"
+	default:
+		return "/spec/template"
+	}
+}
+
 // Note this switch also defines what kinds are injectable
 func (conf *ResourceConfig) getFreshWorkloadObj() runtime.Object {
 	switch strings.ToLower(conf.workload.metaType.Kind) {
@@ -301,6 +384,9 @@ func (conf *ResourceConfig) getFreshWorkloadObj() runtime.Object {
 		return &batchv1beta1.CronJob{}
 	}
 
+	if _, ok := conf.podTemplatePaths[strings.ToLower(conf.workload.metaType.Kind)]; ok {
+		return &unstructured.Unstructured{}
+	}
 	return nil
 }
 
@@ -469,6 +555,25 @@ func (conf *ResourceConfig) parse(bytes []byte) error {
 			}
 		}
 		conf.pod.labels[k8s.WorkloadNamespaceLabel] = v.Namespace
+
+	case *unstructured.Unstructured:
+		if err := yaml.Unmarshal(bytes, v); err != nil {
+			return err
+		}
+		if err := yaml.Unmarshal(bytes, &conf.workload); err != nil {
+			return err
+		}
+
+		conf.workload.obj = v
+		conf.pod.labels[k8s.WorkloadNamespaceLabel] = v.GetNamespace()
+		template, err := podTemplateAt(v, conf.podPathPrefix())
+		if err != nil {
+			return err
+		}
+		if template != nil {
+			conf.complete(template)
+		}
+
 	default:
 		// unmarshal the metadata of other resource kinds like namespace, secret,
 		// config map etc. to be used in the report struct
@@ -484,6 +589,28 @@ func (conf *ResourceConfig) parse(bytes []byte) error {
 	return nil
 }
 
+// podTemplateAt returns the pod template at the JSON pointer path of the custom
+// resource obj, or nil if it has none
+func podTemplateAt(obj *unstructured.Unstructured, path string) (*corev1.PodTemplateSpec, error) {
+	var fields []string
+	for _, token := range strings.Split(strings.TrimPrefix(path, "/"), "/") {
+		fields = append(fields, strings.NewReplacer("~1", "/", "~0", "~").Replace(token))
+	}
+	m, found, err := unstructured.NestedMap(obj.Object, fields...)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pod template at %s in %s/%s: %s", path, strings.ToLower(obj.GetKind()), obj.GetName(), err)
+	}
+	if !found {
+		return nil, nil
+	}
+
+	var template corev1.PodTemplateSpec
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(m, &template); err != nil {
+		return nil, fmt.Errorf("invalid pod template at %s in %s/%s: %s", path, strings.ToLower(obj.GetKind()), obj.GetName(), err)
+	}
+	return &template, nil
+}
+
 func (conf *ResourceConfig) complete(template *corev1.PodTemplateSpec) {
 	conf.pod.spec = &template.Spec
 	conf.pod.meta = &template.ObjectMeta
@@ -513,12 +640,17 @@ func (conf *ResourceConfig) injectPodSpec(values *patch) {
 		WaitBeforeExitSeconds:         conf.proxyWaitBeforeExitSeconds(),
 		IsGateway:                     conf.isGateway(),
 		RequireIdentityOnInboundPorts: conf.requireIdentityOnInboundPorts(),
+		OpaquePorts:                   conf.opaquePorts(),
 		DestinationGetNetworks:        conf.destinationGetNetworks(),
 		OutboundConnectTimeout:        conf.getOutboundConnectTimeout(),
 		InboundConnectTimeout:         conf.getInboundConnectTimeout(),
+		OutboundConnectBackoffMin:     conf.getOutboundConnectBackoffMin(),
+		OutboundConnectBackoffMax:     conf.getOutboundConnectBackoffMax(),
+		OutboundConnectBackoffJitter:  conf.getOutboundConnectBackoffJitter(),
+		AdditionalEnv:                 conf.proxyAdditionalEnv(),
 	}
 
-	if v := conf.pod.meta.Annotations[k8s.ProxyEnableDebugAnnotation]; v != "" {
+	if v := conf.getOverride(k8s.ProxyEnableDebugAnnotation); v != "" {
 		debug, err := strconv.ParseBool(v)
 		if err != nil {
 			log.Warnf("unrecognized value used for the %s annotation: %s", k8s.ProxyEnableDebugAnnotation, v)
@@ -537,6 +669,10 @@ func (conf *ResourceConfig) injectPodSpec(values *patch) {
 		}
 	}
 
+	values.NativeSidecar = conf.nativeSidecar()
+
+	values.ProxyShutdown = !values.NativeSidecar && conf.proxyJobShutdown()
+
 	saVolumeMount := conf.serviceAccountVolumeMount()
 
 	// use the primary container's capabilities to ensure psp compliance, if
@@ -564,11 +700,12 @@ func (conf *ResourceConfig) injectPodSpec(values *patch) {
 		}
 	}
 
-	if !conf.configs.GetGlobal().GetCniEnabled() {
+	if !conf.cniActive() {
 		conf.injectProxyInit(values)
 	}
 
 	values.AddRootVolumes = len(conf.pod.spec.Volumes) == 0
+	values.AddRootInitContainers = len(conf.pod.spec.InitContainers) == 0
 
 	values.Global.Proxy.Trace = &l5dcharts.Trace{}
 	if trace := conf.trace(); trace != nil {
@@ -587,6 +724,16 @@ func (conf *ResourceConfig) injectPodSpec(values *patch) {
 
 }
 
+// cniActive returns true if the linkerd CNI plugin sets up the iptables rules
+// of the pod instead of the init container, either because it's enabled
+// globally or because it's detected on all the nodes the pod can run on
+func (conf *ResourceConfig) cniActive() bool {
+	if conf.configs.GetGlobal().GetCniEnabled() {
+		return true
+	}
+	return conf.cniDetector != nil && conf.cniDetector(conf.pod.spec)
+}
+
 func (conf *ResourceConfig) injectProxyInit(values *patch) {
 	values.Global.ProxyInit = &l5dcharts.ProxyInit{
 		Image: &l5dcharts.Image{
@@ -623,8 +770,6 @@ func (conf *ResourceConfig) injectProxyInit(values *patch) {
 		}
 	}
 
-	values.AddRootInitContainers = len(conf.pod.spec.InitContainers) == 0
-
 }
 
 func (conf *ResourceConfig) serviceAccountVolumeMount() *corev1.VolumeMount {
@@ -835,6 +980,10 @@ func (conf *ResourceConfig) requireIdentityOnInboundPorts() string {
 	return conf.getOverride(k8s.ProxyRequireIdentityOnInboundPortsAnnotation)
 }
 
+func (conf *ResourceConfig) opaquePorts() string {
+	return conf.getOverride(k8s.ProxyOpaquePortsAnnotation)
+}
+
 func (conf *ResourceConfig) destinationGetNetworks() string {
 	if podOverride, hasPodOverride := conf.pod.meta.Annotations[k8s.ProxyDestinationGetNetworks]; hasPodOverride {
 		return podOverride
@@ -891,6 +1040,75 @@ func (conf *ResourceConfig) getInboundConnectTimeout() string {
 	return conf.configs.GetProxy().InboundConnectTimeout
 }
 
+func (conf *ResourceConfig) getOutboundConnectBackoffMin() string {
+	if override := conf.getDurationOverride(k8s.ProxyOutboundConnectBackoffMin); override != "" {
+		return override
+	}
+	return conf.configs.GetProxy().GetOutboundConnectBackoffMin()
+}
+
+func (conf *ResourceConfig) getOutboundConnectBackoffMax() string {
+	if override := conf.getDurationOverride(k8s.ProxyOutboundConnectBackoffMax); override != "" {
+		return override
+	}
+	return conf.configs.GetProxy().GetOutboundConnectBackoffMax()
+}
+
+func (conf *ResourceConfig) getOutboundConnectBackoffJitter() string {
+	if override := conf.getOverride(k8s.ProxyOutboundConnectBackoffJitter); override != "" {
+		if jitter, err := strconv.ParseFloat(override, 64); err != nil || jitter < 0 || jitter > 1 {
+			log.Warnf("unrecognized value used for the %s annotation, must be a ratio between 0 and 1: %s", k8s.ProxyOutboundConnectBackoffJitter, override)
+		} else {
+			return override
+		}
+	}
+	return conf.configs.GetProxy().GetOutboundConnectBackoffJitter()
+}
+
+// proxyAdditionalEnv returns the extra environment variables of the proxy
+// set by the ProxyEnvAnnotation, a YAML or JSON map of names to values, sorted
+// by name. Invalid names are logged and ignored.
+func (conf *ResourceConfig) proxyAdditionalEnv() []l5dcharts.EnvVar {
+	override := conf.getOverride(k8s.ProxyEnvAnnotation)
+	if override == "" {
+		return nil
+	}
+
+	var vars map[string]string
+	if err := yaml.Unmarshal([]byte(override), &vars); err != nil {
+		log.Warnf("unrecognized value used for the %s annotation, expected a map of names to values: %s", k8s.ProxyEnvAnnotation, err)
+		return nil
+	}
+
+	var env []l5dcharts.EnvVar
+	for name, value := range vars {
+		if len(validation.IsEnvVarName(name)) != 0 {
+			log.Warnf("unrecognized environment variable name used for the %s annotation: %s", k8s.ProxyEnvAnnotation, name)
+			continue
+		}
+		env = append(env, l5dcharts.EnvVar{Name: name, Value: value})
+	}
+	sort.Slice(env, func(i, j int) bool { return env[i].Name < env[j].Name })
+	return env
+}
+
+// getDurationOverride returns the pod or namespace override for the given
+// annotation, formatted in milliseconds as expected by the proxy. Invalid
+// durations are logged and ignored.
+func (conf *ResourceConfig) getDurationOverride(annotation string) string {
+	override := conf.getOverride(annotation)
+	if override == "" {
+		return ""
+	}
+
+	duration, err := time.ParseDuration(override)
+	if err != nil {
+		log.Warnf("unrecognized duration value used for the %s annotation: %s", annotation, err)
+		return ""
+	}
+	return fmt.Sprintf("%dms", int(duration.Seconds()*1000))
+}
+
 func (conf *ResourceConfig) isGateway() bool {
 	if override := conf.getOverride(k8s.ProxyEnableGatewayAnnotation); override != "" {
 		value, err := strconv.ParseBool(override)
@@ -914,65 +1132,99 @@ func (conf *ResourceConfig) proxyWaitBeforeExitSeconds() uint64 {
 	return 0
 }
 
-func (conf *ResourceConfig) proxyResourceRequirements() *l5dcharts.Resources {
-	var (
-		requestCPU    k8sResource.Quantity
-		requestMemory k8sResource.Quantity
-		limitCPU      k8sResource.Quantity
-		limitMemory   k8sResource.Quantity
-		err           error
-	)
-	res := &l5dcharts.Resources{}
-
-	if override := conf.getOverride(k8s.ProxyCPURequestAnnotation); override != "" {
-		requestCPU, err = k8sResource.ParseQuantity(override)
-	} else if defaultRequest := conf.configs.GetProxy().GetResource().GetRequestCpu(); defaultRequest != "" {
-		requestCPU, err = k8sResource.ParseQuantity(defaultRequest)
-	}
+// nativeSidecar returns true if the proxy is to be added as a native sidecar
+// container, which Kubernetes starts before the other containers and stops
+// once they have exited. The vendored Kubernetes types have no restartPolicy
+// for containers, so this is only supported by the proxy-injector, whose
+// patches are applied by the API server.
+func (conf *ResourceConfig) nativeSidecar() bool {
+	override := conf.getOverride(k8s.ProxyEnableNativeSidecarAnnotation)
+	if override == "" {
+		return false
+	}
+	value, err := strconv.ParseBool(override)
 	if err != nil {
-		log.Warnf("%s (%s)", err, k8s.ProxyCPURequestAnnotation)
+		log.Warnf("unrecognized value used for the %s annotation: %s", k8s.ProxyEnableNativeSidecarAnnotation, override)
+		return false
 	}
-	if !requestCPU.IsZero() {
-		res.CPU.Request = requestCPU.String()
+	if value && !conf.nativeSidecars {
+		log.Warnf("ignoring the %s annotation: native sidecar containers require the proxy-injector and Kubernetes 1.28+", k8s.ProxyEnableNativeSidecarAnnotation)
+		return false
 	}
+	return value
+}
 
-	if override := conf.getOverride(k8s.ProxyMemoryRequestAnnotation); override != "" {
-		requestMemory, err = k8sResource.ParseQuantity(override)
-	} else if defaultRequest := conf.configs.GetProxy().GetResource().GetRequestMemory(); defaultRequest != "" {
-		requestMemory, err = k8sResource.ParseQuantity(defaultRequest)
+// proxyJobShutdown returns true if the pod belongs to a Job or a CronJob,
+// whose pods only complete once all their containers have exited, including
+// the proxy. The proxy is then shut down by the proxy-shutdown container once
+// the other containers have exited, unless disabled with the
+// ProxyJobShutdownAnnotation. Pods restarted OnFailure are left out: the
+// exit of a failed container can't be told apart from its completion, and
+// the proxy would be shut down before the container restarts.
+func (conf *ResourceConfig) proxyJobShutdown() bool {
+	if conf.pod.labels[k8s.ProxyJobLabel] == "" && conf.pod.labels[k8s.ProxyCronJobLabel] == "" {
+		return false
 	}
-	if err != nil {
-		log.Warnf("%s (%s)", err, k8s.ProxyMemoryRequestAnnotation)
+
+	if conf.pod.spec.RestartPolicy != corev1.RestartPolicyNever {
+		log.Warnf("not shutting down the proxy of the %s pods once their containers exit, as they're restarted %s; use restartPolicy: Never or native sidecars", conf.workload.metaType.Kind, conf.pod.spec.RestartPolicy)
+		return false
 	}
-	if !requestMemory.IsZero() {
-		res.Memory.Request = requestMemory.String()
+
+	if override := conf.getOverride(k8s.ProxyJobShutdownAnnotation); override != "" {
+		value, err := strconv.ParseBool(override)
+		if err != nil {
+			log.Warnf("unrecognized value used for the %s annotation: %s", k8s.ProxyJobShutdownAnnotation, override)
+			return true
+		}
+		return value
 	}
 
-	if override := conf.getOverride(k8s.ProxyCPULimitAnnotation); override != "" {
-		limitCPU, err = k8sResource.ParseQuantity(override)
-	} else if defaultLimit := conf.configs.GetProxy().GetResource().GetLimitCpu(); defaultLimit != "" {
-		limitCPU, err = k8sResource.ParseQuantity(defaultLimit)
+	return true
+}
+
+func (conf *ResourceConfig) proxyResourceRequirements() *l5dcharts.Resources {
+	profile := &ProxyConfigResources{}
+	if name := conf.getOverride(k8s.ProxyResourcesProfileAnnotation); name != "" {
+		if p, ok := ProxyResourceProfiles[name]; ok {
+			profile = &p
+		} else {
+			log.Warnf("unknown proxy resources profile %q (%s)", name, k8s.ProxyResourcesProfileAnnotation)
+		}
 	}
-	if err != nil {
-		log.Warnf("%s (%s)", err, k8s.ProxyCPULimitAnnotation)
+
+	resource := conf.configs.GetProxy().GetResource()
+	res := &l5dcharts.Resources{}
+	res.CPU.Request = conf.proxyResourceQuantity(k8s.ProxyCPURequestAnnotation, profile.CPU.Request, resource.GetRequestCpu())
+	res.Memory.Request = conf.proxyResourceQuantity(k8s.ProxyMemoryRequestAnnotation, profile.Memory.Request, resource.GetRequestMemory())
+	res.CPU.Limit = conf.proxyResourceQuantity(k8s.ProxyCPULimitAnnotation, profile.CPU.Limit, resource.GetLimitCpu())
+	res.Memory.Limit = conf.proxyResourceQuantity(k8s.ProxyMemoryLimitAnnotation, profile.Memory.Limit, resource.GetLimitMemory())
+	return res
+}
+
+// proxyResourceQuantity returns the quantity of the given annotation, or else
+// the one of the resources profile, or else the default one. Returns an empty
+// string if that quantity is invalid or zero.
+func (conf *ResourceConfig) proxyResourceQuantity(annotation, profileQuantity, defaultQuantity string) string {
+	value := defaultQuantity
+	if override := conf.getOverride(annotation); override != "" {
+		value = override
+	} else if profileQuantity != "" {
+		value = profileQuantity
 	}
-	if !limitCPU.IsZero() {
-		res.CPU.Limit = limitCPU.String()
+	if value == "" {
+		return ""
 	}
 
-	if override := conf.getOverride(k8s.ProxyMemoryLimitAnnotation); override != "" {
-		limitMemory, err = k8sResource.ParseQuantity(override)
-	} else if defaultLimit := conf.configs.GetProxy().GetResource().GetLimitMemory(); defaultLimit != "" {
-		limitMemory, err = k8sResource.ParseQuantity(defaultLimit)
-	}
+	quantity, err := k8sResource.ParseQuantity(value)
 	if err != nil {
-		log.Warnf("%s (%s)", err, k8s.ProxyMemoryLimitAnnotation)
+		log.Warnf("%s (%s)", err, annotation)
+		return ""
 	}
-	if !limitMemory.IsZero() {
-		res.Memory.Limit = limitMemory.String()
+	if quantity.IsZero() {
+		return ""
 	}
-
-	return res
+	return quantity.String()
 }
 
 func (conf *ResourceConfig) proxyUID() int64 {
@@ -1,6 +1,7 @@
 package inject
 
 import (
+	"fmt"
 	"reflect"
 	"testing"
 
@@ -10,6 +11,7 @@ import (
 	"github.com/linkerd/linkerd2/pkg/version"
 	"github.com/linkerd/linkerd2/testutil"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/yaml"
@@ -35,6 +37,7 @@ type expectedProxyConfigs struct {
 	inboundSkipPorts              string
 	outboundSkipPorts             string
 	requireIdentityOnInboundPorts string
+	opaquePorts                   string
 	destinationGetNetworks        string
 	outboundConnectTimeout        string
 	inboundConnectTimeout         string
@@ -119,6 +122,7 @@ func TestConfigAccessors(t *testing.T) {
 							k8s.ProxyTraceCollectorSvcAccountAnnotation:      "default",
 							k8s.ProxyWaitBeforeExitSecondsAnnotation:         "123",
 							k8s.ProxyRequireIdentityOnInboundPortsAnnotation: "8888,9999",
+							k8s.ProxyOpaquePortsAnnotation:                   "3306,4222",
 							k8s.ProxyDestinationGetNetworks:                  "10.0.0.0/8",
 							k8s.ProxyOutboundConnectTimeout:                  "6000ms",
 							k8s.ProxyInboundConnectTimeout:                   "600ms",
@@ -159,6 +163,7 @@ func TestConfigAccessors(t *testing.T) {
 					CollectorSvcAccount: "default.tracing",
 				},
 				requireIdentityOnInboundPorts: "8888,9999",
+				opaquePorts:                   "3306,4222",
 				destinationGetNetworks:        "10.0.0.0/8",
 				outboundConnectTimeout:        "6000ms",
 				inboundConnectTimeout:         "600ms",
@@ -315,6 +320,50 @@ func TestConfigAccessors(t *testing.T) {
 				inboundConnectTimeout:  "100ms",
 			},
 		},
+		{id: "use the proxy resources profile",
+			nsAnnotations: map[string]string{
+				k8s.ProxyResourcesProfileAnnotation: "large",
+				k8s.ProxyMemoryLimitAnnotation:      "2Gi",
+			},
+			spec: appsv1.DeploymentSpec{
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{},
+					Spec:       corev1.PodSpec{},
+				},
+			},
+			expected: expectedProxyConfigs{
+				identityContext:            &config.IdentityContext{},
+				image:                      "ghcr.io/linkerd/proxy",
+				imagePullPolicy:            "IfNotPresent",
+				proxyVersion:               proxyVersion,
+				controlPort:                int32(9000),
+				inboundPort:                int32(6000),
+				adminPort:                  int32(6001),
+				outboundPort:               int32(6002),
+				proxyWaitBeforeExitSeconds: 0,
+				logLevel:                   "info,linkerd2_proxy=debug",
+				logFormat:                  "plain",
+				resourceRequirements: &l5dcharts.Resources{
+					CPU: l5dcharts.Constraints{
+						Limit:   "2",
+						Request: "500m",
+					},
+					Memory: l5dcharts.Constraints{
+						Limit:   "2Gi",
+						Request: "256Mi",
+					},
+				},
+				proxyUID:               int64(8888),
+				initImage:              "ghcr.io/linkerd/proxy-init",
+				initImagePullPolicy:    "IfNotPresent",
+				initVersion:            version.ProxyInitVersion,
+				inboundSkipPorts:       "53,58-59",
+				outboundSkipPorts:      "9079-9080",
+				destinationGetNetworks: "10.0.0.0/8,172.16.0.0/12,192.168.0.0/16",
+				outboundConnectTimeout: "1000ms",
+				inboundConnectTimeout:  "100ms",
+			},
+		},
 		{id: "use empty string for dst networks",
 			nsAnnotations: map[string]string{
 				k8s.ProxyDestinationGetNetworks: "",
@@ -581,6 +630,13 @@ func TestConfigAccessors(t *testing.T) {
 				}
 			})
 
+			t.Run("proxyOpaquePorts", func(t *testing.T) {
+				expected := testCase.expected.opaquePorts
+				if actual := resourceConfig.opaquePorts(); expected != actual {
+					t.Errorf("Expected: %v Actual: %v", expected, actual)
+				}
+			})
+
 			t.Run("proxyRequireIdentityOnInboundPorts", func(t *testing.T) {
 				expected := testCase.expected.requireIdentityOnInboundPorts
 				if actual := resourceConfig.requireIdentityOnInboundPorts(); expected != actual {
@@ -611,3 +667,126 @@ func TestConfigAccessors(t *testing.T) {
 		})
 	}
 }
+
+func TestProxyJobShutdown(t *testing.T) {
+	configs := &config.All{Global: &config.Global{LinkerdNamespace: "linkerd"}, Proxy: &config.Proxy{}}
+
+	testCases := []struct {
+		kind          string
+		obj           interface{}
+		annotations   map[string]string
+		restartPolicy corev1.RestartPolicy
+		expected      bool
+	}{
+		{
+			kind:     "Deployment",
+			obj:      &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web"}},
+			expected: false,
+		},
+		{
+			kind:          "Job",
+			obj:           &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "migrate"}},
+			restartPolicy: corev1.RestartPolicyNever,
+			expected:      true,
+		},
+		{
+			kind:          "Job",
+			obj:           &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "migrate"}},
+			restartPolicy: corev1.RestartPolicyOnFailure,
+			expected:      false,
+		},
+		{
+			kind:          "Job",
+			obj:           &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "migrate"}},
+			annotations:   map[string]string{k8s.ProxyJobShutdownAnnotation: "false"},
+			restartPolicy: corev1.RestartPolicyNever,
+			expected:      false,
+		},
+	}
+
+	for i, tc := range testCases {
+		tc := tc // pin
+		t.Run(fmt.Sprintf("%d: %s", i, tc.kind), func(t *testing.T) {
+			switch obj := tc.obj.(type) {
+			case *appsv1.Deployment:
+				obj.Spec.Template.Annotations = tc.annotations
+			case *batchv1.Job:
+				obj.Spec.Template.Annotations = tc.annotations
+				obj.Spec.Template.Spec.RestartPolicy = tc.restartPolicy
+			}
+			data, err := yaml.Marshal(tc.obj)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			resourceConfig := NewResourceConfig(configs, OriginUnknown).WithKind(tc.kind)
+			if err := resourceConfig.parse(data); err != nil {
+				t.Fatal(err)
+			}
+			if actual := resourceConfig.proxyJobShutdown(); actual != tc.expected {
+				t.Errorf("Expected: %v Actual: %v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestProxyAdditionalEnv(t *testing.T) {
+	configs := &config.All{Global: &config.Global{LinkerdNamespace: "linkerd"}, Proxy: &config.Proxy{}}
+
+	testCases := []struct {
+		nsAnnotations map[string]string
+		annotations   map[string]string
+		expected      []l5dcharts.EnvVar
+	}{
+		{
+			expected: nil,
+		},
+		{
+			annotations: map[string]string{k8s.ProxyEnvAnnotation: `{"LINKERD2_PROXY_LIST": "a=b,c=d", "LINKERD2_PROXY_EXPERIMENT": "true"}`},
+			expected: []l5dcharts.EnvVar{
+				{Name: "LINKERD2_PROXY_EXPERIMENT", Value: "true"},
+				{Name: "LINKERD2_PROXY_LIST", Value: "a=b,c=d"},
+			},
+		},
+		{
+			annotations: map[string]string{k8s.ProxyEnvAnnotation: "LINKERD2_PROXY_QUOTED: 'a: \"b\"'\nLINKERD2_PROXY_EMPTY: \"\"\n1NVALID: x\n"},
+			expected: []l5dcharts.EnvVar{
+				{Name: "LINKERD2_PROXY_EMPTY", Value: ""},
+				{Name: "LINKERD2_PROXY_QUOTED", Value: `a: "b"`},
+			},
+		},
+		{
+			annotations: map[string]string{k8s.ProxyEnvAnnotation: "LINKERD2_PROXY_EXPERIMENT=true"},
+			expected:    nil,
+		},
+		{
+			nsAnnotations: map[string]string{k8s.ProxyEnvAnnotation: `{"LINKERD2_PROXY_NS": "true"}`},
+			expected:      []l5dcharts.EnvVar{{Name: "LINKERD2_PROXY_NS", Value: "true"}},
+		},
+		{
+			nsAnnotations: map[string]string{k8s.ProxyEnvAnnotation: `{"LINKERD2_PROXY_NS": "true"}`},
+			annotations:   map[string]string{k8s.ProxyEnvAnnotation: `{"LINKERD2_PROXY_POD": "true"}`},
+			expected:      []l5dcharts.EnvVar{{Name: "LINKERD2_PROXY_POD", Value: "true"}},
+		},
+	}
+
+	for i, tc := range testCases {
+		tc := tc // pin
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web"}}
+			deployment.Spec.Template.Annotations = tc.annotations
+			data, err := yaml.Marshal(deployment)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			resourceConfig := NewResourceConfig(configs, OriginUnknown).WithKind("Deployment").WithNsAnnotations(tc.nsAnnotations)
+			if err := resourceConfig.parse(data); err != nil {
+				t.Fatal(err)
+			}
+			if actual := resourceConfig.proxyAdditionalEnv(); !reflect.DeepEqual(actual, tc.expected) {
+				t.Errorf("Expected: %v Actual: %v", tc.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package inject
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	k8sResource "k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+type (
+	// ProxyConfigSpec is the spec of the ProxyConfig and ClusterProxyConfig
+	// custom resources, holding the defaults of the proxy configuration of the
+	// pods injected by the proxy-injector, respectively in their namespace and
+	// in the whole cluster.
+	ProxyConfigSpec struct {
+		LogLevel          string                `json:"logLevel,omitempty"`
+		Resources         *ProxyConfigResources `json:"resources,omitempty"`
+		SkipInboundPorts  []string              `json:"skipInboundPorts,omitempty"`
+		SkipOutboundPorts []string              `json:"skipOutboundPorts,omitempty"`
+		OpaquePorts       []string              `json:"opaquePorts,omitempty"`
+	}
+
+	// ProxyConfigResources are the compute resources of the proxy.
+	ProxyConfigResources struct {
+		CPU    ProxyConfigConstraints `json:"cpu,omitempty"`
+		Memory ProxyConfigConstraints `json:"memory,omitempty"`
+	}
+
+	// ProxyConfigConstraints are the request and limit of a compute resource.
+	ProxyConfigConstraints struct {
+		Request string `json:"request,omitempty"`
+		Limit   string `json:"limit,omitempty"`
+	}
+)
+
+// ProxyResourceProfileNames are the names of the proxy sizing profiles, from
+// the smallest to the largest
+var ProxyResourceProfileNames = []string{"small", "medium", "large"}
+
+// ProxyResourceProfiles are the proxy sizing profiles, selected with the
+// config.linkerd.io/proxy-resources-profile annotation or the
+// --proxy-resources-profile flag
+var ProxyResourceProfiles = map[string]ProxyConfigResources{
+	"small": {
+		CPU:    ProxyConfigConstraints{Request: "10m", Limit: "100m"},
+		Memory: ProxyConfigConstraints{Request: "20Mi", Limit: "64Mi"},
+	},
+	"medium": {
+		CPU:    ProxyConfigConstraints{Request: "100m", Limit: "1"},
+		Memory: ProxyConfigConstraints{Request: "64Mi", Limit: "250Mi"},
+	},
+	"large": {
+		CPU:    ProxyConfigConstraints{Request: "500m", Limit: "2"},
+		Memory: ProxyConfigConstraints{Request: "256Mi", Limit: "1Gi"},
+	},
+}
+
+// NewProxyConfigSpec parses the spec of an unstructured ProxyConfig or
+// ClusterProxyConfig custom resource, and validates its quantities.
+func NewProxyConfigSpec(u *unstructured.Unstructured) (*ProxyConfigSpec, error) {
+	spec, ok := u.Object["spec"]
+	if !ok {
+		return nil, errors.New("Field 'spec' is missing")
+	}
+	if _, ok := spec.(map[string]interface{}); !ok {
+		return nil, errors.New("Field 'spec' is not an object")
+	}
+
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return nil, err
+	}
+	var s ProxyConfigSpec
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, err
+	}
+
+	if r := s.Resources; r != nil {
+		for field, q := range map[string]string{
+			"cpu.request":    r.CPU.Request,
+			"cpu.limit":      r.CPU.Limit,
+			"memory.request": r.Memory.Request,
+			"memory.limit":   r.Memory.Limit,
+		} {
+			if q == "" {
+				continue
+			}
+			if _, err := k8sResource.ParseQuantity(q); err != nil {
+				return nil, fmt.Errorf("Field 'spec.resources.%s' is invalid: %s", field, err)
+			}
+		}
+	}
+
+	return &s, nil
+}
+
+// Annotations returns the config.linkerd.io annotations overriding the proxy
+// configuration like s does.
+func (s *ProxyConfigSpec) Annotations() map[string]string {
+	annotations := map[string]string{}
+	set := func(annotation, value string) {
+		if value != "" {
+			annotations[annotation] = value
+		}
+	}
+
+	set(k8s.ProxyLogLevelAnnotation, s.LogLevel)
+	if r := s.Resources; r != nil {
+		set(k8s.ProxyCPURequestAnnotation, r.CPU.Request)
+		set(k8s.ProxyCPULimitAnnotation, r.CPU.Limit)
+		set(k8s.ProxyMemoryRequestAnnotation, r.Memory.Request)
+		set(k8s.ProxyMemoryLimitAnnotation, r.Memory.Limit)
+	}
+	set(k8s.ProxyIgnoreInboundPortsAnnotation, strings.Join(s.SkipInboundPorts, ","))
+	set(k8s.ProxyIgnoreOutboundPortsAnnotation, strings.Join(s.SkipOutboundPorts, ","))
+	set(k8s.ProxyOpaquePortsAnnotation, strings.Join(s.OpaquePorts, ","))
+	return annotations
+}
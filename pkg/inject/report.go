@@ -7,7 +7,9 @@ import (
 
 	"github.com/linkerd/linkerd2/pkg/healthcheck"
 	"github.com/linkerd/linkerd2/pkg/k8s"
+	log "github.com/sirupsen/logrus"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
 )
 
 const (
@@ -22,6 +24,8 @@ const (
 	invalidInjectAnnotationNamespace     = "invalid_inject_annotation_at_ns"
 	disabledAutomountServiceAccountToken = "disabled_automount_service_account_token_account"
 	udpPortsEnabled                      = "udp_ports_enabled"
+	windowsNode                          = "windows_node"
+	excludedNode                         = "excluded_node"
 )
 
 var (
@@ -36,6 +40,8 @@ var (
 		invalidInjectAnnotationNamespace:     fmt.Sprintf("invalid value for annotation \"%s\" at namespace", k8s.ProxyInjectAnnotation),
 		disabledAutomountServiceAccountToken: fmt.Sprintf("automountServiceAccountToken set to \"false\""),
 		udpPortsEnabled:                      "UDP port(s) configured on pod spec",
+		windowsNode:                          "pod is scheduled to Windows nodes",
+		excludedNode:                         "pod is scheduled to nodes excluded from injection",
 	}
 )
 
@@ -54,6 +60,18 @@ type Report struct {
 	TracingEnabled               bool
 	AutomountServiceAccountToken bool
 
+	// WindowsNode is true if the pod can only be scheduled to Windows nodes,
+	// where the proxy can't run
+	WindowsNode bool
+
+	// ExcludedNode is true if the nodeSelector of the pod matches the
+	// excluded node selector of the proxy configuration
+	ExcludedNode bool
+
+	// OpaquePorts are the opaque ports detected by `linkerd inject
+	// --detect-opaque-ports`, if any
+	OpaquePorts string
+
 	// Uninjected consists of two boolean flags to indicate if a proxy and
 	// proxy-init containers have been uninjected in this report
 	Uninjected struct {
@@ -89,6 +107,8 @@ func newReport(conf *ResourceConfig) *Report {
 		report.HostNetwork = conf.pod.spec.HostNetwork
 		report.Sidecar = healthcheck.HasExistingSidecars(conf.pod.spec)
 		report.UDP = checkUDPPorts(conf.pod.spec)
+		report.WindowsNode = targetsWindowsNodes(conf.pod.spec)
+		report.ExcludedNode = conf.targetsExcludedNodes()
 		report.TracingEnabled = conf.pod.meta.Annotations[k8s.ProxyTraceCollectorSvcAddrAnnotation] != "" || conf.nsAnnotations[k8s.ProxyTraceCollectorSvcAddrAnnotation] != ""
 		if conf.pod.spec.AutomountServiceAccountToken != nil {
 			report.AutomountServiceAccountToken = *conf.pod.spec.AutomountServiceAccountToken
@@ -122,6 +142,12 @@ func (r *Report) Injectable() (bool, []string) {
 	if r.InjectDisabled {
 		reasons = append(reasons, r.InjectDisabledReason)
 	}
+	if r.WindowsNode {
+		reasons = append(reasons, windowsNode)
+	}
+	if r.ExcludedNode {
+		reasons = append(reasons, excludedNode)
+	}
 
 	if !r.AutomountServiceAccountToken {
 		reasons = append(reasons, disabledAutomountServiceAccountToken)
@@ -145,6 +171,49 @@ func checkUDPPorts(t *v1.PodSpec) bool {
 	return false
 }
 
+// targetsWindowsNodes returns true if the pod can only be scheduled to Windows
+// nodes, according to its nodeSelector or to its required node affinity
+func targetsWindowsNodes(t *v1.PodSpec) bool {
+	if k8s.IsWindows(t.NodeSelector) {
+		return true
+	}
+	if t.Affinity == nil || t.Affinity.NodeAffinity == nil || t.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		return false
+	}
+
+	// the node selector terms are ORed, so all of them must require Windows
+	terms := t.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+	for _, term := range terms {
+		windows := false
+		for _, req := range term.MatchExpressions {
+			if (req.Key == k8s.OSLabel || req.Key == k8s.BetaOSLabel) &&
+				req.Operator == v1.NodeSelectorOpIn && len(req.Values) == 1 && req.Values[0] == k8s.WindowsOS {
+				windows = true
+			}
+		}
+		if !windows {
+			return false
+		}
+	}
+	return len(terms) > 0
+}
+
+// targetsExcludedNodes returns true if the nodeSelector of the pod matches the
+// excluded node selector of the proxy configuration. The pods without a
+// nodeSelector may be scheduled to any node, so they're never excluded.
+func (conf *ResourceConfig) targetsExcludedNodes() bool {
+	excluded := conf.configs.GetProxy().GetExcludedNodeSelector()
+	if excluded == "" || len(conf.pod.spec.NodeSelector) == 0 {
+		return false
+	}
+	selector, err := labels.Parse(excluded)
+	if err != nil {
+		log.Warnf("invalid excluded node selector %q: %s", excluded, err)
+		return false
+	}
+	return selector.Matches(labels.Set(conf.pod.spec.NodeSelector))
+}
+
 // disabledByAnnotation checks annotations for both workload, namespace and returns
 // if disabled, Inject Disabled reason and the resource where that annotation was present
 func (r *Report) disableByAnnotation(conf *ResourceConfig) (bool, string, string) {
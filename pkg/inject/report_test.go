@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/linkerd/linkerd2/controller/gen/config"
 	"github.com/linkerd/linkerd2/pkg/k8s"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -11,12 +12,13 @@ import (
 
 func TestInjectable(t *testing.T) {
 	var testCases = []struct {
-		podSpec             *corev1.PodSpec
-		podMeta             *metav1.ObjectMeta
-		nsAnnotations       map[string]string
-		unsupportedResource bool
-		injectable          bool
-		reasons             []string
+		podSpec              *corev1.PodSpec
+		podMeta              *metav1.ObjectMeta
+		nsAnnotations        map[string]string
+		unsupportedResource  bool
+		excludedNodeSelector string
+		injectable           bool
+		reasons              []string
 	}{
 		{
 			podSpec: &corev1.PodSpec{HostNetwork: false},
@@ -148,12 +150,106 @@ func TestInjectable(t *testing.T) {
 			injectable: false,
 			reasons:    []string{hostNetworkEnabled, sidecarExists, injectEnableAnnotationAbsent},
 		},
+		{
+			podSpec: &corev1.PodSpec{
+				NodeSelector: map[string]string{k8s.OSLabel: k8s.WindowsOS},
+			},
+			podMeta: &metav1.ObjectMeta{
+				Annotations: map[string]string{
+					k8s.ProxyInjectAnnotation: k8s.ProxyInjectEnabled,
+				},
+			},
+
+			injectable: false,
+			reasons:    []string{windowsNode},
+		},
+		{
+			podSpec: &corev1.PodSpec{
+				Affinity: &corev1.Affinity{
+					NodeAffinity: &corev1.NodeAffinity{
+						RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+							NodeSelectorTerms: []corev1.NodeSelectorTerm{
+								{
+									MatchExpressions: []corev1.NodeSelectorRequirement{
+										{Key: k8s.BetaOSLabel, Operator: corev1.NodeSelectorOpIn, Values: []string{k8s.WindowsOS}},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			podMeta: &metav1.ObjectMeta{
+				Annotations: map[string]string{
+					k8s.ProxyInjectAnnotation: k8s.ProxyInjectEnabled,
+				},
+			},
+
+			injectable: false,
+			reasons:    []string{windowsNode},
+		},
+		{
+			podSpec: &corev1.PodSpec{
+				Affinity: &corev1.Affinity{
+					NodeAffinity: &corev1.NodeAffinity{
+						RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+							NodeSelectorTerms: []corev1.NodeSelectorTerm{
+								{
+									MatchExpressions: []corev1.NodeSelectorRequirement{
+										{Key: k8s.OSLabel, Operator: corev1.NodeSelectorOpIn, Values: []string{k8s.WindowsOS}},
+									},
+								},
+								{
+									MatchExpressions: []corev1.NodeSelectorRequirement{
+										{Key: k8s.OSLabel, Operator: corev1.NodeSelectorOpIn, Values: []string{"linux"}},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			podMeta: &metav1.ObjectMeta{
+				Annotations: map[string]string{
+					k8s.ProxyInjectAnnotation: k8s.ProxyInjectEnabled,
+				},
+			},
+
+			injectable: true,
+		},
+		{
+			excludedNodeSelector: "example.com/pool in (gpu,legacy)",
+			podSpec: &corev1.PodSpec{
+				NodeSelector: map[string]string{"example.com/pool": "gpu"},
+			},
+			podMeta: &metav1.ObjectMeta{
+				Annotations: map[string]string{
+					k8s.ProxyInjectAnnotation: k8s.ProxyInjectEnabled,
+				},
+			},
+
+			injectable: false,
+			reasons:    []string{excludedNode},
+		},
+		{
+			excludedNodeSelector: "!example.com/pool",
+			podSpec:              &corev1.PodSpec{},
+			podMeta: &metav1.ObjectMeta{
+				Annotations: map[string]string{
+					k8s.ProxyInjectAnnotation: k8s.ProxyInjectEnabled,
+				},
+			},
+
+			injectable: true,
+		},
 	}
 
 	for i, testCase := range testCases {
 		testCase := testCase
 		t.Run(fmt.Sprintf("test case #%d", i), func(t *testing.T) {
-			resourceConfig := &ResourceConfig{}
+			resourceConfig := &ResourceConfig{
+				configs: &config.All{Proxy: &config.Proxy{ExcludedNodeSelector: testCase.excludedNodeSelector}},
+			}
 			resourceConfig.WithNsAnnotations(testCase.nsAnnotations)
 			resourceConfig.pod.spec = testCase.podSpec
 			resourceConfig.origin = OriginWebhook
@@ -1,69 +1,130 @@
 package inject
 
 import (
+	"encoding/json"
+	"fmt"
+	"sort"
 	"strings"
 
+	jsonpatch "github.com/evanphx/json-patch"
 	"github.com/linkerd/linkerd2/pkg/k8s"
-	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
 )
 
-// Uninject removes from the workload in conf the init and proxy containers,
+// removeOperation is a JSON patch operation removing the value at Path
+type removeOperation struct {
+	Op   string `json:"op"`
+	Path string `json:"path"`
+}
+
+func remove(path string) removeOperation {
+	return removeOperation{Op: "remove", Path: path}
+}
+
+// Uninject removes from the workload in conf the init, proxy and debug containers,
 // the TLS volumes and the extra annotations/labels that were added
 func (conf *ResourceConfig) Uninject(report *Report) ([]byte, error) {
-	if conf.IsNamespace() {
-		uninjectObjectMeta(conf.workload.Meta, report)
-		return conf.YamlMarshalObj()
+	patchJSON, err := conf.UninjectPatch(report)
+	if err != nil || patchJSON == nil {
+		return nil, err
 	}
 
-	if conf.pod.spec == nil {
-		return nil, nil
+	j, err := getFilteredJSON(conf.workload.obj)
+	if err != nil {
+		return nil, err
 	}
+	patch, err := jsonpatch.DecodePatch(patchJSON)
+	if err != nil {
+		return nil, err
+	}
+	if j, err = patch.Apply(j); err != nil {
+		return nil, err
+	}
+	return yaml.JSONToYAML(j)
+}
 
-	conf.uninjectPodSpec(report)
+// UninjectPatch returns the JSON patch removing from the workload in conf the
+// containers, volumes and linkerd.io metadata added by the injection, and
+// nothing else, so that other sidecars and their configuration are left
+// untouched. Returns nil if the workload has no pod spec.
+func (conf *ResourceConfig) UninjectPatch(report *Report) ([]byte, error) {
+	var ops []removeOperation
+	if conf.IsNamespace() {
+		ops = uninjectObjectMeta("", conf.workload.Meta, report)
+	} else {
+		if conf.pod.spec == nil {
+			return nil, nil
+		}
 
-	if conf.workload.Meta != nil {
-		uninjectObjectMeta(conf.workload.Meta, report)
+		prefix := conf.podPathPrefix()
+		ops = conf.uninjectPodSpec(prefix, report)
+		if conf.workload.Meta != nil {
+			ops = append(ops, uninjectObjectMeta("", conf.workload.Meta, report)...)
+		}
+		ops = append(ops, uninjectObjectMeta(prefix, conf.pod.meta, report)...)
 	}
 
-	uninjectObjectMeta(conf.pod.meta, report)
-	return conf.YamlMarshalObj()
+	if ops == nil {
+		ops = []removeOperation{}
+	}
+	return json.Marshal(ops)
 }
 
-// Given a PodSpec, update the PodSpec in place with the sidecar
-// and init-container uninjected
-func (conf *ResourceConfig) uninjectPodSpec(report *Report) {
+// uninjectPodSpec returns the operations removing from the PodSpec the
+// sidecars, init-container and volumes that were injected
+func (conf *ResourceConfig) uninjectPodSpec(prefix string, report *Report) []removeOperation {
 	t := conf.pod.spec
-	initContainers := []v1.Container{}
-	for _, container := range t.InitContainers {
-		if container.Name != k8s.InitContainerName {
-			initContainers = append(initContainers, container)
-		} else {
+
+	var ops []removeOperation
+	var removed []int
+	for i, container := range t.InitContainers {
+		switch container.Name {
+		case k8s.InitContainerName:
 			report.Uninjected.ProxyInit = true
+		case k8s.ProxyContainerName:
+			// injected as a native sidecar container
+			report.Uninjected.Proxy = true
+		default:
+			continue
 		}
+		removed = append(removed, i)
 	}
-	t.InitContainers = initContainers
+	ops = append(ops, removeItems(prefix+"/spec/initContainers", removed, len(t.InitContainers))...)
 
-	containers := []v1.Container{}
-	for _, container := range t.Containers {
-		if container.Name != k8s.ProxyContainerName {
-			containers = append(containers, container)
-		} else {
+	removed = nil
+	for i, container := range t.Containers {
+		switch container.Name {
+		case k8s.ProxyContainerName:
 			report.Uninjected.Proxy = true
+		case k8s.DebugSidecarName:
+		case k8s.ProxyShutdownSidecarName:
+			// shareProcessNamespace was only set for the proxy-shutdown
+			// container to see the processes of the other containers
+			if t.ShareProcessNamespace != nil {
+				ops = append(ops, remove(prefix+"/spec/shareProcessNamespace"))
+			}
+		default:
+			continue
 		}
+		removed = append(removed, i)
 	}
-	t.Containers = containers
+	ops = append(ops, removeItems(prefix+"/spec/containers", removed, len(t.Containers))...)
 
-	volumes := []v1.Volume{}
-	for _, volume := range t.Volumes {
-		if volume.Name != k8s.IdentityEndEntityVolumeName && volume.Name != k8s.PodInfoVolumeName && volume.Name != k8s.InitXtablesLockVolumeMountName {
-			volumes = append(volumes, volume)
+	removed = nil
+	for i, volume := range t.Volumes {
+		if volume.Name == k8s.IdentityEndEntityVolumeName || volume.Name == k8s.PodInfoVolumeName || volume.Name == k8s.InitXtablesLockVolumeMountName {
+			removed = append(removed, i)
 		}
 	}
-	t.Volumes = volumes
+	ops = append(ops, removeItems(prefix+"/spec/volumes", removed, len(t.Volumes))...)
+
+	return ops
 }
 
-func uninjectObjectMeta(t *metav1.ObjectMeta, report *Report) {
+// uninjectObjectMeta returns the operations removing the linkerd.io
+// annotations and labels of the metadata at prefix
+func uninjectObjectMeta(prefix string, t *metav1.ObjectMeta, report *Report) []removeOperation {
 	// We only uninject control plane components in the context
 	// of doing an inject --manual. This is done as a way to update
 	// something about the injection configuration - for example
@@ -73,25 +134,67 @@ func uninjectObjectMeta(t *metav1.ObjectMeta, report *Report) {
 	// This is why we skip that part for control plane components.
 	// Furthermore the latter will never have linkerd.io/inject as
 	// they are always manually injected.
-	if _, ok := t.Labels[k8s.ControllerComponentLabel]; !ok {
-		newAnnotations := make(map[string]string)
-		for key, val := range t.Annotations {
-			if !strings.HasPrefix(key, k8s.Prefix) ||
-				(key == k8s.ProxyInjectAnnotation && val == k8s.ProxyInjectDisabled) {
-				newAnnotations[key] = val
-			} else {
-				report.Uninjected.Proxy = true
-			}
+	if _, ok := t.Labels[k8s.ControllerComponentLabel]; ok {
+		return nil
+	}
 
+	var annotations []string
+	for key, val := range t.Annotations {
+		if strings.HasPrefix(key, k8s.Prefix) &&
+			!(key == k8s.ProxyInjectAnnotation && val == k8s.ProxyInjectDisabled) {
+			annotations = append(annotations, key)
+			report.Uninjected.Proxy = true
 		}
-		t.Annotations = newAnnotations
+	}
 
-		labels := make(map[string]string)
-		for key, val := range t.Labels {
-			if !strings.HasPrefix(key, k8s.Prefix) {
-				labels[key] = val
-			}
+	var labels []string
+	for key := range t.Labels {
+		if strings.HasPrefix(key, k8s.Prefix) {
+			labels = append(labels, key)
 		}
-		t.Labels = labels
 	}
+
+	ops := removeKeys(prefix+"/metadata/annotations", annotations, len(t.Annotations))
+	return append(ops, removeKeys(prefix+"/metadata/labels", labels, len(t.Labels))...)
+}
+
+// removeItems returns the operations removing the items at the given
+// ascending indexes of the array at path of the given length, or the whole
+// array if all its items are removed
+func removeItems(path string, indexes []int, length int) []removeOperation {
+	if len(indexes) == 0 {
+		return nil
+	}
+	if len(indexes) == length {
+		return []removeOperation{remove(path)}
+	}
+
+	// removing the last items first keeps the indexes of the others valid
+	ops := make([]removeOperation, len(indexes))
+	for i, index := range indexes {
+		ops[len(indexes)-1-i] = remove(fmt.Sprintf("%s/%d", path, index))
+	}
+	return ops
+}
+
+// removeKeys returns the operations removing the given keys of the map at
+// path of the given length, or the whole map if all its keys are removed
+func removeKeys(path string, keys []string, length int) []removeOperation {
+	if len(keys) == 0 {
+		return nil
+	}
+	if len(keys) == length {
+		return []removeOperation{remove(path)}
+	}
+
+	sort.Strings(keys)
+	ops := make([]removeOperation, len(keys))
+	for i, key := range keys {
+		ops[i] = remove(fmt.Sprintf("%s/%s", path, escapeJSONPointer(key)))
+	}
+	return ops
+}
+
+func escapeJSONPointer(s string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(s)
 }
@@ -1,7 +1,6 @@
 package issuercerts
 
 import (
-	"crypto/ecdsa"
 	"crypto/x509"
 	"fmt"
 	"io/ioutil"
@@ -16,7 +15,10 @@ import (
 )
 
 const keyMissingError = "key %s containing the %s needs to exist in secret %s if --identity-external-issuer=%v"
-const expirationWarningThresholdInDays = 60
+
+// ExpirationWarningThreshold is the default time before their expiry
+// certificates are reported as expiring soon
+const ExpirationWarningThreshold = 60 * 24 * time.Hour
 
 // IssuerCertData holds the trust anchors cert data used by the CA
 type IssuerCertData struct {
@@ -24,6 +26,9 @@ type IssuerCertData struct {
 	IssuerCrt    string
 	IssuerKey    string
 	Expiry       *time.Time
+	// KeyURI is the URI of the issuer private key when it's held by a KMS,
+	// in which case IssuerKey is empty
+	KeyURI string
 }
 
 // FetchIssuerData fetches the issuer data from the linkerd-identity-issuer secrets (used for linkerd.io/tls schemed secrets)
@@ -38,12 +43,16 @@ func FetchIssuerData(api kubernetes.Interface, trustAnchors, controlPlaneNamespa
 		return nil, fmt.Errorf(keyMissingError, k8s.IdentityIssuerCrtName, "issuer certificate", k8s.IdentityIssuerSecretName, false)
 	}
 
+	if keyURI := secret.Annotations[k8s.IdentityIssuerKeyURIAnnotation]; keyURI != "" {
+		return &IssuerCertData{TrustAnchors: trustAnchors, IssuerCrt: string(crt), KeyURI: keyURI}, nil
+	}
+
 	key, ok := secret.Data[k8s.IdentityIssuerKeyName]
 	if !ok {
 		return nil, fmt.Errorf(keyMissingError, k8s.IdentityIssuerKeyName, "issuer key", k8s.IdentityIssuerSecretName, true)
 	}
 
-	return &IssuerCertData{trustAnchors, string(crt), string(key), nil}, nil
+	return &IssuerCertData{TrustAnchors: trustAnchors, IssuerCrt: string(crt), IssuerKey: string(key)}, nil
 }
 
 // FetchExternalIssuerData fetches the issuer data from the linkerd-identity-issuer secrets (used for kubernetes.io/tls schemed secrets)
@@ -68,7 +77,7 @@ func FetchExternalIssuerData(api kubernetes.Interface, controlPlaneNamespace str
 		return nil, fmt.Errorf(keyMissingError, corev1.TLSPrivateKeyKey, "issuer key", k8s.IdentityIssuerSecretName, true)
 	}
 
-	return &IssuerCertData{string(anchors), string(crt), string(key), nil}, nil
+	return &IssuerCertData{TrustAnchors: string(anchors), IssuerCrt: string(crt), IssuerKey: string(key)}, nil
 }
 
 // LoadIssuerCrtAndKeyFromFiles loads the issuer certificate and key from files
@@ -98,7 +107,7 @@ func LoadIssuerDataFromFiles(keyPEMFile, crtPEMFile, trustPEMFile string) (*Issu
 		return nil, err
 	}
 
-	return &IssuerCertData{string(anchors), crt, key, nil}, nil
+	return &IssuerCertData{TrustAnchors: string(anchors), IssuerCrt: crt, IssuerKey: key}, nil
 }
 
 // CheckCertValidityPeriod ensures the certificate is valid time - wise
@@ -115,8 +124,14 @@ func CheckCertValidityPeriod(cert *x509.Certificate) error {
 
 // CheckExpiringSoon returns an error if a certificate is expiring soon
 func CheckExpiringSoon(cert *x509.Certificate) error {
-	if time.Now().AddDate(0, 0, expirationWarningThresholdInDays).After(cert.NotAfter) {
-		return fmt.Errorf("will expire on %s", cert.NotAfter.Format(time.RFC3339))
+	return CheckExpiringWithin(cert.NotAfter, ExpirationWarningThreshold)
+}
+
+// CheckExpiringWithin returns an error if a certificate expiring on notAfter
+// expires within window
+func CheckExpiringWithin(notAfter time.Time, window time.Duration) error {
+	if time.Now().Add(window).After(notAfter) {
+		return fmt.Errorf("will expire on %s", notAfter.Format(time.RFC3339))
 	}
 	return nil
 }
@@ -124,28 +139,36 @@ func CheckExpiringSoon(cert *x509.Certificate) error {
 // CheckCertAlgoRequirements ensures the certificate respects with the constraints
 // we have posed on the public key and signature algorithms
 func CheckCertAlgoRequirements(cert *x509.Certificate) error {
-	if cert.PublicKeyAlgorithm == x509.ECDSA {
-		// this is a safe cast here as we know we are using ECDSA
-		k, ok := cert.PublicKey.(*ecdsa.PublicKey)
-		if !ok {
-			return fmt.Errorf("expected ecdsa.PublicKey but got something %v", cert.PublicKey)
-		}
-		if k.Params().BitSize != 256 {
-			return fmt.Errorf("must use P-256 curve for public key, instead P-%d was used", k.Params().BitSize)
-		}
-	} else {
-		return fmt.Errorf("must use ECDSA for public key algorithm, instead %s was used", cert.PublicKeyAlgorithm)
-	}
-
-	if cert.SignatureAlgorithm != x509.ECDSAWithSHA256 {
-		return fmt.Errorf("must be signed by an ECDSA P-256 key, instead %s was used", cert.SignatureAlgorithm)
+	if _, err := tls.KeyAlgorithmOf(cert.PublicKey); err != nil {
+		return err
+	}
+
+	switch cert.SignatureAlgorithm {
+	case x509.ECDSAWithSHA256, x509.ECDSAWithSHA384, x509.SHA256WithRSA, x509.SHA384WithRSA:
+	default:
+		return fmt.Errorf("must be signed by an ECDSA or RSA key with SHA-256 or SHA-384, instead %s was used", cert.SignatureAlgorithm)
 	}
 	return nil
 }
 
+// Creds builds the creds out of the data in IssuerCertData, validating that
+// the key matches the certificate. When the key is held by a KMS, only the
+// certificate is loaded and the creds have no private key.
+func (ic *IssuerCertData) Creds() (*tls.Cred, error) {
+	if ic.KeyURI == "" {
+		return tls.ValidateAndCreateCreds(ic.IssuerCrt, ic.IssuerKey)
+	}
+
+	crt, err := tls.DecodePEMCrt(ic.IssuerCrt)
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Cred{Crt: *crt}, nil
+}
+
 // VerifyAndBuildCreds builds and validates the creds out of the data in IssuerCertData
 func (ic *IssuerCertData) VerifyAndBuildCreds(dnsName string) (*tls.Cred, error) {
-	creds, err := tls.ValidateAndCreateCreds(ic.IssuerCrt, ic.IssuerKey)
+	creds, err := ic.Creds()
 	if err != nil {
 		return nil, fmt.Errorf("failed to read CA: %s", err)
 	}
@@ -24,6 +24,11 @@ import (
 
 var minAPIVersion = [3]int{1, 13, 0}
 
+// maxTestedAPIVersion is the most recent Kubernetes minor release Linkerd is
+// tested against. More recent releases are expected to work, but aren't
+// guaranteed to.
+var maxTestedAPIVersion = [2]int{1, 19}
+
 // KubernetesAPI provides a client for accessing a Kubernetes cluster.
 // TODO: support ServiceProfile ClientSet. A prerequisite is moving the
 // ServiceProfile client code from `./controller` to `./pkg` (#2751). This will
@@ -132,6 +137,24 @@ func (kubeAPI *KubernetesAPI) CheckVersion(versionInfo *version.Info) error {
 	return nil
 }
 
+// CheckTestedVersion validates whether the configured Kubernetes cluster's
+// version isn't more recent than the latest minor release Linkerd is tested
+// against.
+func (kubeAPI *KubernetesAPI) CheckTestedVersion(versionInfo *version.Info) error {
+	apiVersion, err := getK8sVersion(versionInfo.String())
+	if err != nil {
+		return err
+	}
+
+	if !isTestedVersion(maxTestedAPIVersion, apiVersion) {
+		return fmt.Errorf("Kubernetes is on version [%d.%d.%d], but Linkerd has only been tested with versions up to [%d.%d]",
+			apiVersion[0], apiVersion[1], apiVersion[2],
+			maxTestedAPIVersion[0], maxTestedAPIVersion[1])
+	}
+
+	return nil
+}
+
 // NamespaceExists validates whether a given namespace exists.
 func (kubeAPI *KubernetesAPI) NamespaceExists(namespace string) (bool, error) {
 	ns, err := kubeAPI.CoreV1().Namespaces().Get(namespace, metav1.GetOptions{})
@@ -179,6 +202,9 @@ func GetPodStatus(pod corev1.Pod) string {
 		switch {
 		case container.State.Terminated != nil && container.State.Terminated.ExitCode == 0 && container.State.Terminated.Signal == 0:
 			continue
+		case container.Name == ProxyContainerName && container.Ready && container.State.Running != nil:
+			// a native sidecar proxy keeps running alongside the app
+			continue
 		case container.State.Terminated != nil:
 			// initialization is failed
 			if len(container.State.Terminated.Reason) == 0 {
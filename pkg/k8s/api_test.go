@@ -284,6 +284,40 @@ status:
   - state:
       waiting:
         reason: PodInitializing
+`,
+		},
+		{
+			desc:     "Pod native sidecar proxy is running",
+			expected: "Running",
+			pod: `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: emoji
+  namespace: emojivoto
+  labels:
+    app: emoji-svc
+    linkerd.io/control-plane-ns: linkerd
+spec:
+  initContainers:
+  - name: linkerd-init
+  - name: linkerd-proxy
+status:
+  phase: Running
+  initContainerStatuses:
+  - name: linkerd-init
+    state:
+      terminated:
+        exitCode: 0
+  - name: linkerd-proxy
+    ready: true
+    state:
+      running: {}
+  containerStatuses:
+  - name: emoji-svc
+    ready: true
+    state:
+      running: {}
 `,
 		},
 	}
@@ -3,6 +3,7 @@ package k8s
 import (
 	"fmt"
 
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 )
@@ -33,10 +34,37 @@ const (
 	LinkAPIGroupVersion = "multicluster.linkerd.io/v1alpha1"
 	LinkKind            = "Link"
 
+	ControlPlaneAPIGroup        = "linkerd.io"
+	ControlPlaneAPIVersion      = "v1alpha1"
+	ControlPlaneAPIGroupVersion = "linkerd.io/v1alpha1"
+	ControlPlaneKind            = "LinkerdControlPlane"
+
+	ProxyConfigAPIGroup        = "config.linkerd.io"
+	ProxyConfigAPIVersion      = "v1alpha1"
+	ProxyConfigAPIGroupVersion = "config.linkerd.io/v1alpha1"
+	ProxyConfigKind            = "ProxyConfig"
+	ClusterProxyConfigKind     = "ClusterProxyConfig"
+
 	// special case k8s job label, to not conflict with Prometheus' job label
 	l5dJob = "k8s_job"
 )
 
+// ProxyConfigGVR is the Group Version and Resource of the namespaced
+// ProxyConfig custom resource.
+var ProxyConfigGVR = schema.GroupVersionResource{
+	Group:    ProxyConfigAPIGroup,
+	Version:  ProxyConfigAPIVersion,
+	Resource: "proxyconfigs",
+}
+
+// ClusterProxyConfigGVR is the Group Version and Resource of the
+// cluster-scoped ClusterProxyConfig custom resource.
+var ClusterProxyConfigGVR = schema.GroupVersionResource{
+	Group:    ProxyConfigAPIGroup,
+	Version:  ProxyConfigAPIVersion,
+	Resource: "clusterproxyconfigs",
+}
+
 type resourceName struct {
 	short  string
 	full   string
@@ -90,6 +90,32 @@ const (
 	// issuer credentials will cease to be valid.
 	IdentityIssuerExpiryAnnotation = Prefix + "/identity-issuer-expiry"
 
+	// IdentityIssuerRotationOverlapAnnotation is set on the issuer secret while
+	// a new issuer certificate is staged, and holds how long the previous trust
+	// anchors must still be trusted once the new issuer is in use.
+	IdentityIssuerRotationOverlapAnnotation = Prefix + "/identity-issuer-rotation-overlap"
+
+	// IdentityIssuerKeyURIAnnotation is set on the issuer secret when the
+	// issuer private key is held by a KMS, and holds the URI of that key.
+	IdentityIssuerKeyURIAnnotation = Prefix + "/identity-issuer-key-uri"
+
+	// TrustBundleLabel is set on the ConfigMaps managed by the trust bundle
+	// controller, so that it only updates and deletes these.
+	TrustBundleLabel = Prefix + "/trust-bundle"
+
+	// IdentityTrustAnchorsOverlapUntilAnnotation is set on the issuer secret
+	// once a new issuer is in use, and holds the time after which the previous
+	// trust anchors can be removed.
+	IdentityTrustAnchorsOverlapUntilAnnotation = Prefix + "/identity-trust-anchors-overlap-until"
+
+	// TrustAnchorsRevisionAnnotation is set on the pod template of the workloads
+	// restarted to pick up new trust anchors, to the revision of those anchors.
+	TrustAnchorsRevisionAnnotation = Prefix + "/trust-anchors-revision"
+
+	// ConfigRevisionAnnotation is set on the linkerd-config-history ConfigMap
+	// to the latest revision it records.
+	ConfigRevisionAnnotation = Prefix + "/config-revision"
+
 	// ProxyVersionAnnotation indicates the version of the injected data plane
 	// (e.g. v0.1.3).
 	ProxyVersionAnnotation = Prefix + "/proxy-version"
@@ -155,6 +181,12 @@ const (
 	// ignoreOutboundPorts config.
 	ProxyIgnoreOutboundPortsAnnotation = ProxyConfigAnnotationsPrefix + "/skip-outbound-ports"
 
+	// ProxyOpaquePortsAnnotation lists the ports whose traffic is opaque to
+	// the proxy, i.e. of protocols that can't be detected like server-speaks-first
+	// ones. The proxy forwards the traffic on these ports, both inbound and
+	// outbound, without trying to detect its protocol.
+	ProxyOpaquePortsAnnotation = ProxyConfigAnnotationsPrefix + "/opaque-ports"
+
 	// ProxyInboundPortAnnotation can be used to override the inboundPort config.
 	ProxyInboundPortAnnotation = ProxyConfigAnnotationsPrefix + "/inbound-port"
 
@@ -178,6 +210,11 @@ const (
 	// ProxyMemoryLimitAnnotation can be used to override the limitMemory config.
 	ProxyMemoryLimitAnnotation = ProxyConfigAnnotationsPrefix + "/proxy-memory-limit"
 
+	// ProxyResourcesProfileAnnotation can be used to set the proxy resources
+	// to the ones of a named sizing profile (small, medium or large). The
+	// resource annotations above take precedence over it.
+	ProxyResourcesProfileAnnotation = ProxyConfigAnnotationsPrefix + "/proxy-resources-profile"
+
 	// ProxyUIDAnnotation can be used to override the UID config.
 	ProxyUIDAnnotation = ProxyConfigAnnotationsPrefix + "/proxy-uid"
 
@@ -210,6 +247,35 @@ const (
 	// timeout in the proxy
 	ProxyInboundConnectTimeout = ProxyConfigAnnotationsPrefix + "/proxy-inbound-connect-timeout"
 
+	// ProxyOutboundConnectBackoffMin can be used to configure the minimum
+	// backoff the proxy applies before retrying a failed outbound connection
+	ProxyOutboundConnectBackoffMin = ProxyConfigAnnotationsPrefix + "/proxy-outbound-connect-backoff-min"
+
+	// ProxyOutboundConnectBackoffMax can be used to configure the maximum
+	// backoff the proxy applies before retrying a failed outbound connection
+	ProxyOutboundConnectBackoffMax = ProxyConfigAnnotationsPrefix + "/proxy-outbound-connect-backoff-max"
+
+	// ProxyOutboundConnectBackoffJitter can be used to configure the jitter
+	// ratio, between 0 and 1, applied to the outbound connection backoff in
+	// the proxy
+	ProxyOutboundConnectBackoffJitter = ProxyConfigAnnotationsPrefix + "/proxy-outbound-connect-backoff-jitter"
+
+	// ProxyEnvAnnotation can be used to set extra environment variables on
+	// the proxy container, as a YAML or JSON map of names to values, e.g.
+	// `{"LINKERD2_PROXY_FEATURE": "enabled"}`, to toggle experimental proxy
+	// features
+	ProxyEnvAnnotation = ProxyConfigAnnotationsPrefix + "/proxy-env"
+
+	// TopologyAwareRoutingAnnotation can be set to "enabled" on a Service for
+	// the destination service to send the proxies only the endpoints in their
+	// own zone, falling back to all the endpoints if there are none
+	TopologyAwareRoutingAnnotation = ProxyConfigAnnotationsPrefix + "/topology-aware-routing"
+
+	// TopologyAwareHintsAnnotation is the upstream annotation set to "auto" on
+	// a Service to enable topology aware hints, which the destination service
+	// honors like the TopologyAwareRoutingAnnotation
+	TopologyAwareHintsAnnotation = "service.kubernetes.io/topology-aware-hints"
+
 	// ProxyEnableGatewayAnnotation can be used to configure the proxy
 	// to operate as a gateway, routing requests that target the inbound router.
 	ProxyEnableGatewayAnnotation = ProxyConfigAnnotationsPrefix + "/enable-gateway"
@@ -224,6 +290,17 @@ const (
 	// injected.
 	ProxyEnableDebugAnnotation = ProxyConfigAnnotationsPrefix + "/enable-debug-sidecar"
 
+	// ProxyJobShutdownAnnotation can be set to false to not inject the
+	// proxy-shutdown container in the pods of Jobs and CronJobs with the Never
+	// restart policy, which otherwise never complete while the proxy keeps
+	// running.
+	ProxyJobShutdownAnnotation = ProxyConfigAnnotationsPrefix + "/proxy-job-shutdown"
+
+	// ProxyEnableNativeSidecarAnnotation can be set to true for the
+	// proxy-injector to add the proxy as a native sidecar container, i.e. an
+	// init container with restartPolicy: Always, on Kubernetes 1.28+.
+	ProxyEnableNativeSidecarAnnotation = ProxyConfigAnnotationsPrefix + "/proxy-enable-native-sidecar"
+
 	// CloseWaitTimeoutAnnotation configures nf_conntrack_tcp_timeout_close_wait.
 	CloseWaitTimeoutAnnotation = ProxyConfigAnnotationsPrefix + "/close-wait-timeout"
 
@@ -242,6 +319,17 @@ const (
 	// mTLS identity.
 	ProxyTraceCollectorSvcAccountAnnotation = ProxyConfigAnnotationsPrefixAlpha + "/trace-collector-service-account"
 
+	// ProxyCertLifetimeAnnotation overrides the lifetime of the certificates
+	// issued to the proxies of a namespace or a service account, e.g. "12h".
+	// Service account annotations take precedence over namespace ones. The
+	// lifetime can't exceed the control plane's issuance lifetime.
+	ProxyCertLifetimeAnnotation = ProxyConfigAnnotationsPrefix + "/proxy-cert-lifetime"
+
+	// ProxyCertRenewalJitterAnnotation overrides the maximum random duration
+	// the lifetime of the certificates issued to the proxies of a namespace or
+	// a service account is shortened by, spreading their renewals.
+	ProxyCertRenewalJitterAnnotation = ProxyConfigAnnotationsPrefix + "/proxy-cert-renewal-jitter"
+
 	// IdentityModeDefault is assigned to IdentityModeAnnotation to
 	// use the control plane's default identity scheme.
 	IdentityModeDefault = "default"
@@ -260,9 +348,25 @@ const (
 	// AddOnsConfigMapName is the name of the ConfigMap containing the linkerd add-ons configuration.
 	AddOnsConfigMapName = "linkerd-config-addons"
 
+	// ConfigHistoryConfigMapName is the name of the ConfigMap recording the
+	// configurations the control plane was installed and upgraded with.
+	ConfigHistoryConfigMapName = "linkerd-config-history"
+
+	// TrustBundleConfigMapName is the name of the ConfigMaps the trust bundle
+	// controller publishes the trust anchors into, in each selected namespace.
+	TrustBundleConfigMapName = "linkerd-trust-bundle"
+
+	// TrustBundleKey is the key of the PEM-encoded trust anchors in the trust
+	// bundle ConfigMaps.
+	TrustBundleKey = "ca-bundle.crt"
+
 	// DebugSidecarName is the name of the default linkerd debug container
 	DebugSidecarName = "linkerd-debug"
 
+	// ProxyShutdownSidecarName is the name of the container shutting down the
+	// proxy of a Job's pod once its other containers have exited
+	ProxyShutdownSidecarName = "linkerd-proxy-shutdown"
+
 	// DebugSidecarImage is the image name of the default linkerd debug container
 	DebugSidecarImage = "ghcr.io/linkerd/debug"
 
@@ -287,15 +391,32 @@ const (
 	// IdentityIssuerSecretName is the name of the Secret that stores issuer credentials.
 	IdentityIssuerSecretName = "linkerd-identity-issuer"
 
+	// CertManagerCertificateNameAnnotation is set by cert-manager on the secrets
+	// it manages, to the name of the Certificate they were issued for.
+	CertManagerCertificateNameAnnotation = "cert-manager.io/certificate-name"
+
 	// IdentityIssuerSchemeLinkerd is the issuer secret scheme used by linkerd
 	IdentityIssuerSchemeLinkerd = "linkerd.io/tls"
 
+	// IdentityIssuerSchemeSpire is the issuer scheme used when the identity
+	// service obtains its issuer certificate from a SPIRE server, in which case
+	// there's no issuer secret
+	IdentityIssuerSchemeSpire = "spiffe.io/spire"
+
 	// IdentityIssuerKeyName is the issuer's private key file.
 	IdentityIssuerKeyName = "key.pem"
 
 	// IdentityIssuerCrtName is the issuer's certificate file.
 	IdentityIssuerCrtName = "crt.pem"
 
+	// IdentityIssuerNextKeyName is the private key file of the issuer staged to
+	// replace the current one.
+	IdentityIssuerNextKeyName = "next-key.pem"
+
+	// IdentityIssuerNextCrtName is the certificate file of the issuer staged to
+	// replace the current one.
+	IdentityIssuerNextCrtName = "next-crt.pem"
+
 	// IdentityIssuerTrustAnchorsNameExternal is the issuer's certificate file (when using cert-manager).
 	IdentityIssuerTrustAnchorsNameExternal = "ca.crt"
 
@@ -418,6 +539,22 @@ const (
 	// GatewayProbePath the path at which the health of the gateway should be probed
 	GatewayProbePath = SvcMirrorPrefix + "/probe-path"
 
+	// FederatedServiceAnnotation can be set to "true" on a local service so
+	// that the destination service also resolves it to the endpoints of its
+	// mirrors from the linked clusters
+	FederatedServiceAnnotation = SvcMirrorPrefix + "/federated"
+
+	// FederatedClusterWeightsAnnotation is a comma-separated list of
+	// cluster=weight pairs weighting the endpoints of a federated service on
+	// each linked cluster. Weights are per endpoint and relative to the
+	// default weight of 10000, which the local endpoints and the endpoints of
+	// the unlisted clusters get: with east=5000, each endpoint of east gets
+	// half the traffic of a local endpoint. Endpoints of clusters with a
+	// weight of 0 are only used when neither the local cluster nor the other
+	// linked clusters have endpoints. Invalid pairs are ignored, and when a
+	// cluster is listed more than once its last weight is used.
+	FederatedClusterWeightsAnnotation = SvcMirrorPrefix + "/cluster-weights"
+
 	// ConfigKeyName is the key in the secret that stores the kubeconfig needed to connect
 	// to a remote cluster
 	ConfigKeyName = "kubeconfig"
@@ -430,8 +567,43 @@ const (
 
 	// ServiceMirrorLabel is the value used in the controller component label
 	ServiceMirrorLabel = "servicemirror"
+
+	// ArchLabel is the well-known label set by the kubelet on every node to the
+	// node's architecture
+	ArchLabel = "kubernetes.io/arch"
+
+	// OSLabel is the well-known label set by the kubelet on every node to the
+	// node's operating system
+	OSLabel = "kubernetes.io/os"
+
+	// BetaOSLabel is the deprecated equivalent of OSLabel, still used in the
+	// nodeSelector of older workloads
+	BetaOSLabel = "beta.kubernetes.io/os"
+
+	// WindowsOS is the value of OSLabel on the Windows nodes, where the proxy
+	// can't run
+	WindowsOS = "windows"
+
+	// CNIResourceLabel is the label set to "true" on the resources installed by
+	// `linkerd install-cni`, including the DaemonSet running the CNI plugin
+	CNIResourceLabel = Prefix + "/cni-resource"
+
+	// CNIReadyNodeLabel is the label set to "true" on the nodes where the
+	// linkerd CNI plugin is known to be active, for the proxy-injector to omit
+	// the init container of the pods scheduled on them
+	CNIReadyNodeLabel = Prefix + "/cni-ready"
 )
 
+// IsWindows returns true if the given node labels, or the nodeSelector of a
+// pod, designate Windows nodes
+func IsWindows(nodeLabels map[string]string) bool {
+	os, ok := nodeLabels[OSLabel]
+	if !ok {
+		os = nodeLabels[BetaOSLabel]
+	}
+	return os == WindowsOS
+}
+
 // CreatedByAnnotationValue returns the value associated with
 // CreatedByAnnotation.
 func CreatedByAnnotationValue() string {
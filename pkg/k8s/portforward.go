@@ -93,6 +93,23 @@ func NewPortForward(
 	return newPortForward(k8sAPI, namespace, podName, host, localPort, remotePort, emitLogs)
 }
 
+// NewPodPortForward returns an instance of the PortForward struct that can be
+// used to establish a port-forward connection to remotePort of a running pod.
+// If localPort is 0, it will use a random ephemeral
+// port.
+func NewPodPortForward(
+	k8sAPI *KubernetesAPI,
+	pod corev1.Pod,
+	host string, localPort, remotePort int,
+	emitLogs bool,
+) (*PortForward, error) {
+	if pod.Status.Phase != corev1.PodRunning {
+		return nil, fmt.Errorf("pod not running: %s", pod.GetName())
+	}
+
+	return newPortForward(k8sAPI, pod.GetNamespace(), pod.GetName(), host, localPort, remotePort, emitLogs)
+}
+
 func newPortForward(
 	k8sAPI *KubernetesAPI,
 	namespace, podName string,
@@ -198,6 +215,11 @@ func (pf *PortForward) URLFor(path string) string {
 	return fmt.Sprintf("http://%s:%d%s", pf.host, pf.localPort, path)
 }
 
+// Address returns the local host:port address of the port-forward connection.
+func (pf *PortForward) Address() string {
+	return fmt.Sprintf("%s:%d", pf.host, pf.localPort)
+}
+
 // getEphemeralPort selects a port for the port-forwarding. It binds to a free
 // ephemeral port and returns the port number.
 func getEphemeralPort() (int, error) {
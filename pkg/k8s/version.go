@@ -5,9 +5,27 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+
+	"k8s.io/apimachinery/pkg/version"
 )
 
-var revisionSeparator = regexp.MustCompile("[^0-9.]")
+var (
+	revisionSeparator = regexp.MustCompile("[^0-9.]")
+
+	// nativeSidecarsVersion is the first Kubernetes version running the init
+	// containers with restartPolicy: Always as sidecar containers
+	nativeSidecarsVersion = [3]int{1, 28, 0}
+)
+
+// SupportsNativeSidecars returns true if the Kubernetes cluster of the given
+// version runs native sidecar containers
+func SupportsNativeSidecars(versionInfo *version.Info) bool {
+	apiVersion, err := getK8sVersion(versionInfo.String())
+	if err != nil {
+		return false
+	}
+	return isCompatibleVersion(nativeSidecarsVersion, apiVersion)
+}
 
 func getK8sVersion(versionString string) ([3]int, error) {
 	var version [3]int
@@ -45,3 +63,13 @@ func isCompatibleVersion(minimalRequirementVersion [3]int, actualVersion [3]int)
 
 	return false
 }
+
+// isTestedVersion returns true if actualVersion isn't more recent than the
+// maximumTestedVersion minor release, regardless of its patch version.
+func isTestedVersion(maximumTestedVersion [2]int, actualVersion [3]int) bool {
+	if actualVersion[0] != maximumTestedVersion[0] {
+		return actualVersion[0] < maximumTestedVersion[0]
+	}
+
+	return actualVersion[1] <= maximumTestedVersion[1]
+}
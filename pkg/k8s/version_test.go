@@ -2,6 +2,8 @@ package k8s
 
 import (
 	"testing"
+
+	"k8s.io/apimachinery/pkg/version"
 )
 
 func TestGetK8sVersion(t *testing.T) {
@@ -82,3 +84,41 @@ func TestIsCompatibleVersion(t *testing.T) {
 		}
 	})
 }
+
+func TestIsTestedVersion(t *testing.T) {
+	testCases := []struct {
+		actual   [3]int
+		expected bool
+	}{
+		{[3]int{1, 19, 0}, true},
+		{[3]int{1, 19, 14}, true},
+		{[3]int{1, 13, 2}, true},
+		{[3]int{0, 20, 0}, true},
+		{[3]int{1, 20, 0}, false},
+		{[3]int{2, 0, 0}, false},
+	}
+
+	for _, tc := range testCases {
+		if tested := isTestedVersion([2]int{1, 19}, tc.actual); tested != tc.expected {
+			t.Errorf("Expected version [%v] to be tested=%t, got %t", tc.actual, tc.expected, tested)
+		}
+	}
+}
+
+func TestSupportsNativeSidecars(t *testing.T) {
+	testCases := []struct {
+		gitVersion string
+		expected   bool
+	}{
+		{"v1.27.9", false},
+		{"v1.28.0", true},
+		{"v1.29.2-eks-5e0fdde", true},
+		{"unknown", false},
+	}
+
+	for _, tc := range testCases {
+		if supported := SupportsNativeSidecars(&version.Info{GitVersion: tc.gitVersion}); supported != tc.expected {
+			t.Errorf("Expected version [%s] to support native sidecars=%t, got %t", tc.gitVersion, tc.expected, supported)
+		}
+	}
+}
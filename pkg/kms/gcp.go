@@ -0,0 +1,183 @@
+package kms
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"sync"
+	"time"
+)
+
+const (
+	gcpKMSEndpoint = "https://cloudkms.googleapis.com/v1/"
+
+	// gcpTokenURL is the metadata server endpoint providing the access tokens
+	// of the service account of the workload (e.g. through GKE Workload
+	// Identity)
+	gcpTokenURL = "http://metadata.google.internal/computeMetadata/v1/instance/service-accounts/default/token"
+
+	// gcpSigningAlgorithm is the only algorithm supported for the issuer keys,
+	// matching the ECDSA P-256 keys generated by Linkerd
+	gcpSigningAlgorithm = "EC_SIGN_P256_SHA256"
+
+	gcpRequestTimeout = 10 * time.Second
+)
+
+var gcpKeyName = regexp.MustCompile(`^projects/[^/]+/locations/[^/]+/keyRings/[^/]+/cryptoKeys/[^/]+/cryptoKeyVersions/[^/]+$`)
+
+// gcpSigner signs digests with a key version of Google Cloud KMS, through
+// its REST API
+type gcpSigner struct {
+	name      string
+	endpoint  string
+	tokenURL  string
+	publicKey crypto.PublicKey
+	client    *http.Client
+
+	token       string
+	tokenExpiry time.Time
+	sync.Mutex  // This mutex protects the token.
+}
+
+func validateGCPKeyName(name string) error {
+	if !gcpKeyName.MatchString(name) {
+		return errors.New("expected projects/<project>/locations/<location>/keyRings/<ring>/cryptoKeys/<key>/cryptoKeyVersions/<version>")
+	}
+	return nil
+}
+
+func newGCPSigner(name, endpoint, tokenURL string) (*gcpSigner, error) {
+	s := &gcpSigner{
+		name:     name,
+		endpoint: endpoint,
+		tokenURL: tokenURL,
+		client:   &http.Client{Timeout: gcpRequestTimeout},
+	}
+
+	var rsp struct {
+		Pem       string `json:"pem"`
+		Algorithm string `json:"algorithm"`
+	}
+	if err := s.call(http.MethodGet, s.endpoint+s.name+"/publicKey", nil, &rsp); err != nil {
+		return nil, fmt.Errorf("failed to get the public key of %s: %s", name, err)
+	}
+	if rsp.Algorithm != gcpSigningAlgorithm {
+		return nil, fmt.Errorf("unsupported algorithm for %s: %s (expected %s)", name, rsp.Algorithm, gcpSigningAlgorithm)
+	}
+
+	block, _ := pem.Decode([]byte(rsp.Pem))
+	if block == nil {
+		return nil, fmt.Errorf("invalid public key for %s", name)
+	}
+	publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key for %s: %s", name, err)
+	}
+	s.publicKey = publicKey
+
+	return s, nil
+}
+
+// Public returns the public key of the KMS key version
+func (s *gcpSigner) Public() crypto.PublicKey {
+	return s.publicKey
+}
+
+// Sign signs a SHA-256 digest, returning an ASN.1 DER encoded signature
+func (s *gcpSigner) Sign(_ io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	if opts.HashFunc() != crypto.SHA256 {
+		return nil, fmt.Errorf("unsupported hash function for %s: %s", s.name, opts.HashFunc())
+	}
+
+	req := map[string]interface{}{
+		"digest": map[string]string{
+			"sha256": base64.StdEncoding.EncodeToString(digest),
+		},
+	}
+	var rsp struct {
+		Signature []byte `json:"signature"`
+	}
+	if err := s.call(http.MethodPost, s.endpoint+s.name+":asymmetricSign", req, &rsp); err != nil {
+		return nil, fmt.Errorf("failed to sign with %s: %s", s.name, err)
+	}
+	return rsp.Signature, nil
+}
+
+func (s *gcpSigner) call(method, url string, body interface{}, rsp interface{}) error {
+	token, err := s.accessToken()
+	if err != nil {
+		return err
+	}
+
+	var reqBody io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(b)
+	}
+	req, err := http.NewRequest(method, url, reqBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	return s.do(req, rsp)
+}
+
+// accessToken returns the access token of the workload, fetching a new one
+// from the metadata server when it's about to expire
+func (s *gcpSigner) accessToken() (string, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.token != "" && time.Now().Add(time.Minute).Before(s.tokenExpiry) {
+		return s.token, nil
+	}
+
+	req, err := http.NewRequest(http.MethodGet, s.tokenURL, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Metadata-Flavor", "Google")
+
+	var rsp struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}
+	if err := s.do(req, &rsp); err != nil {
+		return "", fmt.Errorf("failed to get an access token: %s", err)
+	}
+
+	s.token = rsp.AccessToken
+	s.tokenExpiry = time.Now().Add(time.Duration(rsp.ExpiresIn) * time.Second)
+	return s.token, nil
+}
+
+func (s *gcpSigner) do(req *http.Request, rsp interface{}) error {
+	httpRsp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer httpRsp.Body.Close()
+
+	body, err := ioutil.ReadAll(httpRsp.Body)
+	if err != nil {
+		return err
+	}
+	if httpRsp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s: %s", httpRsp.Status, bytes.TrimSpace(body))
+	}
+	return json.Unmarshal(body, rsp)
+}
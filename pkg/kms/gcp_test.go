@@ -0,0 +1,131 @@
+package kms
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/linkerd/linkerd2/pkg/tls"
+)
+
+const testKeyName = "projects/p/locations/global/keyRings/linkerd/cryptoKeys/issuer/cryptoKeyVersions/1"
+
+// fakeGCPKMS serves the metadata server token endpoint and the Cloud KMS
+// endpoints used by gcpSigner, for a single key version
+func fakeGCPKMS(t *testing.T, key *ecdsa.PrivateKey) *httptest.Server {
+	der, err := x509.MarshalPKIXPublicKey(key.Public())
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	publicKeyPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			if r.Header.Get("Metadata-Flavor") != "Google" {
+				http.Error(w, "missing Metadata-Flavor header", http.StatusForbidden)
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"access_token": "token", "expires_in": 3600})
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer token" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/v1/" + testKeyName + "/publicKey":
+			json.NewEncoder(w).Encode(map[string]string{"pem": string(publicKeyPEM), "algorithm": gcpSigningAlgorithm})
+		case "/v1/" + testKeyName + ":asymmetricSign":
+			var req struct {
+				Digest struct {
+					Sha256 []byte `json:"sha256"`
+				} `json:"digest"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			signature, err := key.Sign(rand.Reader, req.Digest.Sha256, crypto.SHA256)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			json.NewEncoder(w).Encode(map[string][]byte{"signature": signature})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+}
+
+func TestGCPSigner(t *testing.T) {
+	key, err := tls.GenerateKey()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	server := fakeGCPKMS(t, key)
+	defer server.Close()
+
+	signer, err := newGCPSigner(testKeyName, server.URL+"/v1/", server.URL+"/token")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		SignatureAlgorithm:    x509.ECDSAWithSHA256,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, signer.Public(), signer)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	crt, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if err := crt.CheckSignatureFrom(crt); err != nil {
+		t.Fatalf("Expected the certificate to be signed by the KMS key: %s", err)
+	}
+}
+
+func TestParseKeyURI(t *testing.T) {
+	testCases := []struct {
+		uri      string
+		expected string
+		err      string
+	}{
+		{"gcpkms://" + testKeyName, testKeyName, ""},
+		{"gcpkms://projects/p/locations/global/keyRings/linkerd/cryptoKeys/issuer", "", "invalid key URI"},
+		{"awskms://alias/issuer", "", "unsupported KMS"},
+		{testKeyName, "", "invalid key URI"},
+	}
+	for _, tc := range testCases {
+		tc := tc // pin
+		name, err := ParseKeyURI(tc.uri)
+		if tc.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Errorf("Expected error %q for %s, got %v", tc.err, tc.uri, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Unexpected error for %s: %s", tc.uri, err)
+		} else if name != tc.expected {
+			t.Errorf("Expected %s for %s, got %s", tc.expected, tc.uri, name)
+		}
+	}
+}
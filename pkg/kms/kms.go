@@ -0,0 +1,42 @@
+package kms
+
+import (
+	"crypto"
+	"fmt"
+	"strings"
+)
+
+// GCPScheme is the URI scheme of the keys held by Google Cloud KMS, including
+// Cloud HSM keys:
+// gcpkms://projects/<project>/locations/<location>/keyRings/<ring>/cryptoKeys/<key>/cryptoKeyVersions/<version>
+const GCPScheme = "gcpkms"
+
+// NewSigner returns a signer performing the signing operations with the key
+// identified by uri, in the KMS given by its scheme, without ever accessing
+// the private key itself
+func NewSigner(uri string) (crypto.Signer, error) {
+	name, err := ParseKeyURI(uri)
+	if err != nil {
+		return nil, err
+	}
+	return newGCPSigner(name, gcpKMSEndpoint, gcpTokenURL)
+}
+
+// ParseKeyURI checks that uri identifies a key of a supported KMS, returning
+// the name of the key in that KMS
+func ParseKeyURI(uri string) (string, error) {
+	parts := strings.SplitN(uri, "://", 2)
+	if len(parts) != 2 {
+		return "", fmt.Errorf("invalid key URI: %s", uri)
+	}
+
+	switch parts[0] {
+	case GCPScheme:
+		if err := validateGCPKeyName(parts[1]); err != nil {
+			return "", fmt.Errorf("invalid key URI %s: %s", uri, err)
+		}
+		return parts[1], nil
+	default:
+		return "", fmt.Errorf("unsupported KMS in key URI %s; supported KMS: %s", uri, GCPScheme)
+	}
+}
@@ -0,0 +1,186 @@
+package podsecurity
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Level is a level of the Pod Security Standards, enforced by the PodSecurity
+// admission controller
+type Level string
+
+const (
+	// Privileged is the unrestricted level
+	Privileged Level = "privileged"
+
+	// Baseline is the level preventing the known privilege escalations
+	Baseline Level = "baseline"
+
+	// Restricted is the level following the pod hardening best practices
+	Restricted Level = "restricted"
+
+	// EnforceLabel is the namespace label setting the level whose violations
+	// make the PodSecurity admission controller reject pods
+	EnforceLabel = "pod-security.kubernetes.io/enforce"
+
+	seccompPodAnnotation             = "seccomp.security.alpha.kubernetes.io/pod"
+	seccompContainerAnnotationPrefix = "container.seccomp.security.alpha.kubernetes.io/"
+)
+
+// baselineCapabilities are the capabilities the baseline level allows
+// containers to add
+var baselineCapabilities = map[corev1.Capability]bool{
+	"AUDIT_WRITE":      true,
+	"CHOWN":            true,
+	"DAC_OVERRIDE":     true,
+	"FOWNER":           true,
+	"FSETID":           true,
+	"KILL":             true,
+	"MKNOD":            true,
+	"NET_BIND_SERVICE": true,
+	"SETFCAP":          true,
+	"SETGID":           true,
+	"SETPCAP":          true,
+	"SETUID":           true,
+	"SYS_CHROOT":       true,
+}
+
+// NamespaceLevel returns the level enforced in a namespace with the given
+// labels, i.e. Privileged if it has none
+func NamespaceLevel(labels map[string]string) (Level, error) {
+	switch level := Level(labels[EnforceLabel]); level {
+	case "", Privileged:
+		return Privileged, nil
+	case Baseline, Restricted:
+		return level, nil
+	default:
+		return "", fmt.Errorf("invalid %s label %q", EnforceLabel, level)
+	}
+}
+
+// Violations returns the reasons why the PodSecurity admission controller
+// would reject pod at level, sorted. Only the fields known to this version of
+// the Kubernetes API are checked, e.g. the seccomp profiles are read from the
+// annotations.
+func Violations(level Level, pod *corev1.Pod) []string {
+	if level != Baseline && level != Restricted {
+		return nil
+	}
+
+	found := map[string]bool{}
+	add := func(format string, args ...interface{}) {
+		found[fmt.Sprintf(format, args...)] = true
+	}
+
+	spec := pod.Spec
+	if spec.HostNetwork {
+		add("hostNetwork is true")
+	}
+	if spec.HostPID {
+		add("hostPID is true")
+	}
+	if spec.HostIPC {
+		add("hostIPC is true")
+	}
+	for _, v := range spec.Volumes {
+		if v.HostPath != nil {
+			add("volume %s is a hostPath", v.Name)
+		} else if level == Restricted && !restrictedVolume(v) {
+			add("volume %s has a restricted type", v.Name)
+		}
+	}
+
+	podSeccomp := pod.Annotations[seccompPodAnnotation]
+	podNonRoot := false
+	if sc := spec.SecurityContext; sc != nil {
+		podNonRoot = sc.RunAsNonRoot != nil && *sc.RunAsNonRoot
+		if level == Restricted && sc.RunAsUser != nil && *sc.RunAsUser == 0 {
+			add("pod runAsUser is 0")
+		}
+	}
+
+	for _, c := range allContainers(spec) {
+		for _, p := range c.Ports {
+			if p.HostPort != 0 {
+				add("container %s uses hostPort %d", c.Name, p.HostPort)
+			}
+		}
+
+		seccomp := podSeccomp
+		if s, ok := pod.Annotations[seccompContainerAnnotationPrefix+c.Name]; ok {
+			seccomp = s
+		}
+		if seccomp == "unconfined" {
+			add("container %s has an unconfined seccomp profile", c.Name)
+		} else if level == Restricted && seccomp != "runtime/default" && seccomp != "docker/default" && !strings.HasPrefix(seccomp, "localhost/") {
+			add("container %s doesn't set a RuntimeDefault or Localhost seccomp profile", c.Name)
+		}
+
+		sc := c.SecurityContext
+		if sc == nil {
+			sc = &corev1.SecurityContext{}
+		}
+		if sc.Privileged != nil && *sc.Privileged {
+			add("container %s is privileged", c.Name)
+		}
+		if sc.Capabilities != nil {
+			for _, capability := range sc.Capabilities.Add {
+				if !baselineCapabilities[capability] || (level == Restricted && capability != "NET_BIND_SERVICE") {
+					add("container %s adds the %s capability", c.Name, capability)
+				}
+			}
+		}
+
+		if level != Restricted {
+			continue
+		}
+		if sc.AllowPrivilegeEscalation == nil || *sc.AllowPrivilegeEscalation {
+			add("container %s doesn't set allowPrivilegeEscalation to false", c.Name)
+		}
+		if !dropsAll(sc.Capabilities) {
+			add("container %s doesn't drop the ALL capability", c.Name)
+		}
+		if sc.RunAsUser != nil && *sc.RunAsUser == 0 {
+			add("container %s runAsUser is 0", c.Name)
+		}
+		nonRoot := podNonRoot
+		if sc.RunAsNonRoot != nil {
+			nonRoot = *sc.RunAsNonRoot
+		}
+		if !nonRoot {
+			add("container %s doesn't set runAsNonRoot to true", c.Name)
+		}
+	}
+
+	violations := make([]string, 0, len(found))
+	for v := range found {
+		violations = append(violations, v)
+	}
+	sort.Strings(violations)
+	return violations
+}
+
+func allContainers(spec corev1.PodSpec) []corev1.Container {
+	return append(append([]corev1.Container{}, spec.InitContainers...), spec.Containers...)
+}
+
+func restrictedVolume(v corev1.Volume) bool {
+	s := v.VolumeSource
+	return s.ConfigMap != nil || s.CSI != nil || s.DownwardAPI != nil || s.EmptyDir != nil ||
+		s.PersistentVolumeClaim != nil || s.Projected != nil || s.Secret != nil
+}
+
+func dropsAll(capabilities *corev1.Capabilities) bool {
+	if capabilities == nil {
+		return false
+	}
+	for _, c := range capabilities.Drop {
+		if c == "ALL" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,136 @@
+package podsecurity
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNamespaceLevel(t *testing.T) {
+	testCases := []struct {
+		labels   map[string]string
+		expected Level
+		err      bool
+	}{
+		{nil, Privileged, false},
+		{map[string]string{EnforceLabel: "baseline"}, Baseline, false},
+		{map[string]string{EnforceLabel: "restricted"}, Restricted, false},
+		{map[string]string{EnforceLabel: "strict"}, "", true},
+	}
+	for _, tc := range testCases {
+		level, err := NamespaceLevel(tc.labels)
+		if (err != nil) != tc.err || level != tc.expected {
+			t.Errorf("Expected level %q and error %t for %v, got %q and %v", tc.expected, tc.err, tc.labels, level, err)
+		}
+	}
+}
+
+func TestViolations(t *testing.T) {
+	yes, no := true, false
+	root, proxyUID := int64(0), int64(2102)
+
+	proxy := corev1.Container{
+		Name: "linkerd-proxy",
+		SecurityContext: &corev1.SecurityContext{
+			AllowPrivilegeEscalation: &no,
+			ReadOnlyRootFilesystem:   &yes,
+			RunAsUser:                &proxyUID,
+		},
+	}
+	proxyInit := corev1.Container{
+		Name: "linkerd-init",
+		SecurityContext: &corev1.SecurityContext{
+			AllowPrivilegeEscalation: &no,
+			Capabilities:             &corev1.Capabilities{Add: []corev1.Capability{"NET_ADMIN", "NET_RAW"}},
+			RunAsUser:                &root,
+		},
+	}
+	hardenedProxy := corev1.Container{
+		Name: "linkerd-proxy",
+		SecurityContext: &corev1.SecurityContext{
+			AllowPrivilegeEscalation: &no,
+			Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+			RunAsNonRoot:             &yes,
+			RunAsUser:                &proxyUID,
+		},
+	}
+
+	testCases := []struct {
+		name     string
+		level    Level
+		pod      *corev1.Pod
+		expected []string
+	}{
+		{
+			name:     "privileged allows anything",
+			level:    Privileged,
+			pod:      &corev1.Pod{Spec: corev1.PodSpec{HostNetwork: true, InitContainers: []corev1.Container{proxyInit}}},
+			expected: nil,
+		},
+		{
+			name:  "baseline rejects proxy-init",
+			level: Baseline,
+			pod:   &corev1.Pod{Spec: corev1.PodSpec{InitContainers: []corev1.Container{proxyInit}, Containers: []corev1.Container{proxy}}},
+			expected: []string{
+				"container linkerd-init adds the NET_ADMIN capability",
+				"container linkerd-init adds the NET_RAW capability",
+			},
+		},
+		{
+			name:     "baseline allows the proxy",
+			level:    Baseline,
+			pod:      &corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{proxy}}},
+			expected: []string{},
+		},
+		{
+			name:  "restricted rejects the default proxy",
+			level: Restricted,
+			pod:   &corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{proxy}}},
+			expected: []string{
+				"container linkerd-proxy doesn't drop the ALL capability",
+				"container linkerd-proxy doesn't set a RuntimeDefault or Localhost seccomp profile",
+				"container linkerd-proxy doesn't set runAsNonRoot to true",
+			},
+		},
+		{
+			name:  "restricted allows a hardened proxy",
+			level: Restricted,
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{seccompPodAnnotation: "runtime/default"}},
+				Spec:       corev1.PodSpec{Containers: []corev1.Container{hardenedProxy}},
+			},
+			expected: []string{},
+		},
+		{
+			name:  "baseline rejects host namespaces and volumes",
+			level: Baseline,
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{seccompContainerAnnotationPrefix + "linkerd-proxy": "unconfined"}},
+				Spec: corev1.PodSpec{
+					HostNetwork: true,
+					HostPID:     true,
+					Volumes:     []corev1.Volume{{Name: "run", VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: "/run"}}}},
+					Containers:  []corev1.Container{proxy},
+				},
+			},
+			expected: []string{
+				"container linkerd-proxy has an unconfined seccomp profile",
+				"hostNetwork is true",
+				"hostPID is true",
+				"volume run is a hostPath",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.name, func(t *testing.T) {
+			violations := Violations(tc.level, tc.pod)
+			if !reflect.DeepEqual(violations, tc.expected) {
+				t.Errorf("Expected violations %v, got %v", tc.expected, violations)
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package profiles
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	sp "github.com/linkerd/linkerd2/controller/gen/apis/serviceprofile/v1alpha2"
+	rpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/protobuf/types/descriptorpb"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const reflectionServiceName = "grpc.reflection.v1alpha.ServerReflection"
+
+// RenderGRPCReflection lists the services of a running gRPC server through
+// its server reflection service, and renders the corresponding ServiceProfile
+// to a buffer, given a namespace, service, and control plane namespace.
+func RenderGRPCReflection(client rpb.ServerReflectionClient, namespace, name, clusterDomain string, timeout time.Duration, w io.Writer) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	profile, err := grpcReflectionToServiceProfile(ctx, client, namespace, name, clusterDomain)
+	if err != nil {
+		return err
+	}
+
+	return writeProfile(*profile, w)
+}
+
+func grpcReflectionToServiceProfile(ctx context.Context, client rpb.ServerReflectionClient, namespace, name, clusterDomain string) (*sp.ServiceProfile, error) {
+	stream, err := client.ServerReflectionInfo(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query the gRPC server reflection service: %s", err)
+	}
+	defer stream.CloseSend()
+
+	rsp, err := reflectionRequest(stream, &rpb.ServerReflectionRequest{
+		MessageRequest: &rpb.ServerReflectionRequest_ListServices{},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	services := make([]string, 0)
+	for _, service := range rsp.GetListServicesResponse().GetService() {
+		if service.GetName() != reflectionServiceName {
+			services = append(services, service.GetName())
+		}
+	}
+	sort.Strings(services)
+
+	routes := make([]*sp.RouteSpec, 0)
+	for _, service := range services {
+		methods, err := serviceMethods(stream, service)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, method := range methods {
+			route := &sp.RouteSpec{
+				Name: method,
+				Condition: &sp.RequestMatch{
+					Method:    http.MethodPost,
+					PathRegex: regexp.QuoteMeta(fmt.Sprintf("/%s/%s", service, method)),
+				},
+			}
+			routes = append(routes, route)
+		}
+	}
+
+	return &sp.ServiceProfile{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s.%s.svc.%s", name, namespace, clusterDomain),
+			Namespace: namespace,
+		},
+		TypeMeta: serviceProfileMeta,
+		Spec: sp.ServiceProfileSpec{
+			Routes: routes,
+		},
+	}, nil
+}
+
+// serviceMethods returns the names of the methods of the fully qualified
+// service, from the file descriptors the reflection service returns for it.
+func serviceMethods(stream rpb.ServerReflection_ServerReflectionInfoClient, service string) ([]string, error) {
+	rsp, err := reflectionRequest(stream, &rpb.ServerReflectionRequest{
+		MessageRequest: &rpb.ServerReflectionRequest_FileContainingSymbol{
+			FileContainingSymbol: service,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, encoded := range rsp.GetFileDescriptorResponse().GetFileDescriptorProto() {
+		file := &descriptorpb.FileDescriptorProto{}
+		if err := proto.Unmarshal(encoded, file); err != nil {
+			return nil, fmt.Errorf("invalid file descriptor for %s: %s", service, err)
+		}
+
+		for _, s := range file.GetService() {
+			fullName := s.GetName()
+			if file.GetPackage() != "" {
+				fullName = fmt.Sprintf("%s.%s", file.GetPackage(), s.GetName())
+			}
+			if fullName != service {
+				continue
+			}
+
+			methods := make([]string, 0, len(s.GetMethod()))
+			for _, m := range s.GetMethod() {
+				methods = append(methods, m.GetName())
+			}
+			return methods, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no descriptor found for service %s", service)
+}
+
+func reflectionRequest(stream rpb.ServerReflection_ServerReflectionInfoClient, req *rpb.ServerReflectionRequest) (*rpb.ServerReflectionResponse, error) {
+	if err := stream.Send(req); err != nil {
+		return nil, fmt.Errorf("failed to query the gRPC server reflection service: %s", err)
+	}
+
+	rsp, err := stream.Recv()
+	if err != nil {
+		return nil, fmt.Errorf("failed to query the gRPC server reflection service: %s", err)
+	}
+
+	if errRsp := rsp.GetErrorResponse(); errRsp != nil {
+		return nil, fmt.Errorf("gRPC server reflection error: %s", errRsp.GetErrorMessage())
+	}
+
+	return rsp, nil
+}
@@ -0,0 +1,74 @@
+package profiles
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	sp "github.com/linkerd/linkerd2/controller/gen/apis/serviceprofile/v1alpha2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	rpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/grpc/test/bufconn"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGRPCReflectionToServiceProfile(t *testing.T) {
+	namespace := "myns"
+	name := "mysvc"
+	clusterDomain := "mycluster.local"
+
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	healthpb.RegisterHealthServer(server, health.NewServer())
+	reflection.Register(server)
+	go server.Serve(listener)
+	defer server.Stop()
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return listener.Dial() }),
+		grpc.WithInsecure(),
+	)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	defer conn.Close()
+
+	expectedServiceProfile := sp.ServiceProfile{
+		TypeMeta: serviceProfileMeta,
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name + "." + namespace + ".svc." + clusterDomain,
+			Namespace: namespace,
+		},
+		Spec: sp.ServiceProfileSpec{
+			Routes: []*sp.RouteSpec{
+				{
+					Name: "Check",
+					Condition: &sp.RequestMatch{
+						PathRegex: `/grpc\.health\.v1\.Health/Check`,
+						Method:    "POST",
+					},
+				},
+				{
+					Name: "Watch",
+					Condition: &sp.RequestMatch{
+						PathRegex: `/grpc\.health\.v1\.Health/Watch`,
+						Method:    "POST",
+					},
+				},
+			},
+		},
+	}
+
+	actualServiceProfile, err := grpcReflectionToServiceProfile(context.Background(), rpb.NewServerReflectionClient(conn), namespace, name, clusterDomain)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	err = ServiceProfileYamlEquals(*actualServiceProfile, expectedServiceProfile)
+	if err != nil {
+		t.Fatalf("ServiceProfiles are not equal: %v", err)
+	}
+}
@@ -24,8 +24,9 @@ var pathParamRegex = regexp.MustCompile(`\\{[^\}]*\\}`)
 
 // RenderOpenAPI reads an OpenAPI spec file and renders the corresponding
 // ServiceProfile to a buffer, given a namespace, service, and control plane
-// namespace.
-func RenderOpenAPI(fileName, namespace, name, clusterDomain string, w io.Writer) error {
+// namespace. Both Swagger 2.0 and OpenAPI 3.x spec files are supported. The
+// options are applied to all the routes.
+func RenderOpenAPI(fileName, namespace, name, clusterDomain string, options RouteOptions, w io.Writer) error {
 
 	input, err := readFile(fileName)
 	if err != nil {
@@ -41,18 +42,36 @@ func RenderOpenAPI(fileName, namespace, name, clusterDomain string, w io.Writer)
 		return fmt.Errorf("Error parsing yaml: %s", err)
 	}
 
+	if isOpenAPI3(json) {
+		openAPI, err := parseOpenAPI3(json)
+		if err != nil {
+			return fmt.Errorf("Error parsing OpenAPI spec: %s", err)
+		}
+
+		profile, pinned, err := openAPI3ToServiceProfile(openAPI, namespace, name, clusterDomain)
+		if err != nil {
+			return fmt.Errorf("Error parsing OpenAPI spec: %s", err)
+		}
+
+		options.apply(&profile, pinned)
+		return writeProfile(profile, w)
+	}
+
 	swagger := spec.Swagger{}
 	err = swagger.UnmarshalJSON(json)
 	if err != nil {
 		return fmt.Errorf("Error parsing OpenAPI spec: %s", err)
 	}
 
-	profile := swaggerToServiceProfile(swagger, namespace, name, clusterDomain)
+	profile, pinned := swaggerToServiceProfile(swagger, namespace, name, clusterDomain)
+	options.apply(&profile, pinned)
 
 	return writeProfile(profile, w)
 }
 
-func swaggerToServiceProfile(swagger spec.Swagger, namespace, name, clusterDomain string) sp.ServiceProfile {
+// swaggerToServiceProfile returns the ServiceProfile of a Swagger 2.0 spec,
+// and its routes whose operation sets x-linkerd-retryable.
+func swaggerToServiceProfile(swagger spec.Swagger, namespace, name, clusterDomain string) (sp.ServiceProfile, routeSet) {
 	profile := sp.ServiceProfile{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      fmt.Sprintf("%s.%s.svc.%s", name, namespace, clusterDomain),
@@ -62,6 +81,7 @@ func swaggerToServiceProfile(swagger spec.Swagger, namespace, name, clusterDomai
 	}
 
 	routes := make([]*sp.RouteSpec, 0)
+	pinned := routeSet{}
 
 	paths := make([]string, 0)
 	if swagger.Paths != nil {
@@ -78,35 +98,50 @@ func swaggerToServiceProfile(swagger spec.Swagger, namespace, name, clusterDomai
 		if item.Delete != nil {
 			spec := mkRouteSpec(path, pathRegex, http.MethodDelete, item.Delete)
 			routes = append(routes, spec)
+			pinned.addIfRetryableSet(spec, item.Delete)
 		}
 		if item.Get != nil {
 			spec := mkRouteSpec(path, pathRegex, http.MethodGet, item.Get)
 			routes = append(routes, spec)
+			pinned.addIfRetryableSet(spec, item.Get)
 		}
 		if item.Head != nil {
 			spec := mkRouteSpec(path, pathRegex, http.MethodHead, item.Head)
 			routes = append(routes, spec)
+			pinned.addIfRetryableSet(spec, item.Head)
 		}
 		if item.Options != nil {
 			spec := mkRouteSpec(path, pathRegex, http.MethodOptions, item.Options)
 			routes = append(routes, spec)
+			pinned.addIfRetryableSet(spec, item.Options)
 		}
 		if item.Patch != nil {
 			spec := mkRouteSpec(path, pathRegex, http.MethodPatch, item.Patch)
 			routes = append(routes, spec)
+			pinned.addIfRetryableSet(spec, item.Patch)
 		}
 		if item.Post != nil {
 			spec := mkRouteSpec(path, pathRegex, http.MethodPost, item.Post)
 			routes = append(routes, spec)
+			pinned.addIfRetryableSet(spec, item.Post)
 		}
 		if item.Put != nil {
 			spec := mkRouteSpec(path, pathRegex, http.MethodPut, item.Put)
 			routes = append(routes, spec)
+			pinned.addIfRetryableSet(spec, item.Put)
 		}
 	}
 
 	profile.Spec.Routes = routes
-	return profile
+	return profile, pinned
+}
+
+// addIfRetryableSet adds route to the set if its operation sets
+// x-linkerd-retryable.
+func (s routeSet) addIfRetryableSet(route *sp.RouteSpec, operation *spec.Operation) {
+	if _, ok := operation.VendorExtensible.Extensions.GetBool(xLinkerdRetryable); ok {
+		s[route] = struct{}{}
+	}
 }
 
 func mkRouteSpec(path, pathRegex string, method string, operation *spec.Operation) *sp.RouteSpec {
@@ -0,0 +1,281 @@
+package profiles
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	sp "github.com/linkerd/linkerd2/controller/gen/apis/serviceprofile/v1alpha2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const pathItemRefPrefix = "#/components/pathItems/"
+
+// openAPI3 holds the parts of an OpenAPI 3.0 or 3.1 document that are
+// relevant to service profiles. The schemas aren't decoded: the service
+// profile routes only depend on the paths, the methods and the response
+// status codes.
+type openAPI3 struct {
+	OpenAPI    string                      `json:"openapi"`
+	Servers    []openAPI3Server            `json:"servers"`
+	Paths      map[string]openAPI3PathItem `json:"paths"`
+	Components struct {
+		PathItems map[string]openAPI3PathItem `json:"pathItems"`
+	} `json:"components"`
+}
+
+type openAPI3Server struct {
+	URL       string `json:"url"`
+	Variables map[string]struct {
+		Default string `json:"default"`
+	} `json:"variables"`
+}
+
+type openAPI3PathItem struct {
+	Ref     string             `json:"$ref"`
+	Servers []openAPI3Server   `json:"servers"`
+	Get     *openAPI3Operation `json:"get"`
+	Put     *openAPI3Operation `json:"put"`
+	Post    *openAPI3Operation `json:"post"`
+	Delete  *openAPI3Operation `json:"delete"`
+	Options *openAPI3Operation `json:"options"`
+	Head    *openAPI3Operation `json:"head"`
+	Patch   *openAPI3Operation `json:"patch"`
+	Trace   *openAPI3Operation `json:"trace"`
+}
+
+type openAPI3Operation struct {
+	Servers   []openAPI3Server           `json:"servers"`
+	Responses map[string]json.RawMessage `json:"responses"`
+	Retryable *bool                      `json:"x-linkerd-retryable"`
+	Timeout   string                     `json:"x-linkerd-timeout"`
+}
+
+// isOpenAPI3 returns true if the JSON document is an OpenAPI 3.x document, as
+// opposed to a Swagger 2.0 one.
+func isOpenAPI3(doc []byte) bool {
+	var version struct {
+		OpenAPI string `json:"openapi"`
+	}
+	if err := json.Unmarshal(doc, &version); err != nil {
+		return false
+	}
+	return strings.HasPrefix(version.OpenAPI, "3.")
+}
+
+func parseOpenAPI3(doc []byte) (openAPI3, error) {
+	var openAPI openAPI3
+	if err := json.Unmarshal(doc, &openAPI); err != nil {
+		return openAPI, err
+	}
+	return openAPI, nil
+}
+
+// openAPI3ToServiceProfile returns the ServiceProfile of an OpenAPI 3.x spec,
+// and its routes whose operation sets x-linkerd-retryable.
+func openAPI3ToServiceProfile(openAPI openAPI3, namespace, name, clusterDomain string) (sp.ServiceProfile, routeSet, error) {
+	profile := sp.ServiceProfile{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s.%s.svc.%s", name, namespace, clusterDomain),
+			Namespace: namespace,
+		},
+		TypeMeta: serviceProfileMeta,
+	}
+
+	paths := make([]string, 0, len(openAPI.Paths))
+	for path := range openAPI.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	routes := make([]*sp.RouteSpec, 0)
+	pinned := routeSet{}
+	for _, relPath := range paths {
+		item, err := openAPI.resolvePathItem(openAPI.Paths[relPath])
+		if err != nil {
+			return profile, nil, err
+		}
+
+		// Same order as for Swagger 2.0 documents
+		operations := []struct {
+			method    string
+			operation *openAPI3Operation
+		}{
+			{http.MethodDelete, item.Delete},
+			{http.MethodGet, item.Get},
+			{http.MethodHead, item.Head},
+			{http.MethodOptions, item.Options},
+			{http.MethodPatch, item.Patch},
+			{http.MethodPost, item.Post},
+			{http.MethodPut, item.Put},
+			{http.MethodTrace, item.Trace},
+		}
+		for _, op := range operations {
+			if op.operation == nil {
+				continue
+			}
+
+			// The servers of an operation override those of its path, which
+			// override those of the document
+			servers := openAPI.Servers
+			if len(item.Servers) > 0 {
+				servers = item.Servers
+			}
+			if len(op.operation.Servers) > 0 {
+				servers = op.operation.Servers
+			}
+
+			basePaths, err := serverBasePaths(servers)
+			if err != nil {
+				return profile, nil, err
+			}
+			for _, basePath := range basePaths {
+				route := mkOpenAPI3RouteSpec(path.Join(basePath, relPath), op.method, op.operation)
+				if op.operation.Retryable != nil {
+					pinned[route] = struct{}{}
+				}
+				routes = append(routes, route)
+			}
+		}
+	}
+
+	profile.Spec.Routes = routes
+	return profile, pinned, nil
+}
+
+// resolvePathItem returns the path item a path item refers to with $ref, which
+// OpenAPI 3.1 allows to point to the pathItems of the components.
+func (o openAPI3) resolvePathItem(item openAPI3PathItem) (openAPI3PathItem, error) {
+	if item.Ref == "" {
+		return item, nil
+	}
+	if !strings.HasPrefix(item.Ref, pathItemRefPrefix) {
+		return item, fmt.Errorf("unsupported path item reference %s", item.Ref)
+	}
+	resolved, ok := o.Components.PathItems[strings.TrimPrefix(item.Ref, pathItemRefPrefix)]
+	if !ok || resolved.Ref != "" {
+		return item, fmt.Errorf("unresolved path item reference %s", item.Ref)
+	}
+	return resolved, nil
+}
+
+// serverBasePaths returns the distinct paths of the URLs of servers, with
+// their variables substituted by their default values. The URLs can be
+// relative to the document, like "/v1".
+func serverBasePaths(servers []openAPI3Server) ([]string, error) {
+	if len(servers) == 0 {
+		return []string{"/"}, nil
+	}
+
+	seen := make(map[string]struct{})
+	basePaths := make([]string, 0, len(servers))
+	for _, server := range servers {
+		rawURL := server.URL
+		for name, variable := range server.Variables {
+			rawURL = strings.ReplaceAll(rawURL, "{"+name+"}", variable.Default)
+		}
+
+		u, err := url.Parse(rawURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid server URL %s: %s", server.URL, err)
+		}
+
+		basePath := path.Join("/", u.Path)
+		if _, ok := seen[basePath]; ok {
+			continue
+		}
+		seen[basePath] = struct{}{}
+		basePaths = append(basePaths, basePath)
+	}
+	return basePaths, nil
+}
+
+// mkOpenAPI3RouteSpec returns the route of an operation. Unless the operation
+// sets x-linkerd-retryable, the route is retryable if its method is
+// idempotent and it has failure response classes to retry on.
+func mkOpenAPI3RouteSpec(path, method string, operation *openAPI3Operation) *sp.RouteSpec {
+	classes := toOpenAPI3RspClasses(operation.Responses)
+
+	var retryable bool
+	if operation.Retryable != nil {
+		retryable = *operation.Retryable
+	} else if isIdempotent(method) {
+		for _, class := range classes {
+			if class.IsFailure {
+				retryable = true
+				break
+			}
+		}
+	}
+
+	return &sp.RouteSpec{
+		Name:            fmt.Sprintf("%s %s", method, path),
+		Condition:       toReqMatch(pathToRegex(path), method),
+		ResponseClasses: classes,
+		IsRetryable:     retryable,
+		Timeout:         operation.Timeout,
+	}
+}
+
+// toOpenAPI3RspClasses returns the response classes of the status codes of
+// responses, which can be exact codes like "503" or ranges like "5XX". The
+// "default" response doesn't have a status code and is ignored.
+func toOpenAPI3RspClasses(responses map[string]json.RawMessage) []*sp.ResponseClass {
+	if responses == nil {
+		return nil
+	}
+
+	ranges := make([]*sp.Range, 0, len(responses))
+	for status := range responses {
+		if r := parseStatusRange(status); r != nil {
+			ranges = append(ranges, r)
+		}
+	}
+	sort.Slice(ranges, func(i, j int) bool {
+		if ranges[i].Min != ranges[j].Min {
+			return ranges[i].Min < ranges[j].Min
+		}
+		return ranges[i].Max < ranges[j].Max
+	})
+
+	classes := make([]*sp.ResponseClass, 0, len(ranges))
+	for _, r := range ranges {
+		classes = append(classes, &sp.ResponseClass{
+			Condition: &sp.ResponseMatch{Status: r},
+			IsFailure: r.Min >= 500,
+		})
+	}
+	return classes
+}
+
+func parseStatusRange(status string) *sp.Range {
+	if len(status) == 3 && (status[1:] == "XX" || status[1:] == "xx") {
+		class, err := strconv.ParseUint(status[:1], 10, 32)
+		if err != nil || class < 1 || class > 5 {
+			return nil
+		}
+		return &sp.Range{Min: uint32(class) * 100, Max: uint32(class)*100 + 99}
+	}
+
+	code, err := strconv.ParseUint(status, 10, 32)
+	if err != nil || code < 100 || code > 599 {
+		return nil
+	}
+	return &sp.Range{Min: uint32(code), Max: uint32(code)}
+}
+
+// isIdempotent returns true for the methods that RFC 7231 defines as
+// idempotent, whose requests can safely be retried.
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete, http.MethodTrace:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,174 @@
+package profiles
+
+import (
+	"testing"
+
+	sp "github.com/linkerd/linkerd2/controller/gen/apis/serviceprofile/v1alpha2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+const openAPI3Spec = `
+openapi: 3.1.0
+info:
+  title: books
+  version: 1.0.0
+servers:
+- url: https://{host}/{basePath}
+  variables:
+    host:
+      default: books.example.com
+    basePath:
+      default: v1
+- url: /v1
+paths:
+  /books/{id}:
+    parameters:
+    - name: id
+      in: path
+      required: true
+      schema:
+        type: string
+    get:
+      responses:
+        "200":
+          content:
+            application/json:
+              schema:
+                oneOf:
+                - $ref: '#/components/schemas/Book'
+                - $ref: '#/components/schemas/Magazine'
+        "404":
+          description: not found
+        5XX:
+          description: server error
+        default:
+          description: unexpected error
+    post:
+      x-linkerd-timeout: 30s
+      responses:
+        "503":
+          description: unavailable
+  /health:
+    $ref: '#/components/pathItems/health'
+components:
+  pathItems:
+    health:
+      servers:
+      - url: /
+      get:
+        x-linkerd-retryable: false
+        responses:
+          "503":
+            description: unhealthy
+`
+
+func TestOpenAPI3ToServiceProfile(t *testing.T) {
+	namespace := "myns"
+	name := "mysvc"
+	clusterDomain := "mycluster.local"
+
+	doc, err := yaml.YAMLToJSON([]byte(openAPI3Spec))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if !isOpenAPI3(doc) {
+		t.Fatal("Expected an OpenAPI 3 document")
+	}
+
+	openAPI, err := parseOpenAPI3(doc)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	expectedServiceProfile := sp.ServiceProfile{
+		TypeMeta: serviceProfileMeta,
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name + "." + namespace + ".svc." + clusterDomain,
+			Namespace: namespace,
+		},
+		Spec: sp.ServiceProfileSpec{
+			Routes: []*sp.RouteSpec{
+				{
+					Name: "GET /v1/books/{id}",
+					Condition: &sp.RequestMatch{
+						PathRegex: "/v1/books/[^/]*",
+						Method:    "GET",
+					},
+					ResponseClasses: []*sp.ResponseClass{
+						{Condition: &sp.ResponseMatch{Status: &sp.Range{Min: 200, Max: 200}}},
+						{Condition: &sp.ResponseMatch{Status: &sp.Range{Min: 404, Max: 404}}},
+						{Condition: &sp.ResponseMatch{Status: &sp.Range{Min: 500, Max: 599}}, IsFailure: true},
+					},
+					IsRetryable: true,
+				},
+				{
+					Name: "POST /v1/books/{id}",
+					Condition: &sp.RequestMatch{
+						PathRegex: "/v1/books/[^/]*",
+						Method:    "POST",
+					},
+					ResponseClasses: []*sp.ResponseClass{
+						{Condition: &sp.ResponseMatch{Status: &sp.Range{Min: 503, Max: 503}}, IsFailure: true},
+					},
+					Timeout: "30s",
+				},
+				{
+					Name: "GET /health",
+					Condition: &sp.RequestMatch{
+						PathRegex: "/health",
+						Method:    "GET",
+					},
+					ResponseClasses: []*sp.ResponseClass{
+						{Condition: &sp.ResponseMatch{Status: &sp.Range{Min: 503, Max: 503}}, IsFailure: true},
+					},
+				},
+			},
+		},
+	}
+
+	actualServiceProfile, _, err := openAPI3ToServiceProfile(openAPI, namespace, name, clusterDomain)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	err = ServiceProfileYamlEquals(actualServiceProfile, expectedServiceProfile)
+	if err != nil {
+		t.Fatalf("ServiceProfiles are not equal: %v", err)
+	}
+}
+
+func TestServerBasePaths(t *testing.T) {
+	testCases := []struct {
+		servers  []openAPI3Server
+		expected []string
+	}{
+		{nil, []string{"/"}},
+		{[]openAPI3Server{{URL: "https://example.com"}}, []string{"/"}},
+		{[]openAPI3Server{{URL: "https://example.com/api/"}, {URL: "/api"}, {URL: "/v2"}}, []string{"/api", "/v2"}},
+	}
+
+	for i, tc := range testCases {
+		actual, err := serverBasePaths(tc.servers)
+		if err != nil {
+			t.Fatalf("test case %d: unexpected error: %s", i, err)
+		}
+		if len(actual) != len(tc.expected) {
+			t.Fatalf("test case %d: expected %v, got %v", i, tc.expected, actual)
+		}
+		for j := range actual {
+			if actual[j] != tc.expected[j] {
+				t.Errorf("test case %d: expected %v, got %v", i, tc.expected, actual)
+			}
+		}
+	}
+}
+
+func TestIsOpenAPI3(t *testing.T) {
+	if isOpenAPI3([]byte(`{"swagger":"2.0"}`)) {
+		t.Error("Expected a Swagger 2.0 document not to be an OpenAPI 3 document")
+	}
+	if !isOpenAPI3([]byte(`{"openapi":"3.0.3"}`)) {
+		t.Error("Expected an OpenAPI 3.0 document")
+	}
+}
@@ -72,7 +72,7 @@ func TestSwaggerToServiceProfile(t *testing.T) {
 		},
 	}
 
-	actualServiceProfile := swaggerToServiceProfile(swagger, namespace, name, clusterDomain)
+	actualServiceProfile, _ := swaggerToServiceProfile(swagger, namespace, name, clusterDomain)
 
 	err := ServiceProfileYamlEquals(actualServiceProfile, expectedServiceProfile)
 	if err != nil {
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"reflect"
+	"regexp"
 	"text/template"
 	"time"
 
@@ -20,6 +22,9 @@ type profileTemplateConfig struct {
 	ServiceNamespace string
 	ServiceName      string
 	ClusterDomain    string
+	Timeout          string
+	FailureRanges    []*sp.Range
+	RetryBudget      *sp.RetryBudget
 }
 
 var (
@@ -32,6 +37,10 @@ var (
 	minStatus uint32 = 100
 	maxStatus uint32 = 599
 
+	// maxRetryRatio is the largest retry ratio the proxy's retry budget
+	// accepts.
+	maxRetryRatio float32 = 1000
+
 	errRequestMatchField  = errors.New("A request match must have a field set")
 	errResponseMatchField = errors.New("A response match must have a field set")
 )
@@ -47,6 +56,10 @@ var (
 // - presence of required fields
 // - presence of unknown fields
 // - recursive fields
+// - path regular expressions
+// - routes that can't be matched because an earlier route has the same
+//   condition
+// - retry budget sanity
 func Validate(data []byte) error {
 	var serviceProfile sp.ServiceProfile
 	err := yaml.UnmarshalStrict(data, &serviceProfile)
@@ -63,7 +76,7 @@ func Validate(data []byte) error {
 		return fmt.Errorf("ServiceProfile \"%s\" has no routes", serviceProfile.Name)
 	}
 
-	for _, route := range serviceProfile.Spec.Routes {
+	for i, route := range serviceProfile.Spec.Routes {
 		if route.Name == "" {
 			return fmt.Errorf("ServiceProfile \"%s\" has a route with no name", serviceProfile.Name)
 		}
@@ -80,6 +93,13 @@ func Validate(data []byte) error {
 		if err != nil {
 			return fmt.Errorf("ServiceProfile \"%s\" has a route with an invalid condition: %s", serviceProfile.Name, err)
 		}
+		// The first route that matches a request wins, so a route with the
+		// same condition as an earlier one never gets any requests
+		for j, earlier := range serviceProfile.Spec.Routes[:i] {
+			if reflect.DeepEqual(route.Condition, earlier.Condition) {
+				return fmt.Errorf("ServiceProfile \"%s\" routes[%d] (%q) has the same condition as routes[%d] (%q)", serviceProfile.Name, i, route.Name, j, earlier.Name)
+			}
+		}
 		for _, rc := range route.ResponseClasses {
 			if rc.Condition == nil {
 				return fmt.Errorf("ServiceProfile \"%s\" has a response class with no condition", serviceProfile.Name)
@@ -96,22 +116,28 @@ func Validate(data []byte) error {
 		if rb.RetryRatio < 0 {
 			return fmt.Errorf("ServiceProfile \"%s\" RetryBudget RetryRatio must be non-negative: %f", serviceProfile.Name, rb.RetryRatio)
 		}
+		if rb.RetryRatio > maxRetryRatio {
+			return fmt.Errorf("ServiceProfile \"%s\" RetryBudget RetryRatio must not exceed %.0f: %f", serviceProfile.Name, maxRetryRatio, rb.RetryRatio)
+		}
 
 		if rb.TTL == "" {
 			return fmt.Errorf("ServiceProfile \"%s\" RetryBudget missing TTL field", serviceProfile.Name)
 		}
 
-		_, err := time.ParseDuration(rb.TTL)
+		ttl, err := time.ParseDuration(rb.TTL)
 		if err != nil {
 			return fmt.Errorf("ServiceProfile \"%s\" RetryBudget: %s", serviceProfile.Name, err)
 		}
+		if ttl <= 0 {
+			return fmt.Errorf("ServiceProfile \"%s\" RetryBudget TTL must be positive: %s", serviceProfile.Name, rb.TTL)
+		}
 	}
 
 	return nil
 }
 
 // ValidateRequestMatch validates whether a ServiceProfile RequestMatch has at
-// least one field set.
+// least one field set, and whether its path regular expressions compile.
 func ValidateRequestMatch(reqMatch *sp.RequestMatch) error {
 	matchKindSet := false
 	if reqMatch.All != nil {
@@ -143,6 +169,9 @@ func ValidateRequestMatch(reqMatch *sp.RequestMatch) error {
 		}
 	}
 	if reqMatch.PathRegex != "" {
+		if _, err := regexp.Compile(reqMatch.PathRegex); err != nil {
+			return fmt.Errorf("invalid pathRegex %q: %s", reqMatch.PathRegex, err)
+		}
 		matchKindSet = true
 	}
 
@@ -200,18 +229,30 @@ func ValidateResponseMatch(rspMatch *sp.ResponseMatch) error {
 	return nil
 }
 
-func buildConfig(namespace, service, clusterDomain string) *profileTemplateConfig {
-	return &profileTemplateConfig{
+func buildConfig(namespace, service, clusterDomain string, options RouteOptions) *profileTemplateConfig {
+	config := &profileTemplateConfig{
 		ServiceNamespace: namespace,
 		ServiceName:      service,
 		ClusterDomain:    clusterDomain,
+		Timeout:          options.Timeout,
+		RetryBudget:      options.RetryBudget,
 	}
+
+	// The template already classifies 5xx responses as failures
+	for _, r := range options.RetryOn {
+		if r.Min != 500 || r.Max != 599 {
+			config.FailureRanges = append(config.FailureRanges, r)
+		}
+	}
+
+	return config
 }
 
 // RenderProfileTemplate renders a ServiceProfile template to a buffer, given a
-// namespace, service, and control plane namespace.
-func RenderProfileTemplate(namespace, service, clusterDomain string, w io.Writer) error {
-	config := buildConfig(namespace, service, clusterDomain)
+// namespace, service, control plane namespace, and the options to apply to its
+// route.
+func RenderProfileTemplate(namespace, service, clusterDomain string, options RouteOptions, w io.Writer) error {
+	config := buildConfig(namespace, service, clusterDomain, options)
 	template, err := template.New("profile").Parse(Template)
 	if err != nil {
 		return err
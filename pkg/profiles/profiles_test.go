@@ -382,6 +382,24 @@ spec:
     retryRatio: -0.2
     ttl: 10s
   routes:
+  - name: name-1
+    condition:
+      method: GET
+      pathRegex: /route-1`,
+		},
+		{
+			err: errors.New("ServiceProfile \"name.ns.svc.cluster.local\" RetryBudget RetryRatio must not exceed 1000: 1000.500000"),
+			sp: `apiVersion: linkerd.io/v1alpha2
+kind: ServiceProfile
+metadata:
+  name: name.ns.svc.cluster.local
+  namespace: linkerd-ns
+spec:
+  retryBudget:
+    minRetriesPerSecond: 5
+    retryRatio: 1000.5
+    ttl: 10s
+  routes:
   - name: name-1
     condition:
       method: GET
@@ -400,6 +418,61 @@ spec:
     retryRatio: 0.2
     ttl: 10s
   routes:
+  - name: name-1
+    condition:
+      method: GET
+      pathRegex: /route-1`,
+		},
+		{
+			err: errors.New("ServiceProfile \"name.ns.svc.cluster.local\" has a route with an invalid condition: invalid pathRegex \"/books/(\\\\d+\": error parsing regexp: missing closing ): `/books/(\\d+`"),
+			sp: `apiVersion: linkerd.io/v1alpha2
+kind: ServiceProfile
+metadata:
+  name: name.ns.svc.cluster.local
+  namespace: linkerd-ns
+spec:
+  routes:
+  - name: name-1
+    condition:
+      any:
+      - method: GET
+      - pathRegex: '/books/(\d+'`,
+		},
+		{
+			err: errors.New("ServiceProfile \"name.ns.svc.cluster.local\" routes[2] (\"name-3\") has the same condition as routes[0] (\"name-1\")"),
+			sp: `apiVersion: linkerd.io/v1alpha2
+kind: ServiceProfile
+metadata:
+  name: name.ns.svc.cluster.local
+  namespace: linkerd-ns
+spec:
+  routes:
+  - name: name-1
+    condition:
+      method: GET
+      pathRegex: /route-1
+  - name: name-2
+    condition:
+      method: POST
+      pathRegex: /route-1
+  - name: name-3
+    condition:
+      pathRegex: /route-1
+      method: GET`,
+		},
+		{
+			err: errors.New("ServiceProfile \"name.ns.svc.cluster.local\" RetryBudget TTL must be positive: 0s"),
+			sp: `apiVersion: linkerd.io/v1alpha2
+kind: ServiceProfile
+metadata:
+  name: name.ns.svc.cluster.local
+  namespace: linkerd-ns
+spec:
+  retryBudget:
+    minRetriesPerSecond: 5
+    retryRatio: 0.2
+    ttl: 0s
+  routes:
   - name: name-1
     condition:
       method: GET
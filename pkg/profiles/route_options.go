@@ -0,0 +1,170 @@
+package profiles
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	sp "github.com/linkerd/linkerd2/controller/gen/apis/serviceprofile/v1alpha2"
+)
+
+// RouteOptions holds the timeout and retry settings that are applied to all
+// the routes of a generated ServiceProfile.
+type RouteOptions struct {
+	// Timeout is set on the routes that don't have a timeout already.
+	Timeout string
+	// RetryOn holds the status ranges that are classified as failures; when
+	// set, the routes of idempotent methods are marked as retryable.
+	RetryOn []*sp.Range
+	// RetryBudget is the retry budget of the ServiceProfile.
+	RetryBudget *sp.RetryBudget
+}
+
+// ParseRouteOptions parses the values of the --timeout, --retry-on and
+// --retry-budget flags of `linkerd profile`, which are all optional:
+//   - timeout is a duration, like "250ms"
+//   - retryOn is a comma-separated list of status codes ("503"), status code
+//     classes ("5xx") or status code ranges ("502-504")
+//   - retryBudget is a comma-separated list of retryRatio, minRetriesPerSecond
+//     and ttl settings, like "retryRatio=0.2,ttl=10s"; the settings that are not
+//     given default to the values of the profile template
+func ParseRouteOptions(timeout, retryOn, retryBudget string) (RouteOptions, error) {
+	options := RouteOptions{}
+
+	if timeout != "" {
+		if _, err := time.ParseDuration(timeout); err != nil {
+			return options, fmt.Errorf("invalid --timeout %q: %s", timeout, err)
+		}
+		options.Timeout = timeout
+	}
+
+	if retryOn != "" {
+		for _, status := range strings.Split(retryOn, ",") {
+			r, err := parseRetryOnStatus(strings.TrimSpace(status))
+			if err != nil {
+				return options, fmt.Errorf("invalid --retry-on %q: %s", retryOn, err)
+			}
+			options.RetryOn = append(options.RetryOn, r)
+		}
+	}
+
+	if retryBudget != "" {
+		budget, err := parseRetryBudget(retryBudget)
+		if err != nil {
+			return options, fmt.Errorf("invalid --retry-budget %q: %s", retryBudget, err)
+		}
+		options.RetryBudget = budget
+	}
+
+	return options, nil
+}
+
+// IsEmpty returns true if the options don't change the routes.
+func (o RouteOptions) IsEmpty() bool {
+	return o.Timeout == "" && len(o.RetryOn) == 0 && o.RetryBudget == nil
+}
+
+// routeSet is a set of the routes of a ServiceProfile
+type routeSet map[*sp.RouteSpec]struct{}
+
+// apply sets the options on the routes of profile. The routes get a failure
+// response class for each of the RetryOn ranges they don't classify yet, and
+// the routes of idempotent methods are marked as retryable, unless they are
+// pinned: the retryability of the pinned routes, e.g. set by their
+// x-linkerd-retryable OpenAPI extension, is never changed.
+func (o RouteOptions) apply(profile *sp.ServiceProfile, pinned routeSet) {
+	for _, route := range profile.Spec.Routes {
+		if o.Timeout != "" && route.Timeout == "" {
+			route.Timeout = o.Timeout
+		}
+
+		if len(o.RetryOn) == 0 {
+			continue
+		}
+		if _, ok := pinned[route]; !ok && route.Condition != nil && isIdempotent(route.Condition.Method) {
+			route.IsRetryable = true
+		}
+		for _, r := range o.RetryOn {
+			if !hasStatusClass(route.ResponseClasses, r) {
+				route.ResponseClasses = append(route.ResponseClasses, &sp.ResponseClass{
+					Condition: &sp.ResponseMatch{Status: &sp.Range{Min: r.Min, Max: r.Max}},
+					IsFailure: true,
+				})
+			}
+		}
+	}
+
+	if o.RetryBudget != nil {
+		profile.Spec.RetryBudget = o.RetryBudget
+	}
+}
+
+func hasStatusClass(classes []*sp.ResponseClass, r *sp.Range) bool {
+	for _, class := range classes {
+		if class.Condition == nil {
+			continue
+		}
+		status := class.Condition.Status
+		if status != nil && status.Min == r.Min && status.Max == r.Max {
+			return true
+		}
+	}
+	return false
+}
+
+func parseRetryOnStatus(status string) (*sp.Range, error) {
+	if r := parseStatusRange(status); r != nil {
+		return r, nil
+	}
+
+	if bounds := strings.SplitN(status, "-", 2); len(bounds) == 2 {
+		lo, loErr := strconv.ParseUint(bounds[0], 10, 32)
+		hi, hiErr := strconv.ParseUint(bounds[1], 10, 32)
+		if loErr == nil && hiErr == nil && uint32(lo) >= minStatus && uint32(hi) <= maxStatus && lo <= hi {
+			return &sp.Range{Min: uint32(lo), Max: uint32(hi)}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%q is not a status code, a status code class or a status code range", status)
+}
+
+func parseRetryBudget(retryBudget string) (*sp.RetryBudget, error) {
+	budget := &sp.RetryBudget{
+		RetryRatio:          0.2,
+		MinRetriesPerSecond: 10,
+		TTL:                 "10s",
+	}
+
+	for _, setting := range strings.Split(retryBudget, ",") {
+		kv := strings.SplitN(strings.TrimSpace(setting), "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("%q must be of the form key=value", setting)
+		}
+
+		switch key, value := kv[0], kv[1]; key {
+		case "retryRatio":
+			ratio, err := strconv.ParseFloat(value, 32)
+			if err != nil || ratio < 0 {
+				return nil, errors.New("retryRatio must be a non-negative number")
+			}
+			budget.RetryRatio = float32(ratio)
+		case "minRetriesPerSecond":
+			retries, err := strconv.ParseUint(value, 10, 32)
+			if err != nil {
+				return nil, errors.New("minRetriesPerSecond must be a non-negative integer")
+			}
+			budget.MinRetriesPerSecond = uint32(retries)
+		case "ttl":
+			if _, err := time.ParseDuration(value); err != nil {
+				return nil, fmt.Errorf("ttl must be a duration: %s", err)
+			}
+			budget.TTL = value
+		default:
+			return nil, fmt.Errorf("unknown setting %q; must be one of retryRatio, minRetriesPerSecond or ttl", key)
+		}
+	}
+
+	return budget, nil
+}
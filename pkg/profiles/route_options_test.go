@@ -0,0 +1,159 @@
+package profiles
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	sp "github.com/linkerd/linkerd2/controller/gen/apis/serviceprofile/v1alpha2"
+	"sigs.k8s.io/yaml"
+)
+
+func TestParseRouteOptions(t *testing.T) {
+	testCases := []struct {
+		timeout     string
+		retryOn     string
+		retryBudget string
+		expected    RouteOptions
+		err         string
+	}{
+		{
+			expected: RouteOptions{},
+		},
+		{
+			timeout:     "250ms",
+			retryOn:     "5xx, 429,502-504",
+			retryBudget: "retryRatio=0.1,ttl=30s",
+			expected: RouteOptions{
+				Timeout: "250ms",
+				RetryOn: []*sp.Range{{Min: 500, Max: 599}, {Min: 429, Max: 429}, {Min: 502, Max: 504}},
+				RetryBudget: &sp.RetryBudget{
+					RetryRatio:          0.1,
+					MinRetriesPerSecond: 10,
+					TTL:                 "30s",
+				},
+			},
+		},
+		{
+			timeout: "250",
+			err:     "invalid --timeout \"250\": time: missing unit in duration \"250\"",
+		},
+		{
+			retryOn: "504-502",
+			err:     "invalid --retry-on \"504-502\": \"504-502\" is not a status code, a status code class or a status code range",
+		},
+		{
+			retryOn: "6xx",
+			err:     "invalid --retry-on \"6xx\": \"6xx\" is not a status code, a status code class or a status code range",
+		},
+		{
+			retryBudget: "ttl",
+			err:         "invalid --retry-budget \"ttl\": \"ttl\" must be of the form key=value",
+		},
+		{
+			retryBudget: "retries=3",
+			err:         "invalid --retry-budget \"retries=3\": unknown setting \"retries\"; must be one of retryRatio, minRetriesPerSecond or ttl",
+		},
+		{
+			retryBudget: "minRetriesPerSecond=-1",
+			err:         "invalid --retry-budget \"minRetriesPerSecond=-1\": minRetriesPerSecond must be a non-negative integer",
+		},
+	}
+
+	for i, tc := range testCases {
+		actual, err := ParseRouteOptions(tc.timeout, tc.retryOn, tc.retryBudget)
+		if tc.err != "" {
+			if err == nil || err.Error() != tc.err {
+				t.Errorf("test case %d: expected error %q, got %v", i, tc.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("test case %d: unexpected error: %s", i, err)
+		}
+		if !reflect.DeepEqual(actual, tc.expected) {
+			t.Errorf("test case %d: expected %+v, got %+v", i, tc.expected, actual)
+		}
+	}
+}
+
+func TestApplyRouteOptions(t *testing.T) {
+	options, err := ParseRouteOptions("250ms", "5xx,429", "ttl=1m")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	// The POST route isn't idempotent, and the GET /authors route isn't
+	// retryable as set by its x-linkerd-retryable extension
+	profile := GenServiceProfile("mysvc", "myns", "mycluster.local")
+	pinned := &sp.RouteSpec{
+		Name:      "GET /authors",
+		Condition: &sp.RequestMatch{PathRegex: "/authors", Method: "GET"},
+	}
+	profile.Spec.Routes = append(profile.Spec.Routes, &sp.RouteSpec{
+		Name:      "GET /books",
+		Condition: &sp.RequestMatch{PathRegex: "/books", Method: "GET"},
+		Timeout:   "1s",
+	}, pinned)
+	options.apply(&profile, routeSet{pinned: {}})
+
+	failureClasses := func() []*sp.ResponseClass {
+		return []*sp.ResponseClass{
+			{Condition: &sp.ResponseMatch{Status: &sp.Range{Min: 500, Max: 599}}, IsFailure: true},
+			{Condition: &sp.ResponseMatch{Status: &sp.Range{Min: 429, Max: 429}}, IsFailure: true},
+		}
+	}
+	expected := GenServiceProfile("mysvc", "myns", "mycluster.local")
+	expected.Spec.Routes[0].Timeout = "250ms"
+	expected.Spec.Routes[0].ResponseClasses = failureClasses()
+	expected.Spec.Routes = append(expected.Spec.Routes, &sp.RouteSpec{
+		Name:            "GET /books",
+		Condition:       &sp.RequestMatch{PathRegex: "/books", Method: "GET"},
+		ResponseClasses: failureClasses(),
+		IsRetryable:     true,
+		Timeout:         "1s",
+	}, &sp.RouteSpec{
+		Name:            "GET /authors",
+		Condition:       &sp.RequestMatch{PathRegex: "/authors", Method: "GET"},
+		ResponseClasses: failureClasses(),
+		Timeout:         "250ms",
+	})
+	expected.Spec.RetryBudget = &sp.RetryBudget{
+		RetryRatio:          0.2,
+		MinRetriesPerSecond: 10,
+		TTL:                 "1m",
+	}
+
+	err = ServiceProfileYamlEquals(profile, expected)
+	if err != nil {
+		t.Fatalf("ServiceProfiles are not equal: %v", err)
+	}
+}
+
+func TestRenderProfileTemplateWithRouteOptions(t *testing.T) {
+	options, err := ParseRouteOptions("250ms", "5xx,429", "retryRatio=0.1,minRetriesPerSecond=5")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	var buf bytes.Buffer
+	err = RenderProfileTemplate("myns", "mysvc", "mycluster.local", options, &buf)
+	if err != nil {
+		t.Fatalf("Error rendering service profile template: %v", err)
+	}
+
+	var actual sp.ServiceProfile
+	err = yaml.Unmarshal(buf.Bytes(), &actual)
+	if err != nil {
+		t.Fatalf("Error parsing service profile: %v", err)
+	}
+
+	// The template renders the same profile as the options applied to it
+	expected := GenServiceProfile("mysvc", "myns", "mycluster.local")
+	options.apply(&expected, nil)
+
+	err = ServiceProfileYamlEquals(actual, expected)
+	if err != nil {
+		t.Fatalf("ServiceProfiles are not equal: %v", err)
+	}
+}
@@ -4,15 +4,19 @@ import (
 	"bufio"
 	"fmt"
 	"io"
+	"math"
 	"os"
+	"regexp"
 	"sort"
 	"strings"
 	"time"
 
 	"github.com/ghodss/yaml"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/linkerd/linkerd2/controller/api/util"
 	sp "github.com/linkerd/linkerd2/controller/gen/apis/serviceprofile/v1alpha2"
 	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"github.com/linkerd/linkerd2/pkg/addr"
 	"github.com/linkerd/linkerd2/pkg/k8s"
 	"github.com/linkerd/linkerd2/pkg/protohttp"
 	"github.com/linkerd/linkerd2/pkg/tap"
@@ -20,10 +24,36 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+const pathIDTemplate = "{id}"
+
+var (
+	numericIDRegex = regexp.MustCompile(`^[0-9]+$`)
+	uuidRegex      = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	hexIDRegex     = regexp.MustCompile(`^[0-9a-fA-F]{16,}$`)
+)
+
+// tapStreamID identifies a request across the tap events of its stream. The
+// stream IDs are only unique within a proxy, so the addresses of the
+// connection are part of the ID.
+type tapStreamID struct {
+	src    string
+	dst    string
+	base   uint32
+	stream uint64
+}
+
+// tapRoute accumulates the requests observed for a route.
+type tapRoute struct {
+	spec      *sp.RouteSpec
+	latencies []time.Duration
+}
+
 // RenderTapOutputProfile performs a tap on the desired resource and generates
 // a service profile with routes pre-populated from the tap data
-// Only inbound tap traffic is considered.
-func RenderTapOutputProfile(k8sAPI *k8s.KubernetesAPI, tapResource, namespace, name, clusterDomain string, tapDuration time.Duration, routeLimit int, w io.Writer) error {
+// Only inbound tap traffic is considered. The path segments that look like
+// IDs are collapsed into route templates, and unless timeoutFactor is 0, each
+// route gets a timeout of timeoutFactor times its observed p99 latency.
+func RenderTapOutputProfile(k8sAPI *k8s.KubernetesAPI, tapResource, namespace, name, clusterDomain string, tapDuration time.Duration, routeLimit int, timeoutFactor float64, w io.Writer) error {
 	requestParams := util.TapRequestParams{
 		Resource:  tapResource,
 		Namespace: namespace,
@@ -35,7 +65,7 @@ func RenderTapOutputProfile(k8sAPI *k8s.KubernetesAPI, tapResource, namespace, n
 		return err
 	}
 
-	profile, err := tapToServiceProfile(k8sAPI, req, namespace, name, clusterDomain, tapDuration, routeLimit)
+	profile, err := tapToServiceProfile(k8sAPI, req, namespace, name, clusterDomain, tapDuration, routeLimit, timeoutFactor)
 	if err != nil {
 		return err
 	}
@@ -48,7 +78,7 @@ func RenderTapOutputProfile(k8sAPI *k8s.KubernetesAPI, tapResource, namespace, n
 	return nil
 }
 
-func tapToServiceProfile(k8sAPI *k8s.KubernetesAPI, tapReq *pb.TapByResourceRequest, namespace, name, clusterDomain string, tapDuration time.Duration, routeLimit int) (sp.ServiceProfile, error) {
+func tapToServiceProfile(k8sAPI *k8s.KubernetesAPI, tapReq *pb.TapByResourceRequest, namespace, name, clusterDomain string, tapDuration time.Duration, routeLimit int, timeoutFactor float64) (sp.ServiceProfile, error) {
 	profile := sp.ServiceProfile{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      fmt.Sprintf("%s.%s.svc.%s", name, namespace, clusterDomain),
@@ -63,16 +93,20 @@ func tapToServiceProfile(k8sAPI *k8s.KubernetesAPI, tapReq *pb.TapByResourceRequ
 	}
 	defer body.Close()
 
-	routes := routeSpecFromTap(reader, routeLimit)
+	routes := routeSpecFromTap(reader, routeLimit, timeoutFactor)
 
 	profile.Spec.Routes = routes
 
 	return profile, nil
 }
 
-func routeSpecFromTap(tapByteStream *bufio.Reader, routeLimit int) []*sp.RouteSpec {
+// routeSpecFromTap reads tap events until the end of the tap. Once routeLimit
+// routes have been observed, the requests of other routes are ignored, but
+// the latencies of the observed routes are still collected.
+func routeSpecFromTap(tapByteStream *bufio.Reader, routeLimit int, timeoutFactor float64) []*sp.RouteSpec {
 	routes := make([]*sp.RouteSpec, 0)
-	routesMap := make(map[string]*sp.RouteSpec)
+	routesMap := make(map[string]*tapRoute)
+	streams := make(map[tapStreamID]*tapRoute)
 
 	for {
 		log.Debug("Waiting for data...")
@@ -88,24 +122,106 @@ func routeSpecFromTap(tapByteStream *bufio.Reader, routeLimit int) []*sp.RouteSp
 			break
 		}
 
-		routeSpec := getPathDataFromTap(&event)
-		log.Debugf("Created route spec: %v", routeSpec)
+		if event.GetProxyDirection() != pb.TapEvent_INBOUND {
+			continue
+		}
+
+		switch ev := event.GetHttp().GetEvent().(type) {
+		case *pb.TapEvent_Http_RequestInit_:
+			routeSpec := getPathDataFromTap(&event)
+			if routeSpec == nil {
+				continue
+			}
+
+			route, ok := routesMap[routeSpec.Name]
+			if !ok {
+				if len(routesMap) >= routeLimit {
+					continue
+				}
+				log.Debugf("Created route spec: %v", routeSpec)
+				route = &tapRoute{spec: routeSpec}
+				routesMap[routeSpec.Name] = route
+			}
+			streams[toTapStreamID(&event, ev.RequestInit.GetId())] = route
+
+		case *pb.TapEvent_Http_ResponseEnd_:
+			id := toTapStreamID(&event, ev.ResponseEnd.GetId())
+			route, ok := streams[id]
+			if !ok {
+				continue
+			}
+			delete(streams, id)
 
-		if routeSpec != nil {
-			routesMap[routeSpec.Name] = routeSpec
-			if len(routesMap) >= routeLimit {
-				break
+			latency, err := ptypes.Duration(ev.ResponseEnd.GetSinceRequestInit())
+			if err == nil {
+				route.latencies = append(route.latencies, latency)
 			}
 		}
 	}
 
 	for _, path := range sortMapKeys(routesMap) {
-		routes = append(routes, routesMap[path])
+		route := routesMap[path]
+		route.spec.Timeout = suggestTimeout(route.latencies, timeoutFactor)
+		routes = append(routes, route.spec)
 	}
 	return routes
 }
 
-func sortMapKeys(m map[string]*sp.RouteSpec) (keys []string) {
+func toTapStreamID(event *pb.TapEvent, id *pb.TapEvent_Http_StreamId) tapStreamID {
+	return tapStreamID{
+		src:    addr.PublicAddressToString(event.GetSource()),
+		dst:    addr.PublicAddressToString(event.GetDestination()),
+		base:   id.GetBase(),
+		stream: id.GetStream(),
+	}
+}
+
+// suggestTimeout returns factor times the p99 of latencies, rounded up to the
+// millisecond, or an empty timeout when there are no latencies or factor is 0.
+func suggestTimeout(latencies []time.Duration, factor float64) string {
+	if len(latencies) == 0 || factor <= 0 {
+		return ""
+	}
+
+	sorted := make([]time.Duration, len(latencies))
+	copy(sorted, latencies)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	p99 := sorted[int(math.Ceil(0.99*float64(len(sorted))))-1]
+	timeout := math.Ceil(float64(p99) * factor / float64(time.Millisecond))
+	if timeout < 1 {
+		timeout = 1
+	}
+	return (time.Duration(timeout) * time.Millisecond).String()
+}
+
+// templatePath drops the query of path, and replaces its segments that look
+// like IDs (numbers, UUIDs and long hexadecimal strings) by a parameter, so
+// that the requests to e.g. /books/1 and /books/2 share the /books/{id} route.
+func templatePath(path string) string {
+	if i := strings.IndexAny(path, "?#"); i >= 0 {
+		path = path[:i]
+	}
+
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if isIDSegment(segment) {
+			segments[i] = pathIDTemplate
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+func isIDSegment(segment string) bool {
+	if numericIDRegex.MatchString(segment) || uuidRegex.MatchString(segment) {
+		return true
+	}
+	// Long hexadecimal words, like "deadbeefdeadbeef", are unlikely but
+	// possible: only consider hexadecimal strings with a digit as IDs
+	return hexIDRegex.MatchString(segment) && strings.ContainsAny(segment, "0123456789")
+}
+
+func sortMapKeys(m map[string]*tapRoute) (keys []string) {
 	for key := range m {
 		keys = append(keys, key)
 	}
@@ -120,14 +236,14 @@ func getPathDataFromTap(event *pb.TapEvent) *sp.RouteSpec {
 
 	switch ev := event.GetHttp().GetEvent().(type) {
 	case *pb.TapEvent_Http_RequestInit_:
-		path := ev.RequestInit.GetPath()
+		path := templatePath(ev.RequestInit.GetPath())
 		if path == "/" {
 			return nil
 		}
 
 		return mkRouteSpec(
 			path,
-			pathToRegex(path), // for now, no path consolidation
+			pathToRegex(path),
 			ev.RequestInit.GetMethod().GetRegistered().String(),
 			nil)
 	default:
@@ -1,11 +1,17 @@
 package profiles
 
 import (
+	"bufio"
+	"bytes"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"testing"
 	"time"
 
+	"github.com/ghodss/yaml"
+	"github.com/golang/protobuf/ptypes"
+
 	"github.com/linkerd/linkerd2/controller/api/util"
 	sp "github.com/linkerd/linkerd2/controller/gen/apis/serviceprofile/v1alpha2"
 	pb "github.com/linkerd/linkerd2/controller/gen/public"
@@ -119,7 +125,7 @@ func TestTapToServiceProfile(t *testing.T) {
 		},
 	}
 
-	actualServiceProfile, err := tapToServiceProfile(kubeAPI, tapReq, namespace, name, clusterDomain, tapDuration, routeLimit)
+	actualServiceProfile, err := tapToServiceProfile(kubeAPI, tapReq, namespace, name, clusterDomain, tapDuration, routeLimit, 0)
 	if err != nil {
 		t.Fatalf("Failed to create ServiceProfile: %v", err)
 	}
@@ -129,3 +135,154 @@ func TestTapToServiceProfile(t *testing.T) {
 		t.Fatalf("ServiceProfiles are not equal: %v", err)
 	}
 }
+
+func tapRequestInit(base uint32, path string) *pb.TapEvent {
+	return util.CreateTapEvent(
+		&pb.TapEvent_Http{
+			Event: &pb.TapEvent_Http_RequestInit_{
+				RequestInit: &pb.TapEvent_Http_RequestInit{
+					Id:   &pb.TapEvent_Http_StreamId{Base: base},
+					Path: path,
+					Method: &pb.HttpMethod{
+						Type: &pb.HttpMethod_Registered_{
+							Registered: pb.HttpMethod_GET,
+						},
+					},
+				},
+			},
+		},
+		map[string]string{},
+		pb.TapEvent_INBOUND,
+	)
+}
+
+func tapResponseEnd(base uint32, latency time.Duration) *pb.TapEvent {
+	return util.CreateTapEvent(
+		&pb.TapEvent_Http{
+			Event: &pb.TapEvent_Http_ResponseEnd_{
+				ResponseEnd: &pb.TapEvent_Http_ResponseEnd{
+					Id:               &pb.TapEvent_Http_StreamId{Base: base},
+					SinceRequestInit: ptypes.DurationProto(latency),
+				},
+			},
+		},
+		map[string]string{},
+		pb.TapEvent_INBOUND,
+	)
+}
+
+func tapByteStream(t *testing.T, events []*pb.TapEvent) *bufio.Reader {
+	var buf bytes.Buffer
+	for _, event := range events {
+		rec := httptest.NewRecorder()
+		if err := protohttp.WriteProtoToHTTPResponse(rec, event); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		buf.Write(rec.Body.Bytes())
+	}
+	return bufio.NewReader(&buf)
+}
+
+func TestRouteSpecFromTapTemplatesPaths(t *testing.T) {
+	events := []*pb.TapEvent{
+		tapRequestInit(1, "/books/1"),
+		tapRequestInit(2, "/books/42?fields=title"),
+		tapRequestInit(3, "/books/5f0c1c2a-4ac5-4b6a-9e0b-6c1f3b2a9d10/reviews"),
+		tapRequestInit(4, "/books/facade"),
+		// exceeds the route limit
+		tapRequestInit(5, "/authors/1"),
+		tapResponseEnd(1, 10*time.Millisecond),
+		tapResponseEnd(2, 20*time.Millisecond),
+		tapResponseEnd(3, 5*time.Millisecond),
+		tapResponseEnd(5, time.Second),
+	}
+
+	routes := routeSpecFromTap(tapByteStream(t, events), 3, 2)
+
+	expected := []*sp.RouteSpec{
+		{
+			Name:      "GET /books/facade",
+			Condition: &sp.RequestMatch{PathRegex: "/books/facade", Method: "GET"},
+		},
+		{
+			Name:      "GET /books/{id}",
+			Condition: &sp.RequestMatch{PathRegex: "/books/[^/]*", Method: "GET"},
+			Timeout:   "40ms",
+		},
+		{
+			Name:      "GET /books/{id}/reviews",
+			Condition: &sp.RequestMatch{PathRegex: "/books/[^/]*/reviews", Method: "GET"},
+			Timeout:   "10ms",
+		},
+	}
+
+	if !reflect.DeepEqual(routes, expected) {
+		actual, _ := yaml.Marshal(routes)
+		exp, _ := yaml.Marshal(expected)
+		t.Fatalf("Unexpected routes:\n%s\nExpected:\n%s", actual, exp)
+	}
+}
+
+func TestRouteSpecFromTapSeparatesProxies(t *testing.T) {
+	// The proxies of different pods use the same stream IDs
+	fromProxy := func(event *pb.TapEvent, ip uint32) *pb.TapEvent {
+		event.Destination = &pb.TcpAddress{
+			Ip:   &pb.IPAddress{Ip: &pb.IPAddress_Ipv4{Ipv4: ip}},
+			Port: 8080,
+		}
+		return event
+	}
+
+	events := []*pb.TapEvent{
+		fromProxy(tapRequestInit(1, "/books"), 2),
+		fromProxy(tapRequestInit(1, "/authors"), 3),
+		fromProxy(tapResponseEnd(1, 10*time.Millisecond), 2),
+		fromProxy(tapResponseEnd(1, 100*time.Millisecond), 3),
+	}
+
+	routes := routeSpecFromTap(tapByteStream(t, events), 20, 2)
+
+	expected := []*sp.RouteSpec{
+		{
+			Name:      "GET /authors",
+			Condition: &sp.RequestMatch{PathRegex: "/authors", Method: "GET"},
+			Timeout:   "200ms",
+		},
+		{
+			Name:      "GET /books",
+			Condition: &sp.RequestMatch{PathRegex: "/books", Method: "GET"},
+			Timeout:   "20ms",
+		},
+	}
+
+	if !reflect.DeepEqual(routes, expected) {
+		actual, _ := yaml.Marshal(routes)
+		exp, _ := yaml.Marshal(expected)
+		t.Fatalf("Unexpected routes:\n%s\nExpected:\n%s", actual, exp)
+	}
+}
+
+func TestSuggestTimeout(t *testing.T) {
+	latencies := make([]time.Duration, 0, 100)
+	for i := 1; i <= 100; i++ {
+		latencies = append(latencies, time.Duration(i)*time.Millisecond)
+	}
+
+	testCases := []struct {
+		latencies []time.Duration
+		factor    float64
+		expected  string
+	}{
+		{latencies, 2, "198ms"},
+		{latencies, 0, ""},
+		{nil, 2, ""},
+		{[]time.Duration{100 * time.Microsecond}, 1.5, "1ms"},
+		{[]time.Duration{800 * time.Millisecond}, 2, "1.6s"},
+	}
+
+	for i, tc := range testCases {
+		if actual := suggestTimeout(tc.latencies, tc.factor); actual != tc.expected {
+			t.Errorf("test case %d: expected %q, got %q", i, tc.expected, actual)
+		}
+	}
+}
@@ -72,28 +72,35 @@ spec:
       # The response class defines whether responses should be counted as
       # successes or failures.
       isFailure: true
+{{- range .FailureRanges}}
+    - condition:
+        status:
+          min: {{.Min}}
+          max: {{.Max}}
+      isFailure: true
+{{- end}}
 
     # A route can define a request timeout.  Any requests to this route that
     # exceed the timeout will be canceled.  If unspecified, the default timeout
     # is '10s' (ten seconds).
-    # timeout: 250ms
+    {{if .Timeout}}timeout: {{.Timeout}}{{else}}# timeout: 250ms{{end}}
 
   # A service profile can also define a retry budget.  This specifies the
   # maximum total number of retries that should be sent to this service as a
   # ratio of the original request volume.
-  # retryBudget:
+  {{if .RetryBudget}}retryBudget:{{else}}# retryBudget:{{end}}
   #   The retryRatio is the maximum ratio of retries requests to original
   #   requests.  A retryRatio of 0.2 means that retries may add at most an
   #   additional 20% to the request load.
-  #   retryRatio: 0.2
+  {{if .RetryBudget}}  retryRatio: {{.RetryBudget.RetryRatio}}{{else}}#   retryRatio: 0.2{{end}}
 
   #   This is an allowance of retries per second in addition to those allowed
   #   by the retryRatio.  This allows retries to be performed, when the request
   #   rate is very low.
-  #   minRetriesPerSecond: 10
+  {{if .RetryBudget}}  minRetriesPerSecond: {{.RetryBudget.MinRetriesPerSecond}}{{else}}#   minRetriesPerSecond: 10{{end}}
 
   #   This duration indicates for how long requests should be considered for the
   #   purposes of calculating the retryRatio.  A higher value considers a larger
   #   window and therefore allows burstier retries.
-  #   ttl: 10s
+  {{if .RetryBudget}}  ttl: {{.RetryBudget.TTL}}{{else}}#   ttl: 10s{{end}}
 `
@@ -0,0 +1,301 @@
+package tap
+
+import (
+	"fmt"
+	"math/rand"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"github.com/linkerd/linkerd2/pkg/addr"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// maxPendingStreams bounds the number of streams whose events are held
+	// back for each tapped proxy, while waiting for their response
+	maxPendingStreams = 1000
+
+	// pendingStreamTimeout is how long a stream is tracked without its
+	// response ending, e.g. because the proxy ended the tap in between
+	pendingStreamTimeout = time.Minute
+
+	// eventsPerStream is the number of events reported for an HTTP stream:
+	// its request init, response init and response end
+	eventsPerStream = 3
+)
+
+// Filter selects the streams reported to a tap client: it's the compiled
+// form of the filter of a TapByResourceRequest, along with the rate limit of
+// its sample rate and the rate limit of the events sent to the client.
+type Filter struct {
+	// matches are only evaluated when replaying a recording, as the proxies
+	// evaluate them otherwise
+	matches     []*pb.TapByResourceRequest_Match_Http
+	statusClass uint32
+	pathRegex   *regexp.Regexp
+	minLatency  time.Duration
+	sampleRate  float32
+	limiter     *rate.Limiter
+}
+
+// Streams holds the state of a Filter for the streams it's evaluated on
+type Streams struct {
+	filter  *Filter
+	pending map[streamKey]*pendingStream
+}
+
+// streamKey identifies a stream; stream IDs are only unique per proxy
+type streamKey struct {
+	proxy     string
+	direction pb.TapEvent_ProxyDirection
+	base      uint32
+	stream    uint64
+}
+
+type pendingStream struct {
+	events    []*pb.TapEvent
+	responded bool
+	started   time.Time
+}
+
+// NewFilter compiles the filter of a TapByResourceRequest, and limits the
+// events reported to maxEventsPerSecond, unless it's 0. It returns nil when
+// there's nothing to filter.
+func NewFilter(req *pb.TapByResourceRequest, maxEventsPerSecond float64) (*Filter, error) {
+	filter := req.GetFilter()
+	f := &Filter{statusClass: filter.GetStatusClass()}
+	if f.statusClass > 5 {
+		return nil, fmt.Errorf("invalid status class: %d", f.statusClass)
+	}
+	if filter.GetPathRegex() != "" {
+		re, err := regexp.Compile(filter.GetPathRegex())
+		if err != nil {
+			return nil, fmt.Errorf("invalid path regex \"%s\": %s", filter.GetPathRegex(), err)
+		}
+		f.pathRegex = re
+	}
+	if filter.GetMinLatency() != nil {
+		minLatency, err := ptypes.Duration(filter.GetMinLatency())
+		if err != nil {
+			return nil, fmt.Errorf("invalid minimum latency: %s", err)
+		}
+		f.minLatency = minLatency
+	}
+	if req.GetSampleRate() < 0 || req.GetSampleRate() > 1 {
+		return nil, fmt.Errorf("invalid sample rate %v, must be between 0 and 1", req.GetSampleRate())
+	}
+	if rate := req.GetSampleRate(); rate > 0 && rate < 1 {
+		f.sampleRate = rate
+	}
+	if maxEventsPerSecond > 0 {
+		// the burst allows for a second worth of streams
+		burst := int(maxEventsPerSecond)
+		if burst < eventsPerStream {
+			burst = eventsPerStream
+		}
+		f.limiter = rate.NewLimiter(rate.Limit(maxEventsPerSecond), burst)
+	}
+
+	if f.statusClass == 0 && f.pathRegex == nil && f.minLatency == 0 && f.sampleRate == 0 && f.limiter == nil {
+		return nil, nil
+	}
+	return f, nil
+}
+
+// NewReplayFilter returns a Filter evaluating all of a TapByResourceRequest on
+// recorded events, including the HTTP matches otherwise evaluated by the
+// proxies. It returns nil when there's nothing to filter.
+func NewReplayFilter(req *pb.TapByResourceRequest) (*Filter, error) {
+	f, err := NewFilter(req, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []*pb.TapByResourceRequest_Match_Http
+	for _, match := range req.GetMatch().GetAll().GetMatches() {
+		if http := match.GetHttp(); http != nil {
+			matches = append(matches, http)
+		}
+	}
+	if len(matches) == 0 {
+		return f, nil
+	}
+	if f == nil {
+		f = &Filter{}
+	}
+	f.matches = matches
+	return f, nil
+}
+
+// matchesRequest returns whether a request matches all the HTTP matches of the
+// filter
+func (f *Filter) matchesRequest(req *pb.TapEvent_Http_RequestInit) bool {
+	for _, match := range f.matches {
+		var ok bool
+		switch m := match.GetMatch().(type) {
+		case *pb.TapByResourceRequest_Match_Http_Scheme:
+			ok = strings.EqualFold(SchemeString(req.GetScheme()), m.Scheme)
+		case *pb.TapByResourceRequest_Match_Http_Method:
+			ok = strings.EqualFold(MethodString(req.GetMethod()), m.Method)
+		case *pb.TapByResourceRequest_Match_Http_Authority:
+			ok = req.GetAuthority() == m.Authority
+		case *pb.TapByResourceRequest_Match_Http_Path:
+			ok = strings.HasPrefix(req.GetPath(), m.Path)
+		case *pb.TapByResourceRequest_Match_Http_GrpcMethod:
+			ok = req.GetPath() == "/"+m.GrpcMethod
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// SchemeString returns the name of a registered or unregistered scheme
+func SchemeString(s *pb.Scheme) string {
+	if r, ok := s.GetType().(*pb.Scheme_Registered_); ok {
+		return r.Registered.String()
+	}
+	return s.GetUnregistered()
+}
+
+// MethodString returns the name of a registered or unregistered HTTP method
+func MethodString(m *pb.HttpMethod) string {
+	if r, ok := m.GetType().(*pb.HttpMethod_Registered_); ok {
+		return r.Registered.String()
+	}
+	return m.GetUnregistered()
+}
+
+// accept decides whether to report a new stream, by sampling it and then
+// reserving room for its events within the rate limit. The decision holds for
+// all the events of the stream.
+func (f *Filter) accept() bool {
+	if f.sampleRate > 0 && rand.Float32() >= f.sampleRate {
+		return false
+	}
+	if f.limiter != nil && !f.limiter.AllowN(time.Now(), eventsPerStream) {
+		log.Debugf("dropping stream: the rate limit is exceeded")
+		return false
+	}
+	return true
+}
+
+// NewStreams returns the state of the filter for a new set of streams, e.g.
+// the ones of a newly tapped proxy
+func (f *Filter) NewStreams() *Streams {
+	if f == nil {
+		return nil
+	}
+	return &Streams{
+		filter:  f,
+		pending: map[streamKey]*pendingStream{},
+	}
+}
+
+// Filter returns the events to report after receiving event: none while the
+// stream isn't known to match, and all its events held back so far once it
+// is. A nil Streams reports all the events.
+func (s *Streams) Filter(event *pb.TapEvent) []*pb.TapEvent {
+	if s == nil {
+		return []*pb.TapEvent{event}
+	}
+	f := s.filter
+
+	switch ev := event.GetHttp().GetEvent().(type) {
+	case *pb.TapEvent_Http_RequestInit_:
+		if !f.matchesRequest(ev.RequestInit) {
+			return nil
+		}
+		if f.pathRegex != nil && !f.pathRegex.MatchString(ev.RequestInit.GetPath()) {
+			return nil
+		}
+		if len(s.pending) >= maxPendingStreams {
+			s.prune()
+		}
+		if len(s.pending) >= maxPendingStreams {
+			log.Debugf("dropping stream: too many pending streams")
+			return nil
+		}
+		if !f.accept() {
+			return nil
+		}
+		key := newStreamKey(event, ev.RequestInit.GetId())
+		if f.statusClass == 0 && f.minLatency == 0 {
+			// the stream is only tracked so that its response is reported
+			s.pending[key] = &pendingStream{started: time.Now()}
+			return []*pb.TapEvent{event}
+		}
+		s.pending[key] = &pendingStream{events: []*pb.TapEvent{event}, started: time.Now()}
+		return nil
+
+	case *pb.TapEvent_Http_ResponseInit_:
+		key := newStreamKey(event, ev.ResponseInit.GetId())
+		stream, ok := s.pending[key]
+		if !ok {
+			return nil
+		}
+		if f.statusClass != 0 && ev.ResponseInit.GetHttpStatus()/100 != f.statusClass {
+			delete(s.pending, key)
+			return nil
+		}
+		stream.responded = true
+		if f.minLatency == 0 {
+			events := append(stream.events, event)
+			stream.events = nil
+			return events
+		}
+		stream.events = append(stream.events, event)
+		return nil
+
+	case *pb.TapEvent_Http_ResponseEnd_:
+		key := newStreamKey(event, ev.ResponseEnd.GetId())
+		stream, ok := s.pending[key]
+		if !ok {
+			return nil
+		}
+		delete(s.pending, key)
+		if f.statusClass != 0 && !stream.responded {
+			// the stream was reset before its response started
+			return nil
+		}
+		if f.minLatency > 0 {
+			latency, err := ptypes.Duration(ev.ResponseEnd.GetSinceRequestInit())
+			if err != nil || latency < f.minLatency {
+				return nil
+			}
+		}
+		return append(stream.events, event)
+	}
+
+	return nil
+}
+
+// newStreamKey returns the key of the stream of an event, reported by the
+// destination proxy of inbound events and by the source proxy of outbound ones
+func newStreamKey(event *pb.TapEvent, id *pb.TapEvent_Http_StreamId) streamKey {
+	proxy := event.GetSource()
+	if event.GetProxyDirection() == pb.TapEvent_INBOUND {
+		proxy = event.GetDestination()
+	}
+	return streamKey{
+		proxy:     addr.PublicIPToString(proxy.GetIp()),
+		direction: event.GetProxyDirection(),
+		base:      id.GetBase(),
+		stream:    id.GetStream(),
+	}
+}
+
+// prune stops tracking the streams pending for longer than
+// pendingStreamTimeout
+func (s *Streams) prune() {
+	for key, stream := range s.pending {
+		if time.Since(stream.started) > pendingStreamTimeout {
+			delete(s.pending, key)
+		}
+	}
+}
@@ -0,0 +1,256 @@
+package tap
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/duration"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+)
+
+func requestInit(stream uint64, path string) *pb.TapEvent {
+	return &pb.TapEvent{
+		Event: &pb.TapEvent_Http_{
+			Http: &pb.TapEvent_Http{
+				Event: &pb.TapEvent_Http_RequestInit_{
+					RequestInit: &pb.TapEvent_Http_RequestInit{
+						Id:   &pb.TapEvent_Http_StreamId{Base: 1, Stream: stream},
+						Path: path,
+					},
+				},
+			},
+		},
+	}
+}
+
+func responseInit(stream uint64, status uint32) *pb.TapEvent {
+	return &pb.TapEvent{
+		Event: &pb.TapEvent_Http_{
+			Http: &pb.TapEvent_Http{
+				Event: &pb.TapEvent_Http_ResponseInit_{
+					ResponseInit: &pb.TapEvent_Http_ResponseInit{
+						Id:         &pb.TapEvent_Http_StreamId{Base: 1, Stream: stream},
+						HttpStatus: status,
+					},
+				},
+			},
+		},
+	}
+}
+
+func responseEnd(stream uint64, latency time.Duration) *pb.TapEvent {
+	return &pb.TapEvent{
+		Event: &pb.TapEvent_Http_{
+			Http: &pb.TapEvent_Http{
+				Event: &pb.TapEvent_Http_ResponseEnd_{
+					ResponseEnd: &pb.TapEvent_Http_ResponseEnd{
+						Id:               &pb.TapEvent_Http_StreamId{Base: 1, Stream: stream},
+						SinceRequestInit: ptypes.DurationProto(latency),
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestNewFilter(t *testing.T) {
+	for _, req := range []*pb.TapByResourceRequest{
+		{},
+		{Filter: &pb.TapByResourceRequest_Filter{}},
+		{SampleRate: 1},
+	} {
+		f, err := NewFilter(req, 0)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if f != nil {
+			t.Fatalf("Expected no filter for %+v, got %+v", req, f)
+		}
+	}
+
+	for _, req := range []*pb.TapByResourceRequest{
+		{Filter: &pb.TapByResourceRequest_Filter{StatusClass: 6}},
+		{Filter: &pb.TapByResourceRequest_Filter{PathRegex: "("}},
+		{Filter: &pb.TapByResourceRequest_Filter{MinLatency: &duration.Duration{Seconds: 1, Nanos: -1}}},
+		{SampleRate: -0.5},
+		{SampleRate: 2},
+	} {
+		if _, err := NewFilter(req, 0); err == nil {
+			t.Fatalf("Expected an error for %+v", req)
+		}
+	}
+}
+
+func TestSampledStreams(t *testing.T) {
+	t.Run("Samples the streams", func(t *testing.T) {
+		f, err := NewFilter(&pb.TapByResourceRequest{SampleRate: 0.1}, 0)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		streams := f.NewStreams()
+
+		reported := 0
+		for i := uint64(0); i < 10000; i++ {
+			events := streams.Filter(requestInit(i, "/"))
+			events = append(events, streams.Filter(responseInit(i, 200))...)
+			events = append(events, streams.Filter(responseEnd(i, time.Millisecond))...)
+			if len(events) != 0 && len(events) != 3 {
+				t.Fatalf("Expected all the events of stream %d to be sampled together, got %d of them", i, len(events))
+			}
+			reported += len(events) / 3
+		}
+		if reported < 500 || reported > 1500 {
+			t.Fatalf("Expected about 1000 streams to be sampled, got %d", reported)
+		}
+	})
+
+	t.Run("Limits the events per second", func(t *testing.T) {
+		f, err := NewFilter(&pb.TapByResourceRequest{}, 30)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		streams := f.NewStreams()
+
+		var reported []*pb.TapEvent
+		for i := uint64(0); i < 100; i++ {
+			reported = append(reported, streams.Filter(requestInit(i, "/"))...)
+			reported = append(reported, streams.Filter(responseEnd(i, time.Millisecond))...)
+		}
+		// the burst allows for a second worth of events, 3 per stream
+		if len(reported) != 20 {
+			t.Fatalf("Expected 10 streams to be reported, got %d events", len(reported))
+		}
+	})
+}
+
+func TestFilteredStreams(t *testing.T) {
+	testCases := []struct {
+		name     string
+		filter   *pb.TapByResourceRequest_Filter
+		events   []*pb.TapEvent
+		expected []*pb.TapEvent
+	}{
+		{
+			name:   "path regex",
+			filter: &pb.TapByResourceRequest_Filter{PathRegex: "^/api/"},
+			events: []*pb.TapEvent{
+				requestInit(1, "/api/vote"),
+				requestInit(2, "/index.html"),
+				responseInit(2, 200),
+				responseInit(1, 200),
+				responseEnd(2, time.Millisecond),
+				responseEnd(1, time.Millisecond),
+			},
+			expected: []*pb.TapEvent{
+				requestInit(1, "/api/vote"),
+				responseInit(1, 200),
+				responseEnd(1, time.Millisecond),
+			},
+		},
+		{
+			name:   "status class",
+			filter: &pb.TapByResourceRequest_Filter{StatusClass: 5},
+			events: []*pb.TapEvent{
+				requestInit(1, "/ok"),
+				requestInit(2, "/fail"),
+				requestInit(3, "/reset"),
+				responseInit(1, 200),
+				responseInit(2, 503),
+				responseEnd(1, time.Millisecond),
+				responseEnd(2, time.Millisecond),
+				responseEnd(3, time.Millisecond),
+			},
+			expected: []*pb.TapEvent{
+				requestInit(2, "/fail"),
+				responseInit(2, 503),
+				responseEnd(2, time.Millisecond),
+			},
+		},
+		{
+			name:   "minimum latency",
+			filter: &pb.TapByResourceRequest_Filter{MinLatency: ptypes.DurationProto(100 * time.Millisecond)},
+			events: []*pb.TapEvent{
+				requestInit(1, "/fast"),
+				requestInit(2, "/slow"),
+				responseInit(1, 200),
+				responseInit(2, 200),
+				responseEnd(1, 10*time.Millisecond),
+				responseEnd(2, 150*time.Millisecond),
+			},
+			expected: []*pb.TapEvent{
+				requestInit(2, "/slow"),
+				responseInit(2, 200),
+				responseEnd(2, 150*time.Millisecond),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.name, func(t *testing.T) {
+			f, err := NewFilter(&pb.TapByResourceRequest{Filter: tc.filter}, 0)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			streams := f.NewStreams()
+
+			var actual []*pb.TapEvent
+			for _, event := range tc.events {
+				actual = append(actual, streams.Filter(event)...)
+			}
+
+			if len(actual) != len(tc.expected) {
+				t.Fatalf("Expected %d events, got %d: %v", len(tc.expected), len(actual), actual)
+			}
+			for i := range actual {
+				if actual[i].String() != tc.expected[i].String() {
+					t.Fatalf("Expected event %d to be %v, got %v", i, tc.expected[i], actual[i])
+				}
+			}
+			if len(streams.pending) != 0 {
+				t.Fatalf("Expected no pending streams, got %d", len(streams.pending))
+			}
+		})
+	}
+}
+
+func TestNewReplayFilter(t *testing.T) {
+	f, err := NewReplayFilter(&pb.TapByResourceRequest{
+		Match: &pb.TapByResourceRequest_Match{
+			Match: &pb.TapByResourceRequest_Match_All{
+				All: &pb.TapByResourceRequest_Match_Seq{
+					Matches: []*pb.TapByResourceRequest_Match{
+						{Match: &pb.TapByResourceRequest_Match_Http_{
+							Http: &pb.TapByResourceRequest_Match_Http{
+								Match: &pb.TapByResourceRequest_Match_Http_Path{Path: "/api/"},
+							},
+						}},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	streams := f.NewStreams()
+
+	// the same stream ID reported by two proxies
+	inbound := requestInit(1, "/api/vote")
+	inbound.ProxyDirection = pb.TapEvent_INBOUND
+	outbound := requestInit(1, "/index.html")
+	outbound.ProxyDirection = pb.TapEvent_OUTBOUND
+
+	if len(streams.Filter(outbound)) != 0 {
+		t.Fatal("Expected the request not matching the path prefix to be filtered out")
+	}
+	if len(streams.Filter(inbound)) != 1 {
+		t.Fatal("Expected the request matching the path prefix to be reported")
+	}
+	end := responseEnd(1, time.Millisecond)
+	end.ProxyDirection = pb.TapEvent_INBOUND
+	if len(streams.Filter(end)) != 1 {
+		t.Fatal("Expected the response of the matching request to be reported")
+	}
+}
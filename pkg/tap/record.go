@@ -0,0 +1,52 @@
+package tap
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/golang/protobuf/proto"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"github.com/linkerd/linkerd2/pkg/protohttp"
+)
+
+// recordingHeader starts the files recorded by `linkerd tap --record`. It's
+// followed by the TapByResourceRequest of the recording and then by its
+// events, all serialized as in the responses of the tap APIServer.
+const recordingHeader = "l5dtap/1\n"
+
+// Record writes the header of a recording of req to w, and returns a Reader
+// copying into w the tap events read from tapByteStream
+func Record(w io.Writer, req *pb.TapByResourceRequest, tapByteStream io.Reader) (*bufio.Reader, error) {
+	reqBytes, err := proto.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.WriteString(w, recordingHeader); err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(protohttp.SerializeAsPayload(reqBytes)); err != nil {
+		return nil, err
+	}
+
+	return bufio.NewReader(io.TeeReader(tapByteStream, w)), nil
+}
+
+// ReadRecording reads the header of a recording made by Record, and returns
+// the recorded TapByResourceRequest along with a Reader of the recorded events
+func ReadRecording(r io.Reader) (*pb.TapByResourceRequest, *bufio.Reader, error) {
+	reader := bufio.NewReader(r)
+
+	header := make([]byte, len(recordingHeader))
+	if _, err := io.ReadFull(reader, header); err != nil || string(header) != recordingHeader {
+		return nil, nil, errors.New("not a tap recording")
+	}
+
+	req := &pb.TapByResourceRequest{}
+	if err := protohttp.FromByteStreamToProtocolBuffers(reader, req); err != nil {
+		return nil, nil, fmt.Errorf("invalid tap recording: %s", err)
+	}
+
+	return req, reader, nil
+}
@@ -0,0 +1,62 @@
+package tap
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"github.com/linkerd/linkerd2/pkg/protohttp"
+)
+
+func TestRecording(t *testing.T) {
+	req := &pb.TapByResourceRequest{
+		Target: &pb.ResourceSelection{
+			Resource: &pb.Resource{Namespace: "emojivoto", Type: "deployment", Name: "web"},
+		},
+		MaxRps: 10,
+	}
+
+	var stream []byte
+	for _, event := range []*pb.TapEvent{requestInit(1, "/api/vote"), responseInit(1, 200)} {
+		b, err := proto.Marshal(event)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		stream = append(stream, protohttp.SerializeAsPayload(b)...)
+	}
+
+	var recording bytes.Buffer
+	reader, err := Record(&recording, req, bytes.NewReader(stream))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	// the events are only recorded as they're read
+	read, err := ioutil.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if !bytes.Equal(read, stream) {
+		t.Fatal("Expected the recorder to read the tap events unchanged")
+	}
+
+	recorded, events, err := ReadRecording(&recording)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if !proto.Equal(recorded, req) {
+		t.Fatalf("Expected the recorded request to be %v, got %v", req, recorded)
+	}
+	replayed, err := ioutil.ReadAll(events)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if !bytes.Equal(replayed, stream) {
+		t.Fatal("Expected the recorded events to be replayed unchanged")
+	}
+
+	if _, _, err := ReadRecording(bytes.NewBufferString("not a recording")); err == nil {
+		t.Fatal("Expected an error reading an invalid recording")
+	}
+}
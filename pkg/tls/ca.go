@@ -1,6 +1,7 @@
 package tls
 
 import (
+	"crypto"
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/rand"
@@ -8,6 +9,7 @@ import (
 	"crypto/x509/pkix"
 	"fmt"
 	"math/big"
+	mathrand "math/rand"
 	"time"
 )
 
@@ -60,12 +62,24 @@ type (
 		// ValidFrom is the point in time from which the certificate is valid.
 		// This is cert.NotBefore with some clock skew allowance.
 		ValidFrom *time.Time
+
+		// RenewalJitter is the maximum random duration the lifetime of each
+		// certificate is shortened by, so that certificates issued at the same
+		// time aren't all renewed at the same time.
+		RenewalJitter time.Duration
 	}
 
 	// Issuer implementors signs certificate requests.
 	Issuer interface {
 		IssueEndEntityCrt(*x509.CertificateRequest) (Crt, error)
 	}
+
+	// ValidityIssuer implementors can also sign certificate requests with a
+	// validity other than their default one.
+	ValidityIssuer interface {
+		Issuer
+		IssueEndEntityCrtWithValidity(*x509.CertificateRequest, Validity) (Crt, error)
+	}
 )
 
 const (
@@ -112,11 +126,11 @@ func init() {
 // CreateRootCA configures a new root CA with the given settings
 func CreateRootCA(
 	name string,
-	key *ecdsa.PrivateKey,
+	key crypto.Signer,
 	validity Validity,
 ) (*CA, error) {
 	// Configure the root certificate.
-	t := createTemplate(1, &key.PublicKey, validity)
+	t := createTemplate(1, key.Public(), validity)
 	t.Subject = pkix.Name{CommonName: name}
 	t.DNSNames = []string{name}
 	t.IsCA = true
@@ -158,6 +172,17 @@ func GenerateRootCAWithDefaults(name string) (*CA, error) {
 	return CreateRootCA(name, key, Validity{})
 }
 
+// GenerateRootCA generates a new root CA with a key of the given algorithm,
+// and otherwise default settings.
+func GenerateRootCA(name, keyAlgorithm string) (*CA, error) {
+	key, err := GenerateKeyWithAlgorithm(keyAlgorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	return CreateRootCA(name, key, Validity{})
+}
+
 // GenerateCA generates a new intermediate CA.
 func (ca *CA) GenerateCA(name string, maxPathLen int) (*CA, error) {
 	key, err := GenerateKey()
@@ -165,7 +190,7 @@ func (ca *CA) GenerateCA(name string, maxPathLen int) (*CA, error) {
 		return nil, err
 	}
 
-	t := ca.createTemplate(&key.PublicKey)
+	t := ca.createTemplate(&key.PublicKey, ca.Validity)
 	t.Subject = pkix.Name{CommonName: name}
 	t.DNSNames = []string{name}
 	t.IsCA = true
@@ -206,12 +231,17 @@ func (ca *CA) GenerateEndEntityCred(dnsName string) (*Cred, error) {
 // IssueEndEntityCrt creates a new certificate that is valid for the
 // given DNS name, generating a new keypair for it.
 func (ca *CA) IssueEndEntityCrt(csr *x509.CertificateRequest) (Crt, error) {
-	pubkey, ok := csr.PublicKey.(*ecdsa.PublicKey)
-	if !ok {
-		return Crt{}, fmt.Errorf("CSR must contain an ECDSA public key: %+v", csr.PublicKey)
+	return ca.IssueEndEntityCrtWithValidity(csr, ca.Validity)
+}
+
+// IssueEndEntityCrtWithValidity is IssueEndEntityCrt with the given validity
+// instead of the CA's one.
+func (ca *CA) IssueEndEntityCrtWithValidity(csr *x509.CertificateRequest, validity Validity) (Crt, error) {
+	if _, err := KeyAlgorithmOf(csr.PublicKey); err != nil {
+		return Crt{}, fmt.Errorf("CSR must contain a supported public key: %s", err)
 	}
 
-	t := ca.createTemplate(pubkey)
+	t := ca.createTemplate(csr.PublicKey, validity)
 	t.Issuer = ca.Cred.Crt.Certificate.Subject
 	t.Subject = csr.Subject
 	t.Extensions = csr.Extensions
@@ -227,8 +257,8 @@ func (ca *CA) IssueEndEntityCrt(csr *x509.CertificateRequest) (Crt, error) {
 // createTemplate returns a certificate t for a non-CA certificate with
 // no subject name, no subjectAltNames. The t can then be modified into
 // a (root) CA t or an end-entity t by the caller.
-func (ca *CA) createTemplate(pubkey *ecdsa.PublicKey) *x509.Certificate {
-	c := createTemplate(ca.nextSerialNumber, pubkey, ca.Validity)
+func (ca *CA) createTemplate(pubkey crypto.PublicKey, validity Validity) *x509.Certificate {
+	c := createTemplate(ca.nextSerialNumber, pubkey, validity)
 	ca.nextSerialNumber++
 	// if our trust chain contains a certificate that expires
 	// sooner than the one we intend to issue, we clamp the
@@ -246,22 +276,14 @@ func (ca *CA) createTemplate(pubkey *ecdsa.PublicKey) *x509.Certificate {
 // a (root) CA t or an end-entity t by the caller.
 func createTemplate(
 	serialNumber uint64,
-	k *ecdsa.PublicKey,
+	k crypto.PublicKey,
 	v Validity,
 ) *x509.Certificate {
-	// ECDSA is used instead of RSA because ECDSA key generation is
-	// straightforward and fast whereas RSA key generation is extremely slow
-	// and error-prone.
-	//
-	// CA certificates are signed with the same algorithm as end-entity
-	// certificates because they are relatively short-lived, because using one
-	// algorithm minimizes exposure to implementation flaws, and to speed up
-	// signature verification time.
-	//
-	// SHA-256 is used because any larger digest would be truncated to 256 bits
-	// anyway since a P-256 scalar is only 256 bits long.
-	const SignatureAlgorithm = x509.ECDSAWithSHA256
-
+	// The signature algorithm is left for x509.CreateCertificate to pick from
+	// the key of the issuer: SHA-256 with P-256 and RSA keys, and SHA-384 with
+	// P-384 keys. P-256 ECDSA keys are generated by default because their
+	// generation is straightforward and fast whereas RSA key generation is
+	// extremely slow.
 	if v.ValidFrom == nil {
 		now := time.Now()
 		v.ValidFrom = &now
@@ -269,12 +291,11 @@ func createTemplate(
 	notBefore, notAfter := v.Window(*v.ValidFrom)
 
 	return &x509.Certificate{
-		SerialNumber:       big.NewInt(int64(serialNumber)),
-		SignatureAlgorithm: SignatureAlgorithm,
-		NotBefore:          notBefore,
-		NotAfter:           notAfter,
-		PublicKey:          k,
-		KeyUsage:           x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
+		SerialNumber: big.NewInt(int64(serialNumber)),
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+		PublicKey:    k,
+		KeyUsage:     x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
 		ExtKeyUsage: []x509.ExtKeyUsage{
 			x509.ExtKeyUsageServerAuth,
 			x509.ExtKeyUsageClientAuth,
@@ -283,11 +304,16 @@ func createTemplate(
 }
 
 // Window returns the time window for which a certificate should be valid.
+// The lifetime is shortened by a random duration below RenewalJitter, if any.
 func (v *Validity) Window(t time.Time) (time.Time, time.Time) {
 	life := v.Lifetime
 	if life == 0 {
 		life = DefaultLifetime
 	}
+	if v.RenewalJitter > 0 && v.RenewalJitter < life {
+		// #nosec G404 -- the jitter doesn't need to be unpredictable
+		life -= time.Duration(mathrand.Int63n(int64(v.RenewalJitter)))
+	}
 	skew := v.ClockSkewAllowance
 	if skew == 0 {
 		skew = DefaultClockSkewAllowance
@@ -1,6 +1,7 @@
 package tls
 
 import (
+	"crypto/x509"
 	"testing"
 	"time"
 )
@@ -65,3 +66,29 @@ func TestCaIssuesCertsWithCorrectExpiration(t *testing.T) {
 	}
 
 }
+
+func TestCaIssuesCertsWithValidityAndJitter(t *testing.T) {
+	validFrom := time.Now().UTC().Round(time.Second)
+	ca, err := getCa(validFrom, time.Hour*48, time.Hour*24)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	key, err := GenerateKey()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	csr := &x509.CertificateRequest{PublicKey: &key.PublicKey}
+
+	validity := Validity{ValidFrom: &validFrom, Lifetime: time.Hour, RenewalJitter: time.Minute * 10}
+	latest := validFrom.Add(time.Hour).Add(DefaultClockSkewAllowance)
+	earliest := latest.Add(-time.Minute * 10)
+	for i := 0; i < 10; i++ {
+		crt, err := ca.IssueEndEntityCrtWithValidity(csr, validity)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if notAfter := crt.Certificate.NotAfter; notAfter.After(latest) || notAfter.Before(earliest) {
+			t.Fatalf("Expected cert expiration between %v and %v but got %v", earliest, latest, notAfter)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package tls
 
 import (
 	"bytes"
+	"crypto"
 	"crypto/ecdsa"
 	"crypto/rand"
 	"crypto/rsa"
@@ -24,6 +25,12 @@ type (
 		*rsa.PrivateKey
 	}
 
+	// privateKeySigner wraps a signer whose private key is held outside of
+	// the process, e.g. by a KMS or an HSM
+	privateKeySigner struct {
+		crypto.Signer
+	}
+
 	// GenericPrivateKey represents either an EC or an RSA private key
 	GenericPrivateKey interface {
 		matchesCertificate(*x509.Certificate) bool
@@ -64,9 +71,24 @@ func (k privateKeyRSA) marshal() ([]byte, error) {
 	return x509.MarshalPKCS1PrivateKey(k.PrivateKey), nil
 }
 
+func (k privateKeySigner) matchesCertificate(c *x509.Certificate) bool {
+	switch pub := k.Public().(type) {
+	case *ecdsa.PublicKey:
+		return privateKeyEC{&ecdsa.PrivateKey{PublicKey: *pub}}.matchesCertificate(c)
+	case *rsa.PublicKey:
+		return privateKeyRSA{&rsa.PrivateKey{PublicKey: *pub}}.matchesCertificate(c)
+	default:
+		return false
+	}
+}
+
+func (k privateKeySigner) marshal() ([]byte, error) {
+	return nil, errors.New("the private key is held by an external signer")
+}
+
 // validCredOrPanic creates a  Cred, panicking if the key does not match the certificate.
-func validCredOrPanic(ecKey *ecdsa.PrivateKey, crt Crt) Cred {
-	k := privateKeyEC{ecKey}
+func validCredOrPanic(key crypto.Signer, crt Crt) Cred {
+	k := newGenericPrivateKey(key)
 	if !k.matchesCertificate(crt.Certificate) {
 		panic("Cert's public key does not match private key")
 	}
@@ -139,7 +161,11 @@ func (cred *Cred) EncodePrivateKeyPEM() string {
 		panic(fmt.Sprintf("Invalid private key: %s", err))
 	}
 
-	return string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: b}))
+	blockType := "EC PRIVATE KEY"
+	if _, ok := cred.PrivateKey.(privateKeyRSA); ok {
+		blockType = "RSA PRIVATE KEY"
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: b}))
 }
 
 // EncodePrivateKeyP8 encodes the provided key to the PKCS#8 binary form.
@@ -192,6 +218,17 @@ func ValidateAndCreateCreds(crt, key string) (*Cred, error) {
 	return &Cred{PrivateKey: k, Crt: *c}, nil
 }
 
+// NewSignerCred creates credentials for a certificate whose private key is
+// held by an external signer, such as a KMS or an HSM. Such credentials can
+// sign certificates but their private key can't be encoded.
+func NewSignerCred(signer crypto.Signer, crt Crt) (*Cred, error) {
+	k := privateKeySigner{signer}
+	if !k.matchesCertificate(crt.Certificate) {
+		return nil, errors.New("tls: Public key of the signer and certificate do not match")
+	}
+	return &Cred{PrivateKey: k, Crt: crt}, nil
+}
+
 // ReadPEMCreds reads PEM-encoded credentials from the named files.
 func ReadPEMCreds(keyPath, crtPath string) (*Cred, error) {
 	keyb, err := ioutil.ReadFile(keyPath)
@@ -113,3 +113,36 @@ func TestCrtExpiry(t *testing.T) {
 		})
 	}
 }
+
+func TestNewSignerCred(t *testing.T) {
+	key, err := GenerateKey()
+	if err != nil {
+		t.Fatalf("failed to generate key: %s", err)
+	}
+	root, err := CreateRootCA(t.Name(), key, Validity{})
+	if err != nil {
+		t.Fatalf("failed to create CA: %s", err)
+	}
+
+	// The key is only used through its crypto.Signer interface, like a KMS key
+	cred, err := NewSignerCred(key, root.Cred.Crt)
+	if err != nil {
+		t.Fatalf("failed to create signer cred: %s", err)
+	}
+	ca := NewCA(*cred, Validity{})
+	endEntity, err := ca.GenerateEndEntityCred("endentity.test")
+	if err != nil {
+		t.Fatalf("failed to create end entity cred: %s", err)
+	}
+	if err := endEntity.Crt.Verify(root.Cred.Crt.CertPool(), "endentity.test", time.Time{}); err != nil {
+		t.Fatalf("failed to verify end entity cred: %s", err)
+	}
+
+	otherKey, err := GenerateKey()
+	if err != nil {
+		t.Fatalf("failed to generate key: %s", err)
+	}
+	if _, err := NewSignerCred(otherKey, root.Cred.Crt); err == nil {
+		t.Fatal("expected an error for a signer not matching the certificate")
+	}
+}
@@ -0,0 +1,130 @@
+package tls
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"fmt"
+	"strings"
+)
+
+const (
+	// KeyAlgorithmECDSAP256 is the default algorithm of the keys generated for
+	// the issuer and the proxies
+	KeyAlgorithmECDSAP256 = "ecdsa-p256"
+
+	// KeyAlgorithmECDSAP384 generates ECDSA keys on the P-384 curve
+	KeyAlgorithmECDSAP384 = "ecdsa-p384"
+
+	// KeyAlgorithmRSA2048 generates 2048-bit RSA keys
+	KeyAlgorithmRSA2048 = "rsa-2048"
+
+	// KeyAlgorithmRSA3072 generates 3072-bit RSA keys
+	KeyAlgorithmRSA3072 = "rsa-3072"
+
+	// KeyAlgorithmRSA4096 generates 4096-bit RSA keys
+	KeyAlgorithmRSA4096 = "rsa-4096"
+
+	// DefaultKeyAlgorithm is used when no key algorithm is configured
+	DefaultKeyAlgorithm = KeyAlgorithmECDSAP256
+)
+
+// KeyAlgorithms are the supported key algorithms
+var KeyAlgorithms = []string{
+	KeyAlgorithmECDSAP256,
+	KeyAlgorithmECDSAP384,
+	KeyAlgorithmRSA2048,
+	KeyAlgorithmRSA3072,
+	KeyAlgorithmRSA4096,
+}
+
+// ValidateKeyAlgorithm returns an error if algorithm isn't supported. An empty
+// algorithm stands for DefaultKeyAlgorithm.
+func ValidateKeyAlgorithm(algorithm string) error {
+	if algorithm == "" {
+		return nil
+	}
+	for _, a := range KeyAlgorithms {
+		if a == algorithm {
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported key algorithm %q; must be one of: %s", algorithm, strings.Join(KeyAlgorithms, ", "))
+}
+
+// GenerateKeyWithAlgorithm creates a new private key with the given algorithm
+// from the default random source. An empty algorithm stands for
+// DefaultKeyAlgorithm.
+func GenerateKeyWithAlgorithm(algorithm string) (crypto.Signer, error) {
+	switch algorithm {
+	case "", KeyAlgorithmECDSAP256:
+		return GenerateKey()
+	case KeyAlgorithmECDSAP384:
+		return ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	case KeyAlgorithmRSA2048:
+		return rsa.GenerateKey(rand.Reader, 2048)
+	case KeyAlgorithmRSA3072:
+		return rsa.GenerateKey(rand.Reader, 3072)
+	case KeyAlgorithmRSA4096:
+		return rsa.GenerateKey(rand.Reader, 4096)
+	default:
+		return nil, ValidateKeyAlgorithm(algorithm)
+	}
+}
+
+// KeyAlgorithmOf returns the algorithm of a public key, or an error if it
+// isn't one of the supported key algorithms
+func KeyAlgorithmOf(pub crypto.PublicKey) (string, error) {
+	switch k := pub.(type) {
+	case *ecdsa.PublicKey:
+		switch k.Curve {
+		case elliptic.P256():
+			return KeyAlgorithmECDSAP256, nil
+		case elliptic.P384():
+			return KeyAlgorithmECDSAP384, nil
+		}
+		return "", fmt.Errorf("must use the P-256 or P-384 curve for ECDSA keys, instead %s was used", k.Curve.Params().Name)
+	case *rsa.PublicKey:
+		switch k.N.BitLen() {
+		case 2048:
+			return KeyAlgorithmRSA2048, nil
+		case 3072:
+			return KeyAlgorithmRSA3072, nil
+		case 4096:
+			return KeyAlgorithmRSA4096, nil
+		}
+		return "", fmt.Errorf("must use 2048, 3072 or 4096 bits for RSA keys, instead %d were used", k.N.BitLen())
+	default:
+		return "", fmt.Errorf("must use an ECDSA or RSA key, instead %T was used", pub)
+	}
+}
+
+// CheckKeyAlgorithm returns an error if pub doesn't use the given algorithm.
+// An empty algorithm stands for DefaultKeyAlgorithm.
+func CheckKeyAlgorithm(pub crypto.PublicKey, algorithm string) error {
+	if algorithm == "" {
+		algorithm = DefaultKeyAlgorithm
+	}
+	actual, err := KeyAlgorithmOf(pub)
+	if err != nil {
+		return err
+	}
+	if actual != algorithm {
+		return fmt.Errorf("must use an %s key, instead %s was used", algorithm, actual)
+	}
+	return nil
+}
+
+// newGenericPrivateKey wraps a private key generated by this package
+func newGenericPrivateKey(key crypto.Signer) GenericPrivateKey {
+	switch k := key.(type) {
+	case *ecdsa.PrivateKey:
+		return privateKeyEC{k}
+	case *rsa.PrivateKey:
+		return privateKeyRSA{k}
+	default:
+		return privateKeySigner{key}
+	}
+}
@@ -0,0 +1,91 @@
+package tls
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+	"time"
+)
+
+func TestGenerateRootCAWithKeyAlgorithms(t *testing.T) {
+	testCases := []struct {
+		algorithm          string
+		signatureAlgorithm x509.SignatureAlgorithm
+	}{
+		{KeyAlgorithmECDSAP256, x509.ECDSAWithSHA256},
+		{KeyAlgorithmECDSAP384, x509.ECDSAWithSHA384},
+		{KeyAlgorithmRSA2048, x509.SHA256WithRSA},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.algorithm, func(t *testing.T) {
+			ca, err := GenerateRootCA("fake-name", tc.algorithm)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if err := CheckKeyAlgorithm(ca.Cred.Certificate.PublicKey, tc.algorithm); err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if ca.Cred.Certificate.SignatureAlgorithm != tc.signatureAlgorithm {
+				t.Fatalf("Expected the %s signature algorithm, got %s", tc.signatureAlgorithm, ca.Cred.Certificate.SignatureAlgorithm)
+			}
+
+			// The credentials must round-trip through their PEM encoding
+			if _, err := ValidateAndCreateCreds(ca.Cred.EncodeCertificatePEM(), ca.Cred.EncodePrivateKeyPEM()); err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+
+			// and issue certificates for the keys of any supported algorithm
+			key, err := GenerateKeyWithAlgorithm(KeyAlgorithmRSA2048)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			crt, err := ca.IssueEndEntityCrt(&x509.CertificateRequest{
+				Subject:   pkix.Name{CommonName: "foo"},
+				DNSNames:  []string{"foo"},
+				PublicKey: key.Public(),
+			})
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if err := crt.Verify(ca.Cred.CertPool(), "foo", time.Time{}); err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func TestKeyAlgorithmOf(t *testing.T) {
+	p224, err := ecdsa.GenerateKey(elliptic.P224(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if _, err := KeyAlgorithmOf(p224.Public()); err == nil {
+		t.Fatal("Expected P-224 keys to be rejected")
+	}
+
+	p384, err := GenerateKeyWithAlgorithm(KeyAlgorithmECDSAP384)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	err = CheckKeyAlgorithm(p384.Public(), "")
+	expected := "must use an ecdsa-p256 key, instead ecdsa-p384 was used"
+	if err == nil || err.Error() != expected {
+		t.Fatalf("Expected error %q, got %v", expected, err)
+	}
+}
+
+func TestValidateKeyAlgorithm(t *testing.T) {
+	for _, algorithm := range append([]string{""}, KeyAlgorithms...) {
+		if err := ValidateKeyAlgorithm(algorithm); err != nil {
+			t.Errorf("Unexpected error for %q: %s", algorithm, err)
+		}
+	}
+	if err := ValidateKeyAlgorithm("ed25519"); err == nil {
+		t.Error("Expected ed25519 to be rejected")
+	}
+}
@@ -0,0 +1,73 @@
+package tls
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	gotls "crypto/tls"
+	"crypto/x509"
+	"fmt"
+)
+
+const (
+	// CryptoPolicyDefault doesn't restrict the crypto used by the control
+	// plane beyond Go's defaults
+	CryptoPolicyDefault = "default"
+
+	// CryptoPolicyFIPS restricts the crypto used by the control plane to the
+	// algorithms approved by FIPS 140-2
+	CryptoPolicyFIPS = "fips"
+)
+
+// CryptoPolicies are the supported crypto policies
+var CryptoPolicies = []string{CryptoPolicyDefault, CryptoPolicyFIPS}
+
+// fipsCipherSuites are the FIPS-approved TLS 1.2 cipher suites
+var fipsCipherSuites = []uint16{
+	gotls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+	gotls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+	gotls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+	gotls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+}
+
+// ApplyCryptoPolicy restricts the TLS versions, cipher suites and curves
+// negotiated with cfg to the ones approved by the given policy
+func ApplyCryptoPolicy(cfg *gotls.Config, policy string) error {
+	switch policy {
+	case "", CryptoPolicyDefault:
+	case CryptoPolicyFIPS:
+		// Go doesn't allow restricting the TLS 1.3 cipher suites, which
+		// include ChaCha20-Poly1305, so TLS 1.3 is disabled altogether
+		cfg.MinVersion = gotls.VersionTLS12
+		cfg.MaxVersion = gotls.VersionTLS12
+		cfg.CipherSuites = fipsCipherSuites
+		cfg.CurvePreferences = []gotls.CurveID{gotls.CurveP256, gotls.CurveP384}
+	default:
+		return fmt.Errorf("unknown crypto policy: %s", policy)
+	}
+
+	return nil
+}
+
+// CheckCryptoPolicy returns an error if the key of crt isn't approved by the
+// given policy
+func CheckCryptoPolicy(crt *x509.Certificate, policy string) error {
+	if policy != CryptoPolicyFIPS {
+		return nil
+	}
+
+	switch key := crt.PublicKey.(type) {
+	case *ecdsa.PublicKey:
+		if key.Curve == elliptic.P256() || key.Curve == elliptic.P384() {
+			return nil
+		}
+		return fmt.Errorf("the key of %s uses the %s curve, which isn't FIPS-approved; use P-256 or P-384", crt.Subject.CommonName, key.Curve.Params().Name)
+	case *rsa.PublicKey:
+		if key.N.BitLen() >= 2048 {
+			return nil
+		}
+		return fmt.Errorf("the key of %s is a %d-bit RSA key, which isn't FIPS-approved; use at least 2048 bits", crt.Subject.CommonName, key.N.BitLen())
+	default:
+		return fmt.Errorf("the key of %s is a %T, which isn't FIPS-approved; use an ECDSA or RSA key", crt.Subject.CommonName, key)
+	}
+}
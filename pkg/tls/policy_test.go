@@ -0,0 +1,71 @@
+package tls
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	gotls "crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+)
+
+func TestApplyCryptoPolicy(t *testing.T) {
+	cfg := &gotls.Config{}
+	if err := ApplyCryptoPolicy(cfg, CryptoPolicyDefault); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if cfg.CipherSuites != nil || cfg.MaxVersion != 0 {
+		t.Fatal("Expected the default policy not to restrict the TLS config")
+	}
+
+	if err := ApplyCryptoPolicy(cfg, CryptoPolicyFIPS); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if cfg.MaxVersion != gotls.VersionTLS12 || len(cfg.CipherSuites) != len(fipsCipherSuites) {
+		t.Fatal("Expected the FIPS policy to restrict the TLS config")
+	}
+
+	if err := ApplyCryptoPolicy(cfg, "legacy"); err == nil || err.Error() != "unknown crypto policy: legacy" {
+		t.Fatalf("Expected an unknown policy to be rejected, got %v", err)
+	}
+}
+
+func TestCheckCryptoPolicy(t *testing.T) {
+	p256, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	p224, err := ecdsa.GenerateKey(elliptic.P224(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	rsa1024, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	testCases := []struct {
+		key      interface{}
+		policy   string
+		expected string
+	}{
+		{&p256.PublicKey, CryptoPolicyFIPS, ""},
+		{&p224.PublicKey, CryptoPolicyDefault, ""},
+		{&p224.PublicKey, CryptoPolicyFIPS, "the key of test uses the P-224 curve, which isn't FIPS-approved; use P-256 or P-384"},
+		{&rsa1024.PublicKey, CryptoPolicyFIPS, "the key of test is a 1024-bit RSA key, which isn't FIPS-approved; use at least 2048 bits"},
+	}
+
+	for _, tc := range testCases {
+		crt := &x509.Certificate{Subject: pkix.Name{CommonName: "test"}, PublicKey: tc.key}
+		err := CheckCryptoPolicy(crt, tc.policy)
+		if tc.expected == "" {
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+		} else if err == nil || err.Error() != tc.expected {
+			t.Fatalf("Expected error %q, got %v", tc.expected, err)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package version
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// MaxStableSkew is the number of stable minor releases (e.g. from 2.7 to
+	// 2.8) two Linkerd components may be apart and still work together
+	MaxStableSkew = 1
+
+	// MaxEdgeSkew is the number of months two edge releases may be apart and
+	// still work together
+	MaxEdgeSkew = 3
+)
+
+// releaseIndex returns the position of a stable or edge release, in stable
+// minor releases or in months respectively, such that the skew between two
+// releases of the same channel is the difference between their indexes. ok is
+// false for the versions the skew policy doesn't apply to, e.g. dev builds.
+func releaseIndex(v string) (channel string, index int, ok bool) {
+	cv, err := parseChannelVersion(v)
+	if err != nil {
+		return "", 0, false
+	}
+
+	parts := strings.Split(cv.version, ".")
+	if len(parts) < 2 {
+		return "", 0, false
+	}
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return "", 0, false
+	}
+	minor, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return "", 0, false
+	}
+
+	switch cv.channel {
+	case "stable":
+		// there are less than 100 minor releases in a major one
+		return cv.channel, major*100 + minor, true
+	case "edge":
+		// edge releases are named year.month.n
+		return cv.channel, major*12 + minor, true
+	default:
+		return "", 0, false
+	}
+}
+
+// Skew returns the number of releases, as counted by the skew policy of
+// their channel, from the older version to the newer one. It's negative if
+// older is actually newer. ok is false if the versions can't be compared,
+// e.g. if they belong to different channels.
+func Skew(older, newer string) (skew int, ok bool) {
+	olderChannel, olderIndex, ok := releaseIndex(older)
+	if !ok {
+		return 0, false
+	}
+	newerChannel, newerIndex, ok := releaseIndex(newer)
+	if !ok || olderChannel != newerChannel {
+		return 0, false
+	}
+	return newerIndex - olderIndex, true
+}
+
+// maxSkew returns the supported skew for the channel of v
+func maxSkew(v string) int {
+	if strings.HasPrefix(v, "edge-") {
+		return MaxEdgeSkew
+	}
+	return MaxStableSkew
+}
+
+// CheckSkew returns an error if the older version is further behind the
+// newer one than supported. Versions that can't be compared are accepted.
+func CheckSkew(older, newer string) error {
+	skew, ok := Skew(older, newer)
+	if !ok || skew <= maxSkew(newer) {
+		return nil
+	}
+	return fmt.Errorf("%s is more than %d %s behind %s", older, maxSkew(newer), skewUnit(newer), newer)
+}
+
+func skewUnit(v string) string {
+	if strings.HasPrefix(v, "edge-") {
+		return "months"
+	}
+	return "minor releases"
+}
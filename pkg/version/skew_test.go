@@ -0,0 +1,33 @@
+package version
+
+import (
+	"testing"
+)
+
+func TestCheckSkew(t *testing.T) {
+	testCases := []struct {
+		older    string
+		newer    string
+		expected string
+	}{
+		{"stable-2.7.1", "stable-2.8.0", ""},
+		{"stable-2.8.1", "stable-2.7.0", ""},
+		{"stable-2.6.0", "stable-2.8.1", "stable-2.6.0 is more than 1 minor releases behind stable-2.8.1"},
+		{"edge-20.5.1", "edge-20.8.2", ""},
+		{"edge-19.12.1", "edge-20.4.1", "edge-19.12.1 is more than 3 months behind edge-20.4.1"},
+		{"edge-20.1.1", "stable-2.8.1", ""},
+		{"dev-undefined", "stable-2.8.1", ""},
+		{"git-abcdef", "edge-20.4.1", ""},
+	}
+
+	for _, tc := range testCases {
+		err := CheckSkew(tc.older, tc.newer)
+		if tc.expected == "" {
+			if err != nil {
+				t.Errorf("Unexpected error checking %s against %s: %s", tc.older, tc.newer, err)
+			}
+		} else if err == nil || err.Error() != tc.expected {
+			t.Errorf("Expected error %q checking %s against %s, got %v", tc.expected, tc.older, tc.newer, err)
+		}
+	}
+}
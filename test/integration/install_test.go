@@ -315,18 +315,19 @@ func TestInstallOrUpgradeCli(t *testing.T) {
 	if TestHelper.UpgradeFromVersion() != "" {
 
 		cmd = "upgrade"
-		// test 2-stage install during upgrade
-		out, stderr, err := TestHelper.LinkerdRun(cmd, "config")
-		if err != nil {
-			testutil.AnnotatedFatalf(t, "'linkerd upgrade config' command failed",
-				"'linkerd upgrade config' command failed\n%s\n%s", out, stderr)
-		}
+		// test 3-stage install during upgrade
+		for _, stage := range []string{"crds", "config"} {
+			out, stderr, err := TestHelper.LinkerdRun(cmd, stage)
+			if err != nil {
+				testutil.AnnotatedFatalf(t, fmt.Sprintf("'linkerd upgrade %s' command failed", stage),
+					"'linkerd upgrade %s' command failed\n%s\n%s", stage, out, stderr)
+			}
 
-		// apply stage 1
-		out, err = TestHelper.KubectlApply(out, "")
-		if err != nil {
-			testutil.AnnotatedFatalf(t, "'kubectl apply' command failed",
-				"kubectl apply command failed\n%s", out)
+			out, err = TestHelper.KubectlApply(out, "")
+			if err != nil {
+				testutil.AnnotatedFatalf(t, "'kubectl apply' command failed",
+					"kubectl apply command failed\n%s", out)
+			}
 		}
 
 		// prepare for stage 2